@@ -0,0 +1,752 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package abi
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/scval"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// scalarTypes maps the spec types with a fixed ScVal encoding.
+var scalarTypes = map[xdr.ScSpecType]xdr.ScValType{
+	xdr.ScSpecTypeScSpecTypeBool:         xdr.ScValTypeScvBool,
+	xdr.ScSpecTypeScSpecTypeVoid:         xdr.ScValTypeScvVoid,
+	xdr.ScSpecTypeScSpecTypeError:        xdr.ScValTypeScvError,
+	xdr.ScSpecTypeScSpecTypeU32:          xdr.ScValTypeScvU32,
+	xdr.ScSpecTypeScSpecTypeI32:          xdr.ScValTypeScvI32,
+	xdr.ScSpecTypeScSpecTypeU64:          xdr.ScValTypeScvU64,
+	xdr.ScSpecTypeScSpecTypeI64:          xdr.ScValTypeScvI64,
+	xdr.ScSpecTypeScSpecTypeTimepoint:    xdr.ScValTypeScvTimepoint,
+	xdr.ScSpecTypeScSpecTypeDuration:     xdr.ScValTypeScvDuration,
+	xdr.ScSpecTypeScSpecTypeU128:         xdr.ScValTypeScvU128,
+	xdr.ScSpecTypeScSpecTypeI128:         xdr.ScValTypeScvI128,
+	xdr.ScSpecTypeScSpecTypeU256:         xdr.ScValTypeScvU256,
+	xdr.ScSpecTypeScSpecTypeI256:         xdr.ScValTypeScvI256,
+	xdr.ScSpecTypeScSpecTypeBytes:        xdr.ScValTypeScvBytes,
+	xdr.ScSpecTypeScSpecTypeString:       xdr.ScValTypeScvString,
+	xdr.ScSpecTypeScSpecTypeSymbol:       xdr.ScValTypeScvSymbol,
+	xdr.ScSpecTypeScSpecTypeAddress:      xdr.ScValTypeScvAddress,
+	xdr.ScSpecTypeScSpecTypeMuxedAddress: xdr.ScValTypeScvAddress,
+}
+
+// EncodeArgs encodes args, keyed by parameter name, as the argument list of
+// function in the order the spec declares them. Parameters of Option type
+// may be omitted; any other missing or unknown name is an error.
+func (s *ContractSpec) EncodeArgs(function string, args map[string]interface{}) ([]xdr.ScVal, error) {
+	fn, ok := s.Function(function)
+	if !ok {
+		return nil, errors.WrapValidationError(fmt.Sprintf("contract has no function %q", function))
+	}
+
+	known := make(map[string]bool, len(fn.Inputs))
+	out := make([]xdr.ScVal, 0, len(fn.Inputs))
+	for _, in := range fn.Inputs {
+		known[in.Name] = true
+		v, ok := args[in.Name]
+		if !ok && in.Type.Type != xdr.ScSpecTypeScSpecTypeOption {
+			return nil, errors.WrapValidationError(fmt.Sprintf("%s: missing argument %q (%s)", function, in.Name, FormatTypeDef(in.Type)))
+		}
+		val, err := s.Encode(in.Type, v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: argument %s: %w", function, in.Name, err)
+		}
+		out = append(out, val)
+	}
+
+	var unknown []string
+	for name := range args {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, errors.WrapValidationError(fmt.Sprintf("%s: unknown arguments %s", function, strings.Join(unknown, ", ")))
+	}
+	return out, nil
+}
+
+// Encode converts v to an ScVal of type td. Integers may be given as Go
+// integers, integral floats (as decoded from JSON), *big.Int or decimal
+// strings; bytes as []byte or hex; structs as maps keyed by field name;
+// unions as a case name, a single-key map {case: value} or a list
+// [case, values...]; enums by case name or value. An xdr.ScVal, or a value
+// scval can convert, is accepted as long as it matches td.
+func (s *ContractSpec) Encode(td xdr.ScSpecTypeDef, v interface{}) (xdr.ScVal, error) {
+	switch x := v.(type) {
+	case xdr.ScVal:
+		return x, s.Validate(td, x)
+	case scval.Marshaler:
+		return s.convert(td, x)
+	}
+
+	switch td.Type {
+	case xdr.ScSpecTypeScSpecTypeVal:
+		return scval.ToScVal(v)
+
+	case xdr.ScSpecTypeScSpecTypeBool:
+		b, ok := v.(bool)
+		if !ok {
+			return xdr.ScVal{}, mismatch(td, v)
+		}
+		return xdr.ScVal{Type: xdr.ScValTypeScvBool, B: &b}, nil
+
+	case xdr.ScSpecTypeScSpecTypeVoid:
+		if !isNil(v) {
+			return xdr.ScVal{}, mismatch(td, v)
+		}
+		return scval.Void(), nil
+
+	case xdr.ScSpecTypeScSpecTypeError:
+		code, err := toUint32(v)
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		return contractError(code), nil
+
+	case xdr.ScSpecTypeScSpecTypeU32, xdr.ScSpecTypeScSpecTypeI32,
+		xdr.ScSpecTypeScSpecTypeU64, xdr.ScSpecTypeScSpecTypeI64,
+		xdr.ScSpecTypeScSpecTypeTimepoint, xdr.ScSpecTypeScSpecTypeDuration,
+		xdr.ScSpecTypeScSpecTypeU128, xdr.ScSpecTypeScSpecTypeI128,
+		xdr.ScSpecTypeScSpecTypeU256, xdr.ScSpecTypeScSpecTypeI256:
+		n, err := toBigInt(v)
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		return encodeInt(td.Type, n)
+
+	case xdr.ScSpecTypeScSpecTypeBytes:
+		b, err := toBytes(v)
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		sb := xdr.ScBytes(b)
+		return xdr.ScVal{Type: xdr.ScValTypeScvBytes, Bytes: &sb}, nil
+
+	case xdr.ScSpecTypeScSpecTypeBytesN:
+		b, err := toBytes(v)
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		if len(b) != int(td.BytesN.N) {
+			return xdr.ScVal{}, errors.WrapValidationError(fmt.Sprintf("expected %d bytes, got %d", td.BytesN.N, len(b)))
+		}
+		sb := xdr.ScBytes(b)
+		return xdr.ScVal{Type: xdr.ScValTypeScvBytes, Bytes: &sb}, nil
+
+	case xdr.ScSpecTypeScSpecTypeString:
+		str, ok := toString(v)
+		if !ok {
+			return xdr.ScVal{}, mismatch(td, v)
+		}
+		ss := xdr.ScString(str)
+		return xdr.ScVal{Type: xdr.ScValTypeScvString, Str: &ss}, nil
+
+	case xdr.ScSpecTypeScSpecTypeSymbol:
+		str, ok := toString(v)
+		if !ok {
+			return xdr.ScVal{}, mismatch(td, v)
+		}
+		return symbol(str), nil
+
+	case xdr.ScSpecTypeScSpecTypeAddress, xdr.ScSpecTypeScSpecTypeMuxedAddress:
+		str, ok := toString(v)
+		if !ok {
+			return xdr.ScVal{}, mismatch(td, v)
+		}
+		addr, err := scval.NewAddress(str)
+		if err != nil {
+			return xdr.ScVal{}, err
+		}
+		return xdr.ScVal{Type: xdr.ScValTypeScvAddress, Address: &addr}, nil
+
+	case xdr.ScSpecTypeScSpecTypeOption:
+		if isNil(v) {
+			return scval.Void(), nil
+		}
+		return s.Encode(td.Option.ValueType, v)
+
+	case xdr.ScSpecTypeScSpecTypeResult:
+		return s.Encode(td.Result.OkType, v)
+
+	case xdr.ScSpecTypeScSpecTypeVec:
+		items, ok := listOf(v)
+		if !ok {
+			return xdr.ScVal{}, mismatch(td, v)
+		}
+		vals := make([]xdr.ScVal, len(items))
+		for k, item := range items {
+			val, err := s.Encode(td.Vec.ElementType, item)
+			if err != nil {
+				return xdr.ScVal{}, fmt.Errorf("index %d: %w", k, err)
+			}
+			vals[k] = val
+		}
+		return scval.Vec(vals...), nil
+
+	case xdr.ScSpecTypeScSpecTypeMap:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Map {
+			return xdr.ScVal{}, mismatch(td, v)
+		}
+		entries := make([]xdr.ScMapEntry, 0, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			key, err := s.Encode(td.Map.KeyType, iter.Key().Interface())
+			if err != nil {
+				return xdr.ScVal{}, fmt.Errorf("map key %v: %w", iter.Key(), err)
+			}
+			val, err := s.Encode(td.Map.ValueType, iter.Value().Interface())
+			if err != nil {
+				return xdr.ScVal{}, fmt.Errorf("map value for %v: %w", iter.Key(), err)
+			}
+			entries = append(entries, xdr.ScMapEntry{Key: key, Val: val})
+		}
+		return scval.Map(entries...), nil
+
+	case xdr.ScSpecTypeScSpecTypeTuple:
+		items, ok := listOf(v)
+		if !ok {
+			return xdr.ScVal{}, mismatch(td, v)
+		}
+		return s.encodeTuple(td.Tuple.ValueTypes, items)
+
+	case xdr.ScSpecTypeScSpecTypeUdt:
+		return s.encodeUdt(td, v)
+	}
+	return xdr.ScVal{}, errors.WrapValidationError(fmt.Sprintf("unsupported spec type %s", FormatTypeDef(td)))
+}
+
+func (s *ContractSpec) encodeTuple(types []xdr.ScSpecTypeDef, items []interface{}) (xdr.ScVal, error) {
+	if len(items) != len(types) {
+		return xdr.ScVal{}, errors.WrapValidationError(fmt.Sprintf("expected %d values, got %d", len(types), len(items)))
+	}
+	vals := make([]xdr.ScVal, len(items))
+	for k, item := range items {
+		val, err := s.Encode(types[k], item)
+		if err != nil {
+			return xdr.ScVal{}, fmt.Errorf("index %d: %w", k, err)
+		}
+		vals[k] = val
+	}
+	return scval.Vec(vals...), nil
+}
+
+func (s *ContractSpec) encodeUdt(td xdr.ScSpecTypeDef, v interface{}) (xdr.ScVal, error) {
+	name := td.Udt.Name
+	if st, ok := s.Struct(name); ok {
+		return s.encodeStruct(td, st, v)
+	}
+	if u, ok := s.Union(name); ok {
+		return s.encodeUnion(u, v)
+	}
+	if e, ok := s.Enum(name); ok {
+		for _, c := range e.Cases {
+			if matchesCase(v, c.Name, uint32(c.Value)) {
+				val := xdr.Uint32(c.Value)
+				return xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: &val}, nil
+			}
+		}
+		return xdr.ScVal{}, errors.WrapValidationError(fmt.Sprintf("%v is not a case of enum %s", v, name))
+	}
+	if e, ok := s.ErrorEnum(name); ok {
+		for _, c := range e.Cases {
+			if matchesCase(v, c.Name, uint32(c.Value)) {
+				return contractError(uint32(c.Value)), nil
+			}
+		}
+		return xdr.ScVal{}, errors.WrapValidationError(fmt.Sprintf("%v is not a case of error enum %s", v, name))
+	}
+	return xdr.ScVal{}, errors.WrapValidationError(fmt.Sprintf("spec has no type %q", name))
+}
+
+func (s *ContractSpec) encodeStruct(td xdr.ScSpecTypeDef, st xdr.ScSpecUdtStructV0, v interface{}) (xdr.ScVal, error) {
+	if isTupleStruct(st) {
+		items, ok := listOf(v)
+		if !ok {
+			return xdr.ScVal{}, mismatch(td, v)
+		}
+		types := make([]xdr.ScSpecTypeDef, len(st.Fields))
+		for k, f := range st.Fields {
+			types[k] = f.Type
+		}
+		return s.encodeTuple(types, items)
+	}
+
+	fields, ok := stringMap(v)
+	if !ok {
+		// Go structs and the like go through scval and are then checked.
+		return s.convert(td, v)
+	}
+	entries := make([]xdr.ScMapEntry, 0, len(st.Fields))
+	for _, f := range st.Fields {
+		fv, ok := fields[f.Name]
+		if !ok && f.Type.Type != xdr.ScSpecTypeScSpecTypeOption {
+			return xdr.ScVal{}, errors.WrapValidationError(fmt.Sprintf("%s: missing field %q", st.Name, f.Name))
+		}
+		delete(fields, f.Name)
+		val, err := s.Encode(f.Type, fv)
+		if err != nil {
+			return xdr.ScVal{}, fmt.Errorf("field %s: %w", f.Name, err)
+		}
+		entries = append(entries, xdr.ScMapEntry{Key: symbol(f.Name), Val: val})
+	}
+	if len(fields) > 0 {
+		extra := make([]string, 0, len(fields))
+		for name := range fields {
+			extra = append(extra, name)
+		}
+		sort.Strings(extra)
+		return xdr.ScVal{}, errors.WrapValidationError(fmt.Sprintf("%s: unknown fields %s", st.Name, strings.Join(extra, ", ")))
+	}
+	return scval.Map(entries...), nil
+}
+
+func (s *ContractSpec) encodeUnion(u xdr.ScSpecUdtUnionV0, v interface{}) (xdr.ScVal, error) {
+	var (
+		name    string
+		payload []interface{}
+		single  bool
+	)
+	if str, ok := toString(v); ok {
+		name = str
+	} else if m, ok := stringMap(v); ok && len(m) == 1 {
+		for k, val := range m {
+			name, payload, single = k, []interface{}{val}, true
+		}
+	} else if items, ok := listOf(v); ok && len(items) > 0 {
+		if name, ok = toString(items[0]); !ok {
+			return xdr.ScVal{}, errors.WrapValidationError(fmt.Sprintf("%s: first element must be the case name", u.Name))
+		}
+		payload = items[1:]
+	} else {
+		return xdr.ScVal{}, errors.WrapValidationError(fmt.Sprintf("%v (%T) is not a %s", v, v, u.Name))
+	}
+
+	for _, c := range u.Cases {
+		switch c.Kind {
+		case xdr.ScSpecUdtUnionCaseV0KindScSpecUdtUnionCaseVoidV0:
+			if c.VoidCase.Name != name {
+				continue
+			}
+			if len(payload) > 0 && !(single && isNil(payload[0])) {
+				return xdr.ScVal{}, errors.WrapValidationError(fmt.Sprintf("%s::%s takes no values", u.Name, name))
+			}
+			return scval.Vec(symbol(name)), nil
+		case xdr.ScSpecUdtUnionCaseV0KindScSpecUdtUnionCaseTupleV0:
+			if c.TupleCase.Name != name {
+				continue
+			}
+			// {case: [a, b]} carries several values; {case: a} just one.
+			if single && len(c.TupleCase.Type) != 1 {
+				items, ok := listOf(payload[0])
+				if !ok {
+					return xdr.ScVal{}, errors.WrapValidationError(fmt.Sprintf("%s::%s takes a list of %d values", u.Name, name, len(c.TupleCase.Type)))
+				}
+				payload = items
+			}
+			tuple, err := s.encodeTuple(c.TupleCase.Type, payload)
+			if err != nil {
+				return xdr.ScVal{}, fmt.Errorf("%s::%s: %w", u.Name, name, err)
+			}
+			return scval.Vec(append([]xdr.ScVal{symbol(name)}, **tuple.Vec...)...), nil
+		}
+	}
+	return xdr.ScVal{}, errors.WrapValidationError(fmt.Sprintf("%q is not a case of union %s", name, u.Name))
+}
+
+// convert encodes v with scval's default mapping and checks the result
+// against td.
+func (s *ContractSpec) convert(td xdr.ScSpecTypeDef, v interface{}) (xdr.ScVal, error) {
+	val, err := scval.ToScVal(v)
+	if err != nil {
+		return xdr.ScVal{}, err
+	}
+	return val, s.Validate(td, val)
+}
+
+// Validate reports whether val is a well-formed value of type td.
+func (s *ContractSpec) Validate(td xdr.ScSpecTypeDef, val xdr.ScVal) error {
+	if want, ok := scalarTypes[td.Type]; ok {
+		if val.Type != want {
+			return invalid(td, val)
+		}
+		return nil
+	}
+
+	switch td.Type {
+	case xdr.ScSpecTypeScSpecTypeVal:
+		return nil
+
+	case xdr.ScSpecTypeScSpecTypeBytesN:
+		if val.Type != xdr.ScValTypeScvBytes || val.Bytes == nil || len(*val.Bytes) != int(td.BytesN.N) {
+			return invalid(td, val)
+		}
+		return nil
+
+	case xdr.ScSpecTypeScSpecTypeOption:
+		if val.Type == xdr.ScValTypeScvVoid {
+			return nil
+		}
+		return s.Validate(td.Option.ValueType, val)
+
+	case xdr.ScSpecTypeScSpecTypeResult:
+		if val.Type == xdr.ScValTypeScvError {
+			return nil
+		}
+		return s.Validate(td.Result.OkType, val)
+
+	case xdr.ScSpecTypeScSpecTypeVec:
+		items, ok := val.GetVec()
+		if !ok || items == nil {
+			return invalid(td, val)
+		}
+		for k, item := range *items {
+			if err := s.Validate(td.Vec.ElementType, item); err != nil {
+				return fmt.Errorf("index %d: %w", k, err)
+			}
+		}
+		return nil
+
+	case xdr.ScSpecTypeScSpecTypeMap:
+		entries, ok := val.GetMap()
+		if !ok || entries == nil {
+			return invalid(td, val)
+		}
+		for _, e := range *entries {
+			if err := s.Validate(td.Map.KeyType, e.Key); err != nil {
+				return fmt.Errorf("map key: %w", err)
+			}
+			if err := s.Validate(td.Map.ValueType, e.Val); err != nil {
+				return fmt.Errorf("map value: %w", err)
+			}
+		}
+		return nil
+
+	case xdr.ScSpecTypeScSpecTypeTuple:
+		return s.validateTuple(td, td.Tuple.ValueTypes, val)
+
+	case xdr.ScSpecTypeScSpecTypeUdt:
+		return s.validateUdt(td, val)
+	}
+	return errors.WrapValidationError(fmt.Sprintf("unsupported spec type %s", FormatTypeDef(td)))
+}
+
+func (s *ContractSpec) validateTuple(td xdr.ScSpecTypeDef, types []xdr.ScSpecTypeDef, val xdr.ScVal) error {
+	items, ok := val.GetVec()
+	if !ok || items == nil || len(*items) != len(types) {
+		return invalid(td, val)
+	}
+	for k, item := range *items {
+		if err := s.Validate(types[k], item); err != nil {
+			return fmt.Errorf("index %d: %w", k, err)
+		}
+	}
+	return nil
+}
+
+func (s *ContractSpec) validateUdt(td xdr.ScSpecTypeDef, val xdr.ScVal) error {
+	name := td.Udt.Name
+	if st, ok := s.Struct(name); ok {
+		if isTupleStruct(st) {
+			types := make([]xdr.ScSpecTypeDef, len(st.Fields))
+			for k, f := range st.Fields {
+				types[k] = f.Type
+			}
+			return s.validateTuple(td, types, val)
+		}
+		entries, ok := val.GetMap()
+		if !ok || entries == nil || len(*entries) != len(st.Fields) {
+			return invalid(td, val)
+		}
+		for _, f := range st.Fields {
+			found := false
+			for _, e := range *entries {
+				if e.Key.Type == xdr.ScValTypeScvSymbol && e.Key.Sym != nil && string(*e.Key.Sym) == f.Name {
+					if err := s.Validate(f.Type, e.Val); err != nil {
+						return fmt.Errorf("field %s: %w", f.Name, err)
+					}
+					found = true
+					break
+				}
+			}
+			if !found {
+				return errors.WrapValidationError(fmt.Sprintf("%s: missing field %q", name, f.Name))
+			}
+		}
+		return nil
+	}
+
+	if u, ok := s.Union(name); ok {
+		items, ok := val.GetVec()
+		if !ok || items == nil || len(*items) == 0 || (*items)[0].Type != xdr.ScValTypeScvSymbol || (*items)[0].Sym == nil {
+			return invalid(td, val)
+		}
+		tag := string(*(*items)[0].Sym)
+		rest := scval.Vec((*items)[1:]...)
+		for _, c := range u.Cases {
+			switch {
+			case c.VoidCase != nil && c.VoidCase.Name == tag:
+				if len(*items) != 1 {
+					return invalid(td, val)
+				}
+				return nil
+			case c.TupleCase != nil && c.TupleCase.Name == tag:
+				return s.validateTuple(td, c.TupleCase.Type, rest)
+			}
+		}
+		return errors.WrapValidationError(fmt.Sprintf("%q is not a case of union %s", tag, name))
+	}
+
+	if e, ok := s.Enum(name); ok {
+		if val.Type == xdr.ScValTypeScvU32 && val.U32 != nil {
+			for _, c := range e.Cases {
+				if c.Value == *val.U32 {
+					return nil
+				}
+			}
+		}
+		return invalid(td, val)
+	}
+
+	if e, ok := s.ErrorEnum(name); ok {
+		if val.Type == xdr.ScValTypeScvError && val.Error != nil && val.Error.ContractCode != nil {
+			for _, c := range e.Cases {
+				if c.Value == *val.Error.ContractCode {
+					return nil
+				}
+			}
+		}
+		return invalid(td, val)
+	}
+	return errors.WrapValidationError(fmt.Sprintf("spec has no type %q", name))
+}
+
+// isTupleStruct reports whether st is a tuple struct, whose fields are
+// named "0", "1", ... and which is encoded as a Vec.
+func isTupleStruct(st xdr.ScSpecUdtStructV0) bool {
+	if len(st.Fields) == 0 {
+		return false
+	}
+	for k, f := range st.Fields {
+		if f.Name != strconv.Itoa(k) {
+			return false
+		}
+	}
+	return true
+}
+
+func encodeInt(t xdr.ScSpecType, n *big.Int) (xdr.ScVal, error) {
+	fits := func(lo, hi int64) bool { return n.IsInt64() && n.Int64() >= lo && n.Int64() <= hi }
+	ufits := func(hi uint64) bool { return n.IsUint64() && n.Uint64() <= hi }
+	overflow := func(name string) error {
+		return errors.WrapValidationError(fmt.Sprintf("%s overflows %s", n, name))
+	}
+
+	switch t {
+	case xdr.ScSpecTypeScSpecTypeU32:
+		if !ufits(math.MaxUint32) {
+			return xdr.ScVal{}, overflow("u32")
+		}
+		v := xdr.Uint32(n.Uint64())
+		return xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: &v}, nil
+	case xdr.ScSpecTypeScSpecTypeI32:
+		if !fits(math.MinInt32, math.MaxInt32) {
+			return xdr.ScVal{}, overflow("i32")
+		}
+		v := xdr.Int32(n.Int64())
+		return xdr.ScVal{Type: xdr.ScValTypeScvI32, I32: &v}, nil
+	case xdr.ScSpecTypeScSpecTypeU64:
+		if !ufits(math.MaxUint64) {
+			return xdr.ScVal{}, overflow("u64")
+		}
+		v := xdr.Uint64(n.Uint64())
+		return xdr.ScVal{Type: xdr.ScValTypeScvU64, U64: &v}, nil
+	case xdr.ScSpecTypeScSpecTypeI64:
+		if !fits(math.MinInt64, math.MaxInt64) {
+			return xdr.ScVal{}, overflow("i64")
+		}
+		v := xdr.Int64(n.Int64())
+		return xdr.ScVal{Type: xdr.ScValTypeScvI64, I64: &v}, nil
+	case xdr.ScSpecTypeScSpecTypeTimepoint:
+		if !ufits(math.MaxUint64) {
+			return xdr.ScVal{}, overflow("timepoint")
+		}
+		v := xdr.TimePoint(n.Uint64())
+		return xdr.ScVal{Type: xdr.ScValTypeScvTimepoint, Timepoint: &v}, nil
+	case xdr.ScSpecTypeScSpecTypeDuration:
+		if !ufits(math.MaxUint64) {
+			return xdr.ScVal{}, overflow("duration")
+		}
+		v := xdr.Duration(n.Uint64())
+		return xdr.ScVal{Type: xdr.ScValTypeScvDuration, Duration: &v}, nil
+	case xdr.ScSpecTypeScSpecTypeU128:
+		return scval.U128(n)
+	case xdr.ScSpecTypeScSpecTypeI128:
+		return scval.I128(n)
+	case xdr.ScSpecTypeScSpecTypeU256:
+		return scval.U256(n)
+	default:
+		return scval.I256(n)
+	}
+}
+
+func toBigInt(v interface{}) (*big.Int, error) {
+	switch x := v.(type) {
+	case *big.Int:
+		if x != nil {
+			return x, nil
+		}
+	case big.Int:
+		return &x, nil
+	case json.Number:
+		return parseInt(x.String())
+	case string:
+		return parseInt(x)
+	case float32:
+		return floatInt(float64(x))
+	case float64:
+		return floatInt(x)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Int).SetUint64(rv.Uint()), nil
+	}
+	return nil, errors.WrapValidationError(fmt.Sprintf("%v (%T) is not an integer", v, v))
+}
+
+func parseInt(s string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(strings.TrimSpace(s), 10)
+	if !ok {
+		return nil, errors.WrapValidationError(fmt.Sprintf("%q is not an integer", s))
+	}
+	return n, nil
+}
+
+func floatInt(f float64) (*big.Int, error) {
+	if math.IsInf(f, 0) || math.IsNaN(f) || f != math.Trunc(f) {
+		return nil, errors.WrapValidationError(fmt.Sprintf("%v is not an integer", f))
+	}
+	n, _ := new(big.Float).SetFloat64(f).Int(nil)
+	return n, nil
+}
+
+func toUint32(v interface{}) (uint32, error) {
+	n, err := toBigInt(v)
+	if err != nil {
+		return 0, err
+	}
+	if !n.IsUint64() || n.Uint64() > math.MaxUint32 {
+		return 0, errors.WrapValidationError(fmt.Sprintf("%s overflows u32", n))
+	}
+	return uint32(n.Uint64()), nil
+}
+
+func toBytes(v interface{}) ([]byte, error) {
+	switch x := v.(type) {
+	case []byte:
+		return x, nil
+	case string:
+		b, err := hex.DecodeString(strings.TrimPrefix(x, "0x"))
+		if err != nil {
+			return nil, errors.WrapValidationError(fmt.Sprintf("%q is not hex: %v", x, err))
+		}
+		return b, nil
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+		b := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(b), rv)
+		return b, nil
+	}
+	return nil, errors.WrapValidationError(fmt.Sprintf("%v (%T) is not bytes", v, v))
+}
+
+func toString(v interface{}) (string, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.String {
+		return "", false
+	}
+	return rv.String(), true
+}
+
+// listOf returns the elements of a slice or array.
+func listOf(v interface{}) ([]interface{}, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, false
+	}
+	items := make([]interface{}, rv.Len())
+	for k := range items {
+		items[k] = rv.Index(k).Interface()
+	}
+	return items, true
+}
+
+// stringMap returns a copy of a map with string keys.
+func stringMap(v interface{}) (map[string]interface{}, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	m := make(map[string]interface{}, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		m[iter.Key().String()] = iter.Value().Interface()
+	}
+	return m, true
+}
+
+func matchesCase(v interface{}, name string, value uint32) bool {
+	if str, ok := toString(v); ok {
+		return str == name
+	}
+	n, err := toUint32(v)
+	return err == nil && n == value
+}
+
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		return rv.IsNil()
+	}
+	return false
+}
+
+func symbol(name string) xdr.ScVal {
+	sym := xdr.ScSymbol(name)
+	return xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym}
+}
+
+func contractError(code uint32) xdr.ScVal {
+	c := xdr.Uint32(code)
+	return xdr.ScVal{Type: xdr.ScValTypeScvError, Error: &xdr.ScError{Type: xdr.ScErrorTypeSceContract, ContractCode: &c}}
+}
+
+func mismatch(td xdr.ScSpecTypeDef, v interface{}) error {
+	return errors.WrapValidationError(fmt.Sprintf("%v (%T) is not a %s", v, v, FormatTypeDef(td)))
+}
+
+func invalid(td xdr.ScSpecTypeDef, val xdr.ScVal) error {
+	return errors.WrapValidationError(fmt.Sprintf("%s value is not a %s", val.Type, FormatTypeDef(td)))
+}
@@ -0,0 +1,233 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package abi
+
+import (
+	"math/big"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/scval"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAccountID = "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+
+func simpleType(t xdr.ScSpecType) xdr.ScSpecTypeDef {
+	return xdr.ScSpecTypeDef{Type: t}
+}
+
+func udtType(name string) xdr.ScSpecTypeDef {
+	return xdr.ScSpecTypeDef{Type: xdr.ScSpecTypeScSpecTypeUdt, Udt: &xdr.ScSpecTypeUdt{Name: name}}
+}
+
+func testSpec() *ContractSpec {
+	return &ContractSpec{
+		Functions: []xdr.ScSpecFunctionV0{{
+			Name: "transfer",
+			Inputs: []xdr.ScSpecFunctionInputV0{
+				{Name: "from", Type: simpleType(xdr.ScSpecTypeScSpecTypeAddress)},
+				{Name: "to", Type: simpleType(xdr.ScSpecTypeScSpecTypeAddress)},
+				{Name: "amount", Type: simpleType(xdr.ScSpecTypeScSpecTypeI128)},
+				{Name: "memo", Type: xdr.ScSpecTypeDef{
+					Type:   xdr.ScSpecTypeScSpecTypeOption,
+					Option: &xdr.ScSpecTypeOption{ValueType: simpleType(xdr.ScSpecTypeScSpecTypeString)},
+				}},
+			},
+		}},
+		Structs: []xdr.ScSpecUdtStructV0{
+			{Name: "Config", Fields: []xdr.ScSpecUdtStructFieldV0{
+				{Name: "admin", Type: simpleType(xdr.ScSpecTypeScSpecTypeAddress)},
+				{Name: "fee", Type: simpleType(xdr.ScSpecTypeScSpecTypeU32)},
+			}},
+			{Name: "Pair", Fields: []xdr.ScSpecUdtStructFieldV0{
+				{Name: "0", Type: simpleType(xdr.ScSpecTypeScSpecTypeSymbol)},
+				{Name: "1", Type: simpleType(xdr.ScSpecTypeScSpecTypeU64)},
+			}},
+		},
+		Unions: []xdr.ScSpecUdtUnionV0{{Name: "Action", Cases: []xdr.ScSpecUdtUnionCaseV0{
+			{Kind: xdr.ScSpecUdtUnionCaseV0KindScSpecUdtUnionCaseVoidV0, VoidCase: &xdr.ScSpecUdtUnionCaseVoidV0{Name: "Stop"}},
+			{Kind: xdr.ScSpecUdtUnionCaseV0KindScSpecUdtUnionCaseTupleV0, TupleCase: &xdr.ScSpecUdtUnionCaseTupleV0{
+				Name: "Move", Type: []xdr.ScSpecTypeDef{simpleType(xdr.ScSpecTypeScSpecTypeI32), simpleType(xdr.ScSpecTypeScSpecTypeI32)},
+			}},
+			{Kind: xdr.ScSpecUdtUnionCaseV0KindScSpecUdtUnionCaseTupleV0, TupleCase: &xdr.ScSpecUdtUnionCaseTupleV0{
+				Name: "Say", Type: []xdr.ScSpecTypeDef{simpleType(xdr.ScSpecTypeScSpecTypeString)},
+			}},
+		}}},
+		Enums: []xdr.ScSpecUdtEnumV0{{Name: "Color", Cases: []xdr.ScSpecUdtEnumCaseV0{
+			{Name: "Red", Value: 1}, {Name: "Green", Value: 2},
+		}}},
+		ErrorEnums: []xdr.ScSpecUdtErrorEnumV0{{Name: "Error", Cases: []xdr.ScSpecUdtErrorEnumCaseV0{
+			{Name: "NotAllowed", Value: 3},
+		}}},
+	}
+}
+
+func TestEncodeArgs(t *testing.T) {
+	spec := testSpec()
+	args, err := spec.EncodeArgs("transfer", map[string]interface{}{
+		"to":     testAccountID,
+		"from":   testAccountID,
+		"amount": "170141183460469231731687303715884105727",
+	})
+	require.NoError(t, err)
+	require.Len(t, args, 4)
+	assert.Equal(t, xdr.ScValTypeScvAddress, args[0].Type)
+	assert.Equal(t, xdr.ScValTypeScvAddress, args[1].Type)
+	amount, err := scval.BigInt(args[2])
+	require.NoError(t, err)
+	assert.Equal(t, "170141183460469231731687303715884105727", amount.String())
+	assert.Equal(t, xdr.ScValTypeScvVoid, args[3].Type)
+}
+
+func TestEncodeArgs_Errors(t *testing.T) {
+	spec := testSpec()
+	for name, args := range map[string]map[string]interface{}{
+		"missing":  {"from": testAccountID, "to": testAccountID},
+		"unknown":  {"from": testAccountID, "to": testAccountID, "amount": 1, "extra": 1},
+		"bad type": {"from": testAccountID, "to": testAccountID, "amount": "lots"},
+		"overflow": {"from": testAccountID, "to": testAccountID, "amount": new(big.Int).Lsh(big.NewInt(1), 127)},
+	} {
+		_, err := spec.EncodeArgs("transfer", args)
+		assert.ErrorIs(t, err, errs.ErrValidationFailed, name)
+	}
+
+	_, err := spec.EncodeArgs("mint", nil)
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+}
+
+func TestEncode_Integers(t *testing.T) {
+	spec := testSpec()
+	for _, v := range []interface{}{7, uint8(7), float64(7), "7", big.NewInt(7)} {
+		val, err := spec.Encode(simpleType(xdr.ScSpecTypeScSpecTypeU32), v)
+		require.NoError(t, err, "%T", v)
+		assert.Equal(t, xdr.Uint32(7), *val.U32)
+	}
+
+	for _, v := range []interface{}{-1, 1.5, 1 << 40} {
+		_, err := spec.Encode(simpleType(xdr.ScSpecTypeScSpecTypeU32), v)
+		assert.ErrorIs(t, err, errs.ErrValidationFailed, "%v", v)
+	}
+}
+
+func TestEncode_Struct(t *testing.T) {
+	spec := testSpec()
+	val, err := spec.Encode(udtType("Config"), map[string]interface{}{"fee": 30, "admin": testAccountID})
+	require.NoError(t, err)
+	m, ok := val.GetMap()
+	require.True(t, ok)
+	require.Len(t, *m, 2)
+	assert.Equal(t, xdr.ScSymbol("admin"), *(*m)[0].Key.Sym)
+	assert.Equal(t, xdr.ScSymbol("fee"), *(*m)[1].Key.Sym)
+	assert.NoError(t, spec.Validate(udtType("Config"), val))
+
+	type config struct {
+		Admin scval.Address
+		Fee   uint32
+	}
+	val, err = spec.Encode(udtType("Config"), config{Admin: testAccountID, Fee: 30})
+	require.NoError(t, err)
+	assert.Equal(t, xdr.ScValTypeScvMap, val.Type)
+
+	_, err = spec.Encode(udtType("Config"), map[string]interface{}{"admin": testAccountID})
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+	_, err = spec.Encode(udtType("Config"), 42)
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+}
+
+func TestEncode_TupleStruct(t *testing.T) {
+	spec := testSpec()
+	val, err := spec.Encode(udtType("Pair"), []interface{}{"xlm", 2})
+	require.NoError(t, err)
+	vec, ok := val.GetVec()
+	require.True(t, ok)
+	require.Len(t, *vec, 2)
+	assert.Equal(t, xdr.ScValTypeScvSymbol, (*vec)[0].Type)
+	assert.Equal(t, xdr.ScValTypeScvU64, (*vec)[1].Type)
+}
+
+func TestEncode_Union(t *testing.T) {
+	spec := testSpec()
+	for _, v := range []interface{}{
+		[]interface{}{"Move", 1, -2},
+		map[string]interface{}{"Move": []interface{}{1, -2}},
+	} {
+		val, err := spec.Encode(udtType("Action"), v)
+		require.NoError(t, err)
+		vec, _ := val.GetVec()
+		require.Len(t, *vec, 3)
+		assert.Equal(t, xdr.ScSymbol("Move"), *(*vec)[0].Sym)
+		assert.Equal(t, xdr.Int32(-2), *(*vec)[2].I32)
+		assert.NoError(t, spec.Validate(udtType("Action"), val))
+	}
+
+	val, err := spec.Encode(udtType("Action"), "Stop")
+	require.NoError(t, err)
+	vec, _ := val.GetVec()
+	assert.Len(t, *vec, 1)
+
+	val, err = spec.Encode(udtType("Action"), map[string]interface{}{"Say": "hi"})
+	require.NoError(t, err)
+	vec, _ = val.GetVec()
+	assert.Equal(t, xdr.ScValTypeScvString, (*vec)[1].Type)
+
+	_, err = spec.Encode(udtType("Action"), "Jump")
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+	_, err = spec.Encode(udtType("Action"), []interface{}{"Move", 1})
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+}
+
+func TestEncode_Enums(t *testing.T) {
+	spec := testSpec()
+	for _, v := range []interface{}{"Green", 2} {
+		val, err := spec.Encode(udtType("Color"), v)
+		require.NoError(t, err)
+		assert.Equal(t, xdr.Uint32(2), *val.U32)
+	}
+	_, err := spec.Encode(udtType("Color"), 5)
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+
+	val, err := spec.Encode(udtType("Error"), "NotAllowed")
+	require.NoError(t, err)
+	assert.Equal(t, xdr.Uint32(3), *val.Error.ContractCode)
+}
+
+func TestEncode_Containers(t *testing.T) {
+	spec := testSpec()
+	vecOfU32 := xdr.ScSpecTypeDef{Type: xdr.ScSpecTypeScSpecTypeVec, Vec: &xdr.ScSpecTypeVec{ElementType: simpleType(xdr.ScSpecTypeScSpecTypeU32)}}
+	val, err := spec.Encode(vecOfU32, []int{1, 2, 3})
+	require.NoError(t, err)
+	vec, _ := val.GetVec()
+	assert.Len(t, *vec, 3)
+
+	mapOfColor := xdr.ScSpecTypeDef{Type: xdr.ScSpecTypeScSpecTypeMap, Map: &xdr.ScSpecTypeMap{
+		KeyType: simpleType(xdr.ScSpecTypeScSpecTypeSymbol), ValueType: udtType("Color"),
+	}}
+	val, err = spec.Encode(mapOfColor, map[string]string{"b": "Red", "a": "Green"})
+	require.NoError(t, err)
+	m, _ := val.GetMap()
+	assert.Equal(t, xdr.ScSymbol("a"), *(*m)[0].Key.Sym)
+
+	bytes4 := xdr.ScSpecTypeDef{Type: xdr.ScSpecTypeScSpecTypeBytesN, BytesN: &xdr.ScSpecTypeBytesN{N: 4}}
+	val, err = spec.Encode(bytes4, "0xdeadbeef")
+	require.NoError(t, err)
+	assert.Equal(t, xdr.ScBytes{0xde, 0xad, 0xbe, 0xef}, *val.Bytes)
+	_, err = spec.Encode(bytes4, []byte{1})
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+}
+
+func TestValidate(t *testing.T) {
+	spec := testSpec()
+	u32, err := scval.ToScVal(uint32(1))
+	require.NoError(t, err)
+
+	assert.NoError(t, spec.Validate(simpleType(xdr.ScSpecTypeScSpecTypeU32), u32))
+	assert.NoError(t, spec.Validate(udtType("Color"), u32))
+	assert.NoError(t, spec.Validate(simpleType(xdr.ScSpecTypeScSpecTypeVal), u32))
+	assert.ErrorIs(t, spec.Validate(simpleType(xdr.ScSpecTypeScSpecTypeI32), u32), errs.ErrValidationFailed)
+	assert.ErrorIs(t, spec.Validate(udtType("Config"), u32), errs.ErrValidationFailed)
+	assert.ErrorIs(t, spec.Validate(udtType("Missing"), u32), errs.ErrValidationFailed)
+}
@@ -0,0 +1,197 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package abi
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/dotandev/hintents/internal/address"
+	"github.com/dotandev/hintents/internal/scval"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// Decode converts val, which must be a valid value of type td, into plain Go
+// values that marshal cleanly to JSON: numbers up to 64 bits as integers,
+// wider ones as decimal strings, bytes as hex, addresses as strkeys,
+// structs as maps keyed by field name, unions as the case name or a
+// single-key map {case: value}, and enums by case name. It mirrors the
+// forms Encode accepts.
+func (s *ContractSpec) Decode(td xdr.ScSpecTypeDef, val xdr.ScVal) (interface{}, error) {
+	if err := s.Validate(td, val); err != nil {
+		return nil, err
+	}
+	return s.render(td, val), nil
+}
+
+// render converts a value already checked by Validate.
+func (s *ContractSpec) render(td xdr.ScSpecTypeDef, val xdr.ScVal) interface{} {
+	switch td.Type {
+	case xdr.ScSpecTypeScSpecTypeVal:
+		return renderUntyped(val)
+
+	case xdr.ScSpecTypeScSpecTypeOption:
+		if val.Type == xdr.ScValTypeScvVoid {
+			return nil
+		}
+		return s.render(td.Option.ValueType, val)
+
+	case xdr.ScSpecTypeScSpecTypeResult:
+		if val.Type == xdr.ScValTypeScvError {
+			return map[string]interface{}{"error": renderUntyped(val)}
+		}
+		return s.render(td.Result.OkType, val)
+
+	case xdr.ScSpecTypeScSpecTypeVec:
+		items := *val.MustVec()
+		out := make([]interface{}, len(items))
+		for k, item := range items {
+			out[k] = s.render(td.Vec.ElementType, item)
+		}
+		return out
+
+	case xdr.ScSpecTypeScSpecTypeMap:
+		entries := *val.MustMap()
+		out := make(map[string]interface{}, len(entries))
+		for _, e := range entries {
+			out[fmt.Sprint(s.render(td.Map.KeyType, e.Key))] = s.render(td.Map.ValueType, e.Val)
+		}
+		return out
+
+	case xdr.ScSpecTypeScSpecTypeTuple:
+		return s.renderTuple(td.Tuple.ValueTypes, *val.MustVec())
+
+	case xdr.ScSpecTypeScSpecTypeUdt:
+		return s.renderUdt(td, val)
+	}
+	return renderUntyped(val)
+}
+
+func (s *ContractSpec) renderTuple(types []xdr.ScSpecTypeDef, items []xdr.ScVal) []interface{} {
+	out := make([]interface{}, len(items))
+	for k, item := range items {
+		out[k] = s.render(types[k], item)
+	}
+	return out
+}
+
+func (s *ContractSpec) renderUdt(td xdr.ScSpecTypeDef, val xdr.ScVal) interface{} {
+	name := td.Udt.Name
+	if st, ok := s.Struct(name); ok {
+		if isTupleStruct(st) {
+			types := make([]xdr.ScSpecTypeDef, len(st.Fields))
+			for k, f := range st.Fields {
+				types[k] = f.Type
+			}
+			return s.renderTuple(types, *val.MustVec())
+		}
+		out := make(map[string]interface{}, len(st.Fields))
+		for _, e := range *val.MustMap() {
+			for _, f := range st.Fields {
+				if string(e.Key.MustSym()) == f.Name {
+					out[f.Name] = s.render(f.Type, e.Val)
+				}
+			}
+		}
+		return out
+	}
+
+	if u, ok := s.Union(name); ok {
+		items := *val.MustVec()
+		tag := string(items[0].MustSym())
+		for _, c := range u.Cases {
+			if c.TupleCase == nil || c.TupleCase.Name != tag {
+				continue
+			}
+			values := s.renderTuple(c.TupleCase.Type, items[1:])
+			if len(values) == 1 {
+				return map[string]interface{}{tag: values[0]}
+			}
+			return map[string]interface{}{tag: values}
+		}
+		return tag
+	}
+
+	if e, ok := s.Enum(name); ok {
+		for _, c := range e.Cases {
+			if c.Value == *val.U32 {
+				return c.Name
+			}
+		}
+	}
+	if e, ok := s.ErrorEnum(name); ok {
+		for _, c := range e.Cases {
+			if c.Value == *val.Error.ContractCode {
+				return c.Name
+			}
+		}
+	}
+	return renderUntyped(val)
+}
+
+// renderUntyped converts a value with no spec type by its own ScVal type.
+func renderUntyped(val xdr.ScVal) interface{} {
+	switch val.Type {
+	case xdr.ScValTypeScvVoid:
+		return nil
+	case xdr.ScValTypeScvBool:
+		return val.MustB()
+	case xdr.ScValTypeScvU32:
+		return uint32(val.MustU32())
+	case xdr.ScValTypeScvI32:
+		return int32(val.MustI32())
+	case xdr.ScValTypeScvU64:
+		return uint64(val.MustU64())
+	case xdr.ScValTypeScvI64:
+		return int64(val.MustI64())
+	case xdr.ScValTypeScvTimepoint:
+		return uint64(val.MustTimepoint())
+	case xdr.ScValTypeScvDuration:
+		return uint64(val.MustDuration())
+	case xdr.ScValTypeScvU128, xdr.ScValTypeScvI128, xdr.ScValTypeScvU256, xdr.ScValTypeScvI256:
+		n, err := scval.BigInt(val)
+		if err != nil {
+			return val.Type.String()
+		}
+		return n.String()
+	case xdr.ScValTypeScvBytes:
+		return hex.EncodeToString(val.MustBytes())
+	case xdr.ScValTypeScvString:
+		return string(val.MustStr())
+	case xdr.ScValTypeScvSymbol:
+		return string(val.MustSym())
+	case xdr.ScValTypeScvAddress:
+		a, err := address.FromScAddress(val.MustAddress())
+		if err != nil {
+			return val.Type.String()
+		}
+		return a.String()
+	case xdr.ScValTypeScvError:
+		e := val.MustError()
+		if e.ContractCode != nil {
+			return fmt.Sprintf("contract error #%d", *e.ContractCode)
+		}
+		return e.Type.String()
+	case xdr.ScValTypeScvVec:
+		items, _ := val.GetVec()
+		if items == nil {
+			return []interface{}{}
+		}
+		out := make([]interface{}, len(*items))
+		for k, item := range *items {
+			out[k] = renderUntyped(item)
+		}
+		return out
+	case xdr.ScValTypeScvMap:
+		entries, _ := val.GetMap()
+		out := map[string]interface{}{}
+		if entries != nil {
+			for _, e := range *entries {
+				out[fmt.Sprint(renderUntyped(e.Key))] = renderUntyped(e.Val)
+			}
+		}
+		return out
+	}
+	return val.Type.String()
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package abi
+
+import (
+	"testing"
+
+	"github.com/dotandev/hintents/internal/scval"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeRoundTripsEncode(t *testing.T) {
+	spec := &ContractSpec{
+		Structs: []xdr.ScSpecUdtStructV0{{Name: "Point", Fields: []xdr.ScSpecUdtStructFieldV0{
+			{Name: "x", Type: xdr.ScSpecTypeDef{Type: xdr.ScSpecTypeScSpecTypeI128}},
+			{Name: "label", Type: xdr.ScSpecTypeDef{Type: xdr.ScSpecTypeScSpecTypeBytes}},
+		}}},
+		Unions: []xdr.ScSpecUdtUnionV0{{Name: "Shape", Cases: []xdr.ScSpecUdtUnionCaseV0{
+			{Kind: xdr.ScSpecUdtUnionCaseV0KindScSpecUdtUnionCaseVoidV0, VoidCase: &xdr.ScSpecUdtUnionCaseVoidV0{Name: "Empty"}},
+			{Kind: xdr.ScSpecUdtUnionCaseV0KindScSpecUdtUnionCaseTupleV0, TupleCase: &xdr.ScSpecUdtUnionCaseTupleV0{
+				Name: "At", Type: []xdr.ScSpecTypeDef{udtType("Point")},
+			}},
+		}}},
+		Enums: []xdr.ScSpecUdtEnumV0{{Name: "Color", Cases: []xdr.ScSpecUdtEnumCaseV0{{Name: "Red", Value: 1}}}},
+	}
+
+	point := map[string]interface{}{"x": "12", "label": "beef"}
+	for _, tc := range []struct {
+		td xdr.ScSpecTypeDef
+		v  interface{}
+	}{
+		{udtType("Point"), point},
+		{udtType("Shape"), "Empty"},
+		{udtType("Shape"), map[string]interface{}{"At": point}},
+		{udtType("Color"), "Red"},
+		{xdr.ScSpecTypeDef{Type: xdr.ScSpecTypeScSpecTypeOption, Option: &xdr.ScSpecTypeOption{ValueType: udtType("Color")}}, nil},
+	} {
+		val, err := spec.Encode(tc.td, tc.v)
+		require.NoError(t, err)
+		got, err := spec.Decode(tc.td, val)
+		require.NoError(t, err)
+		assert.Equal(t, tc.v, got)
+	}
+}
+
+func TestDecodeRejectsMismatch(t *testing.T) {
+	spec := &ContractSpec{}
+	_, err := spec.Decode(xdr.ScSpecTypeDef{Type: xdr.ScSpecTypeScSpecTypeU32}, symbol("x"))
+	assert.Error(t, err)
+}
+
+func TestDecodeUntyped(t *testing.T) {
+	spec := &ContractSpec{}
+	val := scval.Map(
+		xdr.ScMapEntry{Key: symbol("a"), Val: scval.Vec(symbol("b"))},
+	)
+	got, err := spec.Decode(xdr.ScSpecTypeDef{Type: xdr.ScSpecTypeScSpecTypeVal}, val)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": []interface{}{"b"}}, got)
+}
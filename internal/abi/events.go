@@ -0,0 +1,318 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package abi
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// EventField is one named parameter of a decoded event.
+type EventField struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// DecodedEvent is a contract event matched against a spec. Name and Fields
+// are empty when no known spec describes the event; Topics and Data always
+// hold the raw values decoded without type information.
+type DecodedEvent struct {
+	ID         string        `json:"id,omitempty"`
+	Ledger     uint32        `json:"ledger,omitempty"`
+	TxHash     string        `json:"tx_hash,omitempty"`
+	ContractID string        `json:"contract_id,omitempty"`
+	Name       string        `json:"name,omitempty"`
+	Fields     []EventField  `json:"fields,omitempty"`
+	Topics     []interface{} `json:"topics"`
+	Data       interface{}   `json:"data"`
+}
+
+// Field returns the value of the named field.
+func (e *DecodedEvent) Field(name string) (interface{}, bool) {
+	for _, f := range e.Fields {
+		if f.Name == name {
+			return f.Value, true
+		}
+	}
+	return nil, false
+}
+
+// DecodeEvent matches an event's topics and data against the events in the
+// spec. An event matches when its prefix topics equal the leading symbol
+// topics, the number of topics agrees and every parameter has a value of
+// its declared type.
+func (s *ContractSpec) DecodeEvent(topics []xdr.ScVal, data xdr.ScVal) (*DecodedEvent, error) {
+	for _, ev := range s.Events {
+		if fields, ok := s.matchEvent(ev, topics, data); ok {
+			out := rawEvent(topics, data)
+			out.Name = string(ev.Name)
+			out.Fields = fields
+			return out, nil
+		}
+	}
+	return nil, errors.WrapValidationError(fmt.Sprintf("no event in the spec matches topics %v", rawEvent(topics, data).Topics))
+}
+
+func (s *ContractSpec) matchEvent(ev xdr.ScSpecEventV0, topics []xdr.ScVal, data xdr.ScVal) ([]EventField, bool) {
+	var topicParams, dataParams []xdr.ScSpecEventParamV0
+	for _, p := range ev.Params {
+		if p.Location == xdr.ScSpecEventParamLocationV0ScSpecEventParamLocationTopicList {
+			topicParams = append(topicParams, p)
+		} else {
+			dataParams = append(dataParams, p)
+		}
+	}
+	if len(topics) != len(ev.PrefixTopics)+len(topicParams) {
+		return nil, false
+	}
+	for k, prefix := range ev.PrefixTopics {
+		sym, ok := topics[k].GetSym()
+		if !ok || sym != prefix {
+			return nil, false
+		}
+	}
+
+	// Fields are reported in declaration order, whichever part of the
+	// event carries them.
+	values := make(map[string]interface{}, len(ev.Params))
+	for k, p := range topicParams {
+		v, err := s.Decode(p.Type, topics[len(ev.PrefixTopics)+k])
+		if err != nil {
+			return nil, false
+		}
+		values[p.Name] = v
+	}
+	if !s.matchEventData(ev.DataFormat, dataParams, data, values) {
+		return nil, false
+	}
+
+	fields := make([]EventField, len(ev.Params))
+	for k, p := range ev.Params {
+		fields[k] = EventField{Name: p.Name, Type: FormatTypeDef(p.Type), Value: values[p.Name]}
+	}
+	return fields, true
+}
+
+func (s *ContractSpec) matchEventData(format xdr.ScSpecEventDataFormat, params []xdr.ScSpecEventParamV0, data xdr.ScVal, values map[string]interface{}) bool {
+	switch format {
+	case xdr.ScSpecEventDataFormatScSpecEventDataFormatSingleValue:
+		if len(params) == 0 {
+			return data.Type == xdr.ScValTypeScvVoid
+		}
+		if len(params) != 1 {
+			return false
+		}
+		v, err := s.Decode(params[0].Type, data)
+		if err != nil {
+			return false
+		}
+		values[params[0].Name] = v
+		return true
+
+	case xdr.ScSpecEventDataFormatScSpecEventDataFormatVec:
+		items, ok := data.GetVec()
+		if !ok || items == nil || len(*items) != len(params) {
+			return false
+		}
+		for k, p := range params {
+			v, err := s.Decode(p.Type, (*items)[k])
+			if err != nil {
+				return false
+			}
+			values[p.Name] = v
+		}
+		return true
+
+	case xdr.ScSpecEventDataFormatScSpecEventDataFormatMap:
+		entries, ok := data.GetMap()
+		if !ok || entries == nil {
+			return false
+		}
+		for _, p := range params {
+			found := false
+			for _, e := range *entries {
+				if sym, ok := e.Key.GetSym(); ok && string(sym) == p.Name {
+					v, err := s.Decode(p.Type, e.Val)
+					if err != nil {
+						return false
+					}
+					values[p.Name] = v
+					found = true
+					break
+				}
+			}
+			if !found && p.Type.Type != xdr.ScSpecTypeScSpecTypeOption {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func rawEvent(topics []xdr.ScVal, data xdr.ScVal) *DecodedEvent {
+	out := &DecodedEvent{Topics: make([]interface{}, len(topics)), Data: renderUntyped(data)}
+	for k, t := range topics {
+		out.Topics[k] = renderUntyped(t)
+	}
+	return out
+}
+
+// EventRegistry decodes events using the spec of the contract that emitted
+// them. Specs can be registered up front; with an RPC client, specs of
+// other contracts are fetched on first use and cached. Events no contract
+// spec describes are tried against the registered well-known interfaces,
+// which start out as the SEP-41 token interface.
+type EventRegistry struct {
+	client *rpc.Client
+
+	mu         sync.Mutex
+	specs      map[string]*ContractSpec
+	interfaces []*ContractSpec
+}
+
+// NewEventRegistry returns a registry that fetches unknown specs through
+// client, or works offline when client is nil.
+func NewEventRegistry(client *rpc.Client) *EventRegistry {
+	return &EventRegistry{
+		client:     client,
+		specs:      map[string]*ContractSpec{},
+		interfaces: []*ContractSpec{TokenInterface()},
+	}
+}
+
+// Register sets the spec used for a contract's events.
+func (r *EventRegistry) Register(contractID string, spec *ContractSpec) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.specs[contractID] = spec
+}
+
+// RegisterInterface adds a well-known interface, tried after any already
+// registered, for contracts whose own spec does not match an event.
+func (r *EventRegistry) RegisterInterface(spec *ContractSpec) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interfaces = append(r.interfaces, spec)
+}
+
+// Decode decodes an event emitted by contractID, returning it undecoded
+// when no spec matches.
+func (r *EventRegistry) Decode(ctx context.Context, contractID string, topics []xdr.ScVal, data xdr.ScVal) *DecodedEvent {
+	r.mu.Lock()
+	candidates := append([]*ContractSpec(nil), r.interfaces...)
+	r.mu.Unlock()
+	if spec := r.spec(ctx, contractID); spec != nil {
+		candidates = append([]*ContractSpec{spec}, candidates...)
+	}
+
+	for _, s := range candidates {
+		if ev, err := s.DecodeEvent(topics, data); err == nil {
+			ev.ContractID = contractID
+			return ev
+		}
+	}
+	ev := rawEvent(topics, data)
+	ev.ContractID = contractID
+	return ev
+}
+
+// DecodeRPCEvent decodes an event as returned by getEvents.
+func (r *EventRegistry) DecodeRPCEvent(ctx context.Context, e rpc.ContractEvent) (*DecodedEvent, error) {
+	topics := make([]xdr.ScVal, len(e.Topic))
+	for k, t := range e.Topic {
+		if err := xdr.SafeUnmarshalBase64(t, &topics[k]); err != nil {
+			return nil, errors.WrapUnmarshalFailed(err, t)
+		}
+	}
+	var data xdr.ScVal
+	if err := xdr.SafeUnmarshalBase64(e.Value, &data); err != nil {
+		return nil, errors.WrapUnmarshalFailed(err, e.Value)
+	}
+
+	ev := r.Decode(ctx, e.ContractID, topics, data)
+	ev.ID, ev.Ledger, ev.TxHash = e.ID, e.Ledger, e.TxHash
+	return ev, nil
+}
+
+// FetchEvents runs a getEvents query and decodes every event it returns,
+// along with the cursor for the next page.
+func (r *EventRegistry) FetchEvents(ctx context.Context, req rpc.EventsRequest) ([]*DecodedEvent, string, error) {
+	if r.client == nil {
+		return nil, "", errors.WrapValidationError("event registry has no RPC client")
+	}
+	page, err := r.client.GetEvents(ctx, req)
+	if err != nil {
+		return nil, "", err
+	}
+	out := make([]*DecodedEvent, 0, len(page.Events))
+	for _, e := range page.Events {
+		ev, err := r.DecodeRPCEvent(ctx, e)
+		if err != nil {
+			return nil, "", fmt.Errorf("event %s: %w", e.ID, err)
+		}
+		out = append(out, ev)
+	}
+	return out, page.Cursor, nil
+}
+
+// ResolveContractError names a contract error code from the spec of the
+// contract that raised it, so a registry can be passed to
+// rpc.WithContractErrorResolver. Specs are found and fetched as for events.
+func (r *EventRegistry) ResolveContractError(ctx context.Context, contractID string, code uint32) (rpc.ContractErrorInfo, bool) {
+	spec := r.spec(ctx, contractID)
+	if spec == nil {
+		return rpc.ContractErrorInfo{}, false
+	}
+	enum, c, ok := spec.ErrorCase(code)
+	if !ok {
+		return rpc.ContractErrorInfo{}, false
+	}
+	return rpc.ContractErrorInfo{Enum: enum, Name: c.Name, Doc: c.Doc}, true
+}
+
+// ResolveError names the contract error carried by err, if any, in place
+// and returns err. It suits clients built without
+// rpc.WithContractErrorResolver, such as the one the registry itself uses.
+func (r *EventRegistry) ResolveError(ctx context.Context, err error) error {
+	var ce *errors.ContractError
+	if !errors.As(err, &ce) || ce.Resolved() || ce.ContractID == "" {
+		return err
+	}
+	if info, ok := r.ResolveContractError(ctx, ce.ContractID, ce.Code); ok {
+		ce.Enum, ce.Name, ce.Doc = info.Enum, info.Name, info.Doc
+	}
+	return err
+}
+
+// spec returns the registered or fetched spec of contractID, or nil when
+// none is available. Contracts whose WASM has no spec are remembered; other
+// failures, including Stellar Asset Contracts having no WASM at all, are
+// retried next time from the client's ledger entry cache.
+func (r *EventRegistry) spec(ctx context.Context, contractID string) *ContractSpec {
+	r.mu.Lock()
+	spec, ok := r.specs[contractID]
+	r.mu.Unlock()
+	if ok || r.client == nil || contractID == "" {
+		return spec
+	}
+
+	spec, err := FetchContractSpec(ctx, r.client, contractID)
+	if err != nil {
+		logger.Logger.Debug("No spec for contract, using well-known interfaces", "contract", contractID, "error", err)
+		if !errors.Is(err, errors.ErrSpecNotFound) {
+			return nil
+		}
+		spec = nil
+	}
+	r.Register(contractID, spec)
+	return spec
+}
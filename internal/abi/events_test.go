@@ -0,0 +1,203 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package abi
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/scval"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func addressVal(t *testing.T, s string) xdr.ScVal {
+	t.Helper()
+	addr, err := scval.NewAddress(s)
+	require.NoError(t, err)
+	return xdr.ScVal{Type: xdr.ScValTypeScvAddress, Address: &addr}
+}
+
+func i128Val(t *testing.T, n int64) xdr.ScVal {
+	t.Helper()
+	v, err := scval.I128(big.NewInt(n))
+	require.NoError(t, err)
+	return v
+}
+
+func TestTokenInterfaceTransfer(t *testing.T) {
+	from, to := keypair.MustRandom().Address(), keypair.MustRandom().Address()
+	topics := []xdr.ScVal{symbol("transfer"), addressVal(t, from), addressVal(t, to)}
+
+	ev, err := TokenInterface().DecodeEvent(topics, i128Val(t, 250))
+	require.NoError(t, err)
+	assert.Equal(t, "transfer", ev.Name)
+	require.Len(t, ev.Fields, 3)
+	assert.Equal(t, EventField{Name: "from", Type: "Address", Value: from}, ev.Fields[0])
+	amount, ok := ev.Field("amount")
+	require.True(t, ok)
+	assert.Equal(t, "250", amount)
+
+	// Stellar Asset Contracts add the asset name as a final topic.
+	str := xdr.ScString("USDC:" + from)
+	sac := append(topics, xdr.ScVal{Type: xdr.ScValTypeScvString, Str: &str})
+	ev, err = TokenInterface().DecodeEvent(sac, i128Val(t, 1))
+	require.NoError(t, err)
+	asset, _ := ev.Field("asset")
+	assert.Equal(t, string(str), asset)
+}
+
+func TestTokenInterfaceMintVariants(t *testing.T) {
+	admin, to := keypair.MustRandom().Address(), keypair.MustRandom().Address()
+
+	ev, err := TokenInterface().DecodeEvent([]xdr.ScVal{symbol("mint"), addressVal(t, to)}, i128Val(t, 5))
+	require.NoError(t, err)
+	_, hasAdmin := ev.Field("admin")
+	assert.False(t, hasAdmin)
+
+	ev, err = TokenInterface().DecodeEvent([]xdr.ScVal{symbol("mint"), addressVal(t, admin), addressVal(t, to)}, i128Val(t, 5))
+	require.NoError(t, err)
+	got, _ := ev.Field("admin")
+	assert.Equal(t, admin, got)
+}
+
+func TestDecodeEventDataFormats(t *testing.T) {
+	u32 := xdr.ScSpecTypeDef{Type: xdr.ScSpecTypeScSpecTypeU32}
+	spec := &ContractSpec{Events: []xdr.ScSpecEventV0{
+		{
+			Name:         "moved",
+			PrefixTopics: []xdr.ScSymbol{"game", "moved"},
+			Params: []xdr.ScSpecEventParamV0{
+				{Name: "x", Type: u32},
+				{Name: "player", Type: u32, Location: xdr.ScSpecEventParamLocationV0ScSpecEventParamLocationTopicList},
+				{Name: "y", Type: u32},
+			},
+			DataFormat: xdr.ScSpecEventDataFormatScSpecEventDataFormatVec,
+		},
+		{
+			Name:         "scored",
+			PrefixTopics: []xdr.ScSymbol{"scored"},
+			Params:       []xdr.ScSpecEventParamV0{{Name: "points", Type: u32}},
+			DataFormat:   xdr.ScSpecEventDataFormatScSpecEventDataFormatMap,
+		},
+	}}
+	u := func(n uint32) xdr.ScVal { v, _ := scval.ToScVal(n); return v }
+
+	ev, err := spec.DecodeEvent([]xdr.ScVal{symbol("game"), symbol("moved"), u(7)}, scval.Vec(u(1), u(2)))
+	require.NoError(t, err)
+	assert.Equal(t, []EventField{
+		{Name: "x", Type: "U32", Value: uint32(1)},
+		{Name: "player", Type: "U32", Value: uint32(7)},
+		{Name: "y", Type: "U32", Value: uint32(2)},
+	}, ev.Fields)
+
+	ev, err = spec.DecodeEvent([]xdr.ScVal{symbol("scored")}, scval.Map(xdr.ScMapEntry{Key: symbol("points"), Val: u(3)}))
+	require.NoError(t, err)
+	points, _ := ev.Field("points")
+	assert.Equal(t, uint32(3), points)
+
+	_, err = spec.DecodeEvent([]xdr.ScVal{symbol("game"), symbol("moved"), u(7)}, scval.Vec(u(1)))
+	assert.Error(t, err)
+}
+
+func TestEventRegistryFetchesSpecs(t *testing.T) {
+	id := xdr.ContractId{3}
+	contract := strkey.MustEncode(strkey.VersionByteContract, id[:])
+	wasm := specWasm(t, xdr.ScSpecEntry{
+		Kind: xdr.ScSpecEntryKindScSpecEntryEventV0,
+		EventV0: &xdr.ScSpecEventV0{
+			Name:         "ping",
+			PrefixTopics: []xdr.ScSymbol{"ping"},
+			Params:       []xdr.ScSpecEventParamV0{{Name: "count", Type: xdr.ScSpecTypeDef{Type: xdr.ScSpecTypeScSpecTypeU32}}},
+		},
+	})
+	entries := contractEntries(t, id, wasm)
+
+	count, _ := scval.ToScVal(uint32(4))
+	topic, err := xdr.MarshalBase64(symbol("ping"))
+	require.NoError(t, err)
+	value, err := xdr.MarshalBase64(count)
+	require.NoError(t, err)
+
+	var fetches int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		result := map[string]interface{}{"latestLedger": 10}
+		switch req.Method {
+		case "getLedgerEntries":
+			fetches++
+			result["entries"] = entries
+		case "getEvents":
+			result["cursor"] = "next"
+			result["events"] = []rpc.ContractEvent{{ID: "1", Ledger: 9, ContractID: contract, Topic: []string{topic}, Value: value}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "result": result})
+	}))
+	defer server.Close()
+
+	client, err := rpc.NewClient(rpc.WithNetwork(rpc.Testnet), rpc.WithCacheEnabled(false))
+	require.NoError(t, err)
+	client.SorobanURL = server.URL
+	client.AltURLs = []string{server.URL}
+
+	registry := NewEventRegistry(client)
+	events, cursor, err := registry.FetchEvents(context.Background(), rpc.EventsRequest{StartLedger: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "next", cursor)
+	require.Len(t, events, 1)
+	assert.Equal(t, "ping", events[0].Name)
+	assert.Equal(t, contract, events[0].ContractID)
+	assert.Equal(t, uint32(9), events[0].Ledger)
+	got, _ := events[0].Field("count")
+	assert.Equal(t, uint32(4), got)
+
+	_, _, err = registry.FetchEvents(context.Background(), rpc.EventsRequest{StartLedger: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 2, fetches, "spec is fetched once: instance and code lookups")
+}
+
+func TestEventRegistryUnknownEvent(t *testing.T) {
+	registry := NewEventRegistry(nil)
+	ev := registry.Decode(context.Background(), "", []xdr.ScVal{symbol("custom")}, symbol("x"))
+	assert.Empty(t, ev.Name)
+	assert.Equal(t, []interface{}{"custom"}, ev.Topics)
+	assert.Equal(t, "x", ev.Data)
+
+	spec := &ContractSpec{Events: []xdr.ScSpecEventV0{{Name: "custom", PrefixTopics: []xdr.ScSymbol{"custom"},
+		Params: []xdr.ScSpecEventParamV0{{Name: "tag", Type: xdr.ScSpecTypeDef{Type: xdr.ScSpecTypeScSpecTypeSymbol}}}}}}
+	registry.RegisterInterface(spec)
+	ev = registry.Decode(context.Background(), "", []xdr.ScVal{symbol("custom")}, symbol("x"))
+	assert.Equal(t, "custom", ev.Name)
+}
+
+func TestEventRegistryResolvesContractErrors(t *testing.T) {
+	const contractID = "CCONTRACT"
+	registry := NewEventRegistry(nil)
+	registry.Register(contractID, &ContractSpec{ErrorEnums: []xdr.ScSpecUdtErrorEnumV0{{Name: "TokenError",
+		Cases: []xdr.ScSpecUdtErrorEnumCaseV0{{Name: "InsufficientBalance", Value: 5, Doc: "balance is too low"}}}}})
+
+	info, ok := registry.ResolveContractError(context.Background(), contractID, 5)
+	require.True(t, ok)
+	assert.Equal(t, rpc.ContractErrorInfo{Enum: "TokenError", Name: "InsufficientBalance", Doc: "balance is too low"}, info)
+	_, ok = registry.ResolveContractError(context.Background(), contractID, 6)
+	assert.False(t, ok)
+
+	ce := &errs.ContractError{ContractID: contractID, Function: "transfer", Code: 5}
+	err := registry.ResolveError(context.Background(), errs.NewTransactionResultError("tx_failed", nil).WithContractError(ce))
+	assert.Equal(t, "TokenError::InsufficientBalance", ce.QualifiedName())
+	assert.Contains(t, err.Error(), "Error(Contract, #5) TokenError::InsufficientBalance: balance is too low")
+}
@@ -0,0 +1,31 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package abi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// FetchContractSpec downloads the WASM of a deployed contract and returns
+// its spec. contractID can be a strkey (C...) or 32-byte hex.
+func FetchContractSpec(ctx context.Context, client *rpc.Client, contractID string) (*ContractSpec, error) {
+	entries, err := rpc.FetchContractBytecode(ctx, client, contractID)
+	if err != nil {
+		return nil, err
+	}
+	for _, b64 := range entries {
+		var entry xdr.LedgerEntry
+		if err := xdr.SafeUnmarshalBase64(b64, &entry); err != nil {
+			return nil, fmt.Errorf("decoding ledger entry: %w", err)
+		}
+		if code, ok := entry.Data.GetContractCode(); ok {
+			return ParseWasm(code.Code)
+		}
+	}
+	return nil, fmt.Errorf("contract code not found for %s", contractID)
+}
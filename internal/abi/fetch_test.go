@@ -0,0 +1,85 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package abi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// contractEntries returns the getLedgerEntries results for a contract
+// instance and the WASM it runs.
+func contractEntries(t *testing.T, id xdr.ContractId, wasm []byte) []map[string]interface{} {
+	t.Helper()
+	hash := xdr.Hash{7}
+	instanceKey, err := rpc.LedgerKeyForContractInstance(id)
+	require.NoError(t, err)
+	instance := xdr.LedgerEntry{Data: xdr.LedgerEntryData{
+		Type: xdr.LedgerEntryTypeContractData,
+		ContractData: &xdr.ContractDataEntry{
+			Contract:   instanceKey.ContractData.Contract,
+			Key:        instanceKey.ContractData.Key,
+			Durability: xdr.ContractDataDurabilityPersistent,
+			Val: xdr.ScVal{Type: xdr.ScValTypeScvContractInstance, Instance: &xdr.ScContractInstance{
+				Executable: xdr.ContractExecutable{Type: xdr.ContractExecutableTypeContractExecutableWasm, WasmHash: &hash},
+			}},
+		},
+	}}
+	codeKey := xdr.LedgerKey{Type: xdr.LedgerEntryTypeContractCode, ContractCode: &xdr.LedgerKeyContractCode{Hash: hash}}
+	code := xdr.LedgerEntry{Data: xdr.LedgerEntryData{
+		Type:         xdr.LedgerEntryTypeContractCode,
+		ContractCode: &xdr.ContractCodeEntry{Hash: hash, Code: wasm},
+	}}
+
+	var out []map[string]interface{}
+	for _, kv := range []struct {
+		key   xdr.LedgerKey
+		entry xdr.LedgerEntry
+	}{{instanceKey, instance}, {codeKey, code}} {
+		key, err := rpc.EncodeLedgerKey(kv.key)
+		require.NoError(t, err)
+		entry, err := xdr.MarshalBase64(kv.entry)
+		require.NoError(t, err)
+		out = append(out, map[string]interface{}{"key": key, "xdr": entry})
+	}
+	return out
+}
+
+func TestFetchContractSpec(t *testing.T) {
+	id := xdr.ContractId{1}
+	wasm := specWasm(t, xdr.ScSpecEntry{
+		Kind:       xdr.ScSpecEntryKindScSpecEntryFunctionV0,
+		FunctionV0: &xdr.ScSpecFunctionV0{Name: "transfer"},
+	})
+	entries := contractEntries(t, id, wasm)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0", "id": 1,
+			"result": map[string]interface{}{"entries": entries, "latestLedger": 10},
+		})
+	}))
+	defer server.Close()
+
+	client, err := rpc.NewClient(rpc.WithNetwork(rpc.Testnet), rpc.WithCacheEnabled(false))
+	require.NoError(t, err)
+	client.SorobanURL = server.URL
+	client.AltURLs = []string{server.URL}
+
+	contract, err := strkey.Encode(strkey.VersionByteContract, id[:])
+	require.NoError(t, err)
+	spec, err := FetchContractSpec(context.Background(), client, contract)
+	require.NoError(t, err)
+	_, ok := spec.Function("transfer")
+	assert.True(t, ok)
+}
@@ -7,9 +7,13 @@ import (
 	"bytes"
 	"fmt"
 
+	"github.com/dotandev/hintents/internal/errors"
 	"github.com/stellar/go-stellar-sdk/xdr"
 )
 
+// SpecSectionName is the WASM custom section holding a contract's spec.
+const SpecSectionName = "contractspecv0"
+
 // ContractSpec holds the decoded Soroban contract specification, grouped by
 // entry kind.
 type ContractSpec struct {
@@ -54,3 +58,89 @@ func DecodeContractSpec(data []byte) (*ContractSpec, error) {
 
 	return spec, nil
 }
+
+// ParseWasm extracts and decodes the spec embedded in a contract's WASM.
+func ParseWasm(wasm []byte) (*ContractSpec, error) {
+	data, err := ExtractCustomSection(wasm, SpecSectionName)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, errors.WrapSpecNotFound()
+	}
+	return DecodeContractSpec(data)
+}
+
+// Function returns the function with the given name.
+func (s *ContractSpec) Function(name string) (xdr.ScSpecFunctionV0, bool) {
+	for _, fn := range s.Functions {
+		if string(fn.Name) == name {
+			return fn, true
+		}
+	}
+	return xdr.ScSpecFunctionV0{}, false
+}
+
+// Struct returns the struct type with the given name.
+func (s *ContractSpec) Struct(name string) (xdr.ScSpecUdtStructV0, bool) {
+	for _, st := range s.Structs {
+		if st.Name == name {
+			return st, true
+		}
+	}
+	return xdr.ScSpecUdtStructV0{}, false
+}
+
+// Union returns the union type with the given name.
+func (s *ContractSpec) Union(name string) (xdr.ScSpecUdtUnionV0, bool) {
+	for _, u := range s.Unions {
+		if u.Name == name {
+			return u, true
+		}
+	}
+	return xdr.ScSpecUdtUnionV0{}, false
+}
+
+// Enum returns the enum type with the given name.
+func (s *ContractSpec) Enum(name string) (xdr.ScSpecUdtEnumV0, bool) {
+	for _, e := range s.Enums {
+		if e.Name == name {
+			return e, true
+		}
+	}
+	return xdr.ScSpecUdtEnumV0{}, false
+}
+
+// ErrorEnum returns the error enum type with the given name.
+func (s *ContractSpec) ErrorEnum(name string) (xdr.ScSpecUdtErrorEnumV0, bool) {
+	for _, e := range s.ErrorEnums {
+		if e.Name == name {
+			return e, true
+		}
+	}
+	return xdr.ScSpecUdtErrorEnumV0{}, false
+}
+
+// Event returns the first event with the given name. Several events may
+// share a name when they differ in topics; DecodeEvent considers them all.
+func (s *ContractSpec) Event(name string) (xdr.ScSpecEventV0, bool) {
+	for _, e := range s.Events {
+		if string(e.Name) == name {
+			return e, true
+		}
+	}
+	return xdr.ScSpecEventV0{}, false
+}
+
+// ErrorCase returns the error enum case with the given code, along with the
+// name of the enum declaring it.
+func (s *ContractSpec) ErrorCase(code uint32) (string, xdr.ScSpecUdtErrorEnumCaseV0, bool) {
+	for _, e := range s.ErrorEnums {
+		for _, c := range e.Cases {
+			if uint32(c.Value) == code {
+				return e.Name, c, true
+			}
+		}
+	}
+	return "", xdr.ScSpecUdtErrorEnumCaseV0{}, false
+}
@@ -6,6 +6,7 @@ package abi
 import (
 	"testing"
 
+	"github.com/dotandev/hintents/internal/errors"
 	"github.com/stellar/go-stellar-sdk/xdr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -142,3 +143,32 @@ func TestDecodeContractSpec_Union(t *testing.T) {
 	require.Len(t, spec.Unions, 1)
 	assert.Equal(t, "Outcome", string(spec.Unions[0].Name))
 }
+
+func specWasm(t *testing.T, entries ...xdr.ScSpecEntry) []byte {
+	t.Helper()
+	return buildWasm(struct {
+		name    string
+		payload []byte
+	}{SpecSectionName, marshalEntries(t, entries...)})
+}
+
+func TestParseWasm(t *testing.T) {
+	wasm := specWasm(t, xdr.ScSpecEntry{
+		Kind:       xdr.ScSpecEntryKindScSpecEntryFunctionV0,
+		FunctionV0: &xdr.ScSpecFunctionV0{Name: "hello"},
+	})
+	spec, err := ParseWasm(wasm)
+	require.NoError(t, err)
+
+	fn, ok := spec.Function("hello")
+	require.True(t, ok)
+	assert.Equal(t, xdr.ScSymbol("hello"), fn.Name)
+	_, ok = spec.Function("goodbye")
+	assert.False(t, ok)
+}
+
+func TestParseWasm_NoSpec(t *testing.T) {
+	wasm := buildWasm()
+	_, err := ParseWasm(wasm)
+	assert.ErrorIs(t, err, errors.ErrSpecNotFound)
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package abi
+
+import "github.com/stellar/go-stellar-sdk/xdr"
+
+// TokenInterface returns the events of the SEP-41 token interface, as
+// emitted by Stellar Asset Contracts and by token contracts built on the
+// soroban-sdk token utilities. Variants from before and after CAP-67 are
+// both included, as are the Stellar Asset Contract forms whose last topic
+// is the SEP-11 asset name.
+func TokenInterface() *ContractSpec {
+	var (
+		address = xdr.ScSpecTypeDef{Type: xdr.ScSpecTypeScSpecTypeAddress}
+		i128    = xdr.ScSpecTypeDef{Type: xdr.ScSpecTypeScSpecTypeI128}
+		u32     = xdr.ScSpecTypeDef{Type: xdr.ScSpecTypeScSpecTypeU32}
+		str     = xdr.ScSpecTypeDef{Type: xdr.ScSpecTypeScSpecTypeString}
+		boolean = xdr.ScSpecTypeDef{Type: xdr.ScSpecTypeScSpecTypeBool}
+		u64     = xdr.ScSpecTypeDef{Type: xdr.ScSpecTypeScSpecTypeU64}
+		muxedID = xdr.ScSpecTypeDef{Type: xdr.ScSpecTypeScSpecTypeOption, Option: &xdr.ScSpecTypeOption{ValueType: u64}}
+	)
+	topic := func(name string, td xdr.ScSpecTypeDef) xdr.ScSpecEventParamV0 {
+		return xdr.ScSpecEventParamV0{Name: name, Type: td, Location: xdr.ScSpecEventParamLocationV0ScSpecEventParamLocationTopicList}
+	}
+	data := func(name string, td xdr.ScSpecTypeDef) xdr.ScSpecEventParamV0 {
+		return xdr.ScSpecEventParamV0{Name: name, Type: td, Location: xdr.ScSpecEventParamLocationV0ScSpecEventParamLocationData}
+	}
+
+	base := []struct {
+		name   string
+		format xdr.ScSpecEventDataFormat
+		params []xdr.ScSpecEventParamV0
+	}{
+		{"transfer", xdr.ScSpecEventDataFormatScSpecEventDataFormatSingleValue,
+			[]xdr.ScSpecEventParamV0{topic("from", address), topic("to", address), data("amount", i128)}},
+		{"transfer", xdr.ScSpecEventDataFormatScSpecEventDataFormatMap,
+			[]xdr.ScSpecEventParamV0{topic("from", address), topic("to", address), data("amount", i128), data("to_muxed_id", muxedID)}},
+		{"approve", xdr.ScSpecEventDataFormatScSpecEventDataFormatVec,
+			[]xdr.ScSpecEventParamV0{topic("from", address), topic("spender", address), data("amount", i128), data("expiration_ledger", u32)}},
+		{"mint", xdr.ScSpecEventDataFormatScSpecEventDataFormatSingleValue,
+			[]xdr.ScSpecEventParamV0{topic("to", address), data("amount", i128)}},
+		{"mint", xdr.ScSpecEventDataFormatScSpecEventDataFormatMap,
+			[]xdr.ScSpecEventParamV0{topic("to", address), data("amount", i128), data("to_muxed_id", muxedID)}},
+		{"mint", xdr.ScSpecEventDataFormatScSpecEventDataFormatSingleValue,
+			[]xdr.ScSpecEventParamV0{topic("admin", address), topic("to", address), data("amount", i128)}},
+		{"burn", xdr.ScSpecEventDataFormatScSpecEventDataFormatSingleValue,
+			[]xdr.ScSpecEventParamV0{topic("from", address), data("amount", i128)}},
+		{"clawback", xdr.ScSpecEventDataFormatScSpecEventDataFormatSingleValue,
+			[]xdr.ScSpecEventParamV0{topic("from", address), data("amount", i128)}},
+		{"clawback", xdr.ScSpecEventDataFormatScSpecEventDataFormatSingleValue,
+			[]xdr.ScSpecEventParamV0{topic("admin", address), topic("from", address), data("amount", i128)}},
+		{"set_admin", xdr.ScSpecEventDataFormatScSpecEventDataFormatSingleValue,
+			[]xdr.ScSpecEventParamV0{topic("admin", address), data("new_admin", address)}},
+		{"set_authorized", xdr.ScSpecEventDataFormatScSpecEventDataFormatSingleValue,
+			[]xdr.ScSpecEventParamV0{topic("admin", address), topic("id", address), data("authorize", boolean)}},
+	}
+
+	spec := &ContractSpec{}
+	for _, e := range base {
+		ev := xdr.ScSpecEventV0{
+			Lib:          "sep-41",
+			Name:         xdr.ScSymbol(e.name),
+			PrefixTopics: []xdr.ScSymbol{xdr.ScSymbol(e.name)},
+			Params:       e.params,
+			DataFormat:   e.format,
+		}
+		sac := ev
+		sac.Params = append(append([]xdr.ScSpecEventParamV0(nil), e.params...), topic("asset", str))
+		spec.Events = append(spec.Events, ev, sac)
+	}
+	return spec
+}
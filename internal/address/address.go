@@ -0,0 +1,182 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+// Package address parses, validates and encodes Stellar strkeys: accounts
+// (G...), muxed accounts (M...), contracts (C...), claimable balances
+// (B...), liquidity pools (L...) and signed payloads (P...). It also
+// converts between strkeys and the raw IDs and ScAddresses used in XDR.
+package address
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// Kind is the type of entity a strkey identifies.
+type Kind string
+
+const (
+	Account          Kind = "account"
+	MuxedAccount     Kind = "muxed_account"
+	Contract         Kind = "contract"
+	ClaimableBalance Kind = "claimable_balance"
+	LiquidityPool    Kind = "liquidity_pool"
+	SignedPayload    Kind = "signed_payload"
+)
+
+var kindsByVersion = map[strkey.VersionByte]Kind{
+	strkey.VersionByteAccountID:        Account,
+	strkey.VersionByteMuxedAccount:     MuxedAccount,
+	strkey.VersionByteContract:         Contract,
+	strkey.VersionByteClaimableBalance: ClaimableBalance,
+	strkey.VersionByteLiquidityPool:    LiquidityPool,
+	strkey.VersionByteSignedPayload:    SignedPayload,
+}
+
+// Address is a decoded strkey.
+type Address struct {
+	Kind    Kind
+	key     string
+	payload []byte
+}
+
+// Parse decodes any supported strkey, checking its checksum and that its
+// payload has the length its kind requires. Secret seeds are rejected so
+// they are never handled as addresses by mistake.
+func Parse(s string) (Address, error) {
+	s = strings.TrimSpace(s)
+	version, payload, err := strkey.DecodeAny(s)
+	if err != nil {
+		return Address{}, errors.WrapValidationError(fmt.Sprintf("invalid strkey %q: %v", s, err))
+	}
+	kind, ok := kindsByVersion[version]
+	if !ok {
+		return Address{}, errors.WrapValidationError(fmt.Sprintf("%q is not an address", redact(s)))
+	}
+
+	valid := false
+	switch kind {
+	case Account, Contract, LiquidityPool:
+		valid = len(payload) == 32
+	case MuxedAccount:
+		valid = len(payload) == 40
+	case ClaimableBalance:
+		valid = len(payload) == 33 && xdr.ClaimableBalanceIdType(payload[0]) == xdr.ClaimableBalanceIdTypeClaimableBalanceIdTypeV0
+	case SignedPayload:
+		_, err := strkey.DecodeSignedPayload(s)
+		valid = err == nil
+	}
+	if !valid {
+		return Address{}, errors.WrapValidationError(fmt.Sprintf("%q has a malformed %s payload", s, kind))
+	}
+	return Address{Kind: kind, key: s, payload: payload}, nil
+}
+
+// Validate parses s and, when kinds are given, checks that it is one of
+// them.
+func Validate(s string, kinds ...Kind) error {
+	a, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	if len(kinds) == 0 {
+		return nil
+	}
+	names := make([]string, len(kinds))
+	for i, k := range kinds {
+		if a.Kind == k {
+			return nil
+		}
+		names[i] = string(k)
+	}
+	return errors.WrapValidationError(fmt.Sprintf("%q is a %s address, expected %s", s, a.Kind, strings.Join(names, " or ")))
+}
+
+// IsValid reports whether Validate accepts s.
+func IsValid(s string, kinds ...Kind) bool {
+	return Validate(s, kinds...) == nil
+}
+
+// String returns the strkey.
+func (a Address) String() string { return a.key }
+
+// Bytes returns a copy of the decoded payload: the ed25519 key for
+// accounts, the key followed by the big-endian ID for muxed accounts, the
+// 32-byte ID for contracts and pools, and the type byte followed by the
+// hash for claimable balances.
+func (a Address) Bytes() []byte {
+	return append([]byte(nil), a.payload...)
+}
+
+// ScAddress converts a to the address type used in contract calls and
+// storage. Signed payloads have no ScAddress form.
+func (a Address) ScAddress() (xdr.ScAddress, error) {
+	switch a.Kind {
+	case Account:
+		id, err := xdr.AddressToAccountId(a.key)
+		if err != nil {
+			return xdr.ScAddress{}, errors.WrapValidationError(err.Error())
+		}
+		return xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeAccount, AccountId: &id}, nil
+	case MuxedAccount:
+		m, err := xdr.AddressToMuxedAccount(a.key)
+		if err != nil {
+			return xdr.ScAddress{}, errors.WrapValidationError(err.Error())
+		}
+		med := m.MustMed25519()
+		return xdr.ScAddress{
+			Type:         xdr.ScAddressTypeScAddressTypeMuxedAccount,
+			MuxedAccount: &xdr.MuxedEd25519Account{Id: med.Id, Ed25519: med.Ed25519},
+		}, nil
+	case Contract:
+		var id xdr.ContractId
+		copy(id[:], a.payload)
+		return xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &id}, nil
+	case ClaimableBalance:
+		var hash xdr.Hash
+		copy(hash[:], a.payload[1:])
+		return xdr.ScAddress{
+			Type:               xdr.ScAddressTypeScAddressTypeClaimableBalance,
+			ClaimableBalanceId: &xdr.ClaimableBalanceId{Type: xdr.ClaimableBalanceIdTypeClaimableBalanceIdTypeV0, V0: &hash},
+		}, nil
+	case LiquidityPool:
+		var id xdr.PoolId
+		copy(id[:], a.payload)
+		return xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeLiquidityPool, LiquidityPoolId: &id}, nil
+	}
+	return xdr.ScAddress{}, errors.WrapValidationError(fmt.Sprintf("a %s has no ScAddress form", a.Kind))
+}
+
+// FromScAddress returns the strkey of an ScAddress.
+func FromScAddress(addr xdr.ScAddress) (Address, error) {
+	s, err := addr.String()
+	if err != nil {
+		return Address{}, errors.WrapValidationError(err.Error())
+	}
+	return Parse(s)
+}
+
+// parseKind parses s and requires it to be of kind k.
+func parseKind(s string, k Kind) (Address, error) {
+	a, err := Parse(s)
+	if err != nil {
+		return Address{}, err
+	}
+	if a.Kind != k {
+		return Address{}, errors.WrapValidationError(fmt.Sprintf("%q is a %s address, expected %s", s, a.Kind, k))
+	}
+	return a, nil
+}
+
+// redact keeps secret seeds and other unexpected keys out of error
+// messages.
+func redact(s string) string {
+	if len(s) <= 8 {
+		return s
+	}
+	return s[:4] + "…" + s[len(s)-4:]
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package address
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKeys(t *testing.T) (account, contract string, raw []byte) {
+	t.Helper()
+	kp := keypair.MustRandom()
+	raw = bytes.Repeat([]byte{7}, 32)
+	return kp.Address(), strkey.MustEncode(strkey.VersionByteContract, raw), raw
+}
+
+func TestParseKinds(t *testing.T) {
+	account, contract, raw := testKeys(t)
+	muxed, err := EncodeMuxed(account, 42)
+	require.NoError(t, err)
+	payload, err := EncodeSignedPayload(account, []byte("hello"))
+	require.NoError(t, err)
+	pool := strkey.MustEncode(strkey.VersionByteLiquidityPool, raw)
+	balance, err := ClaimableBalanceAddress(xdr.ClaimableBalanceId{
+		Type: xdr.ClaimableBalanceIdTypeClaimableBalanceIdTypeV0,
+		V0:   &xdr.Hash{1, 2, 3},
+	})
+	require.NoError(t, err)
+
+	tests := map[string]Kind{
+		account:  Account,
+		muxed:    MuxedAccount,
+		contract: Contract,
+		balance:  ClaimableBalance,
+		pool:     LiquidityPool,
+		payload:  SignedPayload,
+	}
+	for s, kind := range tests {
+		a, err := Parse(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, kind, a.Kind)
+		assert.Equal(t, s, a.String())
+		assert.True(t, IsValid(s, kind))
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	account, _, _ := testKeys(t)
+	seed := keypair.MustRandom().Seed()
+
+	for _, s := range []string{"", "not-a-key", account[:len(account)-1] + "A", seed} {
+		_, err := Parse(s)
+		assert.ErrorIs(t, err, errs.ErrValidationFailed, s)
+	}
+
+	_, err := Parse(seed)
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), seed)
+}
+
+func TestValidateKinds(t *testing.T) {
+	account, contract, _ := testKeys(t)
+
+	assert.NoError(t, Validate(account))
+	assert.NoError(t, Validate(contract, Account, Contract))
+
+	err := Validate(contract, Account)
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+	assert.Contains(t, err.Error(), "expected account")
+}
+
+func TestContractIDRoundTrip(t *testing.T) {
+	_, contract, raw := testKeys(t)
+
+	id, err := ContractID(contract)
+	require.NoError(t, err)
+	assert.Equal(t, raw, id[:])
+	assert.Equal(t, contract, ContractAddress(id))
+
+	fromBytes, err := ContractIDFromBytes(raw)
+	require.NoError(t, err)
+	assert.Equal(t, id, fromBytes)
+
+	fromHex, err := ContractIDFromHex(hex.EncodeToString(raw))
+	require.NoError(t, err)
+	assert.Equal(t, id, fromHex)
+
+	_, err = ContractIDFromBytes(raw[:31])
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+	_, err = ContractIDFromHex("0001")
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+}
+
+func TestAccountAndMuxed(t *testing.T) {
+	account, contract, _ := testKeys(t)
+
+	id, err := AccountID(account)
+	require.NoError(t, err)
+	assert.Equal(t, account, id.Address())
+
+	_, err = AccountID(contract)
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+
+	muxed, err := EncodeMuxed(account, 9)
+	require.NoError(t, err)
+	m, err := Muxed(muxed)
+	require.NoError(t, err)
+	assert.Equal(t, xdr.Uint64(9), m.MustMed25519().Id)
+
+	plain, err := Muxed(account)
+	require.NoError(t, err)
+	assert.Equal(t, xdr.CryptoKeyTypeKeyTypeEd25519, plain.Type)
+}
+
+func TestClaimableBalanceID(t *testing.T) {
+	id := xdr.ClaimableBalanceId{Type: xdr.ClaimableBalanceIdTypeClaimableBalanceIdTypeV0, V0: &xdr.Hash{9}}
+	b, err := ClaimableBalanceAddress(id)
+	require.NoError(t, err)
+
+	fromStrkey, err := ClaimableBalanceID(b)
+	require.NoError(t, err)
+	assert.Equal(t, *id.V0, *fromStrkey.V0)
+
+	horizonHex, err := xdr.MarshalHex(id)
+	require.NoError(t, err)
+	fromHex, err := ClaimableBalanceID(horizonHex)
+	require.NoError(t, err)
+	assert.Equal(t, *id.V0, *fromHex.V0)
+}
+
+func TestLiquidityPoolID(t *testing.T) {
+	_, _, raw := testKeys(t)
+	var want xdr.PoolId
+	copy(want[:], raw)
+	l := LiquidityPoolAddress(want)
+
+	for _, s := range []string{l, hex.EncodeToString(raw)} {
+		id, err := LiquidityPoolID(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, want, id)
+	}
+}
+
+func TestSignedPayload(t *testing.T) {
+	account, _, _ := testKeys(t)
+
+	p, err := EncodeSignedPayload(account, []byte("payload"))
+	require.NoError(t, err)
+	signer, payload, err := ParseSignedPayload(p)
+	require.NoError(t, err)
+	assert.Equal(t, account, signer)
+	assert.Equal(t, []byte("payload"), payload)
+
+	_, err = EncodeSignedPayload(account, make([]byte, 65))
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+}
+
+func TestScAddressRoundTrip(t *testing.T) {
+	account, contract, raw := testKeys(t)
+	muxed, err := EncodeMuxed(account, 3)
+	require.NoError(t, err)
+	pool := strkey.MustEncode(strkey.VersionByteLiquidityPool, raw)
+
+	for _, s := range []string{account, muxed, contract, pool} {
+		a, err := Parse(s)
+		require.NoError(t, err)
+		sc, err := a.ScAddress()
+		require.NoError(t, err, s)
+		back, err := FromScAddress(sc)
+		require.NoError(t, err, s)
+		assert.Equal(t, s, back.String())
+	}
+
+	p, err := EncodeSignedPayload(account, []byte{1})
+	require.NoError(t, err)
+	a, err := Parse(p)
+	require.NoError(t, err)
+	_, err = a.ScAddress()
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+}
@@ -0,0 +1,177 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package address
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// AccountID decodes a G address.
+func AccountID(s string) (xdr.AccountId, error) {
+	a, err := parseKind(s, Account)
+	if err != nil {
+		return xdr.AccountId{}, err
+	}
+	var key xdr.Uint256
+	copy(key[:], a.payload)
+	return xdr.AccountId{Type: xdr.PublicKeyTypePublicKeyTypeEd25519, Ed25519: &key}, nil
+}
+
+// Muxed decodes a G or M address into the muxed account form used as
+// transaction and operation sources.
+func Muxed(s string) (xdr.MuxedAccount, error) {
+	if err := Validate(s, Account, MuxedAccount); err != nil {
+		return xdr.MuxedAccount{}, err
+	}
+	m, err := xdr.AddressToMuxedAccount(strings.TrimSpace(s))
+	if err != nil {
+		return xdr.MuxedAccount{}, errors.WrapValidationError(err.Error())
+	}
+	return m, nil
+}
+
+// EncodeMuxed returns the M address of base account G with the given ID.
+func EncodeMuxed(account string, id uint64) (string, error) {
+	var m strkey.MuxedAccount
+	if err := m.SetAccountID(account); err != nil {
+		return "", errors.WrapValidationError(fmt.Sprintf("invalid account %q: %v", account, err))
+	}
+	m.SetID(id)
+	out, err := m.Address()
+	if err != nil {
+		return "", errors.WrapValidationError(err.Error())
+	}
+	return out, nil
+}
+
+// ContractID decodes a C address.
+func ContractID(s string) (xdr.ContractId, error) {
+	a, err := parseKind(s, Contract)
+	if err != nil {
+		return xdr.ContractId{}, err
+	}
+	var id xdr.ContractId
+	copy(id[:], a.payload)
+	return id, nil
+}
+
+// ContractIDFromBytes converts a raw 32-byte contract ID, such as the
+// hash of a contract ID preimage.
+func ContractIDFromBytes(b []byte) (xdr.ContractId, error) {
+	if len(b) != 32 {
+		return xdr.ContractId{}, errors.WrapValidationError(fmt.Sprintf("contract ID must be 32 bytes, got %d", len(b)))
+	}
+	var id xdr.ContractId
+	copy(id[:], b)
+	return id, nil
+}
+
+// ContractIDFromHex decodes a contract ID given as 64 hex characters.
+func ContractIDFromHex(s string) (xdr.ContractId, error) {
+	b, ok := fixedHex(strings.TrimSpace(s), 32)
+	if !ok {
+		return xdr.ContractId{}, errors.WrapValidationError(fmt.Sprintf("contract ID %q is not 64 hex characters", s))
+	}
+	return ContractIDFromBytes(b)
+}
+
+// ContractAddress returns the C address of a contract ID.
+func ContractAddress(id xdr.ContractId) string {
+	return strkey.MustEncode(strkey.VersionByteContract, id[:])
+}
+
+// ClaimableBalanceID decodes a claimable balance given as a B address or
+// as the hex-encoded ClaimableBalanceId XDR Horizon returns.
+func ClaimableBalanceID(s string) (xdr.ClaimableBalanceId, error) {
+	s = strings.TrimSpace(s)
+	if b, err := hex.DecodeString(s); err == nil {
+		var id xdr.ClaimableBalanceId
+		if err := xdr.SafeUnmarshal(b, &id); err != nil {
+			return xdr.ClaimableBalanceId{}, errors.WrapValidationError(fmt.Sprintf("invalid claimable balance ID %q: %v", s, err))
+		}
+		return id, nil
+	}
+	if _, err := parseKind(s, ClaimableBalance); err != nil {
+		return xdr.ClaimableBalanceId{}, err
+	}
+	var id xdr.ClaimableBalanceId
+	if err := id.DecodeFromStrkey(s); err != nil {
+		return xdr.ClaimableBalanceId{}, errors.WrapValidationError(err.Error())
+	}
+	return id, nil
+}
+
+// ClaimableBalanceAddress returns the B address of a claimable balance.
+func ClaimableBalanceAddress(id xdr.ClaimableBalanceId) (string, error) {
+	s, err := id.EncodeToStrkey()
+	if err != nil {
+		return "", errors.WrapValidationError(err.Error())
+	}
+	return s, nil
+}
+
+// LiquidityPoolID decodes a liquidity pool given as an L address or as 64
+// hex characters.
+func LiquidityPoolID(s string) (xdr.PoolId, error) {
+	var id xdr.PoolId
+	if b, ok := fixedHex(strings.TrimSpace(s), 32); ok {
+		copy(id[:], b)
+		return id, nil
+	}
+	a, err := parseKind(s, LiquidityPool)
+	if err != nil {
+		return xdr.PoolId{}, err
+	}
+	copy(id[:], a.payload)
+	return id, nil
+}
+
+// LiquidityPoolAddress returns the L address of a liquidity pool.
+func LiquidityPoolAddress(id xdr.PoolId) string {
+	return strkey.MustEncode(strkey.VersionByteLiquidityPool, id[:])
+}
+
+// ParseSignedPayload decodes a P address into its signer's G address and
+// payload.
+func ParseSignedPayload(s string) (string, []byte, error) {
+	if _, err := parseKind(s, SignedPayload); err != nil {
+		return "", nil, err
+	}
+	sp, err := strkey.DecodeSignedPayload(strings.TrimSpace(s))
+	if err != nil {
+		return "", nil, errors.WrapValidationError(err.Error())
+	}
+	return sp.Signer(), sp.Payload(), nil
+}
+
+// EncodeSignedPayload returns the P address of a signer and a payload of
+// at most 64 bytes.
+func EncodeSignedPayload(signer string, payload []byte) (string, error) {
+	if err := Validate(signer, Account); err != nil {
+		return "", err
+	}
+	sp, err := strkey.NewSignedPayload(signer, payload)
+	if err != nil {
+		return "", errors.WrapValidationError(err.Error())
+	}
+	s, err := sp.Encode()
+	if err != nil {
+		return "", errors.WrapValidationError(err.Error())
+	}
+	return s, nil
+}
+
+func fixedHex(s string, n int) ([]byte, bool) {
+	if len(s) != 2*n {
+		return nil, false
+	}
+	b, err := hex.DecodeString(s)
+	return b, err == nil
+}
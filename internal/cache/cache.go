@@ -239,22 +239,43 @@ func (m *Manager) Clean(force bool) (*CleanupStatus, error) {
 
 	fmt.Println("\nCleaning cache (Least Recently Used files first)...")
 
-	// Get list of cached files
-	files, err := m.ListCachedFiles()
+	status, err = m.Prune()
 	if err != nil {
 		return nil, err
 	}
 
-	if len(files) == 0 {
-		fmt.Println("No cached files found")
-		status.FinalSize = 0
+	// Print summary
+	fmt.Printf("\nCleanup complete!\n")
+	fmt.Printf("Files deleted: %d\n", status.FilesDeleted)
+	fmt.Printf("Space freed: %s\n", formatBytes(status.SpaceFreed))
+	fmt.Printf("Final cache size: %s\n", formatBytes(status.FinalSize))
+
+	return status, nil
+}
+
+// Prune deletes the least recently used files until the cache is at half
+// its maximum size. Unlike Clean, it neither prints nor asks for
+// confirmation.
+func (m *Manager) Prune() (*CleanupStatus, error) {
+	status := &CleanupStatus{DeletedFiles: []string{}}
+	if _, err := os.Stat(m.cacheDir); os.IsNotExist(err) {
 		return status, nil
 	}
 
+	originalSize, err := m.GetCacheSize()
+	if err != nil {
+		return nil, err
+	}
+	status.OriginalSize = originalSize
+
+	files, err := m.ListCachedFiles()
+	if err != nil {
+		return nil, err
+	}
+
 	// Sort by access time (oldest first)
 	SortFilesByAccessTime(files)
 
-	// Delete oldest files
 	targetSize := m.config.MaxSizeBytes / 2 // Target 50% of max size
 	currentSize := originalSize
 
@@ -278,13 +299,6 @@ func (m *Manager) Clean(force bool) (*CleanupStatus, error) {
 	}
 
 	status.FinalSize = currentSize
-
-	// Print summary
-	fmt.Printf("\nCleanup complete!\n")
-	fmt.Printf("Files deleted: %d\n", status.FilesDeleted)
-	fmt.Printf("Space freed: %s\n", formatBytes(status.SpaceFreed))
-	fmt.Printf("Final cache size: %s\n", formatBytes(status.FinalSize))
-
 	return status, nil
 }
 
@@ -168,6 +168,34 @@ func TestCleanLRUWithinLimit(t *testing.T) {
 	assert.Equal(t, int64(1024), status.FinalSize)
 }
 
+func TestPruneWithinLimit(t *testing.T) {
+	cacheDir := t.TempDir()
+	manager := NewManager(cacheDir, Config{MaxSizeBytes: 100})
+
+	// 60 bytes is within the limit but above the 50 byte target.
+	for i := 1; i <= 3; i++ {
+		path := filepath.Join(cacheDir, fmt.Sprintf("file%d", i))
+		require.NoError(t, os.WriteFile(path, make([]byte, 20), 0644))
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	status, err := manager.Prune()
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, status.FilesDeleted)
+	assert.Equal(t, []string{"file1"}, status.DeletedFiles)
+	assert.Equal(t, int64(60), status.OriginalSize)
+	assert.Equal(t, int64(40), status.FinalSize)
+}
+
+func TestPruneMissingDir(t *testing.T) {
+	manager := NewManager(filepath.Join(t.TempDir(), "missing"), DefaultConfig())
+
+	status, err := manager.Prune()
+	require.NoError(t, err)
+	assert.Equal(t, 0, status.FilesDeleted)
+}
+
 func TestFormatBytes(t *testing.T) {
 	tests := []struct {
 		bytes    int64
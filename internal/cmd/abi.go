@@ -4,6 +4,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 
@@ -36,7 +37,7 @@ func abiExec(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("reading WASM file: %w", err)
 	}
 
-	specBytes, err := abi.ExtractCustomSection(wasmBytes, "contractspecv0")
+	specBytes, err := abi.ExtractCustomSection(wasmBytes, abi.SpecSectionName)
 	if err != nil {
 		return err
 	}
@@ -49,23 +50,22 @@ func abiExec(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	switch abiFormat {
-	case "json":
+	if abiFormat != "text" && abiFormat != "json" {
+		return errors.WrapValidationError(fmt.Sprintf("unsupported format: %s (use: text, json)", abiFormat))
+	}
+	if format := outputFormat(abiFormat == "json"); format != outputTable {
 		output, err := abi.FormatJSON(spec)
 		if err != nil {
 			return err
 		}
-		fmt.Println(output)
-	case "text":
-		fmt.Print(abi.FormatText(spec))
-	default:
-		return errors.WrapValidationError(fmt.Sprintf("unsupported format: %s (use: text, json)", abiFormat))
+		return writeStructured(cmd.OutOrStdout(), format, json.RawMessage(output))
 	}
-
+	fmt.Fprint(cmd.OutOrStdout(), abi.FormatText(spec))
 	return nil
 }
 
 func init() {
 	abiCmd.Flags().StringVar(&abiFormat, "format", "text", "Output format: text or json")
+	supportStructuredOutput(abiCmd)
 	rootCmd.AddCommand(abiCmd)
 }
@@ -0,0 +1,386 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/spf13/cobra"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/base"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/operations"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+)
+
+var (
+	accountNet   networkFlags
+	accountOps   int
+	accountWatch bool
+	accountJSON  bool
+)
+
+var accountCmd = &cobra.Command{
+	Use:   "account <G...|M...>",
+	Short: "Show an account's balances, signers, flags, data and recent operations",
+	Long: `Print everything Horizon knows about an account: balances and trustlines,
+signers and thresholds, authorization flags, sponsorships, data entries and
+its most recent operations.
+
+With --watch the account is fetched again on every new ledger and printed
+whenever it changed, until interrupted.
+
+Examples:
+  erst account GABC... --network testnet
+  erst account GABC... --ops 25 --json
+  erst account GABC... --watch`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAccount,
+}
+
+// accountReport is the output of erst account; its JSON form is stable.
+type accountReport struct {
+	Network            string `json:"network"`
+	ID                 string `json:"id"`
+	Sequence           int64  `json:"sequence"`
+	SubentryCount      int32  `json:"subentry_count"`
+	HomeDomain         string `json:"home_domain,omitempty"`
+	LastModifiedLedger uint32 `json:"last_modified_ledger"`
+
+	Thresholds struct {
+		Low    uint8 `json:"low"`
+		Medium uint8 `json:"medium"`
+		High   uint8 `json:"high"`
+	} `json:"thresholds"`
+	Flags struct {
+		AuthRequired        bool `json:"auth_required"`
+		AuthRevocable       bool `json:"auth_revocable"`
+		AuthImmutable       bool `json:"auth_immutable"`
+		AuthClawbackEnabled bool `json:"auth_clawback_enabled"`
+	} `json:"flags"`
+	Balances []accountBalance `json:"balances"`
+	Signers  []accountSigner  `json:"signers"`
+	Data     []accountData    `json:"data,omitempty"`
+
+	Sponsor       string `json:"sponsor,omitempty"`
+	NumSponsoring uint32 `json:"num_sponsoring"`
+	NumSponsored  uint32 `json:"num_sponsored"`
+
+	RecentOperations []accountOperation `json:"recent_operations"`
+}
+
+type accountBalance struct {
+	// Asset is "native", CODE:ISSUER or pool:ID for liquidity pool shares.
+	Asset              string `json:"asset"`
+	Balance            string `json:"balance"`
+	Limit              string `json:"limit,omitempty"`
+	BuyingLiabilities  string `json:"buying_liabilities,omitempty"`
+	SellingLiabilities string `json:"selling_liabilities,omitempty"`
+	Sponsor            string `json:"sponsor,omitempty"`
+	Authorized         bool   `json:"authorized"`
+}
+
+type accountSigner struct {
+	Key     string `json:"key"`
+	Type    string `json:"type"`
+	Weight  int32  `json:"weight"`
+	Sponsor string `json:"sponsor,omitempty"`
+}
+
+// accountData is a data entry. Value is the entry as text when it is valid
+// UTF-8 and base64 otherwise.
+type accountData struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Base64 bool   `json:"base64,omitempty"`
+}
+
+type accountOperation struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	TxHash     string    `json:"tx_hash"`
+	At         time.Time `json:"at"`
+	Successful bool      `json:"successful"`
+	Summary    string    `json:"summary,omitempty"`
+}
+
+func runAccount(cmd *cobra.Command, args []string) error {
+	id := args[0]
+	if _, err := rpc.ParseMuxedAddress(id); err != nil {
+		return err
+	}
+	client, err := accountNet.client(cmd)
+	if err != nil {
+		return err
+	}
+	if !accountWatch {
+		report, err := accountSnapshot(cmd.Context(), client, id)
+		if err != nil {
+			return err
+		}
+		return writeAccountReport(cmd.OutOrStdout(), report)
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+	return watchAccountLedgers(ctx, client, id, cmd.OutOrStdout())
+}
+
+// accountSnapshot fetches the account and its recent operations.
+func accountSnapshot(ctx context.Context, client *rpc.Client, id string) (*accountReport, error) {
+	details, err := client.AccountDetails(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r := &accountReport{
+		Network:            client.GetNetworkName(),
+		ID:                 details.ID,
+		Sequence:           details.Sequence,
+		SubentryCount:      details.SubentryCount,
+		HomeDomain:         details.HomeDomain,
+		LastModifiedLedger: details.LastModifiedLedger,
+		Sponsor:            details.Sponsor,
+		NumSponsoring:      details.NumSponsoring,
+		NumSponsored:       details.NumSponsored,
+		Balances:           []accountBalance{},
+		Signers:            []accountSigner{},
+		RecentOperations:   []accountOperation{},
+	}
+	r.Thresholds.Low, r.Thresholds.Medium, r.Thresholds.High = details.Thresholds.Low, details.Thresholds.Medium, details.Thresholds.High
+	f := details.Flags
+	r.Flags.AuthRequired, r.Flags.AuthRevocable = f.AuthRequired, f.AuthRevocable
+	r.Flags.AuthImmutable, r.Flags.AuthClawbackEnabled = f.AuthImmutable, f.AuthClawbackEnabled
+	for _, s := range details.Signers {
+		r.Signers = append(r.Signers, accountSigner{Key: s.Key, Type: s.Type, Weight: s.Weight, Sponsor: s.Sponsor})
+	}
+	for _, b := range details.Balances {
+		asset := "pool:" + b.LiquidityPoolID
+		if b.Asset != nil {
+			asset = formatAsset(b.Asset)
+		}
+		r.Balances = append(r.Balances, accountBalance{
+			Asset:              asset,
+			Balance:            b.Balance,
+			Limit:              b.Limit,
+			BuyingLiabilities:  nonZeroAmount(b.BuyingLiabilities),
+			SellingLiabilities: nonZeroAmount(b.SellingLiabilities),
+			Sponsor:            b.Sponsor,
+			Authorized:         b.Authorized,
+		})
+	}
+	for _, name := range details.DataKeys() {
+		if s, ok := details.DataString(name); ok {
+			r.Data = append(r.Data, accountData{Name: name, Value: s})
+		} else {
+			r.Data = append(r.Data, accountData{Name: name, Value: base64.StdEncoding.EncodeToString(details.Data[name]), Base64: true})
+		}
+	}
+
+	if accountOps > 0 {
+		ops, err := client.Operations(ctx, horizonclient.OperationRequest{
+			ForAccount: details.ID,
+			Order:      horizonclient.OrderDesc,
+		}, rpc.PageSize(accountOps), rpc.MaxRecords(accountOps)).Collect()
+		if err != nil {
+			return nil, err
+		}
+		for _, op := range ops {
+			b := op.GetBase()
+			r.RecentOperations = append(r.RecentOperations, accountOperation{
+				ID:         b.ID,
+				Type:       b.Type,
+				TxHash:     b.TransactionHash,
+				At:         b.LedgerCloseTime,
+				Successful: b.TransactionSuccessful,
+				Summary:    summarizeOperation(op),
+			})
+		}
+	}
+	return r, nil
+}
+
+// watchAccountLedgers prints the account, then again whenever a new ledger
+// modified it.
+func watchAccountLedgers(ctx context.Context, client *rpc.Client, id string, w io.Writer) error {
+	ledgers := make(chan rpc.LedgerInfo, 1)
+	unsubscribe := client.OnLedger(func(l rpc.LedgerInfo) {
+		select {
+		case ledgers <- l:
+		default:
+		}
+	})
+	defer unsubscribe()
+
+	tickerErr := make(chan error, 1)
+	go func() {
+		tickerErr <- client.RunLedgerTicker(ctx, rpc.LedgerTickerConfig{Source: rpc.LedgerSourceHorizon})
+	}()
+
+	var last uint32
+	refresh := func() error {
+		report, err := accountSnapshot(ctx, client, id)
+		if err != nil {
+			return err
+		}
+		if report.LastModifiedLedger == last {
+			return nil
+		}
+		last = report.LastModifiedLedger
+		return writeAccountReport(w, report)
+	}
+	if err := refresh(); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-tickerErr:
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		case <-ledgers:
+			if err := refresh(); err != nil && ctx.Err() == nil {
+				return err
+			}
+		}
+	}
+}
+
+func writeAccountReport(w io.Writer, r *accountReport) error {
+	if format := outputFormat(accountJSON); format != outputTable {
+		return writeRecord(w, format, r)
+	}
+	fmt.Fprintf(w, "Account %s on %s\n", r.ID, r.Network)
+	fmt.Fprintf(w, "  Sequence: %d, subentries: %d, last modified in ledger %d\n", r.Sequence, r.SubentryCount, r.LastModifiedLedger)
+	if r.HomeDomain != "" {
+		fmt.Fprintf(w, "  Home domain: %s\n", r.HomeDomain)
+	}
+
+	fmt.Fprintf(w, "\nBalances (%d):\n", len(r.Balances))
+	for _, b := range r.Balances {
+		fmt.Fprintf(w, "  %-20s %s", b.Balance, b.Asset)
+		if b.Limit != "" {
+			fmt.Fprintf(w, " (limit %s)", b.Limit)
+		}
+		if !b.Authorized {
+			fmt.Fprint(w, " [not authorized]")
+		}
+		if b.BuyingLiabilities != "" || b.SellingLiabilities != "" {
+			fmt.Fprintf(w, " liabilities buying=%s selling=%s", or0(b.BuyingLiabilities), or0(b.SellingLiabilities))
+		}
+		if b.Sponsor != "" {
+			fmt.Fprintf(w, " sponsored by %s", b.Sponsor)
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintf(w, "\nSigners (thresholds low=%d med=%d high=%d):\n", r.Thresholds.Low, r.Thresholds.Medium, r.Thresholds.High)
+	for _, s := range r.Signers {
+		fmt.Fprintf(w, "  %s weight=%d (%s)", s.Key, s.Weight, s.Type)
+		if s.Sponsor != "" {
+			fmt.Fprintf(w, " sponsored by %s", s.Sponsor)
+		}
+		fmt.Fprintln(w)
+	}
+
+	f := r.Flags
+	fmt.Fprintf(w, "\nFlags: auth_required=%t auth_revocable=%t auth_immutable=%t auth_clawback_enabled=%t\n",
+		f.AuthRequired, f.AuthRevocable, f.AuthImmutable, f.AuthClawbackEnabled)
+	fmt.Fprintf(w, "Sponsorships: sponsoring %d, sponsored %d", r.NumSponsoring, r.NumSponsored)
+	if r.Sponsor != "" {
+		fmt.Fprintf(w, ", account sponsored by %s", r.Sponsor)
+	}
+	fmt.Fprintln(w)
+
+	if len(r.Data) > 0 {
+		fmt.Fprintf(w, "\nData entries (%d):\n", len(r.Data))
+		for _, d := range r.Data {
+			if d.Base64 {
+				fmt.Fprintf(w, "  %s = %s (base64)\n", d.Name, d.Value)
+			} else {
+				fmt.Fprintf(w, "  %s = %q\n", d.Name, d.Value)
+			}
+		}
+	}
+
+	if len(r.RecentOperations) > 0 {
+		fmt.Fprintf(w, "\nRecent operations (%d):\n", len(r.RecentOperations))
+		for _, op := range r.RecentOperations {
+			status := ""
+			if !op.Successful {
+				status = " [failed]"
+			}
+			fmt.Fprintf(w, "  %s  %-24s %s%s\n", op.At.UTC().Format(time.RFC3339), op.Type, op.Summary, status)
+		}
+	}
+	return nil
+}
+
+// summarizeOperation describes the operations whose effect on balances or
+// trust is worth a glance; others are identified by their type alone.
+func summarizeOperation(op operations.Operation) string {
+	switch o := op.(type) {
+	case operations.Payment:
+		return fmt.Sprintf("%s %s %s -> %s", o.Amount, baseAsset(o.Asset), o.From, o.To)
+	case operations.PathPayment:
+		return fmt.Sprintf("%s %s %s -> %s", o.Amount, baseAsset(o.Asset), o.From, o.To)
+	case operations.PathPaymentStrictSend:
+		return fmt.Sprintf("%s %s %s -> %s", o.Amount, baseAsset(o.Asset), o.From, o.To)
+	case operations.CreateAccount:
+		return fmt.Sprintf("%s XLM %s -> %s", o.StartingBalance, o.Funder, o.Account)
+	case operations.ChangeTrust:
+		return fmt.Sprintf("%s limit %s", baseAsset(o.Asset), o.Limit)
+	case operations.AccountMerge:
+		return fmt.Sprintf("%s -> %s", o.Account, o.Into)
+	case operations.ManageData:
+		return o.Name
+	}
+	return ""
+}
+
+func formatAsset(a txnbuild.Asset) string {
+	if a.IsNative() {
+		return "native"
+	}
+	return a.GetCode() + ":" + a.GetIssuer()
+}
+
+func baseAsset(a base.Asset) string {
+	if a.Type == "native" {
+		return "XLM"
+	}
+	return a.Code + ":" + a.Issuer
+}
+
+func nonZeroAmount(s string) string {
+	if s == "0.0000000" {
+		return ""
+	}
+	return s
+}
+
+func or0(s string) string {
+	if s == "" {
+		return "0"
+	}
+	return s
+}
+
+func init() {
+	accountNet.register(accountCmd)
+	accountCmd.Flags().IntVar(&accountOps, "ops", 10, "Number of recent operations to show (0 to skip)")
+	accountCmd.Flags().BoolVarP(&accountWatch, "watch", "w", false, "Print the account again whenever a new ledger changes it")
+	accountCmd.Flags().BoolVar(&accountJSON, "json", false, "Print the report as JSON")
+
+	supportStructuredOutput(accountCmd)
+	rootCmd.AddCommand(accountCmd)
+}
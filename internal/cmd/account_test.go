@@ -0,0 +1,118 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/rpc/rpctest"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/base"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/operations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func accountFixture(t *testing.T) (string, *rpctest.HorizonServer) {
+	t.Helper()
+	id := keypair.MustRandom().Address()
+	issuer := keypair.MustRandom().Address()
+
+	horizon := rpctest.NewHorizonServer()
+	t.Cleanup(horizon.Close)
+	horizon.AddAccount(hProtocol.Account{
+		AccountID:          id,
+		Sequence:           7,
+		HomeDomain:         "example.org",
+		Thresholds:         hProtocol.AccountThresholds{LowThreshold: 1, MedThreshold: 2, HighThreshold: 3},
+		Flags:              hProtocol.AccountFlags{AuthRequired: true},
+		NumSponsoring:      2,
+		LastModifiedLedger: 42,
+		Balances: []hProtocol.Balance{
+			{Balance: "100.0000000", Asset: base.Asset{Type: "native"}},
+			{Balance: "5.0000000", Limit: "1000.0000000", IsAuthorized: boolPtr(true),
+				Asset: base.Asset{Type: "credit_alphanum4", Code: "USDC", Issuer: issuer}},
+		},
+		Signers: []hProtocol.Signer{{Key: id, Type: "ed25519_public_key", Weight: 1}},
+		Data:    map[string]string{"note": "aGVsbG8=", "blob": "/w=="},
+	})
+	for i := 0; i < 3; i++ {
+		op := operations.Payment{From: id, To: issuer, Amount: "1.0000000", Asset: base.Asset{Type: "native"}}
+		op.Base.ID, op.Base.Type, op.Base.TypeI, op.Base.TransactionSuccessful = fmt.Sprint(i), "payment", 1, true
+		horizon.AddOperation(id, op)
+	}
+	return id, horizon
+}
+
+func boolPtr(b bool) *bool { return &b }
+
+func runAccountWith(t *testing.T, horizonURL, id string, ops int) (accountReport, error) {
+	t.Helper()
+	accountNet = networkFlags{network: "testnet", horizonURL: horizonURL}
+	accountOps, accountJSON = ops, true
+	defer func() { accountNet, accountOps, accountJSON = networkFlags{}, 10, false }()
+
+	cmd, out := testCommand("")
+	err := runAccount(cmd, []string{id})
+	var report accountReport
+	if out.Len() > 0 {
+		require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	}
+	return report, err
+}
+
+func TestAccountReport(t *testing.T) {
+	id, horizon := accountFixture(t)
+
+	report, err := runAccountWith(t, horizon.URL, id, 2)
+	require.NoError(t, err)
+	assert.Equal(t, id, report.ID)
+	assert.Equal(t, int64(7), report.Sequence)
+	assert.Equal(t, "example.org", report.HomeDomain)
+	assert.Equal(t, uint8(2), report.Thresholds.Medium)
+	assert.True(t, report.Flags.AuthRequired)
+	assert.Equal(t, uint32(2), report.NumSponsoring)
+
+	require.Len(t, report.Balances, 2)
+	assert.Equal(t, "native", report.Balances[0].Asset)
+	assert.Contains(t, report.Balances[1].Asset, "USDC:G")
+	assert.Equal(t, "1000.0000000", report.Balances[1].Limit)
+
+	require.Len(t, report.Signers, 1)
+	assert.Equal(t, int32(1), report.Signers[0].Weight)
+
+	assert.Equal(t, []accountData{
+		{Name: "blob", Value: "/w==", Base64: true},
+		{Name: "note", Value: "hello"},
+	}, report.Data)
+
+	require.Len(t, report.RecentOperations, 2)
+	assert.Equal(t, "2", report.RecentOperations[0].ID)
+	assert.Equal(t, "payment", report.RecentOperations[0].Type)
+	assert.Contains(t, report.RecentOperations[0].Summary, "1.0000000 XLM")
+}
+
+func TestAccountTextOutput(t *testing.T) {
+	id, horizon := accountFixture(t)
+	accountNet = networkFlags{network: "testnet", horizonURL: horizon.URL}
+	defer func() { accountNet = networkFlags{} }()
+
+	cmd, out := testCommand("")
+	require.NoError(t, runAccount(cmd, []string{id}))
+	assert.Contains(t, out.String(), "Signers (thresholds low=1 med=2 high=3)")
+	assert.Contains(t, out.String(), `note = "hello"`)
+	assert.Contains(t, out.String(), "Recent operations (3)")
+}
+
+func TestAccountNotFound(t *testing.T) {
+	_, horizon := accountFixture(t)
+
+	_, err := runAccountWith(t, horizon.URL, keypair.MustRandom().Address(), 0)
+	assert.Error(t, err)
+	_, err = runAccountWith(t, horizon.URL, "not-an-account", 0)
+	assert.Error(t, err)
+}
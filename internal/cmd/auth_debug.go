@@ -4,6 +4,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 
@@ -83,8 +84,6 @@ Examples:
 			return errors.WrapRPCConnectionFailed(err)
 		}
 
-		fmt.Printf("Transaction Envelope: %d bytes\n", len(resp.EnvelopeXdr))
-
 		config := authtrace.AuthTraceConfig{
 			TraceCustomContracts: true,
 			CaptureSigDetails:    true,
@@ -95,19 +94,19 @@ Examples:
 		trace := tracker.GenerateTrace()
 		reporter := authtrace.NewDetailedReporter(trace)
 
-		if authJSONOutputFlag {
+		if format := outputFormat(authJSONOutputFlag); format != outputTable {
 			jsonStr, err := reporter.GenerateJSONString()
 			if err != nil {
 				return err
 			}
-			fmt.Println(jsonStr)
-		} else {
-			fmt.Println(reporter.GenerateReport())
-			if authDetailedFlag {
-				printDetailedAnalysis(reporter)
-			}
+			return writeStructured(cmd.OutOrStdout(), format, json.RawMessage(jsonStr))
 		}
 
+		fmt.Printf("Transaction Envelope: %d bytes\n", len(resp.EnvelopeXdr))
+		fmt.Println(reporter.GenerateReport())
+		if authDetailedFlag {
+			printDetailedAnalysis(reporter)
+		}
 		return nil
 	},
 }
@@ -135,5 +134,6 @@ func init() {
 	authDebugCmd.Flags().StringVar(&authRPCHeadersFlag, "rpc-headers", "", "Additional headers to include on RPC requests (JSON or key=value list)")
 	authDebugCmd.Flags().BoolVar(&authDetailedFlag, "detailed", false, "Show detailed analysis and missing signatures")
 	authDebugCmd.Flags().BoolVar(&authJSONOutputFlag, "json", false, "Output as JSON")
+	supportStructuredOutput(authDebugCmd)
 	rootCmd.AddCommand(authDebugCmd)
 }
@@ -0,0 +1,287 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/spf13/cobra"
+)
+
+var (
+	benchNet         networkFlags
+	benchEndpoints   []string
+	benchMethod      string
+	benchDuration    time.Duration
+	benchConcurrency int
+)
+
+// benchMethods are the Soroban RPC methods bench can call; none of them
+// takes params.
+var benchMethods = []string{"getLatestLedger", "getHealth", "getNetwork", "getFeeStats", "getVersionInfo"}
+
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Compare the latency, error rate and rate limits of Soroban RPC endpoints",
+	Long: `Call a Soroban RPC method on each endpoint for --duration and compare
+them. All endpoints are measured at the same time, each with --concurrency
+requests in flight, and every request is sent once, without retries or
+failover.
+
+For each endpoint the table shows the request rate, the share of failed
+requests, how many were rate limited (HTTP 429) and the latency
+distribution of the successful ones. Endpoints are ranked by error rate,
+then by p90 latency; the ranking is the suggested order for fallback URLs.
+
+Without --endpoints, the network's Soroban RPC URL is measured. The token
+and headers of the network settings are sent to every endpoint.
+
+Examples:
+  erst bench --endpoints https://rpc-a.example.com,https://rpc-b.example.com
+  erst bench --endpoints a,b,c --method getLatestLedger --duration 30s
+  erst bench --endpoints a,b --concurrency 4 --output json`,
+	Args: cobra.NoArgs,
+	RunE: runBench,
+}
+
+// benchReport is the output of erst bench; its JSON form is stable.
+type benchReport struct {
+	Method      string        `json:"method"`
+	Duration    string        `json:"duration"`
+	Concurrency int           `json:"concurrency"`
+	Results     []benchResult `json:"results"`
+}
+
+// benchResult is the measurement of one endpoint. Latencies are in
+// milliseconds and cover successful requests only.
+type benchResult struct {
+	Endpoint    string  `json:"endpoint"`
+	Requests    int     `json:"requests"`
+	Errors      int     `json:"errors"`
+	ErrorRate   float64 `json:"error_rate"`
+	RateLimited int     `json:"rate_limited"`
+	// FirstRateLimited is the number of the first request that was rate
+	// limited, counting from 1; zero when none was.
+	FirstRateLimited  int          `json:"first_rate_limited,omitempty"`
+	RequestsPerSecond float64      `json:"requests_per_second"`
+	Latency           benchLatency `json:"latency_ms"`
+	LastError         string       `json:"last_error,omitempty"`
+}
+
+type benchLatency struct {
+	Min  float64 `json:"min"`
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+	P99  float64 `json:"p99"`
+	Max  float64 `json:"max"`
+}
+
+func runBench(cmd *cobra.Command, _ []string) error {
+	if !slices.Contains(benchMethods, benchMethod) {
+		return errors.WrapValidationError(fmt.Sprintf("unsupported --method: %s (use: %s)", benchMethod, strings.Join(benchMethods, ", ")))
+	}
+	if benchDuration <= 0 {
+		return errors.WrapValidationError("--duration must be positive")
+	}
+	if benchConcurrency < 1 {
+		return errors.WrapValidationError("--concurrency must be at least 1")
+	}
+	for _, endpoint := range benchEndpoints {
+		if u, err := url.Parse(endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.WrapValidationError(fmt.Sprintf("invalid endpoint URL: %s", endpoint))
+		}
+	}
+	client, err := benchNet.client(cmd)
+	if err != nil {
+		return err
+	}
+	endpoints := benchEndpoints
+	if len(endpoints) == 0 {
+		if client.SorobanURL == "" {
+			return errors.WrapCliArgumentRequired("endpoints")
+		}
+		endpoints = []string{client.SorobanURL}
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+	report := bench(ctx, client, endpoints, benchMethod, benchDuration, benchConcurrency)
+	return writeBenchReport(cmd.OutOrStdout(), report)
+}
+
+// benchSample is the outcome of one request.
+type benchSample struct {
+	latency     time.Duration
+	rateLimited bool
+	err         error
+}
+
+// bench calls method on every endpoint for duration, or until ctx is
+// cancelled, and returns the endpoints ranked best first.
+func bench(ctx context.Context, client *rpc.Client, endpoints []string, method string, duration time.Duration, concurrency int) *benchReport {
+	ctx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	start := time.Now()
+	samples := make([][]benchSample, len(endpoints))
+	var wg sync.WaitGroup
+	for i, endpoint := range endpoints {
+		var mu sync.Mutex
+		for range concurrency {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for ctx.Err() == nil {
+					status, latency, err := client.ProbeSoroban(ctx, endpoint, method)
+					if ctx.Err() != nil {
+						// Requests cut short by the end of the run are not counted.
+						return
+					}
+					mu.Lock()
+					samples[i] = append(samples[i], benchSample{latency: latency, rateLimited: status == http.StatusTooManyRequests, err: err})
+					mu.Unlock()
+				}
+			}()
+		}
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	report := &benchReport{Method: method, Duration: elapsed.Round(time.Millisecond).String(), Concurrency: concurrency}
+	for i, endpoint := range endpoints {
+		report.Results = append(report.Results, benchSummary(endpoint, samples[i], elapsed))
+	}
+	rankBenchResults(report.Results)
+	return report
+}
+
+func benchSummary(endpoint string, samples []benchSample, elapsed time.Duration) benchResult {
+	r := benchResult{Endpoint: endpoint, Requests: len(samples)}
+	var latencies []time.Duration
+	for n, s := range samples {
+		switch {
+		case s.rateLimited:
+			r.RateLimited++
+			if r.FirstRateLimited == 0 {
+				r.FirstRateLimited = n + 1
+			}
+			fallthrough
+		case s.err != nil:
+			r.Errors++
+			r.LastError = s.err.Error()
+		default:
+			latencies = append(latencies, s.latency)
+		}
+	}
+	if r.Requests > 0 {
+		r.ErrorRate = float64(r.Errors) / float64(r.Requests)
+	}
+	if elapsed > 0 {
+		r.RequestsPerSecond = float64(r.Requests) / elapsed.Seconds()
+	}
+	if len(latencies) == 0 {
+		return r
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	var total time.Duration
+	for _, l := range latencies {
+		total += l
+	}
+	r.Latency = benchLatency{
+		Min:  millis(latencies[0]),
+		Mean: millis(total / time.Duration(len(latencies))),
+		P50:  millis(latencyPercentile(latencies, 50)),
+		P90:  millis(latencyPercentile(latencies, 90)),
+		P99:  millis(latencyPercentile(latencies, 99)),
+		Max:  millis(latencies[len(latencies)-1]),
+	}
+	return r
+}
+
+// latencyPercentile returns the nearest-rank percentile of sorted.
+func latencyPercentile(sorted []time.Duration, p int) time.Duration {
+	rank := int(math.Ceil(float64(p) / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+func millis(d time.Duration) float64 {
+	return math.Round(float64(d)/float64(time.Millisecond)*10) / 10
+}
+
+// rankBenchResults orders results by error rate, then by p90 latency.
+// Endpoints that never answered successfully come last.
+func rankBenchResults(results []benchResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if okA, okB := a.Errors < a.Requests, b.Errors < b.Requests; okA != okB {
+			return okA
+		}
+		if a.ErrorRate != b.ErrorRate {
+			return a.ErrorRate < b.ErrorRate
+		}
+		return a.Latency.P90 < b.Latency.P90
+	})
+}
+
+func writeBenchReport(w io.Writer, r *benchReport) error {
+	if format := outputFormat(false); format != outputTable {
+		return writeStructured(w, format, r)
+	}
+	fmt.Fprintf(w, "%s for %s, %d in flight per endpoint\n\n", r.Method, r.Duration, r.Concurrency)
+	fmt.Fprintf(w, "   %-40s %7s %7s %7s %5s %8s %8s %8s %8s\n", "endpoint", "reqs", "req/s", "errors", "429s", "p50 ms", "p90 ms", "p99 ms", "max ms")
+	for i, res := range r.Results {
+		fmt.Fprintf(w, "%d. %-40s %7d %7.1f %6.1f%% %5d %8.1f %8.1f %8.1f %8.1f\n", i+1, res.Endpoint, res.Requests,
+			res.RequestsPerSecond, res.ErrorRate*100, res.RateLimited, res.Latency.P50, res.Latency.P90, res.Latency.P99, res.Latency.Max)
+	}
+
+	var notes []string
+	for _, res := range r.Results {
+		if res.FirstRateLimited > 0 {
+			notes = append(notes, fmt.Sprintf("  %s: rate limited from request %d on", res.Endpoint, res.FirstRateLimited))
+		}
+		if res.Requests > 0 && res.Errors == res.Requests {
+			notes = append(notes, fmt.Sprintf("  %s: every request failed, last with: %s", res.Endpoint, res.LastError))
+		}
+	}
+	if len(notes) > 0 {
+		fmt.Fprintf(w, "\n%s\n", strings.Join(notes, "\n"))
+	}
+
+	if len(r.Results) > 1 {
+		order := make([]string, len(r.Results))
+		for i, res := range r.Results {
+			order[i] = res.Endpoint
+		}
+		fmt.Fprintf(w, "\nSuggested order: %s\n", strings.Join(order, ","))
+	}
+	return nil
+}
+
+func init() {
+	benchNet.register(benchCmd)
+	benchCmd.Flags().StringSliceVar(&benchEndpoints, "endpoints", nil, "Soroban RPC URLs to compare, comma-separated (default: the network's Soroban RPC URL)")
+	benchCmd.Flags().StringVar(&benchMethod, "method", "getLatestLedger", "Method to call: "+strings.Join(benchMethods, ", "))
+	benchCmd.Flags().DurationVar(&benchDuration, "duration", 30*time.Second, "How long to measure")
+	benchCmd.Flags().IntVar(&benchConcurrency, "concurrency", 1, "Requests in flight per endpoint")
+	_ = benchCmd.RegisterFlagCompletionFunc("method", cobra.FixedCompletions(benchMethods, cobra.ShellCompDirectiveNoFileComp))
+
+	supportStructuredOutput(benchCmd)
+	rootCmd.AddCommand(benchCmd)
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// benchServer answers getLatestLedger, rate limiting every request after
+// the first limit ones when limit is positive.
+func benchServer(t *testing.T, limit int32) *httptest.Server {
+	t.Helper()
+	var served atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if limit > 0 && served.Add(1) > limit {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"sequence":1000}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestBench(t *testing.T) {
+	limited := benchServer(t, 3)
+	healthy := benchServer(t, 0)
+	client, err := rpc.NewClient(rpc.WithNetwork(rpc.Testnet))
+	require.NoError(t, err)
+
+	report := bench(context.Background(), client, []string{limited.URL, healthy.URL}, "getLatestLedger", 200*time.Millisecond, 1)
+	require.Len(t, report.Results, 2)
+
+	best, worst := report.Results[0], report.Results[1]
+	assert.Equal(t, healthy.URL, best.Endpoint)
+	assert.Positive(t, best.Requests)
+	assert.Zero(t, best.Errors)
+	assert.Positive(t, best.Latency.Max)
+	assert.LessOrEqual(t, best.Latency.P50, best.Latency.P99)
+
+	assert.Equal(t, limited.URL, worst.Endpoint)
+	assert.Equal(t, 4, worst.FirstRateLimited)
+	assert.Equal(t, worst.Requests-3, worst.RateLimited)
+	assert.Equal(t, worst.RateLimited, worst.Errors)
+	assert.Contains(t, worst.LastError, "rate limit")
+
+	out := &bytes.Buffer{}
+	require.NoError(t, writeBenchReport(out, report))
+	assert.Contains(t, out.String(), "rate limited from request 4 on")
+	assert.Contains(t, out.String(), "Suggested order: "+healthy.URL+","+limited.URL+"\n")
+}
+
+func TestBenchSummary(t *testing.T) {
+	var samples []benchSample
+	for i := 1; i <= 100; i++ {
+		samples = append(samples, benchSample{latency: time.Duration(i) * time.Millisecond})
+	}
+	samples = append(samples, benchSample{err: assert.AnError})
+
+	r := benchSummary("a", samples, 2*time.Second)
+	assert.Equal(t, 101, r.Requests)
+	assert.Equal(t, 1, r.Errors)
+	assert.InDelta(t, 50.5, r.RequestsPerSecond, 0.01)
+	assert.Equal(t, benchLatency{Min: 1, Mean: 50.5, P50: 50, P90: 90, P99: 99, Max: 100}, r.Latency)
+
+	results := []benchResult{
+		{Endpoint: "down", Requests: 5, Errors: 5, ErrorRate: 1},
+		{Endpoint: "slow", Requests: 5, Latency: benchLatency{P90: 80}},
+		{Endpoint: "flaky", Requests: 5, Errors: 1, ErrorRate: 0.2, Latency: benchLatency{P90: 10}},
+		{Endpoint: "fast", Requests: 5, Latency: benchLatency{P90: 20}},
+	}
+	rankBenchResults(results)
+	var order []string
+	for _, r := range results {
+		order = append(order, r.Endpoint)
+	}
+	assert.Equal(t, []string{"fast", "slow", "flaky", "down"}, order)
+}
+
+func TestBenchRejectsBadFlags(t *testing.T) {
+	defer func() {
+		benchMethod, benchDuration, benchConcurrency, benchEndpoints = "getLatestLedger", 30*time.Second, 1, nil
+	}()
+	cmd, _ := testCommand("")
+
+	benchMethod = "simulateTransaction"
+	assert.ErrorContains(t, runBench(cmd, nil), "unsupported --method")
+
+	benchMethod, benchDuration = "getLatestLedger", 0
+	assert.ErrorContains(t, runBench(cmd, nil), "--duration")
+
+	benchDuration, benchConcurrency = time.Second, 0
+	assert.ErrorContains(t, runBench(cmd, nil), "--concurrency")
+
+	benchConcurrency, benchEndpoints = 1, []string{"rpc.example.com"}
+	assert.ErrorContains(t, runBench(cmd, nil), "invalid endpoint URL")
+}
@@ -26,6 +26,34 @@ func getCacheDir() string {
 	return filepath.Join(homeDir, ".erst", "cache")
 }
 
+// cacheStatusReport is the output of erst cache status; its JSON form is
+// stable.
+type cacheStatusReport struct {
+	Directory    string `json:"directory"`
+	SizeBytes    int64  `json:"size_bytes"`
+	Files        int    `json:"files"`
+	MaxSizeBytes int64  `json:"max_size_bytes"`
+	OverLimit    bool   `json:"over_limit"`
+}
+
+// cacheCleanReport is the output of erst cache clean; its JSON form is
+// stable.
+type cacheCleanReport struct {
+	Directory     string   `json:"directory"`
+	FilesDeleted  int      `json:"files_deleted"`
+	BytesFreed    int64    `json:"bytes_freed"`
+	OriginalBytes int64    `json:"original_bytes"`
+	FinalBytes    int64    `json:"final_bytes"`
+	DeletedFiles  []string `json:"deleted_files"`
+}
+
+// cacheClearReport is the output of erst cache clear; its JSON form is
+// stable. Cleared is false when there was no cache to clear.
+type cacheClearReport struct {
+	Directory string `json:"directory"`
+	Cleared   bool   `json:"cleared"`
+}
+
 var cacheCmd = &cobra.Command{
 	Use:   "cache",
 	Short: "Manage transaction and simulation cache",
@@ -74,13 +102,26 @@ var cacheStatusCmd = &cobra.Command{
 			return errors.WrapValidationError(fmt.Sprintf("failed to list cache files: %v", err))
 		}
 
-		fmt.Printf("Cache directory: %s\n", cacheDir)
-		fmt.Printf("Cache size: %s\n", formatBytes(size))
-		fmt.Printf("Files cached: %d\n", len(files))
-		fmt.Printf("Maximum size: %s\n", formatBytes(cache.DefaultConfig().MaxSizeBytes))
+		report := cacheStatusReport{
+			Directory:    cacheDir,
+			SizeBytes:    size,
+			Files:        len(files),
+			MaxSizeBytes: cache.DefaultConfig().MaxSizeBytes,
+		}
+		report.OverLimit = report.SizeBytes > report.MaxSizeBytes
+
+		w := cmd.OutOrStdout()
+		if format := outputFormat(false); format != outputTable {
+			return writeStructured(w, format, report)
+		}
+
+		fmt.Fprintf(w, "Cache directory: %s\n", cacheDir)
+		fmt.Fprintf(w, "Cache size: %s\n", formatBytes(size))
+		fmt.Fprintf(w, "Files cached: %d\n", len(files))
+		fmt.Fprintf(w, "Maximum size: %s\n", formatBytes(report.MaxSizeBytes))
 
-		if size > cache.DefaultConfig().MaxSizeBytes {
-			fmt.Printf("\n[!]  Cache size exceeds maximum limit. Run 'erst cache clean' to free space.\n")
+		if report.OverLimit {
+			fmt.Fprintf(w, "\n[!]  Cache size exceeds maximum limit. Run 'erst cache clean' to free space.\n")
 		}
 
 		return nil
@@ -108,6 +149,24 @@ Use --force to skip the confirmation prompt.`,
 		cacheDir := getCacheDir()
 		manager := cache.NewManager(cacheDir, cache.DefaultConfig())
 
+		if format := outputFormat(false); format != outputTable {
+			if err := requireForce(format, cacheForceFlag); err != nil {
+				return err
+			}
+			status, err := manager.Prune()
+			if err != nil {
+				return errors.WrapValidationError(fmt.Sprintf("cache cleanup failed: %v", err))
+			}
+			return writeStructured(cmd.OutOrStdout(), format, cacheCleanReport{
+				Directory:     cacheDir,
+				FilesDeleted:  status.FilesDeleted,
+				BytesFreed:    status.SpaceFreed,
+				OriginalBytes: status.OriginalSize,
+				FinalBytes:    status.FinalSize,
+				DeletedFiles:  status.DeletedFiles,
+			})
+		}
+
 		status, err := manager.Clean(cacheForceFlag)
 		if err != nil {
 			return errors.WrapValidationError(fmt.Sprintf("cache cleanup failed: %v", err))
@@ -135,9 +194,16 @@ var cacheClearCmd = &cobra.Command{
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cacheDir := getCacheDir()
+		format := outputFormat(false)
+		if err := requireForce(format, cacheForceFlag); err != nil {
+			return err
+		}
 
 		// Check if cache exists
 		if _, err := os.Stat(cacheDir); os.IsNotExist(err) {
+			if format != outputTable {
+				return writeStructured(cmd.OutOrStdout(), format, cacheClearReport{Directory: cacheDir})
+			}
 			fmt.Println("Cache directory does not exist")
 			return nil
 		}
@@ -161,6 +227,10 @@ var cacheClearCmd = &cobra.Command{
 			return errors.WrapValidationError(fmt.Sprintf("failed to clear cache directory: %v", err))
 		}
 
+		if format != outputTable {
+			return writeStructured(cmd.OutOrStdout(), format, cacheClearReport{Directory: cacheDir, Cleared: true})
+		}
+
 		fmt.Println("Cache cleared successfully")
 		return nil
 	},
@@ -193,6 +263,8 @@ func init() {
 	cacheCleanCmd.Flags().BoolVarP(&cacheForceFlag, "force", "f", false, "Skip confirmation prompt")
 	cacheClearCmd.Flags().BoolVarP(&cacheForceFlag, "force", "f", false, "Skip confirmation prompt")
 
+	supportStructuredOutput(cacheStatusCmd, cacheCleanCmd, cacheClearCmd)
+
 	// Add cache command to root
 	rootCmd.AddCommand(cacheCmd)
 }
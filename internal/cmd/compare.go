@@ -107,6 +107,7 @@ func init() {
 	compareCmd.Flags().Uint32Var(&cmpProtoFlag, "protocol-version", 0,
 		"Override protocol version for both simulation passes (20, 21, 22, …)")
 
+	supportStructuredOutput(compareCmd)
 	rootCmd.AddCommand(compareCmd)
 }
 
@@ -130,11 +131,14 @@ func runCompare(cmd *cobra.Command, cmdArgs []string) error {
 		visualizer.SetTheme(visualizer.DetectTheme())
 	}
 
-	fmt.Printf("%s  Compare Replay\n", visualizer.Symbol("chart"))
-	fmt.Printf("Transaction : %s\n", txHash)
-	fmt.Printf("Network     : %s\n", cmpNetworkFlag)
-	fmt.Printf("Local WASM  : %s\n", cmpLocalWasmFlag)
-	fmt.Println()
+	format := outputFormat(false)
+	progress := progressWriter(cmd, format)
+
+	fmt.Fprintf(progress, "%s  Compare Replay\n", visualizer.Symbol("chart"))
+	fmt.Fprintf(progress, "Transaction : %s\n", txHash)
+	fmt.Fprintf(progress, "Network     : %s\n", cmpNetworkFlag)
+	fmt.Fprintf(progress, "Local WASM  : %s\n", cmpLocalWasmFlag)
+	fmt.Fprintln(progress)
 
 	// ── Build RPC client ────────────────────────────────────────────────────
 	token := cmpRPCTokenFlag
@@ -186,12 +190,12 @@ func runCompare(cmd *cobra.Command, cmdArgs []string) error {
 	}
 
 	// ── Fetch transaction ───────────────────────────────────────────────────
-	fmt.Printf("%s Fetching transaction from %s...\n", visualizer.Symbol("pin"), cmpNetworkFlag)
+	fmt.Fprintf(progress, "%s Fetching transaction from %s...\n", visualizer.Symbol("pin"), cmpNetworkFlag)
 	txResp, err := client.GetTransaction(ctx, txHash)
 	if err != nil {
 		return errors.WrapRPCConnectionFailed(err)
 	}
-	fmt.Printf("%s Fetched (envelope: %d bytes)\n\n", visualizer.Success(), len(txResp.EnvelopeXdr))
+	fmt.Fprintf(progress, "%s Fetched (envelope: %d bytes)\n\n", visualizer.Success(), len(txResp.EnvelopeXdr))
 
 	// ── Extract ledger keys & entries ───────────────────────────────────────
 	keys, err := extractLedgerKeys(txResp.ResultMetaXdr)
@@ -215,27 +219,78 @@ func runCompare(cmd *cobra.Command, cmdArgs []string) error {
 	}
 
 	// ── Run two simulation passes in parallel ────────────────────────────────
-	fmt.Printf("%s Running two simulation passes in parallel...\n", visualizer.Symbol("play"))
-	fmt.Printf("   Pass A – local WASM  : %s\n", cmpLocalWasmFlag)
-	fmt.Printf("   Pass B – on-chain WASM: (using network ledger state)\n\n")
+	fmt.Fprintf(progress, "%s Running two simulation passes in parallel...\n", visualizer.Symbol("play"))
+	fmt.Fprintf(progress, "   Pass A – local WASM  : %s\n", cmpLocalWasmFlag)
+	fmt.Fprintf(progress, "   Pass B – on-chain WASM: (using network ledger state)\n\n")
 
 	localResult, onChainResult, runErr := runBothPasses(ctx, runner, txResp, ledgerEntries)
 	if runErr != nil {
 		return runErr
 	}
 
+	// ── Diff & render ────────────────────────────────────────────────────────
+	diffResult := compare.Diff(localResult, onChainResult)
+	if format != outputTable {
+		return writeStructured(cmd.OutOrStdout(), format, newCompareReport(txHash, diffResult, localResult, onChainResult))
+	}
+
 	if cmpVerboseFlag {
 		printVerboseResponse("LOCAL WASM", localResult)
 		printVerboseResponse("ON-CHAIN WASM", onChainResult)
 	}
-
-	// ── Diff & render ────────────────────────────────────────────────────────
-	diffResult := compare.Diff(localResult, onChainResult)
 	compare.Render(diffResult)
 
 	return nil
 }
 
+// compareReport is the output of erst compare; its JSON form is stable.
+// Local and OnChain are the full results of the two simulation passes.
+type compareReport struct {
+	TxHash          string `json:"tx_hash"`
+	Network         string `json:"network"`
+	LocalWasm       string `json:"local_wasm"`
+	StatusMatch     bool   `json:"status_match"`
+	HasDivergence   bool   `json:"has_divergence"`
+	TotalEvents     int    `json:"total_events"`
+	DivergentEvents int    `json:"divergent_events"`
+	// Divergences lists the diagnostic events where the passes took
+	// different call paths.
+	Divergences []compareDivergence           `json:"divergences"`
+	Local       *simulator.SimulationResponse `json:"local"`
+	OnChain     *simulator.SimulationResponse `json:"on_chain"`
+}
+
+type compareDivergence struct {
+	EventIndex int    `json:"event_index"`
+	Reason     string `json:"reason"`
+	Local      string `json:"local"`
+	OnChain    string `json:"on_chain"`
+}
+
+func newCompareReport(txHash string, diff *compare.DiffResult, local, onChain *simulator.SimulationResponse) compareReport {
+	report := compareReport{
+		TxHash:          txHash,
+		Network:         cmpNetworkFlag,
+		LocalWasm:       cmpLocalWasmFlag,
+		StatusMatch:     diff.StatusDiff.Match,
+		HasDivergence:   diff.HasDivergence,
+		TotalEvents:     diff.TotalEvents,
+		DivergentEvents: diff.DivergentEvents,
+		Divergences:     make([]compareDivergence, 0, len(diff.CallPathDivergences)),
+		Local:           local,
+		OnChain:         onChain,
+	}
+	for _, d := range diff.CallPathDivergences {
+		report.Divergences = append(report.Divergences, compareDivergence{
+			EventIndex: d.EventIndex,
+			Reason:     d.Reason,
+			Local:      d.LocalSummary,
+			OnChain:    d.OnChainSummary,
+		})
+	}
+	return report
+}
+
 // runBothPasses executes the local and on-chain simulation concurrently.
 func runBothPasses(
 	ctx context.Context,
@@ -4,7 +4,10 @@
 package cmd
 
 import (
-        "os"
+	"sort"
+
+	"github.com/dotandev/hintents/internal/config"
+	"github.com/dotandev/hintents/internal/rpc"
 	"github.com/spf13/cobra"
 )
 
@@ -12,7 +15,11 @@ import (
 var completionCmd = &cobra.Command{
 	Use:   "completion [bash|zsh|fish|powershell]",
 	Short: "Generate completion script for your shell",
-	Long: `To load completions:
+	Long: `Generate a completion script for erst. Besides commands and flags, the
+scripts complete --network with the built-in and custom network names and
+--profile with the saved profile names, read when you press Tab.
+
+To load completions:
 
 Bash:
 
@@ -52,21 +59,59 @@ PowerShell:
 `,
 	DisableFlagsInUseLine: true,
 	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
-	Args:                  cobra.ExactValidArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		w := cmd.OutOrStdout()
 		switch args[0] {
 		case "bash":
-			cmd.Root().GenBashCompletion(os.Stdout)
+			return cmd.Root().GenBashCompletionV2(w, true)
 		case "zsh":
-			cmd.Root().GenZshCompletion(os.Stdout)
+			return cmd.Root().GenZshCompletion(w)
 		case "fish":
-			cmd.Root().GenFishCompletion(os.Stdout, true)
-		case "powershell":
-			cmd.Root().GenPowerShellCompletionWithDesc(os.Stdout)
+			return cmd.Root().GenFishCompletion(w, true)
+		default:
+			return cmd.Root().GenPowerShellCompletionWithDesc(w)
 		}
 	},
 }
 
+// completeNetworks completes the built-in network names and the custom
+// networks saved in ~/.erst/networks.json.
+func completeNetworks(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	names := []string{string(rpc.Testnet), string(rpc.Mainnet), string(rpc.Futurenet)}
+	if custom, err := config.ListCustomNetworks(); err == nil {
+		sort.Strings(custom)
+		names = append(names, custom...)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeProfiles completes the names of the saved profiles.
+func completeProfiles(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	profiles, err := config.LoadProfiles()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return profiles.Names(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// registerFlagCompletions adds network name completion to every --network
+// flag in the command tree under root that has no completion of its own.
+// It runs once all commands have been added, just before execution.
+func registerFlagCompletions(root *cobra.Command) {
+	var walk func(*cobra.Command)
+	walk = func(c *cobra.Command) {
+		if c.Flags().Lookup("network") != nil {
+			// Fails harmlessly when the flag already has a completion.
+			_ = c.RegisterFlagCompletionFunc("network", completeNetworks)
+		}
+		for _, sub := range c.Commands() {
+			walk(sub)
+		}
+	}
+	walk(root)
+}
+
 func init() {
 	rootCmd.AddCommand(completionCmd)
 }
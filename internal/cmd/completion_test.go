@@ -0,0 +1,78 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/config"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// complete runs cobra's hidden __complete command on root and returns the
+// suggestions, without the trailing directive line.
+func complete(t *testing.T, root *cobra.Command, args ...string) []string {
+	t.Helper()
+	out := &bytes.Buffer{}
+	root.SetOut(out)
+	root.SetArgs(append([]string{cobra.ShellCompRequestCmd}, args...))
+	require.NoError(t, root.Execute())
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.NotEmpty(t, lines)
+	require.True(t, strings.HasPrefix(lines[len(lines)-1], ":"), "last line is the directive")
+	return lines[:len(lines)-1]
+}
+
+func TestCompletionOfNetworksAndProfiles(t *testing.T) {
+	withConfigHome(t)
+	require.NoError(t, config.AddCustomNetwork("local", rpc.NetworkConfig{HorizonURL: "http://localhost:8000"}))
+	require.NoError(t, config.SetProfileValue("staging", "network", "testnet"))
+	require.NoError(t, config.SetProfileValue("prod", "network", "mainnet"))
+
+	root := &cobra.Command{Use: "erst"}
+	var flags networkFlags
+	child := &cobra.Command{Use: "child", Run: func(*cobra.Command, []string) {}}
+	flags.register(child)
+	legacy := &cobra.Command{Use: "legacy", Run: func(*cobra.Command, []string) {}}
+	legacy.Flags().String("network", "mainnet", "")
+	root.AddCommand(child, legacy)
+	registerFlagCompletions(root)
+
+	assert.Equal(t, []string{"testnet", "mainnet", "futurenet", "local"}, complete(t, root, "child", "--network", ""))
+	assert.Equal(t, []string{"prod", "staging"}, complete(t, root, "child", "--profile", ""))
+	assert.Equal(t, []string{"testnet", "mainnet", "futurenet", "local"}, complete(t, root, "legacy", "--network", ""))
+}
+
+func TestCompletionOfConfigArgs(t *testing.T) {
+	withConfigHome(t)
+
+	keys, _ := completeConfigArgs(configSetCmd, nil, "")
+	assert.Equal(t, config.ProfileKeys, keys)
+	networks, _ := completeConfigArgs(configSetCmd, []string{"network"}, "")
+	assert.Contains(t, networks, "futurenet")
+	values, _ := completeConfigArgs(configGetCmd, []string{"network"}, "")
+	assert.Empty(t, values)
+}
+
+func TestCompletionScripts(t *testing.T) {
+	root := &cobra.Command{Use: "erst"}
+	root.AddCommand(completionCmd)
+	defer root.RemoveCommand(completionCmd)
+
+	for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+		out := &bytes.Buffer{}
+		root.SetOut(out)
+		root.SetArgs([]string{"completion", shell})
+		require.NoError(t, root.Execute(), shell)
+		assert.Contains(t, out.String(), "__complete", shell)
+	}
+
+	root.SetArgs([]string{"completion", "tcsh"})
+	assert.Error(t, root.Execute())
+}
@@ -0,0 +1,307 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/config"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/spf13/cobra"
+)
+
+var (
+	configProfile string
+	configReveal  bool
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage connection profiles",
+	Long: `Manage the profiles in ~/.erst/profiles.json. A profile holds the network,
+Horizon and Soroban RPC URLs, headers and token that commands use when the
+matching flags are not given, so they need not be repeated.
+
+Tokens are kept out of profiles.json, in ~/.erst/credentials.json, which
+like the profiles file is readable by its owner only. Each token is
+encrypted like the keys of erst keys, with a passphrase asked for on the
+terminal or read from ` + keysPassphraseEnv + `. Commands ask for it when
+they use a profile's token. Tokens are shown masked unless --reveal is
+given.
+
+Settings: ` + strings.Join(config.ProfileKeys, ", ") + `
+
+Examples:
+  erst config set network testnet
+  erst config set soroban_url http://localhost:8000/rpc --profile local
+  erst config set rpc_token "$TOKEN" --profile local
+  erst config get network
+  erst config list
+  erst config use-profile local`,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <setting> <value>",
+	Short: "Set a profile setting; an empty value clears it",
+	Long: `Set a setting on the profile named by --profile, or on the active profile.
+Without either, the "default" profile is written and made active.`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeConfigArgs,
+	RunE:              runConfigSet,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:               "get <setting>",
+	Short:             "Print a profile setting",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeConfigArgs,
+	RunE:              runConfigGet,
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List profiles and their settings",
+	Args:  cobra.NoArgs,
+	RunE:  runConfigList,
+}
+
+var configUseProfileCmd = &cobra.Command{
+	Use:   "use-profile <name>",
+	Short: "Make a profile the active one",
+	Long: `Make a profile the active one, so commands take their connection settings
+from it. ERST_PROFILE and a command's --profile flag take precedence.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeProfiles,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.UseProfile(args[0]); err != nil {
+			return err
+		}
+		w := cmd.OutOrStdout()
+		if format := outputFormat(false); format != outputTable {
+			return writeStructured(w, format, configActiveProfile{Active: args[0]})
+		}
+		fmt.Fprintf(w, "Using profile %s\n", args[0])
+		return nil
+	},
+}
+
+// configActiveProfile is the output of erst config use-profile; its JSON
+// form is stable.
+type configActiveProfile struct {
+	Active string `json:"active"`
+}
+
+// completeConfigArgs completes the setting name and, for network, its
+// value.
+func completeConfigArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch {
+	case len(args) == 0:
+		return config.ProfileKeys, cobra.ShellCompDirectiveNoFileComp
+	case len(args) == 1 && cmd.Name() == "set" && args[0] == "network":
+		return completeNetworks(cmd, args, toComplete)
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+// configTarget returns the profile config set and get act on, and whether
+// it should become the active one.
+func configTarget() (string, bool, error) {
+	if configProfile != "" {
+		return configProfile, false, nil
+	}
+	name, _, err := config.ActiveProfile()
+	if err != nil {
+		return "", false, err
+	}
+	if name == "" {
+		return config.DefaultProfileName, true, nil
+	}
+	return name, false, nil
+}
+
+// configSetting is the output of erst config set and get; its JSON form is
+// stable.
+type configSetting struct {
+	Profile string `json:"profile"`
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	key, value := args[0], args[1]
+	if err := validateProfileValue(key, value); err != nil {
+		return err
+	}
+	name, activate, err := configTarget()
+	if err != nil {
+		return err
+	}
+	if key == "rpc_token" {
+		passphrase := ""
+		if value != "" {
+			prompt := fmt.Sprintf("Passphrase for the %s token: ", name)
+			if passphrase, err = newKeyPrompt(cmd).passphrase(prompt, true); err != nil {
+				return err
+			}
+		}
+		err = config.SetProfileToken(name, value, passphrase)
+	} else {
+		err = config.SetProfileValue(name, key, value)
+	}
+	if err != nil {
+		return err
+	}
+	if activate {
+		if err := config.UseProfile(name); err != nil {
+			return err
+		}
+	}
+	if key == "rpc_token" && value != "" {
+		value = config.MaskSecret(value)
+	}
+	w := cmd.OutOrStdout()
+	if format := outputFormat(false); format != outputTable {
+		return writeStructured(w, format, configSetting{Profile: name, Key: key, Value: value})
+	}
+	fmt.Fprintf(w, "%s.%s = %s\n", name, key, value)
+	return nil
+}
+
+// validateProfileValue rejects networks erst does not know and URLs it
+// cannot connect to.
+func validateProfileValue(key, value string) error {
+	if value == "" {
+		return nil
+	}
+	switch key {
+	case "network":
+		switch rpc.Network(value) {
+		case rpc.Testnet, rpc.Mainnet, rpc.Futurenet:
+			return nil
+		}
+		if _, err := config.GetCustomNetwork(value); err != nil {
+			return errors.WrapInvalidNetwork(value)
+		}
+	case "horizon_url", "soroban_url":
+		u, err := url.Parse(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.WrapValidationError(fmt.Sprintf("invalid %s: %s (expected an http or https URL)", key, value))
+		}
+	}
+	return nil
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	name, _, err := configTarget()
+	if err != nil {
+		return err
+	}
+	value, err := config.ProfileValue(name, args[0])
+	if err != nil {
+		return err
+	}
+	if args[0] == "rpc_token" && configReveal && value != "" {
+		if value, err = newKeyPrompt(cmd).profileToken(name); err != nil {
+			return err
+		}
+	}
+	w := cmd.OutOrStdout()
+	if format := outputFormat(false); format != outputTable {
+		return writeStructured(w, format, configSetting{Profile: name, Key: args[0], Value: value})
+	}
+	fmt.Fprintln(w, value)
+	return nil
+}
+
+// profileToken decrypts the RPC token saved for the named profile, asking
+// for the passphrase only when there is one. It returns "" otherwise.
+func (p *keyPrompt) profileToken(name string) (string, error) {
+	creds, err := config.LoadCredentials()
+	if err != nil {
+		return "", err
+	}
+	if !creds.Has(name) {
+		return "", nil
+	}
+	passphrase, err := p.passphrase(fmt.Sprintf("Passphrase for the %s token: ", name), false)
+	if err != nil {
+		return "", err
+	}
+	return creds.Token(name, passphrase)
+}
+
+// configListing is the output of erst config list; its JSON form is stable.
+type configListing struct {
+	Active   string                       `json:"active,omitempty"`
+	Profiles map[string]map[string]string `json:"profiles"`
+}
+
+func runConfigList(cmd *cobra.Command, _ []string) error {
+	profiles, err := config.LoadProfiles()
+	if err != nil {
+		return err
+	}
+	active, _, err := config.ActiveProfile()
+	if err != nil {
+		return err
+	}
+
+	prompt := newKeyPrompt(cmd)
+	listing := configListing{Active: active, Profiles: make(map[string]map[string]string)}
+	for _, name := range profiles.Names() {
+		settings := make(map[string]string)
+		for _, key := range config.ProfileKeys {
+			value, err := config.ProfileValue(name, key)
+			if err != nil {
+				return err
+			}
+			if value == "" {
+				continue
+			}
+			if key == "rpc_token" && configReveal {
+				if value, err = prompt.profileToken(name); err != nil {
+					return err
+				}
+			}
+			settings[key] = value
+		}
+		listing.Profiles[name] = settings
+	}
+
+	w := cmd.OutOrStdout()
+	if format := outputFormat(false); format != outputTable {
+		return writeStructured(w, format, listing)
+	}
+	if len(listing.Profiles) == 0 {
+		fmt.Fprintln(w, "No profiles. Create one with: erst config set network testnet")
+		return nil
+	}
+	for _, name := range profiles.Names() {
+		marker := " "
+		if name == active {
+			marker = "*"
+		}
+		fmt.Fprintf(w, "%s %s\n", marker, name)
+		for _, key := range config.ProfileKeys {
+			if value, ok := listing.Profiles[name][key]; ok {
+				fmt.Fprintf(w, "    %-12s %s\n", key, value)
+			}
+		}
+	}
+	return nil
+}
+
+func init() {
+	configCmd.PersistentFlags().StringVar(&configProfile, "profile", "", "Profile to act on (default: the active profile)")
+	_ = configCmd.RegisterFlagCompletionFunc("profile", completeProfiles)
+	configGetCmd.Flags().BoolVar(&configReveal, "reveal", false, "Print rpc_token unmasked")
+	configListCmd.Flags().BoolVar(&configReveal, "reveal", false, "Print tokens unmasked")
+
+	supportStructuredOutput(configSetCmd, configGetCmd, configListCmd, configUseProfileCmd)
+	configCmd.AddCommand(configSetCmd, configGetCmd, configListCmd, configUseProfileCmd)
+	rootCmd.AddCommand(configCmd)
+}
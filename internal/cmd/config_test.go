@@ -0,0 +1,149 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/config"
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withConfigHome(t *testing.T) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("ERST_PROFILE", "")
+	t.Setenv("ERST_RPC_TOKEN", "")
+	t.Setenv(keysPassphraseEnv, "")
+}
+
+func runConfig(t *testing.T, run func(*cobra.Command, []string) error, args ...string) string {
+	t.Helper()
+	cmd, out := testCommand("")
+	require.NoError(t, run(cmd, args))
+	return out.String()
+}
+
+func TestConfigSetActivatesDefault(t *testing.T) {
+	withConfigHome(t)
+
+	runConfig(t, runConfigSet, "network", "testnet")
+	name, profile, err := config.ActiveProfile()
+	require.NoError(t, err)
+	assert.Equal(t, config.DefaultProfileName, name)
+	assert.Equal(t, "testnet", profile.Network)
+
+	assert.Equal(t, "testnet\n", runConfig(t, runConfigGet, "network"))
+
+	withOutputFlag(t, "json")
+	var setting configSetting
+	require.NoError(t, json.Unmarshal([]byte(runConfig(t, runConfigGet, "network")), &setting))
+	assert.Equal(t, configSetting{Profile: "default", Key: "network", Value: "testnet"}, setting)
+}
+
+func TestConfigSetValidates(t *testing.T) {
+	withConfigHome(t)
+	cmd, _ := testCommand("")
+
+	assert.Error(t, runConfigSet(cmd, []string{"network", "nowhere"}))
+	assert.Error(t, runConfigSet(cmd, []string{"soroban_url", "localhost:8000"}))
+	assert.Error(t, runConfigSet(cmd, []string{"colour", "blue"}))
+}
+
+func TestConfigListMasksTokens(t *testing.T) {
+	withConfigHome(t)
+	configProfile = "local"
+	defer func() { configProfile = "" }()
+	t.Setenv(keysPassphraseEnv, "pw")
+
+	out := runConfig(t, runConfigSet, "rpc_token", "super-secret-token")
+	assert.NotContains(t, out, "super-secret")
+	runConfig(t, runConfigSet, "soroban_url", "http://localhost:8000/rpc")
+
+	withOutputFlag(t, "json")
+	var listing configListing
+	require.NoError(t, json.Unmarshal([]byte(runConfig(t, runConfigList)), &listing))
+	assert.Empty(t, listing.Active)
+	assert.Equal(t, "**************oken", listing.Profiles["local"]["rpc_token"])
+	assert.Equal(t, "http://localhost:8000/rpc", listing.Profiles["local"]["soroban_url"])
+}
+
+func TestConfigTokenEncrypted(t *testing.T) {
+	withConfigHome(t)
+	configProfile = "local"
+	defer func() { configProfile, configReveal = "", false }()
+
+	cmd, _ := testCommand("pw\nother\n")
+	assert.ErrorContains(t, runConfigSet(cmd, []string{"rpc_token", "super-secret-token"}), "passphrases do not match")
+	cmd, _ = testCommand("pw\npw\n")
+	require.NoError(t, runConfigSet(cmd, []string{"rpc_token", "super-secret-token"}))
+
+	assert.Equal(t, "**************oken\n", runConfig(t, runConfigGet, "rpc_token"))
+	configReveal = true
+	cmd, out := testCommand("pw\n")
+	require.NoError(t, runConfigGet(cmd, []string{"rpc_token"}))
+	assert.Equal(t, "super-secret-token\n", out.String())
+	cmd, _ = testCommand("wrong\n")
+	assert.ErrorIs(t, runConfigGet(cmd, []string{"rpc_token"}), errs.ErrUnauthorized)
+
+	// Commands decrypt the profile's token, unless another one is given.
+	cmd, _ = testCommand("wrong\n")
+	_, err := (&networkFlags{profile: "local"}).client(cmd)
+	assert.ErrorIs(t, err, errs.ErrUnauthorized)
+	cmd, _ = testCommand("pw\n")
+	_, err = (&networkFlags{profile: "local"}).client(cmd)
+	assert.NoError(t, err)
+	cmd, _ = testCommand("")
+	_, err = (&networkFlags{profile: "local", token: "flag-token"}).client(cmd)
+	assert.NoError(t, err)
+}
+
+func TestConfigUseProfile(t *testing.T) {
+	withConfigHome(t)
+	require.NoError(t, config.SetProfileValue("local", "network", "testnet"))
+
+	cmd, _ := testCommand("")
+	assert.Error(t, configUseProfileCmd.RunE(cmd, []string{"missing"}))
+
+	withOutputFlag(t, "json")
+	var active configActiveProfile
+	require.NoError(t, json.Unmarshal([]byte(runConfig(t, configUseProfileCmd.RunE, "local")), &active))
+	assert.Equal(t, configActiveProfile{Active: "local"}, active)
+	name, _, err := config.ActiveProfile()
+	require.NoError(t, err)
+	assert.Equal(t, "local", name)
+}
+
+func TestNetworkFlagsUseProfile(t *testing.T) {
+	withConfigHome(t)
+	cmd, _ := testCommand("")
+	require.NoError(t, config.SetProfileValue("local", "network", "testnet"))
+	require.NoError(t, config.SetProfileValue("local", "soroban_url", "http://localhost:8000/rpc"))
+	require.NoError(t, config.UseProfile("local"))
+
+	client, err := (&networkFlags{}).client(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, rpc.Testnet, client.Network)
+	assert.Equal(t, "http://localhost:8000/rpc", client.SorobanURL)
+
+	// Flags win over the profile.
+	client, err = (&networkFlags{sorobanURL: "http://other:8000"}).client(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, "http://other:8000", client.SorobanURL)
+
+	// A different network does not inherit the profile's URLs.
+	client, err = (&networkFlags{network: "futurenet"}).client(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, rpc.FuturenetSorobanURL, client.SorobanURL)
+
+	_, err = (&networkFlags{profile: "missing"}).client(cmd)
+	assert.Error(t, err)
+}
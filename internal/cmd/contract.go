@@ -0,0 +1,241 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/dotandev/hintents/internal/abi"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/spf13/cobra"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+var (
+	inspectNet  networkFlags
+	inspectJSON bool
+)
+
+var contractCmd = &cobra.Command{
+	Use:   "contract",
+	Short: "Inspect and invoke deployed Soroban contracts",
+}
+
+var contractInspectCmd = &cobra.Command{
+	Use:   "inspect <C...>",
+	Short: "Show a deployed contract's spec, code hash, storage and TTLs",
+	Long: `Fetch a contract's instance and WASM from Soroban RPC and print:
+
+  - the code hash and size of the WASM it runs
+  - its ledger entries, with their durability, size and remaining TTL
+  - the entries kept in its instance storage
+  - the spec embedded in the WASM: functions, types, errors and events
+
+Persistent and temporary data entries outside instance storage cannot be
+listed through RPC and are not shown.
+
+Examples:
+  erst contract inspect CABC... --network testnet
+  erst contract inspect CABC... --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runContractInspect,
+}
+
+// contractInspectReport is the output of erst contract inspect; its JSON
+// form is stable.
+type contractInspectReport struct {
+	Network      string `json:"network"`
+	ContractID   string `json:"contract_id"`
+	LatestLedger uint32 `json:"latest_ledger"`
+	// Executable is "wasm" or "stellar_asset" for Stellar Asset Contracts,
+	// which have no WASM or spec.
+	Executable string          `json:"executable"`
+	WasmHash   string          `json:"wasm_hash,omitempty"`
+	WasmSize   int             `json:"wasm_size,omitempty"`
+	Entries    []contractEntry `json:"entries"`
+	// InstanceStorage counts the entries in the contract's instance
+	// storage, which live and expire with the instance entry.
+	InstanceStorage struct {
+		Entries int `json:"entries"`
+		Bytes   int `json:"bytes"`
+	} `json:"instance_storage"`
+	Spec json.RawMessage `json:"spec,omitempty"`
+
+	spec *abi.ContractSpec
+}
+
+// contractEntry is one of the contract's ledger entries. TTL is the number
+// of ledgers until it is archived; it is negative once it has been.
+type contractEntry struct {
+	Kind               string `json:"kind"`
+	Durability         string `json:"durability"`
+	Bytes              int    `json:"bytes"`
+	LastModifiedLedger int    `json:"last_modified_ledger"`
+	LiveUntilLedger    int    `json:"live_until_ledger"`
+	TTL                int    `json:"ttl"`
+}
+
+func runContractInspect(cmd *cobra.Command, args []string) error {
+	id := args[0]
+	if !strkey.IsValidContractAddress(id) {
+		return errors.WrapValidationError(fmt.Sprintf("invalid contract ID: %s", id))
+	}
+	client, err := inspectNet.client(cmd)
+	if err != nil {
+		return err
+	}
+	report, err := inspectContract(cmd.Context(), client, id)
+	if err != nil {
+		return err
+	}
+	return writeContractInspectReport(cmd.OutOrStdout(), report)
+}
+
+func inspectContract(ctx context.Context, client *rpc.Client, id string) (*contractInspectReport, error) {
+	var cid xdr.ContractId
+	copy(cid[:], strkey.MustDecode(strkey.VersionByteContract, id))
+	instanceKey, err := rpc.LedgerKeyForContractInstance(cid)
+	if err != nil {
+		return nil, err
+	}
+	key, err := rpc.EncodeLedgerKey(instanceKey)
+	if err != nil {
+		return nil, err
+	}
+	fetched, err := client.GetLedgerEntriesWithTTL(ctx, []string{key})
+	if err != nil {
+		return nil, err
+	}
+	if len(fetched.Entries) == 0 {
+		return nil, errors.WrapValidationError(fmt.Sprintf("contract %s not found on %s (it may never have existed or its instance may be archived)", id, client.GetNetworkName()))
+	}
+
+	r := &contractInspectReport{Network: client.GetNetworkName(), ContractID: id, LatestLedger: fetched.LatestLedger}
+	instance, data, err := contractLedgerEntry(fetched.Entries[0], fetched.LatestLedger, "instance")
+	if err != nil {
+		return nil, err
+	}
+	r.Entries = append(r.Entries, instance)
+	inst := data.ContractData.Val.Instance
+	if inst == nil {
+		return nil, errors.WrapValidationError(fmt.Sprintf("ledger entry for %s is not a contract instance", id))
+	}
+	if inst.Storage != nil {
+		for _, e := range *inst.Storage {
+			k, _ := e.Key.MarshalBinary()
+			v, _ := e.Val.MarshalBinary()
+			r.InstanceStorage.Entries++
+			r.InstanceStorage.Bytes += len(k) + len(v)
+		}
+	}
+
+	if inst.Executable.Type != xdr.ContractExecutableTypeContractExecutableWasm || inst.Executable.WasmHash == nil {
+		r.Executable = "stellar_asset"
+		return r, nil
+	}
+	r.Executable = "wasm"
+	r.WasmHash = inst.Executable.WasmHash.HexString()
+
+	codeKey, err := rpc.EncodeLedgerKey(xdr.LedgerKey{
+		Type:         xdr.LedgerEntryTypeContractCode,
+		ContractCode: &xdr.LedgerKeyContractCode{Hash: *inst.Executable.WasmHash},
+	})
+	if err != nil {
+		return nil, err
+	}
+	code, err := client.GetLedgerEntriesWithTTL(ctx, []string{codeKey})
+	if err != nil {
+		return nil, err
+	}
+	if len(code.Entries) == 0 {
+		return nil, errors.WrapValidationError(fmt.Sprintf("WASM %s of contract %s not found (it may be archived)", r.WasmHash, id))
+	}
+	codeEntry, codeData, err := contractLedgerEntry(code.Entries[0], code.LatestLedger, "code")
+	if err != nil {
+		return nil, err
+	}
+	r.Entries = append(r.Entries, codeEntry)
+	r.WasmSize = len(codeData.ContractCode.Code)
+
+	if r.spec, err = abi.ParseWasm(codeData.ContractCode.Code); err != nil {
+		return nil, err
+	}
+	spec, err := abi.FormatJSON(r.spec)
+	if err != nil {
+		return nil, errors.WrapMarshalFailed(err)
+	}
+	r.Spec = json.RawMessage(spec)
+	return r, nil
+}
+
+// contractLedgerEntry decodes a contract instance or code entry fetched
+// with its TTL.
+func contractLedgerEntry(e rpc.LedgerEntryResult, latest uint32, kind string) (contractEntry, xdr.LedgerEntryData, error) {
+	var data xdr.LedgerEntryData
+	if err := xdr.SafeUnmarshalBase64(e.Xdr, &data); err != nil {
+		return contractEntry{}, data, errors.WrapUnmarshalFailed(err, "LedgerEntryData")
+	}
+	entry := contractEntry{
+		Kind:               kind,
+		Durability:         "persistent",
+		LastModifiedLedger: e.LastModifiedLedger,
+		LiveUntilLedger:    e.LiveUntilLedger,
+		TTL:                e.LiveUntilLedger - int(latest),
+	}
+	if raw, err := data.MarshalBinary(); err == nil {
+		entry.Bytes = len(raw)
+	}
+	switch {
+	case kind == "code" && data.ContractCode != nil:
+	case kind == "instance" && data.ContractData != nil:
+		if data.ContractData.Durability == xdr.ContractDataDurabilityTemporary {
+			entry.Durability = "temporary"
+		}
+	default:
+		return contractEntry{}, data, errors.WrapValidationError(fmt.Sprintf("unexpected %s ledger entry type %s", kind, data.Type))
+	}
+	return entry, data, nil
+}
+
+func writeContractInspectReport(w io.Writer, r *contractInspectReport) error {
+	if format := outputFormat(inspectJSON); format != outputTable {
+		return writeStructured(w, format, r)
+	}
+	fmt.Fprintf(w, "Contract %s on %s (ledger %d)\n", r.ContractID, r.Network, r.LatestLedger)
+	if r.Executable == "wasm" {
+		fmt.Fprintf(w, "  Code hash: %s (%d bytes)\n", r.WasmHash, r.WasmSize)
+	} else {
+		fmt.Fprintf(w, "  Executable: Stellar Asset Contract (built in, no WASM)\n")
+	}
+
+	fmt.Fprintf(w, "\nLedger entries:\n")
+	for _, e := range r.Entries {
+		fmt.Fprintf(w, "  %-9s %-10s %7d bytes  modified %d  live until %d", e.Kind, e.Durability, e.Bytes, e.LastModifiedLedger, e.LiveUntilLedger)
+		if e.TTL < 0 {
+			fmt.Fprintf(w, " (archived)\n")
+		} else {
+			fmt.Fprintf(w, " (%d ledgers left)\n", e.TTL)
+		}
+	}
+	fmt.Fprintf(w, "  Instance storage: %d entries, %d bytes\n", r.InstanceStorage.Entries, r.InstanceStorage.Bytes)
+
+	if r.spec != nil {
+		fmt.Fprintf(w, "\n%s", abi.FormatText(r.spec))
+	}
+	return nil
+}
+
+func init() {
+	inspectNet.register(contractInspectCmd)
+	contractInspectCmd.Flags().BoolVar(&inspectJSON, "json", false, "Print the report as JSON")
+
+	supportStructuredOutput(contractInspectCmd)
+	contractCmd.AddCommand(contractInspectCmd)
+	rootCmd.AddCommand(contractCmd)
+}
@@ -0,0 +1,264 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dotandev/hintents/internal/abi"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/intent"
+	"github.com/spf13/cobra"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+var (
+	invokeNet     networkFlags
+	invokeArgs    []string
+	invokeSource  string
+	invokeSigner  string
+	invokeFee     int64
+	invokeSend    bool
+	invokeWait    bool
+	invokeTimeout time.Duration
+	invokeJSON    bool
+)
+
+var contractInvokeCmd = &cobra.Command{
+	Use:   "invoke <C...> <function>",
+	Short: "Call a contract function: simulate it and optionally submit it",
+	Long: `Call a function on a deployed contract. Arguments are given by name with
+--arg and converted to the types the contract's spec declares. The call is
+simulated and the dry run is printed: the decoded return value, fees,
+footprint, required signers and any warnings.
+
+Argument values are parsed as JSON when they are valid JSON and taken as
+plain strings otherwise, so --arg to=alice and --arg amount=100 both work;
+structs, vectors and maps are written as JSON objects and arrays. Quote a
+string that would parse as JSON: --arg memo='"true"'.
+
+With --send the transaction is assembled from the simulation, signed with
+--signer and submitted through Soroban RPC. The signer is a secret seed or
+the name of a key stored with erst keys, and may also be given in
+ERST_SIGNER_SECRET. The signer's account is the source account of the
+transaction; --source only names another account to simulate a dry run
+from, and must match the signer with --send.
+
+Examples:
+  erst contract invoke CABC... balance --arg id=GABC... --network testnet
+  erst contract invoke CABC... transfer --arg from=GABC... --arg to=GDEF... --arg amount=100 \
+      --send --signer alice
+  erst contract invoke CABC... hello --arg to=world --source GABC... --json`,
+	Args: cobra.ExactArgs(2),
+	RunE: runContractInvoke,
+}
+
+// contractInvokeReport is the output of erst contract invoke; its JSON form
+// is stable.
+type contractInvokeReport struct {
+	Network    string                 `json:"network"`
+	ContractID string                 `json:"contract_id"`
+	Function   string                 `json:"function"`
+	Args       map[string]interface{} `json:"args"`
+	DryRun     *intent.DryRunReport   `json:"dry_run"`
+	// Result is the simulated return value decoded with the contract's
+	// spec.
+	Result     interface{}   `json:"result,omitempty"`
+	Submission *submitReport `json:"submission,omitempty"`
+}
+
+func runContractInvoke(cmd *cobra.Command, args []string) error {
+	id, function := args[0], args[1]
+	if !strkey.IsValidContractAddress(id) {
+		return errors.WrapValidationError(fmt.Sprintf("invalid contract ID: %s", id))
+	}
+	values, err := parseInvokeArgs(invokeArgs)
+	if err != nil {
+		return err
+	}
+
+	var signer intent.Signer
+	ref := invokeSigner
+	if ref == "" {
+		ref = os.Getenv("ERST_SIGNER_SECRET")
+	}
+	if ref != "" {
+		if signer, err = newKeyPrompt(cmd).signer(ref); err != nil {
+			return err
+		}
+	}
+	if invokeSend && signer == nil {
+		return errors.WrapValidationError("--send requires --signer or ERST_SIGNER_SECRET")
+	}
+	if invokeSend && invokeSource != "" && invokeSource != signer.PublicKey() {
+		return errors.WrapValidationError(fmt.Sprintf("--send signs as the source account, but --source %s is not the signer's account %s", invokeSource, signer.PublicKey()))
+	}
+	source := invokeSource
+	if source == "" && signer != nil {
+		source = signer.PublicKey()
+	}
+	if source == "" {
+		return errors.WrapValidationError("--source is required to simulate without a signer")
+	}
+
+	client, err := invokeNet.client(cmd)
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+
+	contract, err := inspectContract(ctx, client, id)
+	if err != nil {
+		return err
+	}
+	spec := contract.spec
+	if spec == nil {
+		spec = abi.TokenInterface()
+	}
+	fn, ok := spec.Function(function)
+	if !ok {
+		return errors.WrapValidationError(fmt.Sprintf("contract %s has no function %q", id, function))
+	}
+	vals, err := spec.EncodeArgs(function, values)
+	if err != nil {
+		return err
+	}
+
+	in := &intent.InvokeIntent{From: source, Contract: id, Function: function, Args: vals}
+	var opts []intent.Option
+	if invokeFee > 0 {
+		opts = append(opts, intent.WithBaseFee(invokeFee))
+	}
+	dry, err := intent.DryRun(ctx, client, in, opts...)
+	if err != nil {
+		return err
+	}
+
+	report := &contractInvokeReport{Network: client.GetNetworkName(), ContractID: id, Function: function, Args: values, DryRun: dry}
+	if dry.ReturnValue != "" && len(fn.Outputs) > 0 {
+		var ret xdr.ScVal
+		if err := xdr.SafeUnmarshalBase64(dry.ReturnValue, &ret); err != nil {
+			return errors.WrapUnmarshalFailed(err, "return value")
+		}
+		if report.Result, err = spec.Decode(fn.Outputs[0], ret); err != nil {
+			return err
+		}
+	}
+	if !invokeSend {
+		return writeContractInvokeReport(cmd.OutOrStdout(), report)
+	}
+	if dry.SimulationError != "" {
+		if err := writeContractInvokeReport(cmd.OutOrStdout(), report); err != nil {
+			return err
+		}
+		return errors.WrapSimulationLogicError(dry.SimulationError)
+	}
+
+	// Resolve simulates again so the footprint and sequence number match
+	// the ledger the transaction is submitted against.
+	prepared, err := intent.Resolve(ctx, client, in, opts...)
+	if err != nil {
+		return err
+	}
+	tx, err := intent.SignTransaction(ctx, prepared.Tx, client.GetNetworkPassphrase(), signer)
+	if err != nil {
+		return err
+	}
+	env, err := tx.Base64()
+	if err != nil {
+		return errors.WrapMarshalFailed(err)
+	}
+	submission, submitErr := submit(cmd, client, "rpc", env, invokeWait, invokeTimeout)
+	report.Submission = submission
+	if err := writeContractInvokeReport(cmd.OutOrStdout(), report); err != nil {
+		return err
+	}
+	return submitErr
+}
+
+// parseInvokeArgs splits name=value arguments, decoding each value as JSON
+// when it is valid JSON and keeping it as a string otherwise. Numbers are
+// kept as json.Number so wide integers lose no precision.
+func parseInvokeArgs(raw []string) (map[string]interface{}, error) {
+	values := make(map[string]interface{}, len(raw))
+	for _, arg := range raw {
+		name, value, ok := strings.Cut(arg, "=")
+		if !ok || name == "" {
+			return nil, errors.WrapValidationError(fmt.Sprintf("invalid --arg %q (use name=value)", arg))
+		}
+		if _, dup := values[name]; dup {
+			return nil, errors.WrapValidationError(fmt.Sprintf("argument %q given more than once", name))
+		}
+		values[name] = value
+		if json.Valid([]byte(value)) {
+			var decoded interface{}
+			dec := json.NewDecoder(strings.NewReader(value))
+			dec.UseNumber()
+			if dec.Decode(&decoded) == nil {
+				values[name] = decoded
+			}
+		}
+	}
+	return values, nil
+}
+
+func writeContractInvokeReport(w io.Writer, r *contractInvokeReport) error {
+	if format := outputFormat(invokeJSON); format != outputTable {
+		return writeStructured(w, format, r)
+	}
+	d := r.DryRun
+	fmt.Fprintf(w, "Invoke %s on %s (%s)\n", r.Function, r.ContractID, r.Network)
+	fmt.Fprintf(w, "  Source: %s\n", d.Source)
+	if d.SimulationError != "" {
+		fmt.Fprintf(w, "  Simulation failed: %s\n", d.SimulationError)
+	} else if r.Result != nil {
+		result, err := json.Marshal(r.Result)
+		if err != nil {
+			return errors.WrapMarshalFailed(err)
+		}
+		fmt.Fprintf(w, "  Result: %s\n", result)
+	}
+	fmt.Fprintf(w, "  Fee:    %s (inclusion %s, resources %s)\n", stroops(d.TotalFee), stroops(d.InclusionFee), stroops(d.ResourceFee))
+	if f := d.Footprint; f != nil {
+		fmt.Fprintf(w, "  Footprint: %d read-only, %d read-write; %d instructions, %d bytes read, %d bytes written\n",
+			f.ReadOnly, f.ReadWrite, f.Instructions, f.ReadBytes, f.WriteBytes)
+	}
+	for _, s := range d.Signers {
+		fmt.Fprintf(w, "  Signer: %s (%s threshold, needs weight %d)\n", s.Account, s.Threshold, s.Needed)
+	}
+	for _, warning := range d.Warnings {
+		fmt.Fprintf(w, "  Warning: %s\n", warning)
+	}
+
+	if r.Submission == nil {
+		if d.SimulationError == "" {
+			fmt.Fprintf(w, "\nDry run only; use --send --signer to submit.\n")
+		}
+		return nil
+	}
+	fmt.Fprintln(w)
+	return writeSubmitReport(w, r.Submission)
+}
+
+func init() {
+	invokeNet.register(contractInvokeCmd)
+	contractInvokeCmd.Flags().StringArrayVar(&invokeArgs, "arg", nil, "Function argument as name=value (repeatable)")
+	contractInvokeCmd.Flags().StringVar(&invokeSource, "source", "", "Source account to simulate from (default: the signer's account; with --send it must be the signer's)")
+	contractInvokeCmd.Flags().StringVar(&invokeSigner, "signer", "", "Secret seed (S...) or stored key name that signs the transaction")
+	_ = contractInvokeCmd.RegisterFlagCompletionFunc("signer", completeKeys)
+	contractInvokeCmd.Flags().Int64Var(&invokeFee, "fee", 0, "Inclusion fee in stroops (default: from recent fee stats)")
+	contractInvokeCmd.Flags().BoolVar(&invokeSend, "send", false, "Sign and submit the transaction after simulating it")
+	contractInvokeCmd.Flags().BoolVar(&invokeWait, "wait", true, "With --send, wait until the transaction is included")
+	contractInvokeCmd.Flags().DurationVar(&invokeTimeout, "timeout", time.Minute, "How long --wait waits for inclusion")
+	contractInvokeCmd.Flags().BoolVar(&invokeJSON, "json", false, "Print the report as JSON")
+
+	supportStructuredOutput(contractInvokeCmd)
+	contractCmd.AddCommand(contractInvokeCmd)
+}
@@ -0,0 +1,157 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc/rpctest"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// invokeFixture serves contractFixture's contract, a source account and a
+// simulation of hello returning 42.
+func invokeFixture(t *testing.T, source string) (string, *rpctest.HorizonServer, *rpctest.SorobanServer) {
+	t.Helper()
+	contract, soroban := contractFixture(t)
+	soroban.On("simulateTransaction", rpctest.Result(map[string]any{
+		"minResourceFee":  "5000",
+		"transactionData": mustB64(t, xdr.SorobanTransactionData{ResourceFee: 5000}),
+		"results":         []map[string]any{{"xdr": mustB64(t, u32Val(42))}},
+		"latestLedger":    1000,
+	}))
+
+	horizon := rpctest.NewHorizonServer()
+	t.Cleanup(horizon.Close)
+	horizon.AddAccount(hProtocol.Account{
+		AccountID: source,
+		Sequence:  10,
+		Signers:   []hProtocol.Signer{{Key: source, Type: "ed25519_public_key", Weight: 1}},
+	})
+	return contract, horizon, soroban
+}
+
+func runInvokeWith(t *testing.T, horizonURL, sorobanURL string, args ...string) (contractInvokeReport, error) {
+	t.Helper()
+	invokeNet = networkFlags{network: "testnet", horizonURL: horizonURL, sorobanURL: sorobanURL}
+	invokeJSON, invokeFee = true, 100
+	defer func() {
+		invokeNet, invokeArgs, invokeSource, invokeSigner = networkFlags{}, nil, "", ""
+		invokeFee, invokeSend, invokeWait, invokeJSON = 0, false, true, false
+	}()
+
+	cmd, out := testCommand("")
+	err := runContractInvoke(cmd, args)
+	var report contractInvokeReport
+	if out.Len() > 0 {
+		require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	}
+	return report, err
+}
+
+func TestContractInvokeDryRun(t *testing.T) {
+	source := keypair.MustRandom().Address()
+	contract, horizon, soroban := invokeFixture(t, source)
+	invokeArgs, invokeSource = []string{"to=world"}, source
+
+	report, err := runInvokeWith(t, horizon.URL, soroban.URL, contract, "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", report.Function)
+	assert.Equal(t, map[string]interface{}{"to": "world"}, report.Args)
+	assert.EqualValues(t, 42, report.Result)
+	require.NotNil(t, report.DryRun)
+	assert.Equal(t, int64(5100), report.DryRun.TotalFee)
+	assert.Nil(t, report.Submission)
+	assert.Empty(t, soroban.Calls("sendTransaction"))
+
+	var params []string
+	require.NoError(t, json.Unmarshal(soroban.Calls("simulateTransaction")[0], &params))
+	var env xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(params[0], &env))
+	call := env.Operations()[0].Body.InvokeHostFunctionOp.HostFunction.InvokeContract
+	require.Len(t, call.Args, 1)
+	assert.Equal(t, xdr.ScSymbol("world"), *call.Args[0].Sym)
+}
+
+func TestContractInvokeSend(t *testing.T) {
+	signer := keypair.MustRandom()
+	contract, horizon, soroban := invokeFixture(t, signer.Address())
+	soroban.On("sendTransaction", rpctest.Result(map[string]any{"status": "PENDING", "hash": "abc123", "latestLedger": 1000}))
+	invokeArgs, invokeSigner, invokeSend, invokeWait = []string{"to=world"}, signer.Seed(), true, false
+
+	report, err := runInvokeWith(t, horizon.URL, soroban.URL, contract, "hello")
+	require.NoError(t, err)
+	require.NotNil(t, report.Submission)
+	assert.Equal(t, "PENDING", report.Submission.Status)
+	assert.Equal(t, "rpc", report.Submission.Via)
+
+	var params struct{ Transaction string }
+	require.NoError(t, json.Unmarshal(soroban.Calls("sendTransaction")[0], &params))
+	var env xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(params.Transaction, &env))
+	assert.Len(t, env.Signatures(), 1)
+	assert.Equal(t, int64(11), int64(env.SeqNum()))
+	assert.NotNil(t, env.V1.Tx.Ext.SorobanData)
+}
+
+func TestContractInvokeRejectsBadArgs(t *testing.T) {
+	source := keypair.MustRandom().Address()
+	t.Setenv("ERST_SIGNER_SECRET", "")
+
+	tests := []struct {
+		name     string
+		function string
+		args     []string
+		send     bool
+		want     string
+	}{
+		{"unknown argument", "hello", []string{"to=world", "extra=1"}, false, "unknown arguments extra"},
+		{"missing argument", "hello", nil, false, `missing argument "to"`},
+		{"unknown function", "goodbye", nil, false, `no function "goodbye"`},
+		{"send without signer", "hello", []string{"to=world"}, true, "--send requires --signer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contract, horizon, soroban := invokeFixture(t, source)
+			invokeArgs, invokeSource, invokeSend = tt.args, source, tt.send
+
+			_, err := runInvokeWith(t, horizon.URL, soroban.URL, contract, tt.function)
+			assert.ErrorContains(t, err, tt.want)
+			assert.Empty(t, soroban.Calls("simulateTransaction"))
+		})
+	}
+}
+
+func TestContractInvokeSendRejectsOtherSource(t *testing.T) {
+	signer := keypair.MustRandom()
+	source := keypair.MustRandom().Address()
+	contract, horizon, soroban := invokeFixture(t, source)
+	invokeArgs, invokeSource, invokeSigner, invokeSend = []string{"to=world"}, source, signer.Seed(), true
+
+	_, err := runInvokeWith(t, horizon.URL, soroban.URL, contract, "hello")
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+	assert.ErrorContains(t, err, "is not the signer's account")
+	assert.Empty(t, soroban.Calls("simulateTransaction"))
+}
+
+func TestParseInvokeArgs(t *testing.T) {
+	values, err := parseInvokeArgs([]string{"to=alice", "amount=100", `memo="true"`, `ids=[1,2]`, "eq=a=b"})
+	require.NoError(t, err)
+	assert.Equal(t, "alice", values["to"])
+	assert.Equal(t, json.Number("100"), values["amount"])
+	assert.Equal(t, "true", values["memo"])
+	assert.Equal(t, []interface{}{json.Number("1"), json.Number("2")}, values["ids"])
+	assert.Equal(t, "a=b", values["eq"])
+
+	_, err = parseInvokeArgs([]string{"noequals"})
+	assert.Error(t, err)
+	_, err = parseInvokeArgs([]string{"a=1", "a=2"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/abi"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/rpc/rpctest"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// specWasm returns a minimal WASM module whose only content is a contract
+// spec section holding entries.
+func specWasm(t *testing.T, entries ...xdr.ScSpecEntry) []byte {
+	t.Helper()
+	var spec bytes.Buffer
+	for _, e := range entries {
+		raw, err := e.MarshalBinary()
+		require.NoError(t, err)
+		spec.Write(raw)
+	}
+	name := abi.SpecSectionName
+	section := append([]byte{byte(len(name))}, name...)
+	section = append(section, spec.Bytes()...)
+	require.Less(t, len(section), 128, "section length must fit in one LEB128 byte")
+
+	wasm := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	wasm = append(wasm, 0x00, byte(len(section)))
+	return append(wasm, section...)
+}
+
+// contractFixture serves a contract whose instance storage holds one
+// entry and whose WASM declares a hello(to: Symbol) -> U32 function.
+func contractFixture(t *testing.T) (string, *rpctest.SorobanServer) {
+	t.Helper()
+	id := xdr.ContractId{9}
+	contract := strkey.MustEncode(strkey.VersionByteContract, id[:])
+	hash := xdr.Hash{4}
+	wasm := specWasm(t, xdr.ScSpecEntry{
+		Kind: xdr.ScSpecEntryKindScSpecEntryFunctionV0,
+		FunctionV0: &xdr.ScSpecFunctionV0{
+			Name:    "hello",
+			Inputs:  []xdr.ScSpecFunctionInputV0{{Name: "to", Type: xdr.ScSpecTypeDef{Type: xdr.ScSpecTypeScSpecTypeSymbol}}},
+			Outputs: []xdr.ScSpecTypeDef{{Type: xdr.ScSpecTypeScSpecTypeU32}},
+		},
+	})
+
+	instanceKey, err := rpc.LedgerKeyForContractInstance(id)
+	require.NoError(t, err)
+	storage := xdr.ScMap{{Key: u32Val(1), Val: u32Val(2)}}
+	instance := xdr.LedgerEntryData{
+		Type: xdr.LedgerEntryTypeContractData,
+		ContractData: &xdr.ContractDataEntry{
+			Contract:   instanceKey.ContractData.Contract,
+			Key:        instanceKey.ContractData.Key,
+			Durability: xdr.ContractDataDurabilityPersistent,
+			Val: xdr.ScVal{Type: xdr.ScValTypeScvContractInstance, Instance: &xdr.ScContractInstance{
+				Executable: xdr.ContractExecutable{Type: xdr.ContractExecutableTypeContractExecutableWasm, WasmHash: &hash},
+				Storage:    &storage,
+			}},
+		},
+	}
+	codeKey := xdr.LedgerKey{Type: xdr.LedgerEntryTypeContractCode, ContractCode: &xdr.LedgerKeyContractCode{Hash: hash}}
+	code := xdr.LedgerEntryData{
+		Type:         xdr.LedgerEntryTypeContractCode,
+		ContractCode: &xdr.ContractCodeEntry{Hash: hash, Code: wasm},
+	}
+
+	soroban := rpctest.NewSorobanServer()
+	t.Cleanup(soroban.Close)
+	soroban.On("getLedgerEntries",
+		rpctest.Result(map[string]any{"latestLedger": 1000, "entries": []map[string]any{
+			{"key": mustB64(t, instanceKey), "xdr": mustB64(t, instance), "lastModifiedLedgerSeq": 50, "liveUntilLedgerSeq": 1500},
+		}}),
+		rpctest.Result(map[string]any{"latestLedger": 1000, "entries": []map[string]any{
+			{"key": mustB64(t, codeKey), "xdr": mustB64(t, code), "lastModifiedLedgerSeq": 40, "liveUntilLedgerSeq": 900},
+		}}),
+	)
+	return contract, soroban
+}
+
+func TestContractInspect(t *testing.T) {
+	contract, soroban := contractFixture(t)
+	inspectNet = networkFlags{network: "testnet", sorobanURL: soroban.URL}
+	inspectJSON = true
+	defer func() { inspectNet, inspectJSON = networkFlags{}, false }()
+
+	cmd, out := testCommand("")
+	require.NoError(t, runContractInspect(cmd, []string{contract}))
+	var report contractInspectReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+
+	assert.Equal(t, "wasm", report.Executable)
+	assert.Equal(t, xdr.Hash{4}.HexString(), report.WasmHash)
+	assert.NotZero(t, report.WasmSize)
+	assert.Equal(t, 1, report.InstanceStorage.Entries)
+
+	require.Len(t, report.Entries, 2)
+	assert.Equal(t, "instance", report.Entries[0].Kind)
+	assert.Equal(t, "persistent", report.Entries[0].Durability)
+	assert.Equal(t, 500, report.Entries[0].TTL)
+	assert.Equal(t, "code", report.Entries[1].Kind)
+	assert.Equal(t, -100, report.Entries[1].TTL)
+
+	var spec struct {
+		Functions []struct {
+			Name    string   `json:"name"`
+			Outputs []string `json:"outputs"`
+		} `json:"functions"`
+	}
+	require.NoError(t, json.Unmarshal(report.Spec, &spec))
+	require.Len(t, spec.Functions, 1)
+	assert.Equal(t, "hello", spec.Functions[0].Name)
+	assert.Equal(t, []string{"U32"}, spec.Functions[0].Outputs)
+}
+
+func TestContractInspectText(t *testing.T) {
+	contract, soroban := contractFixture(t)
+	inspectNet = networkFlags{network: "testnet", sorobanURL: soroban.URL}
+	defer func() { inspectNet = networkFlags{} }()
+
+	cmd, out := testCommand("")
+	require.NoError(t, runContractInspect(cmd, []string{contract}))
+	assert.Contains(t, out.String(), "(500 ledgers left)")
+	assert.Contains(t, out.String(), "(archived)")
+	assert.Contains(t, out.String(), "hello(to: Symbol) -> U32")
+}
+
+func TestContractInspectNotFound(t *testing.T) {
+	soroban := rpctest.NewSorobanServer()
+	defer soroban.Close()
+	soroban.On("getLedgerEntries", rpctest.Result(map[string]any{"latestLedger": 1000, "entries": []any{}}))
+	inspectNet = networkFlags{network: "testnet", sorobanURL: soroban.URL}
+	defer func() { inspectNet = networkFlags{} }()
+
+	cmd, _ := testCommand("")
+	id := xdr.ContractId{1}
+	assert.ErrorContains(t, runContractInspect(cmd, []string{strkey.MustEncode(strkey.VersionByteContract, id[:])}), "not found")
+	assert.ErrorContains(t, runContractInspect(cmd, []string{"GABC"}), "invalid contract ID")
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/dotandev/hintents/internal/simulator"
 	"github.com/dotandev/hintents/internal/snapshot"
 	"github.com/dotandev/hintents/internal/telemetry"
+	"github.com/dotandev/hintents/internal/timing"
 	"github.com/dotandev/hintents/internal/tokenflow"
 	"github.com/dotandev/hintents/internal/visualizer"
 	"github.com/dotandev/hintents/internal/wat"
@@ -55,6 +56,7 @@ var (
 	watchTimeoutFlag   int
 	mockBaseFeeFlag    uint32
 	mockGasPriceFlag   uint64
+	timingsFlag        bool
 )
 
 // DebugCommand holds dependencies for the debug command
@@ -166,6 +168,65 @@ func (d *DebugCommand) runDebug(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// debugReport is the output of erst debug; its JSON form is stable.
+type debugReport struct {
+	TxHash         string `json:"tx_hash"`
+	Network        string `json:"network"`
+	CompareNetwork string `json:"compare_network,omitempty"`
+	// Runs has one entry per simulated timestamp.
+	Runs      []debugRun         `json:"runs"`
+	Findings  []security.Finding `json:"findings"`
+	SessionID string             `json:"session_id"`
+}
+
+// debugRun is one simulation of erst debug. Compare is the result on
+// --compare-network, when given.
+type debugRun struct {
+	Timestamp int64                         `json:"timestamp,omitempty"`
+	Result    *simulator.SimulationResponse `json:"result"`
+	Compare   *simulator.SimulationResponse `json:"compare,omitempty"`
+}
+
+// printSecurityFindings prints the security analysis of erst debug.
+func printSecurityFindings(findings []security.Finding) {
+	fmt.Printf("\n=== Security Analysis ===\n")
+	if len(findings) == 0 {
+		fmt.Printf("%s No security issues detected\n", visualizer.Success())
+		return
+	}
+
+	verifiedCount := 0
+	heuristicCount := 0
+
+	for _, finding := range findings {
+		if finding.Type == security.FindingVerifiedRisk {
+			verifiedCount++
+		} else {
+			heuristicCount++
+		}
+	}
+
+	if verifiedCount > 0 {
+		fmt.Printf("\n[!]  VERIFIED SECURITY RISKS: %d\n", verifiedCount)
+	}
+	if heuristicCount > 0 {
+		fmt.Printf("* HEURISTIC WARNINGS: %d\n", heuristicCount)
+	}
+
+	fmt.Printf("\nFindings:\n")
+	for i, finding := range findings {
+		icon := "*"
+		if finding.Type == security.FindingVerifiedRisk {
+			icon = "[!]"
+		}
+		fmt.Printf("%d. %s [%s] %s - %s\n", i+1, icon, finding.Type, finding.Severity, finding.Title)
+		fmt.Printf("   %s\n", finding.Description)
+		if finding.Evidence != "" {
+			fmt.Printf("   Evidence: %s\n", finding.Evidence)
+		}
+	}
+}
+
 var debugCmd = &cobra.Command{
 	Use:   "debug <transaction-hash>",
 	Short: "Debug a failed Soroban transaction",
@@ -245,7 +306,7 @@ Local WASM Replay Mode:
 				return headers
 			}()); err == nil {
 				networkFlag = string(resolved)
-				fmt.Printf("Resolved network: %s\n", networkFlag)
+				fmt.Fprintf(progressWriter(cmd, outputFormat(false)), "Resolved network: %s\n", networkFlag)
 			}
 		}
 
@@ -275,6 +336,12 @@ Local WASM Replay Mode:
 			logger.SetLevel(slog.LevelWarn)
 		}
 
+		format := outputFormat(false)
+		progress := progressWriter(cmd, format)
+		if (demoMode || wasmPath != "") && format != outputTable {
+			return errors.WrapValidationError(fmt.Sprintf("--demo and --wasm do not support --output %s", format))
+		}
+
 		// Apply theme if specified, otherwise auto-detect
 		if themeFlag != "" {
 			visualizer.SetTheme(visualizer.Theme(themeFlag))
@@ -296,6 +363,14 @@ Local WASM Replay Mode:
 		ctx := cmd.Context()
 		txHash := cmdArgs[0]
 
+		// Profile where the run's time goes if requested
+		var prof *timing.Profiler
+		if timingsFlag {
+			prof = timing.New()
+			ctx = timing.WithProfiler(ctx, prof)
+			defer func() { _ = prof.Report().WriteText(os.Stderr) }()
+		}
+
 		// Initialize OpenTelemetry if enabled
 		if tracingEnabled {
 			cleanup, err := telemetry.Init(ctx, telemetry.Config{
@@ -369,13 +444,13 @@ Local WASM Replay Mode:
 
 		if noCacheFlag {
 			client.CacheEnabled = false
-			fmt.Println("🚫 Cache disabled by --no-cache flag")
+			fmt.Fprintln(progress, "🚫 Cache disabled by --no-cache flag")
 		}
 
-		fmt.Printf("Debugging transaction: %s\n", txHash)
-		fmt.Printf("Primary Network: %s\n", networkFlag)
+		fmt.Fprintf(progress, "Debugging transaction: %s\n", txHash)
+		fmt.Fprintf(progress, "Primary Network: %s\n", networkFlag)
 		if compareNetworkFlag != "" {
-			fmt.Printf("Comparing against Network: %s\n", compareNetworkFlag)
+			fmt.Fprintf(progress, "Comparing against Network: %s\n", compareNetworkFlag)
 		}
 
 		// Fetch transaction details
@@ -410,16 +485,18 @@ Local WASM Replay Mode:
 			spinner.StopWithMessage("Transaction found! Starting debug...")
 		}
 
-		fmt.Printf("Fetching transaction: %s\n", txHash)
+		fmt.Fprintf(progress, "Fetching transaction: %s\n", txHash)
 		resp, err := client.GetTransaction(ctx, txHash)
 		if err != nil {
 			return errors.WrapRPCConnectionFailed(err)
 		}
 
-		fmt.Printf("Transaction fetched successfully. Envelope size: %d bytes\n", len(resp.EnvelopeXdr))
+		fmt.Fprintf(progress, "Transaction fetched successfully. Envelope size: %d bytes\n", len(resp.EnvelopeXdr))
 
 		// Extract ledger keys for replay
+		stopDecode := prof.Start(timing.PhaseDecode)
 		keys, err := extractLedgerKeys(resp.ResultMetaXdr)
+		stopDecode()
 		if err != nil {
 			return errors.WrapUnmarshalFailed(err, "result meta")
 		}
@@ -441,10 +518,11 @@ Local WASM Replay Mode:
 		}
 
 		var lastSimResp *simulator.SimulationResponse
+		var runs []debugRun
 
 		for _, ts := range timestamps {
 			if len(timestamps) > 1 {
-				fmt.Printf("\n--- Simulating at Timestamp: %d ---\n", ts)
+				fmt.Fprintf(progress, "\n--- Simulating at Timestamp: %d ---\n", ts)
 			}
 
 			var simResp *simulator.SimulationResponse
@@ -458,10 +536,12 @@ Local WASM Replay Mode:
 						return errors.WrapValidationError(fmt.Sprintf("failed to load snapshot: %v", err))
 					}
 					ledgerEntries = snap.ToMap()
-					fmt.Printf("Loaded %d ledger entries from snapshot\n", len(ledgerEntries))
+					fmt.Fprintf(progress, "Loaded %d ledger entries from snapshot\n", len(ledgerEntries))
 				} else {
 					// Try to extract from metadata first, fall back to fetching
+					stopDecode := prof.Start(timing.PhaseDecode)
 					ledgerEntries, err = rpc.ExtractLedgerEntriesFromMeta(resp.ResultMetaXdr)
+					stopDecode()
 					if err != nil {
 						logger.Logger.Warn("Failed to extract ledger entries from metadata, fetching from network", "error", err)
 						ledgerEntries, err = client.GetLedgerEntries(ctx, keys)
@@ -473,7 +553,7 @@ Local WASM Replay Mode:
 					}
 				}
 
-				fmt.Printf("Running simulation on %s...\n", networkFlag)
+				fmt.Fprintf(progress, "Running simulation on %s...\n", networkFlag)
 				simReq := &simulator.SimulationRequest{
 					EnvelopeXdr:     resp.EnvelopeXdr,
 					ResultMetaXdr:   resp.ResultMetaXdr,
@@ -488,15 +568,20 @@ Local WASM Replay Mode:
 						return fmt.Errorf("invalid protocol version %d: %w", protocolVersionFlag, err)
 					}
 					simReq.ProtocolVersion = &protocolVersionFlag
-					fmt.Printf("Using protocol version override: %d\n", protocolVersionFlag)
+					fmt.Fprintf(progress, "Using protocol version override: %d\n", protocolVersionFlag)
 				}
 				applySimulationFeeMocks(simReq)
 
+				stopRun := prof.Start(timing.PhaseExecution)
 				simResp, err = runner.Run(simReq)
+				stopRun()
 				if err != nil {
 					return errors.WrapSimulationFailed(err, "")
 				}
-				printSimulationResult(networkFlag, simResp)
+				runs = append(runs, debugRun{Timestamp: ts, Result: simResp})
+				if format == outputTable {
+					printSimulationResult(networkFlag, simResp)
+				}
 				// Fetch contract bytecode on demand for any contract calls in the trace; cache via RPC client
 				if client != nil && simResp != nil && len(simResp.DiagnosticEvents) > 0 {
 					contractIDs := collectContractIDsFromDiagnosticEvents(simResp.DiagnosticEvents)
@@ -515,7 +600,9 @@ Local WASM Replay Mode:
 					defer wg.Done()
 					var entries map[string]string
 					var extractErr error
+					stopDecode := prof.Start(timing.PhaseDecode)
 					entries, extractErr = rpc.ExtractLedgerEntriesFromMeta(resp.ResultMetaXdr)
+					stopDecode()
 					if extractErr != nil {
 						entries, extractErr = client.GetLedgerEntries(ctx, keys)
 						if extractErr != nil {
@@ -530,7 +617,9 @@ Local WASM Replay Mode:
 						Timestamp:     ts,
 					}
 					applySimulationFeeMocks(primaryReq)
+					stopRun := prof.Start(timing.PhaseExecution)
 					primaryResult, primaryErr = runner.Run(primaryReq)
+					stopRun()
 				}()
 
 				go func() {
@@ -569,7 +658,9 @@ Local WASM Replay Mode:
 						return
 					}
 
+					stopDecode := prof.Start(timing.PhaseDecode)
 					entries, extractErr := rpc.ExtractLedgerEntriesFromMeta(compareResp.ResultMetaXdr)
+					stopDecode()
 					if extractErr != nil {
 						entries, extractErr = compareClient.GetLedgerEntries(ctx, keys)
 						if extractErr != nil {
@@ -585,7 +676,9 @@ Local WASM Replay Mode:
 						Timestamp:     ts,
 					}
 					applySimulationFeeMocks(compareReq)
+					stopRun := prof.Start(timing.PhaseExecution)
 					compareResult, compareErr = runner.Run(compareReq)
+					stopRun()
 				}()
 
 				wg.Wait()
@@ -604,9 +697,12 @@ Local WASM Replay Mode:
 				}
 
 				simResp = primaryResult // Use primary for further analysis
-				printSimulationResult(networkFlag, primaryResult)
-				printSimulationResult(compareNetworkFlag, compareResult)
-				diffResults(primaryResult, compareResult, networkFlag, compareNetworkFlag)
+				runs = append(runs, debugRun{Timestamp: ts, Result: primaryResult, Compare: compareResult})
+				if format == outputTable {
+					printSimulationResult(networkFlag, primaryResult)
+					printSimulationResult(compareNetworkFlag, compareResult)
+					diffResults(primaryResult, compareResult, networkFlag, compareNetworkFlag)
+				}
 			}
 			lastSimResp = simResp
 		}
@@ -616,7 +712,7 @@ Local WASM Replay Mode:
 		}
 
 		// Analysis: Error Suggestions (Heuristic-based)
-		if len(lastSimResp.Events) > 0 {
+		if len(lastSimResp.Events) > 0 && format == outputTable {
 			suggestionEngine := decoder.NewSuggestionEngine()
 			
 			// Decode events for analysis
@@ -630,46 +726,14 @@ Local WASM Replay Mode:
 		}
 
 		// Analysis: Security
-		fmt.Printf("\n=== Security Analysis ===\n")
 		secDetector := security.NewDetector()
 		findings := secDetector.Analyze(resp.EnvelopeXdr, resp.ResultMetaXdr, lastSimResp.Events, lastSimResp.Logs)
-		if len(findings) == 0 {
-			fmt.Printf("%s No security issues detected\n", visualizer.Success())
-		} else {
-			verifiedCount := 0
-			heuristicCount := 0
-
-			for _, finding := range findings {
-				if finding.Type == security.FindingVerifiedRisk {
-					verifiedCount++
-				} else {
-					heuristicCount++
-				}
-			}
-
-			if verifiedCount > 0 {
-				fmt.Printf("\n[!]  VERIFIED SECURITY RISKS: %d\n", verifiedCount)
-			}
-			if heuristicCount > 0 {
-				fmt.Printf("* HEURISTIC WARNINGS: %d\n", heuristicCount)
-			}
-
-			fmt.Printf("\nFindings:\n")
-			for i, finding := range findings {
-				icon := "*"
-				if finding.Type == security.FindingVerifiedRisk {
-					icon = "[!]"
-				}
-				fmt.Printf("%d. %s [%s] %s - %s\n", i+1, icon, finding.Type, finding.Severity, finding.Title)
-				fmt.Printf("   %s\n", finding.Description)
-				if finding.Evidence != "" {
-					fmt.Printf("   Evidence: %s\n", finding.Evidence)
-				}
-			}
+		if format == outputTable {
+			printSecurityFindings(findings)
 		}
 
 		// Analysis: Token Flows
-		if report, err := tokenflow.BuildReport(resp.EnvelopeXdr, resp.ResultMetaXdr); err == nil && len(report.Agg) > 0 {
+		if report, err := tokenflow.BuildReport(resp.EnvelopeXdr, resp.ResultMetaXdr); err == nil && len(report.Agg) > 0 && format == outputTable {
 			fmt.Printf("\nToken Flow Summary:\n")
 			for _, line := range report.SummaryLines() {
 				fmt.Printf("  %s\n", line)
@@ -686,11 +750,11 @@ Local WASM Replay Mode:
 		applySimulationFeeMocks(simReq)
 		simReqJSON, err := json.Marshal(simReq)
 		if err != nil {
-			fmt.Printf("Warning: failed to serialize simulation data: %v\n", err)
+			fmt.Fprintf(progress, "Warning: failed to serialize simulation data: %v\n", err)
 		}
 		simRespJSON, err := json.Marshal(lastSimResp)
 		if err != nil {
-			fmt.Printf("Warning: failed to serialize simulation results: %v\n", err)
+			fmt.Fprintf(progress, "Warning: failed to serialize simulation results: %v\n", err)
 		}
 
 		sessionData := &session.SessionData{
@@ -710,6 +774,19 @@ Local WASM Replay Mode:
 			SchemaVersion:   session.SchemaVersion,
 		}
 		SetCurrentSession(sessionData)
+		if format != outputTable {
+			if findings == nil {
+				findings = []security.Finding{}
+			}
+			return writeStructured(cmd.OutOrStdout(), format, debugReport{
+				TxHash:         txHash,
+				Network:        networkFlag,
+				CompareNetwork: compareNetworkFlag,
+				Runs:           runs,
+				Findings:       findings,
+				SessionID:      sessionData.ID,
+			})
+		}
 		fmt.Printf("\nSession created: %s\n", sessionData.ID)
 		fmt.Printf("Run 'erst session save' to persist this session.\n")
 		return nil
@@ -1139,7 +1216,9 @@ func init() {
 	debugCmd.Flags().IntVar(&watchTimeoutFlag, "watch-timeout", 30, "Timeout in seconds for watch mode")
 	debugCmd.Flags().Uint32Var(&mockBaseFeeFlag, "mock-base-fee", 0, "Override base fee (stroops) for local fee sufficiency checks")
 	debugCmd.Flags().Uint64Var(&mockGasPriceFlag, "mock-gas-price", 0, "Override gas price multiplier for local fee sufficiency checks")
+	debugCmd.Flags().BoolVar(&timingsFlag, "timings", false, "Print where the run spent its time (network, XDR decode, cache, execution) to stderr")
 
+	supportStructuredOutput(debugCmd)
 	rootCmd.AddCommand(debugCmd)
 }
 
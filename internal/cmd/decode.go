@@ -0,0 +1,107 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	decodeType string
+	decodeJSON bool
+)
+
+var decodeCmd = &cobra.Command{
+	Use:   "decode <base64|->",
+	Short: "Decode and pretty-print any XDR blob",
+	Long: `Decode a base64 XDR blob and print it in readable form. The type is detected
+automatically: transaction envelopes, results and meta, ledger keys and
+entries, diagnostic events and SCVals are recognized. Use --type when a blob
+is ambiguous or detection picks the wrong one.
+
+Pass '-' to read the blob from stdin. --json prints the decoded value as
+JSON, ready to pipe into jq.
+
+Examples:
+  erst decode AAAAAgAAAAB...
+  erst decode --type key AAAABgAAAAE...
+  curl -s ... | jq -r .envelope_xdr | erst decode - --json | jq .value.operations`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDecode,
+}
+
+func runDecode(cmd *cobra.Command, args []string) error {
+	blob := args[0]
+	if blob == "-" {
+		data, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return errors.WrapValidationError(fmt.Sprintf("failed to read stdin: %v", err))
+		}
+		blob = string(data)
+	}
+	blob = strings.TrimSpace(blob)
+	if blob == "" {
+		return errors.WrapValidationError("nothing to decode: the XDR blob is empty")
+	}
+
+	var (
+		detected *decoder.DetectedXDR
+		err      error
+	)
+	if decodeType == "" || decodeType == "auto" {
+		detected, err = decoder.DetectXDR(blob)
+	} else {
+		kind, kerr := decoder.ParseXDRKind(decodeType)
+		if kerr != nil {
+			return errors.WrapValidationError(fmt.Sprintf("unsupported XDR type: %s (use: auto, envelope, result, meta, entry, key, event, scval)", decodeType))
+		}
+		detected, err = decoder.DecodeXDRAs(blob, kind)
+	}
+	if err != nil {
+		return errors.WrapUnmarshalFailed(err, "XDR")
+	}
+
+	format := outputFormat(decodeJSON)
+	if format == outputTable {
+		out, err := decoder.FormatDetected(detected, "text")
+		if err != nil {
+			return errors.WrapValidationError(fmt.Sprintf("formatting failed: %v", err))
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), strings.TrimRight(out, "\n"))
+	} else {
+		out, err := decoder.FormatDetected(detected, "json")
+		if err != nil {
+			return errors.WrapValidationError(fmt.Sprintf("formatting failed: %v", err))
+		}
+		if err := writeStructured(cmd.OutOrStdout(), format, json.RawMessage(out)); err != nil {
+			return err
+		}
+	}
+
+	// A blob that also decodes as other kinds is worth pointing out, but
+	// not on stdout where it would break JSON consumers.
+	if len(detected.Candidates) > 1 {
+		others := make([]string, 0, len(detected.Candidates)-1)
+		for _, k := range detected.Candidates[1:] {
+			others = append(others, string(k))
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "note: also decodes as %s; use --type to choose\n", strings.Join(others, ", "))
+	}
+	return nil
+}
+
+func init() {
+	decodeCmd.Flags().StringVarP(&decodeType, "type", "t", "auto", "XDR type: auto, envelope, result, meta, entry, key, event, scval")
+	decodeCmd.Flags().BoolVar(&decodeJSON, "json", false, "Print the decoded value as JSON")
+
+	supportStructuredOutput(decodeCmd)
+	rootCmd.AddCommand(decodeCmd)
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runDecodeWith(t *testing.T, typ string, asJSON bool, stdin string, args ...string) (string, string, error) {
+	t.Helper()
+	decodeType, decodeJSON = typ, asJSON
+	defer func() { decodeType, decodeJSON = "auto", false }()
+
+	cmd, out := testCommand(stdin)
+	errOut := &bytes.Buffer{}
+	cmd.SetErr(errOut)
+	err := runDecode(cmd, args)
+	return out.String(), errOut.String(), err
+}
+
+func TestDecodeEnvelopeFromStdin(t *testing.T) {
+	out, _, err := runDecodeWith(t, "auto", true, testEnvelope(t)+"\n", "-")
+	require.NoError(t, err)
+
+	var got struct {
+		Kind  string         `json:"kind"`
+		Value map[string]any `json:"value"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &got))
+	assert.Equal(t, "transaction_envelope", got.Kind)
+	assert.NotEmpty(t, got.Value["operations"])
+}
+
+func TestDecodeTypeOverride(t *testing.T) {
+	key := mustB64(t, xdr.LedgerKey{Type: xdr.LedgerEntryTypeContractCode, ContractCode: &xdr.LedgerKeyContractCode{Hash: xdr.Hash{1}}})
+
+	out, _, err := runDecodeWith(t, "key", false, "", key)
+	require.NoError(t, err)
+	assert.Contains(t, out, "ledger_key")
+
+	_, _, err = runDecodeWith(t, "bogus", false, "", key)
+	assert.ErrorContains(t, err, "unsupported XDR type")
+}
+
+func TestDecodeRejectsBadInput(t *testing.T) {
+	_, _, err := runDecodeWith(t, "auto", false, "  \n", "-")
+	assert.ErrorContains(t, err, "empty")
+
+	_, _, err = runDecodeWith(t, "auto", false, "", "not base64!")
+	assert.Error(t, err)
+}
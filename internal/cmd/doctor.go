@@ -5,12 +5,14 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
 	"strings"
 
+	"github.com/dotandev/hintents/internal/simulator"
 	"github.com/spf13/cobra"
 )
 
@@ -22,76 +24,146 @@ type DependencyStatus struct {
 	FixHint   string
 }
 
+var (
+	doctorNet     networkFlags
+	doctorOffline bool
+)
+
 var doctorCmd = &cobra.Command{
 	Use:   "doctor",
-	Short: "Diagnose development environment setup",
-	Long: `Check the status of required dependencies and development tools.
+	Short: "Diagnose development environment and network setup",
+	Long: `Check the status of required dependencies, development tools and the
+configured network.
 
 This command verifies:
   - Go installation and version
   - Rust toolchain (cargo, rustc)
   - Simulator binary (erst-sim)
+  - Connectivity and latency of each Horizon and Soroban RPC endpoint
+  - That the endpoints serve the network's passphrase
+  - That the RPC token, if any, is accepted
+  - That the endpoints agree on a protocol version the simulator supports
+
+The network is taken from --network and the active profile like any other
+command. Every failed check comes with a hint on how to fix it.
 
 Use this to troubleshoot installation issues or verify your setup.`,
 	Example: `  # Check environment status
   erst doctor
 
+  # Check a profile's endpoints and token
+  erst doctor --profile staging
+
   # View detailed diagnostics
-  erst doctor --verbose`,
+  erst doctor --verbose
+
+  # Only check the local toolchain
+  erst doctor --offline`,
 	Args: cobra.NoArgs,
 	RunE: runDoctor,
 }
 
+// doctorReport is the output of erst doctor; its JSON form is stable.
+type doctorReport struct {
+	// Network is the checked network; empty with --offline.
+	Network string        `json:"network,omitempty"`
+	Checks  []doctorCheck `json:"checks"`
+	OK      bool          `json:"ok"`
+}
+
 func runDoctor(cmd *cobra.Command, args []string) error {
 	verbose, _ := cmd.Flags().GetBool("verbose")
 
-	fmt.Println("Erst Environment Diagnostics")
-	fmt.Println("=============================")
-	fmt.Println()
-
 	dependencies := []DependencyStatus{
 		checkGo(verbose),
 		checkRust(verbose),
 		checkCargo(verbose),
 		checkSimulator(verbose),
 	}
-
-	// Print results
-	allOK := true
+	report := doctorReport{OK: true}
 	for _, dep := range dependencies {
+		report.Checks = append(report.Checks, dependencyCheck(dep))
+	}
+
+	if !doctorOffline {
+		client, err := doctorNet.client(cmd)
+		if err != nil {
+			return err
+		}
+		report.Network = client.GetNetworkName()
+		report.Checks = append(report.Checks, networkChecks(cmd.Context(), client, simulator.Supported())...)
+	}
+	for _, check := range report.Checks {
+		if check.Status == checkFail {
+			report.OK = false
+		}
+	}
+
+	w := cmd.OutOrStdout()
+	if format := outputFormat(false); format != outputTable {
+		return writeStructured(w, format, report)
+	}
+	writeDoctorReport(w, report, verbose)
+	return nil
+}
+
+func dependencyCheck(dep DependencyStatus) doctorCheck {
+	check := doctorCheck{Group: "environment", Name: dep.Name, Status: checkPass, Detail: dep.Version, Path: dep.Path}
+	if !dep.Installed {
+		check.Status = checkFail
+		check.Hint = dep.FixHint
+	}
+	return check
+}
+
+func writeDoctorReport(w io.Writer, report doctorReport, verbose bool) {
+	fmt.Fprintln(w, "Erst Environment Diagnostics")
+	fmt.Fprintln(w, "=============================")
+	fmt.Fprintln(w)
+
+	group := "environment"
+	for _, check := range report.Checks {
+		if check.Group != group {
+			group = check.Group
+			fmt.Fprintln(w)
+			fmt.Fprintf(w, "Network: %s\n", report.Network)
+		}
+
 		status := "[OK]"
 		statusColor := "\033[32m" // Green
-		if !dep.Installed {
+		switch check.Status {
+		case checkWarn:
+			status = "[WARN]"
+			statusColor = "\033[33m" // Yellow
+		case checkFail:
 			status = "[FAIL]"
 			statusColor = "\033[31m" // Red
-			allOK = false
 		}
 
-		fmt.Printf("%s%s\033[0m %s", statusColor, status, dep.Name)
-		if dep.Installed && dep.Version != "" {
-			fmt.Printf(" (%s)", dep.Version)
+		fmt.Fprintf(w, "%s%s\033[0m %s", statusColor, status, check.Name)
+		if check.Detail != "" {
+			fmt.Fprintf(w, " (%s)", check.Detail)
 		}
-		fmt.Println()
+		fmt.Fprintln(w)
 
-		if verbose && dep.Path != "" {
-			fmt.Printf("  Path: %s\n", dep.Path)
+		if verbose && check.Path != "" {
+			fmt.Fprintf(w, "  Path: %s\n", check.Path)
 		}
 
-		if !dep.Installed && dep.FixHint != "" {
-			fmt.Printf("  \033[33m→ %s\033[0m\n", dep.FixHint)
+		if check.Hint != "" {
+			fmt.Fprintf(w, "  \033[33m→ %s\033[0m\n", check.Hint)
 		}
 	}
 
-	fmt.Println()
+	fmt.Fprintln(w)
 
 	// Summary
-	if allOK {
-		fmt.Println("\033[32m[OK] All dependencies are installed and ready!\033[0m")
-		return nil
+	if report.OK {
+		fmt.Fprintln(w, "\033[32m[OK] All checks passed!\033[0m")
+		return
 	}
 
-	fmt.Println("\033[33m⚠ Some dependencies are missing. Follow the hints above to fix.\033[0m")
-	return nil
+	fmt.Fprintln(w, "\033[33m⚠ Some checks failed. Follow the hints above to fix.\033[0m")
 }
 
 func checkGo(verbose bool) DependencyStatus {
@@ -224,6 +296,9 @@ func checkSimulator(verbose bool) DependencyStatus {
 }
 
 func init() {
-	rootCmd.AddCommand(doctorCmd)
+	doctorNet.register(doctorCmd)
 	doctorCmd.Flags().BoolP("verbose", "v", false, "Show detailed diagnostic information")
+	doctorCmd.Flags().BoolVar(&doctorOffline, "offline", false, "Skip the network checks")
+	supportStructuredOutput(doctorCmd)
+	rootCmd.AddCommand(doctorCmd)
 }
@@ -0,0 +1,200 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+)
+
+// Statuses of a doctor check.
+const (
+	checkPass = "pass"
+	checkWarn = "warn"
+	checkFail = "fail"
+)
+
+// doctorSlowLatency is the endpoint latency above which doctor warns.
+const doctorSlowLatency = 2 * time.Second
+
+// doctorCheck is one line of the erst doctor report.
+type doctorCheck struct {
+	// Group is "environment" or "network".
+	Group string `json:"group"`
+	Name  string `json:"name"`
+	// Status is "pass", "warn" or "fail".
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	Path   string `json:"path,omitempty"`
+	Hint   string `json:"hint,omitempty"`
+}
+
+// networkChecks probes the client's endpoints and checks that they are
+// reachable, serve the configured network, accept the token and run a
+// protocol the simulator supports, which are given in supported.
+func networkChecks(ctx context.Context, client *rpc.Client, supported []uint32) []doctorCheck {
+	endpoints := client.CheckEndpoints(ctx)
+	checks := make([]doctorCheck, 0, len(endpoints)+3)
+	var reachable []rpc.EndpointCheck
+	for _, e := range endpoints {
+		checks = append(checks, endpointCheck(e))
+		if e.Err == nil {
+			reachable = append(reachable, e)
+		}
+	}
+	checks = append(checks, tokenCheck(client.HasToken(), endpoints))
+	if len(reachable) == 0 {
+		return checks
+	}
+	checks = append(checks, passphraseCheck(client.GetNetworkPassphrase(), reachable))
+	return append(checks, protocolCheck(reachable, supported))
+}
+
+func endpointName(e rpc.EndpointCheck) string {
+	if e.Kind == rpc.EndpointHorizon {
+		return "Horizon " + e.URL
+	}
+	return "Soroban RPC " + e.URL
+}
+
+func endpointCheck(e rpc.EndpointCheck) doctorCheck {
+	check := doctorCheck{Group: "network", Name: endpointName(e), Status: checkPass}
+	switch {
+	case e.Unauthorized():
+		check.Status = checkFail
+		check.Detail = fmt.Sprintf("HTTP %d after %s", e.StatusCode, e.Latency.Round(time.Millisecond))
+		check.Hint = "The endpoint rejected the request's credentials; see the RPC token check"
+	case errors.Is(e.Err, errors.ErrRateLimitExceeded):
+		check.Status = checkWarn
+		check.Detail = "rate limited (HTTP 429)"
+		check.Hint = "Retry later, or use a token or a provider with a higher limit"
+	case e.Err != nil:
+		check.Status = checkFail
+		check.Detail = e.Err.Error()
+		if e.Kind == rpc.EndpointHorizon {
+			check.Hint = "Check the URL and your connection, or set another with --rpc-url or: erst config set horizon_url <url>"
+		} else {
+			check.Hint = "Check the URL and your connection, or set another with --soroban-url or: erst config set soroban_url <url>"
+		}
+	default:
+		check.Detail = e.Latency.Round(time.Millisecond).String()
+		if e.Version != "" {
+			check.Detail += ", version " + e.Version
+		}
+		if e.Latency > doctorSlowLatency {
+			check.Status = checkWarn
+			check.Hint = "The endpoint is slow; a provider closer to you may respond faster"
+		}
+	}
+	return check
+}
+
+func tokenCheck(hasToken bool, endpoints []rpc.EndpointCheck) doctorCheck {
+	check := doctorCheck{Group: "network", Name: "RPC token", Status: checkPass}
+	var rejected []string
+	accepted := false
+	for _, e := range endpoints {
+		switch {
+		case e.Unauthorized():
+			rejected = append(rejected, e.URL)
+		case e.Err == nil:
+			accepted = true
+		}
+	}
+	switch {
+	case len(rejected) > 0 && hasToken:
+		check.Status = checkFail
+		check.Detail = "rejected by " + strings.Join(rejected, ", ")
+		check.Hint = "Check the token, or set another with --rpc-token, ERST_RPC_TOKEN or: erst config set rpc_token <token>"
+	case len(rejected) > 0:
+		check.Status = checkFail
+		check.Detail = "required by " + strings.Join(rejected, ", ")
+		check.Hint = "Set a token with --rpc-token, ERST_RPC_TOKEN or: erst config set rpc_token <token>"
+	case hasToken && accepted:
+		check.Detail = "accepted"
+	case hasToken:
+		check.Status = checkWarn
+		check.Detail = "not verified; no endpoint answered"
+	default:
+		check.Detail = "not set, and not required"
+	}
+	return check
+}
+
+func passphraseCheck(expected string, reachable []rpc.EndpointCheck) doctorCheck {
+	check := doctorCheck{Group: "network", Name: "Network passphrase", Status: checkPass}
+	var mismatched []string
+	for _, e := range reachable {
+		if e.Passphrase != "" && e.Passphrase != expected {
+			mismatched = append(mismatched, fmt.Sprintf("%s serves %q", e.URL, e.Passphrase))
+		}
+	}
+	switch {
+	case expected == "":
+		check.Status = checkWarn
+		check.Detail = "the network has no passphrase configured"
+		check.Hint = "Add the passphrase to the custom network so signatures and network checks use it"
+	case len(mismatched) > 0:
+		check.Status = checkFail
+		check.Detail = fmt.Sprintf("expected %q, but %s", expected, strings.Join(mismatched, "; "))
+		check.Hint = "The endpoints belong to another network; pick the matching --network or fix the endpoint URLs"
+	default:
+		check.Detail = fmt.Sprintf("%q on every endpoint", expected)
+	}
+	return check
+}
+
+func protocolCheck(reachable []rpc.EndpointCheck, supported []uint32) doctorCheck {
+	check := doctorCheck{Group: "network", Name: "Protocol version", Status: checkPass}
+	var versions []uint32
+	var reports []string
+	var pending uint32
+	for _, e := range reachable {
+		if e.ProtocolVersion == 0 {
+			continue
+		}
+		versions = append(versions, e.ProtocolVersion)
+		reports = append(reports, fmt.Sprintf("%s at %d", endpointName(e), e.ProtocolVersion))
+		if e.CoreProtocolVersion > e.ProtocolVersion {
+			pending = max(pending, e.CoreProtocolVersion)
+		}
+	}
+	if len(versions) == 0 {
+		check.Status = checkWarn
+		check.Detail = "no endpoint reported it"
+		return check
+	}
+	slices.Sort(versions)
+	current := versions[len(versions)-1]
+	switch {
+	case versions[0] != current:
+		check.Status = checkFail
+		check.Detail = strings.Join(reports, ", ")
+		check.Hint = "The endpoints are out of sync; one of them is lagging or has not been upgraded yet"
+	case !slices.Contains(supported, current):
+		check.Status = checkWarn
+		check.Detail = fmt.Sprintf("the network runs protocol %d; the simulator supports %s", current, joinVersions(supported))
+		check.Hint = "Upgrade erst to simulate transactions with this protocol's rules"
+	default:
+		check.Detail = fmt.Sprintf("protocol %d", current)
+	}
+	if pending > current {
+		check.Detail += fmt.Sprintf("; Stellar Core supports %d, so a network upgrade may be pending", pending)
+	}
+	return check
+}
+
+func joinVersions(versions []uint32) string {
+	parts := make([]string, len(versions))
+	for i, v := range versions {
+		parts[i] = fmt.Sprint(v)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/rpc/rpctest"
+	"github.com/stellar/go-stellar-sdk/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func checksByName(checks []doctorCheck) map[string]doctorCheck {
+	byName := make(map[string]doctorCheck, len(checks))
+	for _, c := range checks {
+		byName[c.Name] = c
+	}
+	return byName
+}
+
+func TestNetworkChecksPass(t *testing.T) {
+	horizon := rpctest.NewHorizonServer()
+	defer horizon.Close()
+	soroban := rpctest.NewSorobanServer()
+	defer soroban.Close()
+
+	client, err := rpc.NewClient(rpc.WithNetwork(rpc.Testnet), rpc.WithHorizonURL(horizon.URL), rpc.WithSorobanURL(soroban.URL))
+	require.NoError(t, err)
+	checks := networkChecks(context.Background(), client, []uint32{20, 21, 22})
+
+	require.Len(t, checks, 5)
+	for _, c := range checks {
+		assert.Equal(t, checkPass, c.Status, c.Name)
+		assert.Equal(t, "network", c.Group)
+		assert.Empty(t, c.Hint, c.Name)
+	}
+	byName := checksByName(checks)
+	assert.Contains(t, byName["Horizon "+horizon.URL].Detail, "version rpctest")
+	assert.Equal(t, "not set, and not required", byName["RPC token"].Detail)
+	assert.Equal(t, "protocol 22", byName["Protocol version"].Detail)
+
+	assert.Equal(t, checkWarn, checksByName(networkChecks(context.Background(), client, []uint32{20, 21}))["Protocol version"].Status)
+}
+
+func TestNetworkChecksFail(t *testing.T) {
+	horizon := rpctest.NewHorizonServer()
+	defer horizon.Close()
+	locked := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer locked.Close()
+	soroban := rpctest.NewSorobanServer()
+	defer soroban.Close()
+	soroban.On("getNetwork", rpctest.Result(map[string]any{"passphrase": network.PublicNetworkPassphrase, "protocolVersion": 21}))
+
+	client, err := rpc.NewClient(rpc.WithNetwork(rpc.Testnet), rpc.WithToken("stale"),
+		rpc.WithAltURLs([]string{horizon.URL, locked.URL}), rpc.WithSorobanURL(soroban.URL))
+	require.NoError(t, err)
+	byName := checksByName(networkChecks(context.Background(), client, []uint32{20, 21, 22}))
+
+	assert.Equal(t, checkPass, byName["Horizon "+horizon.URL].Status)
+	assert.Equal(t, checkFail, byName["Horizon "+locked.URL].Status)
+
+	token := byName["RPC token"]
+	assert.Equal(t, checkFail, token.Status)
+	assert.Equal(t, "rejected by "+locked.URL, token.Detail)
+	assert.Contains(t, token.Hint, "--rpc-token")
+
+	passphrase := byName["Network passphrase"]
+	assert.Equal(t, checkFail, passphrase.Status)
+	assert.Contains(t, passphrase.Detail, soroban.URL+` serves "Public Global Stellar Network ; September 2015"`)
+
+	protocol := byName["Protocol version"]
+	assert.Equal(t, checkFail, protocol.Status)
+	assert.Equal(t, "Horizon "+horizon.URL+" at 22, Soroban RPC "+soroban.URL+" at 21", protocol.Detail)
+}
+
+func TestNetworkChecksUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	client, err := rpc.NewClient(rpc.WithNetwork(rpc.Testnet), rpc.WithHorizonURL(url), rpc.WithSorobanURL(url))
+	require.NoError(t, err)
+	checks := networkChecks(context.Background(), client, nil)
+
+	// Without a reachable endpoint there is nothing to compare.
+	require.Len(t, checks, 3)
+	assert.Equal(t, checkFail, checks[0].Status)
+	assert.Contains(t, checks[0].Hint, "--rpc-url")
+	assert.Equal(t, checkFail, checks[1].Status)
+	assert.Contains(t, checks[1].Hint, "--soroban-url")
+	assert.Equal(t, checkPass, checks[2].Status)
+}
@@ -52,12 +52,25 @@ Example:
 	RunE: runDryRun,
 }
 
+// dryRunReport is the output of erst dry-run; its JSON form is stable.
+// Source is "preflight" when Soroban RPC simulated the transaction, with
+// MinResourceFee set, and "simulator" when the local simulator did, with
+// EstimatedFee set. Fees are in stroops.
+type dryRunReport struct {
+	Source          string `json:"source"`
+	MinResourceFee  string `json:"min_resource_fee,omitempty"`
+	EstimatedFee    int64  `json:"estimated_fee,omitempty"`
+	CPUInstructions uint64 `json:"cpu_instructions"`
+	MemoryBytes     uint64 `json:"memory_bytes"`
+}
+
 func init() {
 	dryRunCmd.Flags().StringVarP(&dryRunNetworkFlag, "network", "n", string(rpc.Mainnet), "Stellar network to use (testnet, mainnet, futurenet)")
 	dryRunCmd.Flags().StringVar(&dryRunRPCURLFlag, "rpc-url", "", "Custom Horizon RPC URL to use")
 	dryRunCmd.Flags().StringVar(&dryRunRPCTokenFlag, "rpc-token", "", "RPC authentication token (can also use ERST_RPC_TOKEN env var)")
 	dryRunCmd.Flags().StringVar(&dryRunRPCHeadersFlag, "rpc-headers", "", "Additional headers to include on RPC requests (JSON or key=value list)")
 
+	supportStructuredOutput(dryRunCmd)
 	rootCmd.AddCommand(dryRunCmd)
 }
 
@@ -126,9 +139,18 @@ func runDryRun(cmd *cobra.Command, args []string) error {
 			mem = preflight.Result.Cost.MemBytes_
 		}
 
-		fmt.Printf("Min resource fee (stroops): %s\n", fee)
+		w := cmd.OutOrStdout()
+		if format := outputFormat(false); format != outputTable {
+			return writeStructured(w, format, dryRunReport{
+				Source:          "preflight",
+				MinResourceFee:  fee,
+				CPUInstructions: uint64(cpu),
+				MemoryBytes:     uint64(mem),
+			})
+		}
+		fmt.Fprintf(w, "Min resource fee (stroops): %s\n", fee)
 		if cpu != 0 || mem != 0 {
-			fmt.Printf("Preflight cost: CPU=%d, MEM=%d\n", cpu, mem)
+			fmt.Fprintf(w, "Preflight cost: CPU=%d, MEM=%d\n", cpu, mem)
 		}
 		return nil
 	}
@@ -170,8 +192,17 @@ func runDryRun(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("Estimated required fee (stroops): %d\n", est)
-	fmt.Printf("Budget usage: CPU=%d, MEM=%d\n", resp.BudgetUsage.CPUInstructions, resp.BudgetUsage.MemoryBytes)
+	w := cmd.OutOrStdout()
+	if format := outputFormat(false); format != outputTable {
+		return writeStructured(w, format, dryRunReport{
+			Source:          "simulator",
+			EstimatedFee:    est,
+			CPUInstructions: resp.BudgetUsage.CPUInstructions,
+			MemoryBytes:     resp.BudgetUsage.MemoryBytes,
+		})
+	}
+	fmt.Fprintf(w, "Estimated required fee (stroops): %d\n", est)
+	fmt.Fprintf(w, "Budget usage: CPU=%d, MEM=%d\n", resp.BudgetUsage.CPUInstructions, resp.BudgetUsage.MemoryBytes)
 
 	return nil
 }
@@ -0,0 +1,255 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/dotandev/hintents/internal/abi"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/spf13/cobra"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// eventsLookback is how far back erst events looks without --start-ledger
+// or --follow: about an hour of ledgers.
+const eventsLookback = 720
+
+var (
+	eventsNet         networkFlags
+	eventsContracts   []string
+	eventsTopics      []string
+	eventsType        string
+	eventsStartLedger uint32
+	eventsLimit       int
+	eventsFollow      bool
+	eventsInterval    time.Duration
+	eventsJSON        bool
+)
+
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Print decoded contract events, optionally following new ones",
+	Long: `Query contract events from Soroban RPC and print them decoded. Events of
+contracts whose spec can be fetched, and of SEP-41 tokens, are shown with
+their event name and named fields.
+
+--topic takes a comma-separated pattern, one segment per topic: a symbol, a
+G... or C... address, a base64 ScVal, '*' for any single topic or a trailing
+'**' for any remaining topics. Repeat it to match any of several patterns.
+
+Without --start-ledger events of roughly the last hour are printed, or, with
+--follow, only new ones. --follow keeps polling from where the last page
+ended, like tail -f, until interrupted. --json prints one event per line.
+
+Examples:
+  erst events --contract CABC... --network testnet
+  erst events --contract CABC... --topic transfer,*,GDEF... --follow
+  erst events --start-ledger 51000000 --type contract --json | jq .name`,
+	Args: cobra.NoArgs,
+	RunE: runEvents,
+}
+
+func runEvents(cmd *cobra.Command, _ []string) error {
+	filter, err := eventsFilter()
+	if err != nil {
+		return err
+	}
+	client, err := eventsNet.client(cmd)
+	if err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	if eventsFollow {
+		var stop context.CancelFunc
+		ctx, stop = signal.NotifyContext(ctx, os.Interrupt)
+		defer stop()
+	}
+
+	req := rpc.EventsRequest{StartLedger: eventsStartLedger, Filters: []rpc.EventFilter{filter}, Limit: eventsLimit}
+	if req.StartLedger == 0 {
+		latest, err := client.GetLatestLedger(ctx)
+		if err != nil {
+			return err
+		}
+		req.StartLedger = latest.Sequence
+		if !eventsFollow {
+			if req.StartLedger > eventsLookback {
+				req.StartLedger -= eventsLookback
+			} else {
+				req.StartLedger = 1
+			}
+		}
+	}
+
+	registry := abi.NewEventRegistry(client)
+	w := cmd.OutOrStdout()
+	for {
+		events, cursor, err := registry.FetchEvents(ctx, req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		for _, ev := range events {
+			if err := printEvent(w, ev); err != nil {
+				return err
+			}
+		}
+		if cursor != "" {
+			req = rpc.EventsRequest{Cursor: cursor, Filters: req.Filters, Limit: req.Limit}
+		}
+
+		// A full page means more are waiting; otherwise we are caught up.
+		if cursor != "" && len(events) > 0 && len(events) >= eventsLimit {
+			continue
+		}
+		if !eventsFollow {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(eventsInterval):
+		}
+	}
+}
+
+// eventsFilter builds the getEvents filter from the command's flags.
+func eventsFilter() (rpc.EventFilter, error) {
+	filter := rpc.EventFilter{ContractIDs: eventsContracts}
+	for _, id := range eventsContracts {
+		if !strkey.IsValidContractAddress(id) {
+			return filter, errors.WrapValidationError(fmt.Sprintf("invalid contract ID: %s", id))
+		}
+	}
+	switch eventsType {
+	case "", "all":
+	case "contract", "system", "diagnostic":
+		filter.Type = eventsType
+	default:
+		return filter, errors.WrapValidationError(fmt.Sprintf("invalid event type: %s (use: all, contract, system, diagnostic)", eventsType))
+	}
+	for _, pattern := range eventsTopics {
+		topic, err := parseTopicPattern(pattern)
+		if err != nil {
+			return filter, err
+		}
+		filter.Topics = append(filter.Topics, topic)
+	}
+	return filter, nil
+}
+
+// parseTopicPattern turns "transfer,*,GABC..." into the base64 ScVals
+// getEvents expects, passing wildcards through.
+func parseTopicPattern(pattern string) ([]string, error) {
+	segments := strings.Split(pattern, ",")
+	out := make([]string, 0, len(segments))
+	for i, seg := range segments {
+		seg = strings.TrimSpace(seg)
+		switch {
+		case seg == "*":
+			out = append(out, seg)
+			continue
+		case seg == "**":
+			if i != len(segments)-1 {
+				return nil, errors.WrapValidationError(fmt.Sprintf("topic pattern %q: '**' must be the last segment", pattern))
+			}
+			out = append(out, seg)
+			continue
+		}
+		val, err := topicValue(seg)
+		if err != nil {
+			return nil, errors.WrapValidationError(fmt.Sprintf("topic pattern %q: %v", pattern, err))
+		}
+		b64, err := xdr.MarshalBase64(val)
+		if err != nil {
+			return nil, errors.WrapMarshalFailed(err)
+		}
+		out = append(out, b64)
+	}
+	return out, nil
+}
+
+func topicValue(seg string) (xdr.ScVal, error) {
+	var val xdr.ScVal
+	switch {
+	case seg == "":
+		return val, fmt.Errorf("empty segment")
+	case strkey.IsValidEd25519PublicKey(seg):
+		id, err := xdr.AddressToAccountId(seg)
+		if err != nil {
+			return val, err
+		}
+		return xdr.NewScVal(xdr.ScValTypeScvAddress, xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeAccount, AccountId: &id})
+	case strkey.IsValidContractAddress(seg):
+		raw := strkey.MustDecode(strkey.VersionByteContract, seg)
+		var id xdr.ContractId
+		copy(id[:], raw)
+		return xdr.NewScVal(xdr.ScValTypeScvAddress, xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &id})
+	case xdr.SafeUnmarshalBase64(seg, &val) == nil:
+		return val, nil
+	}
+	sym := xdr.ScSymbol(seg)
+	return xdr.NewScVal(xdr.ScValTypeScvSymbol, sym)
+}
+
+func printEvent(w io.Writer, ev *abi.DecodedEvent) error {
+	if format := outputFormat(eventsJSON); format != outputTable {
+		return writeRecord(w, format, ev)
+	}
+	_, err := fmt.Fprintf(w, "%d  %s  %s  tx=%s\n", ev.Ledger, ev.ContractID, eventBody(ev), ev.TxHash)
+	return err
+}
+
+// eventBody renders an event as name(field=value, ...), or as its raw
+// topics and data when no spec describes it.
+func eventBody(ev *abi.DecodedEvent) string {
+	if ev.Name == "" {
+		return fmt.Sprintf("topics=%s data=%s", compactJSON(ev.Topics), compactJSON(ev.Data))
+	}
+	fields := make([]string, 0, len(ev.Fields))
+	for _, f := range ev.Fields {
+		fields = append(fields, f.Name+"="+compactJSON(f.Value))
+	}
+	return fmt.Sprintf("%s(%s)", ev.Name, strings.Join(fields, ", "))
+}
+
+// compactJSON renders a decoded value on one line.
+func compactJSON(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+func init() {
+	eventsNet.register(eventsCmd)
+	eventsCmd.Flags().StringSliceVar(&eventsContracts, "contract", nil, "Only events of these contract IDs (repeatable, up to 5)")
+	eventsCmd.Flags().StringArrayVar(&eventsTopics, "topic", nil, "Topic pattern, e.g. transfer,*,GABC... (repeatable)")
+	eventsCmd.Flags().StringVar(&eventsType, "type", "all", "Event type: all, contract, system or diagnostic")
+	eventsCmd.Flags().Uint32Var(&eventsStartLedger, "start-ledger", 0, "First ledger to return events from")
+	eventsCmd.Flags().IntVar(&eventsLimit, "limit", 100, "Events to request per page")
+	eventsCmd.Flags().BoolVarP(&eventsFollow, "follow", "f", false, "Keep polling for new events until interrupted")
+	eventsCmd.Flags().DurationVar(&eventsInterval, "interval", rpc.DefaultLedgerPollInterval, "How often to poll in --follow mode")
+	eventsCmd.Flags().BoolVar(&eventsJSON, "json", false, "Print events as JSON, one per line")
+
+	supportStructuredOutput(eventsCmd)
+	rootCmd.AddCommand(eventsCmd)
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dotandev/hintents/internal/rpc/rpctest"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testContractID(t *testing.T) string {
+	t.Helper()
+	id, err := strkey.Encode(strkey.VersionByteContract, make([]byte, 32))
+	require.NoError(t, err)
+	return id
+}
+
+func TestParseTopicPattern(t *testing.T) {
+	account := keypair.MustRandom().Address()
+	topics, err := parseTopicPattern("transfer, *," + account + ",**")
+	require.NoError(t, err)
+	require.Len(t, topics, 4)
+
+	var sym xdr.ScVal
+	require.NoError(t, xdr.SafeUnmarshalBase64(topics[0], &sym))
+	assert.Equal(t, xdr.ScSymbol("transfer"), *sym.Sym)
+	assert.Equal(t, "*", topics[1])
+	var addr xdr.ScVal
+	require.NoError(t, xdr.SafeUnmarshalBase64(topics[2], &addr))
+	assert.Equal(t, xdr.ScValTypeScvAddress, addr.Type)
+	assert.Equal(t, "**", topics[3])
+
+	_, err = parseTopicPattern("**,transfer")
+	assert.Error(t, err)
+	_, err = parseTopicPattern("transfer,,x")
+	assert.Error(t, err)
+}
+
+func TestEventsFollowResumesFromCursor(t *testing.T) {
+	contract := testContractID(t)
+	srv := rpctest.NewSorobanServer()
+	defer srv.Close()
+	srv.On("getLatestLedger", rpctest.Result(map[string]any{"sequence": 500}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	srv.Handle("getEvents", func(json.RawMessage) rpctest.Response {
+		if len(srv.Calls("getEvents")) > 1 {
+			cancel()
+			return rpctest.Result(map[string]any{"events": []any{}, "cursor": "c2", "latestLedger": 501})
+		}
+		return rpctest.Result(map[string]any{
+			"events": []map[string]any{{
+				"type": "contract", "ledger": 500, "contractId": contract, "id": "0001", "txHash": "ab",
+				"topic": []string{mustB64(t, xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &[]xdr.ScSymbol{"ping"}[0]})},
+				"value": mustB64(t, u32Val(5)),
+			}},
+			"cursor":       "c1",
+			"latestLedger": 500,
+		})
+	})
+
+	eventsNet = networkFlags{network: "testnet", sorobanURL: srv.URL}
+	eventsContracts, eventsFollow, eventsInterval, eventsLimit = []string{contract}, true, time.Millisecond, 100
+	defer func() {
+		eventsNet, eventsContracts, eventsFollow, eventsInterval = networkFlags{}, nil, false, 0
+	}()
+
+	cmd, out := testCommand("")
+	cmd.SetContext(ctx)
+	require.NoError(t, runEvents(cmd, nil))
+
+	assert.Contains(t, out.String(), "500  "+contract+`  topics=["ping"] data=5`)
+	calls := srv.Calls("getEvents")
+	require.Len(t, calls, 2)
+	assert.Contains(t, string(calls[0]), `"startLedger":500`)
+	assert.Contains(t, string(calls[1]), `"cursor":"c1"`)
+	assert.NotContains(t, string(calls[1]), "startLedger")
+}
+
+func TestEventsRejectsBadContract(t *testing.T) {
+	eventsContracts = []string{"GNOTACONTRACT"}
+	defer func() { eventsContracts = nil }()
+	_, err := eventsFilter()
+	assert.ErrorContains(t, err, "invalid contract ID")
+}
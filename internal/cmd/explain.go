@@ -52,13 +52,13 @@ Examples:
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) == 0 {
-			return explainFromSession()
+			return explainFromSession(cmd)
 		}
 		return explainFromNetwork(cmd, args[0])
 	},
 }
 
-func explainFromSession() error {
+func explainFromSession(cmd *cobra.Command) error {
 	sess := GetCurrentSession()
 	if sess == nil {
 		return fmt.Errorf("no active session; run 'erst debug <tx-hash>' first or provide a transaction hash")
@@ -81,8 +81,7 @@ func explainFromSession() error {
 		DiagnosticEvents: simResp.DiagnosticEvents,
 		BudgetUsage:      simResp.BudgetUsage,
 	}
-	fmt.Println(heuristic.Summarize(in))
-	return nil
+	return writeExplanation(cmd, in)
 }
 
 func explainFromNetwork(cmd *cobra.Command, txHash string) error {
@@ -167,7 +166,31 @@ func explainFromNetwork(cmd *cobra.Command, txHash string) error {
 		DiagnosticEvents: simResp.DiagnosticEvents,
 		BudgetUsage:      simResp.BudgetUsage,
 	}
-	fmt.Println(heuristic.Summarize(in))
+	return writeExplanation(cmd, in)
+}
+
+// explainReport is the output of erst explain; its JSON form is stable.
+type explainReport struct {
+	TxHash      string `json:"tx_hash"`
+	Network     string `json:"network"`
+	Status      string `json:"status"`
+	Error       string `json:"error,omitempty"`
+	Explanation string `json:"explanation"`
+}
+
+func writeExplanation(cmd *cobra.Command, in heuristic.Input) error {
+	summary := heuristic.Summarize(in)
+	w := cmd.OutOrStdout()
+	if format := outputFormat(false); format != outputTable {
+		return writeStructured(w, format, explainReport{
+			TxHash:      in.TxHash,
+			Network:     in.Network,
+			Status:      in.Status,
+			Error:       in.Error,
+			Explanation: summary,
+		})
+	}
+	fmt.Fprintln(w, summary)
 	return nil
 }
 
@@ -176,5 +199,6 @@ func init() {
 	explainCmd.Flags().StringVar(&explainRPCURLFlag, "rpc-url", "", "Custom RPC URL")
 	explainCmd.Flags().StringVar(&explainRPCToken, "rpc-token", "", "RPC authentication token (can also use ERST_RPC_TOKEN env var)")
 	explainCmd.Flags().StringVar(&explainRPCHeaders, "rpc-headers", "", "Additional headers to include on RPC requests (JSON or key=value list)")
+	supportStructuredOutput(explainCmd)
 	rootCmd.AddCommand(explainCmd)
 }
@@ -15,6 +15,12 @@ import (
 
 var exportSnapshotFlag string
 
+// exportReport is the output of erst export; its JSON form is stable.
+type exportReport struct {
+	Snapshot string `json:"snapshot"`
+	Entries  int    `json:"entries"`
+}
+
 var exportCmd = &cobra.Command{
 	Use:   "export",
 	Short: "Export data from the current session",
@@ -36,8 +42,9 @@ var exportCmd = &cobra.Command{
 			return errors.WrapUnmarshalFailed(err, "session data")
 		}
 
-		if len(simReq.LedgerEntries) == 0 {
-			fmt.Println("Warning: No ledger entries found in the current session.")
+		format := outputFormat(false)
+		if len(simReq.LedgerEntries) == 0 && format == outputTable {
+			fmt.Fprintln(cmd.OutOrStdout(), "Warning: No ledger entries found in the current session.")
 		}
 
 		// Convert to snapshot
@@ -48,12 +55,19 @@ var exportCmd = &cobra.Command{
 			return errors.WrapValidationError(fmt.Sprintf("failed to save snapshot: %v", err))
 		}
 
-		fmt.Printf("Snapshot exported to %s (%d entries)\n", exportSnapshotFlag, len(snap.LedgerEntries))
+		if format != outputTable {
+			return writeStructured(cmd.OutOrStdout(), format, exportReport{
+				Snapshot: exportSnapshotFlag,
+				Entries:  len(snap.LedgerEntries),
+			})
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Snapshot exported to %s (%d entries)\n", exportSnapshotFlag, len(snap.LedgerEntries))
 		return nil
 	},
 }
 
 func init() {
 	exportCmd.Flags().StringVar(&exportSnapshotFlag, "snapshot", "", "Output file for JSON snapshot")
+	supportStructuredOutput(exportCmd)
 	rootCmd.AddCommand(exportCmd)
 }
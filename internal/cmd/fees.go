@@ -0,0 +1,266 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/spf13/cobra"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+)
+
+var (
+	feesNet      networkFlags
+	feesWatch    bool
+	feesInterval time.Duration
+	feesJSON     bool
+)
+
+var feesCmd = &cobra.Command{
+	Use:   "fees",
+	Short: "Show current network fees and suggested fees by priority",
+	Long: `Show the fees paid by classic transactions in recent ledgers, from Horizon,
+and the inclusion fees bid by Soroban transactions, from Soroban RPC, with
+the fee to bid at each priority level:
+
+  low     50th percentile    medium  70th percentile
+  high    90th percentile    urgent  99th percentile
+
+Fees are in stroops; classic fees are per operation. Suggestions are never
+below the network base fee.
+
+With --watch a one-line summary is printed for every new ledger, which is
+useful for following fees during congestion.
+
+Examples:
+  erst fees --network testnet
+  erst fees --json
+  erst fees --watch --interval 10s`,
+	Args: cobra.NoArgs,
+	RunE: runFees,
+}
+
+// feePriorities are the priority levels suggested fees are given for and
+// the percentile of recent fees each one bids.
+var feePriorities = []struct {
+	name       string
+	percentile int
+}{
+	{"low", 50},
+	{"medium", 70},
+	{"high", 90},
+	{"urgent", 99},
+}
+
+// feesReport is the output of erst fees; its JSON form is stable.
+type feesReport struct {
+	Network string       `json:"network"`
+	Ledger  uint32       `json:"ledger"`
+	Classic classicFees  `json:"classic"`
+	Soroban *sorobanFees `json:"soroban,omitempty"`
+	// Suggested is ordered from lowest to highest priority.
+	Suggested []feeSuggestion `json:"suggested"`
+}
+
+type classicFees struct {
+	BaseFee int64 `json:"base_fee"`
+	// CapacityUsage is the fraction of recent ledgers' operation capacity
+	// that was used; near 1 the network is congested.
+	CapacityUsage float64         `json:"capacity_usage"`
+	FeeCharged    feeDistribution `json:"fee_charged"`
+	MaxFee        feeDistribution `json:"max_fee"`
+}
+
+type sorobanFees struct {
+	InclusionFee     feeDistribution `json:"inclusion_fee"`
+	TransactionCount uint32          `json:"transaction_count"`
+	LedgerCount      uint32          `json:"ledger_count"`
+}
+
+type feeDistribution struct {
+	Min  int64 `json:"min"`
+	Mode int64 `json:"mode"`
+	P10  int64 `json:"p10"`
+	P50  int64 `json:"p50"`
+	P90  int64 `json:"p90"`
+	P95  int64 `json:"p95"`
+	P99  int64 `json:"p99"`
+	Max  int64 `json:"max"`
+}
+
+type feeSuggestion struct {
+	Priority   string `json:"priority"`
+	Percentile int    `json:"percentile"`
+	Classic    int64  `json:"classic"`
+	Soroban    int64  `json:"soroban,omitempty"`
+}
+
+func runFees(cmd *cobra.Command, _ []string) error {
+	client, err := feesNet.client(cmd)
+	if err != nil {
+		return err
+	}
+	if !feesWatch {
+		report, err := fetchFees(cmd.Context(), client, cmd.ErrOrStderr())
+		if err != nil {
+			return err
+		}
+		return writeFeesReport(cmd.OutOrStdout(), report)
+	}
+
+	if feesInterval <= 0 {
+		return errors.WrapValidationError("--interval must be positive")
+	}
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+	return watchFees(ctx, client, cmd.OutOrStdout(), cmd.ErrOrStderr())
+}
+
+// fetchFees gathers classic fee stats from Horizon and Soroban fee stats
+// from Soroban RPC. Soroban stats are optional: when the RPC cannot be
+// reached a warning is written to warn and they are left out.
+func fetchFees(ctx context.Context, client *rpc.Client, warn io.Writer) (*feesReport, error) {
+	stats, err := client.FeeStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r := &feesReport{
+		Network: client.GetNetworkName(),
+		Ledger:  stats.LastLedger,
+		Classic: classicFees{
+			BaseFee:       stats.LastLedgerBaseFee,
+			CapacityUsage: stats.LedgerCapacityUsage,
+			FeeCharged:    newFeeDistribution(stats.FeeCharged),
+			MaxFee:        newFeeDistribution(stats.MaxFee),
+		},
+	}
+
+	soroban, err := client.SorobanFeeStats(ctx)
+	if err != nil {
+		fmt.Fprintf(warn, "Warning: Soroban fee stats unavailable: %v\n", err)
+	} else {
+		r.Soroban = &sorobanFees{
+			InclusionFee:     newFeeDistribution(soroban.SorobanInclusionFee.FeeDistribution),
+			TransactionCount: soroban.SorobanInclusionFee.TransactionCount,
+			LedgerCount:      soroban.SorobanInclusionFee.LedgerCount,
+		}
+	}
+
+	for _, p := range feePriorities {
+		s := feeSuggestion{Priority: p.name, Percentile: p.percentile}
+		if s.Classic, err = rpc.SuggestFee(stats.FeeCharged, p.percentile, stats.LastLedgerBaseFee); err != nil {
+			return nil, err
+		}
+		if soroban != nil {
+			if s.Soroban, err = rpc.SuggestFee(soroban.SorobanInclusionFee.FeeDistribution, p.percentile, 0); err != nil {
+				return nil, err
+			}
+		}
+		r.Suggested = append(r.Suggested, s)
+	}
+	return r, nil
+}
+
+func newFeeDistribution(d hProtocol.FeeDistribution) feeDistribution {
+	return feeDistribution{Min: d.Min, Mode: d.Mode, P10: d.P10, P50: d.P50, P90: d.P90, P95: d.P95, P99: d.P99, Max: d.Max}
+}
+
+// watchFees prints a summary line, or a JSON report per line, whenever the
+// fee stats move to a new ledger. Failed refreshes are reported and
+// retried on the next tick.
+func watchFees(ctx context.Context, client *rpc.Client, w, warn io.Writer) error {
+	ticker := time.NewTicker(feesInterval)
+	defer ticker.Stop()
+
+	var last uint32
+	for {
+		report, err := fetchFees(ctx, client, io.Discard)
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case err != nil:
+			fmt.Fprintf(warn, "Warning: %v\n", err)
+		case report.Ledger != last:
+			last = report.Ledger
+			if format := outputFormat(feesJSON); format != outputTable {
+				if err := writeRecord(w, format, report); err != nil {
+					return err
+				}
+			} else {
+				fmt.Fprintln(w, feesLine(report))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// feesLine is the one-line summary printed by --watch.
+func feesLine(r *feesReport) string {
+	line := fmt.Sprintf("ledger %d  capacity %3.0f%%  classic p50/p90/p99 %d/%d/%d",
+		r.Ledger, r.Classic.CapacityUsage*100, r.Classic.FeeCharged.P50, r.Classic.FeeCharged.P90, r.Classic.FeeCharged.P99)
+	if r.Soroban != nil {
+		line += fmt.Sprintf("  soroban p50/p90/p99 %d/%d/%d", r.Soroban.InclusionFee.P50, r.Soroban.InclusionFee.P90, r.Soroban.InclusionFee.P99)
+	}
+	line += "  suggested"
+	for _, s := range r.Suggested {
+		line += fmt.Sprintf(" %s=%d", s.Priority, s.Classic)
+		if r.Soroban != nil {
+			line += fmt.Sprintf("/%d", s.Soroban)
+		}
+	}
+	return line
+}
+
+func writeFeesReport(w io.Writer, r *feesReport) error {
+	if format := outputFormat(feesJSON); format != outputTable {
+		return writeStructured(w, format, r)
+	}
+	fmt.Fprintf(w, "Fees on %s as of ledger %d (stroops)\n", r.Network, r.Ledger)
+	fmt.Fprintf(w, "  Base fee:       %d\n", r.Classic.BaseFee)
+	fmt.Fprintf(w, "  Capacity usage: %.0f%%\n", r.Classic.CapacityUsage*100)
+
+	fmt.Fprintf(w, "\n  %-26s %8s %8s %8s %8s %8s %8s %8s %8s\n", "", "min", "mode", "p10", "p50", "p90", "p95", "p99", "max")
+	printFeeDistribution(w, "Classic fee charged/op", r.Classic.FeeCharged)
+	printFeeDistribution(w, "Classic max fee/op", r.Classic.MaxFee)
+	if r.Soroban != nil {
+		printFeeDistribution(w, "Soroban inclusion fee", r.Soroban.InclusionFee)
+		fmt.Fprintf(w, "  (Soroban: %d transactions over %d ledgers)\n", r.Soroban.TransactionCount, r.Soroban.LedgerCount)
+	}
+
+	fmt.Fprintf(w, "\nSuggested fees:\n")
+	for _, s := range r.Suggested {
+		fmt.Fprintf(w, "  %-7s (p%d)  classic %d/op", s.Priority, s.Percentile, s.Classic)
+		if r.Soroban != nil {
+			fmt.Fprintf(w, ", soroban inclusion %d", s.Soroban)
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
+func printFeeDistribution(w io.Writer, label string, d feeDistribution) {
+	fmt.Fprintf(w, "  %-26s %8d %8d %8d %8d %8d %8d %8d %8d\n", label, d.Min, d.Mode, d.P10, d.P50, d.P90, d.P95, d.P99, d.Max)
+}
+
+func init() {
+	feesNet.register(feesCmd)
+	feesCmd.Flags().BoolVarP(&feesWatch, "watch", "w", false, "Print a summary for every new ledger until interrupted")
+	feesCmd.Flags().DurationVar(&feesInterval, "interval", rpc.FeeStatsTTL, "How often --watch refreshes")
+	feesCmd.Flags().BoolVar(&feesJSON, "json", false, "Print the report as JSON")
+
+	supportStructuredOutput(feesCmd)
+	rootCmd.AddCommand(feesCmd)
+}
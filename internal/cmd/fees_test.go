@@ -0,0 +1,88 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/rpc/rpctest"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func feesFixture(t *testing.T) (*rpctest.HorizonServer, *rpctest.SorobanServer) {
+	t.Helper()
+	horizon := rpctest.NewHorizonServer()
+	t.Cleanup(horizon.Close)
+	horizon.SetFeeStats(hProtocol.FeeStats{
+		LastLedger:          2000,
+		LastLedgerBaseFee:   100,
+		LedgerCapacityUsage: 0.97,
+		FeeCharged:          hProtocol.FeeDistribution{Min: 100, Mode: 100, P10: 100, P50: 100, P70: 300, P90: 1000, P99: 5000, Max: 9000},
+	})
+
+	soroban := rpctest.NewSorobanServer()
+	t.Cleanup(soroban.Close)
+	return horizon, soroban
+}
+
+func runFeesWith(t *testing.T, horizonURL, sorobanURL string) (feesReport, string) {
+	t.Helper()
+	feesNet = networkFlags{network: "testnet", horizonURL: horizonURL, sorobanURL: sorobanURL}
+	feesJSON = true
+	defer func() { feesNet, feesJSON = networkFlags{}, false }()
+
+	cmd, out := testCommand("")
+	stderr := &bytes.Buffer{}
+	cmd.SetErr(stderr)
+	require.NoError(t, runFees(cmd, nil))
+	var report feesReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	return report, stderr.String()
+}
+
+func TestFeesReport(t *testing.T) {
+	horizon, soroban := feesFixture(t)
+	dist := map[string]any{
+		"max": "20000", "min": "100", "mode": "150", "p10": "100", "p20": "100", "p30": "120", "p40": "140",
+		"p50": "150", "p60": "200", "p70": "400", "p80": "800", "p90": "2000", "p95": "5000", "p99": "20000",
+		"transactionCount": "12", "ledgerCount": 50,
+	}
+	soroban.On("getFeeStats", rpctest.Result(map[string]any{
+		"sorobanInclusionFee": dist, "inclusionFee": dist, "latestLedger": 2000,
+	}))
+
+	report, warnings := runFeesWith(t, horizon.URL, soroban.URL)
+	assert.Empty(t, warnings)
+	assert.Equal(t, uint32(2000), report.Ledger)
+	assert.InDelta(t, 0.97, report.Classic.CapacityUsage, 1e-9)
+	assert.Equal(t, int64(1000), report.Classic.FeeCharged.P90)
+	require.NotNil(t, report.Soroban)
+	assert.Equal(t, uint32(12), report.Soroban.TransactionCount)
+
+	assert.Equal(t, []feeSuggestion{
+		{Priority: "low", Percentile: 50, Classic: 100, Soroban: 150},
+		{Priority: "medium", Percentile: 70, Classic: 300, Soroban: 400},
+		{Priority: "high", Percentile: 90, Classic: 1000, Soroban: 2000},
+		{Priority: "urgent", Percentile: 99, Classic: 5000, Soroban: 20000},
+	}, report.Suggested)
+
+	line := feesLine(&report)
+	assert.Contains(t, line, "capacity  97%")
+	assert.Contains(t, line, "high=1000/2000")
+}
+
+func TestFeesWithoutSoroban(t *testing.T) {
+	horizon, soroban := feesFixture(t)
+
+	report, warnings := runFeesWith(t, horizon.URL, soroban.URL)
+	assert.Contains(t, warnings, "Soroban fee stats unavailable")
+	assert.Nil(t, report.Soroban)
+	require.Len(t, report.Suggested, 4)
+	assert.Equal(t, int64(100), report.Suggested[0].Classic)
+	assert.Zero(t, report.Suggested[0].Soroban)
+}
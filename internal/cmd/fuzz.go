@@ -46,17 +46,21 @@ func runFuzz(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("--iterations must be specified and greater than 0")
 	}
 
-	fmt.Printf("Starting fuzzing campaign\n")
-	fmt.Printf("  Iterations: %d\n", fuzzIterations)
-	fmt.Printf("  Timeout: %dms\n", fuzzTimeout)
-	fmt.Printf("  Max Input Size: %d bytes\n", fuzzMaxSize)
-
-	if fuzzInputXDR != "" {
-		fmt.Printf("  Base Input: %s...\n", fuzzInputXDR[:min(32, len(fuzzInputXDR))])
-	}
+	w := cmd.OutOrStdout()
+	format := outputFormat(false)
+	if format == outputTable {
+		fmt.Fprintf(w, "Starting fuzzing campaign\n")
+		fmt.Fprintf(w, "  Iterations: %d\n", fuzzIterations)
+		fmt.Fprintf(w, "  Timeout: %dms\n", fuzzTimeout)
+		fmt.Fprintf(w, "  Max Input Size: %d bytes\n", fuzzMaxSize)
+
+		if fuzzInputXDR != "" {
+			fmt.Fprintf(w, "  Base Input: %s...\n", fuzzInputXDR[:min(32, len(fuzzInputXDR))])
+		}
 
-	if fuzzTargetContract != "" {
-		fmt.Printf("  Target Contract: %s\n", fuzzTargetContract)
+		if fuzzTargetContract != "" {
+			fmt.Fprintf(w, "  Target Contract: %s\n", fuzzTargetContract)
+		}
 	}
 
 	// Initialize simulator runner
@@ -89,13 +93,19 @@ func runFuzz(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("fuzzing failed: %w", err)
 		}
 
-		fmt.Printf("\nFuzz Test Result:\n")
-		fmt.Printf("  Status: %s\n", result.Status)
-		if result.ErrorMessage != "" {
-			fmt.Printf("  Error: %s\n", result.ErrorMessage)
+		if format != outputTable {
+			if err := writeStructured(w, format, newFuzzRun(*result)); err != nil {
+				return err
+			}
+		} else {
+			fmt.Fprintf(w, "\nFuzz Test Result:\n")
+			fmt.Fprintf(w, "  Status: %s\n", result.Status)
+			if result.ErrorMessage != "" {
+				fmt.Fprintf(w, "  Error: %s\n", result.ErrorMessage)
+			}
+			fmt.Fprintf(w, "  Execution Time: %dms\n", result.ExecutionTimeMs)
+			fmt.Fprintf(w, "  Code Coverage: %d%%\n", result.CodeCoverage)
 		}
-		fmt.Printf("  Execution Time: %dms\n", result.ExecutionTimeMs)
-		fmt.Printf("  Code Coverage: %d%%\n", result.CodeCoverage)
 
 		if result.Status == "crash" {
 			return fmt.Errorf("fuzzing found a crash")
@@ -105,8 +115,10 @@ func runFuzz(cmd *cobra.Command, args []string) error {
 	}
 
 	// Run normal fuzzing campaign without base input
-	fmt.Println("\nNo base XDR provided - using random generation")
-	fmt.Println("Starting fuzzing campaign...")
+	if format == outputTable {
+		fmt.Fprintln(w, "\nNo base XDR provided - using random generation")
+		fmt.Fprintln(w, "Starting fuzzing campaign...")
+	}
 
 	// Create empty base input for fuzzing
 	baseInput := &simulator.FuzzerInput{
@@ -129,15 +141,37 @@ func runFuzz(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("fuzzing campaign failed: %w", err)
 	}
 
+	if format != outputTable {
+		avgCov, passes, crashes := harness.CorpusCoverage()
+		report := fuzzReport{
+			Iterations:  fuzzIterations,
+			Runs:        len(harness.Results),
+			Passes:      passes,
+			Crashes:     crashes,
+			AvgCoverage: avgCov,
+			CrashSeeds:  make([]uint64, 0, len(crashingInputs)),
+		}
+		for _, input := range crashingInputs {
+			report.CrashSeeds = append(report.CrashSeeds, input.Seed)
+		}
+		if err := writeStructured(w, format, report); err != nil {
+			return err
+		}
+		if len(crashingInputs) > 0 {
+			return fmt.Errorf("fuzzing found %d crashes", len(crashingInputs))
+		}
+		return nil
+	}
+
 	// Print summary
-	fmt.Println("\n" + harness.Summary())
+	fmt.Fprintln(w, "\n"+harness.Summary())
 
 	// Print first few crashing inputs if found
 	if len(crashingInputs) > 0 {
-		fmt.Printf("\n%d unique crash(es) found!\n", len(crashingInputs))
+		fmt.Fprintf(w, "\n%d unique crash(es) found!\n", len(crashingInputs))
 		for i, input := range crashingInputs {
 			if i < 5 {
-				fmt.Printf("  Crash %d (seed %d): %s...\n",
+				fmt.Fprintf(w, "  Crash %d (seed %d): %s...\n",
 					i+1,
 					input.Seed,
 					fuzzInputXDR[:min(20, len(fuzzInputXDR))],
@@ -145,13 +179,13 @@ func runFuzz(cmd *cobra.Command, args []string) error {
 			}
 		}
 		if len(crashingInputs) > 5 {
-			fmt.Printf("  ... and %d more crashes\n", len(crashingInputs)-5)
+			fmt.Fprintf(w, "  ... and %d more crashes\n", len(crashingInputs)-5)
 		}
 		return fmt.Errorf("fuzzing found %d crashes", len(crashingInputs))
 	}
 
 	if len(results) > 0 {
-		fmt.Printf("\nFuzzing completed: %d/%d tests passed\n",
+		fmt.Fprintf(w, "\nFuzzing completed: %d/%d tests passed\n",
 			len(results),
 			fuzzIterations,
 		)
@@ -160,6 +194,34 @@ func runFuzz(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// fuzzReport is the output of erst fuzz without --xdr; its JSON form is
+// stable.
+type fuzzReport struct {
+	Iterations  uint64   `json:"iterations"`
+	Runs        int      `json:"runs"`
+	Passes      int      `json:"passes"`
+	Crashes     int      `json:"crashes"`
+	AvgCoverage uint32   `json:"avg_coverage"`
+	CrashSeeds  []uint64 `json:"crash_seeds"`
+}
+
+// fuzzRun is the output of erst fuzz --xdr; its JSON form is stable.
+type fuzzRun struct {
+	Status          string `json:"status"`
+	Error           string `json:"error,omitempty"`
+	ExecutionTimeMs uint64 `json:"execution_time_ms"`
+	CodeCoverage    uint32 `json:"code_coverage"`
+}
+
+func newFuzzRun(r simulator.FuzzingResult) fuzzRun {
+	return fuzzRun{
+		Status:          r.Status,
+		Error:           r.ErrorMessage,
+		ExecutionTimeMs: r.ExecutionTimeMs,
+		CodeCoverage:    r.CodeCoverage,
+	}
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
@@ -210,5 +272,6 @@ func init() {
 		"Optional target contract ID to focus fuzzing on",
 	)
 
+	supportStructuredOutput(fuzzCmd)
 	rootCmd.AddCommand(fuzzCmd)
 }
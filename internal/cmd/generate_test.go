@@ -19,6 +19,15 @@ var (
 	genTestName   string
 )
 
+// generateTestReport is the output of erst generate-test; its JSON form is
+// stable.
+type generateTestReport struct {
+	TxHash string `json:"tx_hash"`
+	Lang   string `json:"lang"`
+	Name   string `json:"name,omitempty"`
+	OutDir string `json:"out_dir"`
+}
+
 var generateTestCmd = &cobra.Command{
 	Use:   "generate-test <transaction-hash>",
 	Short: "Generate regression tests from a transaction",
@@ -66,24 +75,38 @@ Example:
 		generator := testgen.NewTestGenerator(client, genTestOutput)
 
 		// Generate tests
-		fmt.Printf("Generating %s regression test(s) for transaction: %s\n", genTestLang, txHash)
+		w := cmd.OutOrStdout()
+		format := outputFormat(false)
+		if format == outputTable {
+			fmt.Fprintf(w, "Generating %s regression test(s) for transaction: %s\n", genTestLang, txHash)
+		}
 		if err := generator.GenerateTests(cmd.Context(), txHash, genTestLang, genTestName); err != nil {
 			return fmt.Errorf("failed to generate tests: %w", err)
 		}
 
-		fmt.Println("[OK] Test generation completed successfully")
+		if format != outputTable {
+			return writeStructured(w, format, generateTestReport{
+				TxHash: txHash,
+				Lang:   genTestLang,
+				Name:   genTestName,
+				OutDir: genTestOutput,
+			})
+		}
+		fmt.Fprintln(w, "[OK] Test generation completed successfully")
 		return nil
 	},
 }
 
 func init() {
 	generateTestCmd.Flags().StringVarP(&genTestLang, "lang", "l", "both", "Target language (go, rust, or both)")
-	generateTestCmd.Flags().StringVarP(&genTestOutput, "output", "o", "", "Output directory (defaults to current directory)")
+	generateTestCmd.Flags().StringVar(&genTestOutput, "out-dir", "", "Output directory (defaults to current directory)")
 	generateTestCmd.Flags().StringVarP(&genTestName, "name", "", "", "Custom test name (defaults to transaction hash)")
 	generateTestCmd.Flags().StringVarP(&networkFlag, "network", "n", string(rpc.Mainnet), "Stellar network to use (testnet, mainnet, futurenet)")
 	generateTestCmd.Flags().StringVar(&rpcURLFlag, "rpc-url", "", "Custom Horizon RPC URL to use")
 	generateTestCmd.Flags().StringVar(&rpcTokenFlag, "rpc-token", "", "RPC authentication token (can also use ERST_RPC_TOKEN env var)")
 	generateTestCmd.Flags().StringVar(&rpcHeadersFlag, "rpc-headers", "", "Additional headers to include on RPC requests (JSON or key=value list)")
 
+	supportStructuredOutput(generateTestCmd)
+	renamedOutputFlag(generateTestCmd, "out-dir")
 	rootCmd.AddCommand(generateTestCmd)
 }
@@ -55,7 +55,10 @@ the preferred RPC URL and network passphrase.`,
 			return fmt.Errorf("invalid network %q (valid: public, testnet, futurenet, standalone)", opts.Network)
 		}
 
-		if shouldRunInitWizard(cmd, initInteractiveFlag) {
+		// The wizard's prompts would end up in structured output, so it
+		// only runs for table output.
+		format := outputFormat(false)
+		if format == outputTable && shouldRunInitWizard(cmd, initInteractiveFlag) {
 			if err := runInitWizard(cmd, &opts); err != nil {
 				return err
 			}
@@ -65,11 +68,32 @@ the preferred RPC URL and network passphrase.`,
 			return err
 		}
 
+		if format != outputTable {
+			network := opts.Network
+			if network == "" {
+				network = "testnet"
+			}
+			return writeStructured(cmd.OutOrStdout(), format, initReport{
+				Directory:         targetDir,
+				Network:           network,
+				RPCURL:            defaultRPCURLForNetwork(network, opts.RPCURL),
+				NetworkPassphrase: defaultPassphraseForNetwork(network, opts.NetworkPassphrase),
+			})
+		}
 		fmt.Fprintf(cmd.OutOrStdout(), "Initialized Erst project scaffold in %s\n", targetDir)
 		return nil
 	},
 }
 
+// initReport is the output of erst init: the settings written into
+// erst.toml. Its JSON form is stable.
+type initReport struct {
+	Directory         string `json:"directory"`
+	Network           string `json:"network"`
+	RPCURL            string `json:"rpc_url"`
+	NetworkPassphrase string `json:"network_passphrase"`
+}
+
 type initScaffoldOptions struct {
 	Force             bool
 	Network           string
@@ -284,12 +308,14 @@ func renderProjectGitignoreBlock() string {
 `
 }
 
+// initNetworks are the network names erst init writes into erst.toml.
+var initNetworks = []string{"public", "testnet", "futurenet", "standalone"}
+
 func isValidInitNetwork(network string) bool {
 	if network == "" {
 		return true
 	}
-	valid := []string{"public", "testnet", "futurenet", "standalone"}
-	for _, candidate := range valid {
+	for _, candidate := range initNetworks {
 		if candidate == network {
 			return true
 		}
@@ -301,7 +327,9 @@ func init() {
 	initCmd.Flags().BoolVar(&initForceFlag, "force", false, "Overwrite generated files when they already exist")
 	initCmd.Flags().BoolVar(&initInteractiveFlag, "interactive", true, "Run an interactive setup wizard for RPC URL and network passphrase")
 	initCmd.Flags().StringVar(&initNetworkName, "network", "testnet", "Default network to write into erst.toml (public, testnet, futurenet, standalone)")
+	_ = initCmd.RegisterFlagCompletionFunc("network", cobra.FixedCompletions(initNetworks, cobra.ShellCompDirectiveNoFileComp))
 	initCmd.Flags().StringVar(&initRPCURLFlag, "rpc-url", "", "RPC URL to write into erst.toml (skips wizard default for this value)")
 	initCmd.Flags().StringVar(&initNetworkPassphraseFlag, "network-passphrase", "", "Network passphrase to write into erst.toml (skips wizard default for this value)")
+	supportStructuredOutput(initCmd)
 	rootCmd.AddCommand(initCmd)
 }
@@ -0,0 +1,311 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/dotandev/hintents/internal/config"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/intent"
+	"github.com/spf13/cobra"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/strkey"
+)
+
+// keysPassphraseEnv names the variable that supplies the keystore
+// passphrase instead of a prompt.
+const keysPassphraseEnv = "ERST_KEYS_PASSPHRASE"
+
+var keysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Manage the signing keys in the local keystore",
+	Long: `Manage the secret seeds in ~/.erst/keys.json. Each seed is encrypted with
+AES-256-GCM under a key derived from a passphrase (PBKDF2-SHA256), and the
+file is readable by its owner only. Public keys are stored in clear, so
+keys can be listed without the passphrase.
+
+Commands that sign, such as submit and contract invoke, take a key name
+wherever they take a secret seed: --signer alice.
+
+The passphrase is asked for on the terminal, or read from
+` + keysPassphraseEnv + ` when it is set.
+
+Examples:
+  erst keys generate alice
+  erst keys import bob < bob.seed
+  erst keys list
+  erst keys export alice
+  erst contract invoke CABC... hello --arg to=world --send --signer alice`,
+}
+
+var keysGenerateCmd = &cobra.Command{
+	Use:   "generate <name>",
+	Short: "Create a random key and store it",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runKeysGenerate,
+}
+
+var keysImportCmd = &cobra.Command{
+	Use:   "import <name>",
+	Short: "Store an existing secret seed",
+	Long: `Store an existing secret seed under name. The seed is read from stdin,
+with a prompt when stdin is a terminal, so it never appears in a flag or
+the shell history.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runKeysImport,
+}
+
+var keysListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the stored keys and their public keys",
+	Args:  cobra.NoArgs,
+	RunE:  runKeysList,
+}
+
+var keysExportCmd = &cobra.Command{
+	Use:               "export <name>",
+	Short:             "Print the secret seed of a stored key",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeKeys,
+	RunE:              runKeysExport,
+}
+
+// keyInfo describes a stored key, without its secret.
+type keyInfo struct {
+	Name      string    `json:"name"`
+	PublicKey string    `json:"public_key"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// keysListing is the output of erst keys list; its JSON form is stable.
+type keysListing struct {
+	Keys []keyInfo `json:"keys"`
+}
+
+// keyExport is the output of erst keys export; its JSON form is stable.
+type keyExport struct {
+	keyInfo
+	Seed string `json:"seed"`
+}
+
+func runKeysGenerate(cmd *cobra.Command, args []string) error {
+	kp, err := keypair.Random()
+	if err != nil {
+		return errors.WrapValidationError(fmt.Sprintf("failed to generate key: %v", err))
+	}
+	return storeKey(cmd, newKeyPrompt(cmd), args[0], kp.Seed())
+}
+
+func runKeysImport(cmd *cobra.Command, args []string) error {
+	prompt := newKeyPrompt(cmd)
+	seed, err := prompt.secret("Secret seed: ")
+	if err != nil {
+		return err
+	}
+	seed = strings.TrimSpace(seed)
+	if !strkey.IsValidEd25519SecretSeed(seed) {
+		return errors.WrapValidationError("invalid secret seed")
+	}
+	return storeKey(cmd, prompt, args[0], seed)
+}
+
+// storeKey encrypts seed under a new passphrase and saves it as name.
+func storeKey(cmd *cobra.Command, prompt *keyPrompt, name, seed string) error {
+	ks, err := config.LoadKeystore()
+	if err != nil {
+		return err
+	}
+	// Check the name before asking for a passphrase.
+	if _, ok := ks.Keys[name]; ok {
+		return errors.WrapValidationError(fmt.Sprintf("key %q already exists", name))
+	}
+	passphrase, err := prompt.passphrase(fmt.Sprintf("Passphrase for %s: ", name), true)
+	if err != nil {
+		return err
+	}
+	key, err := ks.Add(name, seed, passphrase)
+	if err != nil {
+		return err
+	}
+	if err := config.SaveKeystore(ks); err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	info := keyInfo{Name: name, PublicKey: key.PublicKey, CreatedAt: key.CreatedAt}
+	if format := outputFormat(false); format != outputTable {
+		return writeStructured(w, format, info)
+	}
+	fmt.Fprintf(w, "Stored key %s: %s\n", name, key.PublicKey)
+	return nil
+}
+
+func runKeysList(cmd *cobra.Command, _ []string) error {
+	ks, err := config.LoadKeystore()
+	if err != nil {
+		return err
+	}
+	listing := keysListing{Keys: []keyInfo{}}
+	for _, name := range ks.Names() {
+		key := ks.Keys[name]
+		listing.Keys = append(listing.Keys, keyInfo{Name: name, PublicKey: key.PublicKey, CreatedAt: key.CreatedAt})
+	}
+
+	w := cmd.OutOrStdout()
+	if format := outputFormat(false); format != outputTable {
+		return writeStructured(w, format, listing)
+	}
+	if len(listing.Keys) == 0 {
+		fmt.Fprintln(w, "No keys. Create one with: erst keys generate <name>")
+		return nil
+	}
+	for _, k := range listing.Keys {
+		fmt.Fprintf(w, "%-20s %s  %s\n", k.Name, k.PublicKey, k.CreatedAt.Format(time.DateOnly))
+	}
+	return nil
+}
+
+func runKeysExport(cmd *cobra.Command, args []string) error {
+	ks, err := config.LoadKeystore()
+	if err != nil {
+		return err
+	}
+	key, err := ks.Get(args[0])
+	if err != nil {
+		return err
+	}
+	passphrase, err := newKeyPrompt(cmd).passphrase(fmt.Sprintf("Passphrase for %s: ", args[0]), false)
+	if err != nil {
+		return err
+	}
+	seed, err := ks.Seed(args[0], passphrase)
+	if err != nil {
+		return err
+	}
+	w := cmd.OutOrStdout()
+	if format := outputFormat(false); format != outputTable {
+		return writeStructured(w, format, keyExport{
+			keyInfo: keyInfo{Name: args[0], PublicKey: key.PublicKey, CreatedAt: key.CreatedAt},
+			Seed:    seed,
+		})
+	}
+	fmt.Fprintln(w, seed)
+	return nil
+}
+
+// keyPrompt reads secrets from a command's input, one per line. Prompts go
+// to stderr and are only shown when the input is a terminal.
+type keyPrompt struct {
+	cmd *cobra.Command
+	in  *bufio.Reader
+	tty bool
+}
+
+func newKeyPrompt(cmd *cobra.Command) *keyPrompt {
+	in := cmd.InOrStdin()
+	p := &keyPrompt{cmd: cmd, in: bufio.NewReader(in)}
+	if f, ok := in.(*os.File); ok {
+		if info, err := f.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+			p.tty = true
+		}
+	}
+	return p
+}
+
+// secret reads one line, with terminal echo turned off.
+func (p *keyPrompt) secret(prompt string) (string, error) {
+	if p.tty {
+		fmt.Fprint(p.cmd.ErrOrStderr(), prompt)
+		// Without stty, as on Windows, the input is echoed.
+		if stty("-echo") == nil {
+			defer func() {
+				_ = stty("echo")
+				fmt.Fprintln(p.cmd.ErrOrStderr())
+			}()
+		}
+	}
+	line, err := p.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", errors.WrapValidationError(fmt.Sprintf("no input for %q", strings.TrimSuffix(prompt, ": ")))
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// stty changes the settings of the terminal on stdin.
+func stty(arg string) error {
+	c := exec.Command("stty", arg)
+	c.Stdin = os.Stdin
+	return c.Run()
+}
+
+// passphrase returns the keystore passphrase from keysPassphraseEnv or
+// asks for it, twice when confirm is set.
+func (p *keyPrompt) passphrase(prompt string, confirm bool) (string, error) {
+	if passphrase := os.Getenv(keysPassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+	passphrase, err := p.secret(prompt)
+	if err != nil {
+		return "", errors.WrapValidationError("a passphrase is required: type it, or set " + keysPassphraseEnv)
+	}
+	if passphrase == "" {
+		return "", errors.WrapValidationError("passphrase cannot be empty")
+	}
+	if confirm {
+		again, err := p.secret("Repeat passphrase: ")
+		if err != nil || again != passphrase {
+			return "", errors.WrapValidationError("passphrases do not match")
+		}
+	}
+	return passphrase, nil
+}
+
+// signer returns a signer for ref, which is a secret seed or the name of a
+// stored key.
+func (p *keyPrompt) signer(ref string) (intent.Signer, error) {
+	if strkey.IsValidEd25519SecretSeed(ref) {
+		return intent.NewLocalSigner(ref)
+	}
+	ks, err := config.LoadKeystore()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := ks.Keys[ref]; !ok {
+		return nil, errors.WrapValidationError(fmt.Sprintf("signer %q is neither a secret seed nor a stored key (see erst keys list)", ref))
+	}
+	passphrase, err := p.passphrase(fmt.Sprintf("Passphrase for %s: ", ref), false)
+	if err != nil {
+		return nil, err
+	}
+	seed, err := ks.Seed(ref, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return intent.NewLocalSigner(seed)
+}
+
+// completeKeys completes the names of the stored keys.
+func completeKeys(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	ks, err := config.LoadKeystore()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return ks.Names(), cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	supportStructuredOutput(keysGenerateCmd)
+	supportStructuredOutput(keysImportCmd)
+	supportStructuredOutput(keysListCmd)
+	supportStructuredOutput(keysExportCmd)
+	keysCmd.AddCommand(keysGenerateCmd, keysImportCmd, keysListCmd, keysExportCmd)
+	rootCmd.AddCommand(keysCmd)
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/intent"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/network"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withKeystoreHome points the keystore at an empty temporary home and
+// makes passphrases come from stdin.
+func withKeystoreHome(t *testing.T) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv(keysPassphraseEnv, "")
+}
+
+func TestKeysImportListExport(t *testing.T) {
+	withKeystoreHome(t)
+	kp := keypair.MustRandom()
+
+	cmd, out := testCommand(" " + kp.Seed() + "\ncorrect horse\ncorrect horse\n")
+	require.NoError(t, runKeysImport(cmd, []string{"bob"}))
+	assert.Equal(t, "Stored key bob: "+kp.Address()+"\n", out.String())
+
+	cmd, _ = testCommand(kp.Seed() + "\ncorrect horse\ncorrect horse\n")
+	assert.ErrorContains(t, runKeysImport(cmd, []string{"bob"}), `key "bob" already exists`)
+	cmd, _ = testCommand(kp.Address() + "\n")
+	assert.ErrorContains(t, runKeysImport(cmd, []string{"carol"}), "invalid secret seed")
+	cmd, _ = testCommand(kp.Seed() + "\none\ntwo\n")
+	assert.ErrorContains(t, runKeysImport(cmd, []string{"carol"}), "passphrases do not match")
+
+	t.Setenv(keysPassphraseEnv, "from env")
+	cmd, _ = testCommand("")
+	require.NoError(t, runKeysGenerate(cmd, []string{"alice"}))
+	t.Setenv(keysPassphraseEnv, "")
+
+	withOutputFlag(t, "json")
+	cmd, out = testCommand("")
+	require.NoError(t, runKeysList(cmd, nil))
+	var listing keysListing
+	require.NoError(t, json.Unmarshal(out.Bytes(), &listing))
+	require.Len(t, listing.Keys, 2)
+	assert.Equal(t, "alice", listing.Keys[0].Name)
+	assert.Equal(t, "bob", listing.Keys[1].Name)
+	assert.Equal(t, kp.Address(), listing.Keys[1].PublicKey)
+
+	cmd, out = testCommand("correct horse\n")
+	require.NoError(t, runKeysExport(cmd, []string{"bob"}))
+	var exported keyExport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &exported))
+	assert.Equal(t, "bob", exported.Name)
+	assert.Equal(t, kp.Address(), exported.PublicKey)
+	assert.Equal(t, kp.Seed(), exported.Seed)
+
+	withOutputFlag(t, "")
+	cmd, out = testCommand("correct horse\n")
+	require.NoError(t, runKeysExport(cmd, []string{"bob"}))
+	assert.Equal(t, kp.Seed()+"\n", out.String())
+
+	cmd, _ = testCommand("wrong\n")
+	assert.ErrorIs(t, runKeysExport(cmd, []string{"bob"}), errs.ErrUnauthorized)
+	cmd, _ = testCommand("")
+	assert.ErrorContains(t, runKeysExport(cmd, []string{"bob"}), "a passphrase is required")
+	assert.ErrorContains(t, runKeysExport(cmd, []string{"dave"}), `key "dave" does not exist`)
+}
+
+func TestKeyPromptSigner(t *testing.T) {
+	withKeystoreHome(t)
+	kp := keypair.MustRandom()
+	cmd, _ := testCommand(kp.Seed() + "\npw\npw\n")
+	require.NoError(t, runKeysImport(cmd, []string{"bob"}))
+
+	cmd, _ = testCommand("")
+	signer, err := newKeyPrompt(cmd).signer(kp.Seed())
+	require.NoError(t, err)
+	assert.Equal(t, kp.Address(), signer.PublicKey())
+
+	cmd, _ = testCommand("pw\n")
+	signer, err = newKeyPrompt(cmd).signer("bob")
+	require.NoError(t, err)
+	assert.Equal(t, kp.Address(), signer.PublicKey())
+
+	_, err = newKeyPrompt(cmd).signer("SNOTASEED")
+	assert.ErrorContains(t, err, `signer "SNOTASEED" is neither a secret seed nor a stored key`)
+}
+
+func TestSignEnvelope(t *testing.T) {
+	kp := keypair.MustRandom()
+	signed, err := signEnvelope(context.Background(), testEnvelope(t), network.TestNetworkPassphrase,
+		[]intent.Signer{intent.LocalSignerFromKeypair(kp)})
+	require.NoError(t, err)
+
+	var env xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(signed, &env))
+	sigs := env.Signatures()
+	require.Len(t, sigs, 2)
+	assert.Equal(t, kp.Hint(), [4]byte(sigs[1].Hint))
+
+	_, err = signEnvelope(context.Background(), strings.Repeat("A", 8), network.TestNetworkPassphrase, nil)
+	assert.Error(t, err)
+}
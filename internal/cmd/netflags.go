@@ -0,0 +1,122 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dotandev/hintents/internal/config"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/spf13/cobra"
+)
+
+// networkFlags are the connection flags shared by commands that talk to a
+// network. A command keeps one in a package variable and registers it in
+// init. Flags that are not given fall back to the active profile.
+type networkFlags struct {
+	profile    string
+	network    string
+	horizonURL string
+	sorobanURL string
+	token      string
+	headers    string
+}
+
+func (f *networkFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.profile, "profile", "", "Profile to take unset connection settings from (default: the active profile)")
+	cmd.Flags().StringVarP(&f.network, "network", "n", "", "Stellar network to use (testnet, mainnet, futurenet or a custom network name; default: the profile's network, else mainnet)")
+	cmd.Flags().StringVar(&f.horizonURL, "rpc-url", "", "Custom Horizon URL to use")
+	cmd.Flags().StringVar(&f.sorobanURL, "soroban-url", "", "Custom Soroban RPC URL to use")
+	cmd.Flags().StringVar(&f.token, "rpc-token", "", "RPC authentication token (can also use ERST_RPC_TOKEN env var)")
+	cmd.Flags().StringVar(&f.headers, "rpc-headers", "", "Additional headers to include on RPC requests (JSON or key=value list)")
+	_ = cmd.RegisterFlagCompletionFunc("profile", completeProfiles)
+	_ = cmd.RegisterFlagCompletionFunc("network", completeNetworks)
+}
+
+// resolveProfile returns the profile named by --profile or, without it,
+// the active one. The profile is empty when there is none.
+func (f *networkFlags) resolveProfile() (string, *config.Profile, error) {
+	if f.profile == "" {
+		return config.ActiveProfile()
+	}
+	profiles, err := config.LoadProfiles()
+	if err != nil {
+		return "", nil, err
+	}
+	profile, err := profiles.Get(f.profile)
+	if err != nil {
+		return "", nil, err
+	}
+	return f.profile, profile, nil
+}
+
+// client builds an RPC client for the selected network. Networks other
+// than the built-in ones are looked up among the custom networks saved in
+// ~/.erst/networks.json. Settings not given as flags come from the
+// profile, unless --network picked a different network than the
+// profile's. The token and headers fall back to the environment, then to
+// the profile and then to the config file. A profile's token is encrypted;
+// its passphrase is asked for on cmd's input.
+func (f *networkFlags) client(cmd *cobra.Command) (*rpc.Client, error) {
+	name, profile, err := f.resolveProfile()
+	if err != nil {
+		return nil, err
+	}
+	if f.network != "" && f.network != firstNonEmpty(profile.Network, string(rpc.Mainnet)) {
+		name, profile = "", &config.Profile{}
+	}
+	network := firstNonEmpty(f.network, profile.Network, string(rpc.Mainnet))
+	var opts []rpc.ClientOption
+	switch net := rpc.Network(network); net {
+	case rpc.Testnet, rpc.Mainnet, rpc.Futurenet:
+		opts = append(opts, rpc.WithNetwork(net))
+	default:
+		custom, err := config.GetCustomNetwork(network)
+		if err != nil {
+			return nil, errors.WrapInvalidNetwork(network)
+		}
+		opts = append(opts, rpc.WithNetworkConfig(*custom))
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		cfg = config.DefaultConfig()
+	}
+	token := firstNonEmpty(f.token, os.Getenv("ERST_RPC_TOKEN"))
+	if token == "" && name != "" {
+		if token, err = newKeyPrompt(cmd).profileToken(name); err != nil {
+			return nil, err
+		}
+	}
+	if token = firstNonEmpty(token, cfg.RPCToken); token != "" {
+		opts = append(opts, rpc.WithToken(token))
+	}
+	headers := firstNonEmpty(f.headers, os.Getenv("ERST_RPC_HEADERS"), os.Getenv("STELLAR_RPC_HEADERS"), profile.RPCHeaders, cfg.RpcHeaders)
+	if headers != "" {
+		opts = append(opts, rpc.WithHeaders(rpc.ParseHeaders(headers)))
+	}
+	if url := firstNonEmpty(f.horizonURL, profile.HorizonURL); url != "" {
+		opts = append(opts, rpc.WithHorizonURL(url))
+	}
+	if url := firstNonEmpty(f.sorobanURL, profile.SorobanURL); url != "" {
+		opts = append(opts, rpc.WithSorobanURL(url))
+	}
+
+	client, err := rpc.NewClient(opts...)
+	if err != nil {
+		return nil, errors.WrapValidationError(fmt.Sprintf("failed to create client: %v", err))
+	}
+	return client, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
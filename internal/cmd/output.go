@@ -0,0 +1,162 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Formats accepted by the global --output flag.
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+	outputYAML  = "yaml"
+)
+
+// OutputFlag is the global --output format. Empty means the command's
+// default, which is human-readable text.
+var OutputFlag string
+
+// structuredOutputAnnotation marks commands whose result can be printed
+// as JSON or YAML.
+const structuredOutputAnnotation = "erst/structured-output"
+
+// supportStructuredOutput declares that cmds honor --output json and yaml.
+func supportStructuredOutput(cmds ...*cobra.Command) {
+	for _, c := range cmds {
+		if c.Annotations == nil {
+			c.Annotations = map[string]string{}
+		}
+		c.Annotations[structuredOutputAnnotation] = "true"
+	}
+}
+
+// legacyOutputAnnotation names the flag that took over a command's old
+// --output flag, which the global one now shadows.
+const legacyOutputAnnotation = "erst/legacy-output"
+
+// renamedOutputFlag declares that cmd's own --output flag was renamed to
+// flag. Until it is removed, --output values that are not formats still go
+// to flag, with a deprecation warning.
+func renamedOutputFlag(cmd *cobra.Command, flag string) {
+	if cmd.Annotations == nil {
+		cmd.Annotations = map[string]string{}
+	}
+	cmd.Annotations[legacyOutputAnnotation] = flag
+}
+
+// checkOutputFlag rejects unknown formats, and structured formats for
+// commands that only print text, before the command runs.
+func checkOutputFlag(cmd *cobra.Command) error {
+	switch OutputFlag {
+	case "", outputTable:
+		return nil
+	case outputJSON, outputYAML:
+		if cmd.Annotations[structuredOutputAnnotation] != "true" {
+			return errors.WrapValidationError(fmt.Sprintf("%s does not support --output %s", cmd.CommandPath(), OutputFlag))
+		}
+		return nil
+	}
+	if flag := cmd.Annotations[legacyOutputAnnotation]; flag != "" && !cmd.Flags().Changed(flag) {
+		if err := cmd.Flags().Set(flag, OutputFlag); err != nil {
+			return errors.WrapValidationError(fmt.Sprintf("invalid --%s: %v", flag, err))
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %s --output %s is deprecated; use --%s %s\n", cmd.CommandPath(), OutputFlag, flag, OutputFlag)
+		OutputFlag = ""
+		return nil
+	}
+	return errors.WrapValidationError(fmt.Sprintf("invalid --output: %s (use: table, json, yaml)", OutputFlag))
+}
+
+// outputFormat is the format a command prints in: json when its own --json
+// flag is set, otherwise the global --output, defaulting to table.
+func outputFormat(jsonFlag bool) string {
+	switch {
+	case jsonFlag:
+		return outputJSON
+	case OutputFlag == "":
+		return outputTable
+	}
+	return OutputFlag
+}
+
+// requireForce rejects running a command that asks for confirmation with
+// structured output, where there is no one to answer the prompt.
+func requireForce(format string, force bool) error {
+	if format == outputTable || force {
+		return nil
+	}
+	return errors.WrapValidationError(fmt.Sprintf("--output %s requires --force", format))
+}
+
+// progressWriter is where a command reports progress: stdout for table
+// output, stderr otherwise so structured output stays parseable.
+func progressWriter(cmd *cobra.Command, format string) io.Writer {
+	if format == outputTable {
+		return cmd.OutOrStdout()
+	}
+	return cmd.ErrOrStderr()
+}
+
+// writeStructured prints v as indented JSON or as YAML. YAML is produced
+// from the JSON encoding, so both have the same keys, in the same order.
+func writeStructured(w io.Writer, format string, v interface{}) error {
+	if format == outputYAML {
+		return writeYAML(w, v)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// writeRecord prints one record of a stream: a line of JSON or a YAML
+// document.
+func writeRecord(w io.Writer, format string, v interface{}) error {
+	if format == outputYAML {
+		if _, err := io.WriteString(w, "---\n"); err != nil {
+			return err
+		}
+		return writeYAML(w, v)
+	}
+	return json.NewEncoder(w).Encode(v)
+}
+
+func writeYAML(w io.Writer, v interface{}) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return errors.WrapMarshalFailed(err)
+	}
+	// JSON is valid YAML; parsing it into a node keeps the key order, and
+	// clearing the flow style makes the encoder print block YAML.
+	var doc yaml.Node
+	if err := yaml.Unmarshal(raw, &doc); err != nil {
+		return errors.WrapMarshalFailed(err)
+	}
+	clearStyle(&doc)
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return errors.WrapMarshalFailed(err)
+	}
+	return enc.Close()
+}
+
+// clearStyle resets the JSON styles of n and its children. Strings that
+// would read back as another type stay quoted.
+func clearStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		clearStyle(c)
+	}
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVarP(&OutputFlag, "output", "o", "", "Output format: table, json or yaml (default: table)")
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withOutputFlag(t *testing.T, format string) {
+	t.Helper()
+	OutputFlag = format
+	t.Cleanup(func() { OutputFlag = "" })
+}
+
+func TestWriteStructuredYAML(t *testing.T) {
+	v := struct {
+		Zeta   string            `json:"zeta"`
+		Alpha  int               `json:"alpha"`
+		Number string            `json:"number"`
+		Flag   string            `json:"flag"`
+		List   []string          `json:"list"`
+		Raw    json.RawMessage   `json:"raw"`
+		Empty  map[string]string `json:"empty"`
+	}{"last", 1, "123", "true", []string{"a", "b"}, json.RawMessage(`{"b":1,"a":2}`), nil}
+
+	out := &bytes.Buffer{}
+	require.NoError(t, writeStructured(out, outputYAML, v))
+	assert.Equal(t, `zeta: last
+alpha: 1
+number: "123"
+flag: "true"
+list:
+  - a
+  - b
+raw:
+  b: 1
+  a: 2
+empty: null
+`, out.String())
+
+	out.Reset()
+	require.NoError(t, writeStructured(out, outputJSON, map[string]int{"a": 1}))
+	assert.Equal(t, "{\n  \"a\": 1\n}\n", out.String())
+}
+
+func TestWriteRecord(t *testing.T) {
+	out := &bytes.Buffer{}
+	require.NoError(t, writeRecord(out, outputJSON, map[string]int{"a": 1}))
+	require.NoError(t, writeRecord(out, outputJSON, map[string]int{"a": 2}))
+	assert.Equal(t, "{\"a\":1}\n{\"a\":2}\n", out.String())
+
+	out.Reset()
+	require.NoError(t, writeRecord(out, outputYAML, map[string]int{"a": 1}))
+	require.NoError(t, writeRecord(out, outputYAML, map[string]int{"a": 2}))
+	assert.Equal(t, "---\na: 1\n---\na: 2\n", out.String())
+}
+
+func TestCheckOutputFlag(t *testing.T) {
+	structured := &cobra.Command{Use: "structured"}
+	supportStructuredOutput(structured)
+	plain := &cobra.Command{Use: "plain"}
+
+	for _, format := range []string{"", "table", "json", "yaml"} {
+		withOutputFlag(t, format)
+		assert.NoError(t, checkOutputFlag(structured), format)
+	}
+
+	withOutputFlag(t, "table")
+	assert.NoError(t, checkOutputFlag(plain))
+	withOutputFlag(t, "yaml")
+	assert.ErrorContains(t, checkOutputFlag(plain), "plain does not support --output yaml")
+	withOutputFlag(t, "xml")
+	assert.ErrorContains(t, checkOutputFlag(structured), "invalid --output: xml")
+}
+
+func TestCheckOutputFlagRenamed(t *testing.T) {
+	var dir string
+	cmd := &cobra.Command{Use: "report"}
+	cmd.Flags().StringVar(&dir, "out-dir", ".", "")
+	supportStructuredOutput(cmd)
+	renamedOutputFlag(cmd, "out-dir")
+	stderr := &bytes.Buffer{}
+	cmd.SetErr(stderr)
+
+	withOutputFlag(t, "reports/")
+	require.NoError(t, checkOutputFlag(cmd))
+	assert.Equal(t, "reports/", dir)
+	assert.Empty(t, OutputFlag)
+	assert.Contains(t, stderr.String(), "report --output reports/ is deprecated; use --out-dir reports/")
+
+	// Formats keep their meaning.
+	withOutputFlag(t, "json")
+	require.NoError(t, checkOutputFlag(cmd))
+	assert.Equal(t, "json", OutputFlag)
+
+	// The new flag wins over the old spelling.
+	require.NoError(t, cmd.Flags().Set("out-dir", "new/"))
+	withOutputFlag(t, "old/")
+	assert.ErrorContains(t, checkOutputFlag(cmd), "invalid --output: old/")
+}
+
+func TestOutputFormat(t *testing.T) {
+	withOutputFlag(t, "")
+	assert.Equal(t, outputTable, outputFormat(false))
+	assert.Equal(t, outputJSON, outputFormat(true))
+
+	withOutputFlag(t, "yaml")
+	assert.Equal(t, outputYAML, outputFormat(false))
+	assert.Equal(t, outputJSON, outputFormat(true), "--json wins over --output")
+}
+
+func TestConfigListOutputYAML(t *testing.T) {
+	withConfigHome(t)
+	runConfig(t, runConfigSet, "network", "testnet")
+
+	withOutputFlag(t, "yaml")
+	assert.Equal(t, "active: default\nprofiles:\n  default:\n    network: testnet\n", runConfig(t, runConfigList))
+}
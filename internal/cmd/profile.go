@@ -17,15 +17,23 @@ var (
 	profileOutput    string
 )
 
+// profileReport is the output of erst profile; its JSON form is stable.
+type profileReport struct {
+	TraceFile string `json:"trace_file"`
+	Profile   string `json:"profile"`
+}
+
 var profileCmd = &cobra.Command{
 	Use:   "profile [trace-file]",
 	Short: "Export trace as pprof profile for gas-to-function mapping",
 	Long: `Synthesize trace events into a pprof-compliant profile that maps gas
 consumption to functions. The output can be viewed with go tool pprof.
 
+To switch connection profiles instead, see erst config use-profile.
+
 Example:
-  erst profile execution.json -o gas.pb.gz
-  erst profile --file debug_trace.json -o gas.pb.gz
+  erst profile execution.json --out-file gas.pb.gz
+  erst profile --file debug_trace.json --out-file gas.pb.gz
   go tool pprof gas.pb.gz`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -67,14 +75,20 @@ Example:
 			return fmt.Errorf("failed to write pprof profile: %w", err)
 		}
 
-		fmt.Printf("Profile written to %s\n", outPath)
-		fmt.Printf("View with: go tool pprof %s\n", outPath)
+		w := cmd.OutOrStdout()
+		if format := outputFormat(false); format != outputTable {
+			return writeStructured(w, format, profileReport{TraceFile: filename, Profile: outPath})
+		}
+		fmt.Fprintf(w, "Profile written to %s\n", outPath)
+		fmt.Fprintf(w, "View with: go tool pprof %s\n", outPath)
 		return nil
 	},
 }
 
 func init() {
 	profileCmd.Flags().StringVarP(&profileTraceFile, "file", "f", "", "Trace file to load")
-	profileCmd.Flags().StringVarP(&profileOutput, "output", "o", "profile.pb.gz", "Output pprof file path")
+	profileCmd.Flags().StringVar(&profileOutput, "out-file", "profile.pb.gz", "Output pprof file path")
+	supportStructuredOutput(profileCmd)
+	renamedOutputFlag(profileCmd, "out-file")
 	rootCmd.AddCommand(profileCmd)
 }
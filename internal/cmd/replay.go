@@ -0,0 +1,385 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/spf13/cobra"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+var (
+	replayNet  networkFlags
+	replayJSON bool
+)
+
+var replayCmd = &cobra.Command{
+	Use:   "replay <txhash>",
+	Short: "Re-simulate a Soroban transaction and diff it against what happened on-chain",
+	Long: `Fetch a Soroban transaction, the ledger entries in its footprint and the
+code of the contracts it calls, simulate the same envelope again and compare
+the outcome, return value and contract events with the recorded result.
+
+Soroban RPC only simulates against its latest ledger, so the replay runs on
+current state. Footprint entries modified after the transaction's ledger, or
+no longer live, are listed: when the replay diverges they are the first place
+to look.
+
+Examples:
+  erst replay 5c0a...e1 --network testnet
+  erst replay 5c0a...e1 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReplay,
+}
+
+// replayReport is the output of erst replay; its JSON form is stable.
+type replayReport struct {
+	Hash         string `json:"hash"`
+	Network      string `json:"network"`
+	Ledger       int32  `json:"ledger"`
+	LatestLedger uint32 `json:"latest_ledger"`
+
+	OnChain  replayOutcome `json:"on_chain"`
+	Replayed replayOutcome `json:"replayed"`
+
+	Contracts []replayContract `json:"contracts,omitempty"`
+	// Drifted lists footprint keys whose entry changed or disappeared
+	// after the transaction's ledger.
+	Drifted []replayDrift `json:"drifted,omitempty"`
+	// Differences is empty when the replay matches the on-chain result.
+	Differences []string `json:"differences,omitempty"`
+}
+
+type replayOutcome struct {
+	Success     bool                   `json:"success"`
+	Error       string                 `json:"error,omitempty"`
+	ReturnValue string                 `json:"return_value,omitempty"`
+	Events      []decoder.DecodedEvent `json:"events"`
+}
+
+type replayContract struct {
+	ContractID string `json:"contract_id"`
+	WasmHash   string `json:"wasm_hash,omitempty"`
+	CodeSize   int    `json:"code_size,omitempty"`
+
+	wasmHash *xdr.Hash
+}
+
+type replayDrift struct {
+	Key                string `json:"key"`
+	LastModifiedLedger int    `json:"last_modified_ledger,omitempty"`
+	Missing            bool   `json:"missing,omitempty"`
+}
+
+func runReplay(cmd *cobra.Command, args []string) error {
+	hash := args[0]
+	if err := rpc.ValidateTransactionHash(hash); err != nil {
+		return errors.WrapValidationError(fmt.Sprintf("invalid transaction hash: %v", err))
+	}
+	client, err := replayNet.client(cmd)
+	if err != nil {
+		return err
+	}
+	report, err := replay(cmd, client, hash)
+	if err != nil {
+		return err
+	}
+
+	if format := outputFormat(replayJSON); format != outputTable {
+		return writeStructured(cmd.OutOrStdout(), format, report)
+	}
+	printReplayReport(cmd.OutOrStdout(), report)
+	return nil
+}
+
+func replay(cmd *cobra.Command, client *rpc.Client, hash string) (*replayReport, error) {
+	ctx := cmd.Context()
+	tx, err := client.GetTransaction(ctx, hash)
+	if err != nil {
+		return nil, errors.WrapTransactionNotFound(err)
+	}
+	var env xdr.TransactionEnvelope
+	if err := xdr.SafeUnmarshalBase64(tx.EnvelopeXdr, &env); err != nil {
+		return nil, errors.WrapUnmarshalFailed(err, "TransactionEnvelope")
+	}
+	// Simulation takes the transaction a fee bump wraps.
+	if env.IsFeeBump() {
+		env = xdr.TransactionEnvelope{Type: xdr.EnvelopeTypeEnvelopeTypeTx, V1: env.FeeBump.Tx.InnerTx.V1}
+	}
+	if env.V1 == nil || env.V1.Tx.Ext.SorobanData == nil {
+		return nil, errors.WrapValidationError("replay needs a Soroban transaction; " + hash + " has no Soroban data")
+	}
+	data := *env.V1.Tx.Ext.SorobanData
+
+	report := &replayReport{Hash: hash, Network: client.GetNetworkName(), Ledger: tx.Ledger}
+	if report.OnChain, err = onChainOutcome(tx); err != nil {
+		return nil, err
+	}
+
+	footprint := slices.Concat(data.Resources.Footprint.ReadOnly, data.Resources.Footprint.ReadWrite)
+	if err := fetchFootprint(cmd, client, report, footprint); err != nil {
+		return nil, err
+	}
+
+	envXDR, err := xdr.MarshalBase64(env)
+	if err != nil {
+		return nil, errors.WrapMarshalFailed(err)
+	}
+	sim, err := client.SimulateTransaction(ctx, envXDR)
+	if err != nil {
+		return nil, err
+	}
+	if sim.Error != nil {
+		return nil, errors.WrapRPCError(client.SorobanURL, sim.Error.Message, sim.Error.Code)
+	}
+	if report.Replayed, err = replayedOutcome(sim); err != nil {
+		return nil, err
+	}
+	report.LatestLedger = sim.Result.LatestLedger
+	report.Differences = diffOutcomes(report.OnChain, report.Replayed)
+	return report, nil
+}
+
+// onChainOutcome reads what the transaction did from its result and meta.
+func onChainOutcome(tx *rpc.TransactionResponse) (replayOutcome, error) {
+	out := replayOutcome{Events: []decoder.DecodedEvent{}}
+	result, err := decoder.AnalyzeResult(tx.ResultXdr)
+	if err != nil {
+		return out, errors.WrapUnmarshalFailed(err, "TransactionResult")
+	}
+	out.Success = result.Successful
+	if !out.Success {
+		out.Error = result.Code
+		for _, op := range result.Operations {
+			if !op.Successful {
+				out.Error += ": " + op.Code
+				break
+			}
+		}
+		events, err := decoder.DiagnosticEventsFromMetaXDR(tx.ResultMetaXdr)
+		if err == nil {
+			if summary := decoder.SummarizeFailure(events); summary != "" {
+				out.Error += " (" + summary + ")"
+			}
+		}
+	}
+
+	if tx.ResultMetaXdr == "" {
+		return out, nil
+	}
+	meta, err := decoder.AnalyzeMeta(tx.ResultMetaXdr)
+	if err != nil {
+		return out, errors.WrapUnmarshalFailed(err, "TransactionMeta")
+	}
+	for _, op := range meta.Operations {
+		out.Events = append(out.Events, op.Events...)
+	}
+	if meta.Soroban != nil {
+		out.ReturnValue = meta.Soroban.ReturnValue
+		out.Events = append(out.Events, meta.Soroban.Events...)
+	}
+	return out, nil
+}
+
+// replayedOutcome reads the same facts from a simulation. Contract events
+// raised inside a call that later failed are rolled back on-chain, so they
+// are left out.
+func replayedOutcome(sim *rpc.SimulateTransactionResponse) (replayOutcome, error) {
+	out := replayOutcome{
+		Success: sim.Result.Error == "",
+		Error:   sim.Result.Error,
+		Events:  []decoder.DecodedEvent{},
+	}
+	if len(sim.Result.Results) > 0 {
+		var val xdr.ScVal
+		if res := sim.Result.Results[0]; res.XDR != "" && xdr.SafeUnmarshalBase64(res.XDR, &val) == nil {
+			out.ReturnValue = decoder.FormatScVal(val)
+		}
+	}
+	events, err := decoder.DecodeDiagnosticEventsXDR(sim.Result.Events)
+	if err != nil {
+		return out, errors.WrapUnmarshalFailed(err, "diagnostic events")
+	}
+	for _, e := range events {
+		if e.Kind == decoder.DiagnosticKindContract && e.InSuccessfulContractCall {
+			out.Events = append(out.Events, decoder.DecodedEvent{ContractID: e.ContractID, Topics: e.Topics, Data: e.Data})
+		}
+	}
+	return out, nil
+}
+
+// fetchFootprint loads the footprint entries, notes the ones that drifted
+// since the transaction's ledger, and loads the code of every contract
+// instance found, fetching code the footprint does not already include.
+func fetchFootprint(cmd *cobra.Command, client *rpc.Client, report *replayReport, footprint []xdr.LedgerKey) error {
+	keys := make([]string, 0, len(footprint))
+	for _, k := range footprint {
+		b64, err := xdr.MarshalBase64(k)
+		if err != nil {
+			return errors.WrapMarshalFailed(err)
+		}
+		keys = append(keys, b64)
+	}
+	fetched, err := client.GetLedgerEntriesWithTTL(cmd.Context(), keys)
+	if err != nil {
+		return err
+	}
+
+	live := make(map[string]rpc.LedgerEntryResult, len(fetched.Entries))
+	for _, e := range fetched.Entries {
+		live[e.Key] = e
+	}
+	for _, k := range keys {
+		e, ok := live[k]
+		switch {
+		case !ok:
+			report.Drifted = append(report.Drifted, replayDrift{Key: k, Missing: true})
+		case e.LastModifiedLedger > int(report.Ledger):
+			report.Drifted = append(report.Drifted, replayDrift{Key: k, LastModifiedLedger: e.LastModifiedLedger})
+		}
+	}
+
+	codeSize := map[xdr.Hash]int{}
+	var missing []string
+	for _, e := range fetched.Entries {
+		var entry xdr.LedgerEntryData
+		if err := xdr.SafeUnmarshalBase64(e.Xdr, &entry); err != nil {
+			return errors.WrapUnmarshalFailed(err, "LedgerEntryData")
+		}
+		switch {
+		case entry.Type == xdr.LedgerEntryTypeContractCode:
+			codeSize[entry.ContractCode.Hash] = len(entry.ContractCode.Code)
+		case entry.Type == xdr.LedgerEntryTypeContractData && entry.ContractData.Key.Type == xdr.ScValTypeScvLedgerKeyContractInstance:
+			c := replayContract{}
+			if id := entry.ContractData.Contract.ContractId; id != nil {
+				c.ContractID, _ = strkey.Encode(strkey.VersionByteContract, id[:])
+			}
+			if inst := entry.ContractData.Val.Instance; inst != nil && inst.Executable.WasmHash != nil {
+				c.WasmHash, c.wasmHash = inst.Executable.WasmHash.HexString(), inst.Executable.WasmHash
+				key, err := xdr.MarshalBase64(xdr.LedgerKey{
+					Type:         xdr.LedgerEntryTypeContractCode,
+					ContractCode: &xdr.LedgerKeyContractCode{Hash: *c.wasmHash},
+				})
+				if err != nil {
+					return errors.WrapMarshalFailed(err)
+				}
+				if !slices.Contains(keys, key) {
+					missing = append(missing, key)
+				}
+			}
+			report.Contracts = append(report.Contracts, c)
+		}
+	}
+
+	if len(missing) > 0 {
+		code, err := client.GetLedgerEntriesWithTTL(cmd.Context(), missing)
+		if err != nil {
+			return err
+		}
+		for _, e := range code.Entries {
+			var entry xdr.LedgerEntryData
+			if err := xdr.SafeUnmarshalBase64(e.Xdr, &entry); err == nil && entry.ContractCode != nil {
+				codeSize[entry.ContractCode.Hash] = len(entry.ContractCode.Code)
+			}
+		}
+	}
+	for i, c := range report.Contracts {
+		if c.wasmHash != nil {
+			report.Contracts[i].CodeSize = codeSize[*c.wasmHash]
+		}
+	}
+	return nil
+}
+
+// diffOutcomes lists how the replay differs from the on-chain result.
+func diffOutcomes(onChain, replayed replayOutcome) []string {
+	var diffs []string
+	if onChain.Success != replayed.Success {
+		diffs = append(diffs, fmt.Sprintf("status: on-chain %s, replay %s", outcomeStatus(onChain), outcomeStatus(replayed)))
+	}
+	if onChain.Success && replayed.Success && onChain.ReturnValue != replayed.ReturnValue {
+		diffs = append(diffs, fmt.Sprintf("return value: on-chain %s, replay %s", onChain.ReturnValue, replayed.ReturnValue))
+	}
+	if len(onChain.Events) != len(replayed.Events) {
+		diffs = append(diffs, fmt.Sprintf("events: on-chain emitted %d, replay %d", len(onChain.Events), len(replayed.Events)))
+	}
+	for i := range min(len(onChain.Events), len(replayed.Events)) {
+		a, b := onChain.Events[i], replayed.Events[i]
+		if a.ContractID != b.ContractID || !slices.Equal(a.Topics, b.Topics) || a.Data != b.Data {
+			diffs = append(diffs, fmt.Sprintf("event %d: on-chain %s, replay %s", i, formatEvent(a), formatEvent(b)))
+		}
+	}
+	return diffs
+}
+
+func outcomeStatus(o replayOutcome) string {
+	if o.Success {
+		return "succeeded"
+	}
+	return "failed"
+}
+
+func formatEvent(e decoder.DecodedEvent) string {
+	return fmt.Sprintf("%s %v => %s", e.ContractID, e.Topics, e.Data)
+}
+
+func printReplayReport(w io.Writer, r *replayReport) {
+	fmt.Fprintf(w, "Replay of %s on %s\n", r.Hash, r.Network)
+	fmt.Fprintf(w, "  Applied in ledger %d, replayed at ledger %d\n", r.Ledger, r.LatestLedger)
+
+	for _, side := range []struct {
+		label string
+		o     replayOutcome
+	}{{"On-chain", r.OnChain}, {"Replayed", r.Replayed}} {
+		fmt.Fprintf(w, "\n%s: %s\n", side.label, outcomeStatus(side.o))
+		if side.o.Error != "" {
+			fmt.Fprintf(w, "  Error: %s\n", side.o.Error)
+		}
+		if side.o.ReturnValue != "" {
+			fmt.Fprintf(w, "  Return value: %s\n", side.o.ReturnValue)
+		}
+		fmt.Fprintf(w, "  Events: %d\n", len(side.o.Events))
+	}
+
+	if len(r.Contracts) > 0 {
+		fmt.Fprintln(w, "\nContracts:")
+		for _, c := range r.Contracts {
+			fmt.Fprintf(w, "  %s wasm=%s (%d bytes)\n", c.ContractID, c.WasmHash, c.CodeSize)
+		}
+	}
+	if len(r.Drifted) > 0 {
+		fmt.Fprintf(w, "\nFootprint entries changed since ledger %d (%d):\n", r.Ledger, len(r.Drifted))
+		for _, d := range r.Drifted {
+			if d.Missing {
+				fmt.Fprintf(w, "  %s (no longer live)\n", d.Key)
+			} else {
+				fmt.Fprintf(w, "  %s (modified in ledger %d)\n", d.Key, d.LastModifiedLedger)
+			}
+		}
+	}
+
+	if len(r.Differences) == 0 {
+		fmt.Fprintln(w, "\nReplay matches the on-chain result.")
+		return
+	}
+	fmt.Fprintf(w, "\nReplay diverges (%d):\n", len(r.Differences))
+	for _, d := range r.Differences {
+		fmt.Fprintf(w, "  - %s\n", d)
+	}
+}
+
+func init() {
+	replayNet.register(replayCmd)
+	replayCmd.Flags().BoolVar(&replayJSON, "json", false, "Print the report as JSON")
+
+	supportStructuredOutput(replayCmd)
+	rootCmd.AddCommand(replayCmd)
+}
@@ -0,0 +1,168 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/rpc/rpctest"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const replayHash = "3389e9f0f1a65f19736cacf544c2e825313e8447f569233bb8db39aa607c8889"
+
+func mustB64(t *testing.T, v any) string {
+	t.Helper()
+	s, err := xdr.MarshalBase64(v)
+	require.NoError(t, err)
+	return s
+}
+
+func u32Val(n uint32) xdr.ScVal {
+	v := xdr.Uint32(n)
+	return xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: &v}
+}
+
+// replayFixture serves a successful invocation that returned 7 and emitted
+// one event, whose footprint holds the contract instance and one data
+// entry that has since been removed.
+func replayFixture(t *testing.T, simReturn uint32) (*rpctest.HorizonServer, *rpctest.SorobanServer) {
+	t.Helper()
+	cid := xdr.ContractId{2}
+	contract := xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &cid}
+	wasm := xdr.Hash{9}
+	instanceKey := xdr.LedgerKey{Type: xdr.LedgerEntryTypeContractData, ContractData: &xdr.LedgerKeyContractData{
+		Contract: contract, Key: xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance}, Durability: xdr.ContractDataDurabilityPersistent,
+	}}
+	balanceKey := xdr.LedgerKey{Type: xdr.LedgerEntryTypeContractData, ContractData: &xdr.LedgerKeyContractData{
+		Contract: contract, Key: u32Val(1), Durability: xdr.ContractDataDurabilityPersistent,
+	}}
+
+	kp := keypair.MustRandom()
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &txnbuild.SimpleAccount{AccountID: kp.Address(), Sequence: 1},
+		IncrementSequenceNum: true,
+		Operations: []txnbuild.Operation{&txnbuild.InvokeHostFunction{
+			HostFunction: xdr.HostFunction{
+				Type:           xdr.HostFunctionTypeHostFunctionTypeInvokeContract,
+				InvokeContract: &xdr.InvokeContractArgs{ContractAddress: contract, FunctionName: "hello"},
+			},
+			Ext: xdr.TransactionExt{V: 1, SorobanData: &xdr.SorobanTransactionData{
+				Resources: xdr.SorobanResources{Footprint: xdr.LedgerFootprint{
+					ReadOnly:  []xdr.LedgerKey{instanceKey},
+					ReadWrite: []xdr.LedgerKey{balanceKey},
+				}},
+			}},
+		}},
+		BaseFee:       txnbuild.MinBaseFee,
+		Preconditions: txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+	})
+	require.NoError(t, err)
+	env, err := tx.Base64()
+	require.NoError(t, err)
+
+	event := xdr.ContractEvent{ContractId: &cid, Type: xdr.ContractEventTypeContract, Body: xdr.ContractEventBody{
+		V0: &xdr.ContractEventV0{Topics: []xdr.ScVal{u32Val(3)}, Data: u32Val(5)},
+	}}
+	result := xdr.TransactionResult{FeeCharged: 100, Result: xdr.TransactionResultResult{
+		Code: xdr.TransactionResultCodeTxSuccess,
+		Results: &[]xdr.OperationResult{{Code: xdr.OperationResultCodeOpInner, Tr: &xdr.OperationResultTr{
+			Type:                     xdr.OperationTypeInvokeHostFunction,
+			InvokeHostFunctionResult: &xdr.InvokeHostFunctionResult{Code: xdr.InvokeHostFunctionResultCodeInvokeHostFunctionSuccess, Success: &xdr.Hash{}},
+		}}},
+	}}
+	meta := xdr.TransactionMeta{V: 3, V3: &xdr.TransactionMetaV3{
+		Operations:  []xdr.OperationMeta{{}},
+		SorobanMeta: &xdr.SorobanTransactionMeta{Events: []xdr.ContractEvent{event}, ReturnValue: u32Val(7)},
+	}}
+
+	horizon := rpctest.NewHorizonServer()
+	t.Cleanup(horizon.Close)
+	horizon.AddTransaction(hProtocol.Transaction{
+		Hash: replayHash, Ledger: 100, Successful: true,
+		EnvelopeXdr: env, ResultXdr: mustB64(t, result), ResultMetaXdr: mustB64(t, meta),
+	})
+
+	instance := xdr.LedgerEntryData{Type: xdr.LedgerEntryTypeContractData, ContractData: &xdr.ContractDataEntry{
+		Contract: contract, Key: xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance}, Durability: xdr.ContractDataDurabilityPersistent,
+		Val: xdr.ScVal{Type: xdr.ScValTypeScvContractInstance, Instance: &xdr.ScContractInstance{
+			Executable: xdr.ContractExecutable{Type: xdr.ContractExecutableTypeContractExecutableWasm, WasmHash: &wasm},
+		}},
+	}}
+	code := xdr.LedgerEntryData{Type: xdr.LedgerEntryTypeContractCode, ContractCode: &xdr.ContractCodeEntry{Hash: wasm, Code: []byte{0, 'a', 's'}}}
+	codeKey := xdr.LedgerKey{Type: xdr.LedgerEntryTypeContractCode, ContractCode: &xdr.LedgerKeyContractCode{Hash: wasm}}
+
+	soroban := rpctest.NewSorobanServer()
+	t.Cleanup(soroban.Close)
+	soroban.On("getLedgerEntries",
+		rpctest.Result(map[string]any{"latestLedger": 150, "entries": []map[string]any{
+			{"key": mustB64(t, instanceKey), "xdr": mustB64(t, instance), "lastModifiedLedgerSeq": 50},
+		}}),
+		rpctest.Result(map[string]any{"latestLedger": 150, "entries": []map[string]any{
+			{"key": mustB64(t, codeKey), "xdr": mustB64(t, code), "lastModifiedLedgerSeq": 40},
+		}}),
+	)
+	soroban.On("simulateTransaction", rpctest.Result(map[string]any{
+		"latestLedger": 150,
+		"results":      []map[string]any{{"xdr": mustB64(t, u32Val(simReturn)), "auth": []string{}}},
+		"events":       []string{mustB64(t, xdr.DiagnosticEvent{InSuccessfulContractCall: true, Event: event})},
+	}))
+	return horizon, soroban
+}
+
+func runReplayJSON(t *testing.T, simReturn uint32) replayReport {
+	t.Helper()
+	horizon, soroban := replayFixture(t, simReturn)
+	replayNet = networkFlags{network: "testnet", horizonURL: horizon.URL, sorobanURL: soroban.URL}
+	replayJSON = true
+	defer func() { replayNet, replayJSON = networkFlags{}, false }()
+
+	cmd, out := testCommand("")
+	require.NoError(t, runReplay(cmd, []string{replayHash}))
+	var report replayReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	return report
+}
+
+func TestReplayMatches(t *testing.T) {
+	report := runReplayJSON(t, 7)
+
+	assert.Equal(t, int32(100), report.Ledger)
+	assert.Equal(t, uint32(150), report.LatestLedger)
+	assert.True(t, report.OnChain.Success)
+	assert.Equal(t, "7", report.OnChain.ReturnValue)
+	assert.Equal(t, report.OnChain, report.Replayed)
+	assert.Empty(t, report.Differences)
+
+	require.Len(t, report.Contracts, 1)
+	assert.Equal(t, (&xdr.Hash{9}).HexString(), report.Contracts[0].WasmHash)
+	assert.Equal(t, 3, report.Contracts[0].CodeSize)
+	require.Len(t, report.Drifted, 1)
+	assert.True(t, report.Drifted[0].Missing)
+}
+
+func TestReplayDiverges(t *testing.T) {
+	report := runReplayJSON(t, 8)
+
+	require.Len(t, report.Differences, 1)
+	assert.Contains(t, report.Differences[0], "return value: on-chain 7, replay 8")
+
+	var buf bytes.Buffer
+	printReplayReport(&buf, &report)
+	assert.Contains(t, buf.String(), "Replay diverges (1)")
+	assert.Contains(t, buf.String(), "no longer live")
+}
+
+func TestReplayRejectsBadHash(t *testing.T) {
+	cmd, _ := testCommand("")
+	err := runReplay(cmd, []string{"nothex"})
+	assert.ErrorContains(t, err, "invalid transaction hash")
+}
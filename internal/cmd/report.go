@@ -34,9 +34,9 @@ Reports include:
   - Timeline and event distribution
 
 Examples:
-  erst report --file trace.json --format html --output reports/
-  erst report --file trace.json --format pdf --output reports/
-  erst report --file trace.json --format html,pdf --output reports/`,
+  erst report --file trace.json --format html --out-dir reports/
+  erst report --file trace.json --format pdf --out-dir reports/
+  erst report --file trace.json --format html,pdf --out-dir reports/`,
 	RunE: reportExec,
 }
 
@@ -139,7 +139,12 @@ func reportExec(cmd *cobra.Command, args []string) error {
 			return errors.WrapValidationError(fmt.Sprintf("failed to write JSON report: %v", err))
 		}
 
-		fmt.Printf("[OK] Report generated: %s\n", filename)
+		if format := outputFormat(false); format != outputTable {
+			return writeStructured(cmd.OutOrStdout(), format, reportListing{
+				Files: []reportArtifact{{Format: "json", Path: filename}},
+			})
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "[OK] Report generated: %s\n", filename)
 		return nil
 	}
 
@@ -148,13 +153,34 @@ func reportExec(cmd *cobra.Command, args []string) error {
 		return errors.WrapValidationError(fmt.Sprintf("failed to export report: %v", err))
 	}
 
-	for format, path := range results {
-		fmt.Printf("[OK] %s report generated: %s\n", string(format), path)
+	written := reportListing{Files: []reportArtifact{}}
+	for _, format := range formats {
+		if path, ok := results[format]; ok {
+			written.Files = append(written.Files, reportArtifact{Format: format, Path: path})
+		}
+	}
+
+	w := cmd.OutOrStdout()
+	if format := outputFormat(false); format != outputTable {
+		return writeStructured(w, format, written)
+	}
+	for _, f := range written.Files {
+		fmt.Fprintf(w, "[OK] %s report generated: %s\n", f.Format, f.Path)
 	}
 
 	return nil
 }
 
+// reportListing is the output of erst report; its JSON form is stable.
+type reportListing struct {
+	Files []reportArtifact `json:"files"`
+}
+
+type reportArtifact struct {
+	Format string `json:"format"`
+	Path   string `json:"path"`
+}
+
 func countErrors(states []trace.ExecutionState) int {
 	count := 0
 	for _, state := range states {
@@ -216,8 +242,10 @@ func calculateRiskScore(states []trace.ExecutionState) float64 {
 
 func init() {
 	reportCmd.Flags().StringVar(&reportFormat, "format", "html", "Output format: html, pdf, json, or html,pdf")
-	reportCmd.Flags().StringVar(&reportOutput, "output", ".", "Output directory for reports")
+	reportCmd.Flags().StringVar(&reportOutput, "out-dir", ".", "Output directory for reports")
 	reportCmd.Flags().StringVar(&reportFile, "file", "", "Trace file to analyze")
 
+	supportStructuredOutput(reportCmd)
+	renamedOutputFlag(reportCmd, "out-dir")
 	rootCmd.AddCommand(reportCmd)
 }
@@ -40,6 +40,10 @@ Examples:
 
 Get started with 'erst debug --help' or visit the documentation.`,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := checkOutputFlag(cmd); err != nil {
+			return err
+		}
+
 		// Load localizations
 		if err := localization.LoadTranslations(); err != nil {
 			return err
@@ -57,6 +61,7 @@ Get started with 'erst debug --help' or visit the documentation.`,
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() error {
+	registerFlagCompletions(rootCmd)
 	return rootCmd.Execute()
 }
 
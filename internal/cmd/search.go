@@ -18,6 +18,12 @@ var (
 	searchLimitFlag int
 )
 
+// searchReport is the output of erst search; its JSON form is stable.
+// Sessions are ordered most recent first.
+type searchReport struct {
+	Sessions []db.Session `json:"sessions"`
+}
+
 var searchCmd = &cobra.Command{
 	Use:   "search",
 	Short: "Search through saved debugging sessions",
@@ -61,30 +67,38 @@ Results are ordered by timestamp (most recent first) and limited by --limit flag
 			return errors.WrapValidationError(fmt.Sprintf("search failed: %v", err))
 		}
 
+		w := cmd.OutOrStdout()
+		if format := outputFormat(false); format != outputTable {
+			if sessions == nil {
+				sessions = []db.Session{}
+			}
+			return writeStructured(w, format, searchReport{Sessions: sessions})
+		}
+
 		if len(sessions) == 0 {
-			fmt.Println("No matching sessions found.")
+			fmt.Fprintln(w, "No matching sessions found.")
 			return nil
 		}
 
-		fmt.Printf("Found %d matching sessions:\n", len(sessions))
+		fmt.Fprintf(w, "Found %d matching sessions:\n", len(sessions))
 		for _, s := range sessions {
-			fmt.Println("--------------------------------------------------")
-			fmt.Printf("ID: %d\n", s.ID)
-			fmt.Printf("Time: %s\n", s.Timestamp.Format("2006-01-02 15:04:05"))
-			fmt.Printf("Tx Hash: %s\n", s.TxHash)
-			fmt.Printf("Network: %s\n", s.Network)
-			fmt.Printf("Status: %s\n", s.Status)
+			fmt.Fprintln(w, "--------------------------------------------------")
+			fmt.Fprintf(w, "ID: %d\n", s.ID)
+			fmt.Fprintf(w, "Time: %s\n", s.Timestamp.Format("2006-01-02 15:04:05"))
+			fmt.Fprintf(w, "Tx Hash: %s\n", s.TxHash)
+			fmt.Fprintf(w, "Network: %s\n", s.Network)
+			fmt.Fprintf(w, "Status: %s\n", s.Status)
 			if s.ErrorMsg != "" {
-				fmt.Printf("Error: %s\n", s.ErrorMsg)
+				fmt.Fprintf(w, "Error: %s\n", s.ErrorMsg)
 			}
 			if len(s.Events) > 0 {
-				fmt.Println("Events:")
+				fmt.Fprintln(w, "Events:")
 				for _, e := range s.Events {
-					fmt.Printf("  - %s\n", e)
+					fmt.Fprintf(w, "  - %s\n", e)
 				}
 			}
 		}
-		fmt.Println("--------------------------------------------------")
+		fmt.Fprintln(w, "--------------------------------------------------")
 
 		return nil
 	},
@@ -96,5 +110,6 @@ func init() {
 	searchCmd.Flags().StringVar(&searchTxFlag, "tx", "", "Transaction hash to search for")
 	searchCmd.Flags().IntVar(&searchLimitFlag, "limit", 10, "Maximum number of results to return")
 
+	supportStructuredOutput(searchCmd)
 	rootCmd.AddCommand(searchCmd)
 }
@@ -30,6 +30,49 @@ func GetCurrentSession() *session.SessionData {
 	return currentSessionData
 }
 
+// sessionSummary describes one session in the output of the erst session
+// commands; its JSON form is stable. Simulation is only set by resume.
+type sessionSummary struct {
+	ID           string             `json:"id"`
+	Status       string             `json:"status,omitempty"`
+	Network      string             `json:"network"`
+	TxHash       string             `json:"tx_hash"`
+	CreatedAt    time.Time          `json:"created_at"`
+	LastAccessAt time.Time          `json:"last_access_at"`
+	Simulation   *sessionSimulation `json:"simulation,omitempty"`
+}
+
+type sessionSimulation struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	Events int    `json:"events"`
+	Logs   int    `json:"logs"`
+}
+
+// sessionListing is the output of erst session list; its JSON form is
+// stable.
+type sessionListing struct {
+	Sessions []sessionSummary `json:"sessions"`
+}
+
+// sessionDeleteReport is the output of erst session delete; its JSON form
+// is stable.
+type sessionDeleteReport struct {
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+func summarizeSession(data *session.SessionData) sessionSummary {
+	return sessionSummary{
+		ID:           data.ID,
+		Status:       data.Status,
+		Network:      data.Network,
+		TxHash:       data.TxHash,
+		CreatedAt:    data.CreatedAt,
+		LastAccessAt: data.LastAccessAt,
+	}
+}
+
 var sessionCmd = &cobra.Command{
 	Use:   "session",
 	Short: "Manage debugging sessions",
@@ -110,10 +153,15 @@ The session ID can be auto-generated or specified with --id flag.`,
 			return errors.WrapValidationError(fmt.Sprintf("failed to save session: %v", err))
 		}
 
-		fmt.Printf("Session saved: %s\n", data.ID)
-		fmt.Printf("  Transaction: %s\n", data.TxHash)
-		fmt.Printf("  Network: %s\n", data.Network)
-		fmt.Printf("  Created: %s\n", data.CreatedAt.Format(time.RFC3339))
+		w := cmd.OutOrStdout()
+		if format := outputFormat(false); format != outputTable {
+			return writeStructured(w, format, summarizeSession(data))
+		}
+
+		fmt.Fprintf(w, "Session saved: %s\n", data.ID)
+		fmt.Fprintf(w, "  Transaction: %s\n", data.TxHash)
+		fmt.Fprintf(w, "  Network: %s\n", data.Network)
+		fmt.Fprintf(w, "  Created: %s\n", data.CreatedAt.Format(time.RFC3339))
 
 		return nil
 	},
@@ -164,34 +212,48 @@ Use 'erst session list' to see available sessions.`,
 		data.Status = "resumed"
 		SetCurrentSession(data)
 
+		summary := summarizeSession(data)
+		if data.SimResponseJSON != "" {
+			if resp, err := data.ToSimulationResponse(); err == nil {
+				summary.Simulation = &sessionSimulation{
+					Status: resp.Status,
+					Error:  resp.Error,
+					Events: len(resp.Events),
+					Logs:   len(resp.Logs),
+				}
+			}
+		}
+
+		w := cmd.OutOrStdout()
+		if format := outputFormat(false); format != outputTable {
+			return writeStructured(w, format, summary)
+		}
+
 		// Display session info
-		fmt.Printf("Session resumed: %s\n", data.ID)
-		fmt.Printf("  Transaction: %s\n", data.TxHash)
-		fmt.Printf("  Network: %s\n", data.Network)
-		fmt.Printf("  Created: %s\n", data.CreatedAt.Format(time.RFC3339))
-		fmt.Printf("  Last accessed: %s\n", data.LastAccessAt.Format(time.RFC3339))
+		fmt.Fprintf(w, "Session resumed: %s\n", data.ID)
+		fmt.Fprintf(w, "  Transaction: %s\n", data.TxHash)
+		fmt.Fprintf(w, "  Network: %s\n", data.Network)
+		fmt.Fprintf(w, "  Created: %s\n", data.CreatedAt.Format(time.RFC3339))
+		fmt.Fprintf(w, "  Last accessed: %s\n", data.LastAccessAt.Format(time.RFC3339))
 
 		// Show transaction envelope info
 		if data.EnvelopeXdr != "" {
-			fmt.Printf("\nTransaction Envelope:\n")
-			fmt.Printf("  Size: %d bytes\n", len(data.EnvelopeXdr))
+			fmt.Fprintf(w, "\nTransaction Envelope:\n")
+			fmt.Fprintf(w, "  Size: %d bytes\n", len(data.EnvelopeXdr))
 		}
 
 		// Show simulation results if available
-		if data.SimResponseJSON != "" {
-			resp, err := data.ToSimulationResponse()
-			if err == nil {
-				fmt.Printf("\nSimulation Results:\n")
-				fmt.Printf("  Status: %s\n", resp.Status)
-				if resp.Error != "" {
-					fmt.Printf("  Error: %s\n", resp.Error)
-				}
-				if len(resp.Events) > 0 {
-					fmt.Printf("  Events: %d\n", len(resp.Events))
-				}
-				if len(resp.Logs) > 0 {
-					fmt.Printf("  Logs: %d\n", len(resp.Logs))
-				}
+		if sim := summary.Simulation; sim != nil {
+			fmt.Fprintf(w, "\nSimulation Results:\n")
+			fmt.Fprintf(w, "  Status: %s\n", sim.Status)
+			if sim.Error != "" {
+				fmt.Fprintf(w, "  Error: %s\n", sim.Error)
+			}
+			if sim.Events > 0 {
+				fmt.Fprintf(w, "  Events: %d\n", sim.Events)
+			}
+			if sim.Logs > 0 {
+				fmt.Fprintf(w, "  Logs: %d\n", sim.Logs)
 			}
 		}
 
@@ -229,14 +291,23 @@ Displays session ID, network, last access time, and transaction hash.`,
 			return errors.WrapValidationError(fmt.Sprintf("failed to list sessions: %v", err))
 		}
 
+		w := cmd.OutOrStdout()
+		if format := outputFormat(false); format != outputTable {
+			listing := sessionListing{Sessions: make([]sessionSummary, 0, len(sessions))}
+			for _, s := range sessions {
+				listing.Sessions = append(listing.Sessions, summarizeSession(s))
+			}
+			return writeStructured(w, format, listing)
+		}
+
 		if len(sessions) == 0 {
-			fmt.Println("No saved sessions found.")
+			fmt.Fprintln(w, "No saved sessions found.")
 			return nil
 		}
 
-		fmt.Printf("Saved sessions (%d):\n\n", len(sessions))
-		fmt.Printf("%-20s %-12s %-20s %-66s\n", "ID", "Network", "Last Accessed", "Transaction Hash")
-		fmt.Println("--------------------------------------------------------------------------------")
+		fmt.Fprintf(w, "Saved sessions (%d):\n\n", len(sessions))
+		fmt.Fprintf(w, "%-20s %-12s %-20s %-66s\n", "ID", "Network", "Last Accessed", "Transaction Hash")
+		fmt.Fprintln(w, "--------------------------------------------------------------------------------")
 
 		for _, s := range sessions {
 			lastAccess := s.LastAccessAt.Format("2006-01-02 15:04")
@@ -244,7 +315,7 @@ Displays session ID, network, last access time, and transaction hash.`,
 			if len(txHash) > 64 {
 				txHash = txHash[:64] + "..."
 			}
-			fmt.Printf("%-20s %-12s %-20s %-66s\n", s.ID, s.Network, lastAccess, txHash)
+			fmt.Fprintf(w, "%-20s %-12s %-20s %-66s\n", s.ID, s.Network, lastAccess, txHash)
 		}
 
 		return nil
@@ -276,7 +347,10 @@ Use 'erst session list' to see available sessions.`,
 			return errors.WrapValidationError(fmt.Sprintf("failed to delete session '%s': %v", sessionID, err))
 		}
 
-		fmt.Printf("Session deleted: %s\n", sessionID)
+		if format := outputFormat(false); format != outputTable {
+			return writeStructured(cmd.OutOrStdout(), format, sessionDeleteReport{ID: sessionID, Deleted: true})
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Session deleted: %s\n", sessionID)
 		return nil
 	},
 }
@@ -284,6 +358,7 @@ Use 'erst session list' to see available sessions.`,
 func init() {
 	sessionSaveCmd.Flags().StringVar(&sessionIDFlag, "id", "", "Custom session ID (default: auto-generated)")
 
+	supportStructuredOutput(sessionSaveCmd, sessionResumeCmd, sessionListCmd, sessionDeleteCmd)
 	sessionCmd.AddCommand(sessionSaveCmd)
 	sessionCmd.AddCommand(sessionResumeCmd)
 	sessionCmd.AddCommand(sessionListCmd)
@@ -0,0 +1,250 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/spf13/cobra"
+	"github.com/stellar/go-stellar-sdk/amount"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+var (
+	simulateNet  networkFlags
+	simulateXDR  string
+	simulateJSON bool
+)
+
+var simulateCmd = &cobra.Command{
+	Use:   "simulate [tx.xdr|-]",
+	Short: "Simulate a transaction envelope against the network",
+	Long: `Run simulateTransaction for a base64 transaction envelope and print what the
+network reports: the return value, the fee the transaction would need, the
+ledger footprint it touches and the diagnostic events it emits.
+
+The envelope is read from a file, from stdin with '-', or from --xdr.
+
+Examples:
+  erst simulate tx.xdr --network testnet
+  cat tx.xdr | erst simulate - --json
+  erst simulate --xdr AAAAAgAAAAB... --network testnet`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSimulate,
+}
+
+// simulateReport is the output of erst simulate; its JSON form is stable.
+type simulateReport struct {
+	Network      string `json:"network"`
+	LatestLedger uint32 `json:"latest_ledger"`
+	Success      bool   `json:"success"`
+	Error        string `json:"error,omitempty"`
+	// ReturnValue is the decoded value the invocation returned.
+	ReturnValue string `json:"return_value,omitempty"`
+	AuthEntries int    `json:"auth_entries"`
+
+	Fees      simulateFees                `json:"fees"`
+	Cost      simulateCost                `json:"cost"`
+	Footprint *decoder.DecodedSorobanData `json:"footprint,omitempty"`
+	// Restore lists archived entries that must be restored before the
+	// transaction can succeed.
+	Restore *decoder.DecodedSorobanData `json:"restore,omitempty"`
+	Events  []decoder.DiagnosticEvent   `json:"events,omitempty"`
+}
+
+// simulateFees are in stroops. InclusionFee is what the envelope bids;
+// ResourceFee is the minimum the simulation asks for.
+type simulateFees struct {
+	InclusionFee int64 `json:"inclusion_fee"`
+	ResourceFee  int64 `json:"resource_fee"`
+	TotalFee     int64 `json:"total_fee"`
+}
+
+type simulateCost struct {
+	CPUInstructions int64 `json:"cpu_instructions"`
+	MemoryBytes     int64 `json:"memory_bytes"`
+}
+
+func runSimulate(cmd *cobra.Command, args []string) error {
+	envXDR, env, err := readEnvelope(cmd, args, simulateXDR)
+	if err != nil {
+		return err
+	}
+	client, err := simulateNet.client(cmd)
+	if err != nil {
+		return err
+	}
+	resp, err := client.SimulateTransaction(cmd.Context(), envXDR)
+	if err != nil {
+		return err
+	}
+	report, err := buildSimulateReport(client, env, resp)
+	if err != nil {
+		return err
+	}
+
+	if format := outputFormat(simulateJSON); format != outputTable {
+		return writeStructured(cmd.OutOrStdout(), format, report)
+	}
+	printSimulateReport(cmd.OutOrStdout(), report)
+	return nil
+}
+
+func buildSimulateReport(client *rpc.Client, env xdr.TransactionEnvelope, resp *rpc.SimulateTransactionResponse) (*simulateReport, error) {
+	if resp.Error != nil {
+		return nil, errors.WrapRPCError(client.SorobanURL, resp.Error.Message, resp.Error.Code)
+	}
+	sim := resp.Result
+	r := &simulateReport{
+		Network:      client.GetNetworkName(),
+		LatestLedger: sim.LatestLedger,
+		Success:      sim.Error == "",
+		Error:        sim.Error,
+		Cost: simulateCost{
+			CPUInstructions: sim.Cost.CpuInsns + sim.Cost.CpuInsns_,
+			MemoryBytes:     sim.Cost.MemBytes + sim.Cost.MemBytes_,
+		},
+	}
+
+	declared := decoder.DescribeFees(env)
+	r.Fees.InclusionFee = declared.InclusionFee
+	r.Fees.ResourceFee, _ = strconv.ParseInt(sim.MinResourceFee, 10, 64)
+	r.Fees.TotalFee = r.Fees.InclusionFee + r.Fees.ResourceFee
+
+	if len(sim.Results) > 0 {
+		res := sim.Results[0]
+		r.AuthEntries = len(res.Auth)
+		var val xdr.ScVal
+		if res.XDR != "" && xdr.SafeUnmarshalBase64(res.XDR, &val) == nil {
+			r.ReturnValue = decoder.FormatScVal(val)
+		}
+	}
+	if sim.TransactionData != "" {
+		var data xdr.SorobanTransactionData
+		if err := xdr.SafeUnmarshalBase64(sim.TransactionData, &data); err != nil {
+			return nil, errors.WrapUnmarshalFailed(err, "transactionData")
+		}
+		r.Footprint = decoder.DescribeSorobanData(data)
+	}
+	if pre := sim.RestorePreamble; pre != nil {
+		var data xdr.SorobanTransactionData
+		if err := xdr.SafeUnmarshalBase64(pre.TransactionData, &data); err != nil {
+			return nil, errors.WrapUnmarshalFailed(err, "restorePreamble")
+		}
+		r.Restore = decoder.DescribeSorobanData(data)
+	}
+	events, err := decoder.DecodeDiagnosticEventsXDR(sim.Events)
+	if err != nil {
+		return nil, errors.WrapUnmarshalFailed(err, "diagnostic events")
+	}
+	r.Events = events
+	return r, nil
+}
+
+func printSimulateReport(w io.Writer, r *simulateReport) {
+	status := "success"
+	if !r.Success {
+		status = "FAILED"
+	}
+	fmt.Fprintf(w, "Simulation on %s at ledger %d: %s\n", r.Network, r.LatestLedger, status)
+	if r.Error != "" {
+		fmt.Fprintf(w, "  Error: %s\n", r.Error)
+		if summary := decoder.SummarizeFailure(r.Events); summary != "" {
+			fmt.Fprintf(w, "  Cause: %s\n", summary)
+		}
+	}
+	if r.ReturnValue != "" {
+		fmt.Fprintf(w, "  Return value: %s\n", r.ReturnValue)
+	}
+	if r.AuthEntries > 0 {
+		fmt.Fprintf(w, "  Authorization entries: %d\n", r.AuthEntries)
+	}
+
+	fmt.Fprintln(w, "\nFees:")
+	fmt.Fprintf(w, "  Inclusion fee: %s\n", stroops(r.Fees.InclusionFee))
+	fmt.Fprintf(w, "  Resource fee:  %s\n", stroops(r.Fees.ResourceFee))
+	fmt.Fprintf(w, "  Total:         %s\n", stroops(r.Fees.TotalFee))
+	fmt.Fprintf(w, "  Cost: CPU=%d instructions, MEM=%d bytes\n", r.Cost.CPUInstructions, r.Cost.MemoryBytes)
+
+	if fp := r.Footprint; fp != nil {
+		fmt.Fprintln(w, "\nFootprint:")
+		fmt.Fprintf(w, "  Instructions: %d, disk read: %d bytes, write: %d bytes\n", fp.Instructions, fp.DiskReadBytes, fp.WriteBytes)
+		printKeys(w, "Read-only", fp.ReadOnly)
+		printKeys(w, "Read-write", fp.ReadWrite)
+	}
+	if r.Restore != nil {
+		fmt.Fprintf(w, "\nArchived entries must be restored first (resource fee %s):\n", stroops(r.Restore.ResourceFee))
+		printKeys(w, "Restore", r.Restore.ReadWrite)
+	}
+
+	if len(r.Events) > 0 {
+		fmt.Fprintf(w, "\nDiagnostic events (%d):\n", len(r.Events))
+		for _, e := range r.Events {
+			fmt.Fprintf(w, "  %s\n", e)
+		}
+	}
+}
+
+func printKeys(w io.Writer, label string, keys []string) {
+	fmt.Fprintf(w, "  %s (%d):\n", label, len(keys))
+	for _, k := range keys {
+		fmt.Fprintf(w, "    %s\n", k)
+	}
+}
+
+// stroops renders a fee in stroops together with its XLM amount.
+func stroops(n int64) string {
+	return fmt.Sprintf("%d stroops (%s XLM)", n, amount.StringFromInt64(n))
+}
+
+// readEnvelope returns the base64 transaction envelope given by flagValue,
+// by a file named in args, or on stdin when that name is "-", decoded.
+func readEnvelope(cmd *cobra.Command, args []string, flagValue string) (string, xdr.TransactionEnvelope, error) {
+	var env xdr.TransactionEnvelope
+	raw := flagValue
+	switch {
+	case raw != "" && len(args) > 0:
+		return "", env, errors.WrapValidationError("give the envelope as an argument or with --xdr, not both")
+	case raw != "":
+	case len(args) == 0:
+		return "", env, errors.WrapValidationError("a transaction envelope is required: pass a file, '-' for stdin, or --xdr")
+	case args[0] == "-":
+		data, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return "", env, errors.WrapValidationError(fmt.Sprintf("failed to read stdin: %v", err))
+		}
+		raw = string(data)
+	default:
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return "", env, errors.WrapValidationError(fmt.Sprintf("failed to read tx file: %v", err))
+		}
+		raw = string(data)
+	}
+
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", env, errors.WrapValidationError("transaction envelope is empty")
+	}
+	if err := xdr.SafeUnmarshalBase64(raw, &env); err != nil {
+		return "", env, errors.WrapUnmarshalFailed(err, "TransactionEnvelope")
+	}
+	return raw, env, nil
+}
+
+func init() {
+	simulateNet.register(simulateCmd)
+	simulateCmd.Flags().StringVar(&simulateXDR, "xdr", "", "Base64 transaction envelope to simulate")
+	simulateCmd.Flags().BoolVar(&simulateJSON, "json", false, "Print the report as JSON")
+
+	supportStructuredOutput(simulateCmd)
+	rootCmd.AddCommand(simulateCmd)
+}
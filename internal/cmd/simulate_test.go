@@ -0,0 +1,122 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/rpc/rpctest"
+	"github.com/spf13/cobra"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/network"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEnvelope(t *testing.T) string {
+	t.Helper()
+	kp := keypair.MustRandom()
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &txnbuild.SimpleAccount{AccountID: kp.Address(), Sequence: 1},
+		IncrementSequenceNum: true,
+		Operations:           []txnbuild.Operation{&txnbuild.BumpSequence{BumpTo: 9}},
+		BaseFee:              txnbuild.MinBaseFee,
+		Preconditions:        txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+	})
+	require.NoError(t, err)
+	tx, err = tx.Sign(network.TestNetworkPassphrase, kp)
+	require.NoError(t, err)
+	env, err := tx.Base64()
+	require.NoError(t, err)
+	return env
+}
+
+func testCommand(stdin string) (*cobra.Command, *bytes.Buffer) {
+	cmd := &cobra.Command{}
+	out := &bytes.Buffer{}
+	cmd.SetOut(out)
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetContext(context.Background())
+	return cmd, out
+}
+
+func TestReadEnvelope(t *testing.T) {
+	env := testEnvelope(t)
+	path := filepath.Join(t.TempDir(), "tx.xdr")
+	require.NoError(t, os.WriteFile(path, []byte(env+"\n"), 0600))
+
+	cmd, _ := testCommand(" " + env + "\n")
+	for name, args := range map[string][]string{"file": {path}, "stdin": {"-"}} {
+		got, _, err := readEnvelope(cmd, args, "")
+		require.NoError(t, err, name)
+		assert.Equal(t, env, got, name)
+	}
+	got, _, err := readEnvelope(cmd, nil, env)
+	require.NoError(t, err)
+	assert.Equal(t, env, got)
+
+	_, _, err = readEnvelope(cmd, []string{path}, env)
+	assert.Error(t, err)
+	_, _, err = readEnvelope(cmd, nil, "")
+	assert.Error(t, err)
+	_, _, err = readEnvelope(cmd, nil, "not-xdr")
+	assert.Error(t, err)
+}
+
+func TestRunSimulateJSON(t *testing.T) {
+	srv := rpctest.NewSorobanServer()
+	defer srv.Close()
+
+	ret, err := xdr.MarshalBase64(xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: &[]xdr.Uint32{7}[0]})
+	require.NoError(t, err)
+	codeKey := xdr.LedgerKey{Type: xdr.LedgerEntryTypeContractCode, ContractCode: &xdr.LedgerKeyContractCode{Hash: xdr.Hash{1}}}
+	data, err := xdr.MarshalBase64(xdr.SorobanTransactionData{
+		Resources: xdr.SorobanResources{
+			Footprint:    xdr.LedgerFootprint{ReadOnly: []xdr.LedgerKey{codeKey}},
+			Instructions: 5000,
+		},
+		ResourceFee: 1234,
+	})
+	require.NoError(t, err)
+	srv.On("simulateTransaction", rpctest.Result(map[string]any{
+		"latestLedger":    1000,
+		"minResourceFee":  "1234",
+		"transactionData": data,
+		"results":         []map[string]any{{"xdr": ret, "auth": []string{}}},
+		"cost":            map[string]any{"cpuInsns": 4000, "memBytes": 2048},
+	}))
+
+	simulateNet = networkFlags{network: "testnet", sorobanURL: srv.URL}
+	simulateXDR, simulateJSON = testEnvelope(t), true
+	defer func() { simulateNet, simulateXDR, simulateJSON = networkFlags{}, "", false }()
+
+	cmd, out := testCommand("")
+	require.NoError(t, runSimulate(cmd, nil))
+
+	var report simulateReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.True(t, report.Success)
+	assert.Equal(t, "7", report.ReturnValue)
+	assert.Equal(t, int64(100), report.Fees.InclusionFee)
+	assert.Equal(t, int64(1334), report.Fees.TotalFee)
+	assert.Equal(t, int64(4000), report.Cost.CPUInstructions)
+	require.NotNil(t, report.Footprint)
+	assert.Len(t, report.Footprint.ReadOnly, 1)
+	assert.Len(t, srv.Calls("simulateTransaction"), 1)
+}
+
+func TestPrintSimulateReportFailure(t *testing.T) {
+	var buf bytes.Buffer
+	printSimulateReport(&buf, &simulateReport{Network: "testnet", Error: "HostError: Error(Contract, #3)"})
+	assert.Contains(t, buf.String(), "FAILED")
+	assert.Contains(t, buf.String(), "Error(Contract, #3)")
+}
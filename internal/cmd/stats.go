@@ -5,6 +5,7 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 
@@ -56,15 +57,49 @@ func runStats(cmd *cobra.Command, args []string) error {
 	}
 
 	stats := buildContractStats(simResp)
+	w := cmd.OutOrStdout()
+	if format := outputFormat(false); format != outputTable {
+		return writeStructured(w, format, newStatsReport(stats))
+	}
 	if len(stats) == 0 {
-		fmt.Println("No contract call data found in the session.")
+		fmt.Fprintln(w, "No contract call data found in the session.")
 		return nil
 	}
 
-	printStatsTable(stats)
+	printStatsTable(w, stats)
 	return nil
 }
 
+// statsReport is the output of erst stats; its JSON form is stable.
+// Contracts are ordered from the most to the least expensive.
+type statsReport struct {
+	Contracts []contractStatRecord `json:"contracts"`
+}
+
+type contractStatRecord struct {
+	ContractID    string `json:"contract_id"`
+	EstimatedCost uint64 `json:"estimated_cost"`
+	CallDepth     int    `json:"call_depth"`
+	Events        int    `json:"events"`
+	StorageWrites int    `json:"storage_writes"`
+	AuthChecks    int    `json:"auth_checks"`
+}
+
+func newStatsReport(stats []contractStat) statsReport {
+	report := statsReport{Contracts: make([]contractStatRecord, 0, len(stats))}
+	for _, s := range stats {
+		report.Contracts = append(report.Contracts, contractStatRecord{
+			ContractID:    s.contractID,
+			EstimatedCost: s.estimatedCost,
+			CallDepth:     s.callDepth,
+			Events:        s.eventCount,
+			StorageWrites: s.storageWrites,
+			AuthChecks:    s.authChecks,
+		})
+	}
+	return report
+}
+
 func loadSimulationResponse(cmd *cobra.Command, id string) (*simulator.SimulationResponse, error) {
 	if id != "" {
 		store, err := session.NewStore()
@@ -155,22 +190,23 @@ func eventCost(eventType string) uint64 {
 	}
 }
 
-func printStatsTable(stats []contractStat) {
+func printStatsTable(w io.Writer, stats []contractStat) {
 	const colContract = 44
-	fmt.Printf("Top %d most expensive contract calls\n\n", statsTopN)
-	fmt.Printf("%-44s | %-12s | %-7s\n", "Contract ID", "Est. Cost", "Depth")
-	fmt.Println(strings.Repeat("-", colContract+23))
+	fmt.Fprintf(w, "Top %d most expensive contract calls\n\n", statsTopN)
+	fmt.Fprintf(w, "%-44s | %-12s | %-7s\n", "Contract ID", "Est. Cost", "Depth")
+	fmt.Fprintln(w, strings.Repeat("-", colContract+23))
 
 	for i, s := range stats {
 		displayID := s.contractID
 		if len(displayID) > colContract {
 			displayID = displayID[:colContract-3] + "..."
 		}
-		fmt.Printf("%d. %-41s | %-12d | %-7d\n", i+1, displayID, s.estimatedCost, s.callDepth)
+		fmt.Fprintf(w, "%d. %-41s | %-12d | %-7d\n", i+1, displayID, s.estimatedCost, s.callDepth)
 	}
 }
 
 func init() {
 	statsCmd.Flags().StringVar(&statsSessionFlag, "session", "", "Load a saved session by ID")
+	supportStructuredOutput(statsCmd)
 	rootCmd.AddCommand(statsCmd)
 }
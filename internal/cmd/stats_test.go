@@ -62,6 +62,34 @@ func TestBuildContractStats_Sorted(t *testing.T) {
 	}
 }
 
+func TestNewStatsReport(t *testing.T) {
+	cid := "CONTRACT_A"
+	report := newStatsReport(buildContractStats(makeResponse([]simulator.CategorizedEvent{
+		{EventType: "storage_write", ContractID: &cid},
+		{EventType: "storage_write", ContractID: &cid},
+		{EventType: "require_auth", ContractID: &cid},
+	})))
+
+	if len(report.Contracts) != 1 {
+		t.Fatalf("expected 1 contract, got %d", len(report.Contracts))
+	}
+	want := contractStatRecord{
+		ContractID:    cid,
+		EstimatedCost: uint64(2*costWeightStorageWrite + costWeightAuth),
+		CallDepth:     2,
+		Events:        3,
+		StorageWrites: 2,
+		AuthChecks:    1,
+	}
+	if report.Contracts[0] != want {
+		t.Errorf("record = %+v, want %+v", report.Contracts[0], want)
+	}
+
+	if empty := newStatsReport(nil); empty.Contracts == nil {
+		t.Error("expected an empty, non-nil contract list")
+	}
+}
+
 func TestEventCost(t *testing.T) {
 	cases := []struct {
 		eventType string
@@ -0,0 +1,268 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/intent"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/spf13/cobra"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+var (
+	submitNet     networkFlags
+	submitXDR     string
+	submitVia     string
+	submitWait    bool
+	submitTimeout time.Duration
+	submitJSON    bool
+	submitSigners []string
+)
+
+var submitCmd = &cobra.Command{
+	Use:   "submit [tx.xdr|-]",
+	Short: "Submit a signed transaction envelope and optionally wait for the result",
+	Long: `Submit a signed base64 transaction envelope. The envelope is first checked
+against the selected network: its source accounts must exist and its
+signatures must verify for the network's passphrase.
+
+Soroban transactions are sent through Soroban RPC and classic ones through
+Horizon; --via overrides the choice. With --wait the command polls until the
+transaction is included and exits nonzero, with the decoded reason, if it
+failed or did not make it in time.
+
+The envelope is read from a file, from stdin with '-', or from --xdr. Each
+--signer, a secret seed or the name of a key stored with erst keys, adds a
+signature to it before it is sent.
+
+Examples:
+  erst submit tx.xdr --network testnet --wait
+  erst submit tx.xdr --signer alice --wait
+  erst submit tx.xdr --wait --timeout 2m --json
+  cat tx.xdr | erst submit - --via horizon`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSubmit,
+}
+
+// submitReport is the output of erst submit; its JSON form is stable.
+type submitReport struct {
+	Network string `json:"network"`
+	Hash    string `json:"hash"`
+	// Via is "rpc" or "horizon".
+	Via string `json:"via"`
+	// Status is the submission status, PENDING or DUPLICATE, until the
+	// transaction is confirmed as SUCCESS or FAILED.
+	Status      string                 `json:"status"`
+	Ledger      uint32                 `json:"ledger,omitempty"`
+	FeeCharged  int64                  `json:"fee_charged,omitempty"`
+	ReturnValue string                 `json:"return_value,omitempty"`
+	Error       string                 `json:"error,omitempty"`
+	Result      *decoder.DecodedResult `json:"result,omitempty"`
+}
+
+func runSubmit(cmd *cobra.Command, args []string) error {
+	envXDR, env, err := readEnvelope(cmd, args, submitXDR)
+	if err != nil {
+		return err
+	}
+	via, err := submitRoute(env, submitVia)
+	if err != nil {
+		return err
+	}
+	client, err := submitNet.client(cmd)
+	if err != nil {
+		return err
+	}
+	if len(submitSigners) > 0 {
+		prompt := newKeyPrompt(cmd)
+		signers := make([]intent.Signer, 0, len(submitSigners))
+		for _, ref := range submitSigners {
+			signer, err := prompt.signer(ref)
+			if err != nil {
+				return err
+			}
+			signers = append(signers, signer)
+		}
+		if envXDR, err = signEnvelope(cmd.Context(), envXDR, client.GetNetworkPassphrase(), signers); err != nil {
+			return err
+		}
+	}
+
+	report, submitErr := submit(cmd, client, via, envXDR, submitWait, submitTimeout)
+	if report != nil {
+		if err := writeSubmitReport(cmd.OutOrStdout(), report); err != nil {
+			return err
+		}
+	}
+	return submitErr
+}
+
+// submitRoute picks where to send env: Soroban RPC for transactions with
+// Soroban data, Horizon otherwise, unless via names one.
+func submitRoute(env xdr.TransactionEnvelope, via string) (string, error) {
+	switch via {
+	case "rpc", "horizon":
+		return via, nil
+	case "", "auto":
+	default:
+		return "", errors.WrapValidationError(fmt.Sprintf("invalid --via: %s (use: auto, rpc, horizon)", via))
+	}
+	if env.IsFeeBump() {
+		env = xdr.TransactionEnvelope{Type: xdr.EnvelopeTypeEnvelopeTypeTx, V1: env.FeeBump.Tx.InnerTx.V1}
+	}
+	if env.V1 != nil && env.V1.Tx.Ext.SorobanData != nil {
+		return "rpc", nil
+	}
+	return "horizon", nil
+}
+
+// signEnvelope adds a signature from each signer to the base64 envelope,
+// a transaction or a fee bump.
+func signEnvelope(ctx context.Context, envXDR, passphrase string, signers []intent.Signer) (string, error) {
+	generic, err := txnbuild.TransactionFromXDR(envXDR)
+	if err != nil {
+		return "", errors.WrapUnmarshalFailed(err, "TransactionEnvelope")
+	}
+	if tx, ok := generic.Transaction(); ok {
+		if tx, err = intent.SignTransaction(ctx, tx, passphrase, signers...); err != nil {
+			return "", err
+		}
+		return tx.Base64()
+	}
+	tx, _ := generic.FeeBump()
+	if tx, err = intent.SignFeeBump(ctx, tx, passphrase, signers...); err != nil {
+		return "", err
+	}
+	return tx.Base64()
+}
+
+// submit sends the envelope and, if wait is set, waits up to timeout for its
+// outcome. The report is returned alongside any failure so the decoded
+// reason can be shown.
+func submit(cmd *cobra.Command, client *rpc.Client, via, envXDR string, wait bool, timeout time.Duration) (*submitReport, error) {
+	ctx := cmd.Context()
+	report := &submitReport{Network: client.GetNetworkName(), Via: via}
+
+	var (
+		sent *rpc.AsyncSubmitResult
+		err  error
+	)
+	if via == "rpc" {
+		if err := client.VerifyNetwork(ctx); err != nil {
+			return nil, err
+		}
+		sent, err = client.SendTransaction(ctx, envXDR)
+	} else {
+		sent, err = client.SubmitTransactionAsync(ctx, envXDR)
+	}
+	if sent == nil {
+		return nil, err
+	}
+	report.Hash, report.Status = sent.Hash, string(sent.Status)
+	if err != nil {
+		report.Error = err.Error()
+		if sent.ErrorResultXDR != "" {
+			report.Result, _ = decoder.AnalyzeResult(sent.ErrorResultXDR)
+		}
+		return report, err
+	}
+	if !wait {
+		return report, nil
+	}
+
+	poll := rpc.PollConfig{Timeout: timeout}
+	var resultXDR, metaXDR string
+	if via == "rpc" {
+		tx, werr := client.WaitForSorobanTransaction(ctx, sent.Hash, poll)
+		if tx != nil {
+			report.Ledger, resultXDR, metaXDR = tx.Ledger, tx.ResultXdr, tx.ResultMetaXdr
+		}
+		err = werr
+	} else {
+		tx, werr := client.WaitForTransaction(ctx, sent.Hash, poll)
+		if tx != nil {
+			report.Ledger, resultXDR, metaXDR = uint32(tx.Ledger), tx.ResultXdr, tx.ResultMetaXdr
+		}
+		err = werr
+	}
+	if resultXDR == "" {
+		if err != nil {
+			report.Error = err.Error()
+		}
+		return report, err
+	}
+
+	report.Status = "SUCCESS"
+	if err != nil {
+		report.Status, report.Error = "FAILED", err.Error()
+		// A contract error is already part of err's message.
+		var ce *errors.ContractError
+		if events, evErr := decoder.DiagnosticEventsFromMetaXDR(metaXDR); evErr == nil && !errors.As(err, &ce) {
+			if summary := decoder.SummarizeFailure(events); summary != "" {
+				report.Error += " (" + summary + ")"
+			}
+		}
+	}
+	if report.Result, _ = decoder.AnalyzeResult(resultXDR); report.Result != nil {
+		report.FeeCharged = report.Result.FeeCharged
+	}
+	if meta, merr := decoder.AnalyzeMeta(metaXDR); merr == nil && meta.Soroban != nil {
+		report.ReturnValue = meta.Soroban.ReturnValue
+	}
+	return report, err
+}
+
+func writeSubmitReport(w io.Writer, r *submitReport) error {
+	if format := outputFormat(submitJSON); format != outputTable {
+		return writeStructured(w, format, r)
+	}
+	fmt.Fprintf(w, "Transaction %s\n", r.Hash)
+	fmt.Fprintf(w, "  Network: %s (via %s)\n", r.Network, r.Via)
+	fmt.Fprintf(w, "  Status:  %s\n", r.Status)
+	if r.Ledger != 0 {
+		fmt.Fprintf(w, "  Ledger:  %d\n", r.Ledger)
+	}
+	if r.FeeCharged != 0 {
+		fmt.Fprintf(w, "  Fee:     %s\n", stroops(r.FeeCharged))
+	}
+	if r.ReturnValue != "" {
+		fmt.Fprintf(w, "  Return value: %s\n", r.ReturnValue)
+	}
+	if r.Result != nil && !r.Result.Successful {
+		fmt.Fprintf(w, "  Result: %s - %s\n", r.Result.Code, r.Result.Description)
+		for i, op := range r.Result.Operations {
+			if op.Successful {
+				continue
+			}
+			fmt.Fprintf(w, "    operation %d (%s): %s", i, op.Type, op.Code)
+			if op.Explanation != "" {
+				fmt.Fprintf(w, " - %s", op.Explanation)
+			}
+			fmt.Fprintln(w)
+		}
+	}
+	return nil
+}
+
+func init() {
+	submitNet.register(submitCmd)
+	submitCmd.Flags().StringVar(&submitXDR, "xdr", "", "Base64 transaction envelope to submit")
+	submitCmd.Flags().StringVar(&submitVia, "via", "auto", "Submit through: auto, rpc or horizon")
+	submitCmd.Flags().BoolVar(&submitWait, "wait", false, "Wait until the transaction is included")
+	submitCmd.Flags().DurationVar(&submitTimeout, "timeout", time.Minute, "How long --wait waits for inclusion")
+	submitCmd.Flags().BoolVar(&submitJSON, "json", false, "Print the report as JSON")
+	submitCmd.Flags().StringArrayVar(&submitSigners, "signer", nil, "Secret seed (S...) or stored key name to sign with before submitting (repeatable)")
+	_ = submitCmd.RegisterFlagCompletionFunc("signer", completeKeys)
+
+	supportStructuredOutput(submitCmd)
+	rootCmd.AddCommand(submitCmd)
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc/rpctest"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// submitFixture returns a signed envelope and a Horizon that knows its
+// source account.
+func submitFixture(t *testing.T) (string, *rpctest.HorizonServer) {
+	t.Helper()
+	env := testEnvelope(t)
+	var decoded xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(env, &decoded))
+	source := decoded.SourceAccount().ToAccountId().Address()
+
+	horizon := rpctest.NewHorizonServer()
+	t.Cleanup(horizon.Close)
+	horizon.AddAccount(hProtocol.Account{
+		AccountID: source,
+		Sequence:  1,
+		Signers:   []hProtocol.Signer{{Key: source, Type: "ed25519_public_key", Weight: 1}},
+	})
+	return env, horizon
+}
+
+func runSubmitWith(t *testing.T, horizonURL, env string, wait bool) (submitReport, error) {
+	t.Helper()
+	submitNet = networkFlags{network: "testnet", horizonURL: horizonURL}
+	submitXDR, submitWait, submitJSON, submitVia = env, wait, true, "auto"
+	defer func() {
+		submitNet, submitXDR, submitWait, submitJSON, submitVia = networkFlags{}, "", false, false, "auto"
+	}()
+
+	cmd, out := testCommand("")
+	err := runSubmit(cmd, nil)
+	var report submitReport
+	if out.Len() > 0 {
+		require.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	}
+	return report, err
+}
+
+func TestSubmitWaitSuccess(t *testing.T) {
+	env, horizon := submitFixture(t)
+
+	report, err := runSubmitWith(t, horizon.URL, env, true)
+	require.NoError(t, err)
+	assert.Equal(t, "horizon", report.Via)
+	assert.Equal(t, "SUCCESS", report.Status)
+	assert.NotZero(t, report.Ledger)
+	assert.Equal(t, int64(100), report.FeeCharged)
+	assert.Len(t, report.Hash, 64)
+}
+
+func TestSubmitRejected(t *testing.T) {
+	env, horizon := submitFixture(t)
+	horizon.QueueSubmitResult(rpctest.SubmitResult{ResultCode: "tx_bad_seq"})
+
+	report, err := runSubmitWith(t, horizon.URL, env, true)
+	assert.ErrorIs(t, err, errs.ErrTxBadSeq)
+	assert.Equal(t, "ERROR", report.Status)
+	require.NotNil(t, report.Result)
+	assert.Equal(t, "tx_bad_seq", report.Result.Code)
+	assert.Contains(t, report.Error, "tx_bad_seq")
+}
+
+func TestSubmitRoute(t *testing.T) {
+	var classic xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(testEnvelope(t), &classic))
+	via, err := submitRoute(classic, "auto")
+	require.NoError(t, err)
+	assert.Equal(t, "horizon", via)
+
+	soroban := classic
+	v1 := *classic.V1
+	v1.Tx.Ext = xdr.TransactionExt{V: 1, SorobanData: &xdr.SorobanTransactionData{}}
+	soroban.V1 = &v1
+	via, err = submitRoute(soroban, "auto")
+	require.NoError(t, err)
+	assert.Equal(t, "rpc", via)
+
+	via, err = submitRoute(soroban, "horizon")
+	require.NoError(t, err)
+	assert.Equal(t, "horizon", via)
+	_, err = submitRoute(soroban, "smoke-signals")
+	assert.Error(t, err)
+}
@@ -0,0 +1,589 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/spf13/cobra"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/strkey"
+)
+
+var (
+	tuiNet      networkFlags
+	tuiInterval time.Duration
+)
+
+// How much of each live list the TUI keeps, newest first.
+const (
+	tuiMaxLedgers = 50
+	tuiMaxEvents  = 100
+	tuiMaxTxs     = 20
+)
+
+// tuiRedrawInterval is how often the screen is redrawn while new data
+// arrives.
+const tuiRedrawInterval = 500 * time.Millisecond
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Follow the network in a full-screen terminal view",
+	Long: `Show a live view of the network in four panes:
+
+  Ledgers      every closed ledger, streamed from Horizon
+  Events       contract events, polled from Soroban RPC every --interval
+  Selected     an account with its live transactions, or a contract with
+               its ledger entries and interface
+  Inspector    a transaction with its decoded envelope and result
+
+Type a command and press Enter:
+
+  account <G...>     select an account
+  contract <C...>    select a contract; the Events pane then shows its events only
+  tx <hash|n>        inspect a transaction by hash, or the one numbered n in a pane
+  clear              drop the selection
+  help               list the commands
+  quit               leave (Ctrl-C works too)
+
+Examples:
+  erst tui --network testnet
+  erst tui --profile staging --interval 2s`,
+	Args: cobra.NoArgs,
+	RunE: runTUI,
+}
+
+func runTUI(cmd *cobra.Command, _ []string) error {
+	if tuiInterval <= 0 {
+		return errors.WrapValidationError("--interval must be positive")
+	}
+	client, err := tuiNet.client(cmd)
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+	return newTUI(client).run(ctx, cmd.InOrStdin(), cmd.OutOrStdout())
+}
+
+// tuiState is what the panes show. Followers update it from their own
+// goroutines; every method locks it.
+type tuiState struct {
+	mu        sync.Mutex
+	network   string
+	ledgers   []watchRecord
+	events    []watchRecord
+	selected  string
+	account   *accountReport
+	contract  *contractInspectReport
+	txs       []watchRecord
+	inspector []string
+	status    string
+	dirty     bool
+}
+
+// prepend adds r in front of list, keeping at most limit records.
+func prepend(list []watchRecord, r watchRecord, limit int) []watchRecord {
+	list = append([]watchRecord{r}, list...)
+	if len(list) > limit {
+		list = list[:limit]
+	}
+	return list
+}
+
+func (s *tuiState) update(f func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f()
+	s.dirty = true
+}
+
+// takeDirty reports whether the state changed since it was last called.
+func (s *tuiState) takeDirty() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	dirty := s.dirty
+	s.dirty = false
+	return dirty
+}
+
+func (s *tuiState) setStatus(format string, a ...interface{}) {
+	s.update(func() { s.status = fmt.Sprintf(format, a...) })
+}
+
+// refs lists the hashes the panes number: the selected account's
+// transactions first, then the events.
+func (s *tuiState) refs() []string {
+	var refs []string
+	for _, r := range s.txs {
+		refs = append(refs, r.Hash)
+	}
+	for _, r := range s.events {
+		refs = append(refs, r.Hash)
+	}
+	return refs
+}
+
+// tui runs the followers that feed a tuiState and the commands typed by
+// the user.
+type tui struct {
+	client *rpc.Client
+	state  *tuiState
+
+	ctx           context.Context
+	stopSelection context.CancelFunc
+	stopEvents    context.CancelFunc
+}
+
+func newTUI(client *rpc.Client) *tui {
+	return &tui{client: client, state: &tuiState{network: client.GetNetworkName()}}
+}
+
+// run draws the screen and handles input lines until quit, the end of
+// input or the cancellation of ctx.
+func (t *tui) run(ctx context.Context, in io.Reader, out io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	t.ctx = ctx
+
+	t.follow(ctx, "ledgers", func(ctx context.Context) error {
+		return t.client.StreamLedgers(ctx, "", func(l hProtocol.Ledger) error {
+			r := ledgerRecord(l)
+			t.state.update(func() { t.state.ledgers = prepend(t.state.ledgers, r, tuiMaxLedgers) })
+			return nil
+		})
+	})
+	t.followEvents(nil)
+
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	tick := time.NewTicker(tuiRedrawInterval)
+	defer tick.Stop()
+	t.draw(out)
+	for {
+		select {
+		case <-ctx.Done():
+			fmt.Fprintln(out)
+			return nil
+		case line, ok := <-lines:
+			if !ok || t.handle(line) {
+				fmt.Fprintln(out)
+				return nil
+			}
+			t.state.takeDirty()
+			t.draw(out)
+		case <-tick.C:
+			if t.state.takeDirty() {
+				t.draw(out)
+			}
+		}
+	}
+}
+
+// follow runs f until ctx is cancelled. Streams reconnect on their own, so
+// an error is final and is shown in the status line.
+func (t *tui) follow(ctx context.Context, name string, f func(ctx context.Context) error) {
+	go func() {
+		if err := f(ctx); err != nil && ctx.Err() == nil {
+			t.state.setStatus("%s stopped: %v", name, err)
+		}
+	}()
+}
+
+// followEvents restarts the Events pane's follower for contracts, or for
+// every contract when there are none.
+func (t *tui) followEvents(contracts []string) {
+	if t.stopEvents != nil {
+		t.stopEvents()
+	}
+	var ctx context.Context
+	ctx, t.stopEvents = context.WithCancel(t.ctx)
+	t.state.update(func() { t.state.events = nil })
+	t.follow(ctx, "events", func(ctx context.Context) error {
+		return followContractEvents(ctx, t.client, contracts, tuiInterval, func(r watchRecord) {
+			if ctx.Err() == nil {
+				t.state.update(func() { t.state.events = prepend(t.state.events, r, tuiMaxEvents) })
+			}
+		})
+	})
+}
+
+// handle runs one command line and reports whether the user asked to quit.
+func (t *tui) handle(line string) bool {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return false
+	}
+	arg := ""
+	if len(fields) > 1 {
+		arg = fields[1]
+	}
+	switch fields[0] {
+	case "quit", "exit", "q":
+		return true
+	case "account", "a":
+		t.selectAccount(arg)
+	case "contract", "c":
+		t.selectContract(arg)
+	case "tx", "t":
+		t.inspect(arg)
+	case "clear":
+		t.clearSelection()
+		t.followEvents(nil)
+		t.state.setStatus("")
+	case "help", "?":
+		t.state.update(func() {
+			t.state.inspector = tuiHelp
+			t.state.status = ""
+		})
+	default:
+		t.state.setStatus("unknown command: %s (type help for the list)", fields[0])
+	}
+	return false
+}
+
+var tuiHelp = []string{
+	"account <G...>     select an account",
+	"contract <C...>    select a contract and show only its events",
+	"tx <hash|n>        inspect a transaction by hash, or the one numbered n",
+	"clear              drop the selection",
+	"quit               leave",
+}
+
+func (t *tui) clearSelection() {
+	if t.stopSelection != nil {
+		t.stopSelection()
+		t.stopSelection = nil
+	}
+	t.state.update(func() {
+		t.state.selected, t.state.account, t.state.contract, t.state.txs = "", nil, nil, nil
+	})
+}
+
+func (t *tui) selectAccount(id string) {
+	if !strkey.IsValidEd25519PublicKey(id) {
+		t.state.setStatus("invalid account ID: %q", id)
+		return
+	}
+	report, err := accountSnapshot(t.ctx, t.client, id)
+	if err != nil {
+		t.state.setStatus("account %s: %v", id, err)
+		return
+	}
+	wasContract := t.state.contractSelected()
+	t.clearSelection()
+	if wasContract {
+		t.followEvents(nil)
+	}
+	var ctx context.Context
+	ctx, t.stopSelection = context.WithCancel(t.ctx)
+	t.state.update(func() {
+		t.state.selected, t.state.account = id, report
+		t.state.status = "following account " + id
+	})
+	t.follow(ctx, "transactions", func(ctx context.Context) error {
+		// The stream starts with the account's latest transaction.
+		return t.client.StreamAccountTransactions(ctx, id, "now", func(tx hProtocol.Transaction) error {
+			r := transactionRecord(id, tx)
+			report, err := accountSnapshot(ctx, t.client, id)
+			t.state.update(func() {
+				if ctx.Err() != nil {
+					return
+				}
+				t.state.txs = prepend(t.state.txs, r, tuiMaxTxs)
+				if err == nil {
+					t.state.account = report
+				}
+			})
+			return nil
+		})
+	})
+}
+
+func (t *tui) selectContract(id string) {
+	if !strkey.IsValidContractAddress(id) {
+		t.state.setStatus("invalid contract ID: %q", id)
+		return
+	}
+	report, err := inspectContract(t.ctx, t.client, id)
+	if err != nil {
+		t.state.setStatus("contract %s: %v", id, err)
+		return
+	}
+	t.clearSelection()
+	t.state.update(func() {
+		t.state.selected, t.state.contract = id, report
+		t.state.status = "following contract " + id
+	})
+	t.followEvents([]string{id})
+}
+
+func (s *tuiState) contractSelected() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.contract != nil
+}
+
+// inspect shows the transaction named by arg, a hash or the number of a
+// row in the Selected or Events pane.
+func (t *tui) inspect(arg string) {
+	hash := arg
+	if n, err := strconv.Atoi(arg); err == nil {
+		t.state.mu.Lock()
+		refs := t.state.refs()
+		t.state.mu.Unlock()
+		if n < 1 || n > len(refs) {
+			t.state.setStatus("no row numbered %d", n)
+			return
+		}
+		hash = refs[n-1]
+	}
+	if len(hash) != 64 {
+		t.state.setStatus("invalid transaction hash: %q", arg)
+		return
+	}
+	lines, err := inspectTransaction(t.client, hash)
+	if err != nil {
+		t.state.setStatus("transaction %s: %v", shortHash(hash), err)
+		return
+	}
+	t.state.update(func() {
+		t.state.inspector = lines
+		t.state.status = ""
+	})
+}
+
+// inspectTransaction fetches a transaction from Horizon and describes it,
+// with its envelope and result decoded.
+func inspectTransaction(client *rpc.Client, hash string) ([]string, error) {
+	tx, err := client.Horizon.TransactionDetail(hash)
+	if err != nil {
+		return nil, errors.WrapTransactionNotFound(err)
+	}
+	status := "successful"
+	if !tx.Successful {
+		status = "failed"
+	}
+	lines := []string{
+		"Hash:       " + tx.Hash,
+		fmt.Sprintf("Ledger:     %d at %s", tx.Ledger, tx.LedgerCloseTime.UTC().Format(time.RFC3339)),
+		"Source:     " + tx.Account,
+		fmt.Sprintf("Status:     %s, %d operations, fee charged %s", status, tx.OperationCount, stroops(tx.FeeCharged)),
+	}
+	if tx.Memo != "" {
+		lines = append(lines, fmt.Sprintf("Memo:       %q", tx.Memo))
+	}
+	for _, part := range []struct {
+		b64  string
+		kind decoder.XDRKind
+	}{
+		{tx.EnvelopeXdr, decoder.KindTransactionEnvelope},
+		{tx.ResultXdr, decoder.KindTransactionResult},
+	} {
+		if part.b64 == "" {
+			continue
+		}
+		detected, err := decoder.DecodeXDRAs(part.b64, part.kind)
+		if err != nil {
+			lines = append(lines, fmt.Sprintf("%s: %v", part.kind, err))
+			continue
+		}
+		text, err := decoder.FormatDetected(detected, "text")
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, "")
+		lines = append(lines, strings.Split(strings.TrimRight(text, "\n"), "\n")...)
+	}
+	return lines, nil
+}
+
+// draw clears the terminal and renders the state to fit it.
+func (t *tui) draw(out io.Writer) {
+	width, height := terminalSize()
+	if width <= 0 {
+		width = envInt("COLUMNS", 120)
+	}
+	if height <= 0 {
+		height = envInt("LINES", 40)
+	}
+	var b bytes.Buffer
+	b.WriteString("\033[H\033[2J")
+	t.state.render(&b, width, height)
+	_, _ = out.Write(b.Bytes())
+}
+
+func envInt(name string, fallback int) int {
+	if n, err := strconv.Atoi(os.Getenv(name)); err == nil && n > 0 {
+		return n
+	}
+	return fallback
+}
+
+// render writes the panes in width columns and height rows, the last of
+// which is the prompt. Wide terminals get a two-by-two grid, narrow ones a
+// single column.
+func (s *tuiState) render(w io.Writer, width, height int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var latest uint32
+	if len(s.ledgers) > 0 {
+		latest = s.ledgers[0].Ledger
+	}
+	header := fmt.Sprintf("erst tui  %s  ledger %d", s.network, latest)
+	if s.selected != "" {
+		header += "  following " + s.selected
+	}
+	status := s.status
+	if status == "" {
+		status = "account <id> | contract <id> | tx <hash|n> | clear | help | quit"
+	}
+
+	ledgers, events, selected, inspector := s.ledgerLines(), s.eventLines(), s.selectedLines(), s.inspector
+	if len(inspector) == 0 {
+		inspector = []string{"Inspect a transaction with: tx <hash> or tx <n>"}
+	}
+
+	body := max(height-3, 8)
+	var rows []string
+	if width >= 100 {
+		half := (width - 3) / 2
+		top := body / 2
+		rows = append(rows, joinColumns(pane("Ledgers", ledgers, half, top), pane("Events", events, width-3-half, top), half)...)
+		rows = append(rows, joinColumns(pane("Selected", selected, half, body-top), pane("Inspector", inspector, width-3-half, body-top), half)...)
+	} else {
+		quarter := body / 4
+		rows = append(rows, pane("Ledgers", ledgers, width, quarter)...)
+		rows = append(rows, pane("Events", events, width, quarter)...)
+		rows = append(rows, pane("Selected", selected, width, quarter)...)
+		rows = append(rows, pane("Inspector", inspector, width, body-3*quarter)...)
+	}
+
+	for _, row := range append(append([]string{header}, rows...), status) {
+		fmt.Fprintln(w, strings.TrimRight(fit(row, width), " "))
+	}
+	fmt.Fprint(w, "> ")
+}
+
+func (s *tuiState) ledgerLines() []string {
+	if len(s.ledgers) == 0 {
+		return []string{"Waiting for the next ledger..."}
+	}
+	lines := make([]string, len(s.ledgers))
+	for i, r := range s.ledgers {
+		lines[i] = fmt.Sprintf("%d  %s  %s", r.Ledger, r.At.UTC().Format(time.TimeOnly), r.Summary)
+	}
+	return lines
+}
+
+func (s *tuiState) eventLines() []string {
+	if len(s.events) == 0 {
+		return []string{"Waiting for contract events..."}
+	}
+	lines := make([]string, len(s.events))
+	for i, r := range s.events {
+		lines[i] = fmt.Sprintf("%3d %d  %s  %s", len(s.txs)+i+1, r.Ledger, shortID(r.Contract), r.Summary)
+	}
+	return lines
+}
+
+func (s *tuiState) selectedLines() []string {
+	var report bytes.Buffer
+	switch {
+	case s.account != nil:
+		_ = writeAccountReport(&report, s.account)
+	case s.contract != nil:
+		_ = writeContractInspectReport(&report, s.contract)
+	default:
+		return []string{"Select with: account <G...> or contract <C...>"}
+	}
+	var lines []string
+	if len(s.txs) > 0 {
+		lines = append(lines, "Transactions:")
+		for i, r := range s.txs {
+			lines = append(lines, fmt.Sprintf("%3d %d  %s  %s", i+1, r.Ledger, shortHash(r.Hash), r.Summary))
+		}
+		lines = append(lines, "")
+	}
+	return append(lines, strings.Split(strings.TrimRight(report.String(), "\n"), "\n")...)
+}
+
+// pane renders a titled pane of exactly rows lines of width columns,
+// cutting lines that do not fit.
+func pane(title string, lines []string, width, rows int) []string {
+	out := []string{fit("── "+title+" "+strings.Repeat("─", width), width)}
+	for i := 0; i < rows-1; i++ {
+		line := ""
+		if i < len(lines) {
+			line = lines[i]
+		}
+		out = append(out, fit(line, width))
+	}
+	return out
+}
+
+// joinColumns puts right next to left, which is width columns wide.
+func joinColumns(left, right []string, width int) []string {
+	rows := make([]string, max(len(left), len(right)))
+	for i := range rows {
+		l, r := strings.Repeat(" ", width), ""
+		if i < len(left) {
+			l = left[i]
+		}
+		if i < len(right) {
+			r = right[i]
+		}
+		rows[i] = l + " │ " + r
+	}
+	return rows
+}
+
+// fit cuts s to width runes, marking the cut, and pads it to width.
+func fit(s string, width int) string {
+	runes := []rune(strings.ReplaceAll(s, "\t", "  "))
+	if len(runes) > width {
+		return string(runes[:width-1]) + "…"
+	}
+	return s + strings.Repeat(" ", width-len(runes))
+}
+
+// shortID abbreviates an account or contract ID for one-line output.
+func shortID(id string) string {
+	if len(id) <= 12 {
+		return id
+	}
+	return id[:6] + "…" + id[len(id)-4:]
+}
+
+func init() {
+	tuiNet.register(tuiCmd)
+	tuiCmd.Flags().DurationVar(&tuiInterval, "interval", 5*time.Second, "How often contract events are polled")
+
+	rootCmd.AddCommand(tuiCmd)
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/rpc/rpctest"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTUIRender(t *testing.T) {
+	closed := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	s := &tuiState{
+		network:   "testnet",
+		ledgers:   []watchRecord{{Type: "ledger", Ledger: 1234, At: &closed, Summary: "42 txs (2 failed)"}},
+		events:    []watchRecord{{Type: "event", Ledger: 1233, Hash: "ab", Contract: testContractID(t), Summary: `topics=["ping"]`}},
+		selected:  "GABC",
+		account:   &accountReport{ID: "GABC"},
+		txs:       []watchRecord{{Type: "transaction", Ledger: 1234, Hash: strings.Repeat("ef", 32), Summary: "1 ops"}},
+		inspector: []string{"Hash:       " + strings.Repeat("ef", 32)},
+	}
+
+	for _, width := range []int{140, 80} {
+		var out bytes.Buffer
+		s.render(&out, width, 30)
+		screen := out.String()
+		assert.True(t, strings.HasPrefix(screen, "erst tui  testnet  ledger 1234  following GABC"), width)
+		for _, title := range []string{"── Ledgers", "── Events", "── Selected", "── Inspector"} {
+			assert.Contains(t, screen, title, width)
+		}
+		assert.Contains(t, screen, "1234  03:04:05  42 txs (2 failed)")
+		assert.Contains(t, screen, "  1 1234  efefefefefef  1 ops")
+		assert.Contains(t, screen, "  2 1233  "+shortID(testContractID(t))+`  topics=["ping"]`)
+		assert.True(t, strings.HasSuffix(screen, "\n> "), width)
+
+		lines := strings.Split(screen, "\n")
+		assert.Len(t, lines, 30, width)
+		for _, line := range lines {
+			assert.LessOrEqual(t, utf8.RuneCountInString(line), width, line)
+		}
+	}
+	assert.Equal(t, []string{strings.Repeat("ef", 32), "ab"}, s.refs())
+}
+
+func TestTUIInspectTransaction(t *testing.T) {
+	t.Setenv("COLUMNS", "200")
+	source := keypair.MustRandom().Address()
+	hash := strings.Repeat("cd", 32)
+	horizon := rpctest.NewHorizonServer()
+	defer horizon.Close()
+	horizon.AddTransaction(hProtocol.Transaction{
+		Hash: hash, Account: source, Successful: true, OperationCount: 1, FeeCharged: 100,
+		LedgerCloseTime: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), EnvelopeXdr: testEnvelope(t),
+	})
+	soroban := rpctest.NewSorobanServer()
+	defer soroban.Close()
+
+	client, err := rpc.NewClient(rpc.WithNetwork(rpc.Testnet), rpc.WithHorizonURL(horizon.URL), rpc.WithSorobanURL(soroban.URL))
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var out bytes.Buffer
+	require.NoError(t, newTUI(client).run(ctx, strings.NewReader("tx "+hash+"\nquit\n"), &out))
+
+	screens := strings.Split(out.String(), "\033[H\033[2J")
+	screen := screens[len(screens)-1]
+	assert.Contains(t, screen, "Hash:       "+hash)
+	assert.Contains(t, screen, "Source:     "+source)
+	assert.Contains(t, screen, "Status:     successful, 1 operations, fee charged 100 stroops")
+	assert.Contains(t, screen, "type: bump_sequence")
+}
+
+func TestTUICommands(t *testing.T) {
+	client, err := rpc.NewClient(rpc.WithNetwork(rpc.Testnet))
+	require.NoError(t, err)
+	ui := newTUI(client)
+	ui.ctx = context.Background()
+
+	status := func(line string) string {
+		assert.False(t, ui.handle(line), line)
+		return ui.state.status
+	}
+	assert.Equal(t, `invalid account ID: "CNOTANACCOUNT"`, status("account CNOTANACCOUNT"))
+	assert.Equal(t, `invalid contract ID: "GNOTACONTRACT"`, status("contract GNOTACONTRACT"))
+	assert.Equal(t, `invalid transaction hash: "abc"`, status("tx abc"))
+	assert.Equal(t, "no row numbered 3", status("tx 3"))
+	assert.Contains(t, status("frobnicate"), "unknown command: frobnicate")
+	assert.False(t, ui.handle("   "))
+
+	ui.handle("help")
+	assert.Equal(t, tuiHelp, ui.state.inspector)
+	for _, line := range []string{"quit", "q", "exit"} {
+		assert.True(t, ui.handle(line), line)
+	}
+}
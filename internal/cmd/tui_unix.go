@@ -0,0 +1,26 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows && !plan9 && !js && !wasip1
+
+package cmd
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// terminalSize queries the size of the terminal on stdout via the
+// TIOCGWINSZ ioctl. It returns zeros when stdout is not a terminal.
+func terminalSize() (cols, rows int) {
+	var ws struct {
+		Row, Col       uint16
+		Xpixel, Ypixel uint16
+	}
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, os.Stdout.Fd(), syscall.TIOCGWINSZ, uintptr(unsafe.Pointer(&ws)))
+	if errno != 0 {
+		return 0, 0
+	}
+	return int(ws.Col), int(ws.Row)
+}
@@ -0,0 +1,10 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows || plan9 || js || wasip1
+
+package cmd
+
+// terminalSize returns zeros where TIOCGWINSZ is unavailable; the TUI
+// falls back to COLUMNS and LINES.
+func terminalSize() (cols, rows int) { return 0, 0 }
@@ -6,6 +6,7 @@ package cmd
 import (
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"os"
 
@@ -20,6 +21,16 @@ var (
 	newWasmPath string
 )
 
+// upgradeReport is the output of erst simulate-upgrade; its JSON form is
+// stable.
+type upgradeReport struct {
+	TxHash        string                        `json:"tx_hash"`
+	ContractID    string                        `json:"contract_id"`
+	WasmBytes     int                           `json:"wasm_bytes"`
+	LedgerEntries int                           `json:"ledger_entries"`
+	Result        *simulator.SimulationResponse `json:"result"`
+}
+
 var upgradeCmd = &cobra.Command{
 	Use:   "simulate-upgrade <transaction-hash> --new-wasm <path>",
 	Short: "Simulate a transaction with upgraded contract code",
@@ -31,6 +42,8 @@ Example:
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		txHash := args[0]
+		format := outputFormat(false)
+		progress := progressWriter(cmd, format)
 
 		if newWasmPath == "" {
 			return errors.WrapCliArgumentRequired("new-wasm")
@@ -41,7 +54,7 @@ Example:
 		if err != nil {
 			return errors.WrapValidationError(fmt.Sprintf("failed to read WASM file: %v", err))
 		}
-		fmt.Printf("Loaded new WASM code: %d bytes\n", len(newWasmBytes))
+		fmt.Fprintf(progress, "Loaded new WASM code: %d bytes\n", len(newWasmBytes))
 
 		// 2. Setup Client
 		opts := []rpc.ClientOption{
@@ -68,7 +81,7 @@ Example:
 	}
 
 		// 3. Fetch Transaction
-		fmt.Printf("Fetching transaction: %s from %s\n", txHash, networkFlag)
+		fmt.Fprintf(progress, "Fetching transaction: %s from %s\n", txHash, networkFlag)
 		resp, err := client.GetTransaction(cmd.Context(), txHash)
 		if err != nil {
 			return errors.WrapRPCConnectionFailed(err)
@@ -84,19 +97,19 @@ Example:
 		if err != nil {
 			return errors.WrapRPCConnectionFailed(err)
 		}
-		fmt.Printf("Fetched %d ledger entries\n", len(entries))
+		fmt.Fprintf(progress, "Fetched %d ledger entries\n", len(entries))
 
 		// 5. Identify Contract ID and Inject New Code
 		contractID, err := getContractIDFromEnvelope(resp.EnvelopeXdr)
 		if err != nil {
 			return errors.WrapSimulationLogicError(fmt.Sprintf("failed to identify contract from transaction: %v", err))
 		}
-		fmt.Printf("Identified target contract: %x\n", *contractID)
+		fmt.Fprintf(progress, "Identified target contract: %x\n", *contractID)
 
 		if err := injectNewCode(entries, *contractID, newWasmBytes); err != nil {
 			return errors.WrapSimulationLogicError(fmt.Sprintf("failed to inject new code: %v", err))
 		}
-		fmt.Println("Injected new WASM code into simulation state.")
+		fmt.Fprintln(progress, "Injected new WASM code into simulation state.")
 
 		// 6. Run Simulation
 		runner, err := simulator.NewRunner("", false)
@@ -110,12 +123,21 @@ Example:
 			LedgerEntries: entries,
 		}
 
-		fmt.Println("Running simulation with upgraded code...")
+		fmt.Fprintln(progress, "Running simulation with upgraded code...")
 		result, err := runner.Run(simReq)
 		if err != nil {
 			return errors.WrapSimulationFailed(err, "")
 		}
 
+		if format != outputTable {
+			return writeStructured(cmd.OutOrStdout(), format, upgradeReport{
+				TxHash:        txHash,
+				ContractID:    hex.EncodeToString(contractID[:]),
+				WasmBytes:     len(newWasmBytes),
+				LedgerEntries: len(entries),
+				Result:        result,
+			})
+		}
 		printSimulationResult("Upgraded Contract", result)
 
 		return nil
@@ -132,6 +154,7 @@ func init() {
 	upgradeCmd.Flags().StringVar(&rpcTokenFlag, "rpc-token", "", "RPC authentication token (can also use ERST_RPC_TOKEN env var)")
 	upgradeCmd.Flags().StringVar(&rpcHeadersFlag, "rpc-headers", "", "Additional headers to include on RPC requests (JSON or key=value list)")
 
+	supportStructuredOutput(upgradeCmd)
 	rootCmd.AddCommand(upgradeCmd)
 }
 
@@ -4,7 +4,6 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"runtime/debug"
 	"time"
@@ -32,21 +31,21 @@ var versionCmd = &cobra.Command{
 	Short: "Show version information",
 	Long:  "Display detailed build information including version, commit hash, and build date",
 	Args:  cobra.NoArgs,
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		jsonOutput, _ := cmd.Flags().GetBool("json")
 
 		info := getVersionInfo()
 
-		if jsonOutput {
-			output, _ := json.MarshalIndent(info, "", "  ")
-			fmt.Println(string(output))
-		} else {
-			fmt.Printf("Erst Version: %s\n", info.Version)
-			fmt.Printf("Commit SHA:   %s\n", info.CommitSHA)
-			fmt.Printf("Build Date:   %s\n", info.BuildDate)
-			fmt.Printf("Go Version:   %s\n", info.GoVersion)
+		w := cmd.OutOrStdout()
+		if format := outputFormat(jsonOutput); format != outputTable {
+			return writeStructured(w, format, info)
 		}
-		fmt.Printf("erst version %s\n", Version)
+		fmt.Fprintf(w, "Erst Version: %s\n", info.Version)
+		fmt.Fprintf(w, "Commit SHA:   %s\n", info.CommitSHA)
+		fmt.Fprintf(w, "Build Date:   %s\n", info.BuildDate)
+		fmt.Fprintf(w, "Go Version:   %s\n", info.GoVersion)
+		fmt.Fprintf(w, "erst version %s\n", Version)
+		return nil
 	},
 }
 
@@ -85,4 +84,5 @@ func getVersionInfo() VersionInfo {
 func init() {
 	rootCmd.AddCommand(versionCmd)
 	versionCmd.Flags().Bool("json", false, "Output version information in JSON format")
+	supportStructuredOutput(versionCmd)
 }
@@ -0,0 +1,313 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dotandev/hintents/internal/abi"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/spf13/cobra"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/strkey"
+)
+
+var (
+	watchNet       networkFlags
+	watchAccounts  []string
+	watchContracts []string
+	watchLedgers   bool
+	watchInterval  time.Duration
+	watchJSON      bool
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Stream new ledgers, transactions and contract events as one-line summaries",
+	Long: `Follow the network in real time and print one line per item:
+
+  ledger       every closed ledger, with its transaction and operation counts
+  transaction  every transaction affecting an account given with --account
+  event        every event emitted by a contract given with --contract
+
+Ledgers and transactions are streamed from Horizon; events are polled from
+Soroban RPC every --interval. Ledgers are shown when no --account or
+--contract is given, or with --ledgers. Streams reconnect on their own if
+the connection drops. --json prints one JSON record per line.
+
+Examples:
+  erst watch --network testnet
+  erst watch --account GABC... --account GDEF...
+  erst watch --contract CABC... --ledgers
+  erst watch --contract CABC... --json | jq 'select(.type == "event")'`,
+	Args: cobra.NoArgs,
+	RunE: runWatch,
+}
+
+// watchRecord is one line of erst watch output; its JSON form is stable.
+type watchRecord struct {
+	// Type is "ledger", "transaction" or "event".
+	Type    string     `json:"type"`
+	Ledger  uint32     `json:"ledger"`
+	At      *time.Time `json:"at,omitempty"`
+	Summary string     `json:"summary"`
+	// Hash is the transaction hash for transactions and events.
+	Hash string `json:"hash,omitempty"`
+	// Account or Contract is the watched address the record matched.
+	Account    string             `json:"account,omitempty"`
+	Contract   string             `json:"contract,omitempty"`
+	Successful *bool              `json:"successful,omitempty"`
+	Event      *abi.DecodedEvent  `json:"event,omitempty"`
+	Tx         *watchTransaction  `json:"transaction,omitempty"`
+	LedgerInfo *watchLedgerDetail `json:"ledger_info,omitempty"`
+}
+
+type watchLedgerDetail struct {
+	Hash                   string `json:"hash"`
+	SuccessfulTransactions int32  `json:"successful_transactions"`
+	FailedTransactions     int32  `json:"failed_transactions"`
+	Operations             int32  `json:"operations"`
+	BaseFee                int32  `json:"base_fee"`
+	ProtocolVersion        int32  `json:"protocol_version"`
+}
+
+type watchTransaction struct {
+	Source     string `json:"source"`
+	Operations int32  `json:"operations"`
+	FeeCharged int64  `json:"fee_charged"`
+	Memo       string `json:"memo,omitempty"`
+}
+
+func runWatch(cmd *cobra.Command, _ []string) error {
+	for _, id := range watchAccounts {
+		if !strkey.IsValidEd25519PublicKey(id) {
+			return errors.WrapValidationError(fmt.Sprintf("invalid account ID: %s", id))
+		}
+	}
+	for _, id := range watchContracts {
+		if !strkey.IsValidContractAddress(id) {
+			return errors.WrapValidationError(fmt.Sprintf("invalid contract ID: %s", id))
+		}
+	}
+	if len(watchContracts) > 0 && watchInterval <= 0 {
+		return errors.WrapValidationError("--interval must be positive")
+	}
+	client, err := watchNet.client(cmd)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+	showLedgers := watchLedgers || (len(watchAccounts) == 0 && len(watchContracts) == 0)
+	return runWatchLoop(ctx, client, showLedgers, cmd.OutOrStdout())
+}
+
+// runWatchLoop runs one follower per source and prints their records as they
+// arrive until ctx is cancelled or a follower fails for good.
+func runWatchLoop(ctx context.Context, client *rpc.Client, showLedgers bool, w io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	records := make(chan watchRecord)
+	errs := make(chan error, 1)
+	var wg sync.WaitGroup
+	follow := func(f func(ctx context.Context, emit func(watchRecord)) error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			emit := func(r watchRecord) {
+				select {
+				case records <- r:
+				case <-ctx.Done():
+				}
+			}
+			if err := f(ctx, emit); err != nil && ctx.Err() == nil {
+				select {
+				case errs <- err:
+				default:
+				}
+				cancel()
+			}
+		}()
+	}
+
+	if showLedgers {
+		follow(func(ctx context.Context, emit func(watchRecord)) error {
+			return client.StreamLedgers(ctx, "", func(l hProtocol.Ledger) error {
+				emit(ledgerRecord(l))
+				return nil
+			})
+		})
+	}
+	for _, account := range watchAccounts {
+		follow(func(ctx context.Context, emit func(watchRecord)) error {
+			return client.StreamAccountTransactions(ctx, account, "", func(tx hProtocol.Transaction) error {
+				emit(transactionRecord(account, tx))
+				return nil
+			})
+		})
+	}
+	if len(watchContracts) > 0 {
+		follow(func(ctx context.Context, emit func(watchRecord)) error {
+			return followContractEvents(ctx, client, watchContracts, watchInterval, emit)
+		})
+	}
+
+	go func() {
+		wg.Wait()
+		close(records)
+	}()
+	for r := range records {
+		if err := writeWatchRecord(w, r); err != nil {
+			cancel()
+			return err
+		}
+	}
+	select {
+	case err := <-errs:
+		return err
+	default:
+		return nil
+	}
+}
+
+// followContractEvents polls getEvents every interval for the contracts'
+// events from the latest ledger on, like erst events --follow. Without
+// contracts it follows the events of every contract.
+func followContractEvents(ctx context.Context, client *rpc.Client, contracts []string, interval time.Duration, emit func(watchRecord)) error {
+	latest, err := client.GetLatestLedger(ctx)
+	if err != nil {
+		return err
+	}
+	registry := abi.NewEventRegistry(client)
+	req := rpc.EventsRequest{StartLedger: latest.Sequence}
+	if len(contracts) > 0 {
+		req.Filters = []rpc.EventFilter{{ContractIDs: contracts}}
+	}
+	for {
+		events, cursor, err := registry.FetchEvents(ctx, req)
+		if err != nil {
+			return err
+		}
+		for _, ev := range events {
+			emit(eventRecord(ev))
+		}
+		if cursor != "" {
+			req = rpc.EventsRequest{Cursor: cursor, Filters: req.Filters}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+func ledgerRecord(l hProtocol.Ledger) watchRecord {
+	var failed int32
+	if l.FailedTransactionCount != nil {
+		failed = *l.FailedTransactionCount
+	}
+	return watchRecord{
+		Type:    "ledger",
+		Ledger:  uint32(l.Sequence),
+		At:      &l.ClosedAt,
+		Summary: fmt.Sprintf("%d txs (%d failed), %d ops, base fee %d", l.SuccessfulTransactionCount+failed, failed, l.OperationCount, l.BaseFee),
+		LedgerInfo: &watchLedgerDetail{
+			Hash:                   l.Hash,
+			SuccessfulTransactions: l.SuccessfulTransactionCount,
+			FailedTransactions:     failed,
+			Operations:             l.OperationCount,
+			BaseFee:                l.BaseFee,
+			ProtocolVersion:        l.ProtocolVersion,
+		},
+	}
+}
+
+func transactionRecord(account string, tx hProtocol.Transaction) watchRecord {
+	successful := tx.Successful
+	summary := fmt.Sprintf("%d ops from %s, fee %s", tx.OperationCount, tx.Account, stroops(tx.FeeCharged))
+	if tx.Memo != "" {
+		summary += fmt.Sprintf(", memo %q", tx.Memo)
+	}
+	if !successful {
+		summary += " [failed]"
+	}
+	return watchRecord{
+		Type:       "transaction",
+		Ledger:     uint32(tx.Ledger),
+		At:         &tx.LedgerCloseTime,
+		Summary:    summary,
+		Hash:       tx.Hash,
+		Account:    account,
+		Successful: &successful,
+		Tx: &watchTransaction{
+			Source:     tx.Account,
+			Operations: tx.OperationCount,
+			FeeCharged: tx.FeeCharged,
+			Memo:       tx.Memo,
+		},
+	}
+}
+
+func eventRecord(ev *abi.DecodedEvent) watchRecord {
+	return watchRecord{
+		Type:     "event",
+		Ledger:   ev.Ledger,
+		Summary:  eventBody(ev),
+		Hash:     ev.TxHash,
+		Contract: ev.ContractID,
+		Event:    ev,
+	}
+}
+
+func writeWatchRecord(w io.Writer, r watchRecord) error {
+	if format := outputFormat(watchJSON); format != outputTable {
+		return writeRecord(w, format, r)
+	}
+	at := strings.Repeat(" ", 8)
+	if r.At != nil {
+		at = r.At.UTC().Format(time.TimeOnly)
+	}
+	line := fmt.Sprintf("%s  %-11s %9d  ", at, r.Type, r.Ledger)
+	switch r.Type {
+	case "transaction":
+		line += fmt.Sprintf("%s  %s", shortHash(r.Hash), r.Summary)
+	case "event":
+		line += fmt.Sprintf("%s  %s  tx=%s", r.Contract, r.Summary, shortHash(r.Hash))
+	default:
+		line += r.Summary
+	}
+	_, err := fmt.Fprintln(w, line)
+	return err
+}
+
+// shortHash abbreviates a transaction hash for one-line output.
+func shortHash(h string) string {
+	if len(h) <= 12 {
+		return h
+	}
+	return h[:12]
+}
+
+func init() {
+	watchNet.register(watchCmd)
+	watchCmd.Flags().StringSliceVar(&watchAccounts, "account", nil, "Print transactions affecting this account (repeatable)")
+	watchCmd.Flags().StringSliceVar(&watchContracts, "contract", nil, "Print events of this contract (repeatable, up to 5)")
+	watchCmd.Flags().BoolVar(&watchLedgers, "ledgers", false, "Print ledgers even when --account or --contract is given")
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", 5*time.Second, "How often contract events are polled")
+	watchCmd.Flags().BoolVar(&watchJSON, "json", false, "Print one JSON record per line")
+
+	supportStructuredOutput(watchCmd)
+	rootCmd.AddCommand(watchCmd)
+}
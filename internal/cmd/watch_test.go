@@ -0,0 +1,142 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dotandev/hintents/internal/rpc/rpctest"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lineWriter collects output and calls done once it holds want lines.
+type lineWriter struct {
+	mu   sync.Mutex
+	buf  bytes.Buffer
+	want int
+	done func()
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	n, err := w.buf.Write(p)
+	if strings.Count(w.buf.String(), "\n") >= w.want {
+		w.done()
+	}
+	return n, err
+}
+
+func (w *lineWriter) lines() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return strings.Split(strings.TrimSpace(w.buf.String()), "\n")
+}
+
+// sseHorizon serves one record on each of the given stream paths and then
+// keeps the connection open until the client goes away.
+func sseHorizon(t *testing.T, streams map[string]string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := streams[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, "id: 1\ndata: %s\n\n", data)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func runWatchUntil(t *testing.T, want int) []string {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	out := &lineWriter{want: want, done: cancel}
+
+	cmd, _ := testCommand("")
+	cmd.SetContext(ctx)
+	cmd.SetOut(out)
+	require.NoError(t, runWatch(cmd, nil))
+	return out.lines()
+}
+
+func TestWatchLedgersAndTransactions(t *testing.T) {
+	account := keypair.MustRandom().Address()
+	horizon := sseHorizon(t, map[string]string{
+		"/ledgers": `{"sequence":1234,"hash":"abcd","successful_transaction_count":40,"failed_transaction_count":2,` +
+			`"operation_count":120,"base_fee_in_stroops":100,"closed_at":"2025-01-02T03:04:05Z"}`,
+		"/accounts/" + account + "/transactions": `{"hash":"` + strings.Repeat("ef", 32) + `","ledger":1234,` +
+			`"created_at":"2025-01-02T03:04:05Z","source_account":"` + account + `","operation_count":1,` +
+			`"fee_charged":"100","successful":false,"memo":"invoice 7"}`,
+	})
+	watchNet = networkFlags{network: "testnet", horizonURL: horizon.URL}
+	watchAccounts, watchLedgers = []string{account}, true
+	defer func() { watchNet, watchAccounts, watchLedgers = networkFlags{}, nil, false }()
+
+	lines := runWatchUntil(t, 2)
+	require.Len(t, lines, 2)
+	output := strings.Join(lines, "\n")
+	assert.Contains(t, output, "03:04:05  ledger           1234  42 txs (2 failed), 120 ops, base fee 100")
+	assert.Contains(t, output, "03:04:05  transaction      1234  efefefefefef  1 ops from "+account)
+	assert.Contains(t, output, `memo "invoice 7" [failed]`)
+}
+
+func TestWatchContractEventsJSON(t *testing.T) {
+	contract := testContractID(t)
+	soroban := rpctest.NewSorobanServer()
+	defer soroban.Close()
+	soroban.On("getEvents", rpctest.Result(map[string]any{
+		"events": []map[string]any{{
+			"type": "contract", "ledger": 1000, "contractId": contract, "id": "0001", "txHash": "ab",
+			"topic": []string{mustB64(t, xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &[]xdr.ScSymbol{"ping"}[0]})},
+			"value": mustB64(t, u32Val(5)),
+		}},
+		"cursor":       "c1",
+		"latestLedger": 1000,
+	}))
+
+	watchNet = networkFlags{network: "testnet", sorobanURL: soroban.URL}
+	watchContracts, watchInterval, watchJSON = []string{contract}, time.Millisecond, true
+	defer func() { watchNet, watchContracts, watchInterval, watchJSON = networkFlags{}, nil, 0, false }()
+
+	lines := runWatchUntil(t, 1)
+	var record watchRecord
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &record))
+	assert.Equal(t, "event", record.Type)
+	assert.Equal(t, uint32(1000), record.Ledger)
+	assert.Equal(t, contract, record.Contract)
+	assert.Equal(t, `topics=["ping"] data=5`, record.Summary)
+	require.NotNil(t, record.Event)
+	assert.Nil(t, record.At)
+
+	assert.Contains(t, string(soroban.Calls("getEvents")[0]), `"startLedger":1000`)
+}
+
+func TestWatchRejectsBadAddresses(t *testing.T) {
+	defer func() { watchAccounts, watchContracts = nil, nil }()
+	cmd, _ := testCommand("")
+
+	watchAccounts = []string{"CNOTANACCOUNT"}
+	assert.ErrorContains(t, runWatch(cmd, nil), "invalid account ID")
+
+	watchAccounts, watchContracts = nil, []string{"GNOTACONTRACT"}
+	assert.ErrorContains(t, runWatch(cmd, nil), "invalid contract ID")
+}
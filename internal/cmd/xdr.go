@@ -5,6 +5,7 @@ package cmd
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 
 	"github.com/dotandev/hintents/internal/decoder"
@@ -19,16 +20,40 @@ var (
 )
 
 var xdrCmd = &cobra.Command{
-	Use:   "xdr",
+	Use:   "xdr [base64]",
 	Short: "Format and decode XDR data",
-	Long:  `Decode and format XDR structures to JSON or table format for easy inspection.`,
-	RunE:  xdrExec,
+	Long: `Decode and format XDR structures to JSON or text for easy inspection.
+
+By default the type of the blob is detected automatically: transaction
+envelopes, results and meta, ledger entries and keys, diagnostic events and
+SCVals are recognized. Pass --type to decode as a specific type.
+
+Examples:
+  erst xdr AAAAAgAAAAB...
+  erst xdr --data AAAAAQAAAA... --format text
+  erst xdr --data AAAABgAAAAE... --type ledger-key`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: xdrExec,
 }
 
 func xdrExec(cmd *cobra.Command, args []string) error {
+	if xdrData == "" && len(args) == 1 {
+		xdrData = args[0]
+	}
 	if xdrData == "" {
 		return errors.WrapCliArgumentRequired("data")
 	}
+	// --format wins over the global --output.
+	format := xdrFormat
+	if !cmd.Flags().Changed("format") && OutputFlag != "" {
+		format = OutputFlag
+	}
+
+	// The raw formatter still serves the two types it always has, including
+	// its table layout; everything else goes through type detection.
+	if xdrType != "ledger-entry" && xdrType != "diagnostic-event" {
+		return xdrDetectExec(cmd, format)
+	}
 
 	data, err := base64.StdEncoding.DecodeString(xdrData)
 	if err != nil {
@@ -52,17 +77,56 @@ func xdrExec(cmd *cobra.Command, args []string) error {
 		}
 		output = event
 
-	default:
-		return errors.WrapValidationError(fmt.Sprintf("unsupported XDR type: %s (use: ledger-entry, diagnostic-event)", xdrType))
 	}
 
-	formatter := decoder.NewXDRFormatter(decoder.FormatType(xdrFormat))
-	result, err := formatter.Format(output)
+	return writeXDR(cmd, format, func(format string) (string, error) {
+		return decoder.NewXDRFormatter(decoder.FormatType(format)).Format(output)
+	})
+}
+
+// xdrDetectExec decodes xdrData as the type named by --type, detecting it
+// when the type is "auto", and prints the human-readable view.
+func xdrDetectExec(cmd *cobra.Command, format string) error {
+	var (
+		detected *decoder.DetectedXDR
+		err      error
+	)
+	if xdrType == "auto" {
+		detected, err = decoder.DetectXDR(xdrData)
+	} else {
+		kind, kerr := decoder.ParseXDRKind(xdrType)
+		if kerr != nil {
+			return errors.WrapValidationError(fmt.Sprintf("unsupported XDR type: %s (use: auto, envelope, result, meta, ledger-entry, ledger-key, diagnostic-event, scval)", xdrType))
+		}
+		detected, err = decoder.DecodeXDRAs(xdrData, kind)
+	}
 	if err != nil {
-		return errors.WrapValidationError(fmt.Sprintf("formatting failed: %v", err))
+		return errors.WrapUnmarshalFailed(err, xdrType)
 	}
 
-	fmt.Println(result)
+	return writeXDR(cmd, format, func(format string) (string, error) {
+		if format == "table" {
+			format = "text"
+		}
+		return decoder.FormatDetected(detected, format)
+	})
+}
+
+// writeXDR prints what render produces in format. YAML is converted from
+// the JSON rendering.
+func writeXDR(cmd *cobra.Command, format string, render func(format string) (string, error)) error {
+	yaml := format == outputYAML
+	if yaml {
+		format = outputJSON
+	}
+	result, err := render(format)
+	if err != nil {
+		return errors.WrapValidationError(fmt.Sprintf("formatting failed: %v", err))
+	}
+	if yaml {
+		return writeStructured(cmd.OutOrStdout(), outputYAML, json.RawMessage(result))
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), result)
 	return nil
 }
 
@@ -70,8 +134,7 @@ func init() {
 	rootCmd.AddCommand(xdrCmd)
 
 	xdrCmd.Flags().StringVar(&xdrData, "data", "", "Base64-encoded XDR data to decode")
-	xdrCmd.Flags().StringVar(&xdrFormat, "format", "json", "Output format: json or table")
-	xdrCmd.Flags().StringVar(&xdrType, "type", "ledger-entry", "XDR type: ledger-entry, diagnostic-event")
-
-	_ = xdrCmd.MarkFlagRequired("data")
+	xdrCmd.Flags().StringVar(&xdrFormat, "format", "json", "Output format: json, text or table")
+	xdrCmd.Flags().StringVar(&xdrType, "type", "auto", "XDR type: auto, envelope, result, meta, ledger-entry, ledger-key, diagnostic-event, scval")
+	supportStructuredOutput(xdrCmd)
 }
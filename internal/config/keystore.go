@@ -0,0 +1,192 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/keypair"
+)
+
+// keystoreKDF names the key derivation of StoredKey; it is the only one
+// supported so far.
+const keystoreKDF = "pbkdf2-sha256"
+
+// keystoreIterations is the PBKDF2 work factor of new keys. Existing keys
+// keep the count they were stored with.
+var keystoreIterations = 600_000
+
+var keyNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+// StoredKey is a secret seed encrypted with AES-256-GCM under a key derived
+// from a passphrase. The public key is kept in clear so keys can be listed
+// without the passphrase, and it authenticates the ciphertext.
+type StoredKey struct {
+	PublicKey  string    `json:"public_key"`
+	CreatedAt  time.Time `json:"created_at"`
+	KDF        string    `json:"kdf"`
+	Iterations int       `json:"iterations"`
+	Salt       []byte    `json:"salt"`
+	Nonce      []byte    `json:"nonce"`
+	Ciphertext []byte    `json:"ciphertext"`
+}
+
+// Keystore is the content of ~/.erst/keys.json.
+type Keystore struct {
+	Keys map[string]StoredKey `json:"keys"`
+}
+
+// GetKeystorePath returns the path to the keystore file.
+func GetKeystorePath() (string, error) {
+	configDir, err := GetConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "keys.json"), nil
+}
+
+// LoadKeystore loads the saved keys, returning an empty keystore when there
+// are none.
+func LoadKeystore() (*Keystore, error) {
+	path, err := GetKeystorePath()
+	if err != nil {
+		return nil, err
+	}
+	ks := &Keystore{}
+	if err := readJSONFile(path, ks); err != nil {
+		return nil, err
+	}
+	if ks.Keys == nil {
+		ks.Keys = make(map[string]StoredKey)
+	}
+	return ks, nil
+}
+
+// SaveKeystore writes the keystore to disk, readable by the owner only.
+func SaveKeystore(ks *Keystore) error {
+	path, err := GetKeystorePath()
+	if err != nil {
+		return err
+	}
+	return writeJSONFile(path, ks)
+}
+
+// Names returns the key names in sorted order.
+func (ks *Keystore) Names() []string {
+	names := make([]string, 0, len(ks.Keys))
+	for name := range ks.Keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get returns the named key.
+func (ks *Keystore) Get(name string) (*StoredKey, error) {
+	key, ok := ks.Keys[name]
+	if !ok {
+		return nil, errors.WrapConfigError(fmt.Sprintf("key %q does not exist", name), nil)
+	}
+	return &key, nil
+}
+
+// Add encrypts seed with passphrase and stores it as name. It does not
+// save the keystore.
+func (ks *Keystore) Add(name, seed, passphrase string) (*StoredKey, error) {
+	if !keyNamePattern.MatchString(name) {
+		return nil, errors.WrapValidationError(fmt.Sprintf("invalid key name %q: use up to 64 letters, digits, '.', '_' or '-'", name))
+	}
+	if _, ok := ks.Keys[name]; ok {
+		return nil, errors.WrapValidationError(fmt.Sprintf("key %q already exists", name))
+	}
+	if passphrase == "" {
+		return nil, errors.WrapValidationError("passphrase cannot be empty")
+	}
+	kp, err := keypair.ParseFull(seed)
+	if err != nil {
+		return nil, errors.WrapValidationError("invalid secret seed")
+	}
+
+	key := StoredKey{
+		PublicKey:  kp.Address(),
+		CreatedAt:  time.Now().UTC().Truncate(time.Second),
+		KDF:        keystoreKDF,
+		Iterations: keystoreIterations,
+		Salt:       make([]byte, 16),
+	}
+	if _, err := rand.Read(key.Salt); err != nil {
+		return nil, errors.WrapConfigError("failed to generate salt", err)
+	}
+	aead, err := key.cipher(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	key.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(key.Nonce); err != nil {
+		return nil, errors.WrapConfigError("failed to generate nonce", err)
+	}
+	key.Ciphertext = aead.Seal(nil, key.Nonce, []byte(kp.Seed()), []byte(key.PublicKey))
+
+	if ks.Keys == nil {
+		ks.Keys = make(map[string]StoredKey)
+	}
+	ks.Keys[name] = key
+	return &key, nil
+}
+
+// Seed decrypts the named key's secret seed with passphrase.
+func (ks *Keystore) Seed(name, passphrase string) (string, error) {
+	key, err := ks.Get(name)
+	if err != nil {
+		return "", err
+	}
+	aead, err := key.cipher(passphrase)
+	if err != nil {
+		return "", err
+	}
+	seed, err := aead.Open(nil, key.Nonce, key.Ciphertext, []byte(key.PublicKey))
+	if err != nil {
+		return "", errors.WrapUnauthorized(fmt.Sprintf("wrong passphrase for key %q", name))
+	}
+	if kp, err := keypair.ParseFull(string(seed)); err != nil || kp.Address() != key.PublicKey {
+		return "", errors.WrapConfigError(fmt.Sprintf("key %q is corrupt", name), nil)
+	}
+	return string(seed), nil
+}
+
+// cipher derives the AES-256-GCM cipher of the key from passphrase.
+func (k *StoredKey) cipher(passphrase string) (cipher.AEAD, error) {
+	return deriveCipher(k.KDF, k.Iterations, k.Salt, passphrase)
+}
+
+// deriveCipher derives an AES-256-GCM cipher from passphrase. Profile
+// tokens are encrypted the same way as keys.
+func deriveCipher(kdf string, iterations int, salt []byte, passphrase string) (cipher.AEAD, error) {
+	if kdf != keystoreKDF || iterations <= 0 {
+		return nil, errors.WrapConfigError(fmt.Sprintf("unsupported key derivation %q", kdf), nil)
+	}
+	derived, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, 32)
+	if err != nil {
+		return nil, errors.WrapConfigError("failed to derive key", err)
+	}
+	block, err := aes.NewCipher(derived)
+	if err != nil {
+		return nil, errors.WrapConfigError("failed to create cipher", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.WrapConfigError("failed to create cipher", err)
+	}
+	return aead, nil
+}
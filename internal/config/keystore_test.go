@@ -0,0 +1,144 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/keypair"
+)
+
+func TestKeystoreRoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("USERPROFILE", tmpDir)
+	defer func(n int) { keystoreIterations = n }(keystoreIterations)
+	keystoreIterations = 1000
+
+	kp := keypair.MustRandom()
+	ks, err := LoadKeystore()
+	if err != nil || len(ks.Keys) != 0 {
+		t.Fatalf("Expected an empty keystore, got %+v %v", ks, err)
+	}
+	key, err := ks.Add("alice", kp.Seed(), "correct horse")
+	if err != nil {
+		t.Fatalf("Failed to add key: %v", err)
+	}
+	if key.PublicKey != kp.Address() || key.Iterations != 1000 {
+		t.Errorf("Unexpected stored key %+v", key)
+	}
+	if err := SaveKeystore(ks); err != nil {
+		t.Fatalf("Failed to save keystore: %v", err)
+	}
+
+	path := filepath.Join(tmpDir, ".erst", "keys.json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read keystore: %v", err)
+	}
+	if strings.Contains(string(data), kp.Seed()) {
+		t.Error("Seed must not be written in clear")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Failed to stat keystore: %v", err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		t.Errorf("Expected keystore permissions 600, got %o", info.Mode().Perm())
+	}
+
+	ks, err = LoadKeystore()
+	if err != nil {
+		t.Fatalf("Failed to load keystore: %v", err)
+	}
+	if got := ks.Names(); len(got) != 1 || got[0] != "alice" {
+		t.Errorf("Expected [alice], got %v", got)
+	}
+	if seed, err := ks.Seed("alice", "correct horse"); err != nil || seed != kp.Seed() {
+		t.Errorf("Expected seed to round-trip, got %v", err)
+	}
+	if _, err := ks.Seed("alice", "wrong"); !errors.Is(err, errs.ErrUnauthorized) {
+		t.Errorf("Expected unauthorized for a wrong passphrase, got %v", err)
+	}
+	if _, err := ks.Seed("bob", "correct horse"); err == nil {
+		t.Error("Expected error for missing key")
+	}
+}
+
+func TestKeystoreAddRejects(t *testing.T) {
+	seed := keypair.MustRandom().Seed()
+	ks := &Keystore{}
+	if _, err := ks.Add("dup", seed, "pw"); err != nil {
+		t.Fatalf("Failed to add key: %v", err)
+	}
+
+	for name, args := range map[string][3]string{
+		"duplicate name": {"dup", seed, "pw"},
+		"bad name":       {"../alice", seed, "pw"},
+		"empty name":     {"", seed, "pw"},
+		"bad seed":       {"alice", keypair.MustRandom().Address(), "pw"},
+		"no passphrase":  {"alice", seed, ""},
+	} {
+		if _, err := ks.Add(args[0], args[1], args[2]); !errors.Is(err, errs.ErrValidationFailed) {
+			t.Errorf("%s: expected validation error, got %v", name, err)
+		}
+	}
+}
+
+func TestKeystoreTampered(t *testing.T) {
+	ks := &Keystore{}
+	if _, err := ks.Add("alice", keypair.MustRandom().Seed(), "pw"); err != nil {
+		t.Fatalf("Failed to add key: %v", err)
+	}
+	key := ks.Keys["alice"]
+	key.PublicKey = keypair.MustRandom().Address()
+	ks.Keys["alice"] = key
+	if _, err := ks.Seed("alice", "pw"); err == nil {
+		t.Error("Expected error when the public key was changed")
+	}
+
+	key.KDF = "scrypt"
+	ks.Keys["alice"] = key
+	if _, err := ks.Seed("alice", "pw"); !errors.Is(err, errs.ErrConfigFailed) {
+		t.Errorf("Expected config error for an unknown KDF, got %v", err)
+	}
+}
+
+func TestWriteJSONFileReplacesAtomically(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "keys.json")
+	if err := os.WriteFile(path, []byte(`{"keys":{}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writeJSONFile(path, func() {}); err == nil {
+		t.Fatal("Expected error for a value that cannot be marshalled")
+	}
+	if data, _ := os.ReadFile(path); string(data) != `{"keys":{}}` {
+		t.Errorf("A failed write must leave the file untouched, got %q", data)
+	}
+
+	if err := writeJSONFile(path, &Keystore{Keys: map[string]StoredKey{"a": {PublicKey: "G"}}}); err != nil {
+		t.Fatalf("Failed to write: %v", err)
+	}
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), `"public_key": "G"`) {
+		t.Errorf("Expected the new content, got %q", data)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		t.Errorf("Expected permissions 600, got %o", info.Mode().Perm())
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("Expected no temporary files to remain, got %d entries", len(entries))
+	}
+}
@@ -0,0 +1,390 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/errors"
+)
+
+// DefaultProfileName is the profile written to when none is active.
+const DefaultProfileName = "default"
+
+// Profile is a named set of connection settings used by commands that talk
+// to a network when their flags are not given. Tokens are not part of the
+// profile; they are kept apart, encrypted, in the credentials file, see
+// Credentials.
+type Profile struct {
+	Network    string `json:"network,omitempty"`
+	HorizonURL string `json:"horizon_url,omitempty"`
+	SorobanURL string `json:"soroban_url,omitempty"`
+	RPCHeaders string `json:"rpc_headers,omitempty"`
+}
+
+// Profiles is the content of ~/.erst/profiles.json.
+type Profiles struct {
+	Active   string             `json:"active,omitempty"`
+	Profiles map[string]Profile `json:"profiles"`
+}
+
+// ProfileKeys are the settings of a profile, in display order. rpc_token is
+// stored in the credentials file and set with SetProfileToken; the others
+// are set with SetProfileValue.
+var ProfileKeys = []string{"network", "horizon_url", "soroban_url", "rpc_headers", "rpc_token"}
+
+// GetProfilesPath returns the path to the profiles file.
+func GetProfilesPath() (string, error) {
+	configDir, err := GetConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "profiles.json"), nil
+}
+
+// StoredToken is a profile's RPC token, encrypted like a StoredKey. Hint,
+// the masked token, is kept in clear so it can be shown without the
+// passphrase; the profile name authenticates the ciphertext.
+type StoredToken struct {
+	Hint       string `json:"hint"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Credentials is the content of ~/.erst/credentials.json.
+type Credentials struct {
+	Tokens map[string]StoredToken `json:"tokens"`
+}
+
+// GetCredentialsPath returns the path to the file holding profile tokens.
+func GetCredentialsPath() (string, error) {
+	configDir, err := GetConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "credentials.json"), nil
+}
+
+// LoadProfiles loads the saved profiles, returning an empty set when there
+// are none.
+func LoadProfiles() (*Profiles, error) {
+	path, err := GetProfilesPath()
+	if err != nil {
+		return nil, err
+	}
+	profiles := &Profiles{}
+	if err := readJSONFile(path, profiles); err != nil {
+		return nil, err
+	}
+	if profiles.Profiles == nil {
+		profiles.Profiles = make(map[string]Profile)
+	}
+	return profiles, nil
+}
+
+// SaveProfiles writes the profiles to disk, readable by the owner only.
+func SaveProfiles(profiles *Profiles) error {
+	path, err := GetProfilesPath()
+	if err != nil {
+		return err
+	}
+	return writeJSONFile(path, profiles)
+}
+
+// Names returns the profile names in sorted order.
+func (p *Profiles) Names() []string {
+	names := make([]string, 0, len(p.Profiles))
+	for name := range p.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ActiveProfile returns the profile selected by ERST_PROFILE or, failing
+// that, by erst config use-profile. It returns an empty name and profile when
+// neither is set, and an error when the selected profile does not exist.
+func ActiveProfile() (string, *Profile, error) {
+	profiles, err := LoadProfiles()
+	if err != nil {
+		return "", nil, err
+	}
+	name := getEnv("ERST_PROFILE", profiles.Active)
+	if name == "" {
+		return "", &Profile{}, nil
+	}
+	profile, err := profiles.Get(name)
+	if err != nil {
+		return "", nil, err
+	}
+	return name, profile, nil
+}
+
+// Get returns the named profile.
+func (p *Profiles) Get(name string) (*Profile, error) {
+	profile, ok := p.Profiles[name]
+	if !ok {
+		return nil, errors.WrapConfigError(fmt.Sprintf("profile %q does not exist", name), nil)
+	}
+	return &profile, nil
+}
+
+// UseProfile makes name the active profile.
+func UseProfile(name string) error {
+	profiles, err := LoadProfiles()
+	if err != nil {
+		return err
+	}
+	if _, err := profiles.Get(name); err != nil {
+		return err
+	}
+	profiles.Active = name
+	return SaveProfiles(profiles)
+}
+
+// SetProfileValue sets one of ProfileKeys on the named profile, creating
+// the profile if needed. An empty value clears the setting.
+func SetProfileValue(name, key, value string) error {
+	if name == "" {
+		return errors.WrapValidationError("profile name cannot be empty")
+	}
+	if key == "rpc_token" {
+		return errors.WrapValidationError("rpc_token is encrypted; set it with SetProfileToken")
+	}
+
+	profiles, err := LoadProfiles()
+	if err != nil {
+		return err
+	}
+	profile := profiles.Profiles[name]
+	switch key {
+	case "network":
+		profile.Network = value
+	case "horizon_url":
+		profile.HorizonURL = value
+	case "soroban_url":
+		profile.SorobanURL = value
+	case "rpc_headers":
+		profile.RPCHeaders = value
+	default:
+		return errors.WrapValidationError(fmt.Sprintf("unknown setting %q (valid: %s)", key, strings.Join(ProfileKeys, ", ")))
+	}
+	profiles.Profiles[name] = profile
+	return SaveProfiles(profiles)
+}
+
+// SetProfileToken encrypts token with passphrase and saves it as the RPC
+// token of the named profile, creating the profile if needed. An empty
+// token clears it, without the passphrase.
+func SetProfileToken(name, token, passphrase string) error {
+	if name == "" {
+		return errors.WrapValidationError("profile name cannot be empty")
+	}
+	creds, err := LoadCredentials()
+	if err != nil {
+		return err
+	}
+	if err := creds.Set(name, token, passphrase); err != nil {
+		return err
+	}
+	if err := SaveCredentials(creds); err != nil {
+		return err
+	}
+
+	profiles, err := LoadProfiles()
+	if err != nil {
+		return err
+	}
+	if _, ok := profiles.Profiles[name]; ok {
+		return nil
+	}
+	profiles.Profiles[name] = Profile{}
+	return SaveProfiles(profiles)
+}
+
+// ProfileValue returns one of ProfileKeys from the named profile. For
+// rpc_token it returns the masked token, which needs no passphrase; see
+// Credentials.Token for the token itself.
+func ProfileValue(name, key string) (string, error) {
+	profiles, err := LoadProfiles()
+	if err != nil {
+		return "", err
+	}
+	profile, err := profiles.Get(name)
+	if err != nil {
+		return "", err
+	}
+	switch key {
+	case "network":
+		return profile.Network, nil
+	case "horizon_url":
+		return profile.HorizonURL, nil
+	case "soroban_url":
+		return profile.SorobanURL, nil
+	case "rpc_headers":
+		return profile.RPCHeaders, nil
+	case "rpc_token":
+		creds, err := LoadCredentials()
+		if err != nil {
+			return "", err
+		}
+		return creds.Tokens[name].Hint, nil
+	}
+	return "", errors.WrapValidationError(fmt.Sprintf("unknown setting %q (valid: %s)", key, strings.Join(ProfileKeys, ", ")))
+}
+
+// MaskSecret hides all but the last four characters of a secret.
+func MaskSecret(s string) string {
+	if len(s) <= 4 {
+		return strings.Repeat("*", len(s))
+	}
+	return strings.Repeat("*", len(s)-4) + s[len(s)-4:]
+}
+
+// LoadCredentials loads the saved profile tokens, returning an empty set
+// when there are none.
+func LoadCredentials() (*Credentials, error) {
+	path, err := GetCredentialsPath()
+	if err != nil {
+		return nil, err
+	}
+	creds := &Credentials{}
+	if err := readJSONFile(path, creds); err != nil {
+		return nil, err
+	}
+	if creds.Tokens == nil {
+		creds.Tokens = make(map[string]StoredToken)
+	}
+	return creds, nil
+}
+
+// SaveCredentials writes the profile tokens to disk, readable by the owner
+// only.
+func SaveCredentials(creds *Credentials) error {
+	path, err := GetCredentialsPath()
+	if err != nil {
+		return err
+	}
+	return writeJSONFile(path, creds)
+}
+
+// Set encrypts token with passphrase and stores it for the named profile,
+// or removes the profile's token when token is empty. It does not save the
+// credentials.
+func (c *Credentials) Set(name, token, passphrase string) error {
+	if c.Tokens == nil {
+		c.Tokens = make(map[string]StoredToken)
+	}
+	if token == "" {
+		delete(c.Tokens, name)
+		return nil
+	}
+	if passphrase == "" {
+		return errors.WrapValidationError("passphrase cannot be empty")
+	}
+
+	stored := StoredToken{
+		Hint:       MaskSecret(token),
+		KDF:        keystoreKDF,
+		Iterations: keystoreIterations,
+		Salt:       make([]byte, 16),
+	}
+	if _, err := rand.Read(stored.Salt); err != nil {
+		return errors.WrapConfigError("failed to generate salt", err)
+	}
+	aead, err := deriveCipher(stored.KDF, stored.Iterations, stored.Salt, passphrase)
+	if err != nil {
+		return err
+	}
+	stored.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(stored.Nonce); err != nil {
+		return errors.WrapConfigError("failed to generate nonce", err)
+	}
+	stored.Ciphertext = aead.Seal(nil, stored.Nonce, []byte(token), []byte(name))
+	c.Tokens[name] = stored
+	return nil
+}
+
+// Has reports whether a token is saved for the named profile.
+func (c *Credentials) Has(name string) bool {
+	_, ok := c.Tokens[name]
+	return ok
+}
+
+// Token decrypts the RPC token of the named profile with passphrase. It
+// returns "" when the profile has no token.
+func (c *Credentials) Token(name, passphrase string) (string, error) {
+	stored, ok := c.Tokens[name]
+	if !ok {
+		return "", nil
+	}
+	aead, err := deriveCipher(stored.KDF, stored.Iterations, stored.Salt, passphrase)
+	if err != nil {
+		return "", err
+	}
+	token, err := aead.Open(nil, stored.Nonce, stored.Ciphertext, []byte(name))
+	if err != nil {
+		return "", errors.WrapUnauthorized(fmt.Sprintf("wrong passphrase for the token of profile %q", name))
+	}
+	return string(token), nil
+}
+
+// readJSONFile decodes path into v, leaving v untouched if path does not
+// exist.
+func readJSONFile(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.WrapConfigError("failed to read "+filepath.Base(path), err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return errors.WrapConfigError("failed to parse "+filepath.Base(path), err)
+	}
+	return nil
+}
+
+// writeJSONFile writes v to path with owner-only permissions. The data goes
+// to a synced temporary file first, which is then renamed over path, so a
+// crash or a full disk never leaves path truncated.
+func writeJSONFile(path string, v interface{}) error {
+	dir, name := filepath.Split(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return errors.WrapConfigError("failed to create config directory", err)
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return errors.WrapConfigError("failed to marshal "+name, err)
+	}
+	// CreateTemp makes the file readable by its owner only.
+	tmp, err := os.CreateTemp(dir, "."+name+"-*.tmp")
+	if err != nil {
+		return errors.WrapConfigError("failed to write "+name, err)
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.WrapConfigError("failed to write "+name, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return errors.WrapConfigError("failed to write "+name, err)
+	}
+	return nil
+}
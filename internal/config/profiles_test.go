@@ -0,0 +1,130 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+)
+
+func TestProfileSetGetUse(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("USERPROFILE", tmpDir)
+	t.Setenv("ERST_PROFILE", "")
+
+	if name, profile, err := ActiveProfile(); err != nil || name != "" || *profile != (Profile{}) {
+		t.Fatalf("Expected no active profile, got %q %+v %v", name, profile, err)
+	}
+
+	if err := SetProfileValue("dev", "network", "testnet"); err != nil {
+		t.Fatalf("Failed to set network: %v", err)
+	}
+	if err := SetProfileValue("dev", "soroban_url", "http://localhost:8000/rpc"); err != nil {
+		t.Fatalf("Failed to set soroban_url: %v", err)
+	}
+	if err := SetProfileValue("dev", "colour", "blue"); err == nil {
+		t.Error("Expected error for unknown setting")
+	}
+
+	if got, _ := ProfileValue("dev", "soroban_url"); got != "http://localhost:8000/rpc" {
+		t.Errorf("Expected soroban_url to round-trip, got %q", got)
+	}
+	if _, err := ProfileValue("prod", "network"); err == nil {
+		t.Error("Expected error for missing profile")
+	}
+
+	if err := UseProfile("prod"); err == nil {
+		t.Error("Expected error using missing profile")
+	}
+	if err := UseProfile("dev"); err != nil {
+		t.Fatalf("Failed to use profile: %v", err)
+	}
+	name, profile, err := ActiveProfile()
+	if err != nil || name != "dev" || profile.Network != "testnet" {
+		t.Errorf("Expected dev/testnet to be active, got %q %+v %v", name, profile, err)
+	}
+
+	t.Setenv("ERST_PROFILE", "missing")
+	if _, _, err := ActiveProfile(); err == nil {
+		t.Error("Expected error for missing ERST_PROFILE")
+	}
+}
+
+func TestProfileTokenEncrypted(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("USERPROFILE", tmpDir)
+	defer func(n int) { keystoreIterations = n }(keystoreIterations)
+	keystoreIterations = 1000
+
+	if err := SetProfileValue("dev", "rpc_token", "s3cret-token"); !errors.Is(err, errs.ErrValidationFailed) {
+		t.Errorf("Expected SetProfileValue to refuse rpc_token, got %v", err)
+	}
+	if err := SetProfileToken("dev", "s3cret-token", "pw"); err != nil {
+		t.Fatalf("Failed to set token: %v", err)
+	}
+	if _, err := ProfileValue("dev", "network"); err != nil {
+		t.Errorf("Expected SetProfileToken to create the profile, got %v", err)
+	}
+	if got, _ := ProfileValue("dev", "rpc_token"); got != "********oken" {
+		t.Errorf("Expected the masked token, got %q", got)
+	}
+
+	creds, err := LoadCredentials()
+	if err != nil {
+		t.Fatalf("Failed to load credentials: %v", err)
+	}
+	if got, err := creds.Token("dev", "pw"); err != nil || got != "s3cret-token" {
+		t.Errorf("Expected token to round-trip, got %q %v", got, err)
+	}
+	if _, err := creds.Token("dev", "wrong"); !errors.Is(err, errs.ErrUnauthorized) {
+		t.Errorf("Expected ErrUnauthorized for a wrong passphrase, got %v", err)
+	}
+	// The ciphertext is bound to the profile it was saved for.
+	creds.Tokens["prod"] = creds.Tokens["dev"]
+	if _, err := creds.Token("prod", "pw"); !errors.Is(err, errs.ErrUnauthorized) {
+		t.Errorf("Expected a moved token not to decrypt, got %v", err)
+	}
+
+	for _, file := range []string{"profiles.json", "credentials.json"} {
+		data, err := os.ReadFile(filepath.Join(tmpDir, ".erst", file))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", file, err)
+		}
+		if strings.Contains(string(data), "s3cret") {
+			t.Errorf("Token must not be written in clear to %s", file)
+		}
+	}
+
+	info, err := os.Stat(filepath.Join(tmpDir, ".erst", "credentials.json"))
+	if err != nil {
+		t.Fatalf("Failed to stat credentials: %v", err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		t.Errorf("Expected credentials permissions 600, got %o", info.Mode().Perm())
+	}
+
+	if err := SetProfileToken("dev", "", ""); err != nil {
+		t.Fatalf("Failed to clear token: %v", err)
+	}
+	if got, _ := ProfileValue("dev", "rpc_token"); got != "" {
+		t.Errorf("Expected token to be cleared, got %q", got)
+	}
+}
+
+func TestMaskSecret(t *testing.T) {
+	if got := MaskSecret("abcdefgh"); got != "****efgh" {
+		t.Errorf("Expected ****efgh, got %q", got)
+	}
+	if got := MaskSecret("abc"); got != "***" {
+		t.Errorf("Expected ***, got %q", got)
+	}
+}
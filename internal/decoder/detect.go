@@ -0,0 +1,287 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// XDRKind names a top-level XDR type DetectXDR can recognize.
+type XDRKind string
+
+const (
+	KindTransactionEnvelope XDRKind = "transaction_envelope"
+	KindTransactionResult   XDRKind = "transaction_result"
+	KindTransactionMeta     XDRKind = "transaction_meta"
+	KindLedgerEntry         XDRKind = "ledger_entry"
+	KindLedgerKey           XDRKind = "ledger_key"
+	KindDiagnosticEvent     XDRKind = "diagnostic_event"
+	KindScVal               XDRKind = "sc_val"
+)
+
+// xdrKinds lists the kinds in the order DetectXDR prefers them when a blob
+// decodes as more than one, most structured first.
+var xdrKinds = []XDRKind{
+	KindTransactionEnvelope,
+	KindTransactionMeta,
+	KindTransactionResult,
+	KindLedgerEntry,
+	KindLedgerKey,
+	KindDiagnosticEvent,
+	KindScVal,
+}
+
+// DetectedXDR is a blob decoded as the kind DetectXDR settled on. Value is
+// the raw xdr value and View its human-readable, JSON-serializable form.
+// Candidates lists every kind the blob decoded as, preferred first.
+type DetectedXDR struct {
+	Kind       XDRKind     `json:"kind"`
+	Candidates []XDRKind   `json:"candidates,omitempty"`
+	Value      interface{} `json:"-"`
+	View       interface{} `json:"value"`
+}
+
+// DetectXDR decodes a base64 blob without being told its type. A kind
+// matches when the blob decodes as it with no bytes left over and
+// re-encodes to the same bytes, which rules out nearly all accidental
+// matches.
+func DetectXDR(b64 string) (*DetectedXDR, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(b64))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64: %w", err)
+	}
+
+	var found *DetectedXDR
+	for _, kind := range xdrKinds {
+		d, err := decodeKind(raw, kind)
+		if err != nil {
+			continue
+		}
+		if found == nil {
+			found = d
+		}
+		found.Candidates = append(found.Candidates, kind)
+	}
+	if found == nil {
+		return nil, fmt.Errorf("blob is not a recognized XDR type")
+	}
+	return found, nil
+}
+
+// DecodeXDRAs decodes a base64 blob as the given kind.
+func DecodeXDRAs(b64 string, kind XDRKind) (*DetectedXDR, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(b64))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base64: %w", err)
+	}
+	d, err := decodeKind(raw, kind)
+	if err != nil {
+		return nil, err
+	}
+	d.Candidates = []XDRKind{kind}
+	return d, nil
+}
+
+// ParseXDRKind accepts a kind in snake_case or kebab-case, as well as the
+// short names envelope, result, meta, entry, key, event and scval.
+func ParseXDRKind(s string) (XDRKind, error) {
+	name := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(s)), "-", "_")
+	switch name {
+	case "envelope", "tx", "transaction":
+		return KindTransactionEnvelope, nil
+	case "result":
+		return KindTransactionResult, nil
+	case "meta":
+		return KindTransactionMeta, nil
+	case "entry":
+		return KindLedgerEntry, nil
+	case "key":
+		return KindLedgerKey, nil
+	case "event":
+		return KindDiagnosticEvent, nil
+	case "scval":
+		return KindScVal, nil
+	}
+	for _, k := range xdrKinds {
+		if string(k) == name {
+			return k, nil
+		}
+	}
+	return "", fmt.Errorf("unknown XDR type %q", s)
+}
+
+func decodeKind(raw []byte, kind XDRKind) (*DetectedXDR, error) {
+	var (
+		value interface{}
+		view  interface{}
+	)
+	switch kind {
+	case KindTransactionEnvelope:
+		var env xdr.TransactionEnvelope
+		if err := decodeExact(raw, &env); err != nil {
+			return nil, err
+		}
+		d, err := DescribeEnvelope(env)
+		if err != nil {
+			return nil, err
+		}
+		value, view = &env, d
+
+	case KindTransactionResult:
+		var res xdr.TransactionResult
+		if err := decodeExact(raw, &res); err != nil {
+			return nil, err
+		}
+		value, view = &res, DescribeResult(res)
+
+	case KindTransactionMeta:
+		var meta xdr.TransactionMeta
+		if err := decodeExact(raw, &meta); err != nil {
+			return nil, err
+		}
+		d, err := DescribeMeta(meta)
+		if err != nil {
+			return nil, err
+		}
+		value, view = &meta, d
+
+	case KindLedgerEntry:
+		var entry xdr.LedgerEntry
+		if err := decodeExact(raw, &entry); err != nil {
+			return nil, err
+		}
+		v := map[string]interface{}{
+			"type":                 enumName(entry.Data.Type.String(), "LedgerEntryType"),
+			"last_modified_ledger": uint32(entry.LastModifiedLedgerSeq),
+			"fields":               ledgerEntryFields(entry),
+		}
+		if key, err := entry.LedgerKey(); err == nil {
+			v["key"] = describeLedgerKey(key)
+		}
+		value, view = &entry, v
+
+	case KindLedgerKey:
+		var key xdr.LedgerKey
+		if err := decodeExact(raw, &key); err != nil {
+			return nil, err
+		}
+		value, view = &key, map[string]interface{}{
+			"type": enumName(key.Type.String(), "LedgerEntryType"),
+			"key":  describeLedgerKey(key),
+		}
+
+	case KindDiagnosticEvent:
+		var event xdr.DiagnosticEvent
+		if err := decodeExact(raw, &event); err != nil {
+			return nil, err
+		}
+		value, view = &event, DecodeDiagnosticEvent(event)
+
+	case KindScVal:
+		var val xdr.ScVal
+		if err := decodeExact(raw, &val); err != nil {
+			return nil, err
+		}
+		value, view = &val, map[string]interface{}{
+			"type":  enumName(val.Type.String(), "ScValTypeScv"),
+			"value": FormatScVal(val),
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown XDR type %q", kind)
+	}
+	return &DetectedXDR{Kind: kind, Value: value, View: view}, nil
+}
+
+// decodeExact decodes raw into v, requiring every byte to be consumed and
+// the value to re-encode to exactly raw.
+func decodeExact(raw []byte, v interface {
+	UnmarshalBinary([]byte) error
+	MarshalBinary() ([]byte, error)
+}) error {
+	if err := xdr.SafeUnmarshal(raw, v); err != nil {
+		return err
+	}
+	out, err := v.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(out, raw) {
+		return fmt.Errorf("value does not re-encode to its input")
+	}
+	return nil
+}
+
+// FormatDetected renders d as indented JSON or, for "text", as an indented
+// outline of the same fields.
+func FormatDetected(d *DetectedXDR, format string) (string, error) {
+	switch format {
+	case "", "json":
+		out, err := json.MarshalIndent(d, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		return string(out), nil
+	case "text":
+		// Going through JSON applies the views' field names and omissions.
+		b, err := json.Marshal(d.View)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		var generic interface{}
+		if err := json.Unmarshal(b, &generic); err != nil {
+			return "", fmt.Errorf("failed to unmarshal JSON: %w", err)
+		}
+		var sb strings.Builder
+		sb.WriteString(string(d.Kind) + "\n")
+		writeOutline(&sb, generic, "  ")
+		return sb.String(), nil
+	}
+	return "", fmt.Errorf("unsupported format: %s", format)
+}
+
+func writeOutline(sb *strings.Builder, v interface{}, indent string) {
+	switch x := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if isScalar(x[k]) {
+				fmt.Fprintf(sb, "%s%s: %v\n", indent, k, x[k])
+				continue
+			}
+			fmt.Fprintf(sb, "%s%s:\n", indent, k)
+			writeOutline(sb, x[k], indent+"  ")
+		}
+	case []interface{}:
+		for i, item := range x {
+			if isScalar(item) {
+				fmt.Fprintf(sb, "%s- %v\n", indent, item)
+				continue
+			}
+			fmt.Fprintf(sb, "%s[%d]\n", indent, i)
+			writeOutline(sb, item, indent+"  ")
+		}
+	default:
+		fmt.Fprintf(sb, "%s%v\n", indent, x)
+	}
+}
+
+func isScalar(v interface{}) bool {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		return false
+	}
+	return true
+}
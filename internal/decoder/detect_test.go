@@ -0,0 +1,133 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/network"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectXDR(t *testing.T) {
+	source := keypair.MustRandom()
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &txnbuild.SimpleAccount{AccountID: source.Address(), Sequence: 1},
+		IncrementSequenceNum: true,
+		BaseFee:              txnbuild.MinBaseFee,
+		Preconditions:        txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+		Operations:           []txnbuild.Operation{&txnbuild.BumpSequence{BumpTo: 10}},
+	})
+	require.NoError(t, err)
+	tx, err = tx.Sign(network.TestNetworkPassphrase, source)
+	require.NoError(t, err)
+	envelope, err := tx.Base64()
+	require.NoError(t, err)
+
+	entry := accountEntry(source.Address(), 5_0000000, 2)
+	key, err := entry.LedgerKey()
+	require.NoError(t, err)
+	sym := xdr.ScSymbol("hello")
+	ops := []xdr.OperationResult{}
+
+	mustB64 := func(v interface{}) string {
+		s, err := xdr.MarshalBase64(v)
+		require.NoError(t, err)
+		return s
+	}
+	for kind, b64 := range map[XDRKind]string{
+		KindTransactionEnvelope: envelope,
+		KindTransactionResult: mustB64(xdr.TransactionResult{
+			FeeCharged: 100,
+			Result:     xdr.TransactionResultResult{Code: xdr.TransactionResultCodeTxSuccess, Results: &ops},
+		}),
+		KindTransactionMeta: mustB64(xdr.TransactionMeta{V: 2, V2: &xdr.TransactionMetaV2{
+			TxChangesBefore: xdr.LedgerEntryChanges{{Type: xdr.LedgerEntryChangeTypeLedgerEntryState, State: &entry}},
+		}}),
+		KindLedgerEntry: mustB64(entry),
+		KindLedgerKey:   mustB64(key),
+		KindScVal:       mustB64(xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym}),
+	} {
+		d, err := DetectXDR(b64)
+		require.NoError(t, err, kind)
+		assert.Equal(t, kind, d.Kind)
+		assert.Contains(t, d.Candidates, kind)
+		assert.NotNil(t, d.Value)
+
+		_, err = FormatDetected(d, "json")
+		assert.NoError(t, err)
+		_, err = FormatDetected(d, "text")
+		assert.NoError(t, err)
+	}
+}
+
+func TestDetectXDR_Views(t *testing.T) {
+	addr := keypair.MustRandom().Address()
+	b64, err := xdr.MarshalBase64(accountEntry(addr, 5_0000000, 2))
+	require.NoError(t, err)
+
+	d, err := DetectXDR(b64)
+	require.NoError(t, err)
+	out, err := FormatDetected(d, "json")
+	require.NoError(t, err)
+
+	var parsed struct {
+		Kind  string                 `json:"kind"`
+		Value map[string]interface{} `json:"value"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(out), &parsed))
+	assert.Equal(t, "ledger_entry", parsed.Kind)
+	assert.Equal(t, "account:"+addr, parsed.Value["key"])
+
+	text, err := FormatDetected(d, "text")
+	require.NoError(t, err)
+	assert.Contains(t, text, "ledger_entry\n")
+	assert.Contains(t, text, "  fields:\n")
+	assert.Contains(t, text, "    balance: 5.0000000\n")
+	assert.Contains(t, text, "  type: account\n")
+
+	_, err = FormatDetected(d, "yaml")
+	assert.Error(t, err)
+}
+
+func TestDetectXDR_Invalid(t *testing.T) {
+	_, err := DetectXDR("not base64!")
+	assert.Error(t, err)
+	_, err = DetectXDR("AAAA/w==")
+	assert.Error(t, err)
+}
+
+func TestDecodeXDRAs(t *testing.T) {
+	n := xdr.Uint32(7)
+	b64, err := xdr.MarshalBase64(xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: &n})
+	require.NoError(t, err)
+
+	d, err := DecodeXDRAs(b64, KindScVal)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"type": "u32", "value": "7"}, d.View)
+
+	_, err = DecodeXDRAs(b64, KindTransactionEnvelope)
+	assert.Error(t, err)
+}
+
+func TestParseXDRKind(t *testing.T) {
+	for in, want := range map[string]XDRKind{
+		"envelope":     KindTransactionEnvelope,
+		"ledger-entry": KindLedgerEntry,
+		"ledger_key":   KindLedgerKey,
+		"SCVAL":        KindScVal,
+		"meta":         KindTransactionMeta,
+	} {
+		got, err := ParseXDRKind(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+	_, err := ParseXDRKind("bogus")
+	assert.Error(t, err)
+}
@@ -0,0 +1,307 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// DiagnosticEventKind classifies a diagnostic event by what the host emitted it for.
+type DiagnosticEventKind string
+
+const (
+	DiagnosticKindFnCall     DiagnosticEventKind = "fn_call"
+	DiagnosticKindFnReturn   DiagnosticEventKind = "fn_return"
+	DiagnosticKindLog        DiagnosticEventKind = "log"
+	DiagnosticKindError      DiagnosticEventKind = "error"
+	DiagnosticKindContract   DiagnosticEventKind = "contract"
+	DiagnosticKindSystem     DiagnosticEventKind = "system"
+	DiagnosticKindDiagnostic DiagnosticEventKind = "diagnostic"
+)
+
+// HostError is the decoded form of an ScError carried by an error event.
+type HostError struct {
+	Type         string  `json:"type"`
+	Code         string  `json:"code,omitempty"`
+	ContractCode *uint32 `json:"contract_code,omitempty"`
+}
+
+// String renders the error the same way the Soroban host does, e.g. Error(Contract, #5).
+func (e HostError) String() string {
+	if e.ContractCode != nil {
+		return fmt.Sprintf("Error(%s, #%d)", e.Type, *e.ContractCode)
+	}
+	return fmt.Sprintf("Error(%s, %s)", e.Type, e.Code)
+}
+
+// DiagnosticEvent is a structured, human-readable view of an xdr.DiagnosticEvent.
+type DiagnosticEvent struct {
+	Kind                     DiagnosticEventKind `json:"kind"`
+	ContractID               string              `json:"contract_id,omitempty"`
+	Function                 string              `json:"function,omitempty"`
+	InSuccessfulContractCall bool                `json:"in_successful_contract_call"`
+	Topics                   []string            `json:"topics"`
+	Data                     string              `json:"data,omitempty"`
+	Error                    *HostError          `json:"error,omitempty"`
+}
+
+// String returns a one-line summary suitable for terminal output.
+func (e DiagnosticEvent) String() string {
+	var b strings.Builder
+	b.WriteString("[" + string(e.Kind) + "]")
+	if e.ContractID != "" {
+		b.WriteString(" " + e.ContractID)
+	}
+	if e.Function != "" {
+		b.WriteString(" " + e.Function)
+	}
+	if e.Error != nil {
+		b.WriteString(" " + e.Error.String())
+	}
+	if e.Data != "" {
+		b.WriteString(": " + e.Data)
+	}
+	return b.String()
+}
+
+// DecodeDiagnosticEvent converts a single xdr.DiagnosticEvent into its structured form.
+func DecodeDiagnosticEvent(diag xdr.DiagnosticEvent) DiagnosticEvent {
+	out := DiagnosticEvent{
+		InSuccessfulContractCall: diag.InSuccessfulContractCall,
+		Topics:                   make([]string, 0),
+	}
+
+	if diag.Event.ContractId != nil {
+		if id, err := strkey.Encode(strkey.VersionByteContract, diag.Event.ContractId[:]); err == nil {
+			out.ContractID = id
+		}
+	}
+
+	switch diag.Event.Type {
+	case xdr.ContractEventTypeContract:
+		out.Kind = DiagnosticKindContract
+	case xdr.ContractEventTypeSystem:
+		out.Kind = DiagnosticKindSystem
+	default:
+		out.Kind = DiagnosticKindDiagnostic
+	}
+
+	body := diag.Event.Body.V0
+	if body == nil {
+		return out
+	}
+
+	for _, topic := range body.Topics {
+		out.Topics = append(out.Topics, FormatScVal(topic))
+	}
+	out.Data = FormatScVal(body.Data)
+
+	if diag.Event.Type != xdr.ContractEventTypeDiagnostic || len(body.Topics) == 0 {
+		return out
+	}
+
+	marker, ok := body.Topics[0].GetSym()
+	if !ok {
+		return out
+	}
+
+	switch string(marker) {
+	case "fn_call":
+		// topics: ["fn_call", Bytes(contract id), Symbol(function)]
+		out.Kind = DiagnosticKindFnCall
+		if len(body.Topics) > 1 {
+			if raw, ok := body.Topics[1].GetBytes(); ok && len(raw) == 32 {
+				if id, err := strkey.Encode(strkey.VersionByteContract, raw); err == nil {
+					out.ContractID = id
+				}
+			}
+		}
+		if len(body.Topics) > 2 {
+			out.Function = FormatScVal(body.Topics[2])
+		}
+	case "fn_return":
+		// topics: ["fn_return", Symbol(function)]
+		out.Kind = DiagnosticKindFnReturn
+		if len(body.Topics) > 1 {
+			out.Function = FormatScVal(body.Topics[1])
+		}
+	case "log":
+		out.Kind = DiagnosticKindLog
+	case "error":
+		// topics: ["error", Error(type, code)]
+		out.Kind = DiagnosticKindError
+		if len(body.Topics) > 1 {
+			if scErr, ok := body.Topics[1].GetError(); ok {
+				hostErr := decodeHostError(scErr)
+				out.Error = &hostErr
+			}
+		}
+	}
+
+	return out
+}
+
+// DecodeDiagnosticEventsXDR decodes a list of base64-encoded DiagnosticEvent XDR
+// blobs, as returned in the "events" field of simulateTransaction.
+func DecodeDiagnosticEventsXDR(eventsXdr []string) ([]DiagnosticEvent, error) {
+	out := make([]DiagnosticEvent, 0, len(eventsXdr))
+	for i, eventStr := range eventsXdr {
+		data, err := base64.StdEncoding.DecodeString(eventStr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode base64 event %d: %w", i, err)
+		}
+		var diag xdr.DiagnosticEvent
+		if err := xdr.SafeUnmarshal(data, &diag); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal XDR event %d: %w", i, err)
+		}
+		out = append(out, DecodeDiagnosticEvent(diag))
+	}
+	return out, nil
+}
+
+// DiagnosticEventsFromMetaXDR extracts and decodes the diagnostic events embedded in
+// a base64-encoded TransactionMeta, as returned by getTransaction and Horizon.
+// Metas that predate Soroban yield an empty slice.
+func DiagnosticEventsFromMetaXDR(metaXdr string) ([]DiagnosticEvent, error) {
+	if metaXdr == "" {
+		return []DiagnosticEvent{}, nil
+	}
+
+	var meta xdr.TransactionMeta
+	if err := xdr.SafeUnmarshalBase64(metaXdr, &meta); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal transaction meta: %w", err)
+	}
+
+	var raw []xdr.DiagnosticEvent
+	switch meta.V {
+	case 3:
+		if meta.V3 != nil && meta.V3.SorobanMeta != nil {
+			raw = meta.V3.SorobanMeta.DiagnosticEvents
+		}
+	case 4:
+		if meta.V4 != nil {
+			raw = meta.V4.DiagnosticEvents
+		}
+	}
+
+	out := make([]DiagnosticEvent, 0, len(raw))
+	for _, diag := range raw {
+		out = append(out, DecodeDiagnosticEvent(diag))
+	}
+	return out, nil
+}
+
+// ErrorEvents returns only the host error events, in emission order.
+func ErrorEvents(events []DiagnosticEvent) []DiagnosticEvent {
+	var out []DiagnosticEvent
+	for _, e := range events {
+		if e.Kind == DiagnosticKindError {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// SummarizeFailure explains why an invocation failed, using the first error event
+// and the innermost contract call that was active when it was raised. It returns
+// an empty string when the events contain no error.
+func SummarizeFailure(events []DiagnosticEvent) string {
+	var stack []DiagnosticEvent
+	for _, e := range events {
+		switch e.Kind {
+		case DiagnosticKindFnCall:
+			stack = append(stack, e)
+		case DiagnosticKindFnReturn:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		case DiagnosticKindError:
+			var b strings.Builder
+			if len(stack) > 0 {
+				call := stack[len(stack)-1]
+				fmt.Fprintf(&b, "contract %s function %s failed: ", call.ContractID, call.Function)
+			} else if e.ContractID != "" {
+				fmt.Fprintf(&b, "contract %s failed: ", e.ContractID)
+			}
+			if e.Error != nil {
+				b.WriteString(e.Error.String())
+			} else {
+				b.WriteString("host error")
+			}
+			if e.Data != "" {
+				b.WriteString(": " + e.Data)
+			}
+			return b.String()
+		}
+	}
+	return ""
+}
+
+func decodeHostError(scErr xdr.ScError) HostError {
+	out := HostError{
+		Type: strings.TrimPrefix(scErr.Type.String(), "ScErrorTypeSce"),
+	}
+	if scErr.Type == xdr.ScErrorTypeSceContract {
+		if scErr.ContractCode != nil {
+			code := uint32(*scErr.ContractCode)
+			out.ContractCode = &code
+		}
+		return out
+	}
+	if scErr.Code != nil {
+		out.Code = strings.TrimPrefix(scErr.Code.String(), "ScErrorCodeScec")
+	}
+	return out
+}
+
+// FormatScVal renders an ScVal as a compact human-readable string. Strings are
+// quoted, errors use the host's Error(type, code) notation, and containers are
+// expanded recursively.
+func FormatScVal(val xdr.ScVal) string {
+	switch val.Type {
+	case xdr.ScValTypeScvString:
+		if val.Str != nil {
+			return fmt.Sprintf("%q", string(*val.Str))
+		}
+	case xdr.ScValTypeScvError:
+		if val.Error != nil {
+			return decodeHostError(*val.Error).String()
+		}
+	case xdr.ScValTypeScvVec:
+		if val.Vec != nil && *val.Vec != nil {
+			parts := make([]string, 0, len(**val.Vec))
+			for _, item := range **val.Vec {
+				parts = append(parts, FormatScVal(item))
+			}
+			return "[" + strings.Join(parts, ", ") + "]"
+		}
+		return "[]"
+	case xdr.ScValTypeScvMap:
+		if val.Map != nil && *val.Map != nil {
+			parts := make([]string, 0, len(**val.Map))
+			for _, entry := range **val.Map {
+				parts = append(parts, FormatScVal(entry.Key)+": "+FormatScVal(entry.Val))
+			}
+			return "{" + strings.Join(parts, ", ") + "}"
+		}
+		return "{}"
+	}
+	return safeScValString(val)
+}
+
+// safeScValString guards xdr.ScVal.String against malformed values whose
+// union arm pointer is unset.
+func safeScValString(val xdr.ScVal) (s string) {
+	defer func() {
+		if r := recover(); r != nil {
+			s = val.Type.String()
+		}
+	}()
+	return val.String()
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func symVal(s string) xdr.ScVal {
+	sym := xdr.ScSymbol(s)
+	return xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym}
+}
+
+func strVal(s string) xdr.ScVal {
+	str := xdr.ScString(s)
+	return xdr.ScVal{Type: xdr.ScValTypeScvString, Str: &str}
+}
+
+func diagEvent(topics []xdr.ScVal, data xdr.ScVal) xdr.DiagnosticEvent {
+	return xdr.DiagnosticEvent{
+		Event: xdr.ContractEvent{
+			Type: xdr.ContractEventTypeDiagnostic,
+			Body: xdr.ContractEventBody{
+				V:  0,
+				V0: &xdr.ContractEventV0{Topics: topics, Data: data},
+			},
+		},
+	}
+}
+
+func encodeDiag(t *testing.T, diag xdr.DiagnosticEvent) string {
+	t.Helper()
+	raw, err := diag.MarshalBinary()
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+func failedTransferEvents(t *testing.T) []string {
+	t.Helper()
+	contract := xdr.ScBytes(make([]byte, 32))
+	code := xdr.Uint32(5)
+	scErr := xdr.ScError{Type: xdr.ScErrorTypeSceContract, ContractCode: &code}
+
+	return []string{
+		encodeDiag(t, diagEvent(
+			[]xdr.ScVal{symVal("fn_call"), {Type: xdr.ScValTypeScvBytes, Bytes: &contract}, symVal("transfer")},
+			xdr.ScVal{Type: xdr.ScValTypeScvVoid},
+		)),
+		encodeDiag(t, diagEvent(
+			[]xdr.ScVal{symVal("error"), {Type: xdr.ScValTypeScvError, Error: &scErr}},
+			strVal("balance is not sufficient"),
+		)),
+	}
+}
+
+func TestDecodeDiagnosticEventsXDR(t *testing.T) {
+	events, err := DecodeDiagnosticEventsXDR(failedTransferEvents(t))
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	call := events[0]
+	assert.Equal(t, DiagnosticKindFnCall, call.Kind)
+	assert.Equal(t, "transfer", call.Function)
+	assert.Equal(t, 'C', rune(call.ContractID[0]))
+
+	errEvent := events[1]
+	assert.Equal(t, DiagnosticKindError, errEvent.Kind)
+	require.NotNil(t, errEvent.Error)
+	assert.Equal(t, "Error(Contract, #5)", errEvent.Error.String())
+	assert.Equal(t, `"balance is not sufficient"`, errEvent.Data)
+}
+
+func TestDecodeDiagnosticEventsXDR_InvalidInput(t *testing.T) {
+	_, err := DecodeDiagnosticEventsXDR([]string{"not-base64!"})
+	assert.Error(t, err)
+
+	_, err = DecodeDiagnosticEventsXDR([]string{base64.StdEncoding.EncodeToString([]byte{0x01})})
+	assert.Error(t, err)
+}
+
+func TestDecodeDiagnosticEvent_HostErrorCode(t *testing.T) {
+	code := xdr.ScErrorCodeScecMissingValue
+	scErr := xdr.ScError{Type: xdr.ScErrorTypeSceStorage, Code: &code}
+	ev := DecodeDiagnosticEvent(diagEvent(
+		[]xdr.ScVal{symVal("error"), {Type: xdr.ScValTypeScvError, Error: &scErr}},
+		xdr.ScVal{Type: xdr.ScValTypeScvVoid},
+	))
+
+	require.NotNil(t, ev.Error)
+	assert.Equal(t, "Error(Storage, MissingValue)", ev.Error.String())
+}
+
+func TestSummarizeFailure(t *testing.T) {
+	events, err := DecodeDiagnosticEventsXDR(failedTransferEvents(t))
+	require.NoError(t, err)
+
+	summary := SummarizeFailure(events)
+	assert.Contains(t, summary, "function transfer failed")
+	assert.Contains(t, summary, "Error(Contract, #5)")
+	assert.Contains(t, summary, "balance is not sufficient")
+
+	assert.Empty(t, SummarizeFailure(events[:1]))
+	assert.Len(t, ErrorEvents(events), 1)
+}
+
+func TestDiagnosticEventsFromMetaXDR(t *testing.T) {
+	diag := diagEvent([]xdr.ScVal{symVal("log")}, strVal("hello"))
+	meta := xdr.TransactionMeta{
+		V: 3,
+		V3: &xdr.TransactionMetaV3{
+			SorobanMeta: &xdr.SorobanTransactionMeta{
+				ReturnValue:      xdr.ScVal{Type: xdr.ScValTypeScvVoid},
+				DiagnosticEvents: []xdr.DiagnosticEvent{diag},
+			},
+		},
+	}
+	b64, err := xdr.MarshalBase64(meta)
+	require.NoError(t, err)
+
+	events, err := DiagnosticEventsFromMetaXDR(b64)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, DiagnosticKindLog, events[0].Kind)
+	assert.Equal(t, `[log]: "hello"`, events[0].String())
+
+	empty, err := DiagnosticEventsFromMetaXDR("")
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
@@ -0,0 +1,229 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/stellar/go-stellar-sdk/amount"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// DiffLedgerEntries compares two snapshots of ledger entries, each a map
+// of base64 ledger key to base64 LedgerEntry as returned by
+// getLedgerEntries or stored in a snapshot file. Entries only in before
+// are reported as removed, entries only in after as created, and entries
+// in both as updated when any field differs. Changes are sorted by key.
+func DiffLedgerEntries(before, after map[string]string) ([]EntryChange, error) {
+	old, err := decodeEntries(before)
+	if err != nil {
+		return nil, fmt.Errorf("before: %w", err)
+	}
+	cur, err := decodeEntries(after)
+	if err != nil {
+		return nil, fmt.Errorf("after: %w", err)
+	}
+
+	var out []EntryChange
+	for id, b := range old {
+		b := b
+		if a, ok := cur[id]; ok {
+			if c := newEntryChange("updated", &b, &a); len(c.Diff) > 0 {
+				out = append(out, c)
+			}
+			continue
+		}
+		out = append(out, newEntryChange("removed", &b, nil))
+	}
+	for id, a := range cur {
+		a := a
+		if _, ok := old[id]; !ok {
+			out = append(out, newEntryChange("created", nil, &a))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out, nil
+}
+
+// decodeEntries decodes a snapshot, keying each entry by its own ledger
+// key so that snapshots from different sources line up.
+func decodeEntries(m map[string]string) (map[string]xdr.LedgerEntry, error) {
+	out := make(map[string]xdr.LedgerEntry, len(m))
+	for key, b64 := range m {
+		var e xdr.LedgerEntry
+		if err := xdr.SafeUnmarshalBase64(b64, &e); err != nil {
+			return nil, fmt.Errorf("entry for key %s: %w", key, err)
+		}
+		id, err := entryID(e)
+		if err != nil {
+			return nil, fmt.Errorf("entry for key %s: %w", key, err)
+		}
+		out[id] = e
+	}
+	return out, nil
+}
+
+// Changes returns every entry change in the meta in the order the ledger
+// applied them: before the operations, per operation, then after.
+func (d *DecodedMeta) Changes() []EntryChange {
+	out := append([]EntryChange(nil), d.TxChangesBefore...)
+	for _, op := range d.Operations {
+		out = append(out, op.Changes...)
+	}
+	return append(out, d.TxChangesAfter...)
+}
+
+// FormatEntryChanges renders changes as text, one entry per block. Created
+// and removed entries list all their fields; updated ones only those that
+// changed.
+func FormatEntryChanges(changes []EntryChange) string {
+	var sb strings.Builder
+	for _, c := range changes {
+		marker := "~"
+		switch c.Change {
+		case "created", "restored":
+			marker = "+"
+		case "removed":
+			marker = "-"
+		}
+		fmt.Fprintf(&sb, "%s %s %s (%s)\n", marker, c.EntryType, c.Key, c.Change)
+
+		switch {
+		case c.Before == nil && c.After != nil:
+			writeFields(&sb, "+", c.After)
+		case c.After == nil && c.Before != nil:
+			writeFields(&sb, "-", c.Before)
+		default:
+			for _, d := range c.Diff {
+				line := fmt.Sprintf("    %s: %v -> %v", d.Field, orNone(d.Before), orNone(d.After))
+				if d.Delta != "" {
+					line += " (" + d.Delta + ")"
+				}
+				sb.WriteString(line + "\n")
+			}
+		}
+	}
+	return sb.String()
+}
+
+func writeFields(sb *strings.Builder, marker string, fields map[string]interface{}) {
+	names := make([]string, 0, len(fields))
+	for k := range fields {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		fmt.Fprintf(sb, "  %s %s: %v\n", marker, k, fields[k])
+	}
+}
+
+func orNone(v interface{}) interface{} {
+	if v == nil {
+		return "(none)"
+	}
+	return v
+}
+
+// contractValueDiffs breaks a change to a contract data map value down
+// into its keys, so a storage update reads as "value.balance: 5 -> 7"
+// rather than two whole maps.
+func contractValueDiffs(prefix string, before, after xdr.ScVal) []FieldDiff {
+	bm, bok := before.GetMap()
+	am, aok := after.GetMap()
+	if !bok || !aok || bm == nil || am == nil {
+		if FormatScVal(before) == FormatScVal(after) {
+			return nil
+		}
+		return []FieldDiff{{Field: prefix, Before: FormatScVal(before), After: FormatScVal(after)}}
+	}
+
+	type pair struct{ before, after *xdr.ScVal }
+	keys := map[string]*pair{}
+	var names []string
+	for _, side := range []struct {
+		m      *xdr.ScMap
+		before bool
+	}{{bm, true}, {am, false}} {
+		for i := range *side.m {
+			e := &(*side.m)[i]
+			name := FormatScVal(e.Key)
+			p, ok := keys[name]
+			if !ok {
+				p = &pair{}
+				keys[name] = p
+				names = append(names, name)
+			}
+			if side.before {
+				p.before = &e.Val
+			} else {
+				p.after = &e.Val
+			}
+		}
+	}
+	sort.Strings(names)
+
+	var out []FieldDiff
+	for _, name := range names {
+		p, field := keys[name], prefix+"."+name
+		switch {
+		case p.before == nil:
+			out = append(out, FieldDiff{Field: field, After: FormatScVal(*p.after)})
+		case p.after == nil:
+			out = append(out, FieldDiff{Field: field, Before: FormatScVal(*p.before)})
+		default:
+			out = append(out, contractValueDiffs(field, *p.before, *p.after)...)
+		}
+	}
+	return out
+}
+
+// delta returns after - before, signed, for integer fields and for
+// amounts rendered with seven decimals, or "" for anything else.
+func delta(before, after interface{}) string {
+	b, bok := numericField(before)
+	a, aok := numericField(after)
+	if !bok || !aok {
+		return ""
+	}
+	d := a.n - b.n
+	if d == 0 {
+		return ""
+	}
+	sign := "+"
+	if d < 0 {
+		sign = ""
+	}
+	if a.amount {
+		return sign + amount.StringFromInt64(d)
+	}
+	return fmt.Sprintf("%s%d", sign, d)
+}
+
+type numeric struct {
+	n      int64
+	amount bool
+}
+
+func numericField(v interface{}) (numeric, bool) {
+	switch x := v.(type) {
+	case int64:
+		return numeric{n: x}, true
+	case uint32:
+		return numeric{n: int64(x)}, true
+	case int:
+		return numeric{n: int64(x)}, true
+	case string:
+		if !strings.Contains(x, ".") {
+			return numeric{}, false
+		}
+		n, err := amount.ParseInt64(x)
+		if err != nil {
+			return numeric{}, false
+		}
+		return numeric{n: n, amount: true}, true
+	}
+	return numeric{}, false
+}
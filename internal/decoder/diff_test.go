@@ -0,0 +1,158 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func snapshotOf(t *testing.T, entries ...xdr.LedgerEntry) map[string]string {
+	t.Helper()
+	m := make(map[string]string, len(entries))
+	for _, e := range entries {
+		key, err := e.LedgerKey()
+		require.NoError(t, err)
+		k, err := key.MarshalBinaryBase64()
+		require.NoError(t, err)
+		v, err := xdr.MarshalBase64(e)
+		require.NoError(t, err)
+		m[k] = v
+	}
+	return m
+}
+
+func storageEntry(key string, val xdr.ScVal) xdr.LedgerEntry {
+	sym := xdr.ScSymbol(key)
+	return xdr.LedgerEntry{Data: xdr.LedgerEntryData{
+		Type: xdr.LedgerEntryTypeContractData,
+		ContractData: &xdr.ContractDataEntry{
+			Contract:   xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &xdr.ContractId{1}},
+			Key:        xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym},
+			Durability: xdr.ContractDataDurabilityPersistent,
+			Val:        val,
+		},
+	}}
+}
+
+func ttlEntry(hash byte, liveUntil uint32) xdr.LedgerEntry {
+	return xdr.LedgerEntry{Data: xdr.LedgerEntryData{
+		Type: xdr.LedgerEntryTypeTtl,
+		Ttl:  &xdr.TtlEntry{KeyHash: xdr.Hash{hash}, LiveUntilLedgerSeq: xdr.Uint32(liveUntil)},
+	}}
+}
+
+func symbolMap(pairs ...interface{}) xdr.ScVal {
+	m := xdr.ScMap{}
+	for i := 0; i < len(pairs); i += 2 {
+		sym := xdr.ScSymbol(pairs[i].(string))
+		v := xdr.Uint32(pairs[i+1].(int))
+		m = append(m, xdr.ScMapEntry{
+			Key: xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym},
+			Val: xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: &v},
+		})
+	}
+	p := &m
+	return xdr.ScVal{Type: xdr.ScValTypeScvMap, Map: &p}
+}
+
+func TestDiffLedgerEntries(t *testing.T) {
+	alice, bob := keypair.MustRandom().Address(), keypair.MustRandom().Address()
+	before := snapshotOf(t,
+		accountEntry(alice, 50_0000000, 3),
+		accountEntry(bob, 5_0000000, 1),
+		ttlEntry(1, 100),
+	)
+	after := snapshotOf(t,
+		accountEntry(alice, 45_5000000, 4),
+		ttlEntry(1, 200),
+		ttlEntry(2, 300),
+	)
+
+	changes, err := DiffLedgerEntries(before, after)
+	require.NoError(t, err)
+	require.Len(t, changes, 4)
+
+	byKey := map[string]EntryChange{}
+	for _, c := range changes {
+		byKey[c.Key] = c
+	}
+	a := byKey["account:"+alice]
+	assert.Equal(t, "updated", a.Change)
+	assert.Equal(t, []FieldDiff{
+		{Field: "balance", Before: "50.0000000", After: "45.5000000", Delta: "-4.5000000"},
+		{Field: "seq_num", Before: int64(3), After: int64(4), Delta: "+1"},
+	}, a.Diff)
+	assert.Equal(t, "removed", byKey["account:"+bob].Change)
+
+	var ttls []EntryChange
+	for _, c := range changes {
+		if c.EntryType == "ttl" {
+			ttls = append(ttls, c)
+		}
+	}
+	require.Len(t, ttls, 2)
+	assert.Equal(t, "updated", ttls[0].Change)
+	assert.Equal(t, "+100", ttls[0].Diff[0].Delta)
+	assert.Equal(t, "created", ttls[1].Change)
+}
+
+func TestDiffLedgerEntries_Unchanged(t *testing.T) {
+	snap := snapshotOf(t, ttlEntry(1, 100))
+	changes, err := DiffLedgerEntries(snap, snap)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	_, err = DiffLedgerEntries(map[string]string{"k": "not xdr"}, nil)
+	assert.Error(t, err)
+}
+
+func TestDiffLedgerEntries_StorageValues(t *testing.T) {
+	before := snapshotOf(t, storageEntry("State", symbolMap("count", 1, "limit", 10, "old", 3)))
+	after := snapshotOf(t, storageEntry("State", symbolMap("count", 2, "limit", 10, "new", 4)))
+
+	changes, err := DiffLedgerEntries(before, after)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+
+	var fields []string
+	for _, d := range changes[0].Diff {
+		fields = append(fields, d.Field)
+	}
+	assert.Equal(t, []string{"value.count", "value.new", "value.old"}, fields)
+	assert.Nil(t, changes[0].Diff[1].Before)
+	assert.Nil(t, changes[0].Diff[2].After)
+}
+
+func TestDecodedMetaChanges(t *testing.T) {
+	d := &DecodedMeta{
+		TxChangesBefore: []EntryChange{{Key: "a"}},
+		Operations:      []DecodedOperationMeta{{Changes: []EntryChange{{Key: "b"}}}, {Changes: []EntryChange{{Key: "c"}}}},
+		TxChangesAfter:  []EntryChange{{Key: "d"}},
+	}
+	var keys []string
+	for _, c := range d.Changes() {
+		keys = append(keys, c.Key)
+	}
+	assert.Equal(t, []string{"a", "b", "c", "d"}, keys)
+}
+
+func TestFormatEntryChanges(t *testing.T) {
+	alice := keypair.MustRandom().Address()
+	changes, err := DiffLedgerEntries(
+		snapshotOf(t, accountEntry(alice, 50_0000000, 3)),
+		snapshotOf(t, accountEntry(alice, 60_0000000, 3), ttlEntry(1, 100)),
+	)
+	require.NoError(t, err)
+
+	out := FormatEntryChanges(changes)
+	assert.Contains(t, out, "~ account account:"+alice+" (updated)\n")
+	assert.Contains(t, out, "    balance: 50.0000000 -> 60.0000000 (+10.0000000)\n")
+	assert.Contains(t, out, "+ ttl ")
+	assert.Contains(t, out, "  + live_until_ledger_seq: 100\n")
+}
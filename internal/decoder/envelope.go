@@ -4,35 +4,118 @@
 package decoder
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
+	"github.com/stellar/go-stellar-sdk/amount"
 	"github.com/stellar/go-stellar-sdk/xdr"
 )
 
+// DecodedEnvelope is a human-readable, JSON-serializable view of a
+// transaction envelope. Amounts are decimal strings, assets use the
+// CODE:ISSUER form and Soroban values are rendered with FormatScVal.
 type DecodedEnvelope struct {
-	Type       string
-	Source     string
-	Fee        int64
-	Operations []xdr.Operation
-	InnerTx    *DecodedEnvelope // for FeeBump
+	Type           string                `json:"type"`
+	Source         string                `json:"source"`
+	Fee            int64                 `json:"fee"`
+	SequenceNumber int64                 `json:"sequence_number,omitempty"`
+	Memo           *DecodedMemo          `json:"memo,omitempty"`
+	Preconditions  *DecodedPreconditions `json:"preconditions,omitempty"`
+	Operations     []DecodedOperation    `json:"operations,omitempty"`
+	Signatures     []DecodedSignature    `json:"signatures,omitempty"`
+	SorobanData    *DecodedSorobanData   `json:"soroban_data,omitempty"`
+	InnerTx        *DecodedEnvelope      `json:"inner_tx,omitempty"` // for FeeBump
 }
 
+// DecodedMemo is a transaction memo. Hash memos are hex encoded.
+type DecodedMemo struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// DecodedPreconditions are the conditions under which a transaction is
+// valid. Zero bounds are unbounded.
+type DecodedPreconditions struct {
+	MinTime         uint64   `json:"min_time,omitempty"`
+	MaxTime         uint64   `json:"max_time,omitempty"`
+	MinLedger       uint32   `json:"min_ledger,omitempty"`
+	MaxLedger       uint32   `json:"max_ledger,omitempty"`
+	MinSeqNum       *int64   `json:"min_seq_num,omitempty"`
+	MinSeqAge       uint64   `json:"min_seq_age,omitempty"`
+	MinSeqLedgerGap uint32   `json:"min_seq_ledger_gap,omitempty"`
+	ExtraSigners    []string `json:"extra_signers,omitempty"`
+}
+
+// DecodedOperation is a single operation. Type is the snake_case operation
+// name, such as "payment" or "invoke_host_function", and Details holds its
+// fields.
+type DecodedOperation struct {
+	Type          string                 `json:"type"`
+	SourceAccount string                 `json:"source_account,omitempty"`
+	Details       map[string]interface{} `json:"details,omitempty"`
+}
+
+// DecodedSignature is a decorated signature: the hex hint of the signing
+// key and the base64 signature.
+type DecodedSignature struct {
+	Hint      string `json:"hint"`
+	Signature string `json:"signature"`
+}
+
+// DecodedSorobanData holds the resources and fee a Soroban transaction
+// declares, with its footprint keys rendered by describeLedgerKey.
+type DecodedSorobanData struct {
+	ResourceFee   int64    `json:"resource_fee"`
+	Instructions  uint32   `json:"instructions"`
+	DiskReadBytes uint32   `json:"disk_read_bytes"`
+	WriteBytes    uint32   `json:"write_bytes"`
+	ReadOnly      []string `json:"read_only,omitempty"`
+	ReadWrite     []string `json:"read_write,omitempty"`
+}
+
+// AnalyzeEnvelope decodes a base64 transaction envelope of any type into a
+// DecodedEnvelope.
 func AnalyzeEnvelope(b64 string) (*DecodedEnvelope, error) {
 	var env xdr.TransactionEnvelope
 
 	if err := xdr.SafeUnmarshalBase64(b64, &env); err != nil {
 		return nil, err
 	}
+	return DescribeEnvelope(env)
+}
 
+// DescribeEnvelope is AnalyzeEnvelope for an envelope that is already
+// decoded, for example by DecodeEnvelope.
+func DescribeEnvelope(env xdr.TransactionEnvelope) (*DecodedEnvelope, error) {
 	switch env.Type {
 	case xdr.EnvelopeTypeEnvelopeTypeTxV0:
-		return decodeV0(env.V0.Tx)
+		d, err := decodeV0(env.V0.Tx)
+		if err != nil {
+			return nil, err
+		}
+		d.Signatures = decodeSignatures(env.V0.Signatures)
+		return d, nil
 
 	case xdr.EnvelopeTypeEnvelopeTypeTx:
-		return decodeV1(env.V1.Tx)
+		d, err := decodeV1(env.V1.Tx)
+		if err != nil {
+			return nil, err
+		}
+		d.Signatures = decodeSignatures(env.V1.Signatures)
+		return d, nil
 
 	case xdr.EnvelopeTypeEnvelopeTypeTxFeeBump:
-		return decodeFeeBump(env.FeeBump.Tx)
+		d, err := decodeFeeBump(env.FeeBump.Tx)
+		if err != nil {
+			return nil, err
+		}
+		d.Signatures = decodeSignatures(env.FeeBump.Signatures)
+		return d, nil
 
 	default:
 		return nil, fmt.Errorf("unsupported envelope type: %s", env.Type)
@@ -44,20 +127,45 @@ func decodeV0(tx xdr.TransactionV0) (*DecodedEnvelope, error) {
 		Type:    xdr.PublicKeyTypePublicKeyTypeEd25519,
 		Ed25519: &tx.SourceAccountEd25519,
 	}
-	return &DecodedEnvelope{
-		Type:       "TransactionV0",
-		Source:     source.Address(),
-		Fee:        int64(tx.Fee),
-		Operations: tx.Operations,
-	}, nil
+	ops, err := decodeOperations(tx.Operations)
+	if err != nil {
+		return nil, err
+	}
+	d := &DecodedEnvelope{
+		Type:           "TransactionV0",
+		Source:         source.Address(),
+		Fee:            int64(tx.Fee),
+		SequenceNumber: int64(tx.SeqNum),
+		Memo:           decodeMemo(tx.Memo),
+		Operations:     ops,
+	}
+	if tx.TimeBounds != nil {
+		d.Preconditions = &DecodedPreconditions{
+			MinTime: uint64(tx.TimeBounds.MinTime),
+			MaxTime: uint64(tx.TimeBounds.MaxTime),
+		}
+	}
+	return d, nil
 }
+
 func decodeV1(tx xdr.Transaction) (*DecodedEnvelope, error) {
-	return &DecodedEnvelope{
-		Type:       "TransactionV1",
-		Source:     tx.SourceAccount.Address(),
-		Fee:        int64(tx.Fee),
-		Operations: tx.Operations,
-	}, nil
+	ops, err := decodeOperations(tx.Operations)
+	if err != nil {
+		return nil, err
+	}
+	d := &DecodedEnvelope{
+		Type:           "TransactionV1",
+		Source:         tx.SourceAccount.Address(),
+		Fee:            int64(tx.Fee),
+		SequenceNumber: int64(tx.SeqNum),
+		Memo:           decodeMemo(tx.Memo),
+		Preconditions:  decodePreconditions(tx.Cond),
+		Operations:     ops,
+	}
+	if data, ok := tx.Ext.GetSorobanData(); ok {
+		d.SorobanData = DescribeSorobanData(data)
+	}
+	return d, nil
 }
 
 func decodeFeeBump(fb xdr.FeeBumpTransaction) (*DecodedEnvelope, error) {
@@ -77,8 +185,616 @@ func decodeFeeBump(fb xdr.FeeBumpTransaction) (*DecodedEnvelope, error) {
 func DecodeEnvelopeFromInner(inner xdr.FeeBumpTransactionInnerTx) (*DecodedEnvelope, error) {
 	switch inner.Type {
 	case xdr.EnvelopeTypeEnvelopeTypeTx:
-		return decodeV1(inner.V1.Tx)
+		d, err := decodeV1(inner.V1.Tx)
+		if err != nil {
+			return nil, err
+		}
+		d.Signatures = decodeSignatures(inner.V1.Signatures)
+		return d, nil
 	default:
 		return nil, fmt.Errorf("unsupported inner tx type")
 	}
 }
+
+func decodeMemo(m xdr.Memo) *DecodedMemo {
+	switch m.Type {
+	case xdr.MemoTypeMemoText:
+		return &DecodedMemo{Type: "text", Value: m.MustText()}
+	case xdr.MemoTypeMemoId:
+		return &DecodedMemo{Type: "id", Value: fmt.Sprintf("%d", m.MustId())}
+	case xdr.MemoTypeMemoHash:
+		h := m.MustHash()
+		return &DecodedMemo{Type: "hash", Value: hex.EncodeToString(h[:])}
+	case xdr.MemoTypeMemoReturn:
+		h := m.MustRetHash()
+		return &DecodedMemo{Type: "return", Value: hex.EncodeToString(h[:])}
+	default:
+		return nil
+	}
+}
+
+func decodePreconditions(c xdr.Preconditions) *DecodedPreconditions {
+	switch c.Type {
+	case xdr.PreconditionTypePrecondTime:
+		tb := c.MustTimeBounds()
+		return &DecodedPreconditions{MinTime: uint64(tb.MinTime), MaxTime: uint64(tb.MaxTime)}
+	case xdr.PreconditionTypePrecondV2:
+		v2 := c.MustV2()
+		p := &DecodedPreconditions{
+			MinSeqAge:       uint64(v2.MinSeqAge),
+			MinSeqLedgerGap: uint32(v2.MinSeqLedgerGap),
+		}
+		if v2.TimeBounds != nil {
+			p.MinTime, p.MaxTime = uint64(v2.TimeBounds.MinTime), uint64(v2.TimeBounds.MaxTime)
+		}
+		if v2.LedgerBounds != nil {
+			p.MinLedger, p.MaxLedger = uint32(v2.LedgerBounds.MinLedger), uint32(v2.LedgerBounds.MaxLedger)
+		}
+		if v2.MinSeqNum != nil {
+			seq := int64(*v2.MinSeqNum)
+			p.MinSeqNum = &seq
+		}
+		for k := range v2.ExtraSigners {
+			p.ExtraSigners = append(p.ExtraSigners, v2.ExtraSigners[k].Address())
+		}
+		return p
+	default:
+		return nil
+	}
+}
+
+func decodeSignatures(sigs []xdr.DecoratedSignature) []DecodedSignature {
+	out := make([]DecodedSignature, 0, len(sigs))
+	for _, s := range sigs {
+		out = append(out, DecodedSignature{
+			Hint:      hex.EncodeToString(s.Hint[:]),
+			Signature: base64.StdEncoding.EncodeToString(s.Signature),
+		})
+	}
+	return out
+}
+
+// DescribeSorobanData decodes the resources and footprint of Soroban
+// transaction data, such as the transactionData a simulation returns.
+func DescribeSorobanData(data xdr.SorobanTransactionData) *DecodedSorobanData {
+	res := data.Resources
+	d := &DecodedSorobanData{
+		ResourceFee:   int64(data.ResourceFee),
+		Instructions:  uint32(res.Instructions),
+		DiskReadBytes: uint32(res.DiskReadBytes),
+		WriteBytes:    uint32(res.WriteBytes),
+	}
+	for _, key := range res.Footprint.ReadOnly {
+		d.ReadOnly = append(d.ReadOnly, describeLedgerKey(key))
+	}
+	for _, key := range res.Footprint.ReadWrite {
+		d.ReadWrite = append(d.ReadWrite, describeLedgerKey(key))
+	}
+	return d
+}
+
+func decodeOperations(ops []xdr.Operation) ([]DecodedOperation, error) {
+	out := make([]DecodedOperation, 0, len(ops))
+	for i, op := range ops {
+		details, err := operationDetails(op.Body)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+		d := DecodedOperation{
+			Type:    enumName(op.Body.Type.String(), "OperationType"),
+			Details: details,
+		}
+		if op.SourceAccount != nil {
+			d.SourceAccount = op.SourceAccount.Address()
+		}
+		out = append(out, d)
+	}
+	return out, nil
+}
+
+// operationDetails expands the fields of an operation body. It fails only
+// when the body does not carry the arm its type names.
+func operationDetails(body xdr.OperationBody) (map[string]interface{}, error) {
+	missing := fmt.Errorf("%s has no body", body.Type)
+
+	switch body.Type {
+	case xdr.OperationTypeCreateAccount:
+		op, ok := body.GetCreateAccountOp()
+		if !ok {
+			return nil, missing
+		}
+		return map[string]interface{}{
+			"destination":      op.Destination.Address(),
+			"starting_balance": amount.String(op.StartingBalance),
+		}, nil
+
+	case xdr.OperationTypePayment:
+		op, ok := body.GetPaymentOp()
+		if !ok {
+			return nil, missing
+		}
+		return map[string]interface{}{
+			"destination": op.Destination.Address(),
+			"asset":       op.Asset.StringCanonical(),
+			"amount":      amount.String(op.Amount),
+		}, nil
+
+	case xdr.OperationTypePathPaymentStrictReceive:
+		op, ok := body.GetPathPaymentStrictReceiveOp()
+		if !ok {
+			return nil, missing
+		}
+		return map[string]interface{}{
+			"send_asset":  op.SendAsset.StringCanonical(),
+			"send_max":    amount.String(op.SendMax),
+			"destination": op.Destination.Address(),
+			"dest_asset":  op.DestAsset.StringCanonical(),
+			"dest_amount": amount.String(op.DestAmount),
+			"path":        assetPath(op.Path),
+		}, nil
+
+	case xdr.OperationTypePathPaymentStrictSend:
+		op, ok := body.GetPathPaymentStrictSendOp()
+		if !ok {
+			return nil, missing
+		}
+		return map[string]interface{}{
+			"send_asset":  op.SendAsset.StringCanonical(),
+			"send_amount": amount.String(op.SendAmount),
+			"destination": op.Destination.Address(),
+			"dest_asset":  op.DestAsset.StringCanonical(),
+			"dest_min":    amount.String(op.DestMin),
+			"path":        assetPath(op.Path),
+		}, nil
+
+	case xdr.OperationTypeManageSellOffer:
+		op, ok := body.GetManageSellOfferOp()
+		if !ok {
+			return nil, missing
+		}
+		return map[string]interface{}{
+			"selling":  op.Selling.StringCanonical(),
+			"buying":   op.Buying.StringCanonical(),
+			"amount":   amount.String(op.Amount),
+			"price":    op.Price.String(),
+			"offer_id": int64(op.OfferId),
+		}, nil
+
+	case xdr.OperationTypeManageBuyOffer:
+		op, ok := body.GetManageBuyOfferOp()
+		if !ok {
+			return nil, missing
+		}
+		return map[string]interface{}{
+			"selling":    op.Selling.StringCanonical(),
+			"buying":     op.Buying.StringCanonical(),
+			"buy_amount": amount.String(op.BuyAmount),
+			"price":      op.Price.String(),
+			"offer_id":   int64(op.OfferId),
+		}, nil
+
+	case xdr.OperationTypeCreatePassiveSellOffer:
+		op, ok := body.GetCreatePassiveSellOfferOp()
+		if !ok {
+			return nil, missing
+		}
+		return map[string]interface{}{
+			"selling": op.Selling.StringCanonical(),
+			"buying":  op.Buying.StringCanonical(),
+			"amount":  amount.String(op.Amount),
+			"price":   op.Price.String(),
+		}, nil
+
+	case xdr.OperationTypeSetOptions:
+		op, ok := body.GetSetOptionsOp()
+		if !ok {
+			return nil, missing
+		}
+		details := map[string]interface{}{}
+		if op.InflationDest != nil {
+			details["inflation_dest"] = op.InflationDest.Address()
+		}
+		for name, v := range map[string]*xdr.Uint32{
+			"clear_flags":    op.ClearFlags,
+			"set_flags":      op.SetFlags,
+			"master_weight":  op.MasterWeight,
+			"low_threshold":  op.LowThreshold,
+			"med_threshold":  op.MedThreshold,
+			"high_threshold": op.HighThreshold,
+		} {
+			if v != nil {
+				details[name] = uint32(*v)
+			}
+		}
+		if op.HomeDomain != nil {
+			details["home_domain"] = string(*op.HomeDomain)
+		}
+		if op.Signer != nil {
+			details["signer"] = map[string]interface{}{
+				"key":    op.Signer.Key.Address(),
+				"weight": uint32(op.Signer.Weight),
+			}
+		}
+		return details, nil
+
+	case xdr.OperationTypeChangeTrust:
+		op, ok := body.GetChangeTrustOp()
+		if !ok {
+			return nil, missing
+		}
+		return map[string]interface{}{
+			"line":  describeChangeTrustAsset(op.Line),
+			"limit": amount.String(op.Limit),
+		}, nil
+
+	case xdr.OperationTypeAllowTrust:
+		op, ok := body.GetAllowTrustOp()
+		if !ok {
+			return nil, missing
+		}
+		asset := op.Asset.ToAsset(xdr.AccountId{})
+		return map[string]interface{}{
+			"trustor":   op.Trustor.Address(),
+			"asset":     asset.GetCode(),
+			"authorize": uint32(op.Authorize),
+		}, nil
+
+	case xdr.OperationTypeAccountMerge:
+		dest, ok := body.GetDestination()
+		if !ok {
+			return nil, missing
+		}
+		return map[string]interface{}{"destination": dest.Address()}, nil
+
+	case xdr.OperationTypeManageData:
+		op, ok := body.GetManageDataOp()
+		if !ok {
+			return nil, missing
+		}
+		details := map[string]interface{}{"name": string(op.DataName)}
+		if op.DataValue != nil {
+			details["value"] = dataValue(*op.DataValue)
+		}
+		return details, nil
+
+	case xdr.OperationTypeBumpSequence:
+		op, ok := body.GetBumpSequenceOp()
+		if !ok {
+			return nil, missing
+		}
+		return map[string]interface{}{"bump_to": int64(op.BumpTo)}, nil
+
+	case xdr.OperationTypeCreateClaimableBalance:
+		op, ok := body.GetCreateClaimableBalanceOp()
+		if !ok {
+			return nil, missing
+		}
+		claimants := make([]map[string]interface{}, 0, len(op.Claimants))
+		for _, c := range op.Claimants {
+			if v0, ok := c.GetV0(); ok {
+				claimants = append(claimants, map[string]interface{}{
+					"destination": v0.Destination.Address(),
+					"predicate":   describePredicate(v0.Predicate),
+				})
+			}
+		}
+		return map[string]interface{}{
+			"asset":     op.Asset.StringCanonical(),
+			"amount":    amount.String(op.Amount),
+			"claimants": claimants,
+		}, nil
+
+	case xdr.OperationTypeClaimClaimableBalance:
+		op, ok := body.GetClaimClaimableBalanceOp()
+		if !ok {
+			return nil, missing
+		}
+		return map[string]interface{}{"balance_id": hexXDR(op.BalanceId)}, nil
+
+	case xdr.OperationTypeBeginSponsoringFutureReserves:
+		op, ok := body.GetBeginSponsoringFutureReservesOp()
+		if !ok {
+			return nil, missing
+		}
+		return map[string]interface{}{"sponsored_id": op.SponsoredId.Address()}, nil
+
+	case xdr.OperationTypeEndSponsoringFutureReserves, xdr.OperationTypeInflation, xdr.OperationTypeRestoreFootprint:
+		return nil, nil
+
+	case xdr.OperationTypeRevokeSponsorship:
+		op, ok := body.GetRevokeSponsorshipOp()
+		if !ok {
+			return nil, missing
+		}
+		if key, ok := op.GetLedgerKey(); ok {
+			return map[string]interface{}{"ledger_key": describeLedgerKey(key)}, nil
+		}
+		if s, ok := op.GetSigner(); ok {
+			return map[string]interface{}{
+				"account": s.AccountId.Address(),
+				"signer":  s.SignerKey.Address(),
+			}, nil
+		}
+		return nil, missing
+
+	case xdr.OperationTypeClawback:
+		op, ok := body.GetClawbackOp()
+		if !ok {
+			return nil, missing
+		}
+		return map[string]interface{}{
+			"asset":  op.Asset.StringCanonical(),
+			"from":   op.From.Address(),
+			"amount": amount.String(op.Amount),
+		}, nil
+
+	case xdr.OperationTypeClawbackClaimableBalance:
+		op, ok := body.GetClawbackClaimableBalanceOp()
+		if !ok {
+			return nil, missing
+		}
+		return map[string]interface{}{"balance_id": hexXDR(op.BalanceId)}, nil
+
+	case xdr.OperationTypeSetTrustLineFlags:
+		op, ok := body.GetSetTrustLineFlagsOp()
+		if !ok {
+			return nil, missing
+		}
+		return map[string]interface{}{
+			"trustor":     op.Trustor.Address(),
+			"asset":       op.Asset.StringCanonical(),
+			"clear_flags": uint32(op.ClearFlags),
+			"set_flags":   uint32(op.SetFlags),
+		}, nil
+
+	case xdr.OperationTypeLiquidityPoolDeposit:
+		op, ok := body.GetLiquidityPoolDepositOp()
+		if !ok {
+			return nil, missing
+		}
+		return map[string]interface{}{
+			"liquidity_pool_id": hex.EncodeToString(op.LiquidityPoolId[:]),
+			"max_amount_a":      amount.String(op.MaxAmountA),
+			"max_amount_b":      amount.String(op.MaxAmountB),
+			"min_price":         op.MinPrice.String(),
+			"max_price":         op.MaxPrice.String(),
+		}, nil
+
+	case xdr.OperationTypeLiquidityPoolWithdraw:
+		op, ok := body.GetLiquidityPoolWithdrawOp()
+		if !ok {
+			return nil, missing
+		}
+		return map[string]interface{}{
+			"liquidity_pool_id": hex.EncodeToString(op.LiquidityPoolId[:]),
+			"amount":            amount.String(op.Amount),
+			"min_amount_a":      amount.String(op.MinAmountA),
+			"min_amount_b":      amount.String(op.MinAmountB),
+		}, nil
+
+	case xdr.OperationTypeInvokeHostFunction:
+		op, ok := body.GetInvokeHostFunctionOp()
+		if !ok {
+			return nil, missing
+		}
+		details := hostFunctionDetails(op.HostFunction)
+		if len(op.Auth) > 0 {
+			details["auth_entries"] = len(op.Auth)
+		}
+		return details, nil
+
+	case xdr.OperationTypeExtendFootprintTtl:
+		op, ok := body.GetExtendFootprintTtlOp()
+		if !ok {
+			return nil, missing
+		}
+		return map[string]interface{}{"extend_to": uint32(op.ExtendTo)}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported operation type: %s", body.Type)
+	}
+}
+
+func hostFunctionDetails(fn xdr.HostFunction) map[string]interface{} {
+	details := map[string]interface{}{
+		"function_type": enumName(fn.Type.String(), "HostFunctionTypeHostFunctionType"),
+	}
+	switch fn.Type {
+	case xdr.HostFunctionTypeHostFunctionTypeInvokeContract:
+		if args, ok := fn.GetInvokeContract(); ok {
+			details["contract"] = scAddress(args.ContractAddress)
+			details["function"] = string(args.FunctionName)
+			details["args"] = formatScVals(args.Args)
+		}
+	case xdr.HostFunctionTypeHostFunctionTypeCreateContract:
+		if args, ok := fn.GetCreateContract(); ok {
+			createContractDetails(details, args.ContractIdPreimage, args.Executable)
+		}
+	case xdr.HostFunctionTypeHostFunctionTypeCreateContractV2:
+		if args, ok := fn.GetCreateContractV2(); ok {
+			createContractDetails(details, args.ContractIdPreimage, args.Executable)
+			details["constructor_args"] = formatScVals(args.ConstructorArgs)
+		}
+	case xdr.HostFunctionTypeHostFunctionTypeUploadContractWasm:
+		if wasm, ok := fn.GetWasm(); ok {
+			sum := sha256.Sum256(wasm)
+			details["wasm_hash"] = hex.EncodeToString(sum[:])
+			details["wasm_size"] = len(wasm)
+		}
+	}
+	return details
+}
+
+func createContractDetails(details map[string]interface{}, preimage xdr.ContractIdPreimage, exec xdr.ContractExecutable) {
+	if hash, ok := exec.GetWasmHash(); ok {
+		details["wasm_hash"] = hex.EncodeToString(hash[:])
+	} else {
+		details["executable"] = "stellar_asset"
+	}
+	if from, ok := preimage.GetFromAddress(); ok {
+		details["deployer"] = scAddress(from.Address)
+		details["salt"] = hex.EncodeToString(from.Salt[:])
+	}
+	if asset, ok := preimage.GetFromAsset(); ok {
+		details["asset"] = asset.StringCanonical()
+	}
+}
+
+// describeLedgerKey renders a ledger key as its snake_case type followed
+// by the fields that identify the entry, separated by colons.
+func describeLedgerKey(key xdr.LedgerKey) string {
+	parts := []string{enumName(key.Type.String(), "LedgerEntryType")}
+	switch key.Type {
+	case xdr.LedgerEntryTypeAccount:
+		if k, ok := key.GetAccount(); ok {
+			parts = append(parts, k.AccountId.Address())
+		}
+	case xdr.LedgerEntryTypeTrustline:
+		if k, ok := key.GetTrustLine(); ok {
+			parts = append(parts, k.AccountId.Address(), describeTrustLineAsset(k.Asset))
+		}
+	case xdr.LedgerEntryTypeOffer:
+		if k, ok := key.GetOffer(); ok {
+			parts = append(parts, k.SellerId.Address(), fmt.Sprintf("%d", k.OfferId))
+		}
+	case xdr.LedgerEntryTypeData:
+		if k, ok := key.GetData(); ok {
+			parts = append(parts, k.AccountId.Address(), string(k.DataName))
+		}
+	case xdr.LedgerEntryTypeClaimableBalance:
+		if k, ok := key.GetClaimableBalance(); ok {
+			parts = append(parts, hexXDR(k.BalanceId))
+		}
+	case xdr.LedgerEntryTypeLiquidityPool:
+		if k, ok := key.GetLiquidityPool(); ok {
+			parts = append(parts, hex.EncodeToString(k.LiquidityPoolId[:]))
+		}
+	case xdr.LedgerEntryTypeContractData:
+		if k, ok := key.GetContractData(); ok {
+			parts = append(parts, scAddress(k.Contract), FormatScVal(k.Key),
+				enumName(k.Durability.String(), "ContractDataDurability"))
+		}
+	case xdr.LedgerEntryTypeContractCode:
+		if k, ok := key.GetContractCode(); ok {
+			parts = append(parts, hex.EncodeToString(k.Hash[:]))
+		}
+	case xdr.LedgerEntryTypeTtl:
+		if k, ok := key.GetTtl(); ok {
+			parts = append(parts, hex.EncodeToString(k.KeyHash[:]))
+		}
+	}
+	return strings.Join(parts, ":")
+}
+
+func describeTrustLineAsset(a xdr.TrustLineAsset) string {
+	if id, ok := a.GetLiquidityPoolId(); ok {
+		return "liquidity_pool:" + hex.EncodeToString(id[:])
+	}
+	return a.ToAsset().StringCanonical()
+}
+
+func describeChangeTrustAsset(a xdr.ChangeTrustAsset) string {
+	if pool, ok := a.GetLiquidityPool(); ok {
+		if cp, ok := pool.GetConstantProduct(); ok {
+			return fmt.Sprintf("liquidity_pool(%s, %s, fee %d)",
+				cp.AssetA.StringCanonical(), cp.AssetB.StringCanonical(), cp.Fee)
+		}
+		return "liquidity_pool"
+	}
+	return a.ToAsset().StringCanonical()
+}
+
+// describePredicate renders a claim predicate in call notation, such as
+// and(not(before_absolute_time(1700000000)), unconditional).
+func describePredicate(p xdr.ClaimPredicate) string {
+	switch p.Type {
+	case xdr.ClaimPredicateTypeClaimPredicateUnconditional:
+		return "unconditional"
+	case xdr.ClaimPredicateTypeClaimPredicateAnd, xdr.ClaimPredicateTypeClaimPredicateOr:
+		preds, name := p.AndPredicates, "and"
+		if p.Type == xdr.ClaimPredicateTypeClaimPredicateOr {
+			preds, name = p.OrPredicates, "or"
+		}
+		if preds == nil {
+			return name + "()"
+		}
+		parts := make([]string, 0, len(*preds))
+		for _, sub := range *preds {
+			parts = append(parts, describePredicate(sub))
+		}
+		return name + "(" + strings.Join(parts, ", ") + ")"
+	case xdr.ClaimPredicateTypeClaimPredicateNot:
+		if p.NotPredicate == nil || *p.NotPredicate == nil {
+			return "not()"
+		}
+		return "not(" + describePredicate(**p.NotPredicate) + ")"
+	case xdr.ClaimPredicateTypeClaimPredicateBeforeAbsoluteTime:
+		if p.AbsBefore != nil {
+			return fmt.Sprintf("before_absolute_time(%d)", *p.AbsBefore)
+		}
+	case xdr.ClaimPredicateTypeClaimPredicateBeforeRelativeTime:
+		if p.RelBefore != nil {
+			return fmt.Sprintf("before_relative_time(%d)", *p.RelBefore)
+		}
+	}
+	return p.Type.String()
+}
+
+func assetPath(path []xdr.Asset) []string {
+	out := make([]string, 0, len(path))
+	for _, a := range path {
+		out = append(out, a.StringCanonical())
+	}
+	return out
+}
+
+func formatScVals(vals []xdr.ScVal) []string {
+	out := make([]string, 0, len(vals))
+	for _, v := range vals {
+		out = append(out, FormatScVal(v))
+	}
+	return out
+}
+
+func scAddress(addr xdr.ScAddress) string {
+	s, err := addr.String()
+	if err != nil {
+		return addr.Type.String()
+	}
+	return s
+}
+
+// dataValue returns printable data entry values as text and anything else
+// in base64.
+func dataValue(v xdr.DataValue) string {
+	if utf8.Valid(v) && strings.IndexFunc(string(v), func(r rune) bool { return !unicode.IsPrint(r) }) < 0 {
+		return string(v)
+	}
+	return base64.StdEncoding.EncodeToString(v)
+}
+
+// hexXDR hex encodes the XDR of v, the form Horizon uses for balance IDs.
+func hexXDR(v interface{}) string {
+	s, err := xdr.MarshalHex(v)
+	if err != nil {
+		return ""
+	}
+	return s
+}
+
+// enumName turns a generated XDR enum name such as OperationTypeInvokeHostFunction
+// into invoke_host_function, after removing prefix.
+func enumName(name, prefix string) string {
+	name = strings.TrimPrefix(name, prefix)
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
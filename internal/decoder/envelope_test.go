@@ -0,0 +1,144 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/network"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeEnvelope_FeeBump(t *testing.T) {
+	source, dest, sponsor := keypair.MustRandom(), keypair.MustRandom(), keypair.MustRandom()
+	issuer := keypair.MustRandom().Address()
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &txnbuild.SimpleAccount{AccountID: source.Address(), Sequence: 41},
+		BaseFee:              txnbuild.MinBaseFee,
+		IncrementSequenceNum: true,
+		Memo:                 txnbuild.MemoText("invoice 7"),
+		Preconditions: txnbuild.Preconditions{
+			TimeBounds:   txnbuild.NewTimebounds(100, 200),
+			LedgerBounds: &txnbuild.LedgerBounds{MinLedger: 5},
+		},
+		Operations: []txnbuild.Operation{
+			&txnbuild.Payment{
+				Destination: dest.Address(),
+				Amount:      "12.5",
+				Asset:       txnbuild.CreditAsset{Code: "USDC", Issuer: issuer},
+			},
+			&txnbuild.ManageData{Name: "note", Value: []byte("hello"), SourceAccount: dest.Address()},
+		},
+	})
+	require.NoError(t, err)
+	tx, err = tx.Sign(network.TestNetworkPassphrase, source)
+	require.NoError(t, err)
+	fb, err := txnbuild.NewFeeBumpTransaction(txnbuild.FeeBumpTransactionParams{
+		Inner:      tx,
+		FeeAccount: sponsor.Address(),
+		BaseFee:    1000,
+	})
+	require.NoError(t, err)
+	b64, err := fb.Base64()
+	require.NoError(t, err)
+
+	d, err := AnalyzeEnvelope(b64)
+	require.NoError(t, err)
+	assert.Equal(t, "FeeBumpTransaction", d.Type)
+	assert.Equal(t, sponsor.Address(), d.Source)
+	assert.Empty(t, d.Signatures)
+
+	inner := d.InnerTx
+	require.NotNil(t, inner)
+	assert.Equal(t, int64(42), inner.SequenceNumber)
+	assert.Equal(t, &DecodedMemo{Type: "text", Value: "invoice 7"}, inner.Memo)
+	assert.Equal(t, uint64(200), inner.Preconditions.MaxTime)
+	assert.Equal(t, uint32(5), inner.Preconditions.MinLedger)
+	require.Len(t, inner.Signatures, 1)
+	assert.Len(t, inner.Signatures[0].Hint, 8)
+
+	require.Len(t, inner.Operations, 2)
+	assert.Equal(t, "payment", inner.Operations[0].Type)
+	assert.Equal(t, "USDC:"+issuer, inner.Operations[0].Details["asset"])
+	assert.Equal(t, "12.5000000", inner.Operations[0].Details["amount"])
+	assert.Equal(t, "manage_data", inner.Operations[1].Type)
+	assert.Equal(t, dest.Address(), inner.Operations[1].SourceAccount)
+	assert.Equal(t, "hello", inner.Operations[1].Details["value"])
+
+	out, err := json.Marshal(d)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), `"inner_tx":{"type":"TransactionV1"`)
+}
+
+func TestAnalyzeEnvelope_Soroban(t *testing.T) {
+	source := keypair.MustRandom()
+	var contract xdr.ContractId
+	contract[0] = 1
+	addr := xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &contract}
+	sym := xdr.ScSymbol("balance")
+	n := xdr.Uint32(7)
+	key := xdr.LedgerKey{
+		Type: xdr.LedgerEntryTypeContractData,
+		ContractData: &xdr.LedgerKeyContractData{
+			Contract:   addr,
+			Key:        xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym},
+			Durability: xdr.ContractDataDurabilityPersistent,
+		},
+	}
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount: &txnbuild.SimpleAccount{AccountID: source.Address(), Sequence: 1},
+		BaseFee:       txnbuild.MinBaseFee,
+		Preconditions: txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+		Operations: []txnbuild.Operation{&txnbuild.InvokeHostFunction{
+			HostFunction: xdr.HostFunction{
+				Type: xdr.HostFunctionTypeHostFunctionTypeInvokeContract,
+				InvokeContract: &xdr.InvokeContractArgs{
+					ContractAddress: addr,
+					FunctionName:    "transfer",
+					Args:            []xdr.ScVal{{Type: xdr.ScValTypeScvU32, U32: &n}},
+				},
+			},
+			Ext: xdr.TransactionExt{V: 1, SorobanData: &xdr.SorobanTransactionData{
+				Resources: xdr.SorobanResources{
+					Footprint:    xdr.LedgerFootprint{ReadWrite: []xdr.LedgerKey{key}},
+					Instructions: 1000,
+				},
+				ResourceFee: 500,
+			}},
+		}},
+	})
+	require.NoError(t, err)
+	b64, err := tx.Base64()
+	require.NoError(t, err)
+
+	d, err := AnalyzeEnvelope(b64)
+	require.NoError(t, err)
+	require.Len(t, d.Operations, 1)
+	op := d.Operations[0]
+	assert.Equal(t, "invoke_host_function", op.Type)
+	assert.Equal(t, "invoke_contract", op.Details["function_type"])
+	assert.Equal(t, "transfer", op.Details["function"])
+	assert.Equal(t, []string{"7"}, op.Details["args"])
+	assert.Equal(t, scAddress(addr), op.Details["contract"])
+
+	require.NotNil(t, d.SorobanData)
+	assert.Equal(t, int64(500), d.SorobanData.ResourceFee)
+	assert.Equal(t, uint32(1000), d.SorobanData.Instructions)
+	assert.Equal(t, []string{"contract_data:" + scAddress(addr) + ":balance:persistent"}, d.SorobanData.ReadWrite)
+}
+
+func TestAnalyzeEnvelope_Invalid(t *testing.T) {
+	_, err := AnalyzeEnvelope("not xdr")
+	assert.Error(t, err)
+}
+
+func TestDescribePredicate(t *testing.T) {
+	p := txnbuild.AndPredicate(txnbuild.NotPredicate(txnbuild.BeforeAbsoluteTimePredicate(1700000000)), txnbuild.UnconditionalPredicate)
+	assert.Equal(t, "and(not(before_absolute_time(1700000000)), unconditional)", describePredicate(p))
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stellar/go-stellar-sdk/amount"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// FeeBreakdown splits the fee a transaction declares into the inclusion fee
+// bid for a place in the ledger and, for Soroban transactions, the resource
+// fee, together with the resources it pays for. All fees are in stroops.
+// For a fee bump, MaxFee is the outer fee and the resources are those of the
+// inner transaction.
+type FeeBreakdown struct {
+	MaxFee       int64         `json:"max_fee"`
+	InclusionFee int64         `json:"inclusion_fee"`
+	ResourceFee  int64         `json:"resource_fee"`
+	Resources    *FeeResources `json:"resources,omitempty"`
+	Charged      *ChargedFee   `json:"charged,omitempty"`
+}
+
+// FeeResources are the resource limits a Soroban transaction declares.
+// ReadEntries counts the whole footprint, since read-write entries are read
+// as well as written.
+type FeeResources struct {
+	Instructions    uint32 `json:"instructions"`
+	DiskReadBytes   uint32 `json:"disk_read_bytes"`
+	WriteBytes      uint32 `json:"write_bytes"`
+	ReadEntries     int    `json:"read_entries"`
+	WriteEntries    int    `json:"write_entries"`
+	ArchivedEntries int    `json:"archived_entries,omitempty"`
+}
+
+// ChargedFee is what the network actually took. Total comes from the
+// result; the split between inclusion and resource fees needs the meta for
+// Soroban transactions and Itemized reports whether it is known. Refundable
+// includes Rent, and Refunded is the part of the declared resource fee
+// returned to the fee source.
+type ChargedFee struct {
+	Total         int64 `json:"total"`
+	Itemized      bool  `json:"itemized"`
+	InclusionFee  int64 `json:"inclusion_fee,omitempty"`
+	ResourceFee   int64 `json:"resource_fee,omitempty"`
+	NonRefundable int64 `json:"non_refundable,omitempty"`
+	Refundable    int64 `json:"refundable,omitempty"`
+	Rent          int64 `json:"rent,omitempty"`
+	Refunded      int64 `json:"refunded,omitempty"`
+}
+
+// DecodeFeeBreakdown decodes the fee of a base64 transaction envelope and,
+// when resultB64 is given, compares it with the fee charged. metaB64 is
+// optional and itemizes a Soroban transaction's charged fee.
+func DecodeFeeBreakdown(envelopeB64, resultB64, metaB64 string) (*FeeBreakdown, error) {
+	env, err := decodeEnvelope(envelopeB64)
+	if err != nil {
+		return nil, err
+	}
+	b := DescribeFees(env)
+	if resultB64 == "" {
+		return b, nil
+	}
+
+	var result xdr.TransactionResult
+	if err := xdr.SafeUnmarshalBase64(resultB64, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode result XDR: %w", err)
+	}
+	var meta *xdr.TransactionMeta
+	if metaB64 != "" {
+		meta = &xdr.TransactionMeta{}
+		if err := xdr.SafeUnmarshalBase64(metaB64, meta); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal transaction meta: %w", err)
+		}
+	}
+	b.Charged = chargedFee(b, result, meta)
+	return b, nil
+}
+
+// DescribeFees is DecodeFeeBreakdown for an envelope that is already
+// decoded, without the charged fee.
+func DescribeFees(env xdr.TransactionEnvelope) *FeeBreakdown {
+	b := &FeeBreakdown{MaxFee: int64(env.Fee())}
+	if env.IsFeeBump() {
+		b.MaxFee = env.FeeBumpFee()
+	}
+	if data, ok := envelopeSorobanData(env); ok {
+		res := data.Resources
+		b.ResourceFee = int64(data.ResourceFee)
+		b.Resources = &FeeResources{
+			Instructions:  uint32(res.Instructions),
+			DiskReadBytes: uint32(res.DiskReadBytes),
+			WriteBytes:    uint32(res.WriteBytes),
+			ReadEntries:   len(res.Footprint.ReadOnly) + len(res.Footprint.ReadWrite),
+			WriteEntries:  len(res.Footprint.ReadWrite),
+		}
+		if ext, ok := data.Ext.GetResourceExt(); ok {
+			b.Resources.ArchivedEntries = len(ext.ArchivedSorobanEntries)
+		}
+	}
+	b.InclusionFee = b.MaxFee - b.ResourceFee
+	return b
+}
+
+func envelopeSorobanData(env xdr.TransactionEnvelope) (xdr.SorobanTransactionData, bool) {
+	switch env.Type {
+	case xdr.EnvelopeTypeEnvelopeTypeTx:
+		return env.V1.Tx.Ext.GetSorobanData()
+	case xdr.EnvelopeTypeEnvelopeTypeTxFeeBump:
+		if inner, ok := env.FeeBump.Tx.InnerTx.GetV1(); ok {
+			return inner.Tx.Ext.GetSorobanData()
+		}
+	}
+	return xdr.SorobanTransactionData{}, false
+}
+
+func chargedFee(b *FeeBreakdown, result xdr.TransactionResult, meta *xdr.TransactionMeta) *ChargedFee {
+	c := &ChargedFee{Total: int64(result.FeeCharged)}
+	if b.Resources == nil {
+		c.Itemized = true
+		c.InclusionFee = c.Total
+		return c
+	}
+	if meta == nil {
+		return c
+	}
+	ext, ok := sorobanMetaExt(*meta)
+	if !ok {
+		return c
+	}
+	v1, ok := ext.GetV1()
+	if !ok {
+		return c
+	}
+	c.Itemized = true
+	c.NonRefundable = int64(v1.TotalNonRefundableResourceFeeCharged)
+	c.Refundable = int64(v1.TotalRefundableResourceFeeCharged)
+	c.Rent = int64(v1.RentFeeCharged)
+	c.ResourceFee = c.NonRefundable + c.Refundable
+	c.InclusionFee = c.Total - c.ResourceFee
+	c.Refunded = b.ResourceFee - c.ResourceFee
+	return c
+}
+
+func sorobanMetaExt(meta xdr.TransactionMeta) (xdr.SorobanTransactionMetaExt, bool) {
+	switch meta.V {
+	case 3:
+		if sm := meta.MustV3().SorobanMeta; sm != nil {
+			return sm.Ext, true
+		}
+	case 4:
+		if sm := meta.MustV4().SorobanMeta; sm != nil {
+			return sm.Ext, true
+		}
+	}
+	return xdr.SorobanTransactionMetaExt{}, false
+}
+
+// FormatFeeBreakdown renders b as text, with fees in stroops and XLM.
+func FormatFeeBreakdown(b *FeeBreakdown) string {
+	var sb strings.Builder
+	line := func(name string, stroops int64) {
+		fmt.Fprintf(&sb, "  %-16s %12d stroops (%s XLM)\n", name, stroops, amount.StringFromInt64(stroops))
+	}
+
+	sb.WriteString("Declared\n")
+	line("max fee", b.MaxFee)
+	line("inclusion fee", b.InclusionFee)
+	if r := b.Resources; r != nil {
+		line("resource fee", b.ResourceFee)
+		fmt.Fprintf(&sb, "  %-16s %12d\n", "instructions", r.Instructions)
+		fmt.Fprintf(&sb, "  %-16s %12d\n", "disk read bytes", r.DiskReadBytes)
+		fmt.Fprintf(&sb, "  %-16s %12d\n", "write bytes", r.WriteBytes)
+		fmt.Fprintf(&sb, "  %-16s %12d\n", "read entries", r.ReadEntries)
+		fmt.Fprintf(&sb, "  %-16s %12d\n", "write entries", r.WriteEntries)
+		if r.ArchivedEntries > 0 {
+			fmt.Fprintf(&sb, "  %-16s %12d\n", "restored entries", r.ArchivedEntries)
+		}
+	}
+
+	c := b.Charged
+	if c == nil {
+		return sb.String()
+	}
+	sb.WriteString("Charged\n")
+	line("total", c.Total)
+	if !c.Itemized {
+		sb.WriteString("  (pass the transaction meta to split inclusion and resource fees)\n")
+		return sb.String()
+	}
+	line("inclusion fee", c.InclusionFee)
+	if b.Resources != nil {
+		line("resource fee", c.ResourceFee)
+		line("  non-refundable", c.NonRefundable)
+		line("  refundable", c.Refundable)
+		line("    of which rent", c.Rent)
+		line("refunded", c.Refunded)
+	}
+	return sb.String()
+}
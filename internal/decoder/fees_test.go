@@ -0,0 +1,139 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sorobanFeeTx(t *testing.T) string {
+	t.Helper()
+	var contract xdr.ContractId
+	contract[0] = 1
+	addr := xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &contract}
+	key := xdr.LedgerKey{
+		Type: xdr.LedgerEntryTypeContractData,
+		ContractData: &xdr.LedgerKeyContractData{
+			Contract:   addr,
+			Key:        xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance},
+			Durability: xdr.ContractDataDurabilityPersistent,
+		},
+	}
+	code := xdr.LedgerKey{Type: xdr.LedgerEntryTypeContractCode, ContractCode: &xdr.LedgerKeyContractCode{}}
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount: &txnbuild.SimpleAccount{AccountID: keypair.MustRandom().Address(), Sequence: 1},
+		BaseFee:       txnbuild.MinBaseFee,
+		Preconditions: txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+		Operations: []txnbuild.Operation{&txnbuild.InvokeHostFunction{
+			HostFunction: xdr.HostFunction{
+				Type:           xdr.HostFunctionTypeHostFunctionTypeInvokeContract,
+				InvokeContract: &xdr.InvokeContractArgs{ContractAddress: addr, FunctionName: "bump"},
+			},
+			Ext: xdr.TransactionExt{V: 1, SorobanData: &xdr.SorobanTransactionData{
+				Ext: xdr.SorobanTransactionDataExt{V: 1, ResourceExt: &xdr.SorobanResourcesExtV0{ArchivedSorobanEntries: []xdr.Uint32{0}}},
+				Resources: xdr.SorobanResources{
+					Footprint:     xdr.LedgerFootprint{ReadOnly: []xdr.LedgerKey{code}, ReadWrite: []xdr.LedgerKey{key}},
+					Instructions:  250000,
+					DiskReadBytes: 3000,
+					WriteBytes:    120,
+				},
+				ResourceFee: 50000,
+			}},
+		}},
+	})
+	require.NoError(t, err)
+	b64, err := tx.Base64()
+	require.NoError(t, err)
+	return b64
+}
+
+func feeResult(t *testing.T, charged int64) string {
+	t.Helper()
+	results := []xdr.OperationResult{}
+	b64, err := xdr.MarshalBase64(xdr.TransactionResult{
+		FeeCharged: xdr.Int64(charged),
+		Result:     xdr.TransactionResultResult{Code: xdr.TransactionResultCodeTxSuccess, Results: &results},
+	})
+	require.NoError(t, err)
+	return b64
+}
+
+func TestDecodeFeeBreakdown_Soroban(t *testing.T) {
+	env := sorobanFeeTx(t)
+
+	b, err := DecodeFeeBreakdown(env, "", "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(50000), b.ResourceFee)
+	assert.Equal(t, b.MaxFee-50000, b.InclusionFee)
+	assert.Equal(t, &FeeResources{
+		Instructions:    250000,
+		DiskReadBytes:   3000,
+		WriteBytes:      120,
+		ReadEntries:     2,
+		WriteEntries:    1,
+		ArchivedEntries: 1,
+	}, b.Resources)
+	assert.Nil(t, b.Charged)
+
+	b, err = DecodeFeeBreakdown(env, feeResult(t, 30100), "")
+	require.NoError(t, err)
+	assert.Equal(t, &ChargedFee{Total: 30100}, b.Charged)
+
+	meta, err := xdr.MarshalBase64(xdr.TransactionMeta{V: 3, V3: &xdr.TransactionMetaV3{
+		SorobanMeta: &xdr.SorobanTransactionMeta{
+			ReturnValue: xdr.ScVal{Type: xdr.ScValTypeScvVoid},
+			Ext: xdr.SorobanTransactionMetaExt{V: 1, V1: &xdr.SorobanTransactionMetaExtV1{
+				TotalNonRefundableResourceFeeCharged: 20000,
+				TotalRefundableResourceFeeCharged:    10000,
+				RentFeeCharged:                       4000,
+			}},
+		},
+	}})
+	require.NoError(t, err)
+	b, err = DecodeFeeBreakdown(env, feeResult(t, 30100), meta)
+	require.NoError(t, err)
+	assert.Equal(t, &ChargedFee{
+		Total:         30100,
+		Itemized:      true,
+		InclusionFee:  100,
+		ResourceFee:   30000,
+		NonRefundable: 20000,
+		Refundable:    10000,
+		Rent:          4000,
+		Refunded:      20000,
+	}, b.Charged)
+
+	text := FormatFeeBreakdown(b)
+	assert.Contains(t, text, "instructions")
+	assert.Contains(t, text, "20000 stroops (0.0020000 XLM)")
+}
+
+func TestDecodeFeeBreakdown_Classic(t *testing.T) {
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount: &txnbuild.SimpleAccount{AccountID: keypair.MustRandom().Address(), Sequence: 1},
+		BaseFee:       300,
+		Preconditions: txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+		Operations:    []txnbuild.Operation{&txnbuild.BumpSequence{BumpTo: 5}, &txnbuild.BumpSequence{BumpTo: 6}},
+	})
+	require.NoError(t, err)
+	env, err := tx.Base64()
+	require.NoError(t, err)
+
+	b, err := DecodeFeeBreakdown(env, feeResult(t, 200), "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(600), b.MaxFee)
+	assert.Equal(t, int64(600), b.InclusionFee)
+	assert.Nil(t, b.Resources)
+	assert.Equal(t, &ChargedFee{Total: 200, Itemized: true, InclusionFee: 200}, b.Charged)
+	assert.NotContains(t, FormatFeeBreakdown(b), "resource fee")
+
+	_, err = DecodeFeeBreakdown(env, "not-xdr", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// addFixtureSeeds seeds f with the raw XDR of the testdata/xdr fixtures
+// of the given kind, or of every kind when kind is empty.
+func addFixtureSeeds(f *testing.F, kind string) {
+	f.Helper()
+	paths, err := filepath.Glob(filepath.Join("testdata", "xdr", "*"+kind+".b64"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			f.Fatal(err)
+		}
+		raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+		if err != nil {
+			f.Fatalf("%s: %v", path, err)
+		}
+		f.Add(raw)
+	}
+	f.Add([]byte{})
+	f.Add([]byte{0, 0, 0, 2})
+	f.Add([]byte{0xff, 0xff, 0xff, 0xff})
+}
+
+func FuzzAnalyzeEnvelope(f *testing.F) {
+	addFixtureSeeds(f, ".envelope")
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		_, _ = AnalyzeEnvelope(base64.StdEncoding.EncodeToString(raw))
+	})
+}
+
+func FuzzAnalyzeResult(f *testing.F) {
+	addFixtureSeeds(f, ".result")
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		_, _ = AnalyzeResult(base64.StdEncoding.EncodeToString(raw))
+	})
+}
+
+func FuzzAnalyzeMeta(f *testing.F) {
+	addFixtureSeeds(f, ".meta")
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		b64 := base64.StdEncoding.EncodeToString(raw)
+		if d, err := AnalyzeMeta(b64); err == nil {
+			_ = FormatEntryChanges(d.Changes())
+		}
+		_, _ = DiagnosticEventsFromMetaXDR(b64)
+	})
+}
+
+func FuzzDetectXDR(f *testing.F) {
+	addFixtureSeeds(f, "")
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		b64 := base64.StdEncoding.EncodeToString(raw)
+		d, err := DetectXDR(b64)
+		if err != nil {
+			return
+		}
+		for _, format := range []string{"json", "text"} {
+			if _, err := FormatDetected(d, format); err != nil {
+				t.Fatalf("FormatDetected(%s) failed on a blob detected as %s: %v", format, d.Kind, err)
+			}
+		}
+		if _, err := XDRToJSON(b64, d.Kind); err != nil {
+			t.Fatalf("XDRToJSON failed on a blob detected as %s: %v", d.Kind, err)
+		}
+	})
+}
+
+func FuzzDecodeDiagnosticEvents(f *testing.F) {
+	addFixtureSeeds(f, "")
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		events := []string{base64.StdEncoding.EncodeToString(raw)}
+		_, _ = DecodeDiagnosticEventsXDR(events)
+		_, _ = DecodeEvents(events)
+	})
+}
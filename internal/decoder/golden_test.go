@@ -0,0 +1,85 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// update rewrites the golden files instead of comparing against them:
+//
+//	go test ./internal/decoder -run TestGoldenXDR -update
+var update = flag.Bool("update", false, "rewrite golden files in testdata/golden")
+
+// goldenDecoders decodes a fixture by the kind in its file name,
+// testdata/xdr/<name>.<kind>.b64.
+var goldenDecoders = map[string]func(b64 string) (interface{}, error){
+	"envelope": func(b64 string) (interface{}, error) { return AnalyzeEnvelope(b64) },
+	"result":   func(b64 string) (interface{}, error) { return AnalyzeResult(b64) },
+	"meta": func(b64 string) (interface{}, error) {
+		meta, err := AnalyzeMeta(b64)
+		if err != nil {
+			return nil, err
+		}
+		events, err := DiagnosticEventsFromMetaXDR(b64)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"meta": meta, "diagnostic_events": events}, nil
+	},
+}
+
+// TestGoldenXDR decodes every fixture in testdata/xdr and compares the
+// result with testdata/golden/<name>.<kind>.json.
+func TestGoldenXDR(t *testing.T) {
+	fixtures, err := filepath.Glob(filepath.Join("testdata", "xdr", "*.b64"))
+	require.NoError(t, err)
+	require.NotEmpty(t, fixtures)
+
+	for _, path := range fixtures {
+		name := strings.TrimSuffix(filepath.Base(path), ".b64")
+		t.Run(name, func(t *testing.T) {
+			kind := filepath.Ext(name)
+			decode, ok := goldenDecoders[strings.TrimPrefix(kind, ".")]
+			require.True(t, ok, "fixture %s has unknown kind %q", path, kind)
+
+			raw, err := os.ReadFile(path)
+			require.NoError(t, err)
+			got, err := decode(strings.TrimSpace(string(raw)))
+			require.NoError(t, err)
+			assertGolden(t, name, got)
+		})
+	}
+}
+
+// assertGolden compares got, marshaled as indented JSON, with
+// testdata/golden/<name>.json, rewriting the file when -update is set.
+func assertGolden(t *testing.T, name string, got interface{}) {
+	t.Helper()
+	data, err := json.MarshalIndent(got, "", "  ")
+	require.NoError(t, err)
+	data = append(data, '\n')
+
+	path := filepath.Join("testdata", "golden", name+".json")
+	if *update {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, data, 0o644))
+		return
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err, "missing golden file; run with -update to create it")
+	if !bytes.Equal(want, data) {
+		assert.JSONEq(t, string(want), string(data), "%s differs from decoder output; run with -update if the change is intended", path)
+		assert.Equal(t, string(want), string(data), "%s is not formatted as the decoder writes it", path)
+	}
+}
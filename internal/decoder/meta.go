@@ -0,0 +1,436 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/stellar/go-stellar-sdk/amount"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// DecodedMeta is a JSON-serializable view of a TransactionMeta: the ledger
+// entries the transaction changed, grouped the way the ledger applied them.
+// Diagnostic events are left to DiagnosticEventsFromMetaXDR.
+type DecodedMeta struct {
+	Version         int32                  `json:"version"`
+	TxChangesBefore []EntryChange          `json:"tx_changes_before,omitempty"`
+	Operations      []DecodedOperationMeta `json:"operations"`
+	TxChangesAfter  []EntryChange          `json:"tx_changes_after,omitempty"`
+	Soroban         *DecodedSorobanMeta    `json:"soroban,omitempty"`
+}
+
+// DecodedOperationMeta holds the changes and, from meta version 4 on, the
+// contract events of one operation.
+type DecodedOperationMeta struct {
+	Changes []EntryChange  `json:"changes,omitempty"`
+	Events  []DecodedEvent `json:"events,omitempty"`
+}
+
+// DecodedSorobanMeta holds a Soroban transaction's return value and the
+// resource fees actually charged. Events are only set for meta version 3,
+// which reports them for the whole transaction.
+type DecodedSorobanMeta struct {
+	ReturnValue          string         `json:"return_value,omitempty"`
+	Events               []DecodedEvent `json:"events,omitempty"`
+	NonRefundableFee     int64          `json:"non_refundable_fee,omitempty"`
+	RefundableFeeCharged int64          `json:"refundable_fee_charged,omitempty"`
+	RentFeeCharged       int64          `json:"rent_fee_charged,omitempty"`
+}
+
+// EntryChange is one ledger entry before and after a change. Before is
+// nil for created and restored entries and After is nil for removed ones.
+// Diff lists the fields that differ, sorted by name.
+type EntryChange struct {
+	Change    string                 `json:"change"`
+	EntryType string                 `json:"entry_type"`
+	Key       string                 `json:"key"`
+	Before    map[string]interface{} `json:"before,omitempty"`
+	After     map[string]interface{} `json:"after,omitempty"`
+	Diff      []FieldDiff            `json:"diff,omitempty"`
+}
+
+// FieldDiff is a single field whose value changed. Delta is set for
+// integer and amount fields, such as balances and sequence numbers.
+type FieldDiff struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before,omitempty"`
+	After  interface{} `json:"after,omitempty"`
+	Delta  string      `json:"delta,omitempty"`
+}
+
+// AnalyzeMeta decodes a base64 TransactionMeta, such as Horizon's
+// result_meta_xdr or getTransaction's resultMetaXdr, into a DecodedMeta.
+func AnalyzeMeta(b64 string) (*DecodedMeta, error) {
+	var meta xdr.TransactionMeta
+	if err := xdr.SafeUnmarshalBase64(b64, &meta); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal transaction meta: %w", err)
+	}
+	return DescribeMeta(meta)
+}
+
+// DescribeMeta is AnalyzeMeta for a meta that is already decoded.
+func DescribeMeta(meta xdr.TransactionMeta) (*DecodedMeta, error) {
+	d := &DecodedMeta{Version: meta.V}
+	var err error
+	switch meta.V {
+	case 0:
+		if meta.Operations != nil {
+			d.Operations, err = decodeOperationMetas(*meta.Operations)
+		}
+	case 1:
+		v1 := meta.MustV1()
+		if d.TxChangesBefore, err = DiffLedgerEntryChanges(v1.TxChanges); err == nil {
+			d.Operations, err = decodeOperationMetas(v1.Operations)
+		}
+	case 2:
+		v2 := meta.MustV2()
+		err = d.decodeTxChanges(v2.TxChangesBefore, v2.TxChangesAfter)
+		if err == nil {
+			d.Operations, err = decodeOperationMetas(v2.Operations)
+		}
+	case 3:
+		v3 := meta.MustV3()
+		err = d.decodeTxChanges(v3.TxChangesBefore, v3.TxChangesAfter)
+		if err == nil {
+			d.Operations, err = decodeOperationMetas(v3.Operations)
+		}
+		if sm := v3.SorobanMeta; sm != nil {
+			d.Soroban = decodeSorobanExt(sm.Ext)
+			d.Soroban.ReturnValue = FormatScVal(sm.ReturnValue)
+			d.Soroban.Events = contractEvents(sm.Events)
+		}
+	case 4:
+		v4 := meta.MustV4()
+		err = d.decodeTxChanges(v4.TxChangesBefore, v4.TxChangesAfter)
+		for _, op := range v4.Operations {
+			if err != nil {
+				break
+			}
+			var changes []EntryChange
+			changes, err = DiffLedgerEntryChanges(op.Changes)
+			d.Operations = append(d.Operations, DecodedOperationMeta{Changes: changes, Events: contractEvents(op.Events)})
+		}
+		if sm := v4.SorobanMeta; sm != nil {
+			d.Soroban = decodeSorobanExt(sm.Ext)
+			if sm.ReturnValue != nil {
+				d.Soroban.ReturnValue = FormatScVal(*sm.ReturnValue)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported transaction meta version: %d", meta.V)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (d *DecodedMeta) decodeTxChanges(before, after xdr.LedgerEntryChanges) error {
+	var err error
+	if d.TxChangesBefore, err = DiffLedgerEntryChanges(before); err != nil {
+		return err
+	}
+	d.TxChangesAfter, err = DiffLedgerEntryChanges(after)
+	return err
+}
+
+func decodeOperationMetas(ops []xdr.OperationMeta) ([]DecodedOperationMeta, error) {
+	out := make([]DecodedOperationMeta, 0, len(ops))
+	for i, op := range ops {
+		changes, err := DiffLedgerEntryChanges(op.Changes)
+		if err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+		out = append(out, DecodedOperationMeta{Changes: changes})
+	}
+	return out, nil
+}
+
+func decodeSorobanExt(ext xdr.SorobanTransactionMetaExt) *DecodedSorobanMeta {
+	d := &DecodedSorobanMeta{}
+	if v1, ok := ext.GetV1(); ok {
+		d.NonRefundableFee = int64(v1.TotalNonRefundableResourceFeeCharged)
+		d.RefundableFeeCharged = int64(v1.TotalRefundableResourceFeeCharged)
+		d.RentFeeCharged = int64(v1.RentFeeCharged)
+	}
+	return d
+}
+
+func contractEvents(events []xdr.ContractEvent) []DecodedEvent {
+	out := make([]DecodedEvent, 0, len(events))
+	for _, e := range events {
+		d := DecodedEvent{}
+		if e.ContractId != nil {
+			d.ContractID, _ = strkey.Encode(strkey.VersionByteContract, e.ContractId[:])
+		}
+		if v0, ok := e.Body.GetV0(); ok {
+			d.Topics = formatScVals(v0.Topics)
+			d.Data = FormatScVal(v0.Data)
+		}
+		out = append(out, d)
+	}
+	return out
+}
+
+// DiffLedgerEntryChanges pairs each updated or removed entry with the
+// state that precedes it and reports what changed. State changes only
+// provide the before side and are not reported on their own.
+func DiffLedgerEntryChanges(changes xdr.LedgerEntryChanges) ([]EntryChange, error) {
+	var out []EntryChange
+	states := make(map[string]xdr.LedgerEntry)
+
+	for i, c := range changes {
+		switch c.Type {
+		case xdr.LedgerEntryChangeTypeLedgerEntryState:
+			id, err := entryID(*c.State)
+			if err != nil {
+				return nil, fmt.Errorf("change %d: %w", i, err)
+			}
+			states[id] = *c.State
+
+		case xdr.LedgerEntryChangeTypeLedgerEntryCreated:
+			out = append(out, newEntryChange("created", nil, c.Created))
+
+		case xdr.LedgerEntryChangeTypeLedgerEntryRestored:
+			// Restored entries may be updated or removed in the same
+			// transaction, in which case they are the state for that change.
+			id, err := entryID(*c.Restored)
+			if err != nil {
+				return nil, fmt.Errorf("change %d: %w", i, err)
+			}
+			states[id] = *c.Restored
+			out = append(out, newEntryChange("restored", nil, c.Restored))
+
+		case xdr.LedgerEntryChangeTypeLedgerEntryUpdated:
+			id, err := entryID(*c.Updated)
+			if err != nil {
+				return nil, fmt.Errorf("change %d: %w", i, err)
+			}
+			var before *xdr.LedgerEntry
+			if s, ok := states[id]; ok {
+				before = &s
+			}
+			out = append(out, newEntryChange("updated", before, c.Updated))
+
+		case xdr.LedgerEntryChangeTypeLedgerEntryRemoved:
+			id, err := c.Removed.MarshalBinaryBase64()
+			if err != nil {
+				return nil, fmt.Errorf("change %d: %w", i, err)
+			}
+			if s, ok := states[id]; ok {
+				out = append(out, newEntryChange("removed", &s, nil))
+				continue
+			}
+			out = append(out, EntryChange{
+				Change:    "removed",
+				EntryType: enumName(c.Removed.Type.String(), "LedgerEntryType"),
+				Key:       describeLedgerKey(*c.Removed),
+			})
+
+		default:
+			return nil, fmt.Errorf("change %d: unknown ledger entry change type %d", i, c.Type)
+		}
+	}
+	return out, nil
+}
+
+func entryID(e xdr.LedgerEntry) (string, error) {
+	key, err := e.LedgerKey()
+	if err != nil {
+		return "", err
+	}
+	return key.MarshalBinaryBase64()
+}
+
+func newEntryChange(change string, before, after *xdr.LedgerEntry) EntryChange {
+	entry := after
+	if entry == nil {
+		entry = before
+	}
+	c := EntryChange{
+		Change:    change,
+		EntryType: enumName(entry.Data.Type.String(), "LedgerEntryType"),
+	}
+	if key, err := entry.LedgerKey(); err == nil {
+		c.Key = describeLedgerKey(key)
+	}
+	if before != nil {
+		c.Before = ledgerEntryFields(*before)
+	}
+	if after != nil {
+		c.After = ledgerEntryFields(*after)
+	}
+	c.Diff = diffFields(c.Before, c.After)
+	if before != nil && after != nil && entry.Data.Type == xdr.LedgerEntryTypeContractData {
+		c.Diff = expandValueDiff(c.Diff, before.Data.MustContractData().Val, after.Data.MustContractData().Val)
+	}
+	return c
+}
+
+// diffFields compares rendered entries field by field.
+func diffFields(before, after map[string]interface{}) []FieldDiff {
+	seen := make(map[string]bool, len(before)+len(after))
+	var names []string
+	for _, m := range []map[string]interface{}{before, after} {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				names = append(names, k)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	var out []FieldDiff
+	for _, k := range names {
+		b, inBefore := before[k]
+		a, inAfter := after[k]
+		if inBefore && inAfter && fmt.Sprint(b) == fmt.Sprint(a) {
+			continue
+		}
+		out = append(out, FieldDiff{Field: k, Before: b, After: a, Delta: delta(b, a)})
+	}
+	return out
+}
+
+// expandValueDiff replaces the diff of a contract data value with the
+// per-key diffs of contractValueDiffs.
+func expandValueDiff(diffs []FieldDiff, before, after xdr.ScVal) []FieldDiff {
+	for i, d := range diffs {
+		if d.Field == "value" {
+			out := append(append([]FieldDiff(nil), diffs[:i]...), contractValueDiffs("value", before, after)...)
+			return append(out, diffs[i+1:]...)
+		}
+	}
+	return diffs
+}
+
+// ledgerEntryFields renders the fields of an entry that users reason
+// about, leaving out extensions and the last modified ledger.
+func ledgerEntryFields(e xdr.LedgerEntry) map[string]interface{} {
+	switch e.Data.Type {
+	case xdr.LedgerEntryTypeAccount:
+		a := e.Data.MustAccount()
+		fields := map[string]interface{}{
+			"account_id":      a.AccountId.Address(),
+			"balance":         amount.String(a.Balance),
+			"seq_num":         int64(a.SeqNum),
+			"num_sub_entries": uint32(a.NumSubEntries),
+			"flags":           uint32(a.Flags),
+			"home_domain":     string(a.HomeDomain),
+			"master_weight":   a.Thresholds[0],
+			"low_threshold":   a.Thresholds[1],
+			"med_threshold":   a.Thresholds[2],
+			"high_threshold":  a.Thresholds[3],
+		}
+		if a.InflationDest != nil {
+			fields["inflation_dest"] = a.InflationDest.Address()
+		}
+		signers := make([]string, 0, len(a.Signers))
+		for _, s := range a.Signers {
+			signers = append(signers, fmt.Sprintf("%s:%d", s.Key.Address(), s.Weight))
+		}
+		fields["signers"] = signers
+		if liab := a.Liabilities(); liab.Buying != 0 || liab.Selling != 0 {
+			fields["buying_liabilities"] = amount.String(liab.Buying)
+			fields["selling_liabilities"] = amount.String(liab.Selling)
+		}
+		return fields
+
+	case xdr.LedgerEntryTypeTrustline:
+		t := e.Data.MustTrustLine()
+		fields := map[string]interface{}{
+			"account_id": t.AccountId.Address(),
+			"asset":      describeTrustLineAsset(t.Asset),
+			"balance":    amount.String(t.Balance),
+			"limit":      amount.String(t.Limit),
+			"flags":      uint32(t.Flags),
+		}
+		if liab := t.Liabilities(); liab.Buying != 0 || liab.Selling != 0 {
+			fields["buying_liabilities"] = amount.String(liab.Buying)
+			fields["selling_liabilities"] = amount.String(liab.Selling)
+		}
+		return fields
+
+	case xdr.LedgerEntryTypeOffer:
+		o := e.Data.MustOffer()
+		return map[string]interface{}{
+			"seller_id": o.SellerId.Address(),
+			"offer_id":  int64(o.OfferId),
+			"selling":   o.Selling.StringCanonical(),
+			"buying":    o.Buying.StringCanonical(),
+			"amount":    amount.String(o.Amount),
+			"price":     o.Price.String(),
+			"flags":     uint32(o.Flags),
+		}
+
+	case xdr.LedgerEntryTypeData:
+		d := e.Data.MustData()
+		return map[string]interface{}{
+			"account_id": d.AccountId.Address(),
+			"name":       string(d.DataName),
+			"value":      dataValue(d.DataValue),
+		}
+
+	case xdr.LedgerEntryTypeClaimableBalance:
+		cb := e.Data.MustClaimableBalance()
+		claimants := make([]string, 0, len(cb.Claimants))
+		for _, c := range cb.Claimants {
+			if v0, ok := c.GetV0(); ok {
+				claimants = append(claimants, v0.Destination.Address()+":"+describePredicate(v0.Predicate))
+			}
+		}
+		return map[string]interface{}{
+			"balance_id": hexXDR(cb.BalanceId),
+			"asset":      cb.Asset.StringCanonical(),
+			"amount":     amount.String(cb.Amount),
+			"claimants":  claimants,
+		}
+
+	case xdr.LedgerEntryTypeLiquidityPool:
+		lp := e.Data.MustLiquidityPool()
+		fields := map[string]interface{}{
+			"liquidity_pool_id": hex.EncodeToString(lp.LiquidityPoolId[:]),
+		}
+		if cp, ok := lp.Body.GetConstantProduct(); ok {
+			fields["asset_a"] = cp.Params.AssetA.StringCanonical()
+			fields["asset_b"] = cp.Params.AssetB.StringCanonical()
+			fields["reserve_a"] = amount.String(cp.ReserveA)
+			fields["reserve_b"] = amount.String(cp.ReserveB)
+			fields["total_pool_shares"] = amount.String(cp.TotalPoolShares)
+			fields["trustline_count"] = int64(cp.PoolSharesTrustLineCount)
+		}
+		return fields
+
+	case xdr.LedgerEntryTypeContractData:
+		cd := e.Data.MustContractData()
+		return map[string]interface{}{
+			"contract":   scAddress(cd.Contract),
+			"key":        FormatScVal(cd.Key),
+			"durability": enumName(cd.Durability.String(), "ContractDataDurability"),
+			"value":      FormatScVal(cd.Val),
+		}
+
+	case xdr.LedgerEntryTypeContractCode:
+		cc := e.Data.MustContractCode()
+		return map[string]interface{}{
+			"hash": hex.EncodeToString(cc.Hash[:]),
+			"size": len(cc.Code),
+		}
+
+	case xdr.LedgerEntryTypeTtl:
+		ttl := e.Data.MustTtl()
+		return map[string]interface{}{
+			"key_hash":              hex.EncodeToString(ttl.KeyHash[:]),
+			"live_until_ledger_seq": uint32(ttl.LiveUntilLedgerSeq),
+		}
+
+	default:
+		return map[string]interface{}{"type": e.Data.Type.String()}
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func accountEntry(addr string, balance xdr.Int64, seq xdr.SequenceNumber) xdr.LedgerEntry {
+	return xdr.LedgerEntry{
+		LastModifiedLedgerSeq: 10,
+		Data: xdr.LedgerEntryData{
+			Type: xdr.LedgerEntryTypeAccount,
+			Account: &xdr.AccountEntry{
+				AccountId:  xdr.MustAddress(addr),
+				Balance:    balance,
+				SeqNum:     seq,
+				Thresholds: xdr.Thresholds{1, 0, 0, 0},
+			},
+		},
+	}
+}
+
+func TestDiffLedgerEntryChanges(t *testing.T) {
+	source, holder := keypair.MustRandom().Address(), keypair.MustRandom().Address()
+	before := accountEntry(source, 100_0000000, 7)
+	after := accountEntry(source, 90_0000000, 8)
+	removed := accountEntry(holder, 1_0000000, 1)
+	removedKey, err := removed.LedgerKey()
+	require.NoError(t, err)
+	created := accountEntry(holder, 5_0000000, 0)
+
+	changes, err := DiffLedgerEntryChanges(xdr.LedgerEntryChanges{
+		{Type: xdr.LedgerEntryChangeTypeLedgerEntryState, State: &before},
+		{Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated, Updated: &after},
+		{Type: xdr.LedgerEntryChangeTypeLedgerEntryState, State: &removed},
+		{Type: xdr.LedgerEntryChangeTypeLedgerEntryRemoved, Removed: &removedKey},
+		{Type: xdr.LedgerEntryChangeTypeLedgerEntryCreated, Created: &created},
+	})
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+
+	updated := changes[0]
+	assert.Equal(t, "updated", updated.Change)
+	assert.Equal(t, "account", updated.EntryType)
+	assert.Equal(t, "account:"+source, updated.Key)
+	assert.Equal(t, []FieldDiff{
+		{Field: "balance", Before: "100.0000000", After: "90.0000000", Delta: "-10.0000000"},
+		{Field: "seq_num", Before: int64(7), After: int64(8), Delta: "+1"},
+	}, updated.Diff)
+
+	assert.Equal(t, "removed", changes[1].Change)
+	assert.Nil(t, changes[1].After)
+	assert.NotEmpty(t, changes[1].Diff)
+
+	assert.Equal(t, "created", changes[2].Change)
+	assert.Nil(t, changes[2].Before)
+	assert.Equal(t, "5.0000000", changes[2].After["balance"])
+}
+
+func TestAnalyzeMeta_Soroban(t *testing.T) {
+	source := keypair.MustRandom().Address()
+	before := accountEntry(source, 10_0000000, 1)
+	after := accountEntry(source, 9_0000000, 1)
+	var contract xdr.ContractId
+	contract[0] = 2
+	sym, n := xdr.ScSymbol("transfer"), xdr.Uint32(3)
+	ret := xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: &n}
+
+	meta := xdr.TransactionMeta{V: 4, V4: &xdr.TransactionMetaV4{
+		TxChangesBefore: xdr.LedgerEntryChanges{
+			{Type: xdr.LedgerEntryChangeTypeLedgerEntryState, State: &before},
+			{Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated, Updated: &after},
+		},
+		Operations: []xdr.OperationMetaV2{{Events: []xdr.ContractEvent{{
+			ContractId: &contract,
+			Type:       xdr.ContractEventTypeContract,
+			Body: xdr.ContractEventBody{V0: &xdr.ContractEventV0{
+				Topics: []xdr.ScVal{{Type: xdr.ScValTypeScvSymbol, Sym: &sym}},
+				Data:   ret,
+			}},
+		}}}},
+		SorobanMeta: &xdr.SorobanTransactionMetaV2{
+			ReturnValue: &ret,
+			Ext: xdr.SorobanTransactionMetaExt{V: 1, V1: &xdr.SorobanTransactionMetaExtV1{
+				TotalNonRefundableResourceFeeCharged: 100,
+				RentFeeCharged:                       5,
+			}},
+		},
+	}}
+	b64, err := xdr.MarshalBase64(meta)
+	require.NoError(t, err)
+
+	d, err := AnalyzeMeta(b64)
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), d.Version)
+	require.Len(t, d.TxChangesBefore, 1)
+	assert.Equal(t, []FieldDiff{{Field: "balance", Before: "10.0000000", After: "9.0000000", Delta: "-1.0000000"}}, d.TxChangesBefore[0].Diff)
+
+	require.Len(t, d.Operations, 1)
+	require.Len(t, d.Operations[0].Events, 1)
+	assert.Equal(t, []string{"transfer"}, d.Operations[0].Events[0].Topics)
+	assert.Equal(t, "3", d.Operations[0].Events[0].Data)
+
+	require.NotNil(t, d.Soroban)
+	assert.Equal(t, "3", d.Soroban.ReturnValue)
+	assert.Equal(t, int64(100), d.Soroban.NonRefundableFee)
+	assert.Equal(t, int64(5), d.Soroban.RentFeeCharged)
+
+	_, err = json.Marshal(d)
+	assert.NoError(t, err)
+}
+
+func TestAnalyzeMeta_Invalid(t *testing.T) {
+	_, err := AnalyzeMeta("not meta")
+	assert.Error(t, err)
+}
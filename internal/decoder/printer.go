@@ -5,14 +5,18 @@ package decoder
 
 import (
 	"fmt"
+	"sort"
 
-	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stellar/go-stellar-sdk/strkey"
 )
 
 func PrintEnvelope(d *DecodedEnvelope) {
 	fmt.Println("Transaction Type:", d.Type)
 	fmt.Println("Source Account:", maskAccount(d.Source))
 	fmt.Println("Fee:", d.Fee)
+	if d.Memo != nil {
+		fmt.Printf("Memo: %s %s\n", d.Memo.Type, d.Memo.Value)
+	}
 
 	if len(d.Operations) > 0 {
 		fmt.Println("Operations:")
@@ -27,28 +31,23 @@ func PrintEnvelope(d *DecodedEnvelope) {
 	}
 }
 
-func printOperation(i int, op xdr.Operation) {
-	fmt.Printf("  [%d] %s\n", i, op.Body.Type)
-
-	switch op.Body.Type {
-
-	case xdr.OperationTypeInvokeHostFunction:
-		fmt.Println("      Soroban: Invoke Host Function")
-
-	case xdr.OperationTypeExtendFootprintTtl:
-		fmt.Println("      Soroban: Extend Footprint TTL")
-
-	case xdr.OperationTypeRestoreFootprint:
-		fmt.Println("      Soroban: Restore Footprint")
-
-	case xdr.OperationTypePayment:
-		p := op.Body.PaymentOp
-		fmt.Println("      Payment")
-		fmt.Println("      To:", maskAccount(p.Destination.Address()))
-		fmt.Println("      Amount:", p.Amount)
+func printOperation(i int, op DecodedOperation) {
+	fmt.Printf("  [%d] %s\n", i, op.Type)
+	if op.SourceAccount != "" {
+		fmt.Println("      Source:", maskAccount(op.SourceAccount))
+	}
 
-	default:
-		fmt.Println("      (details omitted)")
+	keys := make([]string, 0, len(op.Details))
+	for k := range op.Details {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := op.Details[k]
+		if s, ok := v.(string); ok && strkey.IsValidEd25519PublicKey(s) {
+			v = maskAccount(s)
+		}
+		fmt.Printf("      %s: %v\n", k, v)
 	}
 }
 
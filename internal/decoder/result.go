@@ -0,0 +1,181 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/stellar/go-stellar-sdk/amount"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// DecodedResult is a JSON-serializable view of a TransactionResult. For
+// fee-bump transactions Code is the outer code, and InnerCode and
+// Operations come from the inner transaction.
+type DecodedResult struct {
+	FeeCharged  int64                    `json:"fee_charged"`
+	Code        string                   `json:"code"`
+	Description string                   `json:"description"`
+	Successful  bool                     `json:"successful"`
+	InnerHash   string                   `json:"inner_hash,omitempty"`
+	InnerCode   string                   `json:"inner_code,omitempty"`
+	Operations  []DecodedOperationResult `json:"operations,omitempty"`
+}
+
+// DecodedOperationResult is the outcome of one operation. Code is the
+// operation specific code, such as payment_underfunded, or the generic
+// op_* code when the operation did not run. Details carries what the
+// operation produced, such as a created offer or claimable balance ID.
+type DecodedOperationResult struct {
+	Type        string                 `json:"type,omitempty"`
+	Code        string                 `json:"code"`
+	Successful  bool                   `json:"successful"`
+	Explanation string                 `json:"explanation,omitempty"`
+	Details     map[string]interface{} `json:"details,omitempty"`
+}
+
+// AnalyzeResult decodes a base64 TransactionResult, such as Horizon's
+// result_xdr, into a DecodedResult.
+func AnalyzeResult(b64 string) (*DecodedResult, error) {
+	var result xdr.TransactionResult
+	if err := xdr.SafeUnmarshalBase64(b64, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal transaction result: %w", err)
+	}
+	return DescribeResult(result), nil
+}
+
+// DescribeResult is AnalyzeResult for a result that is already decoded.
+func DescribeResult(result xdr.TransactionResult) *DecodedResult {
+	code := result.Result.Code
+	info := DecodeTransactionResultCode(code)
+	d := &DecodedResult{
+		FeeCharged:  int64(result.FeeCharged),
+		Code:        info.Code,
+		Description: info.Description,
+		Successful:  result.Successful(),
+	}
+
+	results := result.Result.Results
+	if pair, ok := result.Result.GetInnerResultPair(); ok {
+		d.InnerHash = hex.EncodeToString(pair.TransactionHash[:])
+		d.InnerCode = DecodeTransactionResultCode(pair.Result.Result.Code).Code
+		results = pair.Result.Result.Results
+	}
+	if results != nil {
+		for _, r := range *results {
+			d.Operations = append(d.Operations, decodeOperationResult(r))
+		}
+	}
+	return d
+}
+
+func decodeOperationResult(r xdr.OperationResult) DecodedOperationResult {
+	tr, ok := r.GetTr()
+	if r.Code != xdr.OperationResultCodeOpInner || !ok {
+		info := DecodeOperationResultCode(r.Code)
+		return DecodedOperationResult{Code: info.Code, Explanation: info.Explanation}
+	}
+
+	code := operationResultCode(tr)
+	d := DecodedOperationResult{
+		Type:       enumName(tr.Type.String(), "OperationType"),
+		Code:       code,
+		Successful: strings.HasSuffix(code, "_success"),
+	}
+	switch tr.Type {
+	case xdr.OperationTypeCreateAccount:
+		if res, ok := tr.GetCreateAccountResult(); ok {
+			d.Explanation = DecodeCreateAccountResultCode(res.Code).Explanation
+		}
+	case xdr.OperationTypePayment:
+		if res, ok := tr.GetPaymentResult(); ok {
+			d.Explanation = DecodePaymentResultCode(res.Code).Explanation
+		}
+	}
+	if d.Successful {
+		d.Details = operationResultDetails(tr)
+	}
+	return d
+}
+
+// operationResultCode snake-cases the operation specific result code name,
+// turning PaymentResultCodePaymentUnderfunded into payment_underfunded.
+func operationResultCode(tr xdr.OperationResultTr) (code string) {
+	defer func() {
+		// The arm matching tr.Type may be unset in malformed results.
+		if r := recover(); r != nil {
+			code = enumName(tr.Type.String(), "OperationType") + "_unknown"
+		}
+	}()
+	name, err := tr.MapOperationResultTr()
+	if err != nil {
+		return enumName(tr.Type.String(), "OperationType") + "_unknown"
+	}
+	if i := strings.Index(name, "ResultCode"); i >= 0 {
+		name = name[i+len("ResultCode"):]
+	}
+	return enumName(name, "")
+}
+
+func operationResultDetails(tr xdr.OperationResultTr) map[string]interface{} {
+	switch tr.Type {
+	case xdr.OperationTypeManageSellOffer, xdr.OperationTypeCreatePassiveSellOffer:
+		res := tr.ManageSellOfferResult
+		if tr.Type == xdr.OperationTypeCreatePassiveSellOffer {
+			res = tr.CreatePassiveSellOfferResult
+		}
+		if res != nil && res.Success != nil {
+			return offerResultDetails(*res.Success)
+		}
+	case xdr.OperationTypeManageBuyOffer:
+		if res, ok := tr.GetManageBuyOfferResult(); ok && res.Success != nil {
+			return offerResultDetails(*res.Success)
+		}
+	case xdr.OperationTypePathPaymentStrictReceive:
+		if res, ok := tr.GetPathPaymentStrictReceiveResult(); ok && res.Success != nil {
+			return pathPaymentDetails(res.Success.Offers, res.Success.Last)
+		}
+	case xdr.OperationTypePathPaymentStrictSend:
+		if res, ok := tr.GetPathPaymentStrictSendResult(); ok && res.Success != nil {
+			return pathPaymentDetails(res.Success.Offers, res.Success.Last)
+		}
+	case xdr.OperationTypeAccountMerge:
+		if res, ok := tr.GetAccountMergeResult(); ok && res.SourceAccountBalance != nil {
+			return map[string]interface{}{"merged_balance": amount.String(*res.SourceAccountBalance)}
+		}
+	case xdr.OperationTypeCreateClaimableBalance:
+		if res, ok := tr.GetCreateClaimableBalanceResult(); ok && res.BalanceId != nil {
+			return map[string]interface{}{"balance_id": hexXDR(*res.BalanceId)}
+		}
+	case xdr.OperationTypeInvokeHostFunction:
+		if res, ok := tr.GetInvokeHostFunctionResult(); ok && res.Success != nil {
+			return map[string]interface{}{"result_hash": hex.EncodeToString(res.Success[:])}
+		}
+	}
+	return nil
+}
+
+func offerResultDetails(res xdr.ManageOfferSuccessResult) map[string]interface{} {
+	details := map[string]interface{}{
+		"offer_effect":   enumName(res.Offer.Effect.String(), "ManageOfferEffectManageOffer"),
+		"offers_claimed": len(res.OffersClaimed),
+	}
+	if offer := res.Offer.Offer; offer != nil {
+		details["offer_id"] = int64(offer.OfferId)
+		details["amount"] = amount.String(offer.Amount)
+		details["price"] = offer.Price.String()
+	}
+	return details
+}
+
+func pathPaymentDetails(offers []xdr.ClaimAtom, last xdr.SimplePaymentResult) map[string]interface{} {
+	return map[string]interface{}{
+		"destination":    last.Destination.Address(),
+		"asset":          last.Asset.StringCanonical(),
+		"amount":         amount.String(last.Amount),
+		"offers_claimed": len(offers),
+	}
+}
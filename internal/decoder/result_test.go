@@ -0,0 +1,86 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeResult(t *testing.T) {
+	id := xdr.ClaimableBalanceId{Type: xdr.ClaimableBalanceIdTypeClaimableBalanceIdTypeV0, V0: &xdr.Hash{1}}
+	ops := []xdr.OperationResult{
+		{Code: xdr.OperationResultCodeOpInner, Tr: &xdr.OperationResultTr{
+			Type:          xdr.OperationTypePayment,
+			PaymentResult: &xdr.PaymentResult{Code: xdr.PaymentResultCodePaymentUnderfunded},
+		}},
+		{Code: xdr.OperationResultCodeOpInner, Tr: &xdr.OperationResultTr{
+			Type: xdr.OperationTypeCreateClaimableBalance,
+			CreateClaimableBalanceResult: &xdr.CreateClaimableBalanceResult{
+				Code:      xdr.CreateClaimableBalanceResultCodeCreateClaimableBalanceSuccess,
+				BalanceId: &id,
+			},
+		}},
+		{Code: xdr.OperationResultCodeOpBadAuth},
+	}
+	result := xdr.TransactionResult{
+		FeeCharged: 300,
+		Result:     xdr.TransactionResultResult{Code: xdr.TransactionResultCodeTxFailed, Results: &ops},
+	}
+	b64, err := xdr.MarshalBase64(result)
+	require.NoError(t, err)
+
+	d, err := AnalyzeResult(b64)
+	require.NoError(t, err)
+	assert.Equal(t, "tx_failed", d.Code)
+	assert.False(t, d.Successful)
+	require.Len(t, d.Operations, 3)
+
+	assert.Equal(t, "payment", d.Operations[0].Type)
+	assert.Equal(t, "payment_underfunded", d.Operations[0].Code)
+	assert.False(t, d.Operations[0].Successful)
+	assert.NotEmpty(t, d.Operations[0].Explanation)
+
+	assert.Equal(t, "create_claimable_balance_success", d.Operations[1].Code)
+	assert.True(t, d.Operations[1].Successful)
+	assert.Equal(t, hexXDR(id), d.Operations[1].Details["balance_id"])
+
+	assert.Equal(t, "op_bad_auth", d.Operations[2].Code)
+	assert.Empty(t, d.Operations[2].Type)
+}
+
+func TestAnalyzeResult_FeeBump(t *testing.T) {
+	ops := []xdr.OperationResult{{Code: xdr.OperationResultCodeOpInner, Tr: &xdr.OperationResultTr{
+		Type:          xdr.OperationTypePayment,
+		PaymentResult: &xdr.PaymentResult{Code: xdr.PaymentResultCodePaymentSuccess},
+	}}}
+	result := xdr.TransactionResult{
+		FeeCharged: 200,
+		Result: xdr.TransactionResultResult{
+			Code: xdr.TransactionResultCodeTxFeeBumpInnerSuccess,
+			InnerResultPair: &xdr.InnerTransactionResultPair{
+				TransactionHash: xdr.Hash{0xab},
+				Result: xdr.InnerTransactionResult{
+					Result: xdr.InnerTransactionResultResult{Code: xdr.TransactionResultCodeTxSuccess, Results: &ops},
+				},
+			},
+		},
+	}
+
+	d := DescribeResult(result)
+	assert.True(t, d.Successful)
+	assert.Equal(t, "tx_success", d.InnerCode)
+	assert.Len(t, d.InnerHash, 64)
+	assert.Equal(t, "ab00", d.InnerHash[:4])
+	require.Len(t, d.Operations, 1)
+	assert.True(t, d.Operations[0].Successful)
+}
+
+func TestAnalyzeResult_Invalid(t *testing.T) {
+	_, err := AnalyzeResult("AAAA")
+	assert.Error(t, err)
+}
@@ -0,0 +1,204 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/network"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// TxHash returns the hex hash of a base64 transaction envelope on the
+// network with the given passphrase: the value that signers sign and that
+// Horizon and RPC use as the transaction ID. For a fee bump it is the hash
+// of the outer transaction.
+func TxHash(envelopeB64, networkPassphrase string) (string, error) {
+	env, err := decodeEnvelope(envelopeB64)
+	if err != nil {
+		return "", err
+	}
+	hash, err := network.HashTransactionInEnvelope(env, networkPassphrase)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash transaction: %w", err)
+	}
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// SignatureCheck is the outcome of verifying one decorated signature.
+// Signer is the key that produced it, or empty when no candidate key
+// matches its hint and signature.
+type SignatureCheck struct {
+	Index  int    `json:"index"`
+	Hint   string `json:"hint"`
+	Signer string `json:"signer,omitempty"`
+	Valid  bool   `json:"valid"`
+}
+
+// SignatureReport lists the signatures of one transaction. Unsigned holds
+// the candidate signers with no valid signature. A fee bump's inner
+// transaction has its own report in Inner.
+type SignatureReport struct {
+	Hash       string           `json:"hash"`
+	Signatures []SignatureCheck `json:"signatures"`
+	Unsigned   []string         `json:"unsigned,omitempty"`
+	Inner      *SignatureReport `json:"inner,omitempty"`
+}
+
+// Valid reports whether every signature, including the inner
+// transaction's, was produced by a known signer.
+func (r *SignatureReport) Valid() bool {
+	for _, s := range r.Signatures {
+		if !s.Valid {
+			return false
+		}
+	}
+	return r.Inner == nil || r.Inner.Valid()
+}
+
+// VerifySignatures checks the signatures of a base64 transaction envelope
+// without network access. Each signature is verified against the keys the
+// envelope itself names, namely the transaction and operation source
+// accounts, the fee bump source and any extra signers, plus the given
+// signers, which may be G account keys, X hash signers or P signed
+// payload signers. Keys added to an account as additional signers are
+// only known to be valid when passed in. Pre-authorized transaction
+// signers carry no signature and are not reported.
+func VerifySignatures(envelopeB64, networkPassphrase string, signers ...string) (*SignatureReport, error) {
+	env, err := decodeEnvelope(envelopeB64)
+	if err != nil {
+		return nil, err
+	}
+
+	extra := make([]xdr.SignerKey, 0, len(signers))
+	for _, s := range signers {
+		var key xdr.SignerKey
+		if err := key.SetAddress(s); err != nil {
+			return nil, fmt.Errorf("invalid signer %q: %w", s, err)
+		}
+		extra = append(extra, key)
+	}
+
+	if env.IsFeeBump() {
+		fb := env.MustFeeBump()
+		hash, err := network.HashFeeBumpTransaction(fb.Tx, networkPassphrase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash transaction: %w", err)
+		}
+		outer := verify(hash, fb.Signatures, append([]xdr.SignerKey{muxedSigner(fb.Tx.FeeSource)}, extra...))
+
+		inner := xdr.TransactionEnvelope{Type: xdr.EnvelopeTypeEnvelopeTypeTx, V1: fb.Tx.InnerTx.V1}
+		innerHash, err := network.HashTransactionInEnvelope(inner, networkPassphrase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash inner transaction: %w", err)
+		}
+		outer.Inner = verify(innerHash, inner.Signatures(), append(envelopeSigners(inner), extra...))
+		return outer, nil
+	}
+
+	hash, err := network.HashTransactionInEnvelope(env, networkPassphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash transaction: %w", err)
+	}
+	return verify(hash, env.Signatures(), append(envelopeSigners(env), extra...)), nil
+}
+
+func decodeEnvelope(b64 string) (xdr.TransactionEnvelope, error) {
+	var env xdr.TransactionEnvelope
+	if err := xdr.SafeUnmarshalBase64(b64, &env); err != nil {
+		return env, fmt.Errorf("failed to decode envelope XDR: %w", err)
+	}
+	return env, nil
+}
+
+// envelopeSigners returns the signers a non-fee-bump envelope names.
+func envelopeSigners(env xdr.TransactionEnvelope) []xdr.SignerKey {
+	keys := []xdr.SignerKey{muxedSigner(env.SourceAccount())}
+	for _, op := range env.Operations() {
+		if op.SourceAccount != nil {
+			keys = append(keys, muxedSigner(*op.SourceAccount))
+		}
+	}
+	return append(keys, env.ExtraSigners()...)
+}
+
+func muxedSigner(m xdr.MuxedAccount) xdr.SignerKey {
+	key := m.ToAccountId().Ed25519
+	return xdr.SignerKey{Type: xdr.SignerKeyTypeSignerKeyTypeEd25519, Ed25519: key}
+}
+
+func verify(hash [32]byte, sigs []xdr.DecoratedSignature, candidates []xdr.SignerKey) *SignatureReport {
+	// Deduplicate, keeping first-seen order for Unsigned.
+	var keys []xdr.SignerKey
+	for _, c := range candidates {
+		if c.Type == xdr.SignerKeyTypeSignerKeyTypePreAuthTx {
+			continue
+		}
+		dup := false
+		for _, k := range keys {
+			if k.Equals(c) {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			keys = append(keys, c)
+		}
+	}
+
+	report := &SignatureReport{Hash: hex.EncodeToString(hash[:]), Signatures: make([]SignatureCheck, len(sigs))}
+	signed := make([]bool, len(keys))
+	for i, sig := range sigs {
+		check := SignatureCheck{Index: i, Hint: hex.EncodeToString(sig.Hint[:])}
+		for k, key := range keys {
+			if signatureMatches(hash, key, sig) {
+				check.Signer, check.Valid = key.Address(), true
+				signed[k] = true
+				break
+			}
+		}
+		report.Signatures[i] = check
+	}
+	for k, key := range keys {
+		if !signed[k] {
+			report.Unsigned = append(report.Unsigned, key.Address())
+		}
+	}
+	return report
+}
+
+func signatureMatches(hash [32]byte, key xdr.SignerKey, sig xdr.DecoratedSignature) bool {
+	switch key.Type {
+	case xdr.SignerKeyTypeSignerKeyTypeEd25519:
+		kp, err := keypair.ParseAddress(strkey.MustEncode(strkey.VersionByteAccountID, key.Ed25519[:]))
+		if err != nil || kp.Hint() != sig.Hint {
+			return false
+		}
+		return kp.Verify(hash[:], sig.Signature) == nil
+
+	case xdr.SignerKeyTypeSignerKeyTypeHashX:
+		if !bytes.Equal(sig.Hint[:], key.HashX[len(key.HashX)-4:]) {
+			return false
+		}
+		digest := sha256.Sum256(sig.Signature)
+		return digest == [32]byte(*key.HashX)
+
+	case xdr.SignerKeyTypeSignerKeyTypeEd25519SignedPayload:
+		sp := key.Ed25519SignedPayload
+		kp, err := keypair.ParseAddress(strkey.MustEncode(strkey.VersionByteAccountID, sp.Ed25519[:]))
+		if err != nil {
+			return false
+		}
+		if xdr.NewDecoratedSignatureForPayload(nil, kp.Hint(), sp.Payload).Hint != sig.Hint {
+			return false
+		}
+		return kp.Verify(sp.Payload, sig.Signature) == nil
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/network"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signingTx(t *testing.T, source, opSource *keypair.Full) *txnbuild.Transaction {
+	t.Helper()
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount: &txnbuild.SimpleAccount{AccountID: source.Address(), Sequence: 1},
+		BaseFee:       txnbuild.MinBaseFee,
+		Preconditions: txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+		Operations: []txnbuild.Operation{
+			&txnbuild.BumpSequence{BumpTo: 5, SourceAccount: opSource.Address()},
+		},
+	})
+	require.NoError(t, err)
+	return tx
+}
+
+func TestTxHash(t *testing.T) {
+	source := keypair.MustRandom()
+	tx := signingTx(t, source, source)
+	want, err := tx.HashHex(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+
+	b64, err := tx.Base64()
+	require.NoError(t, err)
+	got, err := TxHash(b64, network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	other, err := TxHash(b64, network.PublicNetworkPassphrase)
+	require.NoError(t, err)
+	assert.NotEqual(t, want, other)
+
+	_, err = TxHash("not xdr", network.TestNetworkPassphrase)
+	assert.Error(t, err)
+}
+
+func TestVerifySignatures(t *testing.T) {
+	source, opSource, stranger := keypair.MustRandom(), keypair.MustRandom(), keypair.MustRandom()
+	tx, err := signingTx(t, source, opSource).Sign(network.TestNetworkPassphrase, source, stranger)
+	require.NoError(t, err)
+	b64, err := tx.Base64()
+	require.NoError(t, err)
+
+	report, err := VerifySignatures(b64, network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	require.Len(t, report.Signatures, 2)
+	assert.True(t, report.Signatures[0].Valid)
+	assert.Equal(t, source.Address(), report.Signatures[0].Signer)
+	assert.False(t, report.Signatures[1].Valid, "stranger is not named by the envelope")
+	assert.Equal(t, []string{opSource.Address()}, report.Unsigned)
+	assert.False(t, report.Valid())
+
+	report, err = VerifySignatures(b64, network.TestNetworkPassphrase, stranger.Address())
+	require.NoError(t, err)
+	assert.True(t, report.Signatures[1].Valid)
+
+	// Signatures made for another network do not verify.
+	report, err = VerifySignatures(b64, network.PublicNetworkPassphrase)
+	require.NoError(t, err)
+	assert.False(t, report.Signatures[0].Valid)
+}
+
+func TestVerifySignaturesHashX(t *testing.T) {
+	source := keypair.MustRandom()
+	preimage := []byte("secret preimage")
+	digest := sha256.Sum256(preimage)
+	signer := strkey.MustEncode(strkey.VersionByteHashX, digest[:])
+
+	tx, err := signingTx(t, source, source).SignHashX(preimage)
+	require.NoError(t, err)
+	b64, err := tx.Base64()
+	require.NoError(t, err)
+
+	report, err := VerifySignatures(b64, network.TestNetworkPassphrase, signer)
+	require.NoError(t, err)
+	assert.Equal(t, signer, report.Signatures[0].Signer)
+	assert.Equal(t, hex.EncodeToString(digest[28:]), report.Signatures[0].Hint)
+}
+
+func TestVerifySignaturesFeeBump(t *testing.T) {
+	source, sponsor := keypair.MustRandom(), keypair.MustRandom()
+	inner, err := signingTx(t, source, source).Sign(network.TestNetworkPassphrase, source)
+	require.NoError(t, err)
+	fb, err := txnbuild.NewFeeBumpTransaction(txnbuild.FeeBumpTransactionParams{
+		Inner:      inner,
+		FeeAccount: sponsor.Address(),
+		BaseFee:    txnbuild.MinBaseFee * 2,
+	})
+	require.NoError(t, err)
+	fb, err = fb.Sign(network.TestNetworkPassphrase, sponsor)
+	require.NoError(t, err)
+	b64, err := fb.Base64()
+	require.NoError(t, err)
+
+	report, err := VerifySignatures(b64, network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	assert.True(t, report.Valid())
+	assert.Equal(t, sponsor.Address(), report.Signatures[0].Signer)
+	require.NotNil(t, report.Inner)
+	assert.Equal(t, source.Address(), report.Inner.Signatures[0].Signer)
+
+	hash, err := fb.HashHex(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, hash, report.Hash)
+}
+
+func TestVerifySignaturesInvalidSigner(t *testing.T) {
+	source := keypair.MustRandom()
+	b64, err := signingTx(t, source, source).Base64()
+	require.NoError(t, err)
+	_, err = VerifySignatures(b64, network.TestNetworkPassphrase, "nope")
+	assert.Error(t, err)
+}
+
+func TestVerifySignaturesSignedPayload(t *testing.T) {
+	source, payloadSigner := keypair.MustRandom(), keypair.MustRandom()
+	payload := []byte("authorize this")
+	sp, err := strkey.NewSignedPayload(payloadSigner.Address(), payload)
+	require.NoError(t, err)
+	signer, err := sp.Encode()
+	require.NoError(t, err)
+
+	sig, err := payloadSigner.SignPayloadDecorated(payload)
+	require.NoError(t, err)
+	tx, err := signingTx(t, source, source).AddSignatureDecorated(sig)
+	require.NoError(t, err)
+	b64, err := tx.Base64()
+	require.NoError(t, err)
+
+	report, err := VerifySignatures(b64, network.TestNetworkPassphrase, signer)
+	require.NoError(t, err)
+	assert.Equal(t, signer, report.Signatures[0].Signer)
+	assert.Equal(t, []string{source.Address()}, report.Unsigned)
+}
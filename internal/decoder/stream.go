@@ -0,0 +1,385 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"bufio"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// StreamSection names the part of a meta structure a StreamItem came from.
+type StreamSection string
+
+const (
+	SectionLedgerHeader             StreamSection = "ledger_header"
+	SectionTxSet                    StreamSection = "tx_set"
+	SectionTxResult                 StreamSection = "tx_result"
+	SectionFeeProcessing            StreamSection = "fee_processing"
+	SectionTxChangesBefore          StreamSection = "tx_changes_before"
+	SectionOperation                StreamSection = "operation"
+	SectionTxChangesAfter           StreamSection = "tx_changes_after"
+	SectionSoroban                  StreamSection = "soroban"
+	SectionEvents                   StreamSection = "events"
+	SectionDiagnosticEvents         StreamSection = "diagnostic_events"
+	SectionPostTxApplyFeeProcessing StreamSection = "post_tx_apply_fee_processing"
+	SectionUpgrades                 StreamSection = "upgrades"
+	SectionEvictedKeys              StreamSection = "evicted_keys"
+)
+
+// StreamItem is one element read from a MetaStream. Exactly one of the
+// pointer fields is set. Transaction is the index of the transaction
+// within the ledger and Operation the index of the operation within the
+// transaction; each is -1 where it does not apply.
+type StreamItem struct {
+	Section     StreamSection
+	Transaction int
+	Operation   int
+
+	Header      *xdr.LedgerHeaderHistoryEntry
+	TxSet       *xdr.GeneralizedTransactionSet
+	Result      *xdr.TransactionResultPair
+	Change      *xdr.LedgerEntryChange
+	Event       *xdr.ContractEvent
+	TxEvent     *xdr.TransactionEvent
+	Diagnostic  *xdr.DiagnosticEvent
+	ReturnValue *xdr.ScVal
+	SorobanExt  *xdr.SorobanTransactionMetaExt
+	Upgrade     *xdr.UpgradeEntryMeta
+	EvictedKey  *xdr.LedgerKey
+}
+
+// MetaStream decodes a TransactionMeta or LedgerCloseMeta one element at a
+// time, so that memory use is bounded by the largest single change or
+// event rather than the whole structure. Call Next until it returns io.EOF.
+type MetaStream struct {
+	r       io.Reader
+	version int32
+	tasks   []task
+	tx, op  int
+}
+
+// A task decodes the next piece of the stream. It either returns an item
+// or schedules further tasks and returns nil.
+type task func(s *MetaStream) (*StreamItem, error)
+
+// NewMetaStream starts decoding a binary TransactionMeta from r.
+func NewMetaStream(r io.Reader) (*MetaStream, error) {
+	s := &MetaStream{r: bufio.NewReader(r), tx: -1, op: -1}
+	v, err := s.int32()
+	if err != nil {
+		return nil, fmt.Errorf("reading meta version: %w", err)
+	}
+	s.version = v
+
+	meta, err := transactionMeta(v)
+	if err != nil {
+		return nil, err
+	}
+	s.plan(meta...)
+	return s, nil
+}
+
+// NewMetaStreamBase64 is NewMetaStream for base64 input, such as
+// getTransaction's resultMetaXdr.
+func NewMetaStreamBase64(r io.Reader) (*MetaStream, error) {
+	return NewMetaStream(base64.NewDecoder(base64.StdEncoding, r))
+}
+
+// NewLedgerMetaStream starts decoding a binary LedgerCloseMeta from r,
+// yielding the header, the transaction set, then for each transaction its
+// result followed by its fee and meta items, and finally upgrades and
+// evicted keys. Versions 1 and 2, which cover every ledger since Soroban,
+// are supported.
+func NewLedgerMetaStream(r io.Reader) (*MetaStream, error) {
+	s := &MetaStream{r: bufio.NewReader(r), tx: -1, op: -1}
+	v, err := s.int32()
+	if err != nil {
+		return nil, fmt.Errorf("reading ledger meta version: %w", err)
+	}
+	s.version = v
+
+	switch v {
+	case 1, 2:
+		tasks := []task{
+			discard(&xdr.LedgerCloseMetaExt{}),
+			func(s *MetaStream) (*StreamItem, error) {
+				var h xdr.LedgerHeaderHistoryEntry
+				return s.item(SectionLedgerHeader, &h, func(it *StreamItem) { it.Header = &h })
+			},
+			func(s *MetaStream) (*StreamItem, error) {
+				var set xdr.GeneralizedTransactionSet
+				return s.item(SectionTxSet, &set, func(it *StreamItem) { it.TxSet = &set })
+			},
+			array(transactionResultMeta(v == 2)),
+			func(s *MetaStream) (*StreamItem, error) {
+				s.tx, s.op = -1, -1
+				return nil, nil
+			},
+			array(func(s *MetaStream) (*StreamItem, error) {
+				var u xdr.UpgradeEntryMeta
+				return s.item(SectionUpgrades, &u, func(it *StreamItem) { it.Upgrade = &u })
+			}),
+			array(discard(&xdr.ScpHistoryEntry{})),
+			discard(new(xdr.Uint64)),
+			array(func(s *MetaStream) (*StreamItem, error) {
+				var k xdr.LedgerKey
+				return s.item(SectionEvictedKeys, &k, func(it *StreamItem) { it.EvictedKey = &k })
+			}),
+		}
+		if v == 1 {
+			tasks = append(tasks, array(discard(&xdr.LedgerEntry{})))
+		}
+		s.plan(tasks...)
+	default:
+		return nil, fmt.Errorf("unsupported ledger close meta version %d", v)
+	}
+	return s, nil
+}
+
+// NewLedgerMetaStreamBase64 is NewLedgerMetaStream for base64 input, such
+// as the metadataXdr of a getLedgers response.
+func NewLedgerMetaStreamBase64(r io.Reader) (*MetaStream, error) {
+	return NewLedgerMetaStream(base64.NewDecoder(base64.StdEncoding, r))
+}
+
+// Version returns the version of the meta being decoded.
+func (s *MetaStream) Version() int32 { return s.version }
+
+// Next returns the next item, or io.EOF once the meta is exhausted.
+func (s *MetaStream) Next() (*StreamItem, error) {
+	for len(s.tasks) > 0 {
+		t := s.tasks[len(s.tasks)-1]
+		s.tasks = s.tasks[:len(s.tasks)-1]
+		item, err := t(s)
+		if err != nil {
+			s.tasks = nil
+			return nil, err
+		}
+		if item != nil {
+			return item, nil
+		}
+	}
+	return nil, io.EOF
+}
+
+// plan schedules tasks to run in the order given, before anything already
+// scheduled.
+func (s *MetaStream) plan(tasks ...task) {
+	for i := len(tasks) - 1; i >= 0; i-- {
+		s.tasks = append(s.tasks, tasks[i])
+	}
+}
+
+func (s *MetaStream) decode(v interface{}) error {
+	if _, err := xdr.Unmarshal(s.r, v); err != nil {
+		// The stream only ends after its last task, so running out of input
+		// before then is never a clean io.EOF.
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("%v: %w", err, io.ErrUnexpectedEOF)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *MetaStream) int32() (int32, error) {
+	var v xdr.Int32
+	err := s.decode(&v)
+	return int32(v), err
+}
+
+// item decodes v and returns it as an item of section, set by fill.
+func (s *MetaStream) item(section StreamSection, v interface{}, fill func(*StreamItem)) (*StreamItem, error) {
+	if err := s.decode(v); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", section, err)
+	}
+	it := &StreamItem{Section: section, Transaction: s.tx, Operation: s.op}
+	fill(it)
+	return it, nil
+}
+
+// array reads an XDR array length and schedules elem for every element.
+func array(elem task) task {
+	return func(s *MetaStream) (*StreamItem, error) {
+		var n xdr.Uint32
+		if err := s.decode(&n); err != nil {
+			return nil, fmt.Errorf("reading array length: %w", err)
+		}
+		s.plan(repeat(uint32(n), elem))
+		return nil, nil
+	}
+}
+
+func repeat(n uint32, elem task) task {
+	var t task
+	t = func(s *MetaStream) (*StreamItem, error) {
+		if n == 0 {
+			return nil, nil
+		}
+		n--
+		s.plan(t)
+		return elem(s)
+	}
+	return t
+}
+
+// optional reads an XDR optional flag and schedules body if it is set.
+func optional(body ...task) task {
+	return func(s *MetaStream) (*StreamItem, error) {
+		var present xdr.Uint32
+		if err := s.decode(&present); err != nil {
+			return nil, fmt.Errorf("reading optional flag: %w", err)
+		}
+		if present != 0 {
+			s.plan(body...)
+		}
+		return nil, nil
+	}
+}
+
+// discard decodes a value of v's type and drops it.
+func discard(v interface{}) task {
+	return func(s *MetaStream) (*StreamItem, error) {
+		return nil, s.decode(v)
+	}
+}
+
+func transactionResultMeta(v1 bool) task {
+	return func(s *MetaStream) (*StreamItem, error) {
+		s.tx++
+		s.op = -1
+		var tasks []task
+		if v1 {
+			tasks = append(tasks, discard(&xdr.ExtensionPoint{}))
+		}
+		tasks = append(tasks,
+			func(s *MetaStream) (*StreamItem, error) {
+				var r xdr.TransactionResultPair
+				return s.item(SectionTxResult, &r, func(it *StreamItem) { it.Result = &r })
+			},
+			changes(SectionFeeProcessing),
+			func(s *MetaStream) (*StreamItem, error) {
+				v, err := s.int32()
+				if err != nil {
+					return nil, fmt.Errorf("reading meta version: %w", err)
+				}
+				meta, err := transactionMeta(v)
+				if err != nil {
+					return nil, err
+				}
+				s.plan(meta...)
+				return nil, nil
+			},
+		)
+		if v1 {
+			tasks = append(tasks, changes(SectionPostTxApplyFeeProcessing))
+		}
+		s.plan(tasks...)
+		return nil, nil
+	}
+}
+
+// transactionMeta returns the tasks decoding the body of a TransactionMeta
+// of version v, after its discriminant.
+func transactionMeta(v int32) ([]task, error) {
+	switch v {
+	case 0:
+		return []task{array(operationMeta(false))}, nil
+	case 1:
+		return []task{changes(SectionTxChangesBefore), array(operationMeta(false))}, nil
+	case 2:
+		return []task{
+			changes(SectionTxChangesBefore),
+			array(operationMeta(false)),
+			changes(SectionTxChangesAfter),
+		}, nil
+	case 3:
+		return []task{
+			discard(&xdr.ExtensionPoint{}),
+			changes(SectionTxChangesBefore),
+			array(operationMeta(false)),
+			changes(SectionTxChangesAfter),
+			optional(
+				sorobanExt(),
+				array(contractEvent(SectionEvents)),
+				returnValue(),
+				array(diagnosticEvent()),
+			),
+		}, nil
+	case 4:
+		return []task{
+			discard(&xdr.ExtensionPoint{}),
+			changes(SectionTxChangesBefore),
+			array(operationMeta(true)),
+			changes(SectionTxChangesAfter),
+			optional(sorobanExt(), optional(returnValue())),
+			array(func(s *MetaStream) (*StreamItem, error) {
+				var e xdr.TransactionEvent
+				return s.item(SectionEvents, &e, func(it *StreamItem) { it.TxEvent = &e })
+			}),
+			array(diagnosticEvent()),
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported transaction meta version %d", v)
+}
+
+func operationMeta(v2 bool) task {
+	return func(s *MetaStream) (*StreamItem, error) {
+		s.op++
+		if v2 {
+			s.plan(discard(&xdr.ExtensionPoint{}), changes(SectionOperation), array(contractEvent(SectionOperation)))
+		} else {
+			s.plan(changes(SectionOperation))
+		}
+		return nil, nil
+	}
+}
+
+func changes(section StreamSection) task {
+	return func(s *MetaStream) (*StreamItem, error) {
+		if section != SectionOperation {
+			s.op = -1
+		}
+		s.plan(array(func(s *MetaStream) (*StreamItem, error) {
+			var c xdr.LedgerEntryChange
+			return s.item(section, &c, func(it *StreamItem) { it.Change = &c })
+		}))
+		return nil, nil
+	}
+}
+
+func contractEvent(section StreamSection) task {
+	return func(s *MetaStream) (*StreamItem, error) {
+		var e xdr.ContractEvent
+		return s.item(section, &e, func(it *StreamItem) { it.Event = &e })
+	}
+}
+
+func diagnosticEvent() task {
+	return func(s *MetaStream) (*StreamItem, error) {
+		s.op = -1
+		var e xdr.DiagnosticEvent
+		return s.item(SectionDiagnosticEvents, &e, func(it *StreamItem) { it.Diagnostic = &e })
+	}
+}
+
+func sorobanExt() task {
+	return func(s *MetaStream) (*StreamItem, error) {
+		s.op = -1
+		var ext xdr.SorobanTransactionMetaExt
+		return s.item(SectionSoroban, &ext, func(it *StreamItem) { it.SorobanExt = &ext })
+	}
+}
+
+func returnValue() task {
+	return func(s *MetaStream) (*StreamItem, error) {
+		var v xdr.ScVal
+		return s.item(SectionSoroban, &v, func(it *StreamItem) { it.ReturnValue = &v })
+	}
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func collect(t *testing.T, s *MetaStream) []*StreamItem {
+	t.Helper()
+	var items []*StreamItem
+	for {
+		it, err := s.Next()
+		if err == io.EOF {
+			return items
+		}
+		require.NoError(t, err)
+		items = append(items, it)
+	}
+}
+
+func testTxMetaV4(t *testing.T) xdr.TransactionMeta {
+	t.Helper()
+	source := keypair.MustRandom().Address()
+	before, after := accountEntry(source, 10_0000000, 1), accountEntry(source, 9_0000000, 1)
+	n := xdr.Uint32(3)
+	ret := xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: &n}
+	event := xdr.ContractEvent{
+		ContractId: &xdr.ContractId{2},
+		Type:       xdr.ContractEventTypeContract,
+		Body:       xdr.ContractEventBody{V0: &xdr.ContractEventV0{Data: ret}},
+	}
+	return xdr.TransactionMeta{V: 4, V4: &xdr.TransactionMetaV4{
+		TxChangesBefore: xdr.LedgerEntryChanges{
+			{Type: xdr.LedgerEntryChangeTypeLedgerEntryState, State: &before},
+			{Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated, Updated: &after},
+		},
+		Operations: []xdr.OperationMetaV2{
+			{},
+			{
+				Changes: xdr.LedgerEntryChanges{{Type: xdr.LedgerEntryChangeTypeLedgerEntryCreated, Created: &after}},
+				Events:  []xdr.ContractEvent{event},
+			},
+		},
+		SorobanMeta:      &xdr.SorobanTransactionMetaV2{ReturnValue: &ret},
+		DiagnosticEvents: []xdr.DiagnosticEvent{{InSuccessfulContractCall: true, Event: event}},
+	}}
+}
+
+type itemSummary struct {
+	section StreamSection
+	tx, op  int
+}
+
+func summarize(items []*StreamItem) []itemSummary {
+	out := make([]itemSummary, len(items))
+	for i, it := range items {
+		out[i] = itemSummary{it.Section, it.Transaction, it.Operation}
+	}
+	return out
+}
+
+func TestMetaStream_V4(t *testing.T) {
+	b64, err := xdr.MarshalBase64(testTxMetaV4(t))
+	require.NoError(t, err)
+
+	s, err := NewMetaStreamBase64(strings.NewReader(b64))
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), s.Version())
+
+	items := collect(t, s)
+	assert.Equal(t, []itemSummary{
+		{SectionTxChangesBefore, -1, -1},
+		{SectionTxChangesBefore, -1, -1},
+		{SectionOperation, -1, 1},
+		{SectionOperation, -1, 1},
+		{SectionSoroban, -1, -1},
+		{SectionSoroban, -1, -1},
+		{SectionDiagnosticEvents, -1, -1},
+	}, summarize(items))
+	assert.Equal(t, xdr.LedgerEntryChangeTypeLedgerEntryCreated, items[2].Change.Type)
+	assert.NotNil(t, items[3].Event)
+	assert.NotNil(t, items[4].SorobanExt)
+	assert.Equal(t, xdr.Uint32(3), *items[5].ReturnValue.U32)
+	assert.True(t, items[6].Diagnostic.InSuccessfulContractCall)
+}
+
+func TestMetaStream_V3(t *testing.T) {
+	n := xdr.Uint32(1)
+	ret := xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: &n}
+	meta := xdr.TransactionMeta{V: 3, V3: &xdr.TransactionMetaV3{
+		Operations: []xdr.OperationMeta{{}},
+		SorobanMeta: &xdr.SorobanTransactionMeta{
+			Events:      []xdr.ContractEvent{{Type: xdr.ContractEventTypeContract, Body: xdr.ContractEventBody{V0: &xdr.ContractEventV0{Data: ret}}}},
+			ReturnValue: ret,
+		},
+	}}
+	raw, err := meta.MarshalBinary()
+	require.NoError(t, err)
+
+	s, err := NewMetaStream(bytes.NewReader(raw))
+	require.NoError(t, err)
+	items := collect(t, s)
+	require.Len(t, items, 3)
+	assert.NotNil(t, items[0].SorobanExt)
+	assert.Equal(t, SectionEvents, items[1].Section)
+	assert.NotNil(t, items[1].Event)
+	assert.NotNil(t, items[2].ReturnValue)
+}
+
+func TestMetaStream_Truncated(t *testing.T) {
+	raw, err := testTxMetaV4(t).MarshalBinary()
+	require.NoError(t, err)
+
+	s, err := NewMetaStream(bytes.NewReader(raw[:len(raw)-10]))
+	require.NoError(t, err)
+	for {
+		_, err = s.Next()
+		if err != nil {
+			break
+		}
+	}
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	_, err = NewMetaStream(bytes.NewReader([]byte{0, 0, 0, 9}))
+	assert.Error(t, err)
+}
+
+func TestLedgerMetaStream(t *testing.T) {
+	txMeta := testTxMetaV4(t)
+	result := xdr.TransactionResultPair{Result: xdr.TransactionResult{
+		FeeCharged: 100,
+		Result:     xdr.TransactionResultResult{Code: xdr.TransactionResultCodeTxSuccess, Results: &[]xdr.OperationResult{}},
+	}}
+	entry := accountEntry(keypair.MustRandom().Address(), 1, 1)
+	fee := xdr.LedgerEntryChanges{{Type: xdr.LedgerEntryChangeTypeLedgerEntryUpdated, Updated: &entry}}
+	key, err := entry.LedgerKey()
+	require.NoError(t, err)
+
+	lcm := xdr.LedgerCloseMeta{V: 2, V2: &xdr.LedgerCloseMetaV2{
+		TxSet: xdr.GeneralizedTransactionSet{V: 1, V1TxSet: &xdr.TransactionSetV1{}},
+		TxProcessing: []xdr.TransactionResultMetaV1{
+			{Result: result, FeeProcessing: fee, TxApplyProcessing: xdr.TransactionMeta{V: 2, V2: &xdr.TransactionMetaV2{}}},
+			{Result: result, TxApplyProcessing: txMeta, PostTxApplyFeeProcessing: fee},
+		},
+		EvictedKeys: []xdr.LedgerKey{key},
+	}}
+	b64, err := xdr.MarshalBase64(lcm)
+	require.NoError(t, err)
+
+	s, err := NewLedgerMetaStreamBase64(strings.NewReader(b64))
+	require.NoError(t, err)
+	items := collect(t, s)
+
+	sections := map[StreamSection]int{}
+	for _, it := range items {
+		sections[it.Section]++
+	}
+	assert.Equal(t, 1, sections[SectionLedgerHeader])
+	assert.Equal(t, 1, sections[SectionTxSet])
+	assert.Equal(t, 2, sections[SectionTxResult])
+	assert.Equal(t, 1, sections[SectionFeeProcessing])
+	assert.Equal(t, 1, sections[SectionPostTxApplyFeeProcessing])
+	assert.Equal(t, 1, sections[SectionEvictedKeys])
+
+	require.Equal(t, SectionTxResult, items[2].Section)
+	assert.Equal(t, 0, items[2].Transaction)
+	assert.Equal(t, SectionFeeProcessing, items[3].Section)
+	assert.Equal(t, 0, items[3].Transaction)
+	assert.Equal(t, SectionTxResult, items[4].Section)
+	assert.Equal(t, 1, items[4].Transaction)
+	last := items[len(items)-1]
+	assert.Equal(t, SectionEvictedKeys, last.Section)
+	assert.Equal(t, -1, last.Transaction)
+}
+
+func TestLedgerMetaStream_UnsupportedVersion(t *testing.T) {
+	_, err := NewLedgerMetaStream(bytes.NewReader([]byte{0, 0, 0, 0}))
+	assert.Error(t, err)
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package decoder
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/network"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func roundTrip(t *testing.T, v interface{}, out interface{}) map[string]interface{} {
+	t.Helper()
+	data, err := MarshalXDRJSON(v)
+	require.NoError(t, err)
+	require.NoError(t, UnmarshalXDRJSON(data, out))
+
+	want, err := xdr.MarshalBase64(v)
+	require.NoError(t, err)
+	got, err := xdr.MarshalBase64(out)
+	require.NoError(t, err)
+	assert.Equal(t, want, got, "round trip through %s", data)
+
+	var generic map[string]interface{}
+	_ = json.Unmarshal(data, &generic)
+	return generic
+}
+
+func TestXDRJSONEnvelope(t *testing.T) {
+	source, dest := keypair.MustRandom(), keypair.MustRandom()
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount: &txnbuild.SimpleAccount{AccountID: source.Address(), Sequence: 41},
+		BaseFee:       txnbuild.MinBaseFee,
+		Memo:          txnbuild.MemoText("invoice 7"),
+		Preconditions: txnbuild.Preconditions{TimeBounds: txnbuild.NewTimebounds(100, 200)},
+		Operations: []txnbuild.Operation{
+			&txnbuild.Payment{Destination: dest.Address(), Amount: "10", Asset: txnbuild.CreditAsset{Code: "USDC", Issuer: dest.Address()}},
+		},
+	})
+	require.NoError(t, err)
+	tx, err = tx.Sign(network.TestNetworkPassphrase, source)
+	require.NoError(t, err)
+	env := tx.ToXDR()
+
+	var back xdr.TransactionEnvelope
+	generic := roundTrip(t, env, &back)
+
+	v1 := generic["tx"].(map[string]interface{})
+	inner := v1["tx"].(map[string]interface{})
+	assert.Equal(t, source.Address(), inner["source_account"])
+	assert.Equal(t, float64(100), inner["fee"])
+	assert.Equal(t, "41", inner["seq_num"])
+	assert.Equal(t, map[string]interface{}{"text": "invoice 7"}, inner["memo"])
+	assert.Equal(t, "v0", inner["ext"])
+
+	op := inner["operations"].([]interface{})[0].(map[string]interface{})
+	assert.Nil(t, op["source_account"])
+	payment := op["body"].(map[string]interface{})["payment"].(map[string]interface{})
+	assert.Equal(t, "100000000", payment["amount"])
+	asset := payment["asset"].(map[string]interface{})["credit_alphanum4"].(map[string]interface{})
+	assert.Equal(t, "USDC", asset["asset_code"])
+}
+
+func TestXDRJSONScVal(t *testing.T) {
+	contract := xdr.ContractId{1, 2, 3}
+	sym := xdr.ScSymbol("balance")
+	vec := xdr.ScVec{
+		{Type: xdr.ScValTypeScvVoid},
+		{Type: xdr.ScValTypeScvI128, I128: &xdr.Int128Parts{Hi: -1, Lo: xdr.Uint64(^uint64(0) - 4)}},
+		{Type: xdr.ScValTypeScvAddress, Address: &xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &contract}},
+	}
+	vecPtr := &vec
+	m := xdr.ScMap{{Key: xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym}, Val: xdr.ScVal{Type: xdr.ScValTypeScvVec, Vec: &vecPtr}}}
+	mPtr := &m
+	val := xdr.ScVal{Type: xdr.ScValTypeScvMap, Map: &mPtr}
+
+	data, err := MarshalXDRJSON(val)
+	require.NoError(t, err)
+	var generic interface{}
+	require.NoError(t, json.Unmarshal(data, &generic))
+	entry := generic.(map[string]interface{})["map"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"symbol": "balance"}, entry["key"])
+	items := entry["val"].(map[string]interface{})["vec"].([]interface{})
+	assert.Equal(t, "void", items[0])
+	assert.Equal(t, map[string]interface{}{"i128": "-5"}, items[1])
+	assert.Contains(t, items[2].(map[string]interface{})["address"], "C")
+
+	var back xdr.ScVal
+	roundTrip(t, val, &back)
+}
+
+func TestXDRJSONMeta(t *testing.T) {
+	meta := testTxMetaV4(t)
+	var back xdr.TransactionMeta
+	generic := roundTrip(t, meta, &back)
+	assert.Contains(t, generic, "v4")
+}
+
+func TestXDRToJSONAndBack(t *testing.T) {
+	entry := accountEntry(keypair.MustRandom().Address(), 50, 7)
+	b64, err := xdr.MarshalBase64(entry)
+	require.NoError(t, err)
+
+	js, err := XDRToJSON(b64, "")
+	require.NoError(t, err)
+	assert.Contains(t, js, `"account"`)
+
+	back, err := JSONToXDR(js, KindLedgerEntry)
+	require.NoError(t, err)
+	assert.Equal(t, b64, back)
+}
+
+func TestUnmarshalXDRJSONErrors(t *testing.T) {
+	var val xdr.ScVal
+	assert.Error(t, UnmarshalXDRJSON([]byte(`{"nope": 1}`), &val))
+	assert.Error(t, UnmarshalXDRJSON([]byte(`{"u32": 1, "i32": 2}`), &val))
+	assert.Error(t, UnmarshalXDRJSON([]byte(`"u32"`), &val))
+	assert.Error(t, UnmarshalXDRJSON([]byte(`{"u32": "x"}`), &val))
+
+	require.NoError(t, UnmarshalXDRJSON([]byte(`{"u64": "18446744073709551615"}`), &val))
+	assert.Equal(t, xdr.Uint64(^uint64(0)), *val.U64)
+
+	var entry xdr.LedgerEntry
+	err := UnmarshalXDRJSON([]byte(`{"last_modified_ledger_seq": 1}`), &entry)
+	assert.ErrorContains(t, err, "missing field")
+}
+
+func TestEscapeText(t *testing.T) {
+	for _, s := range []string{"plain", "tab\there", `back\slash`, "\xff\x00bin", "ünïcode"} {
+		got, err := textBytes(escapeText([]byte(s)))
+		require.NoError(t, err)
+		assert.Equal(t, s, string(got))
+	}
+	assert.Equal(t, `\xff`, escapeText([]byte{0xff}))
+}
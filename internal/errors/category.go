@@ -0,0 +1,153 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import (
+	"context"
+	"errors"
+	"net"
+)
+
+// Category is the broad class of an error, shared by callers deciding how to
+// report a failure and by the RPC client deciding whether to try again.
+type Category string
+
+const (
+	CategoryUnknown     Category = "unknown"
+	CategoryNetwork     Category = "network"
+	CategoryRateLimited Category = "rate_limited"
+	CategoryAuth        Category = "auth"
+	CategoryValidation  Category = "validation"
+	CategoryNotFound    Category = "not_found"
+	// CategoryTxMalformed covers transactions the network rejected before
+	// applying them: bad sequence numbers, fees, time bounds and the like.
+	CategoryTxMalformed Category = "tx_malformed"
+	// CategoryTxFailed covers transactions that were applied and had one or
+	// more operations fail.
+	CategoryTxFailed      Category = "tx_failed"
+	CategoryContractError Category = "contract_error"
+)
+
+// Retryable reports whether errors of category c are temporary conditions
+// that sending the same request again may get past.
+func (c Category) Retryable() bool {
+	return c == CategoryNetwork || c == CategoryRateLimited
+}
+
+// Categorized is implemented by errors that know their own category. It is
+// consulted before the sentinel rules in CategoryOf, anywhere in the chain.
+type Categorized interface {
+	error
+	Category() Category
+}
+
+// categoryRules are checked in order, so an error wrapped in a more general
+// one, such as a rate limit reported as a failed connection, keeps the more
+// specific category. Network comes last because most RPC failures are
+// wrapped in ErrRPCConnectionFailed whatever their cause. JSON-RPC internal
+// errors are left unclassified: Soroban RPC uses them for deterministic
+// failures as often as for overload.
+var categoryRules = []struct {
+	category  Category
+	sentinels []error
+}{
+	{CategoryRateLimited, []error{ErrRateLimitExceeded, ErrTxTryAgainLater}},
+	{CategoryAuth, []error{ErrUnauthorized, ErrTxBadAuth, ErrTxBadAuthExtra, ErrWrongNetwork, ErrInvalidSignature}},
+	{CategoryContractError, []error{ErrContractError, ErrSimulationLogicError}},
+	// A fee bump whose inner transaction was rejected matches both the inner
+	// code and ErrTxFailed; the inner code says more.
+	{CategoryTxMalformed, []error{
+		ErrTxTooEarly, ErrTxTooLate, ErrTxMissingOperation, ErrTxBadSeq, ErrTxInsufficientBalance,
+		ErrTxNoAccount, ErrTxInsufficientFee, ErrTxInternalError, ErrTxNotSupported, ErrTxBadSponsorship,
+		ErrTxBadMinSeqAgeOrGap, ErrTxMalformed, ErrTxSorobanInvalid,
+	}},
+	{CategoryTxFailed, []error{ErrTxFailed}},
+	{CategoryTxMalformed, []error{ErrTxStatusError, ErrTxDuplicate}},
+	{CategoryNotFound, []error{
+		ErrTransactionNotFound, ErrLedgerNotFound, ErrLedgerArchived, ErrAccountNotFound, ErrSessionNotFound,
+		ErrNetworkNotFound, ErrSpecNotFound, ErrMissingLedgerKey, ErrSimulatorNotFound, ErrRPCMethodNotFound,
+	}},
+	{CategoryValidation, []error{
+		ErrValidationFailed, ErrInvalidNetwork, ErrArgumentRequired, ErrProtocolUnsupported, ErrWasmInvalid,
+		ErrMemoRequired, ErrConfigFailed, ErrMarshalFailed, ErrRPCResponseTooLarge, ErrRPCParseError,
+		ErrRPCInvalidRequest, ErrRPCInvalidParams, ErrNetworkMismatch,
+	}},
+	{CategoryNetwork, []error{ErrRPCConnectionFailed, ErrRPCTimeout, ErrAllRPCFailed}},
+}
+
+var erstCodeCategories = map[ErstErrorCode]Category{
+	CodeRPCConnectionFailed:  CategoryNetwork,
+	CodeRPCTimeout:           CategoryNetwork,
+	CodeRPCAllFailed:         CategoryNetwork,
+	CodeRPCResponseTooLarge:  CategoryValidation,
+	CodeRPCRateLimitExceeded: CategoryRateLimited,
+	CodeRPCMarshalFailed:     CategoryValidation,
+	CodeTransactionNotFound:  CategoryNotFound,
+	CodeLedgerNotFound:       CategoryNotFound,
+	CodeLedgerArchived:       CategoryNotFound,
+	CodeSimNotFound:          CategoryNotFound,
+	CodeSimLogicError:        CategoryContractError,
+	CodeSimProtoUnsup:        CategoryValidation,
+	CodeValidationFailed:     CategoryValidation,
+}
+
+// CategoryOf classifies err. An error caused by the caller's context being
+// cancelled or running out of time is reported as CategoryUnknown, so it is
+// never retried, as is anything no rule recognises.
+func CategoryOf(err error) Category {
+	if err == nil {
+		return CategoryUnknown
+	}
+	var c Categorized
+	if errors.As(err, &c) {
+		return c.Category()
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return CategoryUnknown
+	}
+	for _, rule := range categoryRules {
+		for _, sentinel := range rule.sentinels {
+			if errors.Is(err, sentinel) {
+				return rule.category
+			}
+		}
+	}
+	var erstErr *ErstError
+	if errors.As(err, &erstErr) {
+		if c, ok := erstCodeCategories[erstErr.Code]; ok {
+			return c
+		}
+	}
+	var codeErr *RPCCodeError
+	if errors.As(err, &codeErr) {
+		return httpStatusCategory(codeErr.Code)
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return CategoryNetwork
+	}
+	return CategoryUnknown
+}
+
+// httpStatusCategory classifies the HTTP statuses RPCCodeError carries
+// alongside JSON-RPC codes; 401, 403 and 429 are matched by its Is method.
+func httpStatusCategory(status int) Category {
+	switch {
+	case status == 404:
+		return CategoryNotFound
+	case status == 408 || status >= 500 && status < 600:
+		return CategoryNetwork
+	case status >= 400 && status < 500:
+		return CategoryValidation
+	default:
+		return CategoryUnknown
+	}
+}
+
+// IsRetryable reports whether err is a temporary condition, such as a
+// dropped connection, a timeout or rate limiting, that retrying the same
+// request may get past.
+func IsRetryable(err error) bool {
+	return CategoryOf(err).Retryable()
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type selfCategorized struct{}
+
+func (selfCategorized) Error() string      { return "quota" }
+func (selfCategorized) Category() Category { return CategoryRateLimited }
+
+func TestCategoryOf(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want Category
+	}{
+		{"nil", nil, CategoryUnknown},
+		{"plain", errors.New("boom"), CategoryUnknown},
+		{"connection", WrapRPCConnectionFailed(errors.New("reset")), CategoryNetwork},
+		{"timeout", WrapRPCTimeout(errors.New("no data")), CategoryNetwork},
+		{"net error", &net.OpError{Op: "dial", Err: errors.New("refused")}, CategoryNetwork},
+		{"http 503", WrapRPCError("u", "unavailable", 503), CategoryNetwork},
+		{"caller cancelled", WrapRPCTimeout(context.Canceled), CategoryUnknown},
+		{"caller deadline", WrapRPCConnectionFailed(context.DeadlineExceeded), CategoryUnknown},
+		{"rate limit", WrapRateLimitExceeded(), CategoryRateLimited},
+		{"rate limit in connection error", WrapRPCConnectionFailed(WrapRPCError("u", "slow down", 429)), CategoryRateLimited},
+		{"try again later", NewSendTransactionError("TRY_AGAIN_LATER", "abc", ""), CategoryRateLimited},
+		{"unauthorized", WrapRPCError("u", "forbidden", 403), CategoryAuth},
+		{"bad auth", NewTransactionResultError("tx_bad_auth", nil), CategoryAuth},
+		{"wrong network", NewEnvelopeSignatureError("testnet", "mainnet", nil), CategoryAuth},
+		{"invalid params", WrapRPCError("u", "invalid", -32602), CategoryValidation},
+		{"http 400", WrapRPCError("u", "bad request", 400), CategoryValidation},
+		{"validation", WrapValidationError("amount"), CategoryValidation},
+		{"not found", WrapTransactionNotFound(errors.New("404")), CategoryNotFound},
+		{"ledger archived", WrapLedgerArchived(7), CategoryNotFound},
+		{"bad seq", NewSendTransactionError("ERROR", "abc", "tx_bad_seq"), CategoryTxMalformed},
+		{"fee bump inner bad seq", NewTransactionResultError("tx_fee_bump_inner_failed", nil).WithInnerResultCode("tx_bad_seq"), CategoryTxMalformed},
+		{"tx failed", NewTransactionResultError("tx_failed", []string{"op_underfunded"}), CategoryTxFailed},
+		{"send status error", NewSendTransactionError("ERROR", "abc", ""), CategoryTxMalformed},
+		{"contract", WrapSimulationLogicError("HostError"), CategoryContractError},
+		{"erst code", NewRPCError(CodeRPCTimeout, errors.New("slow")), CategoryNetwork},
+		{"internal rpc error", WrapRPCError("u", "internal", -32603), CategoryUnknown},
+		{"self categorized", fmt.Errorf("wrapped: %w", selfCategorized{}), CategoryRateLimited},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CategoryOf(tt.err))
+		})
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, IsRetryable(WrapRPCConnectionFailed(errors.New("reset"))))
+	assert.True(t, IsRetryable(WrapRPCError("u", "slow down", 429)))
+	assert.False(t, IsRetryable(WrapRPCError("u", "not found", 404)))
+	assert.False(t, IsRetryable(NewTransactionResultError("tx_failed", nil)))
+	assert.False(t, IsRetryable(context.Canceled))
+	assert.False(t, IsRetryable(nil))
+}
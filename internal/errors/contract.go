@@ -0,0 +1,73 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ContractError is an Error(Contract, #N) raised by a Soroban contract. Name,
+// Enum and Doc come from the contract's spec and are empty when the code
+// could not be resolved. Trail holds the diagnostic events leading up to
+// the error, one line each, in emission order.
+type ContractError struct {
+	ContractID string
+	Function   string
+	Code       uint32
+	Enum       string
+	Name       string
+	Doc        string
+	Trail      []string
+}
+
+func (e *ContractError) Error() string {
+	var b strings.Builder
+	switch {
+	case e.ContractID != "" && e.Function != "":
+		fmt.Fprintf(&b, "contract %s function %s failed: ", e.ContractID, e.Function)
+	case e.ContractID != "":
+		fmt.Fprintf(&b, "contract %s failed: ", e.ContractID)
+	default:
+		b.WriteString("contract failed: ")
+	}
+	fmt.Fprintf(&b, "Error(Contract, #%d)", e.Code)
+	if e.Name != "" {
+		b.WriteString(" " + e.QualifiedName())
+	}
+	if e.Doc != "" {
+		b.WriteString(": " + e.Doc)
+	}
+	return b.String()
+}
+
+// QualifiedName returns the error's spec name as Enum::Name, or "" when the
+// code was not resolved.
+func (e *ContractError) QualifiedName() string {
+	if e.Name == "" {
+		return ""
+	}
+	if e.Enum == "" {
+		return e.Name
+	}
+	return e.Enum + "::" + e.Name
+}
+
+// Resolved reports whether the code was found in the contract's spec.
+func (e *ContractError) Resolved() bool {
+	return e.Name != ""
+}
+
+// TrailString renders the diagnostic trail as an indented block, or "" when
+// there is none.
+func (e *ContractError) TrailString() string {
+	if len(e.Trail) == 0 {
+		return ""
+	}
+	return "  " + strings.Join(e.Trail, "\n  ")
+}
+
+func (e *ContractError) Is(target error) bool {
+	return target == ErrContractError
+}
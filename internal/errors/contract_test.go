@@ -0,0 +1,32 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContractError(t *testing.T) {
+	ce := &ContractError{ContractID: "CABC", Function: "transfer", Code: 5, Trail: []string{"[fn_call] CABC transfer"}}
+	assert.False(t, ce.Resolved())
+	assert.Equal(t, "contract CABC function transfer failed: Error(Contract, #5)", ce.Error())
+
+	ce.Enum, ce.Name, ce.Doc = "TokenError", "InsufficientBalance", "balance is too low"
+	assert.Equal(t, "contract CABC function transfer failed: Error(Contract, #5) TokenError::InsufficientBalance: balance is too low", ce.Error())
+	assert.Equal(t, "  [fn_call] CABC transfer", ce.TrailString())
+
+	err := NewTransactionResultError("tx_failed", []string{"op_trapped"}).WithContractError(ce)
+	assert.True(t, errors.Is(err, ErrContractError))
+	assert.True(t, errors.Is(err, ErrTxFailed))
+	var got *ContractError
+	require.True(t, errors.As(err, &got))
+	assert.Equal(t, uint32(5), got.Code)
+	assert.Equal(t, CategoryContractError, CategoryOf(err))
+
+	assert.Nil(t, NewTransactionResultError("tx_failed", nil).Unwrap())
+}
@@ -6,6 +6,7 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 // New is a proxy to the standard errors.New
@@ -44,6 +45,7 @@ var (
 	ErrSessionNotFound      = errors.New("session not found")
 	ErrUnauthorized         = errors.New("unauthorized")
 	ErrLedgerNotFound       = errors.New("ledger not found")
+	ErrAccountNotFound      = errors.New("account not found")
 	ErrLedgerArchived       = errors.New("ledger has been archived")
 	ErrRateLimitExceeded    = errors.New("rate limit exceeded")
 	ErrRPCResponseTooLarge  = errors.New("RPC response too large")
@@ -52,6 +54,20 @@ var (
 	ErrMissingLedgerKey     = errors.New("missing ledger key in footprint")
 	ErrWasmInvalid          = errors.New("invalid WASM file")
 	ErrSpecNotFound         = errors.New("contract spec not found")
+	ErrMemoRequired         = errors.New("destination requires a memo")
+	ErrAuditLog             = errors.New("audit log error")
+	ErrContractError        = errors.New("contract returned an error")
+	ErrNetworkMismatch      = errors.New("network mismatch")
+)
+
+// Policy sentinels, for callers deciding whether to back off, alert or
+// rotate credentials. ErrUnauthorized is matched by 401 and 403 responses.
+var (
+	// ErrRateLimited is matched by every rate limit response; errors.As into
+	// *RateLimitError, or RetryAfter, gives the wait the server asked for.
+	ErrRateLimited = ErrRateLimitExceeded
+	// ErrAllEndpointsDown is matched when no configured endpoint answered.
+	ErrAllEndpointsDown = ErrAllRPCFailed
 )
 
 type LedgerNotFoundError struct {
@@ -80,8 +96,11 @@ func (e *LedgerArchivedError) Is(target error) bool {
 	return target == ErrLedgerArchived
 }
 
+// RateLimitError is a rate limit response. RetryAfter is the wait the
+// server asked for in its Retry-After header, or 0 if it gave none.
 type RateLimitError struct {
-	Message string
+	Message    string
+	RetryAfter time.Duration
 }
 
 func (e *RateLimitError) Error() string {
@@ -92,6 +111,32 @@ func (e *RateLimitError) Is(target error) bool {
 	return target == ErrRateLimitExceeded
 }
 
+// RetryAfter returns the wait a rate-limited server asked for, and false
+// when err is not a rate limit or the server did not say.
+func RetryAfter(err error) (time.Duration, bool) {
+	var rl *RateLimitError
+	if errors.As(err, &rl) && rl.RetryAfter > 0 {
+		return rl.RetryAfter, true
+	}
+	return 0, false
+}
+
+// NetworkMismatchError reports an endpoint serving a different network from
+// the one the client is configured for.
+type NetworkMismatchError struct {
+	URL      string
+	Expected string
+	Actual   string
+}
+
+func (e *NetworkMismatchError) Error() string {
+	return fmt.Sprintf("%v: %s serves %q, but the client is configured for %q", ErrNetworkMismatch, e.URL, e.Actual, e.Expected)
+}
+
+func (e *NetworkMismatchError) Is(target error) bool {
+	return target == ErrNetworkMismatch
+}
+
 // ResponseTooLargeError indicates the Soroban RPC response exceeded server limits.
 type ResponseTooLargeError struct {
 	URL     string
@@ -162,7 +207,7 @@ func WrapAllRPCFailed() error {
 }
 
 func WrapRPCError(url string, msg string, code int) error {
-	return fmt.Errorf("%w from %s: %s (code %d)", ErrRPCError, url, msg, code)
+	return &RPCCodeError{URL: url, Code: code, Message: msg}
 }
 
 func WrapSimCrash(err error, stderr string) error {
@@ -206,6 +251,16 @@ func WrapLedgerNotFound(sequence uint32) error {
 	}
 }
 
+func WrapAccountNotFound(account string) error {
+	return fmt.Errorf("%w: %s", ErrAccountNotFound, account)
+}
+
+// WrapSourceAccountNotFound reports a transaction whose source account does
+// not exist on the network it is about to be submitted to.
+func WrapSourceAccountNotFound(account, network string) error {
+	return fmt.Errorf("%w: source account %s does not exist on %s", ErrAccountNotFound, account, network)
+}
+
 func WrapLedgerArchived(sequence uint32) error {
 	return &LedgerArchivedError{
 		Sequence: sequence,
@@ -219,6 +274,24 @@ func WrapRateLimitExceeded() error {
 	}
 }
 
+// WrapRateLimited reports a rate limit response that asked the client to
+// wait retryAfter, which may be 0 when the server did not say.
+func WrapRateLimited(retryAfter time.Duration) error {
+	if retryAfter <= 0 {
+		return WrapRateLimitExceeded()
+	}
+	return &RateLimitError{
+		Message:    fmt.Sprintf("%v, retry after %s", ErrRateLimitExceeded, retryAfter),
+		RetryAfter: retryAfter,
+	}
+}
+
+// WrapNetworkMismatch reports that url serves the network with passphrase
+// actual instead of expected.
+func WrapNetworkMismatch(url, expected, actual string) error {
+	return &NetworkMismatchError{URL: url, Expected: expected, Actual: actual}
+}
+
 func WrapConfigError(msg string, err error) error {
 	if err != nil {
 		return fmt.Errorf("%w: %s: %v", ErrConfigFailed, msg, err)
@@ -238,6 +311,22 @@ func WrapSpecNotFound() error {
 	return fmt.Errorf("%w: no contractspecv0 section found; is this a compiled Soroban contract?", ErrSpecNotFound)
 }
 
+// WrapMemoRequired reports that account opted into SEP-29 and a transaction
+// paying it has no memo.
+func WrapMemoRequired(account string) error {
+	return fmt.Errorf("%w: %s sets config.memo_required", ErrMemoRequired, account)
+}
+
+func WrapAuditLog(err error) error {
+	return fmt.Errorf("%w: %w", ErrAuditLog, err)
+}
+
+// WrapAuditChainBroken reports the first audit record whose hash chain does
+// not verify.
+func WrapAuditChainBroken(seq uint64, reason string) error {
+	return fmt.Errorf("%w: chain broken at record %d: %s", ErrAuditLog, seq, reason)
+}
+
 // WrapRPCResponseTooLarge wraps an HTTP 413 response into a readable message
 // explaining that the Soroban RPC response exceeded the server's size limit.
 func WrapRPCResponseTooLarge(url string) error {
@@ -274,11 +363,11 @@ const (
 	CodeLedgerArchived       ErstErrorCode = "RPC_LEDGER_ARCHIVED"
 
 	// Simulator origin
-	CodeSimNotFound     ErstErrorCode = "SIM_BINARY_NOT_FOUND"
-	CodeSimCrash        ErstErrorCode = "SIM_PROCESS_CRASHED"
-	CodeSimExecFailed   ErstErrorCode = "SIM_EXECUTION_FAILED"
-	CodeSimLogicError   ErstErrorCode = "SIM_LOGIC_ERROR"
-	CodeSimProtoUnsup   ErstErrorCode = "SIM_PROTOCOL_UNSUPPORTED"
+	CodeSimNotFound   ErstErrorCode = "SIM_BINARY_NOT_FOUND"
+	CodeSimCrash      ErstErrorCode = "SIM_PROCESS_CRASHED"
+	CodeSimExecFailed ErstErrorCode = "SIM_EXECUTION_FAILED"
+	CodeSimLogicError ErstErrorCode = "SIM_LOGIC_ERROR"
+	CodeSimProtoUnsup ErstErrorCode = "SIM_PROTOCOL_UNSUPPORTED"
 
 	// Shared / general
 	CodeValidationFailed ErstErrorCode = "VALIDATION_FAILED"
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -97,3 +98,23 @@ func TestWrapRPCResponseTooLarge(t *testing.T) {
 	assert.True(t, errors.As(err, &rte))
 	assert.Equal(t, url, rte.URL)
 }
+
+func TestPolicySentinels(t *testing.T) {
+	err := fmt.Errorf("submit: %w", WrapRateLimited(3*time.Second))
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	wait, ok := RetryAfter(err)
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, wait)
+
+	_, ok = RetryAfter(WrapRateLimited(0))
+	assert.False(t, ok)
+	assert.True(t, errors.Is(WrapRPCError("u", "too many requests", 429), ErrRateLimited))
+
+	assert.True(t, errors.Is(WrapRPCError("u", "unauthorized", 401), ErrUnauthorized))
+	assert.True(t, errors.Is(WrapAllRPCFailed(), ErrAllEndpointsDown))
+
+	mismatch := WrapNetworkMismatch("https://rpc.example", "Test SDF Network ; September 2015", "Public Global Stellar Network ; September 2015")
+	assert.True(t, errors.Is(mismatch, ErrNetworkMismatch))
+	assert.Equal(t, CategoryValidation, CategoryOf(mismatch))
+	assert.Contains(t, mismatch.Error(), "https://rpc.example")
+}
@@ -0,0 +1,157 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// HorizonResultCodes are the result codes Horizon attaches to a rejected
+// transaction submission under extras.result_codes.
+type HorizonResultCodes struct {
+	Transaction      string   `json:"transaction"`
+	InnerTransaction string   `json:"inner_transaction,omitempty"`
+	Operations       []string `json:"operations,omitempty"`
+}
+
+// HorizonError is a decoded application/problem+json response from Horizon.
+// It matches ErrRPCError, the HTTP status classes understood by RPCCodeError,
+// and the tx_* and op_* result code sentinels with errors.Is.
+type HorizonError struct {
+	URL         string
+	Status      int
+	Type        string
+	Title       string
+	Detail      string
+	ResultCodes HorizonResultCodes
+	ResultXDR   string
+	EnvelopeXDR string
+}
+
+type horizonExtras struct {
+	ResultCodes HorizonResultCodes `json:"result_codes"`
+	ResultXDR   string             `json:"result_xdr"`
+	EnvelopeXDR string             `json:"envelope_xdr"`
+}
+
+// ParseHorizonProblem decodes a Horizon problem body. A body that is not
+// problem JSON still yields an error carrying the HTTP status.
+func ParseHorizonProblem(url string, status int, body []byte) *HorizonError {
+	var p struct {
+		Type   string          `json:"type"`
+		Title  string          `json:"title"`
+		Status int             `json:"status"`
+		Detail string          `json:"detail"`
+		Extras json.RawMessage `json:"extras"`
+	}
+	e := &HorizonError{URL: url, Status: status}
+	if json.Unmarshal(body, &p) != nil {
+		return e
+	}
+	e.Type, e.Title, e.Detail = p.Type, p.Title, p.Detail
+	e.setExtras(p.Extras)
+	return e
+}
+
+// NewHorizonError builds a HorizonError from already-decoded problem fields,
+// such as those of a horizonclient.Error.
+func NewHorizonError(url string, status int, problemType, title, detail string, extras map[string]interface{}) *HorizonError {
+	e := &HorizonError{URL: url, Status: status, Type: problemType, Title: title, Detail: detail}
+	if len(extras) > 0 {
+		if raw, err := json.Marshal(extras); err == nil {
+			e.setExtras(raw)
+		}
+	}
+	return e
+}
+
+func (e *HorizonError) setExtras(raw []byte) {
+	if len(raw) == 0 {
+		return
+	}
+	var x horizonExtras
+	if json.Unmarshal(raw, &x) != nil {
+		return
+	}
+	e.ResultCodes = x.ResultCodes
+	e.ResultXDR = x.ResultXDR
+	e.EnvelopeXDR = x.EnvelopeXDR
+}
+
+func (e *HorizonError) Error() string {
+	msg := e.Detail
+	if msg == "" {
+		msg = e.Title
+	}
+	s := fmt.Sprintf("%v from %s: %s (status %d)", ErrRPCError, e.URL, msg, e.Status)
+	if code := e.ResultCode(); code != "" {
+		s += " [" + code
+		if len(e.ResultCodes.Operations) > 0 {
+			s += ": " + strings.Join(e.ResultCodes.Operations, ", ")
+		}
+		s += "]"
+	}
+	return s
+}
+
+// Unwrap exposes the response as an RPCCodeError keyed by HTTP status, so
+// callers that only care about the status class keep working.
+func (e *HorizonError) Unwrap() error {
+	return &RPCCodeError{URL: e.URL, Code: e.Status, Message: e.Detail}
+}
+
+func (e *HorizonError) Is(target error) bool {
+	code := e.ResultCode()
+	if code == "" {
+		return false
+	}
+	return matchesTxResultCode(code, target) || matchesOpResultCodes(e.ResultCodes.Operations, target)
+}
+
+// ResultCode returns the transaction result code, preferring the inner
+// transaction's code for fee-bump submissions.
+func (e *HorizonError) ResultCode() string {
+	if e.ResultCodes.InnerTransaction != "" {
+		return NormalizeTxResultCode(e.ResultCodes.InnerTransaction)
+	}
+	return NormalizeTxResultCode(e.ResultCodes.Transaction)
+}
+
+// OperationCode returns the result code of operation i, or "" if Horizon
+// did not report one.
+func (e *HorizonError) OperationCode(i int) string {
+	if i < 0 || i >= len(e.ResultCodes.Operations) {
+		return ""
+	}
+	return e.ResultCodes.Operations[i]
+}
+
+// FailedOperations returns the operations that did not succeed, in
+// transaction order.
+func (e *HorizonError) FailedOperations() []*OperationError {
+	return failedOperations(e.ResultCodes.Operations)
+}
+
+// IsTxBadSeq reports whether the transaction was rejected for its sequence number.
+func (e *HorizonError) IsTxBadSeq() bool {
+	return e.ResultCode() == "tx_bad_seq"
+}
+
+// IsInsufficientFee reports whether the fee bid was below the surge price.
+func (e *HorizonError) IsInsufficientFee() bool {
+	return e.ResultCode() == "tx_insufficient_fee"
+}
+
+// IsTxFailed reports whether one or more operations failed.
+func (e *HorizonError) IsTxFailed() bool {
+	return e.ResultCode() == "tx_failed" || e.ResultCode() == "tx_fee_bump_inner_failed"
+}
+
+// IsTimeout reports whether Horizon gave up waiting for the transaction to be
+// included, in which case it may still be applied later.
+func (e *HorizonError) IsTimeout() bool {
+	return e.Status == 504
+}
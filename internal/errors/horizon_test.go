@@ -0,0 +1,61 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const txFailedProblem = `{
+	"type": "https://stellar.org/horizon-errors/transaction_failed",
+	"title": "Transaction Failed",
+	"status": 400,
+	"detail": "The transaction failed when submitted to the stellar network.",
+	"extras": {
+		"envelope_xdr": "AAAA",
+		"result_xdr": "AAAB",
+		"result_codes": {"transaction": "tx_failed", "operations": ["op_success", "op_underfunded"]}
+	}
+}`
+
+func TestParseHorizonProblem(t *testing.T) {
+	e := ParseHorizonProblem("https://horizon", 400, []byte(txFailedProblem))
+
+	assert.Equal(t, "Transaction Failed", e.Title)
+	assert.Equal(t, "AAAB", e.ResultXDR)
+	assert.Equal(t, "op_underfunded", e.OperationCode(1))
+	assert.Equal(t, "", e.OperationCode(5))
+	assert.True(t, e.IsTxFailed())
+	assert.False(t, e.IsTxBadSeq())
+	assert.Contains(t, e.Error(), "[tx_failed: op_success, op_underfunded]")
+
+	assert.True(t, errors.Is(e, ErrTxFailed))
+	assert.True(t, errors.Is(e, ErrRPCError))
+
+	var codeErr *RPCCodeError
+	require.True(t, errors.As(e, &codeErr))
+	assert.Equal(t, 400, codeErr.Code)
+}
+
+func TestHorizonErrorHelpers(t *testing.T) {
+	badSeq := NewHorizonError("h", 400, "", "Transaction Failed", "", map[string]interface{}{
+		"result_codes": map[string]interface{}{"transaction": "tx_bad_seq"},
+	})
+	assert.True(t, badSeq.IsTxBadSeq())
+	assert.True(t, errors.Is(badSeq, ErrTxBadSeq))
+
+	feeBump := NewHorizonError("h", 400, "", "", "", map[string]interface{}{
+		"result_codes": map[string]interface{}{"transaction": "tx_fee_bump_inner_failed", "inner_transaction": "tx_insufficient_fee"},
+	})
+	assert.True(t, feeBump.IsInsufficientFee())
+
+	limited := ParseHorizonProblem("h", 429, []byte("not json"))
+	assert.True(t, errors.Is(limited, ErrRateLimitExceeded))
+	assert.False(t, errors.Is(limited, ErrTxResultRejected))
+	assert.True(t, ParseHorizonProblem("h", 504, nil).IsTimeout())
+}
@@ -0,0 +1,151 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Operation result code classes, matching Horizon's op_* result codes. Codes
+// that mean the same thing for different operations, such as op_no_trust and
+// op_sell_no_trust, share a sentinel.
+var (
+	ErrOpBadAuth               = errors.New("operation has too few valid signatures")
+	ErrOpNoSourceAccount       = errors.New("operation source account not found")
+	ErrOpNotSupported          = errors.New("operation not supported")
+	ErrOpTooManySubentries     = errors.New("account has too many subentries")
+	ErrOpExceededWorkLimit     = errors.New("operation exceeded the work limit")
+	ErrOpTooManySponsoring     = errors.New("account is sponsoring too many entries")
+	ErrOpMalformed             = errors.New("operation is malformed")
+	ErrOpUnderfunded           = errors.New("source account has insufficient funds")
+	ErrOpLowReserve            = errors.New("account would fall below its minimum reserve")
+	ErrOpAlreadyExists         = errors.New("account already exists")
+	ErrOpSrcNoTrust            = errors.New("source account has no trustline for the asset")
+	ErrOpSrcNotAuthorized      = errors.New("source account is not authorized to send the asset")
+	ErrOpNoDestination         = errors.New("destination account not found")
+	ErrOpNoTrust               = errors.New("account has no trustline for the asset")
+	ErrOpNotAuthorized         = errors.New("account is not authorized to hold the asset")
+	ErrOpLineFull              = errors.New("destination trustline limit would be exceeded")
+	ErrOpNoIssuer              = errors.New("asset issuer not found")
+	ErrOpTooFewOffers          = errors.New("not enough offers to satisfy the path")
+	ErrOpCrossSelf             = errors.New("offer would cross an offer from the same account")
+	ErrOpOverSourceMax         = errors.New("path payment would exceed the maximum send amount")
+	ErrOpUnderDestMin          = errors.New("path payment would deliver less than the minimum amount")
+	ErrOpOfferNotFound         = errors.New("offer not found")
+	ErrOpTooManySigners        = errors.New("account has too many signers")
+	ErrOpInvalidLimit          = errors.New("trustline limit is below the current balance")
+	ErrOpHasSubEntries         = errors.New("account has subentries")
+	ErrOpImmutableSet          = errors.New("account has AUTH_IMMUTABLE set")
+	ErrOpDestFull              = errors.New("destination balance would overflow")
+	ErrOpBadSeq                = errors.New("bump sequence target is invalid")
+	ErrOpDoesNotExist          = errors.New("ledger entry does not exist")
+	ErrOpCannotClaim           = errors.New("claimable balance cannot be claimed")
+	ErrOpTrapped               = errors.New("contract invocation trapped")
+	ErrOpResourceLimitExceeded = errors.New("soroban resource limit exceeded")
+	ErrOpEntryArchived         = errors.New("soroban footprint entry is archived")
+	ErrOpInsufficientRefundFee = errors.New("refundable fee is too small")
+)
+
+var opResultSentinels = map[string]error{
+	"op_bad_auth":                    ErrOpBadAuth,
+	"op_no_source_account":           ErrOpNoSourceAccount,
+	"op_not_supported":               ErrOpNotSupported,
+	"op_too_many_subentries":         ErrOpTooManySubentries,
+	"op_exceeded_work_limit":         ErrOpExceededWorkLimit,
+	"op_too_many_sponsoring":         ErrOpTooManySponsoring,
+	"op_malformed":                   ErrOpMalformed,
+	"op_underfunded":                 ErrOpUnderfunded,
+	"op_low_reserve":                 ErrOpLowReserve,
+	"op_already_exists":              ErrOpAlreadyExists,
+	"op_src_no_trust":                ErrOpSrcNoTrust,
+	"op_src_not_authorized":          ErrOpSrcNotAuthorized,
+	"op_no_destination":              ErrOpNoDestination,
+	"op_no_account":                  ErrOpNoDestination,
+	"op_no_trust":                    ErrOpNoTrust,
+	"op_no_trustline":                ErrOpNoTrust,
+	"op_sell_no_trust":               ErrOpNoTrust,
+	"op_buy_no_trust":                ErrOpNoTrust,
+	"op_not_authorized":              ErrOpNotAuthorized,
+	"op_sell_not_authorized":         ErrOpNotAuthorized,
+	"op_buy_not_authorized":          ErrOpNotAuthorized,
+	"op_line_full":                   ErrOpLineFull,
+	"op_no_issuer":                   ErrOpNoIssuer,
+	"op_sell_no_issuer":              ErrOpNoIssuer,
+	"op_buy_no_issuer":               ErrOpNoIssuer,
+	"op_too_few_offers":              ErrOpTooFewOffers,
+	"op_cross_self":                  ErrOpCrossSelf,
+	"op_offer_cross_self":            ErrOpCrossSelf,
+	"op_over_source_max":             ErrOpOverSourceMax,
+	"op_under_dest_min":              ErrOpUnderDestMin,
+	"op_offer_not_found":             ErrOpOfferNotFound,
+	"op_too_many_signers":            ErrOpTooManySigners,
+	"op_invalid_limit":               ErrOpInvalidLimit,
+	"op_has_sub_entries":             ErrOpHasSubEntries,
+	"op_immutable_set":               ErrOpImmutableSet,
+	"op_dest_full":                   ErrOpDestFull,
+	"op_bad_seq":                     ErrOpBadSeq,
+	"op_does_not_exist":              ErrOpDoesNotExist,
+	"op_cannot_claim":                ErrOpCannotClaim,
+	"function_trapped":               ErrOpTrapped,
+	"op_resource_limit_exceeded":     ErrOpResourceLimitExceeded,
+	"op_entry_archived":              ErrOpEntryArchived,
+	"op_insufficient_refundable_fee": ErrOpInsufficientRefundFee,
+}
+
+// OpResultCodeError returns the sentinel for an operation result code, or nil
+// for op_success and unknown codes.
+func OpResultCodeError(code string) error {
+	return opResultSentinels[strings.ToLower(code)]
+}
+
+// OperationError is the failure of a single operation within a transaction.
+// It matches the sentinel for its result code with errors.Is.
+type OperationError struct {
+	Index int
+	Code  string
+}
+
+func (e *OperationError) Error() string {
+	if sentinel := OpResultCodeError(e.Code); sentinel != nil {
+		return fmt.Sprintf("operation %d failed: %s (%v)", e.Index, e.Code, sentinel)
+	}
+	return fmt.Sprintf("operation %d failed: %s", e.Index, e.Code)
+}
+
+func (e *OperationError) Is(target error) bool {
+	sentinel := OpResultCodeError(e.Code)
+	return sentinel != nil && target == sentinel
+}
+
+// Category is always CategoryTxFailed: the transaction was applied and this
+// operation failed.
+func (e *OperationError) Category() Category {
+	return CategoryTxFailed
+}
+
+// failedOperations returns an OperationError for each code other than
+// op_success, keeping the operation's index in the transaction.
+func failedOperations(codes []string) []*OperationError {
+	var out []*OperationError
+	for i, code := range codes {
+		if code == "" || code == "op_success" {
+			continue
+		}
+		out = append(out, &OperationError{Index: i, Code: code})
+	}
+	return out
+}
+
+// matchesOpResultCodes reports whether any operation failed with the code
+// target stands for.
+func matchesOpResultCodes(codes []string, target error) bool {
+	for _, code := range codes {
+		if sentinel := OpResultCodeError(code); sentinel != nil && target == sentinel {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpResultCodeError(t *testing.T) {
+	assert.Equal(t, ErrOpUnderfunded, OpResultCodeError("op_underfunded"))
+	assert.Equal(t, ErrOpNoTrust, OpResultCodeError("op_sell_no_trust"))
+	assert.Equal(t, ErrOpNoDestination, OpResultCodeError("OP_NO_ACCOUNT"))
+	assert.Nil(t, OpResultCodeError("op_success"))
+	assert.Nil(t, OpResultCodeError("op_unknown"))
+}
+
+func TestOperationCodesMatchSentinels(t *testing.T) {
+	e := ParseHorizonProblem("https://horizon", 400, []byte(txFailedProblem))
+	assert.True(t, errors.Is(e, ErrOpUnderfunded))
+	assert.False(t, errors.Is(e, ErrOpNoTrust))
+
+	ops := e.FailedOperations()
+	require.Len(t, ops, 1)
+	assert.Equal(t, 1, ops[0].Index)
+	assert.True(t, errors.Is(ops[0], ErrOpUnderfunded))
+	assert.Equal(t, "operation 1 failed: op_underfunded (source account has insufficient funds)", ops[0].Error())
+	assert.Equal(t, CategoryTxFailed, CategoryOf(ops[0]))
+
+	res := NewTransactionResultError("tx_failed", []string{"op_no_trust"})
+	assert.True(t, errors.Is(res, ErrOpNoTrust))
+	assert.True(t, errors.Is(res, ErrTxFailed))
+	assert.False(t, errors.Is(res, ErrOpUnderfunded))
+	assert.Len(t, res.FailedOperations(), 1)
+}
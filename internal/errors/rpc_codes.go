@@ -0,0 +1,318 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// JSON-RPC 2.0 error classes returned by Soroban RPC.
+var (
+	ErrRPCParseError     = errors.New("JSON-RPC parse error")
+	ErrRPCInvalidRequest = errors.New("JSON-RPC invalid request")
+	ErrRPCMethodNotFound = errors.New("JSON-RPC method not found")
+	ErrRPCInvalidParams  = errors.New("JSON-RPC invalid params")
+	ErrRPCInternalError  = errors.New("JSON-RPC internal error")
+)
+
+// sendTransaction statuses that indicate the transaction was not accepted.
+var (
+	ErrTxStatusError    = errors.New("transaction rejected by RPC")
+	ErrTxTryAgainLater  = errors.New("transaction not accepted, try again later")
+	ErrTxDuplicate      = errors.New("transaction already submitted")
+	ErrTxResultRejected = errors.New("transaction result indicates failure")
+)
+
+// Transaction result code classes, matching Horizon's tx_* result codes.
+var (
+	ErrTxFailed              = errors.New("one or more operations failed")
+	ErrTxTooEarly            = errors.New("transaction submitted before its time bounds")
+	ErrTxTooLate             = errors.New("transaction submitted after its time bounds")
+	ErrTxMissingOperation    = errors.New("transaction has no operations")
+	ErrTxBadSeq              = errors.New("bad sequence number")
+	ErrTxBadAuth             = errors.New("too few valid signatures or wrong network")
+	ErrTxInsufficientBalance = errors.New("fee would bring account below reserve")
+	ErrTxNoAccount           = errors.New("source account not found")
+	ErrTxInsufficientFee     = errors.New("fee is too small")
+	ErrTxBadAuthExtra        = errors.New("unused signatures attached to transaction")
+	ErrTxInternalError       = errors.New("unknown error in transaction processing")
+	ErrTxNotSupported        = errors.New("transaction type not supported")
+	ErrTxBadSponsorship      = errors.New("sponsorship not confirmed")
+	ErrTxBadMinSeqAgeOrGap   = errors.New("minSeqAge or minSeqLedgerGap conditions not met")
+	ErrTxMalformed           = errors.New("transaction is malformed")
+	ErrTxSorobanInvalid      = errors.New("soroban-specific preconditions not met")
+)
+
+// Pre-submission envelope checks, which report the usual causes of
+// tx_bad_auth before the network does.
+var (
+	ErrWrongNetwork     = errors.New("transaction signed for a different network")
+	ErrInvalidSignature = errors.New("transaction signature does not verify")
+)
+
+var rpcCodeSentinels = map[int]error{
+	-32700: ErrRPCParseError,
+	-32600: ErrRPCInvalidRequest,
+	-32601: ErrRPCMethodNotFound,
+	-32602: ErrRPCInvalidParams,
+	-32603: ErrRPCInternalError,
+}
+
+var sendStatusSentinels = map[string]error{
+	"ERROR":           ErrTxStatusError,
+	"TRY_AGAIN_LATER": ErrTxTryAgainLater,
+	"DUPLICATE":       ErrTxDuplicate,
+}
+
+var txResultSentinels = map[string]error{
+	"tx_failed":                 ErrTxFailed,
+	"tx_fee_bump_inner_failed":  ErrTxFailed,
+	"tx_too_early":              ErrTxTooEarly,
+	"tx_too_late":               ErrTxTooLate,
+	"tx_missing_operation":      ErrTxMissingOperation,
+	"tx_bad_seq":                ErrTxBadSeq,
+	"tx_bad_auth":               ErrTxBadAuth,
+	"tx_insufficient_balance":   ErrTxInsufficientBalance,
+	"tx_no_account":             ErrTxNoAccount,
+	"tx_no_source_account":      ErrTxNoAccount,
+	"tx_insufficient_fee":       ErrTxInsufficientFee,
+	"tx_bad_auth_extra":         ErrTxBadAuthExtra,
+	"tx_internal_error":         ErrTxInternalError,
+	"tx_not_supported":          ErrTxNotSupported,
+	"tx_bad_sponsorship":        ErrTxBadSponsorship,
+	"tx_bad_min_seq_age_or_gap": ErrTxBadMinSeqAgeOrGap,
+	"tx_malformed":              ErrTxMalformed,
+	"tx_soroban_invalid":        ErrTxSorobanInvalid,
+}
+
+// RPCCodeError is a JSON-RPC error response. It matches ErrRPCError and the
+// sentinel for its code class with errors.Is.
+type RPCCodeError struct {
+	URL     string
+	Code    int
+	Message string
+}
+
+func (e *RPCCodeError) Error() string {
+	return fmt.Sprintf("%v from %s: %s (code %d)", ErrRPCError, e.URL, e.Message, e.Code)
+}
+
+func (e *RPCCodeError) Is(target error) bool {
+	if target == ErrRPCError {
+		return true
+	}
+	if target == ErrRateLimitExceeded {
+		return e.Code == 429
+	}
+	if target == ErrUnauthorized {
+		return e.Code == 401 || e.Code == 403
+	}
+	sentinel, ok := rpcCodeSentinels[e.Code]
+	if !ok && e.Code <= -32000 && e.Code >= -32099 {
+		// Implementation-defined server errors.
+		sentinel = ErrRPCInternalError
+	}
+	return sentinel != nil && target == sentinel
+}
+
+// SendTransactionError describes a sendTransaction call whose status was not
+// PENDING. ResultCode is the transaction result code decoded from
+// errorResultXdr, when the RPC supplied one.
+type SendTransactionError struct {
+	Status     string
+	Hash       string
+	ResultCode string
+	// InnerResultCode is the inner transaction's code for fee-bump
+	// submissions rejected with tx_fee_bump_inner_failed.
+	InnerResultCode string
+}
+
+// NewSendTransactionError builds a typed error for a sendTransaction status.
+// resultCode may be a Horizon-style code (tx_bad_seq) or an XDR enum name
+// (TransactionResultCodeTxBadSeq).
+func NewSendTransactionError(status, hash, resultCode string) *SendTransactionError {
+	return &SendTransactionError{
+		Status:     strings.ToUpper(status),
+		Hash:       hash,
+		ResultCode: NormalizeTxResultCode(resultCode),
+	}
+}
+
+// WithInnerResultCode records the inner transaction's result code of a
+// fee-bump submission.
+func (e *SendTransactionError) WithInnerResultCode(code string) *SendTransactionError {
+	e.InnerResultCode = NormalizeTxResultCode(code)
+	return e
+}
+
+func (e *SendTransactionError) Error() string {
+	msg := fmt.Sprintf("transaction %s: status %s", e.Hash, e.Status)
+	if e.ResultCode != "" {
+		msg += " (" + formatResultCode(e.ResultCode, e.InnerResultCode) + ")"
+	}
+	return msg
+}
+
+func (e *SendTransactionError) Is(target error) bool {
+	if s, ok := sendStatusSentinels[e.Status]; ok && target == s {
+		return true
+	}
+	if e.ResultCode == "" {
+		return false
+	}
+	return matchesTxResultCode(e.ResultCode, target) || matchesInnerResultCode(e.InnerResultCode, target)
+}
+
+// TransactionResultError is a failed transaction result with the per-operation
+// result codes, as reported by Horizon or decoded from TransactionResult XDR.
+// It matches the tx_* and op_* result code sentinels with errors.Is.
+type TransactionResultError struct {
+	ResultCode     string
+	OperationCodes []string
+	// InnerResultCode is the inner transaction's code when ResultCode is
+	// tx_fee_bump_inner_failed; OperationCodes then belong to the inner
+	// transaction.
+	InnerResultCode string
+	// Contract is the contract error behind a failed Soroban invocation,
+	// when the result meta recorded one.
+	Contract *ContractError
+}
+
+// NewTransactionResultError builds a typed error for a transaction result code.
+func NewTransactionResultError(resultCode string, operationCodes []string) *TransactionResultError {
+	return &TransactionResultError{
+		ResultCode:     NormalizeTxResultCode(resultCode),
+		OperationCodes: operationCodes,
+	}
+}
+
+// WithInnerResultCode records the inner transaction's result code of a
+// fee-bump transaction.
+func (e *TransactionResultError) WithInnerResultCode(code string) *TransactionResultError {
+	e.InnerResultCode = NormalizeTxResultCode(code)
+	return e
+}
+
+// WithContractError records the contract error that made the transaction
+// fail.
+func (e *TransactionResultError) WithContractError(c *ContractError) *TransactionResultError {
+	e.Contract = c
+	return e
+}
+
+func (e *TransactionResultError) Error() string {
+	msg := "transaction failed: " + formatResultCode(e.ResultCode, e.InnerResultCode)
+	if len(e.OperationCodes) > 0 {
+		msg += " [" + strings.Join(e.OperationCodes, ", ") + "]"
+	}
+	if e.Contract != nil {
+		msg += ": " + e.Contract.Error()
+	}
+	return msg
+}
+
+// Unwrap exposes the contract error, if any, to errors.As.
+func (e *TransactionResultError) Unwrap() error {
+	if e.Contract == nil {
+		return nil
+	}
+	return e.Contract
+}
+
+func (e *TransactionResultError) Is(target error) bool {
+	return matchesTxResultCode(e.ResultCode, target) || matchesInnerResultCode(e.InnerResultCode, target) ||
+		matchesOpResultCodes(e.OperationCodes, target)
+}
+
+// FailedOperations returns the operations that did not succeed, in
+// transaction order.
+func (e *TransactionResultError) FailedOperations() []*OperationError {
+	return failedOperations(e.OperationCodes)
+}
+
+// matchesInnerResultCode lets the inner code of a fee-bump, such as
+// tx_bad_seq, match its sentinel directly. ErrTxResultRejected is left to
+// the outer code.
+func matchesInnerResultCode(code string, target error) bool {
+	return code != "" && target != ErrTxResultRejected && matchesTxResultCode(code, target)
+}
+
+func formatResultCode(code, inner string) string {
+	if inner == "" {
+		return code
+	}
+	return code + ": " + inner
+}
+
+func matchesTxResultCode(code string, target error) bool {
+	if target == ErrTxResultRejected {
+		return true
+	}
+	sentinel, ok := txResultSentinels[code]
+	return ok && target == sentinel
+}
+
+// NormalizeTxResultCode converts an XDR enum name such as
+// TransactionResultCodeTxBadSeq into Horizon's tx_bad_seq form. Codes already
+// in snake case are returned lower-cased.
+func NormalizeTxResultCode(code string) string {
+	code = strings.TrimPrefix(code, "TransactionResultCode")
+	if code == "" || strings.Contains(code, "_") {
+		return strings.ToLower(code)
+	}
+	var b strings.Builder
+	for i, r := range code {
+		if unicode.IsUpper(r) && i > 0 {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// TxResultCodeError returns the sentinel for a transaction result code, or nil
+// for tx_success and unknown codes.
+func TxResultCodeError(code string) error {
+	return txResultSentinels[NormalizeTxResultCode(code)]
+}
+
+// EnvelopeSignatureError describes signatures that do not verify against
+// the transaction hash for the network it is about to be submitted to.
+// SignedFor names another known network the signatures do verify for, in
+// which case the error matches ErrWrongNetwork and ErrNetworkMismatch, and
+// ErrInvalidSignature otherwise. Either way it also matches ErrTxBadAuth,
+// the result the network would have returned.
+type EnvelopeSignatureError struct {
+	Network   string
+	SignedFor string
+	// Invalid lists the failing signatures, such as "signature 0" or
+	// "inner signature 1" for a fee bump's inner transaction.
+	Invalid []string
+}
+
+// NewEnvelopeSignatureError builds the error for signatures invalid on
+// network. signedFor is empty when they verify for no known network.
+func NewEnvelopeSignatureError(network, signedFor string, invalid []string) *EnvelopeSignatureError {
+	return &EnvelopeSignatureError{Network: network, SignedFor: signedFor, Invalid: invalid}
+}
+
+func (e *EnvelopeSignatureError) Error() string {
+	if e.SignedFor != "" {
+		return fmt.Sprintf("%v: signed for %s, but the client is configured for %s", ErrWrongNetwork, e.SignedFor, e.Network)
+	}
+	return fmt.Sprintf("%v on %s: %s was not produced by a signer of the transaction's accounts", ErrInvalidSignature, e.Network, strings.Join(e.Invalid, ", "))
+}
+
+func (e *EnvelopeSignatureError) Is(target error) bool {
+	if target == ErrTxBadAuth {
+		return true
+	}
+	if e.SignedFor != "" {
+		return target == ErrWrongNetwork || target == ErrNetworkMismatch
+	}
+	return target == ErrInvalidSignature
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrapRPCErrorCodeClasses(t *testing.T) {
+	err := WrapRPCError("https://rpc", "invalid params", -32602)
+
+	assert.True(t, errors.Is(err, ErrRPCError))
+	assert.True(t, errors.Is(err, ErrRPCInvalidParams))
+	assert.False(t, errors.Is(err, ErrRPCInternalError))
+	assert.Equal(t, "RPC server returned an error from https://rpc: invalid params (code -32602)", err.Error())
+
+	var codeErr *RPCCodeError
+	assert.True(t, errors.As(err, &codeErr))
+	assert.Equal(t, -32602, codeErr.Code)
+
+	assert.True(t, errors.Is(WrapRPCError("u", "busy", -32001), ErrRPCInternalError))
+	assert.True(t, errors.Is(WrapRPCError("u", "slow down", 429), ErrRateLimitExceeded))
+	assert.True(t, errors.Is(WrapRPCError("u", "forbidden", 403), ErrUnauthorized))
+}
+
+func TestSendTransactionError(t *testing.T) {
+	err := NewSendTransactionError("TRY_AGAIN_LATER", "abc", "")
+	assert.True(t, errors.Is(err, ErrTxTryAgainLater))
+	assert.False(t, errors.Is(err, ErrTxResultRejected))
+
+	err = NewSendTransactionError("error", "abc", "TransactionResultCodeTxBadSeq")
+	assert.True(t, errors.Is(err, ErrTxStatusError))
+	assert.True(t, errors.Is(err, ErrTxBadSeq))
+	assert.True(t, errors.Is(err, ErrTxResultRejected))
+	assert.False(t, errors.Is(err, ErrTxDuplicate))
+	assert.Contains(t, err.Error(), "tx_bad_seq")
+
+	assert.True(t, errors.Is(NewSendTransactionError("DUPLICATE", "abc", ""), ErrTxDuplicate))
+}
+
+func TestTransactionResultError(t *testing.T) {
+	err := NewTransactionResultError("tx_failed", []string{"op_underfunded"})
+	assert.True(t, errors.Is(err, ErrTxFailed))
+	assert.False(t, errors.Is(err, ErrTxBadSeq))
+	assert.Equal(t, "transaction failed: tx_failed [op_underfunded]", err.Error())
+
+	assert.False(t, errors.Is(NewTransactionResultError("tx_unknown", nil), ErrTxFailed))
+}
+
+func TestFeeBumpInnerResultCodes(t *testing.T) {
+	err := NewTransactionResultError("tx_fee_bump_inner_failed", []string{"op_underfunded"}).
+		WithInnerResultCode("TransactionResultCodeTxFailed")
+	assert.True(t, errors.Is(err, ErrTxFailed))
+	assert.Equal(t, "transaction failed: tx_fee_bump_inner_failed: tx_failed [op_underfunded]", err.Error())
+
+	sendErr := NewSendTransactionError("ERROR", "abc", "tx_fee_bump_inner_failed").WithInnerResultCode("tx_bad_seq")
+	assert.True(t, errors.Is(sendErr, ErrTxBadSeq), "inner code matches its sentinel")
+	assert.True(t, errors.Is(sendErr, ErrTxResultRejected))
+	assert.False(t, errors.Is(sendErr, ErrTxInsufficientFee))
+	assert.Contains(t, sendErr.Error(), "tx_fee_bump_inner_failed: tx_bad_seq")
+}
+
+func TestNormalizeTxResultCode(t *testing.T) {
+	assert.Equal(t, "tx_bad_seq", NormalizeTxResultCode("TransactionResultCodeTxBadSeq"))
+	assert.Equal(t, "tx_fee_bump_inner_failed", NormalizeTxResultCode("TransactionResultCodeTxFeeBumpInnerFailed"))
+	assert.Equal(t, "tx_insufficient_fee", NormalizeTxResultCode("TX_INSUFFICIENT_FEE"))
+	assert.Equal(t, "", NormalizeTxResultCode(""))
+	assert.Equal(t, ErrTxSorobanInvalid, TxResultCodeError("TransactionResultCodeTxSorobanInvalid"))
+	assert.Nil(t, TxResultCodeError("tx_success"))
+}
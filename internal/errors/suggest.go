@@ -0,0 +1,133 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// opHints are the remediation hints for operation result codes, keyed by
+// the codes' sentinels.
+var opHints = map[error]string{
+	ErrOpUnderfunded:           "source account has insufficient funds — fund the account or reduce the amount",
+	ErrOpLowReserve:            "account would fall below its minimum reserve — fund it with more XLM or remove subentries",
+	ErrOpAlreadyExists:         "account already exists — use a Payment instead of CreateAccount",
+	ErrOpSrcNoTrust:            "source account has no trustline for the asset — add a ChangeTrust op for the source",
+	ErrOpNoTrust:               "account has no trustline for the asset — add a ChangeTrust op before using it",
+	ErrOpSrcNotAuthorized:      "source account is not authorized for the asset — ask the issuer to authorize the trustline",
+	ErrOpNotAuthorized:         "account is not authorized for the asset — ask the issuer to authorize the trustline",
+	ErrOpNoDestination:         "destination account does not exist — create it with CreateAccount first",
+	ErrOpLineFull:              "destination trustline is full — raise its limit with ChangeTrust",
+	ErrOpNoIssuer:              "asset issuer does not exist — check the asset code and issuer",
+	ErrOpTooFewOffers:          "not enough liquidity on the path — try a different path or a smaller amount",
+	ErrOpOverSourceMax:         "path payment would cost more than sendMax — raise sendMax or retry later",
+	ErrOpUnderDestMin:          "path payment would deliver less than destMin — lower destMin or retry later",
+	ErrOpTooManySubentries:     "account has too many subentries — remove offers, trustlines or data entries",
+	ErrOpHasSubEntries:         "account still has subentries — remove its offers, trustlines and data entries before merging",
+	ErrOpBadAuth:               "operation source lacks valid signatures — sign with the operation source's key",
+	ErrOpNoSourceAccount:       "operation source account does not exist — create it or change the operation source",
+	ErrOpTrapped:               "contract invocation trapped — simulate the transaction to see the contract's diagnostic events",
+	ErrOpResourceLimitExceeded: "soroban resources were exceeded — re-simulate and use the returned resource limits",
+	ErrOpEntryArchived:         "entry archived — submit RestoreFootprint first",
+	ErrOpInsufficientRefundFee: "refundable fee too small — re-simulate and use the returned resource fee",
+}
+
+// suggestionRules are checked in order; every matching rule contributes its
+// hint.
+var suggestionRules = []struct {
+	sentinel error
+	hint     string
+}{
+	{ErrTxBadSeq, "sequence number is stale — reload the source account and rebuild the transaction"},
+	{ErrTxInsufficientFee, "fee is below the network minimum — raise the fee or check current fee stats"},
+	{ErrTxInsufficientBalance, "source account cannot cover the fee — fund the account"},
+	{ErrTxNoAccount, "source account does not exist — create and fund it first"},
+	{ErrTxTooLate, "transaction expired — rebuild it with new time bounds"},
+	{ErrTxTooEarly, "transaction is not valid yet — wait until its time bounds open"},
+	{ErrWrongNetwork, "transaction was signed for another network — sign it with this network's passphrase"},
+	{ErrTxBadAuth, "signatures are missing or invalid — sign with every required key for this network"},
+	{ErrTxBadAuthExtra, "transaction carries unused signatures — remove them"},
+	{ErrTxSorobanInvalid, "soroban data is invalid — re-simulate and use the returned footprint and resources"},
+	{ErrTxTryAgainLater, "network is congested — resubmit later or raise the fee"},
+	{ErrTxDuplicate, "transaction was already submitted — wait for its result instead of resubmitting"},
+	{ErrMemoRequired, "destination requires a memo — add the memo the recipient asked for"},
+	{ErrLedgerArchived, "ledger is no longer held by the RPC — use a history archive or an RPC with longer retention"},
+	{ErrMissingLedgerKey, "footprint is missing a ledger key — re-simulate to rebuild the footprint"},
+	{ErrUnauthorized, "request was rejected as unauthorized — check the API key or credentials for this endpoint"},
+	{ErrRPCResponseTooLarge, "response exceeded the server's size limit — narrow the request"},
+	{ErrAllRPCFailed, "no endpoint answered — check connectivity or configure additional RPC URLs"},
+	{ErrRPCTimeout, "request timed out — retry, or raise the client timeout"},
+	{ErrSimulatorNotFound, "simulator binary not found — build it or set its path in the configuration"},
+}
+
+// Suggest returns actionable hints for the well-known failures in err, in
+// the order they should be tried, or nil when there are none. Failed
+// operations get one hint each, prefixed with their index.
+func Suggest(err error) []string {
+	if err == nil {
+		return nil
+	}
+	var hints []string
+	seen := make(map[string]bool)
+	add := func(hint string) {
+		if !seen[hint] {
+			seen[hint] = true
+			hints = append(hints, hint)
+		}
+	}
+
+	for _, op := range failedOperationsOf(err) {
+		if hint, ok := opHints[OpResultCodeError(op.Code)]; ok {
+			add(fmt.Sprintf("operation %d: %s", op.Index, hint))
+		}
+	}
+
+	var contractErr *ContractError
+	if errors.As(err, &contractErr) {
+		if contractErr.Resolved() {
+			add(fmt.Sprintf("contract rejected the call with %s — check the arguments and contract state it depends on", contractErr.QualifiedName()))
+		} else {
+			add("contract rejected the call — load its spec to name the error code")
+		}
+	}
+
+	var mismatch *NetworkMismatchError
+	if errors.As(err, &mismatch) {
+		add(fmt.Sprintf("%s serves %q — point the client at an endpoint for %q or change the configured network", mismatch.URL, mismatch.Actual, mismatch.Expected))
+	}
+
+	if errors.Is(err, ErrRateLimitExceeded) {
+		if wait, ok := RetryAfter(err); ok {
+			add(fmt.Sprintf("rate limited — wait %s before retrying", wait))
+		} else {
+			add("rate limited — back off before retrying, or use an endpoint with a higher limit")
+		}
+	}
+
+	for _, rule := range suggestionRules {
+		if errors.Is(err, rule.sentinel) {
+			add(rule.hint)
+		}
+	}
+	return hints
+}
+
+// failedOperationsOf returns the failed operations recorded anywhere in
+// err's chain.
+func failedOperationsOf(err error) []*OperationError {
+	var hErr *HorizonError
+	if errors.As(err, &hErr) {
+		return hErr.FailedOperations()
+	}
+	var txErr *TransactionResultError
+	if errors.As(err, &txErr) {
+		return txErr.FailedOperations()
+	}
+	var opErr *OperationError
+	if errors.As(err, &opErr) {
+		return []*OperationError{opErr}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggest(t *testing.T) {
+	assert.Nil(t, Suggest(nil))
+	assert.Nil(t, Suggest(fmt.Errorf("something odd")))
+
+	res := NewTransactionResultError("tx_failed", []string{"op_success", "op_no_trust"})
+	assert.Equal(t, []string{
+		"operation 1: account has no trustline for the asset — add a ChangeTrust op before using it",
+	}, Suggest(fmt.Errorf("submit: %w", res)))
+
+	archived := NewTransactionResultError("tx_failed", []string{"op_entry_archived"})
+	assert.Contains(t, Suggest(archived), "operation 0: entry archived — submit RestoreFootprint first")
+
+	assert.Equal(t, []string{"sequence number is stale — reload the source account and rebuild the transaction"},
+		Suggest(NewTransactionResultError("tx_bad_seq", nil)))
+
+	assert.Equal(t, []string{"rate limited — wait 2s before retrying"}, Suggest(WrapRateLimited(2*time.Second)))
+
+	contract := &ContractError{ContractID: "CABC", Code: 3, Enum: "TokenError", Name: "InsufficientBalance"}
+	hints := Suggest(NewTransactionResultError("tx_failed", []string{"function_trapped"}).WithContractError(contract))
+	assert.Len(t, hints, 2)
+	assert.Contains(t, hints[1], "TokenError::InsufficientBalance")
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/network"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+)
+
+// AccountThresholds sets the master key weight and the operation
+// thresholds of a new account. Nil fields keep the protocol defaults.
+type AccountThresholds struct {
+	MasterWeight *txnbuild.Threshold
+	Low          *txnbuild.Threshold
+	Medium       *txnbuild.Threshold
+	High         *txnbuild.Threshold
+}
+
+func (t *AccountThresholds) empty() bool {
+	return t == nil || (t.MasterWeight == nil && t.Low == nil && t.Medium == nil && t.High == nil)
+}
+
+// AccountIntent creates Account, funded by Funder, and configures its
+// signers and thresholds in the same transaction. Configuring the new
+// account requires its master key to sign as well as Funder.
+type AccountIntent struct {
+	// Funder creates the account and pays the fee. Onboard uses friendbot
+	// on test networks when it is empty.
+	Funder  string
+	Account string
+	// StartingBalance is the native amount sent to Account. It is required
+	// unless Sponsor is set, and must cover the reserve for Signers.
+	StartingBalance string
+	// Sponsor makes Funder pay the reserves of the account and its signers,
+	// so it can start with a zero balance.
+	Sponsor    bool
+	Signers    []txnbuild.Signer
+	Thresholds *AccountThresholds
+}
+
+func (i *AccountIntent) Source() string { return i.Funder }
+
+func (i *AccountIntent) Validate() error {
+	if i.Funder == "" {
+		return errors.WrapValidationError("funder account is required; use Onboard to fund from friendbot")
+	}
+	if err := validateAccount("funder", i.Funder); err != nil {
+		return err
+	}
+	if err := i.validateAccount(); err != nil {
+		return err
+	}
+	if underlying(i.Funder) == underlying(i.Account) {
+		return errors.WrapValidationError("an account cannot fund itself")
+	}
+	if i.StartingBalance == "" && i.Sponsor {
+		return nil
+	}
+	return validateAmount("starting balance", i.StartingBalance)
+}
+
+// validateAccount checks the parts shared with friendbot funding.
+func (i *AccountIntent) validateAccount() error {
+	if !strkey.IsValidEd25519PublicKey(i.Account) {
+		return errors.WrapValidationError(fmt.Sprintf("new account must be a G... address, got %q", i.Account))
+	}
+	seen := make(map[string]bool, len(i.Signers))
+	for _, s := range i.Signers {
+		if s.Address == "" || s.Address == i.Account {
+			return errors.WrapValidationError(fmt.Sprintf("invalid additional signer %q", s.Address))
+		}
+		if seen[s.Address] {
+			return errors.WrapValidationError(fmt.Sprintf("duplicate signer %s", s.Address))
+		}
+		seen[s.Address] = true
+	}
+	if t := i.Thresholds; t != nil && t.MasterWeight != nil && *t.MasterWeight == 0 && len(i.Signers) == 0 {
+		return errors.WrapValidationError("master weight 0 without other signers would lock the account")
+	}
+	return nil
+}
+
+// Operations checks that Account does not exist yet, then creates it and
+// configures its signers and thresholds, under sponsorship if requested.
+func (i *AccountIntent) Operations(ctx context.Context, client *rpc.Client) ([]txnbuild.Operation, error) {
+	if err := checkAbsent(ctx, client, i.Account); err != nil {
+		return nil, err
+	}
+	balance := i.StartingBalance
+	if balance == "" {
+		balance = "0"
+	}
+	ops := append([]txnbuild.Operation{&txnbuild.CreateAccount{Destination: i.Account, Amount: balance}}, i.configure()...)
+	if !i.Sponsor {
+		for _, op := range ops[1:] {
+			op.(*txnbuild.SetOptions).SourceAccount = i.Account
+		}
+		return ops, nil
+	}
+	sponsored := &SponsoredIntent{Sponsor: i.Funder, Sponsored: i.Account, List: ops}
+	if err := sponsored.Validate(); err != nil {
+		return nil, err
+	}
+	return sponsored.Operations(ctx, client)
+}
+
+// configure returns the set options operations for Signers and Thresholds.
+// Thresholds come last so that a master weight of 0 only applies once the
+// other signers exist.
+func (i *AccountIntent) configure() []txnbuild.Operation {
+	var ops []txnbuild.Operation
+	for k := range i.Signers {
+		ops = append(ops, &txnbuild.SetOptions{Signer: &i.Signers[k]})
+	}
+	if t := i.Thresholds; !t.empty() {
+		ops = append(ops, &txnbuild.SetOptions{
+			MasterWeight:    t.MasterWeight,
+			LowThreshold:    t.Low,
+			MediumThreshold: t.Medium,
+			HighThreshold:   t.High,
+		})
+	}
+	return ops
+}
+
+// checkAbsent fails unless account does not exist yet.
+func checkAbsent(ctx context.Context, client *rpc.Client, account string) error {
+	_, err := client.AccountDetails(ctx, account)
+	switch {
+	case errors.Is(err, errors.ErrAccountNotFound):
+		return nil
+	case err != nil:
+		return err
+	}
+	return errors.WrapValidationError(fmt.Sprintf("account %s already exists", account))
+}
+
+// OnboardResult reports how an account was created.
+type OnboardResult struct {
+	// Friendbot is set when friendbot funded the account.
+	Friendbot bool
+	// Transactions are the confirmed transactions, in order.
+	Transactions []*hProtocol.Transaction
+}
+
+// Onboard creates and configures an account in one call. With a Funder it
+// submits in; signers must then include Funder and, when signers or
+// thresholds are configured, the new account's master key. Without a
+// Funder, on test networks only, friendbot funds the account and a second
+// transaction from the account itself applies the configuration; Sponsor
+// and StartingBalance do not apply then.
+func Onboard(ctx context.Context, client *rpc.Client, in *AccountIntent, signers []Signer, policy RetryPolicy) (*OnboardResult, error) {
+	if client == nil {
+		return nil, errors.WrapValidationError("rpc client is required")
+	}
+	if in.Funder != "" {
+		res, err := SubmitWithRetry(ctx, client, in, signers, policy)
+		if err != nil {
+			return nil, err
+		}
+		return &OnboardResult{Transactions: []*hProtocol.Transaction{res.Tx}}, nil
+	}
+
+	if err := in.validateAccount(); err != nil {
+		return nil, err
+	}
+	if client.Network == rpc.Mainnet || client.GetNetworkPassphrase() == network.PublicNetworkPassphrase {
+		return nil, errors.WrapValidationError("friendbot is only available on test networks; a funder is required")
+	}
+	if in.Sponsor {
+		return nil, errors.WrapValidationError("friendbot cannot sponsor an account; a funder is required")
+	}
+	if err := checkAbsent(ctx, client, in.Account); err != nil {
+		return nil, err
+	}
+
+	logger.Logger.Info("Funding account from friendbot", "account", in.Account)
+	funded, err := client.Horizon.Fund(in.Account)
+	if err != nil {
+		return nil, errors.WrapRPCConnectionFailed(fmt.Errorf("friendbot: %w", err))
+	}
+	out := &OnboardResult{Friendbot: true, Transactions: []*hProtocol.Transaction{&funded}}
+
+	ops := in.configure()
+	if len(ops) == 0 {
+		return out, nil
+	}
+	res, err := SubmitWithRetry(ctx, client, NewOps(in.Account, ops...), signers, policy)
+	if err != nil {
+		return out, fmt.Errorf("account %s was funded but not configured: %w", in.Account, err)
+	}
+	out.Transactions = append(out.Transactions, res.Tx)
+	return out, nil
+}
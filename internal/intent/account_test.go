@@ -0,0 +1,105 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// friendbotHorizon creates every account it is asked to fund.
+type friendbotHorizon struct {
+	*submitHorizon
+	funded []string
+}
+
+func (h *friendbotHorizon) Fund(addr string) (hProtocol.Transaction, error) {
+	h.funded = append(h.funded, addr)
+	h.accounts[addr] = testAccount(addr, 100<<32)
+	return hProtocol.Transaction{Hash: "friendbot", Successful: true}, nil
+}
+
+func TestAccountIntent_Operations(t *testing.T) {
+	newcomer, cosigner := keypair.MustRandom(), keypair.MustRandom()
+	client := newTestClient(t, newTestHorizon(testAccount(testSource, 1)))
+	ctx := context.Background()
+	zero, two := txnbuild.Threshold(0), txnbuild.Threshold(2)
+	in := &AccountIntent{
+		Funder:          testSource,
+		Account:         newcomer.Address(),
+		StartingBalance: "5",
+		Signers:         []txnbuild.Signer{{Address: cosigner.Address(), Weight: 2}},
+		Thresholds:      &AccountThresholds{MasterWeight: &zero, Medium: &two, High: &two},
+	}
+	require.NoError(t, in.Validate())
+	ops, err := in.Operations(ctx, client)
+	require.NoError(t, err)
+	require.Len(t, ops, 3)
+	assert.Equal(t, "5", ops[0].(*txnbuild.CreateAccount).Amount)
+	assert.Equal(t, cosigner.Address(), ops[1].(*txnbuild.SetOptions).Signer.Address)
+	assert.Equal(t, newcomer.Address(), ops[1].GetSourceAccount())
+	assert.Equal(t, &zero, ops[2].(*txnbuild.SetOptions).MasterWeight, "thresholds come after the signers")
+
+	in.Sponsor, in.StartingBalance = true, ""
+	require.NoError(t, in.Validate())
+	ops, err = in.Operations(ctx, client)
+	require.NoError(t, err)
+	require.Len(t, ops, 5)
+	assert.IsType(t, &txnbuild.BeginSponsoringFutureReserves{}, ops[0])
+	assert.Equal(t, testSource, ops[1].GetSourceAccount())
+	assert.Equal(t, "0", ops[1].(*txnbuild.CreateAccount).Amount)
+	assert.IsType(t, &txnbuild.EndSponsoringFutureReserves{}, ops[4])
+
+	_, err = (&AccountIntent{Funder: newcomer.Address(), Account: testSource, StartingBalance: "1"}).Operations(ctx, client)
+	assert.ErrorIs(t, err, errs.ErrValidationFailed, "existing accounts are not recreated")
+}
+
+func TestAccountIntent_Validate(t *testing.T) {
+	newcomer := keypair.MustRandom().Address()
+	zero := txnbuild.Threshold(0)
+	for name, in := range map[string]*AccountIntent{
+		"no funder":   {Account: newcomer, StartingBalance: "1"},
+		"no balance":  {Funder: testSource, Account: newcomer},
+		"muxed":       {Funder: testSource, Account: "M" + newcomer[1:], StartingBalance: "1"},
+		"self signer": {Funder: testSource, Account: newcomer, StartingBalance: "1", Signers: []txnbuild.Signer{{Address: newcomer, Weight: 1}}},
+		"locked":      {Funder: testSource, Account: newcomer, StartingBalance: "1", Thresholds: &AccountThresholds{MasterWeight: &zero}},
+	} {
+		assert.ErrorIs(t, in.Validate(), errs.ErrValidationFailed, name)
+	}
+}
+
+func TestOnboard_Friendbot(t *testing.T) {
+	newcomer, cosigner := keypair.MustRandom(), keypair.MustRandom()
+	h := &friendbotHorizon{submitHorizon: newSubmitHorizon()}
+	client := newTestClient(t, h)
+	ctx := context.Background()
+
+	res, err := Onboard(ctx, client, &AccountIntent{
+		Account: newcomer.Address(),
+		Signers: []txnbuild.Signer{{Address: cosigner.Address(), Weight: 1}},
+	}, []Signer{LocalSignerFromKeypair(newcomer)}, RetryPolicy{Poll: testPoll})
+	require.NoError(t, err)
+	assert.True(t, res.Friendbot)
+	assert.Len(t, res.Transactions, 2)
+	assert.Equal(t, []string{newcomer.Address()}, h.funded)
+	require.Len(t, h.submitted, 1)
+	assert.Equal(t, newcomer.Address(), h.submitted[0].SourceAccount().AccountID)
+
+	other := keypair.MustRandom().Address()
+	_, err = Onboard(ctx, client, &AccountIntent{Account: other, Sponsor: true}, nil, RetryPolicy{Poll: testPoll})
+	assert.ErrorIs(t, err, errs.ErrValidationFailed, "friendbot cannot sponsor")
+
+	client.Network = rpc.Mainnet
+	_, err = Onboard(ctx, client, &AccountIntent{Account: other}, nil, RetryPolicy{Poll: testPoll})
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+	assert.Len(t, h.funded, 1)
+}
@@ -0,0 +1,244 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/dotandev/hintents/internal/rpc"
+)
+
+// Step is one intent of a Batch.
+type Step struct {
+	// ID names the step for DependsOn and in results.
+	ID     string
+	Intent Intent
+	// DependsOn lists steps that must be confirmed before this one is
+	// resolved, for example the account creation a trustline needs.
+	DependsOn []string
+	// Signers sign this step; Batch.Signers are used when empty. Every
+	// signature must be needed, or the network rejects the transaction.
+	Signers []Signer
+	Options []Option
+}
+
+// StepStatus is the outcome of one step.
+type StepStatus string
+
+const (
+	StepPending   StepStatus = "pending"
+	StepConfirmed StepStatus = "confirmed"
+	StepFailed    StepStatus = "failed"
+	// StepSkipped means a dependency did not confirm.
+	StepSkipped StepStatus = "skipped"
+)
+
+// StepResult reports what happened to one step.
+type StepResult struct {
+	ID     string     `json:"id"`
+	Status StepStatus `json:"status"`
+	Hash   string     `json:"hash,omitempty"`
+	Ledger int32      `json:"ledger,omitempty"`
+	Err    error      `json:"-"`
+}
+
+// Batch submits intents in dependency order. Steps whose dependencies are
+// all confirmed form a wave: a wave is submitted back to back, with
+// sequence numbers reserved locally so several steps may share a source,
+// and confirmed before the next wave is resolved against the new ledger
+// state.
+type Batch struct {
+	Steps   []Step
+	Signers []Signer
+	// Poll controls the wait for confirmation; rpc.DefaultPollConfig when
+	// zero.
+	Poll rpc.PollConfig
+	// Sequences is shared with other submitters using the same source
+	// accounts; a private manager is used when nil.
+	Sequences *SequenceManager
+}
+
+// Order returns the steps grouped into waves, keeping the declared order
+// within each wave. Unknown or duplicate IDs and cycles are errors.
+func (b *Batch) Order() ([][]*Step, error) {
+	index := make(map[string]int, len(b.Steps))
+	for k, s := range b.Steps {
+		if s.ID == "" {
+			return nil, errors.WrapValidationError(fmt.Sprintf("step %d has no id", k))
+		}
+		if s.Intent == nil {
+			return nil, errors.WrapValidationError(fmt.Sprintf("step %q has no intent", s.ID))
+		}
+		if _, dup := index[s.ID]; dup {
+			return nil, errors.WrapValidationError(fmt.Sprintf("duplicate step id %q", s.ID))
+		}
+		index[s.ID] = k
+	}
+	for _, s := range b.Steps {
+		for _, dep := range s.DependsOn {
+			if _, ok := index[dep]; !ok {
+				return nil, errors.WrapValidationError(fmt.Sprintf("step %q depends on unknown step %q", s.ID, dep))
+			}
+		}
+	}
+
+	placed := make(map[string]bool, len(b.Steps))
+	var waves [][]*Step
+	for len(placed) < len(b.Steps) {
+		var wave []*Step
+		for k := range b.Steps {
+			s := &b.Steps[k]
+			if placed[s.ID] {
+				continue
+			}
+			ready := true
+			for _, dep := range s.DependsOn {
+				if !placed[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				wave = append(wave, s)
+			}
+		}
+		if len(wave) == 0 {
+			return nil, errors.WrapValidationError("steps have a dependency cycle")
+		}
+		for _, s := range wave {
+			placed[s.ID] = true
+		}
+		waves = append(waves, wave)
+	}
+	return waves, nil
+}
+
+// Run resolves, signs and submits every step. Results are returned in the
+// order of Steps. The error is non-nil when the batch could not be planned
+// or when any step did not confirm; results are returned in both cases once
+// planning succeeded.
+func (b *Batch) Run(ctx context.Context, client *rpc.Client) ([]StepResult, error) {
+	waves, err := b.Order()
+	if err != nil {
+		return nil, err
+	}
+	poll := b.Poll
+	if poll == (rpc.PollConfig{}) {
+		poll = rpc.DefaultPollConfig()
+	}
+
+	results := make(map[string]*StepResult, len(b.Steps))
+	for _, s := range b.Steps {
+		results[s.ID] = &StepResult{ID: s.ID, Status: StepPending}
+	}
+	sequences := b.Sequences
+	if sequences == nil {
+		sequences = NewSequenceManager(client)
+	}
+
+	for _, wave := range waves {
+		var submitted []*StepResult
+		for _, s := range wave {
+			r := results[s.ID]
+			if dep := failedDependency(s, results); dep != "" {
+				r.Status = StepSkipped
+				r.Err = errors.WrapValidationError(fmt.Sprintf("dependency %q did not confirm", dep))
+				continue
+			}
+			if err := b.submit(ctx, client, s, r, sequences); err != nil {
+				r.Status, r.Err = StepFailed, err
+				continue
+			}
+			submitted = append(submitted, r)
+		}
+
+		for _, r := range submitted {
+			tx, err := client.WaitForTransaction(ctx, r.Hash, poll)
+			if tx != nil {
+				r.Ledger = tx.Ledger
+			}
+			if err != nil {
+				r.Status, r.Err = StepFailed, err
+				continue
+			}
+			r.Status = StepConfirmed
+			logger.Logger.Debug("Batch step confirmed", "step", r.ID, "hash", r.Hash, "ledger", r.Ledger)
+		}
+	}
+
+	out := make([]StepResult, 0, len(b.Steps))
+	var failed int
+	var first error
+	for _, s := range b.Steps {
+		r := results[s.ID]
+		if r.Status != StepConfirmed {
+			failed++
+			if first == nil {
+				first = r.Err
+			}
+		}
+		out = append(out, *r)
+	}
+	if failed > 0 {
+		return out, fmt.Errorf("%d of %d batch steps did not confirm: %w", failed, len(out), first)
+	}
+	return out, nil
+}
+
+func (b *Batch) submit(ctx context.Context, client *rpc.Client, s *Step, r *StepResult, sequences *SequenceManager) error {
+	seq, err := sequences.Reserve(ctx, s.Intent.Source())
+	if err != nil {
+		return err
+	}
+	env, err := b.build(ctx, client, s, seq)
+	if err != nil {
+		seq.Release()
+		return err
+	}
+	res, err := client.SubmitTransactionAsync(ctx, env)
+	if res != nil {
+		r.Hash = res.Hash
+	}
+	if err != nil {
+		seq.Fail(err)
+		return err
+	}
+	seq.Commit()
+	return nil
+}
+
+// build resolves and signs s, returning the envelope.
+func (b *Batch) build(ctx context.Context, client *rpc.Client, s *Step, seq *Reservation) (string, error) {
+	opts := append(append([]Option{}, s.Options...), seq.Option())
+	p, err := Resolve(ctx, client, s.Intent, opts...)
+	if err != nil {
+		return "", err
+	}
+	signers := s.Signers
+	if len(signers) == 0 {
+		signers = b.Signers
+	}
+	tx, err := SignTransaction(ctx, p.Tx, client.GetNetworkPassphrase(), signers...)
+	if err != nil {
+		return "", err
+	}
+	env, err := tx.Base64()
+	if err != nil {
+		return "", errors.WrapMarshalFailed(err)
+	}
+	return env, nil
+}
+
+// failedDependency returns the first dependency of s that did not confirm.
+func failedDependency(s *Step, results map[string]*StepResult) string {
+	for _, dep := range s.DependsOn {
+		if results[dep].Status != StepConfirmed {
+			return dep
+		}
+	}
+	return ""
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"testing"
+	"time"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/network"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// submitHorizon accepts every submission and applies account creations, so
+// dependent steps see the new accounts. Transactions listed in fail are
+// reported as failed on inclusion; unknown hashes are not found.
+type submitHorizon struct {
+	*testHorizon
+	submitted []*txnbuild.Transaction
+	fail      map[int]bool
+	included  map[string]bool
+}
+
+func newSubmitHorizon(accounts ...hProtocol.Account) *submitHorizon {
+	return &submitHorizon{testHorizon: newTestHorizon(accounts...), fail: map[int]bool{}, included: map[string]bool{}}
+}
+
+func (h *submitHorizon) AsyncSubmitTransactionXDR(env string) (hProtocol.AsyncTransactionSubmissionResponse, error) {
+	gen, err := txnbuild.TransactionFromXDR(env)
+	if err != nil {
+		return hProtocol.AsyncTransactionSubmissionResponse{}, err
+	}
+	tx, _ := gen.Transaction()
+	hash, err := tx.HashHex(network.TestNetworkPassphrase)
+	if err != nil {
+		return hProtocol.AsyncTransactionSubmissionResponse{}, err
+	}
+	h.included[hash] = !h.fail[len(h.submitted)]
+	h.submitted = append(h.submitted, tx)
+	if h.included[hash] {
+		for _, op := range tx.Operations() {
+			if c, ok := op.(*txnbuild.CreateAccount); ok {
+				h.accounts[c.Destination] = testAccount(c.Destination, 100<<32)
+			}
+		}
+	}
+	return hProtocol.AsyncTransactionSubmissionResponse{TxStatus: "PENDING", Hash: hash}, nil
+}
+
+func (h *submitHorizon) TransactionDetail(hash string) (hProtocol.Transaction, error) {
+	ok, known := h.included[hash]
+	if !known {
+		return hProtocol.Transaction{}, &horizonclient.Error{Problem: problem.P{Status: http.StatusNotFound}}
+	}
+	return hProtocol.Transaction{Hash: hash, Ledger: 7, Successful: ok}, nil
+}
+
+func testBatch(newcomer *keypair.Full, source *keypair.Full) *Batch {
+	return &Batch{
+		Steps: []Step{
+			{ID: "trust", Intent: NewOps(newcomer.Address(), &txnbuild.ChangeTrust{Line: txnbuild.ChangeTrustAssetWrapper{Asset: testUSDC()}, Limit: "1000"}), DependsOn: []string{"create"}, Signers: []Signer{LocalSignerFromKeypair(newcomer)}},
+			{ID: "create", Intent: &PaymentIntent{From: source.Address(), To: newcomer.Address(), Asset: txnbuild.NativeAsset{}, Amount: "5"}},
+			{ID: "bump", Intent: NewOps(source.Address(), &txnbuild.BumpSequence{BumpTo: 0})},
+		},
+		Signers: []Signer{LocalSignerFromKeypair(source)},
+		Poll:    rpc.PollConfig{Interval: time.Millisecond, Timeout: time.Second},
+	}
+}
+
+func TestBatch_Run(t *testing.T) {
+	source, newcomer := keypair.MustRandom(), keypair.MustRandom()
+	h := newSubmitHorizon(testAccount(source.Address(), 10))
+	client := newTestClient(t, h)
+
+	results, err := testBatch(newcomer, source).Run(context.Background(), client)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	for _, r := range results {
+		assert.Equal(t, StepConfirmed, r.Status, r.ID)
+		assert.Equal(t, int32(7), r.Ledger)
+	}
+	assert.Equal(t, "trust", results[0].ID, "results follow the declared order")
+
+	require.Len(t, h.submitted, 3)
+	assert.IsType(t, &txnbuild.CreateAccount{}, h.submitted[0].Operations()[0])
+	assert.Equal(t, int64(11), h.submitted[0].SequenceNumber())
+	assert.Equal(t, int64(12), h.submitted[1].SequenceNumber(), "same-wave steps share the source")
+	assert.Equal(t, newcomer.Address(), h.submitted[2].SourceAccount().AccountID)
+	hash, err := h.submitted[2].Hash(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(hash[:]), results[0].Hash)
+}
+
+func TestBatch_SkipsDependentsOfFailures(t *testing.T) {
+	source, newcomer := keypair.MustRandom(), keypair.MustRandom()
+	h := newSubmitHorizon(testAccount(source.Address(), 10))
+	h.fail[0] = true
+	client := newTestClient(t, h)
+
+	results, err := testBatch(newcomer, source).Run(context.Background(), client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2 of 3")
+	assert.Equal(t, StepSkipped, results[0].Status)
+	assert.Equal(t, StepFailed, results[1].Status)
+	assert.Equal(t, StepConfirmed, results[2].Status)
+	assert.Len(t, h.submitted, 2)
+}
+
+func TestBatch_Order(t *testing.T) {
+	op := NewOps(testSource, &txnbuild.BumpSequence{})
+	b := &Batch{Steps: []Step{
+		{ID: "c", Intent: op, DependsOn: []string{"a", "b"}},
+		{ID: "a", Intent: op},
+		{ID: "b", Intent: op, DependsOn: []string{"a"}},
+		{ID: "d", Intent: op},
+	}}
+	waves, err := b.Order()
+	require.NoError(t, err)
+	var ids [][]string
+	for _, w := range waves {
+		var wave []string
+		for _, s := range w {
+			wave = append(wave, s.ID)
+		}
+		ids = append(ids, wave)
+	}
+	assert.Equal(t, [][]string{{"a", "d"}, {"b"}, {"c"}}, ids)
+
+	for name, steps := range map[string][]Step{
+		"cycle":     {{ID: "a", Intent: op, DependsOn: []string{"b"}}, {ID: "b", Intent: op, DependsOn: []string{"a"}}},
+		"unknown":   {{ID: "a", Intent: op, DependsOn: []string{"z"}}},
+		"duplicate": {{ID: "a", Intent: op}, {ID: "a", Intent: op}},
+		"no intent": {{ID: "a"}},
+	} {
+		_, err := (&Batch{Steps: steps}).Order()
+		assert.ErrorIs(t, err, errs.ErrValidationFailed, name)
+	}
+}
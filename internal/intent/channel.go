@@ -0,0 +1,398 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/amount"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+)
+
+const (
+	// DefaultChannelCheckInterval is how often Run checks channel balances.
+	DefaultChannelCheckInterval = time.Minute
+	// DefaultChannelMaxFailures is how many consecutive channel faults take
+	// a channel out of rotation.
+	DefaultChannelMaxFailures = 3
+	// DefaultChannelMinBalance is the native balance, in stroops, below
+	// which a channel is reported for top-up: the base reserve plus room
+	// for a few thousand fees.
+	DefaultChannelMinBalance int64 = 2 * amount.One
+)
+
+// ChannelPoolConfig configures a ChannelPool.
+type ChannelPoolConfig struct {
+	// MinBalance in stroops below which OnLowBalance is called. Defaults to
+	// DefaultChannelMinBalance.
+	MinBalance int64
+	// MaxFailures consecutive channel faults take a channel out of rotation
+	// until a check finds it usable again. Defaults to
+	// DefaultChannelMaxFailures.
+	MaxFailures int
+	// Interval between checks in Run. Defaults to
+	// DefaultChannelCheckInterval.
+	Interval time.Duration
+	// OnLowBalance is called for every channel below MinBalance on each
+	// check.
+	OnLowBalance func(ChannelStatus)
+	// Poll controls the wait for confirmation in Submit;
+	// rpc.DefaultPollConfig when zero.
+	Poll rpc.PollConfig
+}
+
+// ChannelStatus is a snapshot of one channel account.
+type ChannelStatus struct {
+	Account string
+	// Balance is the native balance in stroops as of the last check.
+	Balance int64
+	Healthy bool
+	InUse   bool
+	// Failures counts consecutive channel faults.
+	Failures  int
+	LastError string
+}
+
+// channel is one pooled account. Its fields are guarded by ChannelPool.mu.
+type channel struct {
+	signer  Signer
+	balance int64
+	healthy bool
+	inUse   bool
+	// queued is set while c sits in ChannelPool.free.
+	queued   bool
+	failures int
+	lastErr  error
+}
+
+// ChannelPool implements the channel-account pattern. An account can have
+// only one transaction per ledger in flight, so services that submit faster
+// use a set of funded channel accounts as transaction sources and pay fees
+// from them, while every operation keeps the real account as its own
+// source. Each transaction must then be signed by both the channel and the
+// real account.
+type ChannelPool struct {
+	client    *rpc.Client
+	config    ChannelPoolConfig
+	sequences *SequenceManager
+
+	mu       sync.Mutex
+	channels []*channel
+	free     chan *channel
+}
+
+// NewChannelPool creates a pool of the channel accounts controlled by
+// signers. Channels start in rotation; call Check to verify them first.
+func NewChannelPool(client *rpc.Client, cfg ChannelPoolConfig, signers ...Signer) (*ChannelPool, error) {
+	if client == nil {
+		return nil, errors.WrapValidationError("channel pool requires a client")
+	}
+	if len(signers) == 0 {
+		return nil, errors.WrapValidationError("channel pool requires at least one channel account")
+	}
+	if cfg.MinBalance <= 0 {
+		cfg.MinBalance = DefaultChannelMinBalance
+	}
+	if cfg.MaxFailures <= 0 {
+		cfg.MaxFailures = DefaultChannelMaxFailures
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultChannelCheckInterval
+	}
+	if cfg.Poll == (rpc.PollConfig{}) {
+		cfg.Poll = rpc.DefaultPollConfig()
+	}
+
+	p := &ChannelPool{
+		client:    client,
+		config:    cfg,
+		sequences: NewSequenceManager(client),
+		free:      make(chan *channel, len(signers)),
+	}
+	seen := make(map[string]bool, len(signers))
+	for _, s := range signers {
+		account := s.PublicKey()
+		if err := validateAccount("channel", account); err != nil {
+			return nil, err
+		}
+		if seen[account] {
+			return nil, errors.WrapValidationError(fmt.Sprintf("duplicate channel account %s", account))
+		}
+		seen[account] = true
+		c := &channel{signer: s, healthy: true}
+		p.channels = append(p.channels, c)
+		p.enqueueLocked(c)
+	}
+	return p, nil
+}
+
+// Channel is a checked-out channel account. Return it with Checkin.
+type Channel struct {
+	c    *channel
+	done bool
+}
+
+// Account is the channel's address.
+func (ch *Channel) Account() string { return ch.c.signer.PublicKey() }
+
+// Signer signs for the channel account.
+func (ch *Channel) Signer() Signer { return ch.c.signer }
+
+// Wrap returns in rebuilt with the channel as transaction source.
+func (ch *Channel) Wrap(in Intent) Intent {
+	return &channelIntent{channel: ch.Account(), inner: in}
+}
+
+// Checkout waits for a free, healthy channel until ctx is done.
+func (p *ChannelPool) Checkout(ctx context.Context) (*Channel, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case c := <-p.free:
+			p.mu.Lock()
+			c.queued = false
+			if !c.healthy {
+				// Taken out of rotation while it was queued.
+				p.mu.Unlock()
+				continue
+			}
+			c.inUse = true
+			p.mu.Unlock()
+			return &Channel{c: c}, nil
+		}
+	}
+}
+
+// Checkin returns ch to the pool. err is the outcome of its transaction:
+// faults of the channel itself, such as a bad sequence number or a fee it
+// cannot pay, count towards taking it out of rotation; failures of the
+// operations do not. Repeated calls are ignored.
+func (p *ChannelPool) Checkin(ch *Channel, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if ch.done {
+		return
+	}
+	ch.done = true
+	c := ch.c
+	c.inUse = false
+
+	if channelFault(err) {
+		c.failures++
+		c.lastErr = err
+		if c.failures >= p.config.MaxFailures && c.healthy {
+			c.healthy = false
+			logger.Logger.Warn("Channel taken out of rotation", "channel", ch.Account(), "failures", c.failures, "error", err)
+		}
+	} else {
+		c.failures = 0
+	}
+	if c.healthy {
+		p.enqueueLocked(c)
+	}
+}
+
+// enqueueLocked makes c available to Checkout. The buffer holds every
+// channel, so this never blocks. p.mu is held, except during construction.
+func (p *ChannelPool) enqueueLocked(c *channel) {
+	if !c.queued {
+		c.queued = true
+		p.free <- c
+	}
+}
+
+// channelFault reports whether err is the channel's fault rather than the
+// operations'.
+func channelFault(err error) bool {
+	if err == nil {
+		return false
+	}
+	for _, target := range []error{
+		errors.ErrTxBadSeq,
+		errors.ErrTxInsufficientBalance,
+		errors.ErrTxNoAccount,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Submit runs in through a free channel: it resolves the intent with the
+// channel as source, signs it with the channel and signers, submits it and
+// waits for confirmation. signers must cover the accounts the operations
+// act for.
+func (p *ChannelPool) Submit(ctx context.Context, in Intent, signers []Signer, opts ...Option) (*hProtocol.Transaction, error) {
+	ch, err := p.Checkout(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := p.submit(ctx, ch, in, signers, opts)
+	p.Checkin(ch, err)
+	return tx, err
+}
+
+func (p *ChannelPool) submit(ctx context.Context, ch *Channel, in Intent, signers []Signer, opts []Option) (*hProtocol.Transaction, error) {
+	seq, err := p.sequences.Reserve(ctx, ch.Account())
+	if err != nil {
+		return nil, err
+	}
+	prepared, err := Resolve(ctx, p.client, ch.Wrap(in), append(append([]Option{}, opts...), seq.Option())...)
+	if err != nil {
+		seq.Release()
+		return nil, err
+	}
+	tx, err := SignTransaction(ctx, prepared.Tx, p.client.GetNetworkPassphrase(), append([]Signer{ch.Signer()}, signers...)...)
+	if err != nil {
+		seq.Release()
+		return nil, err
+	}
+	env, err := tx.Base64()
+	if err != nil {
+		seq.Release()
+		return nil, errors.WrapMarshalFailed(err)
+	}
+	res, err := p.client.SubmitTransactionAsync(ctx, env)
+	if err != nil {
+		seq.Fail(err)
+		return nil, err
+	}
+	seq.Commit()
+	return p.client.WaitForTransaction(ctx, res.Hash, p.config.Poll)
+}
+
+// Status returns a snapshot of every channel in the order they were given.
+func (p *ChannelPool) Status() []ChannelStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]ChannelStatus, 0, len(p.channels))
+	for _, c := range p.channels {
+		out = append(out, p.statusLocked(c))
+	}
+	return out
+}
+
+func (p *ChannelPool) statusLocked(c *channel) ChannelStatus {
+	s := ChannelStatus{
+		Account:  c.signer.PublicKey(),
+		Balance:  c.balance,
+		Healthy:  c.healthy,
+		InUse:    c.inUse,
+		Failures: c.failures,
+	}
+	if c.lastErr != nil {
+		s.LastError = c.lastErr.Error()
+	}
+	return s
+}
+
+// Check loads every channel account, resyncs its sequence number and
+// records its balance. Channels that cannot be loaded are taken out of
+// rotation; channels out of rotation that load with at least MinBalance are
+// put back. OnLowBalance is called for channels below MinBalance.
+func (p *ChannelPool) Check(ctx context.Context) []ChannelStatus {
+	p.mu.Lock()
+	channels := append([]*channel(nil), p.channels...)
+	p.mu.Unlock()
+
+	out := make([]ChannelStatus, 0, len(channels))
+	for _, c := range channels {
+		account := c.signer.PublicKey()
+		details, err := p.client.AccountDetails(ctx, account)
+
+		p.mu.Lock()
+		if err != nil {
+			c.lastErr = err
+			c.healthy = false
+			logger.Logger.Warn("Channel check failed", "channel", account, "error", err)
+		} else {
+			c.balance, _ = amount.ParseInt64(details.NativeBalance())
+			if !c.healthy && c.balance >= p.config.MinBalance {
+				c.healthy, c.failures, c.lastErr = true, 0, nil
+				if !c.inUse {
+					p.enqueueLocked(c)
+				}
+				logger.Logger.Info("Channel back in rotation", "channel", account)
+			}
+		}
+		status := p.statusLocked(c)
+		p.mu.Unlock()
+		out = append(out, status)
+
+		if err == nil && !status.InUse {
+			// Idle channels only; a resync under an in-flight transaction
+			// would hand its number out again.
+			if rerr := p.sequences.Resync(ctx, account); rerr != nil {
+				logger.Logger.Debug("Channel sequence resync failed", "channel", account, "error", rerr)
+			}
+		}
+		if err == nil && status.Balance < p.config.MinBalance {
+			logger.Logger.Warn("Channel balance low", "channel", account, "balance", amount.StringFromInt64(status.Balance))
+			if p.config.OnLowBalance != nil {
+				p.config.OnLowBalance(status)
+			}
+		}
+	}
+	return out
+}
+
+// Run checks the channels every Interval until ctx is cancelled.
+func (p *ChannelPool) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.config.Interval)
+	defer ticker.Stop()
+	for {
+		p.Check(ctx)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// channelIntent is inner with a channel account as transaction source.
+type channelIntent struct {
+	channel string
+	inner   Intent
+}
+
+func (i *channelIntent) Source() string { return i.channel }
+
+func (i *channelIntent) Validate() error {
+	if err := validateAccount("channel", i.channel); err != nil {
+		return err
+	}
+	return i.inner.Validate()
+}
+
+// Operations returns the inner operations, those without a source account
+// acting for the inner intent's source.
+func (i *channelIntent) Operations(ctx context.Context, client *rpc.Client) ([]txnbuild.Operation, error) {
+	ops, err := i.inner.Operations(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]txnbuild.Operation, 0, len(ops))
+	for _, op := range ops {
+		if op.GetSourceAccount() != "" {
+			out = append(out, op)
+			continue
+		}
+		withSource, err := withSourceAccount(op, i.inner.Source())
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, withSource)
+	}
+	return out, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/base"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fundedAccount(id string, seq int64, balance string) hProtocol.Account {
+	acc := testAccount(id, seq)
+	acc.Balances = []hProtocol.Balance{{Balance: balance, Asset: base.Asset{Type: "native"}}}
+	return acc
+}
+
+func TestChannelPool_Submit(t *testing.T) {
+	owner, dest, a, b := keypair.MustRandom(), keypair.MustRandom(), keypair.MustRandom(), keypair.MustRandom()
+	h := newSubmitHorizon(testAccount(owner.Address(), 1), testAccount(dest.Address(), 1),
+		testAccount(a.Address(), 10), testAccount(b.Address(), 20))
+	pool, err := NewChannelPool(newTestClient(t, h), ChannelPoolConfig{Poll: testPoll},
+		LocalSignerFromKeypair(a), LocalSignerFromKeypair(b))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	pay := &PaymentIntent{From: owner.Address(), To: dest.Address(), Asset: txnbuild.NativeAsset{}, Amount: "1"}
+	ownerSigner := []Signer{LocalSignerFromKeypair(owner)}
+	for i := 0; i < 3; i++ {
+		tx, err := pool.Submit(ctx, pay, ownerSigner)
+		require.NoError(t, err)
+		assert.True(t, tx.Successful)
+	}
+
+	require.Len(t, h.submitted, 3)
+	sources := []string{}
+	for _, tx := range h.submitted {
+		sources = append(sources, tx.SourceAccount().AccountID)
+		assert.Equal(t, owner.Address(), tx.Operations()[0].GetSourceAccount(), "operations keep the real source")
+		assert.Len(t, tx.Signatures(), 2)
+	}
+	assert.Equal(t, []string{a.Address(), b.Address(), a.Address()}, sources)
+	assert.Equal(t, int64(12), h.submitted[2].SequenceNumber(), "channel sequence is tracked locally")
+}
+
+func TestChannelPool_Health(t *testing.T) {
+	a := keypair.MustRandom()
+	h := newSubmitHorizon(fundedAccount(a.Address(), 1, "1.0000000"))
+	var low []ChannelStatus
+	pool, err := NewChannelPool(newTestClient(t, h), ChannelPoolConfig{
+		MaxFailures:  2,
+		OnLowBalance: func(s ChannelStatus) { low = append(low, s) },
+	}, LocalSignerFromKeypair(a))
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	badSeq := errs.NewSendTransactionError("ERROR", "", "tx_bad_seq")
+	for i := 0; i < 2; i++ {
+		ch, err := pool.Checkout(ctx)
+		require.NoError(t, err)
+		pool.Checkin(ch, badSeq)
+		pool.Checkin(ch, nil) // ignored
+	}
+	status := pool.Status()[0]
+	assert.False(t, status.Healthy)
+	assert.Equal(t, 2, status.Failures)
+	assert.Contains(t, status.LastError, "tx_bad_seq")
+
+	short, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = pool.Checkout(short)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "no channel in rotation")
+
+	// Below the minimum balance: reported, still out of rotation.
+	pool.Check(ctx)
+	require.Len(t, low, 1)
+	assert.Equal(t, int64(10_000_000), low[0].Balance)
+	assert.False(t, pool.Status()[0].Healthy)
+
+	h.accounts[a.Address()] = fundedAccount(a.Address(), 1, "50.0000000")
+	statuses := pool.Check(ctx)
+	assert.True(t, statuses[0].Healthy)
+	assert.Len(t, low, 1)
+	ch, err := pool.Checkout(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, a.Address(), ch.Account())
+
+	// Operation failures are not the channel's fault.
+	pool.Checkin(ch, errs.ErrTxFailed)
+	assert.Equal(t, 0, pool.Status()[0].Failures)
+}
+
+func TestNewChannelPool_Validation(t *testing.T) {
+	client := newTestClient(t, newTestHorizon())
+	_, err := NewChannelPool(client, ChannelPoolConfig{})
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+
+	kp := keypair.MustRandom()
+	_, err = NewChannelPool(client, ChannelPoolConfig{}, LocalSignerFromKeypair(kp), LocalSignerFromKeypair(kp))
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+}
@@ -0,0 +1,227 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// maxClaimants is the protocol limit on claimants per balance.
+const maxClaimants = 10
+
+// PredicateUnconditional may be claimed at any time.
+func PredicateUnconditional() xdr.ClaimPredicate {
+	return txnbuild.UnconditionalPredicate
+}
+
+// PredicateBefore may be claimed until t.
+func PredicateBefore(t time.Time) xdr.ClaimPredicate {
+	return txnbuild.BeforeAbsoluteTimePredicate(t.Unix())
+}
+
+// PredicateAfter may be claimed from t on, for example a refund to the
+// sender once the recipient's window has closed.
+func PredicateAfter(t time.Time) xdr.ClaimPredicate {
+	return txnbuild.NotPredicate(PredicateBefore(t))
+}
+
+// PredicateWithin may be claimed for d after the balance is created.
+func PredicateWithin(d time.Duration) xdr.ClaimPredicate {
+	return txnbuild.BeforeRelativeTimePredicate(int64(d / time.Second))
+}
+
+// PredicateBetween may be claimed from from until until.
+func PredicateBetween(from, until time.Time) xdr.ClaimPredicate {
+	return txnbuild.AndPredicate(PredicateAfter(from), PredicateBefore(until))
+}
+
+// PredicateNot inverts p.
+func PredicateNot(p xdr.ClaimPredicate) xdr.ClaimPredicate {
+	return txnbuild.NotPredicate(p)
+}
+
+// PredicateAll holds when every one of preds holds. The protocol only knows
+// binary conjunctions, so longer lists are nested; a single predicate is
+// returned as is.
+func PredicateAll(preds ...xdr.ClaimPredicate) xdr.ClaimPredicate {
+	return foldPredicates(preds, txnbuild.AndPredicate)
+}
+
+// PredicateAny holds when at least one of preds holds, nesting like
+// PredicateAll.
+func PredicateAny(preds ...xdr.ClaimPredicate) xdr.ClaimPredicate {
+	return foldPredicates(preds, txnbuild.OrPredicate)
+}
+
+func foldPredicates(preds []xdr.ClaimPredicate, join func(l, r xdr.ClaimPredicate) xdr.ClaimPredicate) xdr.ClaimPredicate {
+	if len(preds) == 0 {
+		return PredicateUnconditional()
+	}
+	out := preds[len(preds)-1]
+	for k := len(preds) - 2; k >= 0; k-- {
+		out = join(preds[k], out)
+	}
+	return out
+}
+
+// CreateClaimableBalanceIntent locks Amount of Asset from From in a balance
+// that only Claimants can claim, each under its own predicate. The balance
+// ID is known once the transaction is built; see ClaimableBalanceIDs.
+type CreateClaimableBalanceIntent struct {
+	From      string
+	Asset     txnbuild.Asset
+	Amount    string
+	Claimants []txnbuild.Claimant
+}
+
+func (i *CreateClaimableBalanceIntent) Source() string { return i.From }
+
+func (i *CreateClaimableBalanceIntent) Validate() error {
+	if err := validateAccount("source", i.From); err != nil {
+		return err
+	}
+	if i.Asset == nil {
+		return errors.WrapValidationError("claimable balance asset is required")
+	}
+	if err := validateAmount("claimable balance", i.Amount); err != nil {
+		return err
+	}
+	if len(i.Claimants) == 0 || len(i.Claimants) > maxClaimants {
+		return errors.WrapValidationError(fmt.Sprintf("a claimable balance needs 1 to %d claimants", maxClaimants))
+	}
+	seen := make(map[string]bool, len(i.Claimants))
+	for _, c := range i.Claimants {
+		if err := validateAccount("claimant", c.Destination); err != nil {
+			return err
+		}
+		if seen[c.Destination] {
+			return errors.WrapValidationError(fmt.Sprintf("duplicate claimant %s", c.Destination))
+		}
+		seen[c.Destination] = true
+	}
+	return nil
+}
+
+// Operations checks that From can send the asset, so op_underfunded and
+// op_no_trust surface here rather than on submission.
+func (i *CreateClaimableBalanceIntent) Operations(ctx context.Context, client *rpc.Client) ([]txnbuild.Operation, error) {
+	if !i.Asset.IsNative() && underlying(i.From) != i.Asset.GetIssuer() {
+		source, err := client.AccountDetails(ctx, i.From)
+		if err != nil {
+			return nil, err
+		}
+		if !holdsAsset(source, i.Asset) {
+			return nil, errors.WrapValidationError(fmt.Sprintf("source %s has no trustline for %s", i.From, assetString(i.Asset)))
+		}
+	}
+	return []txnbuild.Operation{&txnbuild.CreateClaimableBalance{
+		Amount:       i.Amount,
+		Asset:        i.Asset,
+		Destinations: i.Claimants,
+	}}, nil
+}
+
+// ClaimClaimableBalanceIntent claims the balance BalanceID into Claimant.
+type ClaimClaimableBalanceIntent struct {
+	Claimant string
+	// BalanceID is the hex balance ID, as Horizon reports it.
+	BalanceID string
+}
+
+func (i *ClaimClaimableBalanceIntent) Source() string { return i.Claimant }
+
+func (i *ClaimClaimableBalanceIntent) Validate() error {
+	if err := validateAccount("claimant", i.Claimant); err != nil {
+		return err
+	}
+	var id xdr.ClaimableBalanceId
+	if err := xdr.SafeUnmarshalHex(i.BalanceID, &id); err != nil {
+		return errors.WrapValidationError(fmt.Sprintf("invalid claimable balance id %q", i.BalanceID))
+	}
+	return nil
+}
+
+// Operations looks the balance up and checks that Claimant may claim it
+// now, so op_cannot_claim and op_does_not_exist surface here. The claimant
+// also needs a trustline for non-native assets.
+func (i *ClaimClaimableBalanceIntent) Operations(ctx context.Context, client *rpc.Client) ([]txnbuild.Operation, error) {
+	balance, err := client.ClaimableBalance(ctx, i.BalanceID)
+	if err != nil {
+		return nil, err
+	}
+	claimant := underlying(i.Claimant)
+	if !balance.ClaimableBy(claimant, time.Now()) {
+		return nil, errors.WrapValidationError(fmt.Sprintf("claimable balance %s cannot be claimed by %s now", i.BalanceID, claimant))
+	}
+	if balance.Asset != nil && !balance.Asset.IsNative() && claimant != balance.Asset.GetIssuer() {
+		account, err := client.AccountDetails(ctx, i.Claimant)
+		if err != nil {
+			return nil, err
+		}
+		if !holdsAsset(account, balance.Asset) {
+			return nil, errors.WrapValidationError(fmt.Sprintf("claimant %s has no trustline for %s", claimant, assetString(balance.Asset)))
+		}
+	}
+	return []txnbuild.Operation{&txnbuild.ClaimClaimableBalance{BalanceID: i.BalanceID}}, nil
+}
+
+// ClaimableBalanceIDs returns the IDs of the balances tx creates, in
+// operation order. They depend on the source account and sequence number,
+// so they are final once the transaction is built.
+func ClaimableBalanceIDs(tx *txnbuild.Transaction) ([]string, error) {
+	var ids []string
+	for k, op := range tx.Operations() {
+		if _, ok := op.(*txnbuild.CreateClaimableBalance); !ok {
+			continue
+		}
+		id, err := tx.ClaimableBalanceID(k)
+		if err != nil {
+			return nil, errors.WrapValidationError(fmt.Sprintf("failed to derive claimable balance id: %v", err))
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// CreatedClaimableBalanceIDs returns the IDs of the balances a submitted
+// transaction created, from its base64 TransactionResult such as Horizon's
+// result_xdr. Fee-bump results are unwrapped.
+func CreatedClaimableBalanceIDs(resultXDR string) ([]string, error) {
+	var result xdr.TransactionResult
+	if err := xdr.SafeUnmarshalBase64(resultXDR, &result); err != nil {
+		return nil, errors.WrapUnmarshalFailed(err, resultXDR)
+	}
+	results, ok := result.OperationResults()
+	if !ok {
+		return nil, nil
+	}
+	var ids []string
+	for _, r := range results {
+		tr, ok := r.GetTr()
+		if !ok {
+			continue
+		}
+		created, ok := tr.GetCreateClaimableBalanceResult()
+		if !ok {
+			continue
+		}
+		id, ok := created.GetBalanceId()
+		if !ok {
+			continue
+		}
+		s, err := xdr.MarshalHex(id)
+		if err != nil {
+			return nil, errors.WrapMarshalFailed(err)
+		}
+		ids = append(ids, s)
+	}
+	return ids, nil
+}
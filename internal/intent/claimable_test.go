@@ -0,0 +1,144 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBalanceID = "00000000da0d57da7d4850e7fc10d2a9d0ebc731f7afb40574c03395b17d49149b91f5be"
+
+// withClaimableBalance serves balance as the answer to every Horizon request.
+func withClaimableBalance(t *testing.T, client *rpc.Client, balance hProtocol.ClaimableBalance) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(balance)
+	}))
+	t.Cleanup(server.Close)
+	client.HorizonURL = server.URL
+	client.AltURLs = []string{server.URL}
+}
+
+func TestPredicates(t *testing.T) {
+	from, until := time.Unix(1000, 0), time.Unix(2000, 0)
+
+	between := PredicateBetween(from, until)
+	require.Equal(t, xdr.ClaimPredicateTypeClaimPredicateAnd, between.Type)
+	and := between.MustAndPredicates()
+	assert.Equal(t, xdr.ClaimPredicateTypeClaimPredicateNot, and[0].Type)
+	assert.Equal(t, xdr.Int64(1000), (*and[0].MustNotPredicate()).MustAbsBefore())
+	assert.Equal(t, xdr.Int64(2000), and[1].MustAbsBefore())
+
+	assert.Equal(t, xdr.Int64(3600), PredicateWithin(time.Hour).MustRelBefore())
+
+	all := PredicateAll(PredicateBefore(until), PredicateAfter(from), PredicateWithin(time.Minute))
+	outer := all.MustAndPredicates()
+	assert.Equal(t, xdr.ClaimPredicateTypeClaimPredicateBeforeAbsoluteTime, outer[0].Type)
+	assert.Len(t, outer[1].MustAndPredicates(), 2, "longer lists are nested")
+
+	assert.Equal(t, xdr.ClaimPredicateTypeClaimPredicateUnconditional, PredicateAny().Type)
+	single := PredicateAny(PredicateBefore(until))
+	assert.Equal(t, xdr.ClaimPredicateTypeClaimPredicateBeforeAbsoluteTime, single.Type)
+}
+
+func TestCreateClaimableBalanceIntent(t *testing.T) {
+	recipient := keypair.MustRandom().Address()
+	client := newTestClient(t, newTestHorizon(testAccount(testSource, 10)))
+	in := &CreateClaimableBalanceIntent{
+		From:   testSource,
+		Asset:  txnbuild.NativeAsset{},
+		Amount: "25",
+		Claimants: []txnbuild.Claimant{
+			txnbuild.NewClaimant(recipient, nil),
+			{Destination: testSource, Predicate: PredicateAfter(time.Now().Add(24 * time.Hour))},
+		},
+	}
+	p, err := Resolve(context.Background(), client, in, WithBaseFee(100))
+	require.NoError(t, err)
+
+	ids, err := ClaimableBalanceIDs(p.Tx)
+	require.NoError(t, err)
+	require.Len(t, ids, 1)
+	want, err := p.Tx.ClaimableBalanceID(0)
+	require.NoError(t, err)
+	assert.Equal(t, want, ids[0])
+
+	// The same ID is reported by the result once the transaction applied.
+	var id xdr.ClaimableBalanceId
+	require.NoError(t, xdr.SafeUnmarshalHex(ids[0], &id))
+	results := []xdr.OperationResult{{
+		Code: xdr.OperationResultCodeOpInner,
+		Tr: &xdr.OperationResultTr{
+			Type: xdr.OperationTypeCreateClaimableBalance,
+			CreateClaimableBalanceResult: &xdr.CreateClaimableBalanceResult{
+				Code:      xdr.CreateClaimableBalanceResultCodeCreateClaimableBalanceSuccess,
+				BalanceId: &id,
+			},
+		},
+	}}
+	resultXDR, err := xdr.MarshalBase64(xdr.TransactionResult{
+		FeeCharged: 100,
+		Result:     xdr.TransactionResultResult{Code: xdr.TransactionResultCodeTxSuccess, Results: &results},
+	})
+	require.NoError(t, err)
+	created, err := CreatedClaimableBalanceIDs(resultXDR)
+	require.NoError(t, err)
+	assert.Equal(t, ids, created)
+
+	for name, bad := range map[string]*CreateClaimableBalanceIntent{
+		"no claimants": {From: testSource, Asset: txnbuild.NativeAsset{}, Amount: "1"},
+		"duplicate": {From: testSource, Asset: txnbuild.NativeAsset{}, Amount: "1",
+			Claimants: []txnbuild.Claimant{txnbuild.NewClaimant(recipient, nil), txnbuild.NewClaimant(recipient, nil)}},
+		"amount": {From: testSource, Asset: txnbuild.NativeAsset{}, Amount: "0",
+			Claimants: []txnbuild.Claimant{txnbuild.NewClaimant(recipient, nil)}},
+	} {
+		assert.ErrorIs(t, bad.Validate(), errs.ErrValidationFailed, name)
+	}
+}
+
+func TestClaimClaimableBalanceIntent(t *testing.T) {
+	claimant := keypair.MustRandom().Address()
+	client := newTestClient(t, newTestHorizon())
+	created := time.Now().Add(-time.Hour)
+	withClaimableBalance(t, client, hProtocol.ClaimableBalance{
+		BalanceID:        testBalanceID,
+		Asset:            "native",
+		Amount:           "25.0000000",
+		LastModifiedTime: &created,
+		Claimants: []hProtocol.Claimant{
+			{Destination: claimant, Predicate: PredicateUnconditional()},
+			// The sender's refund window has not opened yet.
+			{Destination: testSource, Predicate: PredicateAfter(time.Now().Add(time.Hour))},
+		},
+	})
+	ctx := context.Background()
+
+	in := &ClaimClaimableBalanceIntent{Claimant: claimant, BalanceID: testBalanceID}
+	require.NoError(t, in.Validate())
+	ops, err := in.Operations(ctx, client)
+	require.NoError(t, err)
+	require.Len(t, ops, 1)
+	assert.Equal(t, testBalanceID, ops[0].(*txnbuild.ClaimClaimableBalance).BalanceID)
+
+	early := &ClaimClaimableBalanceIntent{Claimant: testSource, BalanceID: testBalanceID}
+	_, err = early.Operations(ctx, client)
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+
+	bad := &ClaimClaimableBalanceIntent{Claimant: claimant, BalanceID: "not-an-id"}
+	assert.ErrorIs(t, bad.Validate(), errs.ErrValidationFailed)
+}
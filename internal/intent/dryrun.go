@@ -0,0 +1,216 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/amount"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// OperationSummary describes one operation of a dry-run.
+type OperationSummary struct {
+	Type   string `json:"type"`
+	Source string `json:"source"`
+	// Effect is a one-line description of what the operation does.
+	Effect string `json:"effect,omitempty"`
+}
+
+// FootprintSummary sizes the ledger footprint of a Soroban transaction.
+type FootprintSummary struct {
+	ReadOnly     int    `json:"read_only"`
+	ReadWrite    int    `json:"read_write"`
+	Instructions uint32 `json:"instructions"`
+	ReadBytes    uint32 `json:"read_bytes"`
+	WriteBytes   uint32 `json:"write_bytes"`
+}
+
+// DryRunReport is everything a reviewer needs to approve an intent before it
+// is signed. Nothing in it has been submitted.
+type DryRunReport struct {
+	Source      string             `json:"source"`
+	EnvelopeXDR string             `json:"envelope_xdr"`
+	Operations  []OperationSummary `json:"operations"`
+
+	InclusionFee int64 `json:"inclusion_fee"`
+	ResourceFee  int64 `json:"resource_fee"`
+	TotalFee     int64 `json:"total_fee"`
+
+	Signers []SignatureRequirement `json:"signers,omitempty"`
+
+	// The fields below are only set for Soroban transactions.
+	Footprint *FootprintSummary `json:"footprint,omitempty"`
+	// ReturnValue is the base64 ScVal the invocation returned in simulation.
+	ReturnValue string `json:"return_value,omitempty"`
+	// Events holds the base64 diagnostic events of the simulation.
+	Events          []string `json:"events,omitempty"`
+	CPUInstructions int64    `json:"cpu_instructions,omitempty"`
+	MemoryBytes     int64    `json:"memory_bytes,omitempty"`
+	// Restore lists the base64 ledger keys that must be restored first, and
+	// RestoreFee the resource fee of doing so.
+	Restore    []string `json:"restore,omitempty"`
+	RestoreFee int64    `json:"restore_fee,omitempty"`
+	// SimulationError is set when the invocation failed in simulation.
+	SimulationError string `json:"simulation_error,omitempty"`
+
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// DryRun resolves in exactly as Resolve would and reports the outcome
+// without submitting anything. Problems found during simulation, such as a
+// failing invocation or archived entries, are reported rather than returned
+// as errors.
+func DryRun(ctx context.Context, client *rpc.Client, in Intent, opts ...Option) (*DryRunReport, error) {
+	opts = append(append([]Option{}, opts...), func(o *options) { o.keepFailedSimulation = true })
+	p, err := Resolve(ctx, client, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	env, err := p.EnvelopeXDR()
+	if err != nil {
+		return nil, errors.WrapMarshalFailed(err)
+	}
+
+	r := &DryRunReport{
+		Source:       in.Source(),
+		EnvelopeXDR:  env,
+		InclusionFee: p.InclusionFee,
+		ResourceFee:  p.ResourceFee,
+		TotalFee:     p.TotalFee(),
+	}
+	for _, op := range p.Tx.Operations() {
+		r.Operations = append(r.Operations, summarize(op, in.Source()))
+	}
+
+	if p.Simulation != nil {
+		r.reportSimulation(p)
+	}
+
+	session, err := NewSigningSession(ctx, client, p.Tx)
+	if err != nil {
+		r.Warnings = append(r.Warnings, fmt.Sprintf("signers could not be determined: %v", err))
+		return r, nil
+	}
+	r.Signers = session.Requirements()
+	for _, req := range r.Signers {
+		if req.Needed > 1 {
+			r.Warnings = append(r.Warnings, fmt.Sprintf("%s needs signatures worth %d at the %s threshold", req.Account, req.Needed, req.Threshold))
+		}
+	}
+	if acc := session.accounts[underlying(in.Source())]; acc != nil {
+		if native := nativeBalance(acc); native < r.TotalFee {
+			r.Warnings = append(r.Warnings, fmt.Sprintf("source holds %s XLM, less than the maximum fee of %s XLM", amount.StringFromInt64(native), amount.StringFromInt64(r.TotalFee)))
+		}
+	}
+	return r, nil
+}
+
+func (r *DryRunReport) reportSimulation(p *Prepared) {
+	sim := p.Simulation.Result
+	r.Events = sim.Events
+	r.CPUInstructions = sim.Cost.CpuInsns + sim.Cost.CpuInsns_
+	r.MemoryBytes = sim.Cost.MemBytes + sim.Cost.MemBytes_
+	if len(sim.Results) > 0 {
+		r.ReturnValue = sim.Results[0].XDR
+	}
+	if sim.Error != "" {
+		r.SimulationError = sim.Error
+		r.Warnings = append(r.Warnings, "invocation fails in simulation and would fail on submission")
+	} else if p.simErr != nil {
+		r.Warnings = append(r.Warnings, p.simErr.Error())
+	}
+
+	var data xdr.SorobanTransactionData
+	if xdr.SafeUnmarshalBase64(sim.TransactionData, &data) == nil {
+		r.Footprint = &FootprintSummary{
+			ReadOnly:     len(data.Resources.Footprint.ReadOnly),
+			ReadWrite:    len(data.Resources.Footprint.ReadWrite),
+			Instructions: uint32(data.Resources.Instructions),
+			ReadBytes:    uint32(data.Resources.DiskReadBytes),
+			WriteBytes:   uint32(data.Resources.WriteBytes),
+		}
+	}
+
+	if pre := sim.RestorePreamble; pre != nil {
+		r.RestoreFee, _ = strconv.ParseInt(pre.MinResourceFee, 10, 64)
+		var restore xdr.SorobanTransactionData
+		if xdr.SafeUnmarshalBase64(pre.TransactionData, &restore) == nil {
+			for _, key := range restore.Resources.Footprint.ReadWrite {
+				if s, err := xdr.MarshalBase64(key); err == nil {
+					r.Restore = append(r.Restore, s)
+				}
+			}
+		}
+	}
+
+	for _, op := range p.Tx.Operations() {
+		invoke, ok := op.(*txnbuild.InvokeHostFunction)
+		if !ok {
+			continue
+		}
+		for _, entry := range invoke.Auth {
+			if creds, ok := entry.Credentials.GetAddress(); ok {
+				r.Warnings = append(r.Warnings, fmt.Sprintf("authorization entry for %s must be signed separately", scAddressString(creds.Address)))
+			}
+		}
+	}
+}
+
+// summarize describes op, whose default source is txSource.
+func summarize(op txnbuild.Operation, txSource string) OperationSummary {
+	s := OperationSummary{Source: op.GetSourceAccount()}
+	if s.Source == "" {
+		s.Source = txSource
+	}
+	if x, err := op.BuildXDR(); err == nil {
+		s.Type = strings.TrimPrefix(x.Body.Type.String(), "OperationType")
+	}
+
+	switch o := op.(type) {
+	case *txnbuild.CreateAccount:
+		s.Effect = fmt.Sprintf("create %s with %s XLM", o.Destination, o.Amount)
+	case *txnbuild.Payment:
+		s.Effect = fmt.Sprintf("pay %s %s to %s", o.Amount, assetString(o.Asset), o.Destination)
+	case *txnbuild.PathPaymentStrictSend:
+		s.Effect = fmt.Sprintf("send %s %s for at least %s %s to %s", o.SendAmount, assetString(o.SendAsset), o.DestMin, assetString(o.DestAsset), o.Destination)
+	case *txnbuild.PathPaymentStrictReceive:
+		s.Effect = fmt.Sprintf("send at most %s %s for %s %s to %s", o.SendMax, assetString(o.SendAsset), o.DestAmount, assetString(o.DestAsset), o.Destination)
+	case *txnbuild.ChangeTrust:
+		if a, ok := o.Line.(txnbuild.ChangeTrustAssetWrapper); ok {
+			s.Effect = fmt.Sprintf("set trustline to %s with limit %s", assetString(a.Asset), o.Limit)
+		}
+	case *txnbuild.BeginSponsoringFutureReserves:
+		s.Effect = "sponsor reserves of " + o.SponsoredID
+	case *txnbuild.InvokeHostFunction:
+		if args, ok := o.HostFunction.GetInvokeContract(); ok {
+			s.Effect = fmt.Sprintf("call %s on %s", args.FunctionName, scAddressString(args.ContractAddress))
+		}
+	}
+	return s
+}
+
+func scAddressString(a xdr.ScAddress) string {
+	if s, err := a.String(); err == nil {
+		return s
+	}
+	return a.Type.String()
+}
+
+// nativeBalance returns the XLM balance of acc in stroops.
+func nativeBalance(acc *rpc.AccountDetails) int64 {
+	for _, b := range acc.Balances {
+		if _, ok := b.Asset.(txnbuild.NativeAsset); ok {
+			v, _ := amount.ParseInt64(b.Balance)
+			return v
+		}
+	}
+	return 0
+}
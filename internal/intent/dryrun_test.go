@@ -0,0 +1,91 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/keypair"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/base"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRun_Classic(t *testing.T) {
+	master, cosigner := keypair.MustRandom(), keypair.MustRandom()
+	acc := multisigAccount(master, cosigner, 2)
+	acc.Balances = []hProtocol.Balance{{Balance: "0.0000100", Asset: base.Asset{Type: "native"}}}
+	client := newTestClient(t, newTestHorizon(acc, testAccount(testDestination, 1)))
+
+	in := &PaymentIntent{From: master.Address(), To: testDestination, Asset: txnbuild.NativeAsset{}, Amount: "3"}
+	r, err := DryRun(context.Background(), client, in, WithBaseFee(200))
+	require.NoError(t, err)
+
+	require.Len(t, r.Operations, 1)
+	assert.Equal(t, "Payment", r.Operations[0].Type)
+	assert.Equal(t, master.Address(), r.Operations[0].Source)
+	assert.Equal(t, "pay 3 native to "+testDestination, r.Operations[0].Effect)
+	assert.Equal(t, int64(200), r.TotalFee)
+	assert.Nil(t, r.Footprint)
+	assert.NotEmpty(t, r.EnvelopeXDR)
+
+	require.Len(t, r.Signers, 1)
+	assert.Equal(t, int32(2), r.Signers[0].Needed)
+	require.Len(t, r.Warnings, 2)
+	assert.Contains(t, r.Warnings[0], "needs signatures worth 2")
+	assert.Contains(t, r.Warnings[1], "less than the maximum fee")
+}
+
+func TestDryRun_SorobanRestore(t *testing.T) {
+	client := newTestClient(t, newTestHorizon(testAccount(testSource, 3)))
+
+	key := xdr.LedgerKey{Type: xdr.LedgerEntryTypeContractData, ContractData: &xdr.LedgerKeyContractData{
+		Contract:   testContractInvocation().ContractAddress,
+		Key:        xdr.ScVal{Type: xdr.ScValTypeScvLedgerKeyContractInstance},
+		Durability: xdr.ContractDataDurabilityPersistent,
+	}}
+	restore := xdr.SorobanTransactionData{ResourceFee: 50}
+	restore.Resources.Footprint.ReadWrite = []xdr.LedgerKey{key}
+	restoreData, err := xdr.MarshalBase64(restore)
+	require.NoError(t, err)
+	withSoroban(t, client, map[string]interface{}{
+		"minResourceFee":  "900",
+		"transactionData": testSorobanData(t, 900),
+		"restorePreamble": map[string]interface{}{"minResourceFee": "50", "transactionData": restoreData},
+		"cost":            map[string]interface{}{"cpuInsns": 1234},
+	})
+
+	in := &InvokeIntent{From: testSource, Contract: testContract(t), Function: "bump"}
+	r, err := DryRun(context.Background(), client, in, WithBaseFee(100))
+	require.NoError(t, err, "restore-blocked invocations are reported, not rejected")
+
+	assert.Equal(t, "InvokeHostFunction", r.Operations[0].Type)
+	assert.Contains(t, r.Operations[0].Effect, "call bump on C")
+	require.NotNil(t, r.Footprint)
+	assert.Equal(t, int64(1234), r.CPUInstructions)
+	assert.Equal(t, int64(50), r.RestoreFee)
+	require.Len(t, r.Restore, 1)
+	want, err := xdr.MarshalBase64(key)
+	require.NoError(t, err)
+	assert.Equal(t, want, r.Restore[0])
+	assert.Contains(t, r.Warnings, "simulation logic error: archived ledger entries must be restored first")
+}
+
+func TestDryRun_SimulationError(t *testing.T) {
+	client := newTestClient(t, newTestHorizon(testAccount(testSource, 3)))
+	withSoroban(t, client, map[string]interface{}{"error": "HostError: Error(Contract, #3)"})
+
+	in := &InvokeIntent{From: testSource, Contract: testContract(t), Function: "bump"}
+	r, err := DryRun(context.Background(), client, in, WithBaseFee(100))
+	require.NoError(t, err)
+	assert.Equal(t, "HostError: Error(Contract, #3)", r.SimulationError)
+	assert.NotEmpty(t, r.Warnings)
+
+	_, err = Resolve(context.Background(), client, in, WithBaseFee(100))
+	assert.Error(t, err, "Resolve still rejects failed simulations")
+}
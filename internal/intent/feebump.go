@@ -0,0 +1,69 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"fmt"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// WrapWithFeeBump wraps a signed inner transaction in a fee-bump paid by
+// feeSource. maxFee is the most feeSource may be charged in stroops,
+// including any Soroban resource fee of the inner transaction. The fee-bump
+// still has to be signed by feeSource.
+func WrapWithFeeBump(inner *txnbuild.Transaction, feeSource string, maxFee int64) (*txnbuild.FeeBumpTransaction, error) {
+	if inner == nil {
+		return nil, errors.WrapValidationError("inner transaction is required")
+	}
+	if err := validateAccount("fee", feeSource); err != nil {
+		return nil, err
+	}
+	if len(inner.Signatures()) == 0 {
+		return nil, errors.WrapValidationError("inner transaction must be signed before it is fee-bumped")
+	}
+
+	baseFee, err := feeBumpBaseFee(inner, maxFee)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := txnbuild.NewFeeBumpTransaction(txnbuild.FeeBumpTransactionParams{
+		Inner:      inner,
+		FeeAccount: feeSource,
+		BaseFee:    baseFee,
+	})
+	if err != nil {
+		return nil, errors.WrapValidationError(fmt.Sprintf("failed to build fee-bump transaction: %v", err))
+	}
+	return tx, nil
+}
+
+// feeBumpBaseFee converts a total fee ceiling into the per-operation rate a
+// fee-bump is specified in. The fee-bump counts as one extra operation, and
+// its rate must be at least that of the inner transaction.
+func feeBumpBaseFee(inner *txnbuild.Transaction, maxFee int64) (int64, error) {
+	resourceFee := sorobanResourceFee(inner.ToXDR())
+	ops := int64(len(inner.Operations())) + 1
+	baseFee := (maxFee - resourceFee) / ops
+
+	minRate := inner.BaseFee()
+	if minRate < txnbuild.MinBaseFee {
+		minRate = txnbuild.MinBaseFee
+	}
+	if baseFee < minRate {
+		return 0, errors.WrapValidationError(fmt.Sprintf(
+			"max fee %d stroops is below the minimum of %d for a fee-bump of this transaction",
+			maxFee, minRate*ops+resourceFee))
+	}
+	return baseFee, nil
+}
+
+func sorobanResourceFee(env xdr.TransactionEnvelope) int64 {
+	if env.V1 == nil || env.V1.Tx.Ext.SorobanData == nil {
+		return 0
+	}
+	return int64(env.V1.Tx.Ext.SorobanData.ResourceFee)
+}
@@ -0,0 +1,42 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapWithFeeBump(t *testing.T) {
+	source, sponsor := keypair.MustRandom(), keypair.MustRandom()
+	inner, err := testPaymentTx(t, source.Address(), 1).Sign(network.TestNetworkPassphrase, source)
+	require.NoError(t, err)
+
+	fb, err := WrapWithFeeBump(inner, sponsor.Address(), 10_000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5_000), fb.BaseFee(), "one inner operation plus the fee-bump itself")
+	assert.Equal(t, int64(10_000), fb.MaxFee())
+	assert.Equal(t, sponsor.Address(), fb.FeeAccount())
+	assert.Equal(t, inner.Signatures(), fb.InnerTransaction().Signatures())
+}
+
+func TestWrapWithFeeBump_Rejections(t *testing.T) {
+	source, sponsor := keypair.MustRandom(), keypair.MustRandom()
+	unsigned := testPaymentTx(t, source.Address(), 1)
+	_, err := WrapWithFeeBump(unsigned, sponsor.Address(), 10_000)
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+
+	signed, err := unsigned.Sign(network.TestNetworkPassphrase, source)
+	require.NoError(t, err)
+	_, err = WrapWithFeeBump(signed, sponsor.Address(), 150)
+	assert.ErrorIs(t, err, errs.ErrValidationFailed, "below two operations at the minimum rate")
+
+	_, err = WrapWithFeeBump(signed, "nope", 10_000)
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+}
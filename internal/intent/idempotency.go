@@ -0,0 +1,80 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/dotandev/hintents/internal/rpc"
+)
+
+// SubmitIdempotent submits in at most once per key. The first call resolves
+// and signs the intent, records key → transaction hash in store before
+// anything reaches the network, and executes the record. Later calls with
+// the same key, from this process or another sharing the store, do not
+// build a new transaction: they resume the recorded one if its outcome is
+// still unknown and otherwise return it as is.
+//
+// A failed record stays failed; its transaction was never applied, so
+// callers wanting another attempt use a new key. The returned error follows
+// Execute: nil once the record is final, non-nil while its outcome is
+// unknown.
+func SubmitIdempotent(ctx context.Context, client *rpc.Client, store Store, key string, in Intent, signers []Signer, poll rpc.PollConfig, opts ...Option) (*Record, error) {
+	if key == "" {
+		return nil, errors.WrapValidationError("idempotency key is required")
+	}
+	if client == nil {
+		return nil, errors.WrapValidationError("rpc client is required")
+	}
+
+	r, err := store.Load(ctx, key)
+	switch {
+	case err == nil:
+		return resumeIdempotent(ctx, client, store, r, in, poll)
+	case !errors.Is(err, ErrRecordNotFound):
+		return nil, err
+	}
+
+	p, err := Resolve(ctx, client, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	passphrase := client.GetNetworkPassphrase()
+	tx, err := SignTransaction(ctx, p.Tx, passphrase, signers...)
+	if err != nil {
+		return nil, err
+	}
+	if r, err = NewRecord(key, passphrase, tx); err != nil {
+		return nil, err
+	}
+	if err := store.Create(ctx, r); err != nil {
+		if !errors.Is(err, ErrRecordExists) {
+			return nil, err
+		}
+		// Another caller won the race; follow its transaction instead.
+		if r, err = store.Load(ctx, key); err != nil {
+			return nil, err
+		}
+		return resumeIdempotent(ctx, client, store, r, in, poll)
+	}
+	return r, Execute(ctx, client, store, r, poll)
+}
+
+// resumeIdempotent handles a key that is already recorded.
+func resumeIdempotent(ctx context.Context, client *rpc.Client, store Store, r *Record, in Intent, poll rpc.PollConfig) (*Record, error) {
+	if underlying(r.Source) != underlying(in.Source()) {
+		return nil, errors.WrapValidationError(fmt.Sprintf("idempotency key %q was used for a transaction from %s", r.ID, r.Source))
+	}
+	if r.NetworkPassphrase != client.GetNetworkPassphrase() {
+		return nil, errors.WrapValidationError(fmt.Sprintf("idempotency key %q was used on another network", r.ID))
+	}
+	logger.Logger.Debug("Idempotency key already recorded", "key", r.ID, "state", r.State, "hash", r.Hash)
+	if r.State.Final() {
+		return r, nil
+	}
+	return r, Execute(ctx, client, store, r, poll)
+}
@@ -0,0 +1,336 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+// Package intent turns a declared outcome, such as "pay 100 USDC from A to B"
+// or "call f on contract C", into a built, simulated and fee-estimated
+// transaction that only needs signing.
+package intent
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// Intent is a desired outcome that can be expressed as operations on behalf
+// of a single source account.
+type Intent interface {
+	// Source is the account whose sequence number the transaction consumes.
+	Source() string
+	// Validate checks the intent without touching the network.
+	Validate() error
+	// Operations returns the operations realizing the intent. It may query
+	// the network, for example to discover a payment path.
+	Operations(ctx context.Context, client *rpc.Client) ([]txnbuild.Operation, error)
+}
+
+// DefaultTimeout is how long a prepared transaction stays valid.
+const DefaultTimeout = 5 * time.Minute
+
+// DefaultFeePercentile is the fee stats percentile used when no base fee is
+// given.
+const DefaultFeePercentile = 70
+
+type options struct {
+	baseFee       int64
+	feePercentile int
+	timeout       time.Duration
+	memo          txnbuild.Memo
+	sequence      *int64
+	skipMemoCheck bool
+	// keepFailedSimulation returns a failed or restore-blocked simulation
+	// in Prepared instead of an error, for reports.
+	keepFailedSimulation bool
+}
+
+// Option customizes Resolve.
+type Option func(*options)
+
+// WithBaseFee fixes the per-operation inclusion fee in stroops instead of
+// deriving it from recent fee stats.
+func WithBaseFee(stroops int64) Option {
+	return func(o *options) { o.baseFee = stroops }
+}
+
+// WithFeePercentile selects the fee stats percentile for the inclusion fee.
+func WithFeePercentile(p int) Option {
+	return func(o *options) { o.feePercentile = p }
+}
+
+// WithTimeout sets the validity window of the transaction.
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) { o.timeout = d }
+}
+
+// WithMemo attaches a memo to the transaction.
+func WithMemo(m txnbuild.Memo) Option {
+	return func(o *options) { o.memo = m }
+}
+
+// WithoutMemoCheck skips the SEP-29 check Resolve otherwise makes when a
+// transaction without a memo pays a destination account.
+func WithoutMemoCheck() Option {
+	return func(o *options) { o.skipMemoCheck = true }
+}
+
+// WithSequence uses seq as the source account's current sequence number
+// instead of loading it, for transactions queued behind others that have not
+// been applied yet. The transaction consumes seq+1.
+func WithSequence(seq int64) Option {
+	return func(o *options) { o.sequence = &seq }
+}
+
+// Prepared is a resolved intent: a transaction ready for signing together
+// with how its fee was arrived at.
+type Prepared struct {
+	Intent Intent
+	Tx     *txnbuild.Transaction
+	// InclusionFee is the classic fee bid over all operations, in stroops.
+	InclusionFee int64
+	// ResourceFee is the Soroban resource fee in stroops; 0 for classic
+	// transactions.
+	ResourceFee int64
+	// Simulation is the preflight response for Soroban transactions.
+	Simulation *rpc.SimulateTransactionResponse
+
+	// simErr is why the simulation could not be applied, when kept.
+	simErr error
+}
+
+// TotalFee is the maximum fee the transaction may be charged, in stroops.
+func (p *Prepared) TotalFee() int64 {
+	return p.InclusionFee + p.ResourceFee
+}
+
+// EnvelopeXDR returns the unsigned transaction envelope as base64.
+func (p *Prepared) EnvelopeXDR() (string, error) {
+	return p.Tx.Base64()
+}
+
+// Resolve validates in, builds its operations against the current state of
+// the source account, refuses memo-less payments to accounts that require a
+// memo under SEP-29, simulates Soroban invocations to fill in footprint,
+// resources and authorization, and prices the transaction.
+func Resolve(ctx context.Context, client *rpc.Client, in Intent, opts ...Option) (*Prepared, error) {
+	if client == nil {
+		return nil, errors.WrapValidationError("rpc client is required")
+	}
+	if err := in.Validate(); err != nil {
+		return nil, err
+	}
+	o := options{feePercentile: DefaultFeePercentile, timeout: DefaultTimeout}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	ops, err := in.Operations(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	if len(ops) == 0 {
+		return nil, errors.WrapValidationError("intent produced no operations")
+	}
+	soroban, err := sorobanOperation(ops)
+	if err != nil {
+		return nil, err
+	}
+	if soroban != nil {
+		// The simulation is applied to a copy, so an intent that hands out
+		// its own operations, like Ops, resolves afresh every time.
+		if soroban, err = copySorobanOperation(soroban); err != nil {
+			return nil, err
+		}
+		ops = []txnbuild.Operation{soroban}
+	}
+	if !o.skipMemoCheck {
+		if err := CheckMemoRequired(ctx, client, o.memo, ops); err != nil {
+			return nil, err
+		}
+	}
+
+	var sequence int64
+	if o.sequence != nil {
+		sequence = *o.sequence
+	} else {
+		account, err := client.AccountDetails(ctx, in.Source())
+		if err != nil {
+			return nil, err
+		}
+		sequence = account.Sequence
+	}
+
+	baseFee := o.baseFee
+	if baseFee <= 0 {
+		if baseFee, err = client.SuggestClassicFee(ctx, o.feePercentile); err != nil {
+			return nil, err
+		}
+	}
+
+	params := txnbuild.TransactionParams{
+		SourceAccount:        &txnbuild.SimpleAccount{AccountID: in.Source(), Sequence: sequence},
+		IncrementSequenceNum: true,
+		Operations:           ops,
+		BaseFee:              baseFee,
+		Memo:                 o.memo,
+		Preconditions:        txnbuild.Preconditions{TimeBounds: txnbuild.NewTimeout(int64(o.timeout / time.Second))},
+	}
+	tx, err := txnbuild.NewTransaction(params)
+	if err != nil {
+		return nil, errors.WrapValidationError(fmt.Sprintf("failed to build transaction: %v", err))
+	}
+	p := &Prepared{Intent: in, Tx: tx, InclusionFee: baseFee * int64(len(ops))}
+	if soroban == nil {
+		return p, nil
+	}
+
+	env, err := tx.Base64()
+	if err != nil {
+		return nil, errors.WrapMarshalFailed(err)
+	}
+	sim, err := client.SimulateTransaction(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+	p.Simulation = sim
+	if err := applySimulation(soroban, sim); err != nil {
+		if !o.keepFailedSimulation {
+			return nil, err
+		}
+		p.simErr = err
+		return p, nil
+	}
+	p.ResourceFee, _ = strconv.ParseInt(sim.Result.MinResourceFee, 10, 64)
+
+	logger.Logger.Debug("Intent simulated", "source", in.Source(), "resource_fee", p.ResourceFee)
+
+	// Rebuild from the original sequence now that the operation carries its
+	// footprint and resource fee.
+	params.SourceAccount = &txnbuild.SimpleAccount{AccountID: in.Source(), Sequence: sequence}
+	if p.Tx, err = txnbuild.NewTransaction(params); err != nil {
+		return nil, errors.WrapValidationError(fmt.Sprintf("failed to assemble transaction: %v", err))
+	}
+	return p, nil
+}
+
+// sorobanOperation returns the Soroban operation of ops, or nil for classic
+// transactions. The protocol allows at most one, and nothing beside it.
+func sorobanOperation(ops []txnbuild.Operation) (txnbuild.Operation, error) {
+	for _, op := range ops {
+		if _, ok := op.(txnbuild.SorobanOperation); ok {
+			if len(ops) > 1 {
+				return nil, errors.WrapValidationError("a Soroban operation must be the only operation in its transaction")
+			}
+			return op, nil
+		}
+	}
+	return nil, nil
+}
+
+// copySorobanOperation returns a copy of op that applySimulation can fill in
+// without touching op.
+func copySorobanOperation(op txnbuild.Operation) (txnbuild.Operation, error) {
+	switch o := op.(type) {
+	case *txnbuild.InvokeHostFunction:
+		c := *o
+		c.Auth = append([]xdr.SorobanAuthorizationEntry(nil), o.Auth...)
+		return &c, nil
+	case *txnbuild.ExtendFootprintTtl:
+		c := *o
+		return &c, nil
+	case *txnbuild.RestoreFootprint:
+		c := *o
+		return &c, nil
+	}
+	return nil, errors.WrapValidationError(fmt.Sprintf("unsupported Soroban operation %T", op))
+}
+
+// applySimulation copies the footprint, resources and, unless the caller
+// supplied its own, the authorization entries from sim into op.
+func applySimulation(op txnbuild.Operation, sim *rpc.SimulateTransactionResponse) error {
+	if sim.Result.Error != "" {
+		return errors.WrapSimulationLogicError(sim.Result.Error)
+	}
+	if sim.Result.RestorePreamble != nil {
+		return errors.WrapSimulationLogicError("archived ledger entries must be restored first")
+	}
+	var data xdr.SorobanTransactionData
+	if err := xdr.SafeUnmarshalBase64(sim.Result.TransactionData, &data); err != nil {
+		return errors.WrapUnmarshalFailed(err, sim.Result.TransactionData)
+	}
+	ext := xdr.TransactionExt{V: 1, SorobanData: &data}
+
+	switch o := op.(type) {
+	case *txnbuild.InvokeHostFunction:
+		o.Ext = ext
+		if len(o.Auth) == 0 && len(sim.Result.Results) > 0 {
+			for _, raw := range sim.Result.Results[0].Auth {
+				var entry xdr.SorobanAuthorizationEntry
+				if err := xdr.SafeUnmarshalBase64(raw, &entry); err != nil {
+					return errors.WrapUnmarshalFailed(err, raw)
+				}
+				o.Auth = append(o.Auth, entry)
+			}
+		}
+	case *txnbuild.ExtendFootprintTtl:
+		o.Ext = ext
+	case *txnbuild.RestoreFootprint:
+		o.Ext = ext
+	default:
+		return errors.WrapValidationError(fmt.Sprintf("unsupported Soroban operation %T", op))
+	}
+	return nil
+}
+
+// Ops is an intent made of caller-built operations, for flows no template
+// covers.
+type Ops struct {
+	SourceAccount string
+	List          []txnbuild.Operation
+}
+
+// NewOps returns an intent that submits ops from source.
+func NewOps(source string, ops ...txnbuild.Operation) *Ops {
+	return &Ops{SourceAccount: source, List: ops}
+}
+
+func (i *Ops) Source() string { return i.SourceAccount }
+
+func (i *Ops) Validate() error {
+	if err := validateAccount("source", i.SourceAccount); err != nil {
+		return err
+	}
+	if len(i.List) == 0 {
+		return errors.WrapValidationError("at least one operation is required")
+	}
+	return nil
+}
+
+func (i *Ops) Operations(context.Context, *rpc.Client) ([]txnbuild.Operation, error) {
+	return i.List, nil
+}
+
+// validateAccount checks that address is a G... or M... account.
+func validateAccount(field, address string) error {
+	if address == "" {
+		return errors.WrapValidationError(field + " account is required")
+	}
+	if _, err := rpc.ParseMuxedAddress(address); err != nil {
+		return errors.WrapValidationError(fmt.Sprintf("invalid %s account %q", field, address))
+	}
+	return nil
+}
+
+// underlying returns the G... account behind a possibly muxed address.
+func underlying(address string) string {
+	if m, err := rpc.ParseMuxedAddress(address); err == nil {
+		return m.Account
+	}
+	return address
+}
@@ -0,0 +1,230 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testSource      = keypair.MustRandom().Address()
+	testDestination = keypair.MustRandom().Address()
+)
+
+type testHorizon struct {
+	horizonclient.ClientInterface
+	accounts map[string]hProtocol.Account
+}
+
+func newTestHorizon(accounts ...hProtocol.Account) *testHorizon {
+	h := &testHorizon{accounts: map[string]hProtocol.Account{}}
+	for _, a := range accounts {
+		h.accounts[a.AccountID] = a
+	}
+	return h
+}
+
+func (h *testHorizon) AccountDetail(req horizonclient.AccountRequest) (hProtocol.Account, error) {
+	acc, ok := h.accounts[req.AccountID]
+	if !ok {
+		return hProtocol.Account{}, &horizonclient.Error{Problem: problem.P{Status: http.StatusNotFound}}
+	}
+	return acc, nil
+}
+
+func (h *testHorizon) FeeStats() (hProtocol.FeeStats, error) {
+	return hProtocol.FeeStats{
+		LastLedgerBaseFee: 100,
+		FeeCharged:        hProtocol.FeeDistribution{P50: 150, P70: 200, P90: 400},
+	}, nil
+}
+
+func testAccount(id string, seq int64) hProtocol.Account {
+	return hProtocol.Account{
+		AccountID: id,
+		Sequence:  seq,
+		Signers:   []hProtocol.Signer{{Key: id, Type: "ed25519_public_key", Weight: 1}},
+	}
+}
+
+func newTestClient(t *testing.T, horizon horizonclient.ClientInterface) *rpc.Client {
+	t.Helper()
+	client, err := rpc.NewClient(rpc.WithNetwork(rpc.Testnet), rpc.WithCacheEnabled(false))
+	require.NoError(t, err)
+	client.Horizon = horizon
+	return client
+}
+
+// withSoroban points client at a fake Soroban RPC answering every
+// simulateTransaction with result.
+func withSoroban(t *testing.T, client *rpc.Client, result map[string]interface{}) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "result": result})
+	}))
+	t.Cleanup(server.Close)
+	client.SorobanURL = server.URL
+	client.AltURLs = []string{server.URL}
+}
+
+func testSorobanData(t *testing.T, resourceFee int64) string {
+	t.Helper()
+	data := xdr.SorobanTransactionData{ResourceFee: xdr.Int64(resourceFee)}
+	s, err := xdr.MarshalBase64(data)
+	require.NoError(t, err)
+	return s
+}
+
+func testContractInvocation() xdr.InvokeContractArgs {
+	return xdr.InvokeContractArgs{
+		ContractAddress: xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &xdr.ContractId{1}},
+		FunctionName:    "hello",
+	}
+}
+
+func TestResolve_Classic(t *testing.T) {
+	client := newTestClient(t, newTestHorizon(testAccount(testSource, 41)))
+	in := NewOps(testSource,
+		&txnbuild.Payment{Destination: testDestination, Amount: "1", Asset: txnbuild.NativeAsset{}},
+		&txnbuild.BumpSequence{BumpTo: 100},
+	)
+
+	p, err := Resolve(context.Background(), client, in, WithMemo(txnbuild.MemoText("hi")))
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), p.Tx.SequenceNumber())
+	assert.Equal(t, int64(200), p.Tx.BaseFee(), "P70 of fee charged")
+	assert.Equal(t, int64(400), p.TotalFee())
+	assert.Nil(t, p.Simulation)
+	assert.Equal(t, txnbuild.MemoText("hi"), p.Tx.Memo())
+
+	env, err := p.EnvelopeXDR()
+	require.NoError(t, err)
+	assert.NotEmpty(t, env)
+}
+
+func TestResolve_Soroban(t *testing.T) {
+	client := newTestClient(t, newTestHorizon(testAccount(testSource, 7)))
+
+	args := testContractInvocation()
+	entry := xdr.SorobanAuthorizationEntry{
+		Credentials: xdr.SorobanCredentials{Type: xdr.SorobanCredentialsTypeSorobanCredentialsSourceAccount},
+		RootInvocation: xdr.SorobanAuthorizedInvocation{Function: xdr.SorobanAuthorizedFunction{
+			Type:       xdr.SorobanAuthorizedFunctionTypeSorobanAuthorizedFunctionTypeContractFn,
+			ContractFn: &args,
+		}},
+	}
+	auth, err := xdr.MarshalBase64(entry)
+	require.NoError(t, err)
+	withSoroban(t, client, map[string]interface{}{
+		"minResourceFee":  "5000",
+		"transactionData": testSorobanData(t, 5000),
+		"results":         []map[string]interface{}{{"auth": []string{auth}, "xdr": "AAAAAQ=="}},
+		"latestLedger":    10,
+	})
+
+	op := &txnbuild.InvokeHostFunction{HostFunction: xdr.HostFunction{
+		Type:           xdr.HostFunctionTypeHostFunctionTypeInvokeContract,
+		InvokeContract: &args,
+	}}
+	p, err := Resolve(context.Background(), client, NewOps(testSource, op), WithBaseFee(100))
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(5000), p.ResourceFee)
+	assert.Equal(t, int64(5100), p.TotalFee())
+	assert.Equal(t, int64(5100), p.Tx.MaxFee(), "resource fee is folded into the envelope fee")
+	assert.Equal(t, int64(8), p.Tx.SequenceNumber())
+	resolved := p.Tx.Operations()[0].(*txnbuild.InvokeHostFunction)
+	require.Len(t, resolved.Auth, 1)
+	assert.Equal(t, xdr.ScSymbol("hello"), resolved.Auth[0].RootInvocation.Function.ContractFn.FunctionName)
+	assert.Empty(t, op.Auth, "the caller's operation is left as it was")
+}
+
+func TestResolve_SorobanAgainUsesNewSimulation(t *testing.T) {
+	client := newTestClient(t, newTestHorizon(testAccount(testSource, 7)))
+	args := testContractInvocation()
+	simulateAuth := func(function xdr.ScSymbol) {
+		authArgs := args
+		authArgs.FunctionName = function
+		entry := xdr.SorobanAuthorizationEntry{
+			Credentials: xdr.SorobanCredentials{Type: xdr.SorobanCredentialsTypeSorobanCredentialsSourceAccount},
+			RootInvocation: xdr.SorobanAuthorizedInvocation{Function: xdr.SorobanAuthorizedFunction{
+				Type:       xdr.SorobanAuthorizedFunctionTypeSorobanAuthorizedFunctionTypeContractFn,
+				ContractFn: &authArgs,
+			}},
+		}
+		auth, err := xdr.MarshalBase64(entry)
+		require.NoError(t, err)
+		withSoroban(t, client, map[string]interface{}{
+			"minResourceFee":  "5000",
+			"transactionData": testSorobanData(t, 5000),
+			"results":         []map[string]interface{}{{"auth": []string{auth}, "xdr": "AAAAAQ=="}},
+			"latestLedger":    10,
+		})
+	}
+
+	in := NewOps(testSource, &txnbuild.InvokeHostFunction{HostFunction: xdr.HostFunction{
+		Type:           xdr.HostFunctionTypeHostFunctionTypeInvokeContract,
+		InvokeContract: &args,
+	}})
+	simulateAuth("first")
+	_, err := Resolve(context.Background(), client, in, WithBaseFee(100))
+	require.NoError(t, err)
+
+	simulateAuth("second")
+	p, err := Resolve(context.Background(), client, in, WithBaseFee(100))
+	require.NoError(t, err)
+	resolved := p.Tx.Operations()[0].(*txnbuild.InvokeHostFunction)
+	require.Len(t, resolved.Auth, 1)
+	assert.Equal(t, xdr.ScSymbol("second"), resolved.Auth[0].RootInvocation.Function.ContractFn.FunctionName)
+}
+
+func TestResolve_SimulationError(t *testing.T) {
+	client := newTestClient(t, newTestHorizon(testAccount(testSource, 1)))
+	withSoroban(t, client, map[string]interface{}{"error": "HostError: contract trapped"})
+
+	args := testContractInvocation()
+	op := &txnbuild.InvokeHostFunction{HostFunction: xdr.HostFunction{
+		Type:           xdr.HostFunctionTypeHostFunctionTypeInvokeContract,
+		InvokeContract: &args,
+	}}
+	_, err := Resolve(context.Background(), client, NewOps(testSource, op), WithBaseFee(100))
+	assert.ErrorIs(t, err, errs.ErrSimulationLogicError)
+}
+
+func TestResolve_Validation(t *testing.T) {
+	client := newTestClient(t, newTestHorizon())
+	ctx := context.Background()
+
+	_, err := Resolve(ctx, client, NewOps("nope", &txnbuild.BumpSequence{}))
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+
+	_, err = Resolve(ctx, client, NewOps(testSource))
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+
+	_, err = Resolve(ctx, client, NewOps(testSource, &txnbuild.BumpSequence{}))
+	assert.ErrorIs(t, err, errs.ErrAccountNotFound)
+
+	args := testContractInvocation()
+	_, err = Resolve(ctx, client, NewOps(testSource,
+		&txnbuild.InvokeHostFunction{HostFunction: xdr.HostFunction{Type: xdr.HostFunctionTypeHostFunctionTypeInvokeContract, InvokeContract: &args}},
+		&txnbuild.BumpSequence{},
+	))
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dotandev/hintents/internal/abi"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// InvokeIntent calls Function on a Soroban contract. Footprint, resources
+// and, unless Auth is given, the authorization entries are taken from
+// simulation when the intent is resolved.
+type InvokeIntent struct {
+	From     string
+	Contract string
+	Function string
+	Args     []xdr.ScVal
+	// Auth overrides the entries returned by simulation, for example when
+	// they have already been signed by other parties.
+	Auth []xdr.SorobanAuthorizationEntry
+}
+
+// InvokeByName builds an InvokeIntent from arguments keyed by parameter
+// name. The contract's spec is fetched from the network and used to order
+// the arguments and encode each one as the type the function declares.
+func InvokeByName(ctx context.Context, client *rpc.Client, from, contract, function string, args map[string]interface{}) (*InvokeIntent, error) {
+	spec, err := abi.FetchContractSpec(ctx, client, contract)
+	if err != nil {
+		return nil, err
+	}
+	vals, err := spec.EncodeArgs(function, args)
+	if err != nil {
+		return nil, err
+	}
+	return &InvokeIntent{From: from, Contract: contract, Function: function, Args: vals}, nil
+}
+
+func (i *InvokeIntent) Source() string { return i.From }
+
+func (i *InvokeIntent) Validate() error {
+	if err := validateAccount("source", i.From); err != nil {
+		return err
+	}
+	if _, err := strkey.Decode(strkey.VersionByteContract, i.Contract); err != nil {
+		return errors.WrapValidationError(fmt.Sprintf("invalid contract address %q", i.Contract))
+	}
+	if i.Function == "" {
+		return errors.WrapValidationError("contract function is required")
+	}
+	return nil
+}
+
+func (i *InvokeIntent) Operations(context.Context, *rpc.Client) ([]txnbuild.Operation, error) {
+	raw, err := strkey.Decode(strkey.VersionByteContract, i.Contract)
+	if err != nil {
+		return nil, errors.WrapValidationError(fmt.Sprintf("invalid contract address %q", i.Contract))
+	}
+	var id xdr.ContractId
+	copy(id[:], raw)
+
+	return []txnbuild.Operation{&txnbuild.InvokeHostFunction{
+		HostFunction: xdr.HostFunction{
+			Type: xdr.HostFunctionTypeHostFunctionTypeInvokeContract,
+			InvokeContract: &xdr.InvokeContractArgs{
+				ContractAddress: xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeContract, ContractId: &id},
+				FunctionName:    xdr.ScSymbol(i.Function),
+				Args:            i.Args,
+			},
+		},
+		Auth: append([]xdr.SorobanAuthorizationEntry(nil), i.Auth...),
+	}}, nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testContract(t *testing.T) string {
+	t.Helper()
+	c, err := strkey.Encode(strkey.VersionByteContract, make([]byte, 32))
+	require.NoError(t, err)
+	return c
+}
+
+func TestInvokeIntent(t *testing.T) {
+	client := newTestClient(t, newTestHorizon(testAccount(testSource, 3)))
+	withSoroban(t, client, map[string]interface{}{
+		"minResourceFee":  "900",
+		"transactionData": testSorobanData(t, 900),
+		"results":         []map[string]interface{}{{"xdr": "AAAAAQ=="}},
+	})
+
+	amount := xdr.Uint32(5)
+	in := &InvokeIntent{
+		From:     testSource,
+		Contract: testContract(t),
+		Function: "bump",
+		Args:     []xdr.ScVal{{Type: xdr.ScValTypeScvU32, U32: &amount}},
+	}
+	p, err := Resolve(context.Background(), client, in, WithBaseFee(100))
+	require.NoError(t, err)
+
+	op, ok := p.Tx.Operations()[0].(*txnbuild.InvokeHostFunction)
+	require.True(t, ok, "got %T", p.Tx.Operations()[0])
+	assert.Equal(t, xdr.ScSymbol("bump"), op.HostFunction.InvokeContract.FunctionName)
+	assert.Len(t, op.HostFunction.InvokeContract.Args, 1)
+	assert.Equal(t, int64(1000), p.TotalFee())
+}
+
+func TestInvokeIntent_Validate(t *testing.T) {
+	contract := testContract(t)
+	for _, in := range []*InvokeIntent{
+		{From: testSource, Contract: testSource, Function: "f"},
+		{From: testSource, Contract: contract},
+		{From: "", Contract: contract, Function: "f"},
+	} {
+		assert.ErrorIs(t, in.Validate(), errs.ErrValidationFailed, "%+v", in)
+	}
+}
+
+func TestInvokeByName(t *testing.T) {
+	spec := xdr.ScSpecEntry{Kind: xdr.ScSpecEntryKindScSpecEntryFunctionV0, FunctionV0: &xdr.ScSpecFunctionV0{
+		Name: "bump",
+		Inputs: []xdr.ScSpecFunctionInputV0{
+			{Name: "by", Type: xdr.ScSpecTypeDef{Type: xdr.ScSpecTypeScSpecTypeU32}},
+			{Name: "who", Type: xdr.ScSpecTypeDef{Type: xdr.ScSpecTypeScSpecTypeAddress}},
+		},
+	}}
+	specBytes, err := spec.MarshalBinary()
+	require.NoError(t, err)
+	// A WASM module holding nothing but the contractspecv0 custom section.
+	section := append([]byte{byte(len("contractspecv0"))}, "contractspecv0"...)
+	section = append(section, specBytes...)
+	require.Less(t, len(section), 128)
+	wasm := append([]byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00, 0x00, byte(len(section))}, section...)
+
+	var id xdr.ContractId
+	hash := xdr.Hash{9}
+	instanceKey, err := rpc.LedgerKeyForContractInstance(id)
+	require.NoError(t, err)
+	codeKey := xdr.LedgerKey{Type: xdr.LedgerEntryTypeContractCode, ContractCode: &xdr.LedgerKeyContractCode{Hash: hash}}
+	instance := xdr.LedgerEntryData{Type: xdr.LedgerEntryTypeContractData, ContractData: &xdr.ContractDataEntry{
+		Contract: instanceKey.ContractData.Contract,
+		Key:      instanceKey.ContractData.Key,
+		Val: xdr.ScVal{Type: xdr.ScValTypeScvContractInstance, Instance: &xdr.ScContractInstance{
+			Executable: xdr.ContractExecutable{Type: xdr.ContractExecutableTypeContractExecutableWasm, WasmHash: &hash},
+		}},
+	}}
+	code := xdr.LedgerEntryData{Type: xdr.LedgerEntryTypeContractCode, ContractCode: &xdr.ContractCodeEntry{Hash: hash, Code: wasm}}
+
+	var entries []map[string]interface{}
+	for key, data := range map[*xdr.LedgerKey]xdr.LedgerEntryData{&instanceKey: instance, &codeKey: code} {
+		keyB64, err := rpc.EncodeLedgerKey(*key)
+		require.NoError(t, err)
+		entryB64, err := xdr.MarshalBase64(xdr.LedgerEntry{Data: data})
+		require.NoError(t, err)
+		entries = append(entries, map[string]interface{}{"key": keyB64, "xdr": entryB64})
+	}
+	client := newTestClient(t, newTestHorizon(testAccount(testSource, 3)))
+	withSoroban(t, client, map[string]interface{}{"entries": entries, "latestLedger": 10})
+
+	in, err := InvokeByName(context.Background(), client, testSource, testContract(t), "bump",
+		map[string]interface{}{"who": testSource, "by": 5})
+	require.NoError(t, err)
+	require.Len(t, in.Args, 2)
+	assert.Equal(t, xdr.Uint32(5), *in.Args[0].U32)
+	assert.Equal(t, xdr.ScValTypeScvAddress, in.Args[1].Type)
+	require.NoError(t, in.Validate())
+
+	_, err = InvokeByName(context.Background(), client, testSource, testContract(t), "bump",
+		map[string]interface{}{"by": 5})
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+}
@@ -0,0 +1,212 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// MaxMemoTextLength is the longest text memo, in bytes.
+const MaxMemoTextLength = 28
+
+// MemoRequiredDataKey is the account data entry through which an account
+// opts into SEP-29. Its value is "1" when a memo is required.
+const MemoRequiredDataKey = "config.memo_required"
+
+// TextMemo returns a text memo, failing if s is longer than 28 bytes or is
+// not valid UTF-8.
+func TextMemo(s string) (txnbuild.MemoText, error) {
+	if len(s) > MaxMemoTextLength {
+		return "", errors.WrapValidationError(fmt.Sprintf("text memo is %d bytes, at most %d are allowed", len(s), MaxMemoTextLength))
+	}
+	if !utf8.ValidString(s) {
+		return "", errors.WrapValidationError("text memo is not valid UTF-8")
+	}
+	return txnbuild.MemoText(s), nil
+}
+
+// IDMemo parses a decimal id memo.
+func IDMemo(s string) (txnbuild.MemoID, error) {
+	id, err := strconv.ParseUint(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return 0, errors.WrapValidationError(fmt.Sprintf("invalid id memo %q: must be an unsigned 64-bit integer", s))
+	}
+	return txnbuild.MemoID(id), nil
+}
+
+// HashMemo parses a hash memo given as 64 hex characters or as the base64
+// Horizon uses.
+func HashMemo(s string) (txnbuild.MemoHash, error) {
+	h, err := memoHash(s)
+	return txnbuild.MemoHash(h), err
+}
+
+// ReturnMemo parses a return memo, the hash of the transaction being
+// refunded, in the same forms as HashMemo.
+func ReturnMemo(s string) (txnbuild.MemoReturn, error) {
+	h, err := memoHash(s)
+	return txnbuild.MemoReturn(h), err
+}
+
+// ParseMemo builds a memo from a type and value. The type may be given as
+// text, id, hash, return or none, with or without a MEMO_ prefix and in
+// either case, covering both SEP-7 and Horizon spellings. It returns nil for
+// none, or for an empty type with an empty value.
+func ParseMemo(memoType, value string) (txnbuild.Memo, error) {
+	switch strings.TrimPrefix(strings.ToLower(strings.TrimSpace(memoType)), "memo_") {
+	case "", "none":
+		if value != "" {
+			return nil, errors.WrapValidationError("memo type is required with a memo")
+		}
+		return nil, nil
+	case "text":
+		return TextMemo(value)
+	case "id":
+		return IDMemo(value)
+	case "hash":
+		return HashMemo(value)
+	case "return":
+		return ReturnMemo(value)
+	}
+	return nil, errors.WrapValidationError(fmt.Sprintf("unknown memo type %q", memoType))
+}
+
+// MemoFromHorizon decodes the memo_type and memo fields of a Horizon
+// transaction. Horizon reports hash and return memos in base64.
+func MemoFromHorizon(memoType, memo string) (txnbuild.Memo, error) {
+	return ParseMemo(memoType, memo)
+}
+
+// MemoFromXDR converts an XDR memo, returning nil for MEMO_NONE. Text memos
+// already on the ledger are taken as they are, even if not valid UTF-8.
+func MemoFromXDR(m xdr.Memo) (txnbuild.Memo, error) {
+	switch m.Type {
+	case xdr.MemoTypeMemoNone:
+		return nil, nil
+	case xdr.MemoTypeMemoText:
+		if m.Text == nil {
+			break
+		}
+		return txnbuild.MemoText(*m.Text), nil
+	case xdr.MemoTypeMemoId:
+		if m.Id == nil {
+			break
+		}
+		return txnbuild.MemoID(*m.Id), nil
+	case xdr.MemoTypeMemoHash:
+		if m.Hash == nil {
+			break
+		}
+		return txnbuild.MemoHash(*m.Hash), nil
+	case xdr.MemoTypeMemoReturn:
+		if m.RetHash == nil {
+			break
+		}
+		return txnbuild.MemoReturn(*m.RetHash), nil
+	default:
+		return nil, errors.WrapValidationError(fmt.Sprintf("unknown memo type %d", m.Type))
+	}
+	return nil, errors.WrapValidationError(fmt.Sprintf("malformed %s has no value", m.Type))
+}
+
+// FormatMemo returns the Horizon memo_type and memo fields for m, with hash
+// and return memos in base64. A nil memo formats as "none".
+func FormatMemo(m txnbuild.Memo) (memoType, value string) {
+	switch v := m.(type) {
+	case nil:
+		return "none", ""
+	case txnbuild.MemoText:
+		return "text", string(v)
+	case txnbuild.MemoID:
+		return "id", strconv.FormatUint(uint64(v), 10)
+	case txnbuild.MemoHash:
+		return "hash", base64.StdEncoding.EncodeToString(v[:])
+	case txnbuild.MemoReturn:
+		return "return", base64.StdEncoding.EncodeToString(v[:])
+	}
+	return fmt.Sprintf("%T", m), ""
+}
+
+// MemoDestinations returns the accounts ops pay or merge into, in order and
+// without duplicates: the destinations SEP-29 asks wallets to check. Muxed
+// destinations are left out, because their ID already identifies the
+// recipient.
+func MemoDestinations(ops []txnbuild.Operation) []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, op := range ops {
+		var dest string
+		switch o := op.(type) {
+		case *txnbuild.Payment:
+			dest = o.Destination
+		case *txnbuild.PathPaymentStrictReceive:
+			dest = o.Destination
+		case *txnbuild.PathPaymentStrictSend:
+			dest = o.Destination
+		case *txnbuild.AccountMerge:
+			dest = o.Destination
+		}
+		if !strkey.IsValidEd25519PublicKey(dest) || seen[dest] {
+			continue
+		}
+		seen[dest] = true
+		out = append(out, dest)
+	}
+	return out
+}
+
+// CheckMemoRequired implements the SEP-29 check: when memo is nil and any
+// destination of ops has config.memo_required set to "1", it returns an
+// error matching errors.ErrMemoRequired that names the account.
+// Destinations that do not exist yet cannot require a memo and are
+// skipped.
+func CheckMemoRequired(ctx context.Context, client *rpc.Client, memo txnbuild.Memo, ops []txnbuild.Operation) error {
+	if memo != nil {
+		return nil
+	}
+	for _, dest := range MemoDestinations(ops) {
+		account, err := client.AccountDetails(ctx, dest)
+		if errors.Is(err, errors.ErrAccountNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if v, ok := account.DataString(MemoRequiredDataKey); ok && v == "1" {
+			return errors.WrapMemoRequired(dest)
+		}
+	}
+	return nil
+}
+
+func memoHash(s string) ([32]byte, error) {
+	var h [32]byte
+	s = strings.TrimSpace(s)
+	var (
+		raw []byte
+		err error
+	)
+	if len(s) == hex.EncodedLen(len(h)) {
+		raw, err = hex.DecodeString(s)
+	} else {
+		raw, err = base64.StdEncoding.DecodeString(s)
+	}
+	if err != nil || len(raw) != len(h) {
+		return h, errors.WrapValidationError(fmt.Sprintf("invalid memo hash %q: must be 32 bytes in hex or base64", s))
+	}
+	copy(h[:], raw)
+	return h, nil
+}
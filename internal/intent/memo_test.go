@@ -0,0 +1,116 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMemo(t *testing.T) {
+	hash := strings.Repeat("ab", 32)
+	var want txnbuild.MemoHash
+	for i := range want {
+		want[i] = 0xab
+	}
+
+	cases := []struct {
+		memoType, value string
+		want            txnbuild.Memo
+	}{
+		{"", "", nil},
+		{"none", "", nil},
+		{"MEMO_TEXT", "order 7", txnbuild.MemoText("order 7")},
+		{"id", "18446744073709551615", txnbuild.MemoID(18446744073709551615)},
+		{"MEMO_ID", "1", txnbuild.MemoID(1)},
+		{"hash", hash, want},
+		{"hash", "q6urq6urq6urq6urq6urq6urq6urq6urq6urq6urq6s=", want},
+		{"return", hash, txnbuild.MemoReturn(want)},
+	}
+	for _, c := range cases {
+		got, err := ParseMemo(c.memoType, c.value)
+		require.NoError(t, err, "%s %s", c.memoType, c.value)
+		assert.Equal(t, c.want, got, "%s %s", c.memoType, c.value)
+	}
+
+	for _, bad := range [][2]string{
+		{"text", strings.Repeat("x", 29)},
+		{"text", "\xff"},
+		{"id", "-1"},
+		{"hash", "abcd"},
+		{"", "orphan"},
+		{"MEMO_NUMBER", "1"},
+	} {
+		_, err := ParseMemo(bad[0], bad[1])
+		assert.ErrorIs(t, err, errs.ErrValidationFailed, "%s %q", bad[0], bad[1])
+	}
+}
+
+func TestMemoRoundTrip(t *testing.T) {
+	for _, m := range []txnbuild.Memo{txnbuild.MemoText("hi"), txnbuild.MemoID(9), txnbuild.MemoHash{1}, txnbuild.MemoReturn{2}} {
+		x, err := m.ToXDR()
+		require.NoError(t, err)
+		got, err := MemoFromXDR(x)
+		require.NoError(t, err)
+		assert.Equal(t, m, got)
+
+		memoType, value := FormatMemo(m)
+		got, err = MemoFromHorizon(memoType, value)
+		require.NoError(t, err)
+		assert.Equal(t, m, got)
+	}
+
+	got, err := MemoFromXDR(xdr.Memo{Type: xdr.MemoTypeMemoNone})
+	require.NoError(t, err)
+	assert.Nil(t, got)
+	_, err = MemoFromXDR(xdr.Memo{Type: xdr.MemoTypeMemoHash})
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+}
+
+func TestMemoDestinations(t *testing.T) {
+	other := keypair.MustRandom().Address()
+	var muxed strkey.MuxedAccount
+	require.NoError(t, muxed.SetAccountID(other))
+	muxed.SetID(7)
+	muxedAddr, err := muxed.Address()
+	require.NoError(t, err)
+	ops := []txnbuild.Operation{
+		&txnbuild.Payment{Destination: testDestination, Amount: "1", Asset: txnbuild.NativeAsset{}},
+		&txnbuild.PathPaymentStrictSend{Destination: testDestination},
+		&txnbuild.AccountMerge{Destination: muxedAddr},
+		&txnbuild.BumpSequence{BumpTo: 1},
+		&txnbuild.PathPaymentStrictReceive{Destination: other},
+	}
+	assert.Equal(t, []string{testDestination, other}, MemoDestinations(ops))
+}
+
+func TestCheckMemoRequired(t *testing.T) {
+	exchange := testAccount(testDestination, 1)
+	exchange.Data = map[string]string{MemoRequiredDataKey: "MQ=="}
+	client := newTestClient(t, newTestHorizon(testAccount(testSource, 41), exchange))
+	pay := NewOps(testSource, &txnbuild.Payment{Destination: testDestination, Amount: "1", Asset: txnbuild.NativeAsset{}})
+
+	_, err := Resolve(context.Background(), client, pay)
+	require.ErrorIs(t, err, errs.ErrMemoRequired)
+	assert.Contains(t, err.Error(), testDestination)
+
+	_, err = Resolve(context.Background(), client, pay, WithMemo(txnbuild.MemoID(5)))
+	assert.NoError(t, err)
+	_, err = Resolve(context.Background(), client, pay, WithoutMemoCheck())
+	assert.NoError(t, err)
+
+	// A destination that does not exist yet cannot have opted in.
+	fresh := NewOps(testSource, &txnbuild.Payment{Destination: keypair.MustRandom().Address(), Amount: "1", Asset: txnbuild.NativeAsset{}})
+	_, err = Resolve(context.Background(), client, fresh)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,351 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// ThresholdLevel is the account threshold an operation is checked against.
+type ThresholdLevel int
+
+const (
+	ThresholdLow ThresholdLevel = iota
+	ThresholdMedium
+	ThresholdHigh
+)
+
+func (l ThresholdLevel) String() string {
+	switch l {
+	case ThresholdLow:
+		return "low"
+	case ThresholdMedium:
+		return "medium"
+	default:
+		return "high"
+	}
+}
+
+// thresholdFor returns the threshold level stellar-core applies to op.
+func thresholdFor(op txnbuild.Operation) ThresholdLevel {
+	switch o := op.(type) {
+	case *txnbuild.AllowTrust, *txnbuild.SetTrustLineFlags, *txnbuild.BumpSequence, *txnbuild.ClaimClaimableBalance:
+		return ThresholdLow
+	case *txnbuild.AccountMerge:
+		return ThresholdHigh
+	case *txnbuild.SetOptions:
+		if o.MasterWeight != nil || o.LowThreshold != nil || o.MediumThreshold != nil || o.HighThreshold != nil || o.Signer != nil {
+			return ThresholdHigh
+		}
+	}
+	return ThresholdMedium
+}
+
+// SignatureRequirement is the signing state of one account whose authority
+// the transaction needs.
+type SignatureRequirement struct {
+	Account   string         `json:"account"`
+	Level     ThresholdLevel `json:"-"`
+	Threshold string         `json:"threshold"`
+	// Needed is the weight required; never less than 1.
+	Needed    int32 `json:"needed"`
+	Collected int32 `json:"collected"`
+	// Signed lists the keys whose signatures count towards Collected.
+	Signed []string `json:"signed,omitempty"`
+	// Pending lists the remaining keys that could still sign.
+	Pending []rpc.AccountSigner `json:"pending,omitempty"`
+}
+
+// Met reports whether enough weight has signed.
+func (r SignatureRequirement) Met() bool { return r.Collected >= r.Needed }
+
+// SigningBundle is the portable form of a partially signed transaction,
+// suitable for handing to other signers as JSON.
+type SigningBundle struct {
+	NetworkPassphrase string                 `json:"network_passphrase"`
+	Hash              string                 `json:"hash"`
+	EnvelopeXDR       string                 `json:"envelope_xdr"`
+	Requirements      []SignatureRequirement `json:"requirements"`
+}
+
+// SigningSession collects signatures for a transaction from several parties
+// and checks them against the current signers and thresholds of every
+// account the transaction acts for. Ed25519, pre-authorized transaction and
+// hash(x) signers are understood.
+type SigningSession struct {
+	passphrase string
+	tx         *txnbuild.Transaction
+	hash       [32]byte
+	levels     map[string]ThresholdLevel
+	order      []string
+	accounts   map[string]*rpc.AccountDetails
+}
+
+// NewSigningSession loads the signer configuration of every source account
+// in tx.
+func NewSigningSession(ctx context.Context, client *rpc.Client, tx *txnbuild.Transaction) (*SigningSession, error) {
+	if tx == nil {
+		return nil, errors.WrapValidationError("transaction is required")
+	}
+	s := &SigningSession{
+		passphrase: client.GetNetworkPassphrase(),
+		tx:         tx,
+		levels:     map[string]ThresholdLevel{},
+		accounts:   map[string]*rpc.AccountDetails{},
+	}
+	hash, err := tx.Hash(s.passphrase)
+	if err != nil {
+		return nil, errors.WrapMarshalFailed(err)
+	}
+	s.hash = hash
+
+	txSource := tx.SourceAccount().AccountID
+	s.require(txSource, ThresholdLow)
+	created := map[string]bool{}
+	for _, op := range tx.Operations() {
+		source := op.GetSourceAccount()
+		if source == "" {
+			source = txSource
+		}
+		s.require(source, thresholdFor(op))
+		if c, ok := op.(*txnbuild.CreateAccount); ok {
+			created[underlying(c.Destination)] = true
+		}
+	}
+
+	for _, account := range s.order {
+		details, err := client.AccountDetails(ctx, account)
+		if errors.Is(err, errors.ErrAccountNotFound) && created[account] {
+			// Accounts created by this transaction start with only their
+			// master key at weight 1 and all thresholds at 0.
+			details = &rpc.AccountDetails{
+				ID:      account,
+				Signers: []rpc.AccountSigner{{Key: account, Type: "ed25519_public_key", Weight: 1}},
+			}
+		} else if err != nil {
+			return nil, err
+		}
+		s.accounts[account] = details
+	}
+	return s, nil
+}
+
+func (s *SigningSession) require(address string, level ThresholdLevel) {
+	account := underlying(address)
+	current, ok := s.levels[account]
+	if !ok {
+		s.order = append(s.order, account)
+	}
+	if !ok || level > current {
+		s.levels[account] = level
+	}
+}
+
+// Transaction returns the transaction with all signatures collected so far.
+func (s *SigningSession) Transaction() *txnbuild.Transaction { return s.tx }
+
+// Sign adds a signature from each signer.
+func (s *SigningSession) Sign(ctx context.Context, signers ...Signer) error {
+	tx, err := SignTransaction(ctx, s.tx, s.passphrase, signers...)
+	if err != nil {
+		return err
+	}
+	s.tx = tx
+	return nil
+}
+
+// Merge adds the signatures of other copies of the same transaction, given
+// as base64 envelopes. Signatures already present are skipped; signatures
+// that belong to no relevant signer are rejected.
+func (s *SigningSession) Merge(envelopes ...string) error {
+	for _, env := range envelopes {
+		gen, err := txnbuild.TransactionFromXDR(env)
+		if err != nil {
+			return errors.WrapUnmarshalFailed(err, env)
+		}
+		other, ok := gen.Transaction()
+		if !ok {
+			return errors.WrapValidationError("fee-bump envelopes cannot be merged into a signing session")
+		}
+		hash, err := other.Hash(s.passphrase)
+		if err != nil {
+			return errors.WrapMarshalFailed(err)
+		}
+		if hash != s.hash {
+			return errors.WrapValidationError(fmt.Sprintf("envelope is for transaction %x, not %x", hash, s.hash))
+		}
+
+		var added []xdr.DecoratedSignature
+		for _, sig := range other.Signatures() {
+			if s.hasSignature(sig) {
+				continue
+			}
+			if !s.recognized(sig) {
+				return errors.WrapValidationError(fmt.Sprintf("signature with hint %x matches no signer of the transaction", sig.Hint))
+			}
+			added = append(added, sig)
+		}
+		if len(added) == 0 {
+			continue
+		}
+		if s.tx, err = s.tx.AddSignatureDecorated(added...); err != nil {
+			return errors.WrapValidationError(fmt.Sprintf("failed to add signatures: %v", err))
+		}
+	}
+	return nil
+}
+
+func (s *SigningSession) hasSignature(sig xdr.DecoratedSignature) bool {
+	for _, have := range s.tx.Signatures() {
+		if have.Hint == sig.Hint && bytes.Equal(have.Signature, sig.Signature) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *SigningSession) recognized(sig xdr.DecoratedSignature) bool {
+	for _, acc := range s.accounts {
+		for _, signer := range acc.Signers {
+			if s.signatureMatches(signer, sig) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Requirements reports, per account, how much weight has signed and which
+// signers are still missing.
+func (s *SigningSession) Requirements() []SignatureRequirement {
+	out := make([]SignatureRequirement, 0, len(s.order))
+	for _, account := range s.order {
+		acc := s.accounts[account]
+		level := s.levels[account]
+		r := SignatureRequirement{
+			Account:   account,
+			Level:     level,
+			Threshold: level.String(),
+			Needed:    neededWeight(acc.Thresholds, level),
+		}
+		for _, signer := range acc.Signers {
+			if signer.Weight <= 0 {
+				continue
+			}
+			if s.signed(signer) {
+				r.Collected += signer.Weight
+				r.Signed = append(r.Signed, signer.Key)
+			} else {
+				r.Pending = append(r.Pending, signer)
+			}
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// Complete reports whether every requirement is met.
+func (s *SigningSession) Complete() bool {
+	for _, r := range s.Requirements() {
+		if !r.Met() {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate returns an error describing the missing weight unless the
+// transaction is fully signed.
+func (s *SigningSession) Validate() error {
+	var missing []string
+	for _, r := range s.Requirements() {
+		if !r.Met() {
+			missing = append(missing, fmt.Sprintf("%s needs %d more (%s threshold)", r.Account, r.Needed-r.Collected, r.Threshold))
+		}
+	}
+	if len(missing) > 0 {
+		return errors.WrapValidationError("insufficient signatures: " + strings.Join(missing, "; "))
+	}
+	return nil
+}
+
+// Export returns the envelope with all collected signatures.
+func (s *SigningSession) Export() (string, error) {
+	return s.tx.Base64()
+}
+
+// Bundle returns the envelope together with the signing status.
+func (s *SigningSession) Bundle() (*SigningBundle, error) {
+	env, err := s.Export()
+	if err != nil {
+		return nil, errors.WrapMarshalFailed(err)
+	}
+	return &SigningBundle{
+		NetworkPassphrase: s.passphrase,
+		Hash:              hex.EncodeToString(s.hash[:]),
+		EnvelopeXDR:       env,
+		Requirements:      s.Requirements(),
+	}, nil
+}
+
+func (s *SigningSession) signed(signer rpc.AccountSigner) bool {
+	if signer.Type == "preauth_tx" {
+		raw, err := strkey.Decode(strkey.VersionByteHashTx, signer.Key)
+		return err == nil && bytes.Equal(raw, s.hash[:])
+	}
+	for _, sig := range s.tx.Signatures() {
+		if s.signatureMatches(signer, sig) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *SigningSession) signatureMatches(signer rpc.AccountSigner, sig xdr.DecoratedSignature) bool {
+	switch signer.Type {
+	case "sha256_hash":
+		raw, err := strkey.Decode(strkey.VersionByteHashX, signer.Key)
+		if err != nil || len(raw) < 4 || !bytes.Equal(sig.Hint[:], raw[len(raw)-4:]) {
+			return false
+		}
+		digest := sha256.Sum256(sig.Signature)
+		return bytes.Equal(digest[:], raw)
+	case "ed25519_public_key", "":
+		kp, err := keypair.ParseAddress(signer.Key)
+		if err != nil || kp.Hint() != sig.Hint {
+			return false
+		}
+		return kp.Verify(s.hash[:], sig.Signature) == nil
+	}
+	return false
+}
+
+func neededWeight(t rpc.AccountThresholds, level ThresholdLevel) int32 {
+	var w uint8
+	switch level {
+	case ThresholdLow:
+		w = t.Low
+	case ThresholdMedium:
+		w = t.Medium
+	default:
+		w = t.High
+	}
+	if w == 0 {
+		// stellar-core still demands at least one valid signature.
+		return 1
+	}
+	return int32(w)
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func multisigAccount(master, cosigner *keypair.Full, medium uint8) hProtocol.Account {
+	acc := testAccount(master.Address(), 10)
+	acc.Thresholds = hProtocol.AccountThresholds{LowThreshold: 1, MedThreshold: medium, HighThreshold: 3}
+	acc.Signers = []hProtocol.Signer{
+		{Key: master.Address(), Type: "ed25519_public_key", Weight: 1},
+		{Key: cosigner.Address(), Type: "ed25519_public_key", Weight: 1},
+	}
+	return acc
+}
+
+func testPaymentTx(t *testing.T, source string, seq int64) *txnbuild.Transaction {
+	t.Helper()
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &txnbuild.SimpleAccount{AccountID: source, Sequence: seq},
+		IncrementSequenceNum: true,
+		Operations:           []txnbuild.Operation{&txnbuild.Payment{Destination: testDestination, Amount: "1", Asset: txnbuild.NativeAsset{}}},
+		BaseFee:              txnbuild.MinBaseFee,
+		Preconditions:        txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+	})
+	require.NoError(t, err)
+	return tx
+}
+
+func TestSigningSession_MergeAcrossSigners(t *testing.T) {
+	master, cosigner := keypair.MustRandom(), keypair.MustRandom()
+	client := newTestClient(t, newTestHorizon(multisigAccount(master, cosigner, 2)))
+	ctx := context.Background()
+	tx := testPaymentTx(t, master.Address(), 10)
+
+	s, err := NewSigningSession(ctx, client, tx)
+	require.NoError(t, err)
+	reqs := s.Requirements()
+	require.Len(t, reqs, 1)
+	assert.Equal(t, "medium", reqs[0].Threshold)
+	assert.Equal(t, int32(2), reqs[0].Needed)
+	assert.Len(t, reqs[0].Pending, 2)
+
+	require.NoError(t, s.Sign(ctx, LocalSignerFromKeypair(master)))
+	assert.False(t, s.Complete())
+	assert.ErrorIs(t, s.Validate(), errs.ErrValidationFailed)
+
+	// The cosigner signs an independent copy of the unsigned transaction.
+	other, err := NewSigningSession(ctx, client, tx)
+	require.NoError(t, err)
+	require.NoError(t, other.Sign(ctx, LocalSignerFromKeypair(cosigner)))
+	env, err := other.Export()
+	require.NoError(t, err)
+
+	require.NoError(t, s.Merge(env, env))
+	assert.Len(t, s.Transaction().Signatures(), 2, "duplicates are skipped")
+	assert.True(t, s.Complete())
+	assert.NoError(t, s.Validate())
+
+	bundle, err := s.Bundle()
+	require.NoError(t, err)
+	raw, err := json.Marshal(bundle)
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), `"collected":2`)
+}
+
+func TestSigningSession_MergeRejections(t *testing.T) {
+	master, cosigner := keypair.MustRandom(), keypair.MustRandom()
+	client := newTestClient(t, newTestHorizon(multisigAccount(master, cosigner, 2)))
+	ctx := context.Background()
+
+	s, err := NewSigningSession(ctx, client, testPaymentTx(t, master.Address(), 10))
+	require.NoError(t, err)
+
+	different, err := testPaymentTx(t, master.Address(), 11).Sign(client.GetNetworkPassphrase(), master)
+	require.NoError(t, err)
+	env, err := different.Base64()
+	require.NoError(t, err)
+	assert.ErrorIs(t, s.Merge(env), errs.ErrValidationFailed, "different transaction")
+
+	stranger, err := testPaymentTx(t, master.Address(), 10).Sign(client.GetNetworkPassphrase(), keypair.MustRandom())
+	require.NoError(t, err)
+	env, err = stranger.Base64()
+	require.NoError(t, err)
+	assert.ErrorIs(t, s.Merge(env), errs.ErrValidationFailed, "unknown signer")
+}
+
+func TestThresholdFor(t *testing.T) {
+	weight := txnbuild.Threshold(2)
+	assert.Equal(t, ThresholdLow, thresholdFor(&txnbuild.BumpSequence{}))
+	assert.Equal(t, ThresholdMedium, thresholdFor(&txnbuild.Payment{}))
+	assert.Equal(t, ThresholdMedium, thresholdFor(&txnbuild.SetOptions{}))
+	assert.Equal(t, ThresholdHigh, thresholdFor(&txnbuild.SetOptions{MasterWeight: &weight}))
+	assert.Equal(t, ThresholdHigh, thresholdFor(&txnbuild.AccountMerge{}))
+}
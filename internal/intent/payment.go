@@ -0,0 +1,91 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/amount"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+)
+
+// PaymentIntent sends Amount of Asset from From to To. A native payment to an
+// account that does not exist yet creates it instead, unless To is a muxed
+// address.
+type PaymentIntent struct {
+	From   string
+	To     string
+	Asset  txnbuild.Asset
+	Amount string
+}
+
+func (i *PaymentIntent) Source() string { return i.From }
+
+func (i *PaymentIntent) Validate() error {
+	if err := validateAccount("source", i.From); err != nil {
+		return err
+	}
+	if err := validateAccount("destination", i.To); err != nil {
+		return err
+	}
+	if i.Asset == nil {
+		return errors.WrapValidationError("payment asset is required")
+	}
+	return validateAmount("payment", i.Amount)
+}
+
+// Operations checks that the destination can receive the asset before
+// building the payment, so the common op_no_destination and op_no_trust
+// failures surface here rather than on submission.
+func (i *PaymentIntent) Operations(ctx context.Context, client *rpc.Client) ([]txnbuild.Operation, error) {
+	dest, err := client.AccountDetails(ctx, i.To)
+	switch {
+	case errors.Is(err, errors.ErrAccountNotFound) && i.Asset.IsNative():
+		if account := underlying(i.To); account != i.To {
+			return nil, errors.WrapValidationError(fmt.Sprintf("destination %s does not exist and a muxed address cannot be created; create %s first", i.To, account))
+		}
+		return []txnbuild.Operation{&txnbuild.CreateAccount{Destination: i.To, Amount: i.Amount}}, nil
+	case err != nil:
+		return nil, err
+	}
+
+	if !i.Asset.IsNative() && dest.ID != i.Asset.GetIssuer() && !holdsAsset(dest, i.Asset) {
+		return nil, errors.WrapValidationError(fmt.Sprintf("destination %s has no trustline for %s", i.To, assetString(i.Asset)))
+	}
+	return []txnbuild.Operation{&txnbuild.Payment{Destination: i.To, Amount: i.Amount, Asset: i.Asset}}, nil
+}
+
+func holdsAsset(acc *rpc.AccountDetails, asset txnbuild.Asset) bool {
+	for _, b := range acc.Balances {
+		if b.Asset != nil && sameAsset(b.Asset, asset) {
+			return true
+		}
+	}
+	return false
+}
+
+func validateAmount(field, v string) error {
+	n, err := amount.ParseInt64(v)
+	if err != nil || n <= 0 {
+		return errors.WrapValidationError(field + " amount must be a positive decimal with at most 7 places")
+	}
+	return nil
+}
+
+func sameAsset(a, b txnbuild.Asset) bool {
+	if a.IsNative() || b.IsNative() {
+		return a.IsNative() == b.IsNative()
+	}
+	return a.GetCode() == b.GetCode() && a.GetIssuer() == b.GetIssuer()
+}
+
+func assetString(a txnbuild.Asset) string {
+	if a.IsNative() {
+		return "native"
+	}
+	return a.GetCode() + ":" + a.GetIssuer()
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/base"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testIssuer = keypair.MustRandom().Address()
+
+func testUSDC() txnbuild.Asset {
+	return txnbuild.CreditAsset{Code: "USDC", Issuer: testIssuer}
+}
+
+func withBalance(acc hProtocol.Account, asset txnbuild.Asset, balance string) hProtocol.Account {
+	acc.Balances = append(acc.Balances, hProtocol.Balance{
+		Balance: balance,
+		Asset:   base.Asset{Type: "credit_alphanum4", Code: asset.GetCode(), Issuer: asset.GetIssuer()},
+	})
+	return acc
+}
+
+func TestPaymentIntent_Credit(t *testing.T) {
+	client := newTestClient(t, newTestHorizon(
+		testAccount(testSource, 1),
+		withBalance(testAccount(testDestination, 5), testUSDC(), "0"),
+	))
+
+	p, err := Resolve(context.Background(), client, &PaymentIntent{From: testSource, To: testDestination, Asset: testUSDC(), Amount: "100"})
+	require.NoError(t, err)
+	ops := p.Tx.Operations()
+	require.Len(t, ops, 1)
+	payment, ok := ops[0].(*txnbuild.Payment)
+	require.True(t, ok, "got %T", ops[0])
+	assert.Equal(t, testDestination, payment.Destination)
+	assert.Equal(t, "100", payment.Amount)
+}
+
+func TestPaymentIntent_CreatesMissingAccount(t *testing.T) {
+	client := newTestClient(t, newTestHorizon(testAccount(testSource, 1)))
+
+	in := &PaymentIntent{From: testSource, To: testDestination, Asset: txnbuild.NativeAsset{}, Amount: "5"}
+	ops, err := in.Operations(context.Background(), client)
+	require.NoError(t, err)
+	require.Len(t, ops, 1)
+	create, ok := ops[0].(*txnbuild.CreateAccount)
+	require.True(t, ok, "got %T", ops[0])
+	assert.Equal(t, "5", create.Amount)
+}
+
+func TestPaymentIntent_MissingMuxedDestination(t *testing.T) {
+	client := newTestClient(t, newTestHorizon(testAccount(testSource, 1)))
+	muxed, err := xdr.MuxedAccountFromAccountId(testDestination, 7)
+	require.NoError(t, err)
+
+	in := &PaymentIntent{From: testSource, To: muxed.Address(), Asset: txnbuild.NativeAsset{}, Amount: "5"}
+	require.NoError(t, in.Validate())
+	_, err = in.Operations(context.Background(), client)
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+	assert.ErrorContains(t, err, "muxed address cannot be created")
+}
+
+func TestPaymentIntent_Rejections(t *testing.T) {
+	client := newTestClient(t, newTestHorizon(testAccount(testSource, 1), testAccount(testDestination, 1)))
+	ctx := context.Background()
+
+	_, err := (&PaymentIntent{From: testSource, To: testDestination, Asset: testUSDC(), Amount: "1"}).Operations(ctx, client)
+	assert.ErrorIs(t, err, errs.ErrValidationFailed, "no trustline")
+
+	other := keypair.MustRandom().Address()
+	_, err = (&PaymentIntent{From: testSource, To: other, Asset: testUSDC(), Amount: "1"}).Operations(ctx, client)
+	assert.ErrorIs(t, err, errs.ErrAccountNotFound)
+
+	for _, in := range []*PaymentIntent{
+		{From: testSource, To: testDestination, Asset: testUSDC(), Amount: "0"},
+		{From: testSource, To: testDestination, Asset: testUSDC(), Amount: "1.12345678"},
+		{From: testSource, To: testDestination, Amount: "1"},
+		{From: testSource, To: "bogus", Asset: testUSDC(), Amount: "1"},
+	} {
+		assert.ErrorIs(t, in.Validate(), errs.ErrValidationFailed, "%+v", in)
+	}
+}
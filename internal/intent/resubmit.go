@@ -0,0 +1,155 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/dotandev/hintents/internal/rpc"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+)
+
+// DefaultMaxAttempts is how often SubmitWithRetry builds and submits a
+// transaction before giving up.
+const DefaultMaxAttempts = 3
+
+// RetryPolicy controls SubmitWithRetry.
+type RetryPolicy struct {
+	// MaxAttempts counts the first submission. Defaults to
+	// DefaultMaxAttempts; 1 disables retries.
+	MaxAttempts int
+	// Backoff is waited before each rebuild.
+	Backoff time.Duration
+	// Poll controls the wait for confirmation; rpc.DefaultPollConfig when
+	// zero.
+	Poll rpc.PollConfig
+	// Sequences, when set, issues the sequence numbers so retries cooperate
+	// with other submitters from the same source. Otherwise every attempt
+	// loads the account.
+	Sequences *SequenceManager
+}
+
+// Attempt is one build and submission of a transaction.
+type Attempt struct {
+	Number   int    `json:"number"`
+	Sequence int64  `json:"sequence,omitempty"`
+	Hash     string `json:"hash,omitempty"`
+	// Err is why the attempt did not confirm; nil for the last attempt of
+	// a successful submission.
+	Err error `json:"-"`
+}
+
+// SubmitResult is the outcome of SubmitWithRetry.
+type SubmitResult struct {
+	Tx       *hProtocol.Transaction
+	Attempts []Attempt
+}
+
+// Retryable reports whether err is an envelope-level failure that a rebuilt
+// transaction can avoid: a stale sequence number or expired time bounds.
+// The rejected transaction consumed nothing, so rebuilding cannot apply the
+// intent twice.
+func Retryable(err error) bool {
+	return errors.Is(err, errors.ErrTxBadSeq) || errors.Is(err, errors.ErrTxTooLate)
+}
+
+// SubmitWithRetry resolves, signs and submits in, and waits for
+// confirmation. When an attempt fails with a Retryable error, the
+// transaction is rebuilt from scratch, with a fresh sequence number, new
+// time bounds and a new simulation for Soroban invocations, re-signed and
+// submitted again, up to policy.MaxAttempts times. The result lists every
+// attempt and is returned together with the error of the last one.
+func SubmitWithRetry(ctx context.Context, client *rpc.Client, in Intent, signers []Signer, policy RetryPolicy, opts ...Option) (*SubmitResult, error) {
+	if client == nil {
+		return nil, errors.WrapValidationError("rpc client is required")
+	}
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = DefaultMaxAttempts
+	}
+	if policy.Poll == (rpc.PollConfig{}) {
+		policy.Poll = rpc.DefaultPollConfig()
+	}
+
+	result := &SubmitResult{}
+	for n := 1; ; n++ {
+		a := Attempt{Number: n}
+		tx, err := submitAttempt(ctx, client, in, signers, policy, opts, &a)
+		a.Err = err
+		result.Attempts = append(result.Attempts, a)
+		if err == nil {
+			result.Tx = tx
+			return result, nil
+		}
+		if !Retryable(err) || n >= policy.MaxAttempts {
+			if n > 1 {
+				err = fmt.Errorf("submission failed after %d attempts: %w", n, err)
+			}
+			return result, err
+		}
+		logger.Logger.Info("Rebuilding rejected transaction", "source", in.Source(), "attempt", n, "error", err)
+
+		if policy.Backoff > 0 {
+			select {
+			case <-ctx.Done():
+				return result, ctx.Err()
+			case <-time.After(policy.Backoff):
+			}
+		}
+	}
+}
+
+// submitAttempt runs one attempt, recording its sequence number and hash in
+// a.
+func submitAttempt(ctx context.Context, client *rpc.Client, in Intent, signers []Signer, policy RetryPolicy, opts []Option, a *Attempt) (*hProtocol.Transaction, error) {
+	var seq *Reservation
+	if policy.Sequences != nil {
+		var err error
+		if seq, err = policy.Sequences.Reserve(ctx, in.Source()); err != nil {
+			return nil, err
+		}
+		opts = append(append([]Option{}, opts...), seq.Option())
+	}
+	release := func() {
+		if seq != nil {
+			seq.Release()
+		}
+	}
+
+	p, err := Resolve(ctx, client, in, opts...)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	a.Sequence = p.Tx.SequenceNumber()
+	passphrase := client.GetNetworkPassphrase()
+	tx, err := SignTransaction(ctx, p.Tx, passphrase, signers...)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	if a.Hash, err = tx.HashHex(passphrase); err != nil {
+		release()
+		return nil, errors.WrapMarshalFailed(err)
+	}
+	env, err := tx.Base64()
+	if err != nil {
+		release()
+		return nil, errors.WrapMarshalFailed(err)
+	}
+
+	if _, err := client.SubmitTransactionAsync(ctx, env); err != nil {
+		if seq != nil {
+			seq.Fail(err)
+		}
+		return nil, err
+	}
+	if seq != nil {
+		seq.Commit()
+	}
+	return client.WaitForTransaction(ctx, a.Hash, policy.Poll)
+}
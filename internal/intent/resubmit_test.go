@@ -0,0 +1,93 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rejectHorizon rejects the next submissions with the queued result codes
+// before accepting again. onReject runs after each rejection.
+type rejectHorizon struct {
+	*submitHorizon
+	reject   []xdr.TransactionResultCode
+	onReject func()
+}
+
+func (h *rejectHorizon) AsyncSubmitTransactionXDR(env string) (hProtocol.AsyncTransactionSubmissionResponse, error) {
+	if len(h.reject) == 0 {
+		return h.submitHorizon.AsyncSubmitTransactionXDR(env)
+	}
+	code := h.reject[0]
+	h.reject = h.reject[1:]
+	result, err := xdr.MarshalBase64(xdr.TransactionResult{FeeCharged: 100, Result: xdr.TransactionResultResult{Code: code}})
+	if err != nil {
+		return hProtocol.AsyncTransactionSubmissionResponse{}, err
+	}
+	if h.onReject != nil {
+		h.onReject()
+	}
+	return hProtocol.AsyncTransactionSubmissionResponse{TxStatus: "ERROR", ErrorResultXDR: result}, nil
+}
+
+func TestSubmitWithRetry_RebuildsOnBadSeq(t *testing.T) {
+	kp := keypair.MustRandom()
+	h := &rejectHorizon{
+		submitHorizon: newSubmitHorizon(testAccount(kp.Address(), 10)),
+		reject:        []xdr.TransactionResultCode{xdr.TransactionResultCodeTxBadSeq},
+	}
+	// Another submitter consumed sequence numbers concurrently.
+	h.onReject = func() { h.accounts[kp.Address()] = testAccount(kp.Address(), 15) }
+	client := newTestClient(t, h)
+
+	in := NewOps(kp.Address(), &txnbuild.BumpSequence{})
+	res, err := SubmitWithRetry(context.Background(), client, in, []Signer{LocalSignerFromKeypair(kp)},
+		RetryPolicy{Poll: testPoll, Sequences: NewSequenceManager(client)})
+	require.NoError(t, err)
+	require.Len(t, res.Attempts, 2)
+	assert.ErrorIs(t, res.Attempts[0].Err, errs.ErrTxBadSeq)
+	assert.Equal(t, int64(11), res.Attempts[0].Sequence)
+	assert.NoError(t, res.Attempts[1].Err)
+	assert.Equal(t, int64(16), res.Attempts[1].Sequence)
+	assert.NotEqual(t, res.Attempts[0].Hash, res.Attempts[1].Hash)
+	assert.Equal(t, res.Attempts[1].Hash, res.Tx.Hash)
+
+	require.Len(t, h.submitted, 1)
+	assert.Len(t, h.submitted[0].Signatures(), 1, "the rebuilt transaction is signed again")
+}
+
+func TestSubmitWithRetry_GivesUp(t *testing.T) {
+	kp := keypair.MustRandom()
+	h := &rejectHorizon{
+		submitHorizon: newSubmitHorizon(testAccount(kp.Address(), 10)),
+		reject: []xdr.TransactionResultCode{
+			xdr.TransactionResultCodeTxTooLate,
+			xdr.TransactionResultCodeTxTooLate,
+			xdr.TransactionResultCodeTxInsufficientFee,
+		},
+	}
+	client := newTestClient(t, h)
+	in := NewOps(kp.Address(), &txnbuild.BumpSequence{})
+	signers := []Signer{LocalSignerFromKeypair(kp)}
+
+	res, err := SubmitWithRetry(context.Background(), client, in, signers, RetryPolicy{MaxAttempts: 2, Poll: testPoll})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errs.ErrTxTooLate)
+	assert.Contains(t, err.Error(), "after 2 attempts")
+	assert.Len(t, res.Attempts, 2)
+
+	res, err = SubmitWithRetry(context.Background(), client, in, signers, RetryPolicy{Poll: testPoll})
+	assert.ErrorIs(t, err, errs.ErrTxInsufficientFee)
+	assert.Len(t, res.Attempts, 1, "other rejections are not retried")
+	assert.Empty(t, h.submitted)
+}
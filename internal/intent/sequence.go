@@ -0,0 +1,177 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/dotandev/hintents/internal/rpc"
+)
+
+// SequenceManager hands out sequence numbers for source accounts to
+// concurrent submitters. Numbers are loaded from the network once and then
+// issued locally, so transactions can be built while earlier ones are still
+// in flight. Numbers that were reserved but never reached the network are
+// reissued before new ones, closing the gap they would otherwise leave.
+//
+// After a resync the manager only knows what the network has applied;
+// transactions still queued in stellar-core may then collide with newly
+// issued numbers and fail with tx_bad_seq, which triggers another resync.
+type SequenceManager struct {
+	client *rpc.Client
+
+	mu       sync.Mutex
+	accounts map[string]*accountSequence
+}
+
+type accountSequence struct {
+	// next is the lowest number never issued; 0 until loaded.
+	next int64
+	// released holds issued numbers returned unused, ascending.
+	released []int64
+	// generation changes on every resync, invalidating older reservations.
+	generation int
+}
+
+// NewSequenceManager returns a manager loading sequence numbers via client.
+func NewSequenceManager(client *rpc.Client) *SequenceManager {
+	return &SequenceManager{client: client, accounts: map[string]*accountSequence{}}
+}
+
+// Reservation is a sequence number held by one transaction. Exactly one of
+// Commit, Release or Fail should be called once its fate is known.
+type Reservation struct {
+	Account string
+	// Sequence is the number the transaction consumes.
+	Sequence int64
+
+	m          *SequenceManager
+	generation int
+	done       bool
+}
+
+// Option builds the transaction with this reservation's sequence number.
+func (r *Reservation) Option() Option {
+	return WithSequence(r.Sequence - 1)
+}
+
+// Commit records that the network accepted the transaction, consuming the
+// number even if the transaction later fails.
+func (r *Reservation) Commit() {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+	r.done = true
+}
+
+// Release returns the number for reuse because the transaction was never
+// accepted.
+func (r *Reservation) Release() {
+	r.m.mu.Lock()
+	defer r.m.mu.Unlock()
+	if r.done {
+		return
+	}
+	r.done = true
+	s := r.m.accounts[r.Account]
+	if s == nil || s.generation != r.generation || r.Sequence >= s.next {
+		return
+	}
+	i := sort.Search(len(s.released), func(i int) bool { return s.released[i] >= r.Sequence })
+	if i < len(s.released) && s.released[i] == r.Sequence {
+		return
+	}
+	s.released = append(s.released, 0)
+	copy(s.released[i+1:], s.released[i:])
+	s.released[i] = r.Sequence
+}
+
+// Fail handles a submission error: tx_bad_seq means the local view is
+// wrong and the account is resynced on its next reservation; any other
+// rejection releases the number.
+func (r *Reservation) Fail(err error) {
+	if !errors.Is(err, errors.ErrTxBadSeq) {
+		r.Release()
+		return
+	}
+	r.m.mu.Lock()
+	r.done = true
+	r.m.mu.Unlock()
+	r.m.invalidate(r.Account)
+}
+
+// Reserve issues the next sequence number for account.
+func (m *SequenceManager) Reserve(ctx context.Context, account string) (*Reservation, error) {
+	account = underlying(account)
+
+	m.mu.Lock()
+	if s := m.accounts[account]; s != nil && s.next != 0 {
+		defer m.mu.Unlock()
+		return m.issue(account, s), nil
+	}
+	m.mu.Unlock()
+
+	// Load outside the lock; concurrent loads of the same account are
+	// harmless because only the first result is kept.
+	details, err := m.client.AccountDetails(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.accounts[account]
+	if s == nil {
+		s = &accountSequence{}
+		m.accounts[account] = s
+	}
+	if s.next == 0 {
+		s.next = details.Sequence + 1
+	}
+	return m.issue(account, s), nil
+}
+
+// issue hands out the lowest released number, or a new one. m.mu is held.
+func (m *SequenceManager) issue(account string, s *accountSequence) *Reservation {
+	r := &Reservation{Account: account, m: m, generation: s.generation}
+	if len(s.released) > 0 {
+		r.Sequence, s.released = s.released[0], s.released[1:]
+	} else {
+		r.Sequence = s.next
+		s.next++
+	}
+	return r
+}
+
+// Resync discards what the manager knows about account and reloads its
+// sequence number from the network.
+func (m *SequenceManager) Resync(ctx context.Context, account string) error {
+	account = underlying(account)
+	m.invalidate(account)
+	details, err := m.client.AccountDetails(ctx, account)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if s := m.accounts[account]; s.next == 0 {
+		s.next = details.Sequence + 1
+	}
+	return nil
+}
+
+func (m *SequenceManager) invalidate(account string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s := m.accounts[account]
+	if s == nil {
+		s = &accountSequence{}
+		m.accounts[account] = s
+	}
+	s.next, s.released = 0, nil
+	s.generation++
+	logger.Logger.Debug("Sequence number invalidated", "account", account)
+}
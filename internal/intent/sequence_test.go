@@ -0,0 +1,93 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequenceManager_Concurrent(t *testing.T) {
+	m := NewSequenceManager(newTestClient(t, newTestHorizon(testAccount(testSource, 100))))
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	var got []int64
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r, err := m.Reserve(ctx, testSource)
+			if !assert.NoError(t, err) {
+				return
+			}
+			r.Commit()
+			mu.Lock()
+			got = append(got, r.Sequence)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+	require.Len(t, got, 50)
+	for i, seq := range got {
+		assert.Equal(t, int64(101+i), seq)
+	}
+}
+
+func TestSequenceManager_ReleaseFillsGap(t *testing.T) {
+	m := NewSequenceManager(newTestClient(t, newTestHorizon(testAccount(testSource, 10))))
+	ctx := context.Background()
+	reserve := func() *Reservation {
+		r, err := m.Reserve(ctx, testSource)
+		require.NoError(t, err)
+		return r
+	}
+
+	a, b, c := reserve(), reserve(), reserve()
+	assert.Equal(t, []int64{11, 12, 13}, []int64{a.Sequence, b.Sequence, c.Sequence})
+	b.Commit()
+
+	c.Release()
+	a.Fail(errs.NewSendTransactionError("ERROR", "", "tx_insufficient_fee"))
+	a.Release() // already settled; ignored
+
+	assert.Equal(t, int64(11), reserve().Sequence, "gaps are refilled lowest first")
+	assert.Equal(t, int64(13), reserve().Sequence)
+	assert.Equal(t, int64(14), reserve().Sequence)
+}
+
+func TestSequenceManager_ResyncOnBadSeq(t *testing.T) {
+	h := newTestHorizon(testAccount(testSource, 10))
+	m := NewSequenceManager(newTestClient(t, h))
+	ctx := context.Background()
+
+	r, err := m.Reserve(ctx, testSource)
+	require.NoError(t, err)
+	stale, err := m.Reserve(ctx, testSource)
+	require.NoError(t, err)
+
+	// Another process used the account meanwhile.
+	h.accounts[testSource] = testAccount(testSource, 20)
+	r.Fail(errs.NewSendTransactionError("ERROR", "", "tx_bad_seq"))
+	stale.Release() // from before the resync; ignored
+
+	next, err := m.Reserve(ctx, testSource)
+	require.NoError(t, err)
+	assert.Equal(t, int64(21), next.Sequence)
+
+	h.accounts[testSource] = testAccount(testSource, 30)
+	require.NoError(t, m.Resync(ctx, testSource))
+	next, err = m.Reserve(ctx, testSource)
+	require.NoError(t, err)
+	assert.Equal(t, int64(31), next.Sequence)
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/network"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// Signer produces Ed25519 signatures for one account key. Implementations
+// may keep the secret anywhere, such as in memory, a hardware wallet, a KMS
+// or a remote signing service.
+type Signer interface {
+	// PublicKey returns the G... address of the signing key.
+	PublicKey() string
+	// Sign signs payload, usually a 32-byte transaction or authorization
+	// hash.
+	Sign(ctx context.Context, payload []byte) (xdr.DecoratedSignature, error)
+}
+
+// LocalSigner signs with a secret seed held in memory.
+type LocalSigner struct {
+	kp *keypair.Full
+}
+
+// NewLocalSigner returns a signer for an S... secret seed.
+func NewLocalSigner(seed string) (*LocalSigner, error) {
+	kp, err := keypair.ParseFull(seed)
+	if err != nil {
+		return nil, errors.WrapValidationError("invalid secret seed")
+	}
+	return &LocalSigner{kp: kp}, nil
+}
+
+// LocalSignerFromKeypair wraps an already parsed keypair.
+func LocalSignerFromKeypair(kp *keypair.Full) *LocalSigner {
+	return &LocalSigner{kp: kp}
+}
+
+func (s *LocalSigner) PublicKey() string { return s.kp.Address() }
+
+func (s *LocalSigner) Sign(ctx context.Context, hash []byte) (xdr.DecoratedSignature, error) {
+	if err := ctx.Err(); err != nil {
+		return xdr.DecoratedSignature{}, err
+	}
+	return s.kp.SignDecorated(hash)
+}
+
+// SignTransaction adds a signature from each signer to tx.
+func SignTransaction(ctx context.Context, tx *txnbuild.Transaction, passphrase string, signers ...Signer) (*txnbuild.Transaction, error) {
+	hash, err := tx.Hash(passphrase)
+	if err != nil {
+		return nil, errors.WrapMarshalFailed(err)
+	}
+	sigs, err := signHash(ctx, hash[:], signers)
+	if err != nil {
+		return nil, err
+	}
+	if tx, err = tx.AddSignatureDecorated(sigs...); err != nil {
+		return nil, errors.WrapValidationError(fmt.Sprintf("failed to add signatures: %v", err))
+	}
+	return tx, nil
+}
+
+// SignFeeBump adds a signature from each signer to a fee-bump transaction.
+func SignFeeBump(ctx context.Context, tx *txnbuild.FeeBumpTransaction, passphrase string, signers ...Signer) (*txnbuild.FeeBumpTransaction, error) {
+	hash, err := tx.Hash(passphrase)
+	if err != nil {
+		return nil, errors.WrapMarshalFailed(err)
+	}
+	sigs, err := signHash(ctx, hash[:], signers)
+	if err != nil {
+		return nil, err
+	}
+	if tx, err = tx.AddSignatureDecorated(sigs...); err != nil {
+		return nil, errors.WrapValidationError(fmt.Sprintf("failed to add signatures: %v", err))
+	}
+	return tx, nil
+}
+
+func signHash(ctx context.Context, hash []byte, signers []Signer) ([]xdr.DecoratedSignature, error) {
+	sigs := make([]xdr.DecoratedSignature, 0, len(signers))
+	for _, s := range signers {
+		sig, err := s.Sign(ctx, hash)
+		if err != nil {
+			return nil, errors.WrapUnauthorized(fmt.Sprintf("signer %s: %v", s.PublicKey(), err))
+		}
+		sigs = append(sigs, sig)
+	}
+	return sigs, nil
+}
+
+// SignAuthEntry authorizes a Soroban invocation on behalf of the signer's
+// account. The entry must carry address credentials for that account; it is
+// returned with its signature and expiration ledger set.
+func SignAuthEntry(ctx context.Context, entry xdr.SorobanAuthorizationEntry, signer Signer, passphrase string, validUntilLedger uint32) (xdr.SorobanAuthorizationEntry, error) {
+	creds, ok := entry.Credentials.GetAddress()
+	if !ok {
+		return entry, errors.WrapValidationError("auth entry uses source account credentials and needs no separate signature")
+	}
+	address := signer.PublicKey()
+	pub, err := strkey.Decode(strkey.VersionByteAccountID, address)
+	if err != nil {
+		return entry, errors.WrapValidationError(fmt.Sprintf("invalid signer key %q", address))
+	}
+	account, ok := creds.Address.GetAccountId()
+	if !ok || account.Address() != address {
+		return entry, errors.WrapValidationError(fmt.Sprintf("auth entry is not for %s", address))
+	}
+
+	preimage := xdr.HashIdPreimage{
+		Type: xdr.EnvelopeTypeEnvelopeTypeSorobanAuthorization,
+		SorobanAuthorization: &xdr.HashIdPreimageSorobanAuthorization{
+			NetworkId:                 network.ID(passphrase),
+			Nonce:                     creds.Nonce,
+			SignatureExpirationLedger: xdr.Uint32(validUntilLedger),
+			Invocation:                entry.RootInvocation,
+		},
+	}
+	payload, err := preimage.MarshalBinary()
+	if err != nil {
+		return entry, errors.WrapMarshalFailed(err)
+	}
+	hash := sha256.Sum256(payload)
+	sig, err := signer.Sign(ctx, hash[:])
+	if err != nil {
+		return entry, errors.WrapUnauthorized(fmt.Sprintf("signer %s: %v", address, err))
+	}
+
+	// The account contract expects a vector of {public_key, signature} maps.
+	pubBytes, sigBytes := xdr.ScBytes(pub), xdr.ScBytes(sig.Signature)
+	pkSym, sigSym := xdr.ScSymbol("public_key"), xdr.ScSymbol("signature")
+	m := &xdr.ScMap{
+		{Key: xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &pkSym}, Val: xdr.ScVal{Type: xdr.ScValTypeScvBytes, Bytes: &pubBytes}},
+		{Key: xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sigSym}, Val: xdr.ScVal{Type: xdr.ScValTypeScvBytes, Bytes: &sigBytes}},
+	}
+	vec := &xdr.ScVec{{Type: xdr.ScValTypeScvMap, Map: &m}}
+
+	creds.SignatureExpirationLedger = xdr.Uint32(validUntilLedger)
+	creds.Signature = xdr.ScVal{Type: xdr.ScValTypeScvVec, Vec: &vec}
+	entry.Credentials.Address = &creds
+	return entry, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/network"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingSigner struct{ address string }
+
+func (s failingSigner) PublicKey() string { return s.address }
+
+func (s failingSigner) Sign(context.Context, []byte) (xdr.DecoratedSignature, error) {
+	return xdr.DecoratedSignature{}, fmt.Errorf("device locked")
+}
+
+func TestNewLocalSigner(t *testing.T) {
+	kp := keypair.MustRandom()
+	s, err := NewLocalSigner(kp.Seed())
+	require.NoError(t, err)
+	assert.Equal(t, kp.Address(), s.PublicKey())
+
+	_, err = NewLocalSigner(kp.Address())
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+}
+
+func TestSignTransaction(t *testing.T) {
+	kp := keypair.MustRandom()
+	ctx := context.Background()
+	tx := testPaymentTx(t, kp.Address(), 1)
+
+	signed, err := SignTransaction(ctx, tx, network.TestNetworkPassphrase, LocalSignerFromKeypair(kp))
+	require.NoError(t, err)
+	require.Len(t, signed.Signatures(), 1)
+	hash, err := tx.Hash(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	assert.NoError(t, kp.Verify(hash[:], signed.Signatures()[0].Signature))
+
+	_, err = SignTransaction(ctx, tx, network.TestNetworkPassphrase, failingSigner{kp.Address()})
+	assert.ErrorIs(t, err, errs.ErrUnauthorized)
+}
+
+func TestSignFeeBump(t *testing.T) {
+	source, sponsor := keypair.MustRandom(), keypair.MustRandom()
+	ctx := context.Background()
+	inner, err := SignTransaction(ctx, testPaymentTx(t, source.Address(), 1), network.TestNetworkPassphrase, LocalSignerFromKeypair(source))
+	require.NoError(t, err)
+	fb, err := WrapWithFeeBump(inner, sponsor.Address(), 1000)
+	require.NoError(t, err)
+
+	fb, err = SignFeeBump(ctx, fb, network.TestNetworkPassphrase, LocalSignerFromKeypair(sponsor))
+	require.NoError(t, err)
+	require.Len(t, fb.Signatures(), 1)
+	hash, err := fb.Hash(network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	assert.NoError(t, sponsor.Verify(hash[:], fb.Signatures()[0].Signature))
+}
+
+func TestSignAuthEntry(t *testing.T) {
+	kp := keypair.MustRandom()
+	ctx := context.Background()
+	entry := xdr.SorobanAuthorizationEntry{
+		Credentials: xdr.SorobanCredentials{
+			Type: xdr.SorobanCredentialsTypeSorobanCredentialsAddress,
+			Address: &xdr.SorobanAddressCredentials{
+				Address: xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeAccount, AccountId: xdr.MustAddressPtr(kp.Address())},
+				Nonce:   42,
+			},
+		},
+		RootInvocation: xdr.SorobanAuthorizedInvocation{
+			Function: xdr.SorobanAuthorizedFunction{
+				Type:       xdr.SorobanAuthorizedFunctionTypeSorobanAuthorizedFunctionTypeContractFn,
+				ContractFn: func() *xdr.InvokeContractArgs { a := testContractInvocation(); return &a }(),
+			},
+		},
+	}
+
+	signed, err := SignAuthEntry(ctx, entry, LocalSignerFromKeypair(kp), network.TestNetworkPassphrase, 1000)
+	require.NoError(t, err)
+	creds := signed.Credentials.Address
+	assert.Equal(t, xdr.Uint32(1000), creds.SignatureExpirationLedger)
+	vec, ok := creds.Signature.GetVec()
+	require.True(t, ok)
+	require.Len(t, *vec, 1)
+	m, ok := (*vec)[0].GetMap()
+	require.True(t, ok)
+	require.Len(t, *m, 2)
+	sig := (*m)[1].Val.MustBytes()
+
+	preimage := xdr.HashIdPreimage{
+		Type: xdr.EnvelopeTypeEnvelopeTypeSorobanAuthorization,
+		SorobanAuthorization: &xdr.HashIdPreimageSorobanAuthorization{
+			NetworkId:                 network.ID(network.TestNetworkPassphrase),
+			Nonce:                     42,
+			SignatureExpirationLedger: 1000,
+			Invocation:                entry.RootInvocation,
+		},
+	}
+	payload, err := preimage.MarshalBinary()
+	require.NoError(t, err)
+	hash := sha256.Sum256(payload)
+	assert.NoError(t, kp.Verify(hash[:], sig))
+	assert.Equal(t, xdr.Uint32(0), entry.Credentials.Address.SignatureExpirationLedger, "input entry is left untouched")
+
+	_, err = SignAuthEntry(ctx, entry, LocalSignerFromKeypair(keypair.MustRandom()), network.TestNetworkPassphrase, 1000)
+	assert.ErrorIs(t, err, errs.ErrValidationFailed, "different account")
+
+	sourceCreds := entry
+	sourceCreds.Credentials = xdr.SorobanCredentials{Type: xdr.SorobanCredentialsTypeSorobanCredentialsSourceAccount}
+	_, err = SignAuthEntry(ctx, sourceCreds, LocalSignerFromKeypair(kp), network.TestNetworkPassphrase, 1000)
+	assert.ErrorIs(t, err, errs.ErrValidationFailed, "source account credentials")
+}
@@ -0,0 +1,165 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+)
+
+// SponsoredIntent runs operations whose reserves are paid by Sponsor rather
+// than by Sponsored, bracketing them with begin and end sponsoring future
+// reserves. Sponsor is the transaction source and pays the fee.
+//
+// Operations without a source account act for Sponsored, except
+// CreateAccount, which Sponsor submits. The resulting transaction must be
+// signed by both accounts; for a newly created account that is its master
+// key.
+type SponsoredIntent struct {
+	Sponsor   string
+	Sponsored string
+	List      []txnbuild.Operation
+}
+
+// NewSponsoredAccount returns the common onboarding flow: Sponsor creates
+// account with a zero balance, pays the reserves for it and for a trustline
+// to each of assets.
+func NewSponsoredAccount(sponsor, account string, assets ...txnbuild.Asset) (*SponsoredIntent, error) {
+	ops := []txnbuild.Operation{&txnbuild.CreateAccount{Destination: account, Amount: "0"}}
+	for _, a := range assets {
+		line, err := a.ToChangeTrustAsset()
+		if err != nil {
+			return nil, errors.WrapValidationError(fmt.Sprintf("invalid trustline asset: %v", err))
+		}
+		ops = append(ops, &txnbuild.ChangeTrust{Line: line, Limit: txnbuild.MaxTrustlineLimit})
+	}
+	return &SponsoredIntent{Sponsor: sponsor, Sponsored: account, List: ops}, nil
+}
+
+func (i *SponsoredIntent) Source() string { return i.Sponsor }
+
+func (i *SponsoredIntent) Validate() error {
+	if err := validateAccount("sponsor", i.Sponsor); err != nil {
+		return err
+	}
+	if err := validateAccount("sponsored", i.Sponsored); err != nil {
+		return err
+	}
+	if underlying(i.Sponsor) == underlying(i.Sponsored) {
+		return errors.WrapValidationError("an account cannot sponsor itself")
+	}
+	if len(i.List) == 0 {
+		return errors.WrapValidationError("at least one sponsored operation is required")
+	}
+	for _, op := range i.List {
+		switch op.(type) {
+		case *txnbuild.BeginSponsoringFutureReserves, *txnbuild.EndSponsoringFutureReserves:
+			return errors.WrapValidationError("sponsorship brackets are added automatically")
+		case txnbuild.SorobanOperation:
+			return errors.WrapValidationError("Soroban operations cannot be sponsored")
+		}
+	}
+	return nil
+}
+
+// Operations returns begin, the sponsored operations with their source
+// accounts filled in, and end, in that order.
+func (i *SponsoredIntent) Operations(context.Context, *rpc.Client) ([]txnbuild.Operation, error) {
+	ops := make([]txnbuild.Operation, 0, len(i.List)+2)
+	ops = append(ops, &txnbuild.BeginSponsoringFutureReserves{SponsoredID: underlying(i.Sponsored), SourceAccount: i.Sponsor})
+	for _, op := range i.List {
+		if op.GetSourceAccount() != "" {
+			ops = append(ops, op)
+			continue
+		}
+		source := i.Sponsored
+		if _, ok := op.(*txnbuild.CreateAccount); ok {
+			source = i.Sponsor
+		}
+		withSource, err := withSourceAccount(op, source)
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, withSource)
+	}
+	ops = append(ops, &txnbuild.EndSponsoringFutureReserves{SourceAccount: i.Sponsored})
+	return ops, nil
+}
+
+// RequiredSigners lists the accounts that must sign the transaction.
+func (i *SponsoredIntent) RequiredSigners() []string {
+	return []string{underlying(i.Sponsor), underlying(i.Sponsored)}
+}
+
+// withSourceAccount returns a copy of op acting for source. Operations are
+// copied so the caller's values are left untouched.
+func withSourceAccount(op txnbuild.Operation, source string) (txnbuild.Operation, error) {
+	switch o := op.(type) {
+	case *txnbuild.CreateAccount:
+		c := *o
+		c.SourceAccount = source
+		return &c, nil
+	case *txnbuild.ChangeTrust:
+		c := *o
+		c.SourceAccount = source
+		return &c, nil
+	case *txnbuild.Payment:
+		c := *o
+		c.SourceAccount = source
+		return &c, nil
+	case *txnbuild.SetOptions:
+		c := *o
+		c.SourceAccount = source
+		return &c, nil
+	case *txnbuild.ManageData:
+		c := *o
+		c.SourceAccount = source
+		return &c, nil
+	case *txnbuild.ManageSellOffer:
+		c := *o
+		c.SourceAccount = source
+		return &c, nil
+	case *txnbuild.ManageBuyOffer:
+		c := *o
+		c.SourceAccount = source
+		return &c, nil
+	case *txnbuild.CreatePassiveSellOffer:
+		c := *o
+		c.SourceAccount = source
+		return &c, nil
+	case *txnbuild.CreateClaimableBalance:
+		c := *o
+		c.SourceAccount = source
+		return &c, nil
+	case *txnbuild.ClaimClaimableBalance:
+		c := *o
+		c.SourceAccount = source
+		return &c, nil
+	case *txnbuild.LiquidityPoolDeposit:
+		c := *o
+		c.SourceAccount = source
+		return &c, nil
+	case *txnbuild.LiquidityPoolWithdraw:
+		c := *o
+		c.SourceAccount = source
+		return &c, nil
+	case *txnbuild.PathPaymentStrictSend:
+		c := *o
+		c.SourceAccount = source
+		return &c, nil
+	case *txnbuild.PathPaymentStrictReceive:
+		c := *o
+		c.SourceAccount = source
+		return &c, nil
+	case *txnbuild.InvokeHostFunction:
+		c := *o
+		c.SourceAccount = source
+		return &c, nil
+	}
+	return nil, errors.WrapValidationError(fmt.Sprintf("%T needs an explicit source account", op))
+}
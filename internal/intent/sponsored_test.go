@@ -0,0 +1,61 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSponsoredIntent_Onboarding(t *testing.T) {
+	sponsor, newcomer := keypair.MustRandom(), keypair.MustRandom()
+	client := newTestClient(t, newTestHorizon(testAccount(sponsor.Address(), 20)))
+	ctx := context.Background()
+
+	in, err := NewSponsoredAccount(sponsor.Address(), newcomer.Address(), testUSDC())
+	require.NoError(t, err)
+	p, err := Resolve(ctx, client, in, WithBaseFee(100))
+	require.NoError(t, err)
+
+	ops := p.Tx.Operations()
+	require.Len(t, ops, 4)
+	begin, ok := ops[0].(*txnbuild.BeginSponsoringFutureReserves)
+	require.True(t, ok, "got %T", ops[0])
+	assert.Equal(t, newcomer.Address(), begin.SponsoredID)
+	assert.Equal(t, sponsor.Address(), ops[1].GetSourceAccount(), "the sponsor creates the account")
+	assert.Equal(t, newcomer.Address(), ops[2].GetSourceAccount(), "the trustline belongs to the new account")
+	_, ok = ops[3].(*txnbuild.EndSponsoringFutureReserves)
+	require.True(t, ok, "got %T", ops[3])
+	assert.Equal(t, newcomer.Address(), ops[3].GetSourceAccount())
+	assert.Equal(t, []string{sponsor.Address(), newcomer.Address()}, in.RequiredSigners())
+	assert.Empty(t, in.List[1].GetSourceAccount(), "caller operations are not modified")
+
+	s, err := NewSigningSession(ctx, client, p.Tx)
+	require.NoError(t, err)
+	require.NoError(t, s.Sign(ctx, LocalSignerFromKeypair(sponsor)))
+	assert.ErrorIs(t, s.Validate(), errs.ErrValidationFailed, "the new account must sign too")
+	require.NoError(t, s.Sign(ctx, LocalSignerFromKeypair(newcomer)))
+	assert.NoError(t, s.Validate())
+}
+
+func TestSponsoredIntent_Validate(t *testing.T) {
+	sponsored := keypair.MustRandom().Address()
+	for _, in := range []*SponsoredIntent{
+		{Sponsor: testSource, Sponsored: testSource, List: []txnbuild.Operation{&txnbuild.BumpSequence{}}},
+		{Sponsor: testSource, Sponsored: sponsored},
+		{Sponsor: testSource, Sponsored: sponsored, List: []txnbuild.Operation{&txnbuild.EndSponsoringFutureReserves{}}},
+	} {
+		assert.ErrorIs(t, in.Validate(), errs.ErrValidationFailed, "%+v", in)
+	}
+
+	in := &SponsoredIntent{Sponsor: testSource, Sponsored: sponsored, List: []txnbuild.Operation{&txnbuild.BumpSequence{}}}
+	_, err := in.Operations(context.Background(), nil)
+	assert.ErrorIs(t, err, errs.ErrValidationFailed, "operations without a known source field need one set")
+}
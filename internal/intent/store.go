@@ -0,0 +1,332 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+)
+
+var (
+	// ErrRecordNotFound is returned by Store.Load for unknown IDs.
+	ErrRecordNotFound = errors.New("intent record not found")
+	// ErrRecordExists is returned by Store.Create for IDs already in use.
+	ErrRecordExists = errors.New("intent record already exists")
+)
+
+// State is where a stored transaction is in its lifecycle.
+type State string
+
+const (
+	StateBuilt     State = "built"
+	StateSigned    State = "signed"
+	StateSubmitted State = "submitted"
+	StateConfirmed State = "confirmed"
+	StateFailed    State = "failed"
+)
+
+// Final reports whether s is a terminal state.
+func (s State) Final() bool { return s == StateConfirmed || s == StateFailed }
+
+// Record is the persisted form of one transaction.
+type Record struct {
+	ID                string `json:"id"`
+	State             State  `json:"state"`
+	Source            string `json:"source"`
+	NetworkPassphrase string `json:"network_passphrase"`
+	// EnvelopeXDR is the latest envelope, with every signature collected.
+	EnvelopeXDR string `json:"envelope_xdr"`
+	Hash        string `json:"hash"`
+	// ValidUntil is the upper time bound; a transaction not included by
+	// then never will be. Zero means unbounded.
+	ValidUntil time.Time `json:"valid_until,omitempty"`
+	Ledger     int32     `json:"ledger,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// NewRecord returns a record for tx in the built state, or signed when tx
+// already carries signatures.
+func NewRecord(id, networkPassphrase string, tx *txnbuild.Transaction) (*Record, error) {
+	if id == "" {
+		return nil, errors.WrapValidationError("record id is required")
+	}
+	r := &Record{ID: id, NetworkPassphrase: networkPassphrase, Source: tx.SourceAccount().AccountID, CreatedAt: time.Now()}
+	if err := r.setTransaction(tx); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// SetSigned replaces the envelope with its signed form.
+func (r *Record) SetSigned(tx *txnbuild.Transaction) error {
+	if r.State.Final() || r.State == StateSubmitted {
+		return errors.WrapValidationError(fmt.Sprintf("record %s is already %s", r.ID, r.State))
+	}
+	return r.setTransaction(tx)
+}
+
+func (r *Record) setTransaction(tx *txnbuild.Transaction) error {
+	hash, err := tx.HashHex(r.NetworkPassphrase)
+	if err != nil {
+		return errors.WrapMarshalFailed(err)
+	}
+	if r.Hash != "" && hash != r.Hash {
+		return errors.WrapValidationError(fmt.Sprintf("record %s is for transaction %s, not %s", r.ID, r.Hash, hash))
+	}
+	env, err := tx.Base64()
+	if err != nil {
+		return errors.WrapMarshalFailed(err)
+	}
+	r.Hash, r.EnvelopeXDR = hash, env
+	r.ValidUntil = time.Time{}
+	if max := tx.Timebounds().MaxTime; max > 0 {
+		r.ValidUntil = time.Unix(max, 0)
+	}
+	r.State = StateBuilt
+	if len(tx.Signatures()) > 0 {
+		r.State = StateSigned
+	}
+	return nil
+}
+
+// Store persists records so that a restarted process can resume them.
+type Store interface {
+	Save(ctx context.Context, r *Record) error
+	// Create saves r only if no record with its ID exists, atomically
+	// with respect to other processes using the store, and returns
+	// ErrRecordExists otherwise.
+	Create(ctx context.Context, r *Record) error
+	Load(ctx context.Context, id string) (*Record, error)
+	// Pending returns every record not yet confirmed or failed, oldest
+	// first.
+	Pending(ctx context.Context) ([]*Record, error)
+}
+
+// Execute submits a signed record and waits for the outcome, saving each
+// transition before acting on it. It is safe to call again after a crash
+// at any point: a record already submitted is looked up before it is sent
+// again. Rejections and failed results end in StateFailed without an error;
+// errors mean the outcome is still unknown.
+func Execute(ctx context.Context, client *rpc.Client, store Store, r *Record, poll rpc.PollConfig) error {
+	switch r.State {
+	case StateConfirmed, StateFailed:
+		return nil
+	case StateBuilt:
+		return errors.WrapValidationError(fmt.Sprintf("record %s is not signed", r.ID))
+	case StateSubmitted:
+		done, err := checkIncluded(ctx, client, store, r)
+		if done || err != nil {
+			return err
+		}
+	}
+
+	// Record the attempt first, so a crash mid-submission is followed by a
+	// lookup rather than a blind resubmission.
+	r.State = StateSubmitted
+	if err := save(ctx, store, r); err != nil {
+		return err
+	}
+	// A duplicate is this envelope sent before, by an earlier attempt or
+	// another process; its outcome is waited for like our own.
+	if _, err := client.SubmitTransactionAsync(ctx, r.EnvelopeXDR); err != nil && !errors.Is(err, errors.ErrTxDuplicate) {
+		if errors.Is(err, errors.ErrTxStatusError) {
+			return finish(ctx, store, r, StateFailed, 0, err)
+		}
+		return err
+	}
+
+	tx, err := client.WaitForTransaction(ctx, r.Hash, poll)
+	var resultErr *errors.TransactionResultError
+	switch {
+	case err == nil:
+		return finish(ctx, store, r, StateConfirmed, tx.Ledger, nil)
+	case errors.As(err, &resultErr):
+		return finish(ctx, store, r, StateFailed, tx.Ledger, err)
+	case expired(r):
+		return finish(ctx, store, r, StateFailed, 0, errors.ErrTxTooLate)
+	}
+	return err
+}
+
+// Resume executes every pending record that is signed or submitted. Records
+// still waiting for signatures are returned untouched, along with the rest.
+// The first error is returned after all records have been tried.
+func Resume(ctx context.Context, client *rpc.Client, store Store, poll rpc.PollConfig) ([]*Record, error) {
+	records, err := store.Pending(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var first error
+	for _, r := range records {
+		if r.State == StateBuilt {
+			continue
+		}
+		logger.Logger.Debug("Resuming intent", "id", r.ID, "state", r.State, "hash", r.Hash)
+		if err := Execute(ctx, client, store, r, poll); err != nil && first == nil {
+			first = fmt.Errorf("resume %s: %w", r.ID, err)
+		}
+	}
+	return records, first
+}
+
+// checkIncluded finishes r if the network already applied it, or if it can
+// no longer be applied.
+func checkIncluded(ctx context.Context, client *rpc.Client, store Store, r *Record) (bool, error) {
+	tx, err := client.Horizon.TransactionDetail(r.Hash)
+	if err == nil {
+		if tx.Successful {
+			return true, finish(ctx, store, r, StateConfirmed, tx.Ledger, nil)
+		}
+		return true, finish(ctx, store, r, StateFailed, tx.Ledger, errors.ErrTxFailed)
+	}
+	if hErr, ok := err.(*horizonclient.Error); !ok || hErr.Problem.Status != http.StatusNotFound {
+		return false, errors.WrapRPCConnectionFailed(err)
+	}
+	if expired(r) {
+		return true, finish(ctx, store, r, StateFailed, 0, errors.ErrTxTooLate)
+	}
+	return false, nil
+}
+
+func expired(r *Record) bool {
+	return !r.ValidUntil.IsZero() && time.Now().After(r.ValidUntil)
+}
+
+func finish(ctx context.Context, store Store, r *Record, state State, ledger int32, cause error) error {
+	r.State, r.Ledger = state, ledger
+	if cause != nil {
+		r.Error = cause.Error()
+	}
+	logger.Logger.Debug("Intent finished", "id", r.ID, "state", state, "hash", r.Hash)
+	return save(ctx, store, r)
+}
+
+func save(ctx context.Context, store Store, r *Record) error {
+	r.UpdatedAt = time.Now()
+	return store.Save(ctx, r)
+}
+
+// FileStore keeps one JSON file per record in a directory.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore returns a store in dir, creating it if needed.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, errors.WrapConfigError("failed to create intent store directory", err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// path derives a file name from the ID so that any ID is safe to use.
+func (s *FileStore) path(id string) string {
+	sum := sha256.Sum256([]byte(id))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:16])+".json")
+}
+
+func (s *FileStore) Save(_ context.Context, r *Record) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return errors.WrapMarshalFailed(err)
+	}
+	// Write then rename so a crash never leaves a truncated record.
+	tmp := s.path(r.ID) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write intent record: %w", err)
+	}
+	if err := os.Rename(tmp, s.path(r.ID)); err != nil {
+		return fmt.Errorf("failed to write intent record: %w", err)
+	}
+	return nil
+}
+
+func (s *FileStore) Create(_ context.Context, r *Record) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return errors.WrapMarshalFailed(err)
+	}
+	// A hard link fails if the target exists, so only one of several
+	// racing processes creates the record. The temporary name is unique
+	// per call for the same reason.
+	f, err := os.CreateTemp(s.dir, "create-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write intent record: %w", err)
+	}
+	tmp := f.Name()
+	defer os.Remove(tmp)
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write intent record: %w", err)
+	}
+	if err := os.Link(tmp, s.path(r.ID)); err != nil {
+		if os.IsExist(err) {
+			return fmt.Errorf("%w: %s", ErrRecordExists, r.ID)
+		}
+		return fmt.Errorf("failed to write intent record: %w", err)
+	}
+	return nil
+}
+
+func (s *FileStore) Load(_ context.Context, id string) (*Record, error) {
+	data, err := os.ReadFile(s.path(id))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", ErrRecordNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read intent record: %w", err)
+	}
+	var r Record
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, errors.WrapUnmarshalFailed(err, string(data))
+	}
+	return &r, nil
+}
+
+func (s *FileStore) Pending(_ context.Context) ([]*Record, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list intent records: %w", err)
+	}
+	var out []*Record
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read intent record: %w", err)
+		}
+		var r Record
+		if err := json.Unmarshal(data, &r); err != nil {
+			logger.Logger.Warn("Skipping unreadable intent record", "file", e.Name(), "error", err)
+			continue
+		}
+		if !r.State.Final() {
+			out = append(out, &r)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dotandev/hintents/internal/errors"
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteStore keeps records in a SQLite database, for services tracking
+// many transactions.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore opens or creates the database at path.
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", path+"?_journal_mode=WAL")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open intent store: %w", err)
+	}
+	query := `
+	CREATE TABLE IF NOT EXISTS intents (
+		id TEXT PRIMARY KEY,
+		state TEXT NOT NULL,
+		record TEXT NOT NULL,
+		created_at TIMESTAMP NOT NULL
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_intents_state ON intents(state);
+	`
+	if _, err := db.Exec(query); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create intent store schema: %w", err)
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+func (s *SQLiteStore) Save(ctx context.Context, r *Record) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return errors.WrapMarshalFailed(err)
+	}
+	query := `
+	INSERT INTO intents (id, state, record, created_at) VALUES (?, ?, ?, ?)
+	ON CONFLICT(id) DO UPDATE SET
+		state = excluded.state,
+		record = excluded.record
+	`
+	if _, err := s.db.ExecContext(ctx, query, r.ID, string(r.State), string(data), r.CreatedAt); err != nil {
+		return fmt.Errorf("failed to save intent record: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLiteStore) Create(ctx context.Context, r *Record) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return errors.WrapMarshalFailed(err)
+	}
+	query := `
+	INSERT INTO intents (id, state, record, created_at) VALUES (?, ?, ?, ?)
+	ON CONFLICT(id) DO NOTHING
+	`
+	res, err := s.db.ExecContext(ctx, query, r.ID, string(r.State), string(data), r.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save intent record: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("%w: %s", ErrRecordExists, r.ID)
+	}
+	return nil
+}
+
+func (s *SQLiteStore) Load(ctx context.Context, id string) (*Record, error) {
+	var data string
+	err := s.db.QueryRowContext(ctx, `SELECT record FROM intents WHERE id = ?`, id).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", ErrRecordNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load intent record: %w", err)
+	}
+	var r Record
+	if err := json.Unmarshal([]byte(data), &r); err != nil {
+		return nil, errors.WrapUnmarshalFailed(err, data)
+	}
+	return &r, nil
+}
+
+func (s *SQLiteStore) Pending(ctx context.Context) ([]*Record, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT record FROM intents WHERE state NOT IN (?, ?) ORDER BY created_at ASC`,
+		string(StateConfirmed), string(StateFailed))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list intent records: %w", err)
+	}
+	defer rows.Close()
+
+	var out []*Record
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to scan intent record: %w", err)
+		}
+		var r Record
+		if err := json.Unmarshal([]byte(data), &r); err != nil {
+			return nil, errors.WrapUnmarshalFailed(err, data)
+		}
+		out = append(out, &r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating intent records: %w", err)
+	}
+	return out, nil
+}
+
+// Close closes the database.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
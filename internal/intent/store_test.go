@@ -0,0 +1,204 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/network"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPoll = rpc.PollConfig{Interval: time.Millisecond, Timeout: 50 * time.Millisecond}
+
+func testSignedRecord(t *testing.T, id string, timeout int64) (*Record, *keypair.Full) {
+	t.Helper()
+	kp := keypair.MustRandom()
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &txnbuild.SimpleAccount{AccountID: kp.Address(), Sequence: 1},
+		IncrementSequenceNum: true,
+		Operations:           []txnbuild.Operation{&txnbuild.BumpSequence{}},
+		BaseFee:              txnbuild.MinBaseFee,
+		Preconditions:        txnbuild.Preconditions{TimeBounds: txnbuild.NewTimebounds(0, timeout)},
+	})
+	require.NoError(t, err)
+	r, err := NewRecord(id, network.TestNetworkPassphrase, tx)
+	require.NoError(t, err)
+	assert.Equal(t, StateBuilt, r.State)
+
+	tx, err = tx.Sign(network.TestNetworkPassphrase, kp)
+	require.NoError(t, err)
+	require.NoError(t, r.SetSigned(tx))
+	assert.Equal(t, StateSigned, r.State)
+	return r, kp
+}
+
+func testStores(t *testing.T) map[string]Store {
+	files, err := NewFileStore(filepath.Join(t.TempDir(), "intents"))
+	require.NoError(t, err)
+	db, err := NewSQLiteStore(filepath.Join(t.TempDir(), "intents.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return map[string]Store{"file": files, "sqlite": db}
+}
+
+func TestStore_SaveLoadPending(t *testing.T) {
+	ctx := context.Background()
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			first, _ := testSignedRecord(t, "order/1", 0)
+			second, _ := testSignedRecord(t, "order/2", 0)
+			second.CreatedAt = first.CreatedAt.Add(time.Second)
+			require.NoError(t, store.Save(ctx, second))
+			require.NoError(t, store.Save(ctx, first))
+
+			loaded, err := store.Load(ctx, "order/1")
+			require.NoError(t, err)
+			assert.Equal(t, first.Hash, loaded.Hash)
+			assert.Equal(t, first.EnvelopeXDR, loaded.EnvelopeXDR)
+
+			_, err = store.Load(ctx, "order/3")
+			assert.ErrorIs(t, err, ErrRecordNotFound)
+
+			first.State = StateConfirmed
+			require.NoError(t, store.Save(ctx, first))
+			pending, err := store.Pending(ctx)
+			require.NoError(t, err)
+			require.Len(t, pending, 1)
+			assert.Equal(t, "order/2", pending[0].ID)
+		})
+	}
+}
+
+func TestExecute(t *testing.T) {
+	ctx := context.Background()
+	h := newSubmitHorizon()
+	client := newTestClient(t, h)
+	store, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	r, kp := testSignedRecord(t, "pay-1", 0)
+	h.accounts[kp.Address()] = testAccount(kp.Address(), 1)
+	require.NoError(t, Execute(ctx, client, store, r, testPoll))
+	assert.Equal(t, StateConfirmed, r.State)
+	assert.Equal(t, int32(7), r.Ledger)
+	assert.Len(t, h.submitted, 1)
+
+	saved, err := store.Load(ctx, "pay-1")
+	require.NoError(t, err)
+	assert.Equal(t, StateConfirmed, saved.State)
+
+	require.NoError(t, Execute(ctx, client, store, r, testPoll))
+	assert.Len(t, h.submitted, 1, "finished records are not resubmitted")
+}
+
+func TestResume(t *testing.T) {
+	ctx := context.Background()
+	h := newSubmitHorizon()
+	client := newTestClient(t, h)
+	store, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	// Submitted before the crash and since included.
+	included, _ := testSignedRecord(t, "included", 0)
+	included.State = StateSubmitted
+	h.included[included.Hash] = true
+	// Submitted before the crash, lost, and past its time bounds.
+	lost, _ := testSignedRecord(t, "lost", time.Now().Add(-time.Minute).Unix())
+	lost.State = StateSubmitted
+	// Signed but never sent.
+	signed, kp := testSignedRecord(t, "signed", 0)
+	h.accounts[kp.Address()] = testAccount(kp.Address(), 1)
+	for _, r := range []*Record{included, lost, signed} {
+		require.NoError(t, store.Save(ctx, r))
+	}
+
+	records, err := Resume(ctx, client, store, testPoll)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+
+	states := map[string]State{}
+	for _, r := range records {
+		states[r.ID] = r.State
+	}
+	assert.Equal(t, map[string]State{"included": StateConfirmed, "lost": StateFailed, "signed": StateConfirmed}, states)
+	assert.Len(t, h.submitted, 1, "only the unsent record is submitted")
+
+	pending, err := store.Pending(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestStore_Create(t *testing.T) {
+	ctx := context.Background()
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			r, _ := testSignedRecord(t, "once", 0)
+			require.NoError(t, store.Create(ctx, r))
+			other, _ := testSignedRecord(t, "once", 0)
+			assert.ErrorIs(t, store.Create(ctx, other), ErrRecordExists)
+
+			loaded, err := store.Load(ctx, "once")
+			require.NoError(t, err)
+			assert.Equal(t, r.Hash, loaded.Hash, "the first record is kept")
+		})
+	}
+}
+
+func TestSubmitIdempotent(t *testing.T) {
+	ctx := context.Background()
+	kp := keypair.MustRandom()
+	h := newSubmitHorizon(testAccount(kp.Address(), 10))
+	client := newTestClient(t, h)
+	store, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	signers := []Signer{LocalSignerFromKeypair(kp)}
+	in := NewOps(kp.Address(), &txnbuild.BumpSequence{})
+
+	first, err := SubmitIdempotent(ctx, client, store, "invoice-42", in, signers, testPoll)
+	require.NoError(t, err)
+	assert.Equal(t, StateConfirmed, first.State)
+
+	// The account moved on, so a rebuilt transaction would differ.
+	h.accounts[kp.Address()] = testAccount(kp.Address(), 11)
+	again, err := SubmitIdempotent(ctx, client, store, "invoice-42", in, signers, testPoll)
+	require.NoError(t, err)
+	assert.Equal(t, first.Hash, again.Hash)
+	assert.Equal(t, StateConfirmed, again.State)
+	assert.Len(t, h.submitted, 1, "a recorded key is never submitted twice")
+
+	_, err = SubmitIdempotent(ctx, client, store, "invoice-42", NewOps(testSource, &txnbuild.BumpSequence{}), nil, testPoll)
+	assert.ErrorIs(t, err, errs.ErrValidationFailed, "keys are bound to their source")
+
+	_, err = SubmitIdempotent(ctx, client, store, "", in, signers, testPoll)
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+}
+
+func TestSubmitIdempotent_ResumesUnknownOutcome(t *testing.T) {
+	ctx := context.Background()
+	h := newSubmitHorizon()
+	client := newTestClient(t, h)
+	store, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	// A previous process crashed after sending the transaction.
+	r, kp := testSignedRecord(t, "refund-7", 0)
+	r.State = StateSubmitted
+	require.NoError(t, store.Save(ctx, r))
+	h.included[r.Hash] = true
+
+	got, err := SubmitIdempotent(ctx, client, store, "refund-7", NewOps(kp.Address(), &txnbuild.BumpSequence{}), nil, testPoll)
+	require.NoError(t, err)
+	assert.Equal(t, StateConfirmed, got.State)
+	assert.Equal(t, r.Hash, got.Hash)
+	assert.Empty(t, h.submitted)
+}
@@ -0,0 +1,263 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/amount"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+)
+
+// SwapMode selects which side of a swap is fixed.
+type SwapMode string
+
+const (
+	// StrictSend spends exactly Amount of SendAsset.
+	StrictSend SwapMode = "strict_send"
+	// StrictReceive delivers exactly Amount of DestAsset.
+	StrictReceive SwapMode = "strict_receive"
+)
+
+// DefaultQuoteTTL is how long a swap route is reused before it is quoted
+// again.
+const DefaultQuoteTTL = 30 * time.Second
+
+// SwapIntent exchanges SendAsset for DestAsset over the best route Horizon's
+// path finding offers. Limit bounds the other side of the trade: the minimum
+// received for StrictSend or the maximum spent for StrictReceive. When Limit
+// is empty it is derived from the first quote and MaxSlippageBps; with no
+// slippage allowed the quoted amount itself is used, so any adverse price
+// move fails the transaction rather than filling at a worse rate.
+//
+// Routes older than QuoteTTL are quoted again, but the bound stays fixed: if
+// the market has moved past it, Operations fails instead of building a
+// transaction that would.
+type SwapIntent struct {
+	From string
+	// To receives the purchased asset; defaults to From.
+	To        string
+	Mode      SwapMode
+	SendAsset txnbuild.Asset
+	DestAsset txnbuild.Asset
+	Amount    string
+	Limit     string
+	// MaxSlippageBps is the tolerated price move from the first quote, in
+	// basis points. Ignored when Limit is set.
+	MaxSlippageBps int
+	// QuoteTTL defaults to DefaultQuoteTTL.
+	QuoteTTL time.Duration
+
+	// Route is the path chosen by the last call to Operations, quoted at
+	// QuotedAt. Callers may preset both to reuse a quote shown to the user.
+	Route    *rpc.PaymentPath
+	QuotedAt time.Time
+
+	// bound is the limit fixed when the first quote was taken.
+	bound string
+}
+
+func (i *SwapIntent) Source() string { return i.From }
+
+func (i *SwapIntent) destination() string {
+	if i.To == "" {
+		return i.From
+	}
+	return i.To
+}
+
+func (i *SwapIntent) Validate() error {
+	if err := validateAccount("source", i.From); err != nil {
+		return err
+	}
+	if i.To != "" {
+		if err := validateAccount("destination", i.To); err != nil {
+			return err
+		}
+	}
+	if i.Mode != StrictSend && i.Mode != StrictReceive {
+		return errors.WrapValidationError(fmt.Sprintf("unknown swap mode %q", i.Mode))
+	}
+	if i.SendAsset == nil || i.DestAsset == nil {
+		return errors.WrapValidationError("send and destination assets are required")
+	}
+	if sameAsset(i.SendAsset, i.DestAsset) && i.destination() == i.From {
+		return errors.WrapValidationError("swap must change the asset or the recipient")
+	}
+	if err := validateAmount("swap", i.Amount); err != nil {
+		return err
+	}
+	if i.MaxSlippageBps < 0 || i.MaxSlippageBps >= 10000 {
+		return errors.WrapValidationError(fmt.Sprintf("max slippage must be between 0 and 9999 basis points, got %d", i.MaxSlippageBps))
+	}
+	if i.Limit != "" {
+		return validateAmount("swap limit", i.Limit)
+	}
+	return nil
+}
+
+// Operations discovers a route, re-quoting a stale one, and builds the
+// matching path payment.
+func (i *SwapIntent) Operations(ctx context.Context, client *rpc.Client) ([]txnbuild.Operation, error) {
+	ttl := i.QuoteTTL
+	if ttl <= 0 {
+		ttl = DefaultQuoteTTL
+	}
+	if i.Route == nil || time.Since(i.QuotedAt) > ttl {
+		route, err := i.findRoute(ctx, client)
+		if err != nil {
+			return nil, err
+		}
+		if i.Route != nil {
+			logger.Logger.Debug("Swap re-quoted", "source_amount", route.SourceAmount, "destination_amount", route.DestinationAmount)
+		}
+		i.Route, i.QuotedAt = route, time.Now()
+	}
+	route := i.Route
+
+	bound, err := i.limit()
+	if err != nil {
+		return nil, err
+	}
+	if err := i.checkRoute(route, bound); err != nil {
+		return nil, err
+	}
+
+	if i.Mode == StrictSend {
+		return []txnbuild.Operation{&txnbuild.PathPaymentStrictSend{
+			SendAsset:   i.SendAsset,
+			SendAmount:  i.Amount,
+			Destination: i.destination(),
+			DestAsset:   i.DestAsset,
+			DestMin:     bound,
+			Path:        route.Path,
+		}}, nil
+	}
+
+	return []txnbuild.Operation{&txnbuild.PathPaymentStrictReceive{
+		SendAsset:   i.SendAsset,
+		SendMax:     bound,
+		Destination: i.destination(),
+		DestAsset:   i.DestAsset,
+		DestAmount:  i.Amount,
+		Path:        route.Path,
+	}}, nil
+}
+
+// limit returns DestMin for StrictSend or SendMax for StrictReceive, deriving
+// it from the current route the first time it is needed.
+func (i *SwapIntent) limit() (string, error) {
+	if i.Limit != "" {
+		return i.Limit, nil
+	}
+	if i.bound != "" {
+		return i.bound, nil
+	}
+	quote := i.Route.SourceAmount
+	if i.Mode == StrictSend {
+		quote = i.Route.DestinationAmount
+	}
+	if i.MaxSlippageBps == 0 {
+		i.bound = quote
+		return quote, nil
+	}
+	stroops, err := amount.ParseInt64(quote)
+	if err != nil {
+		return "", errors.WrapValidationError(fmt.Sprintf("invalid quoted amount %q", quote))
+	}
+	i.bound = amount.StringFromInt64(applySlippage(stroops, i.MaxSlippageBps, i.Mode == StrictReceive))
+	return i.bound, nil
+}
+
+// checkRoute fails if route no longer satisfies bound.
+func (i *SwapIntent) checkRoute(route *rpc.PaymentPath, bound string) error {
+	if i.Mode == StrictSend {
+		less, err := amountLess(route.DestinationAmount, bound)
+		if err != nil {
+			return err
+		}
+		if less {
+			return errors.WrapValidationError(fmt.Sprintf("market moved: best route delivers %s %s, below the minimum of %s", route.DestinationAmount, assetString(i.DestAsset), bound))
+		}
+		return nil
+	}
+	more, err := amountLess(bound, route.SourceAmount)
+	if err != nil {
+		return err
+	}
+	if more {
+		return errors.WrapValidationError(fmt.Sprintf("market moved: best route costs %s %s, above the maximum of %s", route.SourceAmount, assetString(i.SendAsset), bound))
+	}
+	return nil
+}
+
+// applySlippage moves stroops by bps basis points, down for a minimum and
+// up for a maximum, rounding against the trader.
+func applySlippage(stroops int64, bps int, up bool) int64 {
+	factor := int64(10000 - bps)
+	if up {
+		factor = int64(10000 + bps)
+	}
+	v := new(big.Int).Mul(big.NewInt(stroops), big.NewInt(factor))
+	if up {
+		v.Add(v, big.NewInt(9999))
+	}
+	v.Quo(v, big.NewInt(10000))
+	if !v.IsInt64() {
+		return math.MaxInt64
+	}
+	return v.Int64()
+}
+
+// amountLess reports whether a < b for two decimal amounts.
+func amountLess(a, b string) (bool, error) {
+	x, err := amount.ParseInt64(a)
+	if err != nil {
+		return false, errors.WrapValidationError(fmt.Sprintf("invalid amount %q", a))
+	}
+	y, err := amount.ParseInt64(b)
+	if err != nil {
+		return false, errors.WrapValidationError(fmt.Sprintf("invalid amount %q", b))
+	}
+	return x < y, nil
+}
+
+// findRoute returns the best quoted route between the two assets.
+func (i *SwapIntent) findRoute(ctx context.Context, client *rpc.Client) (*rpc.PaymentPath, error) {
+	var (
+		paths []rpc.PaymentPath
+		err   error
+	)
+	if i.Mode == StrictSend {
+		paths, err = client.FindStrictSendPaths(ctx, rpc.StrictSendPathRequest{
+			SourceAsset:       i.SendAsset,
+			SourceAmount:      i.Amount,
+			DestinationAssets: []txnbuild.Asset{i.DestAsset},
+		})
+	} else {
+		paths, err = client.FindStrictReceivePaths(ctx, rpc.StrictReceivePathRequest{
+			DestinationAsset:  i.DestAsset,
+			DestinationAmount: i.Amount,
+			SourceAssets:      []txnbuild.Asset{i.SendAsset},
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Paths are sorted best first; only the asset pair needs checking.
+	for k := range paths {
+		if sameAsset(paths[k].SourceAsset, i.SendAsset) && sameAsset(paths[k].DestinationAsset, i.DestAsset) {
+			return &paths[k], nil
+		}
+	}
+	return nil, errors.WrapValidationError(fmt.Sprintf("no path from %s to %s", assetString(i.SendAsset), assetString(i.DestAsset)))
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withPaths serves paths as the answer to every path finding request.
+func withPaths(t *testing.T, client *rpc.Client, paths ...hProtocol.Path) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var page hProtocol.PathsPage
+		page.Embedded.Records = paths
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+	t.Cleanup(server.Close)
+	client.HorizonURL = server.URL
+	client.AltURLs = []string{server.URL}
+}
+
+func testPath(sendAmount, destAmount string) hProtocol.Path {
+	return hProtocol.Path{
+		SourceAssetType:        "native",
+		SourceAmount:           sendAmount,
+		DestinationAssetType:   "credit_alphanum4",
+		DestinationAssetCode:   "USDC",
+		DestinationAssetIssuer: testIssuer,
+		DestinationAmount:      destAmount,
+		Path:                   []hProtocol.Asset{{Type: "credit_alphanum4", Code: "EURC", Issuer: testIssuer}},
+	}
+}
+
+func TestSwapIntent_StrictSend(t *testing.T) {
+	client := newTestClient(t, newTestHorizon(testAccount(testSource, 1)))
+	withPaths(t, client, testPath("10", "2.5"), testPath("10", "2.7"))
+
+	in := &SwapIntent{From: testSource, Mode: StrictSend, SendAsset: txnbuild.NativeAsset{}, DestAsset: testUSDC(), Amount: "10"}
+	p, err := Resolve(context.Background(), client, in, WithBaseFee(100))
+	require.NoError(t, err)
+
+	require.NotNil(t, in.Route)
+	assert.Equal(t, "2.7", in.Route.DestinationAmount, "best quote wins")
+	op, ok := p.Tx.Operations()[0].(*txnbuild.PathPaymentStrictSend)
+	require.True(t, ok, "got %T", p.Tx.Operations()[0])
+	assert.Equal(t, "2.7", op.DestMin)
+	assert.Equal(t, testSource, op.Destination)
+	require.Len(t, op.Path, 1)
+	assert.Equal(t, "EURC", op.Path[0].GetCode())
+}
+
+func TestSwapIntent_StrictReceiveWithLimit(t *testing.T) {
+	client := newTestClient(t, newTestHorizon())
+	withPaths(t, client, testPath("9.5", "2"))
+
+	in := &SwapIntent{From: testSource, To: testDestination, Mode: StrictReceive, SendAsset: txnbuild.NativeAsset{}, DestAsset: testUSDC(), Amount: "2", Limit: "10"}
+	ops, err := in.Operations(context.Background(), client)
+	require.NoError(t, err)
+	op, ok := ops[0].(*txnbuild.PathPaymentStrictReceive)
+	require.True(t, ok, "got %T", ops[0])
+	assert.Equal(t, "10", op.SendMax)
+	assert.Equal(t, "2", op.DestAmount)
+	assert.Equal(t, testDestination, op.Destination)
+}
+
+func TestSwapIntent_NoRoute(t *testing.T) {
+	client := newTestClient(t, newTestHorizon())
+	withPaths(t, client)
+
+	in := &SwapIntent{From: testSource, Mode: StrictSend, SendAsset: txnbuild.NativeAsset{}, DestAsset: testUSDC(), Amount: "10"}
+	_, err := in.Operations(context.Background(), client)
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+}
+
+func TestSwapIntent_Slippage(t *testing.T) {
+	client := newTestClient(t, newTestHorizon())
+	ctx := context.Background()
+	withPaths(t, client, testPath("10", "2.5"))
+
+	in := &SwapIntent{From: testSource, Mode: StrictSend, SendAsset: txnbuild.NativeAsset{}, DestAsset: testUSDC(), Amount: "10", MaxSlippageBps: 100}
+	ops, err := in.Operations(ctx, client)
+	require.NoError(t, err)
+	assert.Equal(t, "2.4750000", ops[0].(*txnbuild.PathPaymentStrictSend).DestMin)
+
+	// A stale quote is refreshed, but the bound from the first quote holds.
+	in.QuotedAt = time.Now().Add(-time.Minute)
+	withPaths(t, client, testPath("10", "2.48"))
+	ops, err = in.Operations(ctx, client)
+	require.NoError(t, err)
+	assert.Equal(t, "2.48", in.Route.DestinationAmount)
+	assert.Equal(t, "2.4750000", ops[0].(*txnbuild.PathPaymentStrictSend).DestMin)
+
+	in.QuotedAt = time.Now().Add(-time.Minute)
+	withPaths(t, client, testPath("10", "2.4"))
+	_, err = in.Operations(ctx, client)
+	assert.ErrorIs(t, err, errs.ErrValidationFailed, "market moved past the bound")
+}
+
+func TestSwapIntent_FreshQuoteIsReused(t *testing.T) {
+	client := newTestClient(t, newTestHorizon())
+	withPaths(t, client)
+
+	route := &rpc.PaymentPath{SourceAsset: txnbuild.NativeAsset{}, SourceAmount: "9.5", DestinationAsset: testUSDC(), DestinationAmount: "2"}
+	in := &SwapIntent{From: testSource, Mode: StrictReceive, SendAsset: txnbuild.NativeAsset{}, DestAsset: testUSDC(), Amount: "2", MaxSlippageBps: 50, Route: route, QuotedAt: time.Now()}
+	ops, err := in.Operations(context.Background(), client)
+	require.NoError(t, err, "no path finding request is made")
+	assert.Equal(t, "9.5475000", ops[0].(*txnbuild.PathPaymentStrictReceive).SendMax)
+}
+
+func TestApplySlippage(t *testing.T) {
+	assert.Equal(t, int64(99), applySlippage(100, 100, false))
+	assert.Equal(t, int64(98), applySlippage(99, 100, false), "minimum rounds down")
+	assert.Equal(t, int64(100), applySlippage(99, 100, true), "maximum rounds up")
+	assert.Equal(t, int64(math.MaxInt64), applySlippage(math.MaxInt64, 1, true))
+}
+
+func TestSwapIntent_Validate(t *testing.T) {
+	for _, in := range []*SwapIntent{
+		{From: testSource, Mode: "market", SendAsset: txnbuild.NativeAsset{}, DestAsset: testUSDC(), Amount: "1"},
+		{From: testSource, Mode: StrictSend, SendAsset: txnbuild.NativeAsset{}, DestAsset: txnbuild.NativeAsset{}, Amount: "1"},
+		{From: testSource, Mode: StrictSend, SendAsset: txnbuild.NativeAsset{}, DestAsset: testUSDC(), Amount: "1", Limit: "-1"},
+		{From: testSource, Mode: StrictSend, SendAsset: txnbuild.NativeAsset{}, DestAsset: testUSDC(), Amount: "1", MaxSlippageBps: 10000},
+	} {
+		assert.ErrorIs(t, in.Validate(), errs.ErrValidationFailed, "%+v", in)
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/amount"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+)
+
+// TrustlineIntent adds, changes or removes Account's trustline to Asset.
+type TrustlineIntent struct {
+	Account string
+	Asset   txnbuild.Asset
+	// Limit is the most Account may hold; empty means the maximum.
+	// Ignored when Remove is set.
+	Limit string
+	// Remove deletes the trustline, which only succeeds once its balance
+	// and liabilities are zero.
+	Remove bool
+	// AllowClawback accepts trusting an asset whose issuer has clawback
+	// enabled, allowing the issuer to take the balance back at any time.
+	AllowClawback bool
+}
+
+func (i *TrustlineIntent) Source() string { return i.Account }
+
+func (i *TrustlineIntent) Validate() error {
+	if err := validateAccount("trusting", i.Account); err != nil {
+		return err
+	}
+	if i.Asset == nil || i.Asset.IsNative() {
+		return errors.WrapValidationError("trustline asset must be a non-native asset")
+	}
+	if underlying(i.Account) == i.Asset.GetIssuer() {
+		return errors.WrapValidationError("an issuer cannot trust its own asset")
+	}
+	if !i.Remove && i.Limit != "" {
+		return validateAmount("trustline limit", i.Limit)
+	}
+	return nil
+}
+
+// Operations compares the request with the current trustline and, for new
+// trustlines, with the issuer's flags, so op_invalid_limit, op_no_issuer
+// and removals of funded trustlines surface here rather than on submission.
+func (i *TrustlineIntent) Operations(ctx context.Context, client *rpc.Client) ([]txnbuild.Operation, error) {
+	account, err := client.AccountDetails(ctx, i.Account)
+	if err != nil {
+		return nil, err
+	}
+	line, err := i.Asset.ToChangeTrustAsset()
+	if err != nil {
+		return nil, errors.WrapValidationError(fmt.Sprintf("invalid trustline asset: %v", err))
+	}
+	current := trustline(account, i.Asset)
+	name := assetString(i.Asset)
+
+	if i.Remove {
+		if current == nil {
+			return nil, errors.WrapValidationError(fmt.Sprintf("%s has no trustline for %s to remove", i.Account, name))
+		}
+		if nonzero(current.Balance) {
+			return nil, errors.WrapValidationError(fmt.Sprintf("cannot remove trustline for %s holding a balance of %s", name, current.Balance))
+		}
+		if nonzero(current.BuyingLiabilities) || nonzero(current.SellingLiabilities) {
+			return nil, errors.WrapValidationError(fmt.Sprintf("cannot remove trustline for %s with open offers", name))
+		}
+		return []txnbuild.Operation{&txnbuild.ChangeTrust{Line: line, Limit: "0"}}, nil
+	}
+
+	limit := i.Limit
+	if limit == "" {
+		limit = txnbuild.MaxTrustlineLimit
+	}
+	if current != nil {
+		if sameAmount(current.Limit, limit) {
+			return nil, errors.WrapValidationError(fmt.Sprintf("trustline for %s already has limit %s", name, current.Limit))
+		}
+		// The limit must leave room for what is held and already bought.
+		held, _ := amount.ParseInt64(current.Balance)
+		buying, _ := amount.ParseInt64(current.BuyingLiabilities)
+		newLimit, _ := amount.ParseInt64(limit)
+		if newLimit < held+buying {
+			return nil, errors.WrapValidationError(fmt.Sprintf(
+				"limit %s for %s is below the balance and buying liabilities of %s", limit, name, amount.StringFromInt64(held+buying)))
+		}
+		if !current.Authorized {
+			logger.Logger.Warn("Trustline is not authorized by the issuer", "account", i.Account, "asset", name)
+		}
+		return []txnbuild.Operation{&txnbuild.ChangeTrust{Line: line, Limit: limit}}, nil
+	}
+
+	issuer, err := client.AccountDetails(ctx, i.Asset.GetIssuer())
+	if errors.Is(err, errors.ErrAccountNotFound) {
+		return nil, errors.WrapValidationError(fmt.Sprintf("issuer of %s does not exist", name))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if issuer.Flags.AuthClawbackEnabled && !i.AllowClawback {
+		return nil, errors.WrapValidationError(fmt.Sprintf("the issuer of %s can claw balances back; set AllowClawback to trust it anyway", name))
+	}
+	if issuer.Flags.AuthRequired {
+		logger.Logger.Info("Issuer must authorize the new trustline before it can hold a balance", "account", i.Account, "asset", name)
+	}
+	return []txnbuild.Operation{&txnbuild.ChangeTrust{Line: line, Limit: limit}}, nil
+}
+
+// trustline returns acc's balance line for asset, or nil.
+func trustline(acc *rpc.AccountDetails, asset txnbuild.Asset) *rpc.AccountBalance {
+	for k := range acc.Balances {
+		if b := &acc.Balances[k]; b.Asset != nil && sameAsset(b.Asset, asset) {
+			return b
+		}
+	}
+	return nil
+}
+
+func nonzero(v string) bool {
+	n, _ := amount.ParseInt64(v)
+	return n != 0
+}
+
+func sameAmount(a, b string) bool {
+	x, errX := amount.ParseInt64(a)
+	y, errY := amount.ParseInt64(b)
+	return errX == nil && errY == nil && x == y
+}
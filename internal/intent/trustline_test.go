@@ -0,0 +1,85 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package intent
+
+import (
+	"context"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrustlineIntent_Add(t *testing.T) {
+	issuer := testAccount(testIssuer, 1)
+	h := newTestHorizon(testAccount(testSource, 1), issuer)
+	client := newTestClient(t, h)
+	ctx := context.Background()
+
+	ops, err := (&TrustlineIntent{Account: testSource, Asset: testUSDC()}).Operations(ctx, client)
+	require.NoError(t, err)
+	require.Len(t, ops, 1)
+	assert.Equal(t, txnbuild.MaxTrustlineLimit, ops[0].(*txnbuild.ChangeTrust).Limit)
+
+	issuer.Flags.AuthClawbackEnabled = true
+	h.accounts[testIssuer] = issuer
+	_, err = (&TrustlineIntent{Account: testSource, Asset: testUSDC()}).Operations(ctx, client)
+	assert.ErrorIs(t, err, errs.ErrValidationFailed, "clawback must be accepted explicitly")
+	_, err = (&TrustlineIntent{Account: testSource, Asset: testUSDC(), AllowClawback: true}).Operations(ctx, client)
+	assert.NoError(t, err)
+
+	delete(h.accounts, testIssuer)
+	_, err = (&TrustlineIntent{Account: testSource, Asset: testUSDC()}).Operations(ctx, client)
+	assert.ErrorIs(t, err, errs.ErrValidationFailed, "missing issuer")
+}
+
+func TestTrustlineIntent_ChangeLimit(t *testing.T) {
+	acc := withBalance(testAccount(testSource, 1), testUSDC(), "40.0000000")
+	acc.Balances[0].Limit = "100.0000000"
+	acc.Balances[0].BuyingLiabilities = "10.0000000"
+	client := newTestClient(t, newTestHorizon(acc))
+	ctx := context.Background()
+
+	ops, err := (&TrustlineIntent{Account: testSource, Asset: testUSDC(), Limit: "50"}).Operations(ctx, client)
+	require.NoError(t, err)
+	assert.Equal(t, "50", ops[0].(*txnbuild.ChangeTrust).Limit)
+
+	for name, in := range map[string]*TrustlineIntent{
+		"below holdings": {Account: testSource, Asset: testUSDC(), Limit: "49.9999999"},
+		"unchanged":      {Account: testSource, Asset: testUSDC(), Limit: "100"},
+	} {
+		_, err := in.Operations(ctx, client)
+		assert.ErrorIs(t, err, errs.ErrValidationFailed, name)
+	}
+}
+
+func TestTrustlineIntent_Remove(t *testing.T) {
+	funded := withBalance(testAccount(testSource, 1), testUSDC(), "1.0000000")
+	empty := withBalance(testAccount(testDestination, 1), testUSDC(), "0.0000000")
+	client := newTestClient(t, newTestHorizon(funded, empty))
+	ctx := context.Background()
+
+	ops, err := (&TrustlineIntent{Account: testDestination, Asset: testUSDC(), Remove: true}).Operations(ctx, client)
+	require.NoError(t, err)
+	assert.Equal(t, "0", ops[0].(*txnbuild.ChangeTrust).Limit)
+
+	_, err = (&TrustlineIntent{Account: testSource, Asset: testUSDC(), Remove: true}).Operations(ctx, client)
+	assert.ErrorIs(t, err, errs.ErrValidationFailed, "funded trustlines are not removed")
+
+	eurc := txnbuild.CreditAsset{Code: "EURC", Issuer: testIssuer}
+	_, err = (&TrustlineIntent{Account: testSource, Asset: eurc, Remove: true}).Operations(ctx, client)
+	assert.ErrorIs(t, err, errs.ErrValidationFailed, "nothing to remove")
+}
+
+func TestTrustlineIntent_Validate(t *testing.T) {
+	for name, in := range map[string]*TrustlineIntent{
+		"native": {Account: testSource, Asset: txnbuild.NativeAsset{}},
+		"issuer": {Account: testIssuer, Asset: testUSDC()},
+		"limit":  {Account: testSource, Asset: testUSDC(), Limit: "-1"},
+	} {
+		assert.ErrorIs(t, in.Validate(), errs.ErrValidationFailed, name)
+	}
+}
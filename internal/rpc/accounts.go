@@ -0,0 +1,201 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+)
+
+// AccountThresholds are the signature weights required for low, medium and
+// high threshold operations.
+type AccountThresholds struct {
+	Low    uint8
+	Medium uint8
+	High   uint8
+}
+
+// AccountFlags are the issuer authorization flags set on an account.
+type AccountFlags struct {
+	AuthRequired        bool
+	AuthRevocable       bool
+	AuthImmutable       bool
+	AuthClawbackEnabled bool
+}
+
+// AccountBalance is a single balance line. Asset is nil for liquidity pool
+// shares, which are identified by LiquidityPoolID instead.
+type AccountBalance struct {
+	Asset              txnbuild.Asset
+	LiquidityPoolID    string
+	Balance            string
+	Limit              string
+	BuyingLiabilities  string
+	SellingLiabilities string
+	Sponsor            string
+	Authorized         bool
+	ClawbackEnabled    bool
+}
+
+// AccountSigner is a signer and the weight it contributes.
+type AccountSigner struct {
+	Key     string
+	Type    string
+	Weight  int32
+	Sponsor string
+}
+
+// AccountDetails gathers everything Horizon reports about an account in one
+// typed value.
+type AccountDetails struct {
+	ID            string
+	Sequence      int64
+	SubentryCount int32
+	HomeDomain    string
+	Thresholds    AccountThresholds
+	Flags         AccountFlags
+	Balances      []AccountBalance
+	Signers       []AccountSigner
+	// Data holds the account's data entries, base64-decoded.
+	Data               map[string][]byte
+	Sponsor            string
+	NumSponsoring      uint32
+	NumSponsored       uint32
+	LastModifiedLedger uint32
+}
+
+// DataString returns the data entry name as a string when it is valid UTF-8.
+func (a *AccountDetails) DataString(name string) (string, bool) {
+	v, ok := a.Data[name]
+	if !ok || !utf8.Valid(v) {
+		return "", false
+	}
+	return string(v), true
+}
+
+// DataKeys returns the data entry names in sorted order.
+func (a *AccountDetails) DataKeys() []string {
+	keys := make([]string, 0, len(a.Data))
+	for k := range a.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// NativeBalance returns the account's XLM balance.
+func (a *AccountDetails) NativeBalance() string {
+	for _, b := range a.Balances {
+		if b.Asset != nil && b.Asset.IsNative() {
+			return b.Balance
+		}
+	}
+	return "0"
+}
+
+// SignerWeight returns the weight of key, or 0 if it is not a signer.
+func (a *AccountDetails) SignerWeight(key string) int32 {
+	for _, s := range a.Signers {
+		if s.Key == key {
+			return s.Weight
+		}
+	}
+	return 0
+}
+
+// AccountDetails fetches the account id (a G... or M... address) and decodes its balances, signers,
+// thresholds, flags, sponsorship counters and data entries.
+func (c *Client) AccountDetails(ctx context.Context, id string) (*AccountDetails, error) {
+	if id == "" {
+		return nil, errors.WrapValidationError("account id is required")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.logContext(ctx, LogSubsystemRPC).Debug("Fetching account details", "account", id)
+
+	start := time.Now()
+	acc, err := c.Horizon.AccountDetail(horizonclient.AccountRequest{AccountID: underlyingAccount(id)})
+	if err != nil {
+		if hErr, ok := err.(*horizonclient.Error); ok && hErr.Problem.Status == http.StatusNotFound {
+			return nil, errors.WrapAccountNotFound(id)
+		}
+		c.logContext(ctx, LogSubsystemRPC).Error("Failed to fetch account details", "account", id, "error", err)
+		return nil, c.requestError(ctx, "account_detail", c.HorizonURL, 0, start, errors.WrapRPCConnectionFailed(err))
+	}
+
+	return decodeAccountDetails(acc)
+}
+
+func decodeAccountDetails(acc hProtocol.Account) (*AccountDetails, error) {
+	details := &AccountDetails{
+		ID:            acc.AccountID,
+		Sequence:      acc.Sequence,
+		SubentryCount: acc.SubentryCount,
+		HomeDomain:    acc.HomeDomain,
+		Thresholds: AccountThresholds{
+			Low:    acc.Thresholds.LowThreshold,
+			Medium: acc.Thresholds.MedThreshold,
+			High:   acc.Thresholds.HighThreshold,
+		},
+		Flags: AccountFlags{
+			AuthRequired:        acc.Flags.AuthRequired,
+			AuthRevocable:       acc.Flags.AuthRevocable,
+			AuthImmutable:       acc.Flags.AuthImmutable,
+			AuthClawbackEnabled: acc.Flags.AuthClawbackEnabled,
+		},
+		Data:               make(map[string][]byte, len(acc.Data)),
+		Sponsor:            acc.Sponsor,
+		NumSponsoring:      acc.NumSponsoring,
+		NumSponsored:       acc.NumSponsored,
+		LastModifiedLedger: acc.LastModifiedLedger,
+	}
+
+	for _, b := range acc.Balances {
+		balance := AccountBalance{
+			LiquidityPoolID:    b.LiquidityPoolId,
+			Balance:            b.Balance,
+			Limit:              b.Limit,
+			BuyingLiabilities:  b.BuyingLiabilities,
+			SellingLiabilities: b.SellingLiabilities,
+			Sponsor:            b.Sponsor,
+			Authorized:         b.IsAuthorized == nil || *b.IsAuthorized,
+			ClawbackEnabled:    b.IsClawbackEnabled != nil && *b.IsClawbackEnabled,
+		}
+		if b.LiquidityPoolId == "" {
+			balance.Asset = assetFromHorizon(b.Type, b.Code, b.Issuer)
+		}
+		details.Balances = append(details.Balances, balance)
+	}
+
+	for _, s := range acc.Signers {
+		details.Signers = append(details.Signers, AccountSigner{
+			Key:     s.Key,
+			Type:    s.Type,
+			Weight:  s.Weight,
+			Sponsor: s.Sponsor,
+		})
+	}
+
+	for name, encoded := range acc.Data {
+		raw, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, errors.WrapUnmarshalFailed(err, fmt.Sprintf("data entry %q", name))
+		}
+		details.Data[name] = raw
+	}
+
+	return details, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/base"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type accountHorizon struct {
+	horizonclient.ClientInterface
+	account hProtocol.Account
+	err     error
+}
+
+func (m *accountHorizon) AccountDetail(req horizonclient.AccountRequest) (hProtocol.Account, error) {
+	if m.err != nil {
+		return hProtocol.Account{}, m.err
+	}
+	return m.account, nil
+}
+
+func TestAccountDetails(t *testing.T) {
+	unauthorized := false
+	client := &Client{Horizon: &accountHorizon{account: hProtocol.Account{
+		AccountID:     testClaimant,
+		Sequence:      42,
+		Thresholds:    hProtocol.AccountThresholds{LowThreshold: 1, MedThreshold: 2, HighThreshold: 3},
+		Flags:         hProtocol.AccountFlags{AuthRequired: true},
+		NumSponsoring: 2,
+		Sponsor:       testIssuer,
+		Balances: []hProtocol.Balance{
+			{Balance: "100.0000000", Asset: base.Asset{Type: "native"}},
+			{Balance: "5.0000000", Sponsor: testIssuer, IsAuthorized: &unauthorized, Asset: base.Asset{Type: "credit_alphanum4", Code: "USDC", Issuer: testIssuer}},
+			{Balance: "1.0000000", LiquidityPoolId: "pool1", Asset: base.Asset{Type: "liquidity_pool_shares"}},
+		},
+		Signers: []hProtocol.Signer{{Key: testClaimant, Type: "ed25519_public_key", Weight: 1}},
+		Data: map[string]string{
+			"config": "aGVsbG8=",
+			"blob":   "/w==",
+		},
+	}}}
+
+	details, err := client.AccountDetails(context.Background(), testClaimant)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(42), details.Sequence)
+	assert.Equal(t, uint8(3), details.Thresholds.High)
+	assert.True(t, details.Flags.AuthRequired)
+	assert.Equal(t, testIssuer, details.Sponsor)
+	assert.Equal(t, "100.0000000", details.NativeBalance())
+	assert.Equal(t, int32(1), details.SignerWeight(testClaimant))
+
+	require.Len(t, details.Balances, 3)
+	assert.Equal(t, "USDC", details.Balances[1].Asset.GetCode())
+	assert.False(t, details.Balances[1].Authorized)
+	assert.Equal(t, testIssuer, details.Balances[1].Sponsor)
+	assert.Nil(t, details.Balances[2].Asset)
+	assert.Equal(t, "pool1", details.Balances[2].LiquidityPoolID)
+
+	assert.Equal(t, []string{"blob", "config"}, details.DataKeys())
+	s, ok := details.DataString("config")
+	assert.True(t, ok)
+	assert.Equal(t, "hello", s)
+	_, ok = details.DataString("blob")
+	assert.False(t, ok)
+	assert.Equal(t, []byte{0xff}, details.Data["blob"])
+}
+
+func TestAccountDetails_NotFound(t *testing.T) {
+	client := &Client{Horizon: &accountHorizon{err: &horizonclient.Error{Problem: problem.P{Status: 404}}}}
+
+	_, err := client.AccountDetails(context.Background(), testClaimant)
+	assert.ErrorIs(t, err, errs.ErrAccountNotFound)
+
+	_, err = client.AccountDetails(context.Background(), "")
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+}
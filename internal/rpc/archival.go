@@ -0,0 +1,249 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+const (
+	// DefaultArchivalCheckInterval is how often the watcher polls entry TTLs.
+	DefaultArchivalCheckInterval = 5 * time.Minute
+	// DefaultArchivalThreshold is roughly one day of ledgers at ~5s per ledger.
+	DefaultArchivalThreshold uint32 = 17280
+)
+
+// LedgerEntriesWithTTL is the result of a getLedgerEntries call that keeps the
+// liveness metadata the plain GetLedgerEntries discards.
+type LedgerEntriesWithTTL struct {
+	Entries      []LedgerEntryResult
+	LatestLedger uint32
+}
+
+// GetLedgerEntriesWithTTL fetches ledger entries directly from Soroban RPC,
+// bypassing the cache, and returns each entry's live-until ledger alongside the
+// latest ledger observed by the RPC. Keys with no live entry are simply absent.
+func (c *Client) GetLedgerEntriesWithTTL(ctx context.Context, keys []string) (*LedgerEntriesWithTTL, error) {
+	if len(keys) == 0 {
+		return &LedgerEntriesWithTTL{}, nil
+	}
+
+	var result struct {
+		Entries      []LedgerEntryResult `json:"entries"`
+		LatestLedger uint32              `json:"latestLedger"`
+	}
+	if err := c.callSoroban(ctx, "getLedgerEntries", []interface{}{keys}, &result); err != nil {
+		return nil, err
+	}
+	c.observeLedger(result.LatestLedger)
+	return &LedgerEntriesWithTTL{Entries: result.Entries, LatestLedger: result.LatestLedger}, nil
+}
+
+// ArchivalStatus describes how close a single ledger entry is to being archived.
+type ArchivalStatus struct {
+	Key             string
+	LiveUntilLedger uint32
+	LatestLedger    uint32
+	// LedgersUntilArchival is negative once the entry's TTL has lapsed.
+	LedgersUntilArchival int64
+	// Missing is set when the RPC returned no live entry for the key, which
+	// means it was never created or has already been archived.
+	Missing bool
+}
+
+// Expiring reports whether the entry will be archived within threshold ledgers.
+func (s ArchivalStatus) Expiring(threshold uint32) bool {
+	return s.Missing || s.LedgersUntilArchival <= int64(threshold)
+}
+
+// ArchivalWatcherConfig configures an ArchivalWatcher.
+type ArchivalWatcherConfig struct {
+	// Keys are base64-encoded XDR LedgerKeys to monitor.
+	Keys []string
+	// ContractID, if set, adds the contract's instance and code entries.
+	ContractID string
+	// Interval between checks. Defaults to DefaultArchivalCheckInterval.
+	Interval time.Duration
+	// Threshold in ledgers below which an entry is reported. Nil means
+	// DefaultArchivalThreshold; zero reports only entries that are missing
+	// or have lapsed.
+	Threshold *uint32
+	// OnExpiring is called for every entry within the threshold on each check.
+	OnExpiring func(ArchivalStatus)
+}
+
+// ArchivalWatcher periodically checks the TTL of a set of ledger entries and
+// reports those approaching archival via a callback and a channel.
+type ArchivalWatcher struct {
+	client    *Client
+	config    ArchivalWatcherConfig
+	threshold uint32
+
+	mu          sync.Mutex
+	keys        []string
+	instanceKey string
+	codeKey     string
+
+	alerts chan ArchivalStatus
+}
+
+// NewArchivalWatcher creates a watcher for the configured keys and/or contract.
+func NewArchivalWatcher(client *Client, cfg ArchivalWatcherConfig) (*ArchivalWatcher, error) {
+	if client == nil {
+		return nil, errors.WrapValidationError("archival watcher requires a client")
+	}
+	if len(cfg.Keys) == 0 && cfg.ContractID == "" {
+		return nil, errors.WrapValidationError("archival watcher requires ledger keys or a contract ID")
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultArchivalCheckInterval
+	}
+	w := &ArchivalWatcher{
+		client:    client,
+		config:    cfg,
+		threshold: DefaultArchivalThreshold,
+		keys:      append([]string(nil), cfg.Keys...),
+		alerts:    make(chan ArchivalStatus, 64),
+	}
+	if cfg.Threshold != nil {
+		w.threshold = *cfg.Threshold
+	}
+
+	if cfg.ContractID != "" {
+		cid, err := decodeContractID(cfg.ContractID)
+		if err != nil {
+			return nil, errors.WrapValidationError(fmt.Sprintf("invalid contract ID: %v", err))
+		}
+		instanceKey, err := LedgerKeyForContractInstance(cid)
+		if err != nil {
+			return nil, err
+		}
+		w.instanceKey, err = EncodeLedgerKey(instanceKey)
+		if err != nil {
+			return nil, err
+		}
+		w.keys = append(w.keys, w.instanceKey)
+	}
+
+	return w, nil
+}
+
+// Alerts returns the channel on which expiring entries are delivered. The
+// channel is closed when Run returns. Alerts are dropped if the channel is full.
+func (w *ArchivalWatcher) Alerts() <-chan ArchivalStatus {
+	return w.alerts
+}
+
+// Check performs a single TTL check and returns the status of every watched key.
+func (w *ArchivalWatcher) Check(ctx context.Context) ([]ArchivalStatus, error) {
+	w.mu.Lock()
+	keys := append([]string(nil), w.keys...)
+	w.mu.Unlock()
+
+	resp, err := w.client.GetLedgerEntriesWithTTL(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[string]LedgerEntryResult, len(resp.Entries))
+	for _, e := range resp.Entries {
+		byKey[e.Key] = e
+	}
+
+	w.resolveCodeKey(byKey)
+
+	statuses := make([]ArchivalStatus, 0, len(keys))
+	for _, key := range keys {
+		status := ArchivalStatus{Key: key, LatestLedger: resp.LatestLedger}
+		entry, ok := byKey[key]
+		if !ok {
+			status.Missing = true
+		} else {
+			status.LiveUntilLedger = uint32(entry.LiveUntilLedger)
+			status.LedgersUntilArchival = int64(entry.LiveUntilLedger) - int64(resp.LatestLedger)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// resolveCodeKey adds the contract's WASM code entry once the instance entry
+// has been observed, since the code hash is only known from the instance.
+// The lock is held throughout so concurrent checks add the key only once.
+func (w *ArchivalWatcher) resolveCodeKey(byKey map[string]LedgerEntryResult) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.instanceKey == "" || w.codeKey != "" {
+		return
+	}
+	instance, ok := byKey[w.instanceKey]
+	if !ok {
+		return
+	}
+	codeHash, err := ContractCodeHashFromInstanceEntry(instance.Xdr)
+	if err != nil {
+		w.client.log(LogSubsystemRPC).Debug("Contract instance has no WASM code entry to watch", "error", err)
+		return
+	}
+	codeKey, err := EncodeLedgerKey(xdr.LedgerKey{
+		Type:         xdr.LedgerEntryTypeContractCode,
+		ContractCode: &xdr.LedgerKeyContractCode{Hash: codeHash},
+	})
+	if err != nil {
+		return
+	}
+	w.codeKey = codeKey
+	w.keys = append(w.keys, codeKey)
+}
+
+// Run checks the watched entries every Interval until ctx is cancelled,
+// reporting expiring entries through OnExpiring and the Alerts channel.
+func (w *ArchivalWatcher) Run(ctx context.Context) error {
+	defer close(w.alerts)
+
+	ticker := time.NewTicker(w.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		w.checkAndNotify(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *ArchivalWatcher) checkAndNotify(ctx context.Context) {
+	statuses, err := w.Check(ctx)
+	if err != nil {
+		w.client.log(LogSubsystemRPC).Warn("Archival check failed", "error", err)
+		return
+	}
+	for _, status := range statuses {
+		if !status.Expiring(w.threshold) {
+			continue
+		}
+		w.client.log(LogSubsystemRPC).Info("Ledger entry approaching archival",
+			"key", status.Key,
+			"ledgers_until_archival", status.LedgersUntilArchival,
+			"missing", status.Missing,
+		)
+		if w.config.OnExpiring != nil {
+			w.config.OnExpiring(status)
+		}
+		select {
+		case w.alerts <- status:
+		default:
+			w.client.log(LogSubsystemRPC).Warn("Archival alert channel full, dropping alert", "key", status.Key)
+		}
+	}
+}
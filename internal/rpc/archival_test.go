@@ -0,0 +1,200 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newArchivalTestServer(t *testing.T, latest uint32, liveUntil map[string]int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string     `json:"method"`
+			Params [][]string `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "getLedgerEntries", req.Method)
+
+		entries := []LedgerEntryResult{}
+		for _, key := range req.Params[0] {
+			if ttl, ok := liveUntil[key]; ok {
+				entries = append(entries, LedgerEntryResult{Key: key, Xdr: "AAAA", LiveUntilLedger: ttl})
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"result": map[string]interface{}{
+				"entries":      entries,
+				"latestLedger": latest,
+			},
+		})
+	}))
+}
+
+func newArchivalTestClient(t *testing.T, url string) *Client {
+	t.Helper()
+	client, err := NewClient(WithNetwork(Testnet), WithSorobanURL(url), WithAltURLs([]string{url}))
+	require.NoError(t, err)
+	client.SorobanURL = url
+	return client
+}
+
+func TestNewArchivalWatcher_Validation(t *testing.T) {
+	_, err := NewArchivalWatcher(nil, ArchivalWatcherConfig{Keys: []string{"k"}})
+	assert.Error(t, err)
+
+	client := newArchivalTestClient(t, "http://localhost")
+	_, err = NewArchivalWatcher(client, ArchivalWatcherConfig{})
+	assert.Error(t, err)
+
+	_, err = NewArchivalWatcher(client, ArchivalWatcherConfig{ContractID: "not-a-contract"})
+	assert.Error(t, err)
+
+	w, err := NewArchivalWatcher(client, ArchivalWatcherConfig{Keys: []string{"k"}})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultArchivalCheckInterval, w.config.Interval)
+	assert.Equal(t, DefaultArchivalThreshold, w.threshold)
+
+	zero := uint32(0)
+	w, err = NewArchivalWatcher(client, ArchivalWatcherConfig{Keys: []string{"k"}, Threshold: &zero})
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0), w.threshold)
+}
+
+func TestArchivalWatcher_Check(t *testing.T) {
+	server := newArchivalTestServer(t, 1000, map[string]int{
+		"fresh":    50000,
+		"expiring": 1100,
+		"lapsed":   900,
+	})
+	defer server.Close()
+
+	threshold := uint32(500)
+	w, err := NewArchivalWatcher(newArchivalTestClient(t, server.URL), ArchivalWatcherConfig{
+		Keys:      []string{"fresh", "expiring", "lapsed", "gone"},
+		Threshold: &threshold,
+	})
+	require.NoError(t, err)
+
+	statuses, err := w.Check(context.Background())
+	require.NoError(t, err)
+	require.Len(t, statuses, 4)
+
+	assert.Equal(t, int64(49000), statuses[0].LedgersUntilArchival)
+	assert.False(t, statuses[0].Expiring(500))
+
+	assert.Equal(t, uint32(1100), statuses[1].LiveUntilLedger)
+	assert.Equal(t, int64(100), statuses[1].LedgersUntilArchival)
+	assert.True(t, statuses[1].Expiring(500))
+
+	assert.Equal(t, int64(-100), statuses[2].LedgersUntilArchival)
+	assert.True(t, statuses[2].Expiring(500))
+
+	assert.True(t, statuses[3].Missing)
+	assert.Equal(t, uint32(1000), statuses[3].LatestLedger)
+
+	assert.False(t, statuses[1].Expiring(0))
+	assert.True(t, statuses[2].Expiring(0))
+	assert.True(t, statuses[3].Expiring(0))
+}
+
+func TestArchivalWatcher_ResolveCodeKeyOnce(t *testing.T) {
+	hexID := "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+	w, err := NewArchivalWatcher(newArchivalTestClient(t, "http://localhost"), ArchivalWatcherConfig{ContractID: hexID})
+	require.NoError(t, err)
+
+	cid, err := decodeContractID(hexID)
+	require.NoError(t, err)
+	instanceKey, err := LedgerKeyForContractInstance(cid)
+	require.NoError(t, err)
+	wasmHash := xdr.Hash{1, 2, 3}
+	entry := xdr.LedgerEntry{Data: xdr.LedgerEntryData{
+		Type: xdr.LedgerEntryTypeContractData,
+		ContractData: &xdr.ContractDataEntry{
+			Contract:   instanceKey.ContractData.Contract,
+			Key:        instanceKey.ContractData.Key,
+			Durability: xdr.ContractDataDurabilityPersistent,
+			Val: xdr.ScVal{
+				Type: xdr.ScValTypeScvContractInstance,
+				Instance: &xdr.ScContractInstance{Executable: xdr.ContractExecutable{
+					Type:     xdr.ContractExecutableTypeContractExecutableWasm,
+					WasmHash: &wasmHash,
+				}},
+			},
+		},
+	}}
+	entryXDR, err := xdr.MarshalBase64(entry)
+	require.NoError(t, err)
+	byKey := map[string]LedgerEntryResult{w.instanceKey: {Key: w.instanceKey, Xdr: entryXDR}}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.resolveCodeKey(byKey)
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, w.keys, 2)
+	assert.Equal(t, w.codeKey, w.keys[1])
+}
+
+func TestArchivalWatcher_RunReportsExpiring(t *testing.T) {
+	server := newArchivalTestServer(t, 1000, map[string]int{
+		"fresh":    50000,
+		"expiring": 1010,
+	})
+	defer server.Close()
+
+	var mu sync.Mutex
+	var called []string
+	threshold := uint32(100)
+	w, err := NewArchivalWatcher(newArchivalTestClient(t, server.URL), ArchivalWatcherConfig{
+		Keys:      []string{"fresh", "expiring"},
+		Interval:  time.Hour,
+		Threshold: &threshold,
+		OnExpiring: func(s ArchivalStatus) {
+			mu.Lock()
+			defer mu.Unlock()
+			called = append(called, s.Key)
+		},
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- w.Run(ctx) }()
+
+	select {
+	case alert := <-w.Alerts():
+		assert.Equal(t, "expiring", alert.Key)
+		assert.Equal(t, int64(10), alert.LedgersUntilArchival)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for archival alert")
+	}
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"expiring"}, called)
+
+	_, open := <-w.Alerts()
+	assert.False(t, open)
+}
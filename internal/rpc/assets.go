@@ -0,0 +1,151 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/amount"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+)
+
+// AssetStatsFilter narrows an asset statistics query. Either field may be
+// empty; with both empty every asset on the network is returned.
+type AssetStatsFilter struct {
+	Code   string
+	Issuer string
+}
+
+// AssetHolderCounts counts trustlines by authorization state.
+type AssetHolderCounts struct {
+	Authorized                      int32
+	AuthorizedToMaintainLiabilities int32
+	Unauthorized                    int32
+}
+
+// Total returns the number of trustlines in any state.
+func (h AssetHolderCounts) Total() int32 {
+	return h.Authorized + h.AuthorizedToMaintainLiabilities + h.Unauthorized
+}
+
+// AssetHolderAmounts sums trustline balances by authorization state.
+type AssetHolderAmounts struct {
+	Authorized                      string
+	AuthorizedToMaintainLiabilities string
+	Unauthorized                    string
+}
+
+// AssetStatistics describes how an issued asset is distributed across
+// accounts, claimable balances, liquidity pools and contracts.
+type AssetStatistics struct {
+	Asset                   txnbuild.Asset
+	ContractID              string
+	Accounts                AssetHolderCounts
+	Balances                AssetHolderAmounts
+	NumClaimableBalances    int32
+	ClaimableBalancesAmount string
+	NumLiquidityPools       int32
+	LiquidityPoolsAmount    string
+	NumContracts            int32
+	ContractsAmount         string
+	Flags                   AccountFlags
+}
+
+// CirculatingSupply returns the total amount held anywhere on the network,
+// in the asset's smallest unit. Unparseable amounts count as zero.
+func (s *AssetStatistics) CirculatingSupply() int64 {
+	var total int64
+	for _, v := range []string{
+		s.Balances.Authorized,
+		s.Balances.AuthorizedToMaintainLiabilities,
+		s.Balances.Unauthorized,
+		s.ClaimableBalancesAmount,
+		s.LiquidityPoolsAmount,
+		s.ContractsAmount,
+	} {
+		total += parseAmountOrZero(v)
+	}
+	return total
+}
+
+// CirculatingSupplyString returns CirculatingSupply as a decimal amount.
+func (s *AssetStatistics) CirculatingSupplyString() string {
+	return amount.StringFromInt64(s.CirculatingSupply())
+}
+
+// AssetStats returns statistics for every asset matching filter.
+func (c *Client) AssetStats(ctx context.Context, filter AssetStatsFilter, opts ...IteratorOption) ([]AssetStatistics, error) {
+	c.logContext(ctx, LogSubsystemRPC).Debug("Fetching asset stats", "code", filter.Code, "issuer", filter.Issuer)
+
+	records, err := c.Assets(ctx, horizonclient.AssetRequest{
+		ForAssetCode:   filter.Code,
+		ForAssetIssuer: filter.Issuer,
+	}, opts...).Collect()
+	if err != nil {
+		c.logContext(ctx, LogSubsystemRPC).Error("Failed to fetch asset stats", "error", err)
+		return nil, errors.WrapRPCConnectionFailed(err)
+	}
+
+	out := make([]AssetStatistics, 0, len(records))
+	for _, r := range records {
+		out = append(out, decodeAssetStat(r))
+	}
+
+	c.logContext(ctx, LogSubsystemRPC).Debug("Asset stats retrieved", "count", len(out))
+	return out, nil
+}
+
+// AssetStat returns statistics for a single issued asset.
+func (c *Client) AssetStat(ctx context.Context, asset txnbuild.Asset) (*AssetStatistics, error) {
+	if asset == nil || asset.IsNative() {
+		return nil, errors.WrapValidationError("asset statistics require an issued asset")
+	}
+
+	stats, err := c.AssetStats(ctx, AssetStatsFilter{Code: asset.GetCode(), Issuer: asset.GetIssuer()}, MaxRecords(1))
+	if err != nil {
+		return nil, err
+	}
+	if len(stats) == 0 {
+		return nil, errors.WrapValidationError("no statistics for asset " + asset.GetCode() + ":" + asset.GetIssuer())
+	}
+	return &stats[0], nil
+}
+
+// AssetHolders returns an iterator over the accounts holding a trustline to
+// asset, for issuers auditing how their asset is distributed.
+func (c *Client) AssetHolders(ctx context.Context, asset txnbuild.Asset, opts ...IteratorOption) *Iterator[hProtocol.Account] {
+	return c.Accounts(ctx, horizonclient.AccountsRequest{Asset: canonicalAssetList([]txnbuild.Asset{asset})}, opts...)
+}
+
+func decodeAssetStat(r hProtocol.AssetStat) AssetStatistics {
+	return AssetStatistics{
+		Asset:      assetFromHorizon(r.Type, r.Code, r.Issuer),
+		ContractID: r.ContractID,
+		Accounts: AssetHolderCounts{
+			Authorized:                      r.Accounts.Authorized,
+			AuthorizedToMaintainLiabilities: r.Accounts.AuthorizedToMaintainLiabilities,
+			Unauthorized:                    r.Accounts.Unauthorized,
+		},
+		Balances: AssetHolderAmounts{
+			Authorized:                      r.Balances.Authorized,
+			AuthorizedToMaintainLiabilities: r.Balances.AuthorizedToMaintainLiabilities,
+			Unauthorized:                    r.Balances.Unauthorized,
+		},
+		NumClaimableBalances:    r.NumClaimableBalances,
+		ClaimableBalancesAmount: r.ClaimableBalancesAmount,
+		NumLiquidityPools:       r.NumLiquidityPools,
+		LiquidityPoolsAmount:    r.LiquidityPoolsAmount,
+		NumContracts:            r.NumContracts,
+		ContractsAmount:         r.ContractsAmount,
+		Flags: AccountFlags{
+			AuthRequired:        r.Flags.AuthRequired,
+			AuthRevocable:       r.Flags.AuthRevocable,
+			AuthImmutable:       r.Flags.AuthImmutable,
+			AuthClawbackEnabled: r.Flags.AuthClawbackEnabled,
+		},
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/base"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type assetsHorizon struct {
+	horizonclient.ClientInterface
+	req         horizonclient.AssetRequest
+	accountsReq horizonclient.AccountsRequest
+}
+
+func (m *assetsHorizon) Accounts(req horizonclient.AccountsRequest) (hProtocol.AccountsPage, error) {
+	m.accountsReq = req
+	return hProtocol.AccountsPage{}, nil
+}
+
+func (m *assetsHorizon) Assets(req horizonclient.AssetRequest) (hProtocol.AssetsPage, error) {
+	m.req = req
+	var page hProtocol.AssetsPage
+	page.Embedded.Records = []hProtocol.AssetStat{{
+		Asset:                   base.Asset{Type: "credit_alphanum4", Code: "USDC", Issuer: testIssuer},
+		Accounts:                hProtocol.AssetStatAccounts{Authorized: 10, Unauthorized: 2},
+		Balances:                hProtocol.AssetStatBalances{Authorized: "100.0000000", Unauthorized: "1.5000000"},
+		NumClaimableBalances:    1,
+		ClaimableBalancesAmount: "3.0000000",
+		LiquidityPoolsAmount:    "0.5000000",
+		Flags:                   hProtocol.AccountFlags{AuthRevocable: true},
+	}}
+	return page, nil
+}
+
+func (m *assetsHorizon) NextAssetsPage(hProtocol.AssetsPage) (hProtocol.AssetsPage, error) {
+	return hProtocol.AssetsPage{}, nil
+}
+
+func TestAssetStats(t *testing.T) {
+	horizon := &assetsHorizon{}
+	client := &Client{Horizon: horizon}
+
+	stats, err := client.AssetStats(context.Background(), AssetStatsFilter{Issuer: testIssuer})
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, testIssuer, horizon.req.ForAssetIssuer)
+
+	s := stats[0]
+	assert.Equal(t, "USDC", s.Asset.GetCode())
+	assert.Equal(t, int32(12), s.Accounts.Total())
+	assert.True(t, s.Flags.AuthRevocable)
+	assert.Equal(t, "105.0000000", s.CirculatingSupplyString())
+}
+
+func TestAssetStat(t *testing.T) {
+	horizon := &assetsHorizon{}
+	client := &Client{Horizon: horizon}
+
+	s, err := client.AssetStat(context.Background(), txnbuild.CreditAsset{Code: "USDC", Issuer: testIssuer})
+	require.NoError(t, err)
+	assert.Equal(t, "USDC", horizon.req.ForAssetCode)
+	assert.Equal(t, int32(1), s.NumClaimableBalances)
+
+	_, err = client.AssetStat(context.Background(), txnbuild.NativeAsset{})
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+}
+
+func TestAssetHolders(t *testing.T) {
+	horizon := &assetsHorizon{}
+	client := &Client{Horizon: horizon}
+
+	it := client.AssetHolders(context.Background(), txnbuild.CreditAsset{Code: "USDC", Issuer: testIssuer})
+	assert.False(t, it.Next())
+	require.NoError(t, it.Err())
+	assert.Equal(t, "USDC:"+testIssuer, horizon.accountsReq.Asset)
+}
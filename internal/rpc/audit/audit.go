@@ -0,0 +1,106 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+// Package audit keeps an append-only, hash-chained record of transaction
+// submissions. Every record carries the hash of the one before it, so
+// removing, reordering or editing a record breaks the chain at that point
+// and Verify reports it. Records are kept in a JSON Lines file or a SQLite
+// table; attach either to an RPC client with rpc.WithAuditLog.
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+)
+
+// Events recorded for a submission. A submit record is written before the
+// envelope is sent and a result record once the outcome is known; a submit
+// without a matching result means the outcome was never observed.
+const (
+	EventSubmit = "submit"
+	EventResult = "result"
+)
+
+// Record is one audit log entry. Seq, PrevHash and Hash are assigned by the
+// log on Append.
+type Record struct {
+	Seq        uint64    `json:"seq"`
+	Time       time.Time `json:"time"`
+	Event      string    `json:"event"`
+	TxHash     string    `json:"tx_hash,omitempty"`
+	Source     string    `json:"source,omitempty"`
+	FeeSource  string    `json:"fee_source,omitempty"`
+	Operations []string  `json:"operations,omitempty"`
+	Network    string    `json:"network,omitempty"`
+	Endpoint   string    `json:"endpoint,omitempty"`
+	Status     string    `json:"status,omitempty"`
+	ResultCode string    `json:"result_code,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	PrevHash   string    `json:"prev_hash"`
+	Hash       string    `json:"hash"`
+}
+
+// Log is an append-only audit log.
+type Log interface {
+	// Append assigns r its sequence number and chain hashes and stores it.
+	Append(r *Record) error
+	// Records returns every record in order.
+	Records() ([]Record, error)
+	Close() error
+}
+
+// chainHash is the hex SHA-256 of r's JSON encoding with Hash cleared.
+func chainHash(r Record) (string, error) {
+	r.Hash = ""
+	b, err := json.Marshal(r)
+	if err != nil {
+		return "", errors.WrapMarshalFailed(err)
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// seal links r to the record before it, whose sequence number and hash are
+// prevSeq and prevHash.
+func seal(r *Record, prevSeq uint64, prevHash string) error {
+	r.Seq = prevSeq + 1
+	r.PrevHash = prevHash
+	if r.Time.IsZero() {
+		r.Time = time.Now()
+	}
+	r.Time = r.Time.UTC()
+	hash, err := chainHash(*r)
+	if err != nil {
+		return err
+	}
+	r.Hash = hash
+	return nil
+}
+
+// Verify checks that records form an unbroken chain from the first record,
+// with consecutive sequence numbers and hashes that match their contents.
+func Verify(records []Record) error {
+	var prevSeq uint64
+	prevHash := ""
+	for _, r := range records {
+		if r.Seq != prevSeq+1 {
+			return errors.WrapAuditChainBroken(r.Seq, "sequence does not follow the previous record")
+		}
+		if r.PrevHash != prevHash {
+			return errors.WrapAuditChainBroken(r.Seq, "previous hash does not match")
+		}
+		want, err := chainHash(r)
+		if err != nil {
+			return err
+		}
+		if r.Hash != want {
+			return errors.WrapAuditChainBroken(r.Seq, "record hash does not match its contents")
+		}
+		prevSeq, prevHash = r.Seq, r.Hash
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func appendRecords(t *testing.T, l Log, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		require.NoError(t, l.Append(&Record{Event: EventSubmit, TxHash: strings.Repeat("a", 64), Operations: []string{"payment"}}))
+	}
+}
+
+func TestVerify(t *testing.T) {
+	var records []Record
+	prevSeq, prevHash := uint64(0), ""
+	for _, ev := range []string{EventSubmit, EventResult, EventSubmit} {
+		r := Record{Event: ev}
+		require.NoError(t, seal(&r, prevSeq, prevHash))
+		records = append(records, r)
+		prevSeq, prevHash = r.Seq, r.Hash
+	}
+	require.NoError(t, Verify(records))
+	assert.Equal(t, records[0].Hash, records[1].PrevHash)
+
+	edited := append([]Record(nil), records...)
+	edited[1].Status = "PENDING"
+	assert.ErrorContains(t, Verify(edited), "record 2")
+
+	removed := []Record{records[0], records[2]}
+	assert.ErrorIs(t, Verify(removed), errs.ErrAuditLog)
+
+	swapped := []Record{records[1], records[0], records[2]}
+	assert.ErrorIs(t, Verify(swapped), errs.ErrAuditLog)
+}
+
+func TestJSONLLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	l, err := OpenJSONL(path)
+	require.NoError(t, err)
+	appendRecords(t, l, 2)
+	require.NoError(t, l.Close())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// Reopening continues the chain.
+	l, err = OpenJSONL(path)
+	require.NoError(t, err)
+	appendRecords(t, l, 1)
+	records, err := l.Records()
+	require.NoError(t, err)
+	require.NoError(t, l.Close())
+	require.Len(t, records, 3)
+	assert.Equal(t, uint64(3), records[2].Seq)
+	require.NoError(t, Verify(records))
+
+	assert.ErrorIs(t, l.Append(&Record{Event: EventSubmit}), errs.ErrAuditLog)
+}
+
+func TestJSONLLog_RefusesTamperedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	l, err := OpenJSONL(path)
+	require.NoError(t, err)
+	appendRecords(t, l, 2)
+	require.NoError(t, l.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, []byte(strings.Replace(string(data), `"payment"`, `"create_account"`, 1)), 0600))
+
+	_, err = OpenJSONL(path)
+	assert.ErrorIs(t, err, errs.ErrAuditLog)
+	assert.ErrorContains(t, err, "record 1")
+}
+
+func TestSQLiteLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.db")
+	l, err := OpenSQLite(path)
+	require.NoError(t, err)
+	appendRecords(t, l, 2)
+	require.NoError(t, l.Close())
+
+	l, err = OpenSQLite(path)
+	require.NoError(t, err)
+	defer l.Close()
+	appendRecords(t, l, 1)
+
+	records, err := l.Records()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	require.NoError(t, Verify(records))
+
+	_, err = l.db.Exec(`DELETE FROM audit_log WHERE seq = 2`)
+	assert.ErrorContains(t, err, "append-only")
+	_, err = l.db.Exec(`UPDATE audit_log SET record = '{}' WHERE seq = 1`)
+	assert.ErrorContains(t, err, "append-only")
+}
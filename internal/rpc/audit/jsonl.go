@@ -0,0 +1,120 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/dotandev/hintents/internal/errors"
+)
+
+// JSONLLog is a Log stored as one JSON record per line in a file opened for
+// appending only. Each record is synced to disk before Append returns.
+type JSONLLog struct {
+	mu       sync.Mutex
+	path     string
+	f        *os.File
+	lastSeq  uint64
+	lastHash string
+}
+
+// OpenJSONL opens, or creates with mode 0600, the JSON Lines audit log at
+// path. An existing log is verified first, and new records continue its
+// chain; a broken chain is an error rather than something to append to.
+func OpenJSONL(path string) (*JSONLLog, error) {
+	l := &JSONLLog{path: path}
+	records, err := l.read()
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err := Verify(records); err != nil {
+		return nil, err
+	}
+	if n := len(records); n > 0 {
+		l.lastSeq, l.lastHash = records[n-1].Seq, records[n-1].Hash
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, errors.WrapAuditLog(err)
+	}
+	l.f = f
+	return l, nil
+}
+
+// Append implements Log.
+func (l *JSONLLog) Append(r *Record) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		return errors.WrapAuditLog(os.ErrClosed)
+	}
+
+	rec := *r
+	if err := seal(&rec, l.lastSeq, l.lastHash); err != nil {
+		return err
+	}
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return errors.WrapMarshalFailed(err)
+	}
+	if _, err := l.f.Write(append(line, '\n')); err != nil {
+		return errors.WrapAuditLog(err)
+	}
+	if err := l.f.Sync(); err != nil {
+		return errors.WrapAuditLog(err)
+	}
+	l.lastSeq, l.lastHash = rec.Seq, rec.Hash
+	*r = rec
+	return nil
+}
+
+// Records implements Log.
+func (l *JSONLLog) Records() ([]Record, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.read()
+}
+
+func (l *JSONLLog) read() ([]Record, error) {
+	f, err := os.Open(l.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			return nil, errors.WrapUnmarshalFailed(err, fmt.Sprintf("%s line %d", l.path, line))
+		}
+		records = append(records, r)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.WrapAuditLog(err)
+	}
+	return records, nil
+}
+
+// Close implements Log.
+func (l *JSONLLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.f == nil {
+		return nil
+	}
+	err := l.f.Close()
+	l.f = nil
+	return err
+}
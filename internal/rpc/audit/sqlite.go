@@ -0,0 +1,124 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dotandev/hintents/internal/errors"
+	_ "modernc.org/sqlite"
+)
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS audit_log (
+	seq       INTEGER PRIMARY KEY,
+	hash      TEXT NOT NULL,
+	prev_hash TEXT NOT NULL,
+	record    TEXT NOT NULL
+);
+CREATE TRIGGER IF NOT EXISTS audit_log_no_update BEFORE UPDATE ON audit_log
+BEGIN SELECT RAISE(ABORT, 'audit_log is append-only'); END;
+CREATE TRIGGER IF NOT EXISTS audit_log_no_delete BEFORE DELETE ON audit_log
+BEGIN SELECT RAISE(ABORT, 'audit_log is append-only'); END;
+`
+
+// SQLiteLog is a Log stored in the audit_log table of a SQLite database.
+// Triggers reject updates and deletes, and the hash chain exposes rows
+// changed by other means.
+type SQLiteLog struct {
+	db *sql.DB
+}
+
+// OpenSQLite opens, or creates, a SQLite audit log at path.
+func OpenSQLite(path string) (*SQLiteLog, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, errors.WrapAuditLog(err)
+	}
+	l, err := NewSQLite(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+// NewSQLite keeps the audit log in an already-open database, creating the
+// table if needed. Close closes db.
+func NewSQLite(db *sql.DB) (*SQLiteLog, error) {
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		return nil, errors.WrapAuditLog(fmt.Errorf("failed to initialize audit schema: %w", err))
+	}
+	return &SQLiteLog{db: db}, nil
+}
+
+// Append implements Log. The previous record is read and the new one
+// inserted in a single transaction, so concurrent writers cannot fork the
+// chain.
+func (l *SQLiteLog) Append(r *Record) error {
+	tx, err := l.db.Begin()
+	if err != nil {
+		return errors.WrapAuditLog(err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	var prevSeq uint64
+	var prevHash string
+	err = tx.QueryRow(`SELECT seq, hash FROM audit_log ORDER BY seq DESC LIMIT 1`).Scan(&prevSeq, &prevHash)
+	if err != nil && err != sql.ErrNoRows {
+		return errors.WrapAuditLog(err)
+	}
+
+	rec := *r
+	if err := seal(&rec, prevSeq, prevHash); err != nil {
+		return err
+	}
+	body, err := json.Marshal(rec)
+	if err != nil {
+		return errors.WrapMarshalFailed(err)
+	}
+	if _, err := tx.Exec(`INSERT INTO audit_log (seq, hash, prev_hash, record) VALUES (?, ?, ?, ?)`,
+		rec.Seq, rec.Hash, rec.PrevHash, string(body)); err != nil {
+		return errors.WrapAuditLog(err)
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.WrapAuditLog(err)
+	}
+	*r = rec
+	return nil
+}
+
+// Records implements Log.
+func (l *SQLiteLog) Records() ([]Record, error) {
+	rows, err := l.db.Query(`SELECT seq, record FROM audit_log ORDER BY seq`)
+	if err != nil {
+		return nil, errors.WrapAuditLog(err)
+	}
+	defer rows.Close()
+
+	var records []Record
+	for rows.Next() {
+		var seq uint64
+		var body string
+		if err := rows.Scan(&seq, &body); err != nil {
+			return nil, errors.WrapAuditLog(err)
+		}
+		var r Record
+		if err := json.Unmarshal([]byte(body), &r); err != nil {
+			return nil, errors.WrapUnmarshalFailed(err, fmt.Sprintf("audit record %d", seq))
+		}
+		records = append(records, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.WrapAuditLog(err)
+	}
+	return records, nil
+}
+
+// Close implements Log.
+func (l *SQLiteLog) Close() error {
+	return l.db.Close()
+}
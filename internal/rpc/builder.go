@@ -6,12 +6,18 @@ package rpc
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc/audit"
+	"github.com/dotandev/hintents/internal/rpc/metrics"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
 )
 
@@ -24,11 +30,25 @@ type clientBuilder struct {
 	sorobanURL     string
 	altURLs        []string
 	cacheEnabled   bool
+	simCache       *SimulationCache
+	streamConfig   StreamConfig
 	config         *NetworkConfig
 	httpClient     *http.Client
 	requestTimeout time.Duration
+	metrics        *metrics.Collector
+	logConfig      LogConfig
+	wireDump       io.Writer
+	budgets        map[string]time.Duration
+	onSlowRequest  func(SlowRequest)
+	auditLog       audit.Log
+	onCacheEvent   func(CacheEvent)
+	contractErrors ContractErrorResolver
+	vars           *expvarSet
+	correlation    correlationConfig
+	hooks          *clientHooks
+	faults         *FaultTransport
 	// custom headers to inject on each request
-	headers         map[string]string
+	headers map[string]string
 }
 
 const defaultHTTPTimeout = 15 * time.Second
@@ -38,7 +58,7 @@ func newBuilder() *clientBuilder {
 		network:        Mainnet,
 		cacheEnabled:   true,
 		requestTimeout: defaultHTTPTimeout,
-		headers:         make(map[string]string),
+		headers:        make(map[string]string),
 	}
 }
 
@@ -67,39 +87,85 @@ func WithHeaders(headers map[string]string) ClientOption {
 }
 
 // ParseHeaders converts a string into a header map. The input may be a JSON
-// object (e.g. '{"A":"1"}') or a comma-separated list of key=value or
-// key:value pairs. Empty input returns an empty map.
+// object (e.g. '{"A":"1","B":2}') or a comma-separated list of key=value or
+// key:value pairs. Empty input returns an empty map. Pairs whose name is not
+// a valid header name or whose value contains control characters are
+// dropped, since net/http would refuse to send them.
 func ParseHeaders(input string) map[string]string {
 	headers := make(map[string]string)
+	input = strings.TrimSpace(input)
 	if input == "" {
 		return headers
 	}
 
 	// try JSON first
-	var obj map[string]string
-	if err := json.Unmarshal([]byte(input), &obj); err == nil {
-		return obj
+	if strings.HasPrefix(input, "{") {
+		var obj map[string]interface{}
+		if err := json.Unmarshal([]byte(input), &obj); err == nil {
+			for k, v := range obj {
+				addHeader(headers, k, jsonHeaderValue(v))
+			}
+			return headers
+		}
 	}
 
 	for _, part := range strings.Split(input, ",") {
-		part = strings.TrimSpace(part)
-		if part == "" {
-			continue
-		}
 		idx := strings.IndexAny(part, "=:")
 		if idx == -1 {
 			continue
 		}
-		key := strings.TrimSpace(part[:idx])
-		value := strings.TrimSpace(part[idx+1:])
-		if key != "" && value != "" {
-			headers[key] = value
-		}
+		addHeader(headers, part[:idx], part[idx+1:])
 	}
 
 	return headers
 }
 
+func addHeader(headers map[string]string, key, value string) {
+	key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+	if validHeaderName(key) && value != "" && validHeaderValue(value) {
+		headers[key] = value
+	}
+}
+
+// jsonHeaderValue renders a JSON scalar as a header value; objects, arrays
+// and null yield "" and are dropped.
+func jsonHeaderValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return ""
+}
+
+// validHeaderName reports whether name is an RFC 7230 token.
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c >= 0x80 || c <= ' ' || strings.IndexByte("\"(),/:;<=>?@[\\]{}", c) != -1 {
+			return false
+		}
+	}
+	return true
+}
+
+// validHeaderValue reports whether value has no control characters other
+// than tab.
+func validHeaderValue(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if c := value[i]; (c < ' ' && c != '\t') || c == 0x7f {
+			return false
+		}
+	}
+	return true
+}
+
 func WithHorizonURL(url string) ClientOption {
 	return func(b *clientBuilder) error {
 		if url != "" {
@@ -160,6 +226,24 @@ func WithCacheEnabled(enabled bool) ClientOption {
 	}
 }
 
+// WithSimulationCache enables in-memory caching of simulateTransaction results
+// per envelope and ledger. Entries are also dropped after maxAge; pass 0 to use
+// DefaultSimulationCacheMaxAge.
+func WithSimulationCache(maxAge time.Duration) ClientOption {
+	return func(b *clientBuilder) error {
+		b.simCache = NewSimulationCache(maxAge)
+		return nil
+	}
+}
+
+// WithStreamConfig overrides the reconnection behaviour of Horizon streams.
+func WithStreamConfig(cfg StreamConfig) ClientOption {
+	return func(b *clientBuilder) error {
+		b.streamConfig = cfg
+		return nil
+	}
+}
+
 // WithRequestTimeout sets a custom HTTP request timeout for all RPC calls.
 // Use this to override the default 15-second timeout, for example on slow connections.
 // A value of 0 disables the timeout (not recommended for production use).
@@ -177,6 +261,256 @@ func WithHTTPClient(client *http.Client) ClientOption {
 	}
 }
 
+// WithMetricsCollector exports the client's request counts, latencies,
+// errors, retries, failovers and cache statistics as Prometheus metrics
+// registered with reg, typically prometheus.DefaultRegisterer. Clients
+// sharing a registerer share series, told apart by the endpoint label.
+func WithMetricsCollector(reg prometheus.Registerer) ClientOption {
+	return func(b *clientBuilder) error {
+		if reg == nil {
+			b.metrics = nil
+			return nil
+		}
+		m, err := metrics.New(reg)
+		if err != nil {
+			return err
+		}
+		b.metrics = m
+		return nil
+	}
+}
+
+// WithLogger sends the client's logs to l instead of logger.Logger.
+func WithLogger(l *slog.Logger) ClientOption {
+	return func(b *clientBuilder) error {
+		b.logConfig.Logger = l
+		return nil
+	}
+}
+
+// WithExpvar publishes the client's request, error, failover and cache
+// lookup counters and its HealthStatus through the standard expvar package,
+// served at /debug/vars by any process that imports it over HTTP, for
+// visibility without Prometheus. Variables are named "<prefix>.requests",
+// keyed by endpoint then method, "<prefix>.errors", by endpoint then kind,
+// "<prefix>.failovers", "<prefix>.cache", by cache then result, and
+// "<prefix>.health"; an empty prefix uses DefaultExpvarPrefix. expvar
+// cannot unpublish, so clients built with the same prefix share its
+// counters and health shows the most recent of them.
+func WithExpvar(prefix string) ClientOption {
+	return func(b *clientBuilder) error {
+		if prefix == "" {
+			prefix = DefaultExpvarPrefix
+		}
+		vars, err := publishExpvar(prefix)
+		if err != nil {
+			return err
+		}
+		b.vars = vars
+		return nil
+	}
+}
+
+// WithCorrelationIDKey reads correlation IDs from the context value stored
+// under key, instead of the one set by ContextWithCorrelationID, so request
+// IDs an application already keeps in its contexts reach the RPC provider's
+// logs. Values must be strings or implement fmt.Stringer. Every request
+// made under such a context carries the ID in a header, and the client's
+// log lines for the call carry it as "correlation_id". Horizon SDK calls
+// that take no context, such as transaction submission, are not tagged. A
+// client passed to WithHTTPClient sends the header only when this option or
+// WithCorrelationHeader is given.
+func WithCorrelationIDKey(key any) ClientOption {
+	return func(b *clientBuilder) error {
+		if key == nil {
+			return errors.WrapValidationError("correlation ID key must not be nil")
+		}
+		b.correlation.key = key
+		return nil
+	}
+}
+
+// WithCorrelationHeader sets the header correlation IDs are sent in,
+// DefaultCorrelationHeader by default.
+func WithCorrelationHeader(name string) ClientOption {
+	return func(b *clientBuilder) error {
+		if name == "" {
+			return errors.WrapValidationError("correlation header name must not be empty")
+		}
+		b.correlation.header = http.CanonicalHeaderKey(name)
+		return nil
+	}
+}
+
+// WithRequestHook calls fn before every HTTP attempt the client makes,
+// transport retries included. Hooks run on the request's goroutine, in the
+// order registered, and should return quickly. Any number may be added.
+func WithRequestHook(fn func(RequestEvent)) ClientOption {
+	return func(b *clientBuilder) error {
+		if fn != nil {
+			b.hookSet().onRequest = append(b.hookSet().onRequest, fn)
+		}
+		return nil
+	}
+}
+
+// WithResponseHook calls fn when every HTTP attempt completes, with its
+// status code or transport error and duration.
+func WithResponseHook(fn func(ResponseEvent)) ClientOption {
+	return func(b *clientBuilder) error {
+		if fn != nil {
+			b.hookSet().onResponse = append(b.hookSet().onResponse, fn)
+		}
+		return nil
+	}
+}
+
+// WithRetryHook calls fn each time the retry transport is about to retry a
+// failed attempt. A client passed to WithHTTPClient has no retry transport,
+// so its hook is never called.
+func WithRetryHook(fn func(RetryEvent)) ClientOption {
+	return func(b *clientBuilder) error {
+		if fn != nil {
+			b.hookSet().onRetry = append(b.hookSet().onRetry, fn)
+		}
+		return nil
+	}
+}
+
+// WithFailoverHook calls fn each time a call fails on a node and the client
+// rotates to the next one.
+func WithFailoverHook(fn func(FailoverEvent)) ClientOption {
+	return func(b *clientBuilder) error {
+		if fn != nil {
+			b.hookSet().onFailover = append(b.hookSet().onFailover, fn)
+		}
+		return nil
+	}
+}
+
+func (b *clientBuilder) hookSet() *clientHooks {
+	if b.hooks == nil {
+		b.hooks = &clientHooks{}
+	}
+	return b.hooks
+}
+
+// WithSubsystemLogLevel sets the minimum level logged for one subsystem,
+// such as LogSubsystemFailover, so it can be made more or less verbose than
+// the rest of the client.
+func WithSubsystemLogLevel(subsystem string, lvl slog.Level) ClientOption {
+	return func(b *clientBuilder) error {
+		if b.logConfig.Levels == nil {
+			b.logConfig.Levels = make(map[string]slog.Level)
+		}
+		b.logConfig.Levels[subsystem] = lvl
+		return nil
+	}
+}
+
+// WithDebugLogSampling keeps one in every n repeated debug records; see
+// LogConfig.DebugSampleRate.
+func WithDebugLogSampling(n int) ClientOption {
+	return func(b *clientBuilder) error {
+		b.logConfig.DebugSampleRate = n
+		return nil
+	}
+}
+
+// WithWireDump writes every HTTP request the client sends and the response
+// it receives, headers and bodies included, to w, for attaching to bug
+// reports against an RPC provider. Authorization and cookie headers,
+// parameters named like credentials, the client's token and Stellar secret
+// seeds are redacted. Each transport retry is dumped separately. The bodies
+// of event streams are not dumped.
+func WithWireDump(w io.Writer) ClientOption {
+	return func(b *clientBuilder) error {
+		b.wireDump = w
+		return nil
+	}
+}
+
+// WithLatencyBudget warns when a request for method takes longer than
+// budget, logging the endpoint and a DNS, connect, TLS and server-time
+// breakdown, and counting it when metrics are enabled. method is a Soroban
+// JSON-RPC method such as "simulateTransaction" or a Horizon request such as
+// "GET /accounts"; an empty method sets the budget for every method without
+// its own. Budgets apply per HTTP attempt, so retries are timed separately.
+func WithLatencyBudget(method string, budget time.Duration) ClientOption {
+	return func(b *clientBuilder) error {
+		if budget < 0 {
+			return errors.WrapValidationError("latency budget must not be negative")
+		}
+		if b.budgets == nil {
+			b.budgets = make(map[string]time.Duration)
+		}
+		b.budgets[method] = budget
+		return nil
+	}
+}
+
+// WithSlowRequestHandler calls fn for every request over its latency budget,
+// in addition to the warning log. fn runs on the request's goroutine and
+// should return quickly.
+func WithSlowRequestHandler(fn func(SlowRequest)) ClientOption {
+	return func(b *clientBuilder) error {
+		b.onSlowRequest = fn
+		return nil
+	}
+}
+
+// WithAuditLog records every SubmitTransactionAsync call in l: a submit
+// record with the hash, source, operations and endpoint before the envelope
+// is sent, and a result record with the status once the response arrives. A
+// submission whose submit record cannot be written is not sent. The client
+// does not close l.
+func WithAuditLog(l audit.Log) ClientOption {
+	return func(b *clientBuilder) error {
+		b.auditLog = l
+		return nil
+	}
+}
+
+// WithFaultInjection injects the failures cfg describes into every HTTP
+// attempt the client makes, beneath its retry transport, so retry and
+// failover settings can be exercised against a flaky network. Use
+// Client.InjectedFaults to see what was injected. It is meant for tests
+// and chaos drills, never for production clients.
+func WithFaultInjection(cfg FaultConfig) ClientOption {
+	return func(b *clientBuilder) error {
+		for _, p := range []float64{cfg.LatencyProbability, cfg.TimeoutProbability, cfg.ResetProbability, cfg.TruncateProbability, cfg.MalformedProbability} {
+			if p < 0 || p > 1 {
+				return errors.WrapValidationError(fmt.Sprintf("fault probability %v is outside [0, 1]", p))
+			}
+		}
+		b.faults = NewFaultTransport(cfg, nil)
+		return nil
+	}
+}
+
+// WithCacheEventHandler calls fn for every lookup in the ledger entry and
+// simulation caches, with the key, the method it served, whether it was a
+// hit, a miss or stale, and the entry's age. Lookups are also logged at
+// debug level on LogSubsystemCache and counted when metrics are enabled. fn
+// runs on the caller's goroutine and should return quickly.
+func WithCacheEventHandler(fn func(CacheEvent)) ClientOption {
+	return func(b *clientBuilder) error {
+		b.onCacheEvent = fn
+		return nil
+	}
+}
+
+// WithContractErrorResolver names the Error(Contract, #N) codes of failed
+// simulations and transactions from the spec of the contract that raised
+// them, using r. Without it, contract errors carry only the code and the
+// diagnostic trail.
+func WithContractErrorResolver(r ContractErrorResolver) ClientOption {
+	return func(b *clientBuilder) error {
+		b.contractErrors = r
+		return nil
+	}
+}
+
 func NewClient(opts ...ClientOption) (*Client, error) {
 	builder := newBuilder()
 
@@ -252,9 +586,22 @@ func (b *clientBuilder) build() (*Client, error) {
 		b.config = &cfg
 	}
 
+	logs := newClientLoggers(b.logConfig)
+	logs.correlation = b.correlation
 	if b.httpClient == nil {
-		b.httpClient = createHTTPClient(b.token, b.headers, b.requestTimeout)
+		base := b.wrapBaseTransport(http.DefaultTransport, logs)
+		b.httpClient = newHTTPClient(base, b.token, b.headers, b.requestTimeout)
+		if rt, ok := b.httpClient.Transport.(*RetryTransport); ok {
+			rt.metrics = b.metrics
+			rt.logs = logs
+			rt.hooks = b.hooks
+		}
+	} else if b.faults != nil || b.wireDump != nil || len(b.budgets) > 0 || b.correlation.configured() || b.hooks.transportHooks() {
+		hc := *b.httpClient
+		hc.Transport = b.wrapBaseTransport(hc.Transport, logs)
+		b.httpClient = &hc
 	}
+	b.httpClient = instrument(b.httpClient, b.metrics, b.vars)
 
 	if len(b.altURLs) == 0 && b.horizonURL != "" {
 		b.altURLs = []string{b.horizonURL}
@@ -268,21 +615,67 @@ func (b *clientBuilder) build() (*Client, error) {
 		b.altURLs = []string{b.horizonURL}
 	}
 
-	return &Client{
+	c := &Client{
 		HorizonURL: b.horizonURL,
 		Horizon: &horizonclient.Client{
 			HorizonURL: b.horizonURL,
 			HTTP:       b.httpClient,
 		},
-		Network:      b.network,
-		SorobanURL:   b.sorobanURL,
-		AltURLs:      b.altURLs,
-		httpClient:   b.httpClient,
-		token:        b.token,
-		Config:       *b.config,
-		CacheEnabled: b.cacheEnabled,
-		Headers:      b.headers,
-		failures:     make(map[string]int),
-		lastFailure:  make(map[string]time.Time),
-	}, nil
-}
\ No newline at end of file
+		Network:         b.network,
+		SorobanURL:      b.sorobanURL,
+		AltURLs:         b.altURLs,
+		httpClient:      b.httpClient,
+		token:           b.token,
+		Config:          *b.config,
+		CacheEnabled:    b.cacheEnabled,
+		SimulationCache: b.simCache,
+		StreamConfig:    b.streamConfig,
+		Headers:         b.headers,
+		failures:        make(map[string]int),
+		lastFailure:     make(map[string]time.Time),
+		lastSuccess:     make(map[string]time.Time),
+		metrics:         b.metrics,
+		logs:            logs,
+		audit:           b.auditLog,
+		onCacheEvent:    b.onCacheEvent,
+		contractErrors:  b.contractErrors,
+		vars:            b.vars,
+		hooks:           b.hooks,
+		faults:          b.faults,
+	}
+	if b.vars != nil {
+		b.vars.client.Store(c)
+	}
+	return c, nil
+}
+
+// wrapBaseTransport adds the per-attempt transports, request and response
+// hooks, correlation headers, wire dumping, latency budgets and fault
+// injection, around base. For the client's own HTTP client base is
+// beneath the auth and retry transports; a caller's client is wrapped whole.
+func (b *clientBuilder) wrapBaseTransport(base http.RoundTripper, logs *clientLoggers) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if b.faults != nil {
+		b.faults.transport = base
+		base = b.faults
+	}
+	if b.wireDump != nil {
+		base = newWireDumpTransport(b.wireDump, base, b.token)
+	}
+	if len(b.budgets) > 0 {
+		base = &latencyTransport{
+			budgets:   b.budgets,
+			onSlow:    b.onSlowRequest,
+			logs:      logs,
+			metrics:   b.metrics,
+			transport: base,
+		}
+	}
+	base = &correlationTransport{cfg: b.correlation, transport: base}
+	if b.hooks.transportHooks() {
+		base = &hookTransport{hooks: b.hooks, transport: base}
+	}
+	return base
+}
@@ -77,6 +77,16 @@ func TestParseHeadersHelper(t *testing.T) {
 	}
 }
 
+func TestParseHeadersDropsUnsendable(t *testing.T) {
+	h := ParseHeaders("X-Token=a\r\nInjected: 1,Bad Name=1,Ok=2")
+	if len(h) != 1 || h["Ok"] != "2" {
+		t.Errorf("expected only Ok=2, got %v", h)
+	}
+	if h := ParseHeaders("null"); h == nil {
+		t.Error("expected an empty map for JSON null")
+	}
+}
+
 func TestBuilderWithHeaders(t *testing.T) {
 	headers := map[string]string{"X-Test": "header"}
 	client, err := NewClient(WithHeaders(headers))
@@ -6,12 +6,10 @@ package rpc
 import (
 	"context"
 	"encoding/base64"
-	"encoding/hex"
 	"fmt"
 	"strings"
 
-	"github.com/dotandev/hintents/internal/logger"
-	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/dotandev/hintents/internal/address"
 	"github.com/stellar/go-stellar-sdk/xdr"
 )
 
@@ -77,27 +75,9 @@ func decodeContractID(contractIDStr string) (xdr.ContractId, error) {
 		return xdr.ContractId{}, fmt.Errorf("empty contract id")
 	}
 	if s[0] == 'C' {
-		decoded, err := strkey.Decode(strkey.VersionByteContract, s)
-		if err != nil {
-			return xdr.ContractId{}, fmt.Errorf("decode strkey contract id: %w", err)
-		}
-		if len(decoded) != 32 {
-			return xdr.ContractId{}, fmt.Errorf("contract id must be 32 bytes, got %d", len(decoded))
-		}
-		var cid xdr.ContractId
-		copy(cid[:], decoded)
-		return cid, nil
-	}
-	raw, err := hex.DecodeString(s)
-	if err != nil {
-		return xdr.ContractId{}, fmt.Errorf("decode hex contract id: %w", err)
-	}
-	if len(raw) != 32 {
-		return xdr.ContractId{}, fmt.Errorf("contract id must be 32 bytes, got %d", len(raw))
+		return address.ContractID(s)
 	}
-	var cid xdr.ContractId
-	copy(cid[:], raw)
-	return cid, nil
+	return address.ContractIDFromHex(s)
 }
 
 // FetchContractBytecode fetches the un-executed WASM for the given contract ID via getLedgerEntries,
@@ -152,7 +132,7 @@ func FetchContractBytecode(ctx context.Context, c *Client, contractIDStr string)
 	for k, v := range codeEntries {
 		entries[k] = v
 	}
-	logger.Logger.Debug("Fetched contract bytecode on demand", "contract_id", contractIDStr, "cached", true)
+	c.logContext(ctx, LogSubsystemRPC).Debug("Fetched contract bytecode on demand", "contract_id", contractIDStr, "cached", true)
 	return entries, nil
 }
 
@@ -174,7 +154,7 @@ func FetchBytecodeForTraceContractCalls(ctx context.Context, c *Client, contract
 		// require parsing; simpler to always call FetchContractBytecode which uses the client cache.
 		fetched, err := FetchContractBytecode(ctx, c, id)
 		if err != nil {
-			logger.Logger.Warn("Failed to fetch contract bytecode for trace", "contract_id", id, "error", err)
+			c.logContext(ctx, LogSubsystemRPC).Warn("Failed to fetch contract bytecode for trace", "contract_id", id, "error", err)
 			continue
 		}
 		if existingMap == nil {
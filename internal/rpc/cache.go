@@ -158,28 +158,44 @@ func getCacheKey(key string) string {
 // Get retrieves a value from the SQLite cache.
 // Returns (value, found, error).
 func Get(key string) (string, bool, error) {
-	db, err := ensureDB()
-	if err != nil {
+	entry, found, err := Lookup(key)
+	if err != nil || !found || !time.Now().Before(entry.ExpiresAt) {
 		return "", false, err
 	}
+	return entry.Value, true, nil
+}
 
-	keyHash := getCacheKey(key)
-	now := time.Now().UnixNano()
+// Lookup returns the cached entry for key with its timestamps. Unlike Get it
+// also returns entries that have expired but not yet been cleaned up, so
+// callers can tell a stale entry from a missing one.
+func Lookup(key string) (CachedEntry, bool, error) {
+	db, err := ensureDB()
+	if err != nil {
+		return CachedEntry{}, false, err
+	}
 
 	var value string
+	var createdAt, expiresAt int64
 	err = db.QueryRow(
-		"SELECT value FROM rpc_cache WHERE key_hash = ? AND expires_at > ?",
-		keyHash, now,
-	).Scan(&value)
+		"SELECT value, created_at, expires_at FROM rpc_cache WHERE key_hash = ?",
+		getCacheKey(key),
+	).Scan(&value, &createdAt, &expiresAt)
 
 	if err == sql.ErrNoRows {
-		return "", false, nil
+		return CachedEntry{}, false, nil
 	}
 	if err != nil {
-		return "", false, fmt.Errorf("cache read failed: %w", err)
+		return CachedEntry{}, false, fmt.Errorf("cache read failed: %w", err)
 	}
 
-	return value, true, nil
+	entry := CachedEntry{
+		Key:       key,
+		Value:     value,
+		CreatedAt: time.Unix(0, createdAt),
+		ExpiresAt: time.Unix(0, expiresAt),
+	}
+	entry.TTL = entry.ExpiresAt.Sub(entry.CreatedAt)
+	return entry, true, nil
 }
 
 // SetWithTTL stores a value in the cache with a specific TTL.
@@ -0,0 +1,55 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"time"
+)
+
+// Results of a cache lookup reported in CacheEvent.Result. A stale entry was
+// found but had outlived its TTL, or for the simulation cache was simulated
+// against an older ledger, and was not used.
+const (
+	CacheResultHit   = "hit"
+	CacheResultMiss  = "miss"
+	CacheResultStale = "stale"
+)
+
+// Caches reported in CacheEvent.Cache.
+const (
+	CacheLedgerEntries = "ledger_entries"
+	CacheSimulation    = "simulation"
+)
+
+// CacheEvent describes one cache lookup. Key is the base64 ledger key for
+// the ledger entry cache and the envelope hash for the simulation cache.
+// Age is how long ago the entry was stored and TTL how long it was meant to
+// live; both are zero on a miss.
+type CacheEvent struct {
+	Cache  string
+	Method string
+	Key    string
+	Result string
+	Age    time.Duration
+	TTL    time.Duration
+}
+
+// observeCache reports a lookup to the cache logger, the metrics collector,
+// the expvar counters and the WithCacheEventHandler callback.
+func (c *Client) observeCache(ctx context.Context, ev CacheEvent) {
+	c.logContext(ctx, LogSubsystemCache).Debug("Cache lookup",
+		"cache", ev.Cache,
+		"method", ev.Method,
+		"key", ev.Key,
+		"result", ev.Result,
+		"age", ev.Age.Round(time.Millisecond),
+		"ttl", ev.TTL,
+	)
+	c.metrics.ObserveCache(ev.Cache, ev.Result, ev.Age)
+	c.vars.observeCache(ev.Cache, ev.Result)
+	if c.onCacheEvent != nil {
+		c.onCacheEvent(ev)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLedgerEntries_CacheEvents(t *testing.T) {
+	setupTestCacheDB(t)
+	require.NoError(t, SetWithTTL("fresh", "v1", time.Hour))
+	require.NoError(t, SetWithTTL("expired", "v2", time.Nanosecond))
+	time.Sleep(time.Millisecond)
+
+	var events []CacheEvent
+	client := &Client{CacheEnabled: true, onCacheEvent: func(ev CacheEvent) { events = append(events, ev) }}
+
+	// No nodes are configured, so the lookups are all that happens.
+	_, err := client.GetLedgerEntries(context.Background(), []string{"fresh", "expired", "absent"})
+	require.Error(t, err)
+
+	require.Len(t, events, 3)
+	for i, want := range []string{CacheResultHit, CacheResultStale, CacheResultMiss} {
+		assert.Equal(t, want, events[i].Result, events[i].Key)
+		assert.Equal(t, CacheLedgerEntries, events[i].Cache)
+		assert.Equal(t, "getLedgerEntries", events[i].Method)
+	}
+	assert.Equal(t, "fresh", events[0].Key)
+	assert.Equal(t, time.Hour, events[0].TTL)
+	assert.Greater(t, events[1].Age, events[1].TTL)
+	assert.Zero(t, events[2].Age)
+
+	entries, err := client.GetLedgerEntries(context.Background(), []string{"fresh"})
+	require.NoError(t, err)
+	assert.Equal(t, "v1", entries["fresh"])
+}
+
+func TestSimulateTransaction_CacheEvents(t *testing.T) {
+	server, _ := newSimulateServer(t, func(call int32) map[string]interface{} {
+		return map[string]interface{}{"result": map[string]interface{}{"latestLedger": 42}}
+	})
+	defer server.Close()
+
+	var results []string
+	client, err := NewClient(
+		WithNetwork(Testnet),
+		WithSorobanURL(server.URL),
+		WithAltURLs([]string{server.URL}),
+		WithSimulationCache(time.Minute),
+		WithCacheEventHandler(func(ev CacheEvent) {
+			assert.Equal(t, CacheSimulation, ev.Cache)
+			assert.Equal(t, "simulateTransaction", ev.Method)
+			assert.Equal(t, HashEnvelope("AAAA"), ev.Key)
+			results = append(results, ev.Result)
+		}),
+	)
+	require.NoError(t, err)
+	client.SorobanURL = server.URL
+	now := time.Now()
+	client.SimulationCache.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		_, err := client.SimulateTransaction(context.Background(), "AAAA")
+		require.NoError(t, err)
+	}
+	// A new ledger evicts entries outright, so only outliving the max age
+	// leaves a stale entry to find.
+	now = now.Add(2 * time.Minute)
+	_, err = client.SimulateTransaction(context.Background(), "AAAA")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{CacheResultMiss, CacheResultHit, CacheResultStale}, results)
+}
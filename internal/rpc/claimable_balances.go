@@ -0,0 +1,261 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// ClaimableBalanceFilter selects claimable balances. Any combination of
+// Claimant, Sponsor and Asset may be set; Horizon ANDs them together.
+type ClaimableBalanceFilter struct {
+	Claimant string
+	Sponsor  string
+	Asset    txnbuild.Asset
+	// PageSize is the number of records fetched per request (default 200).
+	PageSize int
+	// MaxRecords stops paging once this many balances were collected; 0 means
+	// no limit.
+	MaxRecords int
+}
+
+// PredicateKind identifies the shape of a claim predicate.
+type PredicateKind string
+
+const (
+	PredicateUnconditional PredicateKind = "unconditional"
+	PredicateAnd           PredicateKind = "and"
+	PredicateOr            PredicateKind = "or"
+	PredicateNot           PredicateKind = "not"
+	PredicateBeforeAbs     PredicateKind = "before_absolute_time"
+	PredicateBeforeRel     PredicateKind = "before_relative_time"
+)
+
+// ClaimPredicate is a decoded claimant predicate tree.
+type ClaimPredicate struct {
+	Kind PredicateKind
+	// AbsBefore is set for PredicateBeforeAbs.
+	AbsBefore time.Time
+	// RelBefore is set for PredicateBeforeRel, relative to balance creation.
+	RelBefore time.Duration
+	// Children holds the operands of and/or, or the single operand of not.
+	Children []ClaimPredicate
+}
+
+// Satisfied reports whether the predicate holds at time at for a balance
+// created at createdAt. createdAt is only consulted by relative predicates.
+func (p ClaimPredicate) Satisfied(at, createdAt time.Time) bool {
+	switch p.Kind {
+	case PredicateUnconditional:
+		return true
+	case PredicateBeforeAbs:
+		return at.Before(p.AbsBefore)
+	case PredicateBeforeRel:
+		return at.Before(createdAt.Add(p.RelBefore))
+	case PredicateNot:
+		return len(p.Children) == 1 && !p.Children[0].Satisfied(at, createdAt)
+	case PredicateAnd:
+		for _, c := range p.Children {
+			if !c.Satisfied(at, createdAt) {
+				return false
+			}
+		}
+		return len(p.Children) > 0
+	case PredicateOr:
+		for _, c := range p.Children {
+			if c.Satisfied(at, createdAt) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// String renders the predicate in a compact human-readable form.
+func (p ClaimPredicate) String() string {
+	switch p.Kind {
+	case PredicateBeforeAbs:
+		return "before(" + p.AbsBefore.UTC().Format(time.RFC3339) + ")"
+	case PredicateBeforeRel:
+		return "before(+" + p.RelBefore.String() + ")"
+	case PredicateNot, PredicateAnd, PredicateOr:
+		parts := make([]string, 0, len(p.Children))
+		for _, c := range p.Children {
+			parts = append(parts, c.String())
+		}
+		return string(p.Kind) + "(" + strings.Join(parts, ", ") + ")"
+	}
+	return string(p.Kind)
+}
+
+// decodeClaimPredicate converts an XDR predicate into its decoded form.
+func decodeClaimPredicate(p xdr.ClaimPredicate) (ClaimPredicate, error) {
+	switch p.Type {
+	case xdr.ClaimPredicateTypeClaimPredicateUnconditional:
+		return ClaimPredicate{Kind: PredicateUnconditional}, nil
+	case xdr.ClaimPredicateTypeClaimPredicateBeforeAbsoluteTime:
+		return ClaimPredicate{Kind: PredicateBeforeAbs, AbsBefore: time.Unix(int64(p.MustAbsBefore()), 0).UTC()}, nil
+	case xdr.ClaimPredicateTypeClaimPredicateBeforeRelativeTime:
+		return ClaimPredicate{Kind: PredicateBeforeRel, RelBefore: time.Duration(p.MustRelBefore()) * time.Second}, nil
+	case xdr.ClaimPredicateTypeClaimPredicateNot:
+		inner := p.MustNotPredicate()
+		if inner == nil {
+			return ClaimPredicate{}, fmt.Errorf("not predicate without operand")
+		}
+		child, err := decodeClaimPredicate(*inner)
+		if err != nil {
+			return ClaimPredicate{}, err
+		}
+		return ClaimPredicate{Kind: PredicateNot, Children: []ClaimPredicate{child}}, nil
+	case xdr.ClaimPredicateTypeClaimPredicateAnd:
+		children, err := decodeClaimPredicates(p.MustAndPredicates())
+		return ClaimPredicate{Kind: PredicateAnd, Children: children}, err
+	case xdr.ClaimPredicateTypeClaimPredicateOr:
+		children, err := decodeClaimPredicates(p.MustOrPredicates())
+		return ClaimPredicate{Kind: PredicateOr, Children: children}, err
+	}
+	return ClaimPredicate{}, fmt.Errorf("unknown claim predicate type %d", p.Type)
+}
+
+func decodeClaimPredicates(in []xdr.ClaimPredicate) ([]ClaimPredicate, error) {
+	out := make([]ClaimPredicate, 0, len(in))
+	for _, p := range in {
+		decoded, err := decodeClaimPredicate(p)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, decoded)
+	}
+	return out, nil
+}
+
+// BalanceClaimant is an account allowed to claim a balance, and when.
+type BalanceClaimant struct {
+	Destination string
+	Predicate   ClaimPredicate
+}
+
+// ClaimableBalance is a claimable balance with its predicates decoded.
+type ClaimableBalance struct {
+	ID                 string
+	Asset              txnbuild.Asset
+	Amount             string
+	Sponsor            string
+	LastModifiedLedger uint32
+	LastModifiedTime   *time.Time
+	Claimants          []BalanceClaimant
+	PagingToken        string
+}
+
+// ClaimableBy reports whether account may claim the balance at time at.
+// Relative predicates are evaluated against LastModifiedTime, which equals
+// the creation time for balances that have never been modified.
+func (b *ClaimableBalance) ClaimableBy(account string, at time.Time) bool {
+	var createdAt time.Time
+	if b.LastModifiedTime != nil {
+		createdAt = *b.LastModifiedTime
+	}
+	for _, c := range b.Claimants {
+		if c.Destination == account && c.Predicate.Satisfied(at, createdAt) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClaimableBalances returns the claimable balances matching filter,
+// following pagination until exhausted or filter.MaxRecords is reached.
+func (c *Client) ClaimableBalances(ctx context.Context, filter ClaimableBalanceFilter) ([]ClaimableBalance, error) {
+	q := url.Values{}
+	if filter.Claimant != "" {
+		q.Set("claimant", filter.Claimant)
+	}
+	if filter.Sponsor != "" {
+		q.Set("sponsor", filter.Sponsor)
+	}
+	if filter.Asset != nil {
+		if _, _, _, err := horizonAssetFields("filter", filter.Asset); err != nil {
+			return nil, err
+		}
+		q.Set("asset", canonicalAssetList([]txnbuild.Asset{filter.Asset}))
+	}
+	pageSize := normalizePageSize(filter.PageSize)
+	q.Set("limit", strconv.Itoa(pageSize))
+
+	c.logContext(ctx, LogSubsystemRPC).Debug("Fetching claimable balances", "claimant", filter.Claimant, "sponsor", filter.Sponsor)
+
+	var out []ClaimableBalance
+	for {
+		var page hProtocol.ClaimableBalances
+		if err := c.getHorizon(ctx, "/claimable_balances", q, &page); err != nil {
+			return nil, err
+		}
+		for _, record := range page.Embedded.Records {
+			balance, err := decodeClaimableBalance(record)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, balance)
+			if filter.MaxRecords > 0 && len(out) >= filter.MaxRecords {
+				return out, nil
+			}
+		}
+		records := page.Embedded.Records
+		if len(records) < pageSize {
+			break
+		}
+		q.Set("cursor", records[len(records)-1].PT)
+	}
+
+	c.logContext(ctx, LogSubsystemRPC).Debug("Claimable balances fetched", "count", len(out))
+	return out, nil
+}
+
+// ClaimableBalance fetches a single claimable balance by its ID.
+func (c *Client) ClaimableBalance(ctx context.Context, id string) (*ClaimableBalance, error) {
+	if id == "" {
+		return nil, errors.WrapValidationError("claimable balance id is required")
+	}
+
+	var record hProtocol.ClaimableBalance
+	if err := c.getHorizon(ctx, "/claimable_balances/"+url.PathEscape(id), nil, &record); err != nil {
+		return nil, err
+	}
+	balance, err := decodeClaimableBalance(record)
+	if err != nil {
+		return nil, err
+	}
+	return &balance, nil
+}
+
+func decodeClaimableBalance(record hProtocol.ClaimableBalance) (ClaimableBalance, error) {
+	claimants := make([]BalanceClaimant, 0, len(record.Claimants))
+	for _, cl := range record.Claimants {
+		predicate, err := decodeClaimPredicate(cl.Predicate)
+		if err != nil {
+			return ClaimableBalance{}, errors.WrapUnmarshalFailed(err, "claimable balance "+record.BalanceID)
+		}
+		claimants = append(claimants, BalanceClaimant{Destination: cl.Destination, Predicate: predicate})
+	}
+	return ClaimableBalance{
+		ID:                 record.BalanceID,
+		Asset:              assetFromCanonical(record.Asset),
+		Amount:             record.Amount,
+		Sponsor:            record.Sponsor,
+		LastModifiedLedger: record.LastModifiedLedger,
+		LastModifiedTime:   record.LastModifiedTime,
+		Claimants:          claimants,
+		PagingToken:        record.PT,
+	}, nil
+}
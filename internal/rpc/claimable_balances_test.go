@@ -0,0 +1,103 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testClaimant = "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+
+const testBalanceJSON = `{
+	"id": "00000000abc",
+	"asset": "USDC:` + testIssuer + `",
+	"amount": "25.0000000",
+	"sponsor": "` + testIssuer + `",
+	"last_modified_ledger": 100,
+	"last_modified_time": "2024-01-01T00:00:00Z",
+	"paging_token": "100-00000000abc",
+	"claimants": [
+		{"destination": "` + testClaimant + `", "predicate": {"and": [
+			{"not": {"abs_before": "2024-01-02T00:00:00Z", "abs_before_epoch": "1704153600"}},
+			{"rel_before": "172800"}
+		]}},
+		{"destination": "` + testIssuer + `", "predicate": {"unconditional": true}}
+	]
+}`
+
+func TestClaimableBalances(t *testing.T) {
+	client := newHorizonTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/claimable_balances", r.URL.Path)
+		q := r.URL.Query()
+		assert.Equal(t, testClaimant, q.Get("claimant"))
+		assert.Equal(t, "USDC:"+testIssuer, q.Get("asset"))
+		w.Write([]byte(`{"_embedded":{"records":[` + testBalanceJSON + `]}}`))
+	})
+
+	balances, err := client.ClaimableBalances(context.Background(), ClaimableBalanceFilter{
+		Claimant: testClaimant,
+		Asset:    txnbuild.CreditAsset{Code: "USDC", Issuer: testIssuer},
+	})
+	require.NoError(t, err)
+	require.Len(t, balances, 1)
+
+	b := balances[0]
+	assert.Equal(t, "00000000abc", b.ID)
+	assert.Equal(t, "USDC", b.Asset.GetCode())
+	require.Len(t, b.Claimants, 2)
+
+	pred := b.Claimants[0].Predicate
+	assert.Equal(t, PredicateAnd, pred.Kind)
+	require.Len(t, pred.Children, 2)
+	assert.Equal(t, PredicateNot, pred.Children[0].Kind)
+	assert.Equal(t, 48*time.Hour, pred.Children[1].RelBefore)
+
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.False(t, b.ClaimableBy(testClaimant, created.Add(time.Hour)), "before the not-before window")
+	assert.True(t, b.ClaimableBy(testClaimant, created.Add(36*time.Hour)))
+	assert.False(t, b.ClaimableBy(testClaimant, created.Add(72*time.Hour)), "after relative expiry")
+	assert.True(t, b.ClaimableBy(testIssuer, created.Add(72*time.Hour)))
+}
+
+func TestClaimableBalances_Paging(t *testing.T) {
+	calls := 0
+	client := newHorizonTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			assert.Empty(t, r.URL.Query().Get("cursor"))
+			w.Write([]byte(`{"_embedded":{"records":[` + testBalanceJSON + `]}}`))
+			return
+		}
+		assert.Equal(t, "100-00000000abc", r.URL.Query().Get("cursor"))
+		w.Write([]byte(`{"_embedded":{"records":[]}}`))
+	})
+
+	balances, err := client.ClaimableBalances(context.Background(), ClaimableBalanceFilter{Sponsor: testIssuer, PageSize: 1})
+	require.NoError(t, err)
+	assert.Len(t, balances, 1)
+	assert.Equal(t, 2, calls)
+}
+
+func TestClaimableBalance(t *testing.T) {
+	client := newHorizonTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/claimable_balances/00000000abc", r.URL.Path)
+		w.Write([]byte(testBalanceJSON))
+	})
+
+	b, err := client.ClaimableBalance(context.Background(), "00000000abc")
+	require.NoError(t, err)
+	assert.Equal(t, "25.0000000", b.Amount)
+	assert.Equal(t, "and(not(before(2024-01-02T00:00:00Z)), before(+48h0m0s))", b.Claimants[0].Predicate.String())
+
+	_, err = client.ClaimableBalance(context.Background(), "")
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+}
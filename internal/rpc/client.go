@@ -15,11 +15,13 @@ import (
 	"time"
 
 	"github.com/dotandev/hintents/internal/logger"
+	"github.com/dotandev/hintents/internal/rpc/audit"
+	"github.com/dotandev/hintents/internal/rpc/metrics"
 
 	"github.com/dotandev/hintents/internal/telemetry"
+	"github.com/dotandev/hintents/internal/timing"
 	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
 	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
-	effects "github.com/stellar/go-stellar-sdk/protocols/horizon/effects"
 	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/dotandev/hintents/internal/errors"
@@ -104,21 +106,48 @@ var (
 
 // Client handles interactions with the Stellar Network
 type Client struct {
-	Horizon      horizonclient.ClientInterface
-	HorizonURL   string
-	Network      Network
-	SorobanURL   string
-	AltURLs      []string
-	currIndex    int
-	mu           sync.RWMutex
-	httpClient   *http.Client
-	token        string // stored for reference, not logged
+	Horizon    horizonclient.ClientInterface
+	HorizonURL string
+	Network    Network
+	SorobanURL string
+	AltURLs    []string
+	currIndex  int
+	mu         sync.RWMutex
+	httpClient *http.Client
+	token      string // stored for reference, not logged
 	// headers that will be attached to each HTTP request
 	Headers      map[string]string
 	Config       NetworkConfig
 	CacheEnabled bool
+	// SimulationCache, when set, short-circuits repeated simulations of the
+	// same envelope within a ledger.
+	SimulationCache *SimulationCache
+	// StreamConfig controls reconnection of Horizon SSE streams.
+	StreamConfig StreamConfig
 	failures     map[string]int
 	lastFailure  map[string]time.Time
+	lastSuccess  map[string]time.Time
+	feeStats     feeStatsCache
+	ledgers      ledgerHub
+	metrics      *metrics.Collector
+	submissions  submissionSpans
+	logs         *clientLoggers
+	audit        audit.Log
+	onCacheEvent func(CacheEvent)
+	// contractErrors names contract error codes; see WithContractErrorResolver.
+	contractErrors ContractErrorResolver
+	vars           *expvarSet
+	hooks          *clientHooks
+	faults         *FaultTransport
+}
+
+// InjectedFaults returns how many faults WithFaultInjection has injected
+// so far; it is zero when fault injection is off.
+func (c *Client) InjectedFaults() FaultCounts {
+	if c.faults == nil {
+		return FaultCounts{}
+	}
+	return c.faults.Injected()
 }
 
 // NodeFailure records a failure for a specific RPC URL
@@ -127,19 +156,67 @@ type NodeFailure struct {
 	Reason error
 }
 
-// AllNodesFailedError represents a failure after exhausting all RPC endpoints
+// AllNodesFailedError represents a failure after exhausting all RPC endpoints.
+// Its message names each endpoint with a short reason, such as
+// "a.example: HTTP 429 Too Many Requests"; Failures and errors.As give the
+// full errors.
 type AllNodesFailedError struct {
 	Failures []NodeFailure
 }
 
 func (e *AllNodesFailedError) Error() string {
-	var reasons []string
+	if len(e.Failures) == 0 {
+		return "all RPC endpoints failed: no endpoints configured"
+	}
+	// Endpoints retried with the same outcome are listed once.
+	var order []string
+	counts := map[string]int{}
 	for _, f := range e.Failures {
-		reasons = append(reasons, fmt.Sprintf("%s: %v", f.URL, f.Reason))
+		line := endpointLabel(f.URL) + ": " + f.Summary()
+		if counts[line] == 0 {
+			order = append(order, line)
+		}
+		counts[line]++
+	}
+	reasons := make([]string, len(order))
+	for i, line := range order {
+		reasons[i] = line
+		if n := counts[line]; n > 1 {
+			reasons[i] += fmt.Sprintf(" (%d attempts)", n)
+		}
 	}
 	return fmt.Sprintf("all RPC endpoints failed: [%s]", strings.Join(reasons, ", "))
 }
 
+// Is matches errors.ErrAllEndpointsDown.
+func (e *AllNodesFailedError) Is(target error) bool {
+	return target == errors.ErrAllEndpointsDown
+}
+
+// Unwrap exposes each node's failure, a *RequestError for nodes that were
+// tried, to errors.Is and errors.As.
+func (e *AllNodesFailedError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		errs = append(errs, f.Reason)
+	}
+	return errs
+}
+
+// Category is that of the first retryable node failure, so the call is worth
+// repeating if any node may recover, and otherwise that of the first failure.
+func (e *AllNodesFailedError) Category() errors.Category {
+	for _, f := range e.Failures {
+		if c := errors.CategoryOf(f.Reason); c.Retryable() {
+			return c
+		}
+	}
+	if len(e.Failures) == 0 {
+		return errors.CategoryNetwork
+	}
+	return errors.CategoryOf(e.Failures[0].Reason)
+}
+
 // isHealthy checks if an endpoint is currently healthy or if circuit is open.
 // This is a best-effort check — there is an intentional TOCTOU window between
 // this call and the subsequent http.Do; no lock is held across both operations
@@ -183,7 +260,11 @@ func (c *Client) markSuccess(url string) {
 	if c.failures == nil {
 		c.failures = make(map[string]int)
 	}
+	if c.lastSuccess == nil {
+		c.lastSuccess = make(map[string]time.Time)
+	}
 	c.failures[url] = 0
+	c.lastSuccess[url] = time.Now()
 }
 
 // NewClientDefault creates a new RPC client with sensible defaults
@@ -242,7 +323,10 @@ func (c *Client) rotateURL() bool {
 		}
 	}
 
+	previous := c.HorizonURL
 	c.HorizonURL = c.AltURLs[c.currIndex]
+	c.metrics.ObserveFailover(endpointLabel(previous), endpointLabel(c.HorizonURL))
+	c.vars.observeFailover(endpointLabel(previous), endpointLabel(c.HorizonURL))
 	httpClient := c.httpClient
 	if httpClient == nil {
 		httpClient = createHTTPClient(c.token, c.Headers, defaultHTTPTimeout)
@@ -252,7 +336,7 @@ func (c *Client) rotateURL() bool {
 		HTTP:       httpClient,
 	}
 
-	logger.Logger.Warn("RPC failover triggered", "new_url", c.HorizonURL)
+	c.log(LogSubsystemFailover).Warn("RPC failover triggered", "new_url", c.HorizonURL)
 	return true
 }
 
@@ -266,9 +350,12 @@ func (c *Client) getHTTPClient() *http.Client {
 // createHTTPClient creates an HTTP client with optional authentication headers and a configurable timeout.
 // `headers` is a map of arbitrary string headers that will be added on every request.
 func createHTTPClient(token string, headers map[string]string, timeout time.Duration) *http.Client {
-	cfg := DefaultRetryConfig()
+	return newHTTPClient(http.DefaultTransport, token, headers, timeout)
+}
 
-	var baseTransport http.RoundTripper = http.DefaultTransport
+// newHTTPClient is createHTTPClient over baseTransport.
+func newHTTPClient(baseTransport http.RoundTripper, token string, headers map[string]string, timeout time.Duration) *http.Client {
+	cfg := DefaultRetryConfig()
 
 	var transport http.RoundTripper = baseTransport
 	if token != "" || len(headers) > 0 {
@@ -343,7 +430,11 @@ func (c *Client) GetTransaction(ctx context.Context, hash string) (*TransactionR
 	}
 	var failures []NodeFailure
 	for attempt := 0; attempt < len(c.AltURLs); attempt++ {
-		resp, err := c.getTransactionAttempt(ctx, hash)
+		hopStart := time.Now()
+		hopCtx, span := startFailoverHop(ctx, "get_transaction", attempt, c.HorizonURL)
+		resp, err := c.getTransactionAttempt(hopCtx, hash)
+		endHop(ctx, span, hopStart, err)
+		err = c.requestError(ctx, "get_transaction", c.HorizonURL, attempt, hopStart, err)
 		if err == nil {
 			c.markSuccess(c.HorizonURL)
 			return resp, nil
@@ -355,8 +446,8 @@ func (c *Client) GetTransaction(ctx context.Context, hash string) (*TransactionR
 
 		// Only rotate if this isn't the last possible URL
 		if attempt < len(c.AltURLs)-1 {
-			logger.Logger.Warn("Retrying with fallback RPC...", "error", err)
-			if !c.rotateURL() {
+			c.logContext(ctx, LogSubsystemFailover).Warn("Retrying with fallback RPC...", "error", err)
+			if !c.failover("get_transaction", attempt, err, time.Since(hopStart)) {
 				break
 			}
 		}
@@ -374,7 +465,7 @@ func (c *Client) getTransactionAttempt(ctx context.Context, hash string) (*Trans
 	)
 	defer span.End()
 
-	logger.Logger.Debug("Fetching transaction details", "hash", hash, "url", c.HorizonURL)
+	c.logContext(ctx, LogSubsystemRPC).Debug("Fetching transaction details", "hash", hash, "url", c.HorizonURL)
 
 	// Fail fast if circuit breaker is open for this Horizon endpoint.
 	if !c.isHealthy(c.HorizonURL) {
@@ -386,7 +477,7 @@ func (c *Client) getTransactionAttempt(ctx context.Context, hash string) (*Trans
 	tx, err := c.Horizon.TransactionDetail(hash)
 	if err != nil {
 		span.RecordError(err)
-		logger.Logger.Error("Failed to fetch transaction", "hash", hash, "error", err, "url", c.HorizonURL)
+		c.logContext(ctx, LogSubsystemRPC).Error("Failed to fetch transaction", "hash", hash, "error", err, "url", c.HorizonURL)
 		return nil, errors.WrapRPCConnectionFailed(err)
 	}
 
@@ -396,7 +487,7 @@ func (c *Client) getTransactionAttempt(ctx context.Context, hash string) (*Trans
 		attribute.Int("result_meta.size_bytes", len(tx.ResultMetaXdr)),
 	)
 
-	logger.Logger.Info("Transaction fetched", "hash", hash, "envelope_size", len(tx.EnvelopeXdr), "url", c.HorizonURL)
+	c.logContext(ctx, LogSubsystemRPC).Info("Transaction fetched", "hash", hash, "envelope_size", len(tx.EnvelopeXdr), "url", c.HorizonURL)
 
 	return ParseTransactionResponse(tx), nil
 }
@@ -421,17 +512,20 @@ type GetLedgerEntriesRequest struct {
 	Params  []interface{} `json:"params"`
 }
 
+// LedgerEntryResult is a single entry returned by getLedgerEntries.
+type LedgerEntryResult struct {
+	Key                string `json:"key"`
+	Xdr                string `json:"xdr"`
+	LastModifiedLedger int    `json:"lastModifiedLedgerSeq"`
+	LiveUntilLedger    int    `json:"liveUntilLedgerSeq"`
+}
+
 type GetLedgerEntriesResponse struct {
 	Jsonrpc string `json:"jsonrpc"`
 	ID      int    `json:"id"`
 	Result  struct {
-		Entries []struct {
-			Key                string `json:"key"`
-			Xdr                string `json:"xdr"`
-			LastModifiedLedger int    `json:"lastModifiedLedgerSeq"`
-			LiveUntilLedger    int    `json:"liveUntilLedgerSeq"`
-		} `json:"entries"`
-		LatestLedger int `json:"latestLedger"`
+		Entries      []LedgerEntryResult `json:"entries"`
+		LatestLedger int                 `json:"latestLedger"`
 	} `json:"result"`
 	Error *struct {
 		Code    int    `json:"code"`
@@ -468,7 +562,11 @@ func (c *Client) GetLedgerHeader(ctx context.Context, sequence uint32) (*LedgerH
 	}
 	var failures []NodeFailure
 	for attempt := 0; attempt < len(c.AltURLs); attempt++ {
-		resp, err := c.getLedgerHeaderAttempt(ctx, sequence)
+		hopStart := time.Now()
+		hopCtx, span := startFailoverHop(ctx, "get_ledger_header", attempt, c.HorizonURL)
+		resp, err := c.getLedgerHeaderAttempt(hopCtx, sequence)
+		endHop(ctx, span, hopStart, err)
+		err = c.requestError(ctx, "get_ledger_header", c.HorizonURL, attempt, hopStart, err)
 		if err == nil {
 			c.markSuccess(c.HorizonURL)
 			return resp, nil
@@ -479,8 +577,8 @@ func (c *Client) GetLedgerHeader(ctx context.Context, sequence uint32) (*LedgerH
 		failures = append(failures, NodeFailure{URL: c.HorizonURL, Reason: err})
 
 		if attempt < len(c.AltURLs)-1 {
-			logger.Logger.Warn("Retrying ledger header fetch with fallback RPC...", "error", err)
-			if !c.rotateURL() {
+			c.logContext(ctx, LogSubsystemFailover).Warn("Retrying ledger header fetch with fallback RPC...", "error", err)
+			if !c.failover("get_ledger_header", attempt, err, time.Since(hopStart)) {
 				break
 			}
 		}
@@ -498,7 +596,7 @@ func (c *Client) getLedgerHeaderAttempt(ctx context.Context, sequence uint32) (*
 	)
 	defer span.End()
 
-	logger.Logger.Debug("Fetching ledger header", "sequence", sequence, "network", c.Network, "url", c.HorizonURL)
+	c.logContext(ctx, LogSubsystemRPC).Debug("Fetching ledger header", "sequence", sequence, "network", c.Network, "url", c.HorizonURL)
 
 	// Fail fast if circuit breaker is open for this Horizon endpoint.
 	if !c.isHealthy(c.HorizonURL) {
@@ -511,7 +609,7 @@ func (c *Client) getLedgerHeaderAttempt(ctx context.Context, sequence uint32) (*
 	ledger, err := c.Horizon.LedgerDetail(sequence)
 	if err != nil {
 		span.RecordError(err)
-		return nil, c.handleLedgerError(err, sequence)
+		return nil, c.handleLedgerError(ctx, err, sequence)
 	}
 
 	response := FromHorizonLedger(ledger)
@@ -522,7 +620,7 @@ func (c *Client) getLedgerHeaderAttempt(ctx context.Context, sequence uint32) (*
 		attribute.Int("ledger.tx_count", int(response.SuccessfulTxCount+response.FailedTxCount)),
 	)
 
-	logger.Logger.Info("Ledger header fetched successfully",
+	c.logContext(ctx, LogSubsystemRPC).Info("Ledger header fetched successfully",
 		"sequence", sequence,
 		"hash", response.Hash,
 		"url", c.HorizonURL,
@@ -532,30 +630,30 @@ func (c *Client) getLedgerHeaderAttempt(ctx context.Context, sequence uint32) (*
 }
 
 // handleLedgerError provides detailed error messages for ledger fetch failures
-func (c *Client) handleLedgerError(err error, sequence uint32) error {
+func (c *Client) handleLedgerError(ctx context.Context, err error, sequence uint32) error {
 	// Check if it's a Horizon error
 	if hErr, ok := err.(*horizonclient.Error); ok {
 		switch hErr.Problem.Status {
 		case 404:
-			logger.Logger.Warn("Ledger not found", "sequence", sequence, "status", 404)
+			c.logContext(ctx, LogSubsystemRPC).Warn("Ledger not found", "sequence", sequence, "status", 404)
 			return errors.WrapLedgerNotFound(sequence)
 		case 410:
-			logger.Logger.Warn("Ledger archived", "sequence", sequence, "status", 410)
+			c.logContext(ctx, LogSubsystemRPC).Warn("Ledger archived", "sequence", sequence, "status", 410)
 			return errors.WrapLedgerArchived(sequence)
 		case 413:
-			logger.Logger.Warn("Response too large", "sequence", sequence, "status", 413)
+			c.logContext(ctx, LogSubsystemRPC).Warn("Response too large", "sequence", sequence, "status", 413)
 			return errors.WrapRPCResponseTooLarge(c.HorizonURL)
 		case 429:
-			logger.Logger.Warn("Rate limit exceeded", "sequence", sequence, "status", 429)
+			c.logContext(ctx, LogSubsystemRPC).Warn("Rate limit exceeded", "sequence", sequence, "status", 429)
 			return errors.WrapRateLimitExceeded()
 		default:
-			logger.Logger.Error("Horizon error", "sequence", sequence, "status", hErr.Problem.Status, "detail", hErr.Problem.Detail)
+			c.logContext(ctx, LogSubsystemRPC).Error("Horizon error", "sequence", sequence, "status", hErr.Problem.Status, "detail", hErr.Problem.Detail)
 			return errors.WrapRPCError(c.HorizonURL, hErr.Problem.Detail, hErr.Problem.Status)
 		}
 	}
 
 	// Generic error
-	logger.Logger.Error("Failed to fetch ledger", "sequence", sequence, "error", err)
+	c.logContext(ctx, LogSubsystemRPC).Error("Failed to fetch ledger", "sequence", sequence, "error", err)
 	return errors.WrapRPCConnectionFailed(err)
 }
 
@@ -591,25 +689,36 @@ func (c *Client) GetLedgerEntries(ctx context.Context, keys []string) (map[strin
 
 	// Check cache if enabled
 	if c.CacheEnabled {
+		stopTiming := timing.FromContext(ctx).Start(timing.PhaseCache)
+		now := time.Now()
 		for _, key := range keys {
-			val, hit, err := Get(key)
+			entry, found, err := Lookup(key)
 			if err != nil {
-				logger.Logger.Warn("Cache read failed", "error", err)
+				c.logContext(ctx, LogSubsystemCache).Warn("Cache read failed", "error", err)
 			}
-			if hit {
-				entries[key] = val
-				logger.Logger.Debug("Cache hit", "key", key)
+			ev := CacheEvent{Cache: CacheLedgerEntries, Method: "getLedgerEntries", Key: key, Result: CacheResultMiss}
+			if found {
+				ev.Age, ev.TTL = now.Sub(entry.CreatedAt), entry.TTL
+				ev.Result = CacheResultStale
+				if now.Before(entry.ExpiresAt) {
+					ev.Result = CacheResultHit
+				}
+			}
+			c.observeCache(ctx, ev)
+			if ev.Result == CacheResultHit {
+				entries[key] = entry.Value
 			} else {
 				keysToFetch = append(keysToFetch, key)
 			}
 		}
+		stopTiming()
 	} else {
 		keysToFetch = keys
 	}
 
 	// If all keys found in cache, return immediately
 	if len(keysToFetch) == 0 {
-		logger.Logger.Info("All ledger entries found in cache", "count", len(keys))
+		c.logContext(ctx, LogSubsystemCache).Info("All ledger entries found in cache", "count", len(keys))
 		return entries, nil
 	}
 
@@ -617,10 +726,14 @@ func (c *Client) GetLedgerEntries(ctx context.Context, keys []string) (map[strin
 		return nil, &AllNodesFailedError{}
 	}
 
-	logger.Logger.Debug("Fetching ledger entries from RPC", "count", len(keysToFetch), "url", c.SorobanURL)
+	c.logContext(ctx, LogSubsystemRPC).Debug("Fetching ledger entries from RPC", "count", len(keysToFetch), "url", c.SorobanURL)
 	var failures []NodeFailure
 	for attempt := 0; attempt < len(c.AltURLs); attempt++ {
-		res, err := c.getLedgerEntriesAttempt(ctx, keysToFetch)
+		hopStart := time.Now()
+		hopCtx, span := startFailoverHop(ctx, "getLedgerEntries", attempt, c.SorobanURL)
+		res, err := c.getLedgerEntriesAttempt(hopCtx, keysToFetch)
+		endHop(ctx, span, hopStart, err)
+		err = c.requestError(ctx, "getLedgerEntries", c.SorobanURL, attempt, hopStart, err)
 		if err == nil {
 			c.markSuccess(c.SorobanURL)
 			// Merge with cached results
@@ -634,8 +747,8 @@ func (c *Client) GetLedgerEntries(ctx context.Context, keys []string) (map[strin
 		failures = append(failures, NodeFailure{URL: c.SorobanURL, Reason: err})
 
 		if attempt < len(c.AltURLs)-1 {
-			logger.Logger.Warn("Retrying with fallback Soroban RPC...", "error", err)
-			if !c.rotateURL() {
+			c.logContext(ctx, LogSubsystemFailover).Warn("Retrying with fallback Soroban RPC...", "error", err)
+			if !c.failover("getLedgerEntries", attempt, err, time.Since(hopStart)) {
 				break
 			}
 			continue
@@ -659,7 +772,7 @@ func (c *Client) getLedgerEntriesAttempt(ctx context.Context, keysToFetch []stri
 		}
 	}
 
-	logger.Logger.Debug("Fetching ledger entries", "count", len(keysToFetch), "url", targetURL)
+	c.logContext(ctx, LogSubsystemRPC).Debug("Fetching ledger entries", "count", len(keysToFetch), "url", targetURL)
 
 	// Fail fast if circuit breaker is open for this Soroban endpoint.
 	if !c.isHealthy(targetURL) {
@@ -719,7 +832,7 @@ func (c *Client) getLedgerEntriesAttempt(ctx context.Context, keysToFetch []stri
 		// Cache the new entry
 		if c.CacheEnabled {
 			if err := Set(entry.Key, entry.Xdr); err != nil {
-				logger.Logger.Warn("Failed to cache entry", "key", entry.Key, "error", err)
+				c.logContext(ctx, LogSubsystemCache).Warn("Failed to cache entry", "key", entry.Key, "error", err)
 			}
 		}
 	}
@@ -729,7 +842,7 @@ func (c *Client) getLedgerEntriesAttempt(ctx context.Context, keysToFetch []stri
 		return nil, fmt.Errorf("ledger entry verification failed: %w", err)
 	}
 
-	logger.Logger.Info("Ledger entries fetched",
+	c.logContext(ctx, LogSubsystemRPC).Info("Ledger entries fetched",
 		"total_requested", len(keysToFetch),
 		"from_cache", len(keysToFetch)-fetchedCount,
 		"from_rpc", fetchedCount,
@@ -743,6 +856,7 @@ type TransactionSummary struct {
 	Hash      string
 	Status    string
 	CreatedAt string
+	Source    MuxedAddress
 }
 
 type AccountSummary struct {
@@ -757,29 +871,14 @@ type EventSummary struct {
 }
 
 func (c *Client) GetAccountTransactions(ctx context.Context, account string, limit int) ([]TransactionSummary, error) {
-	logger.Logger.Debug("Fetching account transactions", "account", account)
+	c.logContext(ctx, LogSubsystemRPC).Debug("Fetching account transactions", "account", account)
 
-	pageSize := normalizePageSize(limit)
-	req := horizonclient.TransactionRequest{
-		ForAccount: account,
-		Limit:      uint(pageSize),
+	transactions, err := c.Transactions(ctx, horizonclient.TransactionRequest{
+		ForAccount: underlyingAccount(account),
 		Order:      horizonclient.OrderDesc,
-	}
-
-	transactions, err := pageIterator[hProtocol.TransactionsPage, hProtocol.Transaction]{
-		first: func() (hProtocol.TransactionsPage, error) {
-			return c.Horizon.Transactions(req)
-		},
-		next: func(page hProtocol.TransactionsPage) (hProtocol.TransactionsPage, error) {
-			return c.Horizon.NextTransactionsPage(page)
-		},
-		records: func(page hProtocol.TransactionsPage) []hProtocol.Transaction {
-			return page.Embedded.Records
-		},
-		max: limit,
-	}.collect()
+	}, PageSize(limit), MaxRecords(limit)).Collect()
 	if err != nil {
-		logger.Logger.Error("Failed to fetch account transactions", "account", account, "error", err)
+		c.logContext(ctx, LogSubsystemRPC).Error("Failed to fetch account transactions", "account", account, "error", err)
 		return nil, errors.WrapRPCConnectionFailed(err)
 	}
 
@@ -789,38 +888,24 @@ func (c *Client) GetAccountTransactions(ctx context.Context, account string, lim
 			Hash:      tx.Hash,
 			Status:    getTransactionStatus(tx),
 			CreatedAt: tx.LedgerCloseTime.Format("2006-01-02 15:04:05"),
+			Source:    TransactionSource(tx),
 		})
 	}
 
-	logger.Logger.Debug("Account transactions retrieved", "count", len(summaries))
+	c.logContext(ctx, LogSubsystemRPC).Debug("Account transactions retrieved", "count", len(summaries))
 	return summaries, nil
 }
 
 // GetEventsForAccount fetches effects (treated as events) for an account using shared page iteration.
 func (c *Client) GetEventsForAccount(ctx context.Context, account string, limit int) ([]EventSummary, error) {
-	logger.Logger.Debug("Fetching account events", "account", account)
+	c.logContext(ctx, LogSubsystemRPC).Debug("Fetching account events", "account", account)
 
-	pageSize := normalizePageSize(limit)
-	req := horizonclient.EffectRequest{
-		ForAccount: account,
-		Limit:      uint(pageSize),
+	eventRecords, err := c.Effects(ctx, horizonclient.EffectRequest{
+		ForAccount: underlyingAccount(account),
 		Order:      horizonclient.OrderDesc,
-	}
-
-	eventRecords, err := pageIterator[effects.EffectsPage, effects.Effect]{
-		first: func() (effects.EffectsPage, error) {
-			return c.Horizon.Effects(req)
-		},
-		next: func(page effects.EffectsPage) (effects.EffectsPage, error) {
-			return c.Horizon.NextEffectsPage(page)
-		},
-		records: func(page effects.EffectsPage) []effects.Effect {
-			return page.Embedded.Records
-		},
-		max: limit,
-	}.collect()
+	}, PageSize(limit), MaxRecords(limit)).Collect()
 	if err != nil {
-		logger.Logger.Error("Failed to fetch account events", "account", account, "error", err)
+		c.logContext(ctx, LogSubsystemRPC).Error("Failed to fetch account events", "account", account, "error", err)
 		return nil, errors.WrapRPCConnectionFailed(err)
 	}
 
@@ -832,34 +917,19 @@ func (c *Client) GetEventsForAccount(ctx context.Context, account string, limit
 		})
 	}
 
-	logger.Logger.Debug("Account events retrieved", "count", len(out))
+	c.logContext(ctx, LogSubsystemRPC).Debug("Account events retrieved", "count", len(out))
 	return out, nil
 }
 
 // GetAccounts fetches account records using shared page iteration.
 func (c *Client) GetAccounts(ctx context.Context, limit int) ([]AccountSummary, error) {
-	logger.Logger.Debug("Fetching accounts")
+	c.logContext(ctx, LogSubsystemRPC).Debug("Fetching accounts")
 
-	pageSize := normalizePageSize(limit)
-	req := horizonclient.AccountsRequest{
-		Limit: uint(pageSize),
+	accountRecords, err := c.Accounts(ctx, horizonclient.AccountsRequest{
 		Order: horizonclient.OrderDesc,
-	}
-
-	accountRecords, err := pageIterator[hProtocol.AccountsPage, hProtocol.Account]{
-		first: func() (hProtocol.AccountsPage, error) {
-			return c.Horizon.Accounts(req)
-		},
-		next: func(page hProtocol.AccountsPage) (hProtocol.AccountsPage, error) {
-			return c.Horizon.NextAccountsPage(page)
-		},
-		records: func(page hProtocol.AccountsPage) []hProtocol.Account {
-			return page.Embedded.Records
-		},
-		max: limit,
-	}.collect()
+	}, PageSize(limit), MaxRecords(limit)).Collect()
 	if err != nil {
-		logger.Logger.Error("Failed to fetch accounts", "error", err)
+		c.logContext(ctx, LogSubsystemRPC).Error("Failed to fetch accounts", "error", err)
 		return nil, errors.WrapRPCConnectionFailed(err)
 	}
 
@@ -872,7 +942,7 @@ func (c *Client) GetAccounts(ctx context.Context, limit int) ([]AccountSummary,
 		})
 	}
 
-	logger.Logger.Debug("Accounts retrieved", "count", len(out))
+	c.logContext(ctx, LogSubsystemRPC).Debug("Accounts retrieved", "count", len(out))
 	return out, nil
 }
 
@@ -898,7 +968,23 @@ type SimulateTransactionResponse struct {
 		// We only need minimal pieces for fee/budget estimation.
 		MinResourceFee  string `json:"minResourceFee,omitempty"`
 		TransactionData string `json:"transactionData,omitempty"`
-		Cost            struct {
+		// Events holds base64-encoded DiagnosticEvent XDR emitted during simulation.
+		Events []string `json:"events,omitempty"`
+		// Error is set by the RPC when the simulated invocation itself failed.
+		Error        string `json:"error,omitempty"`
+		LatestLedger uint32 `json:"latestLedger,omitempty"`
+		// Results holds the return value and the base64 SorobanAuthorizationEntry
+		// XDR the invocation requires, one element per host function.
+		Results []struct {
+			Auth []string `json:"auth,omitempty"`
+			XDR  string   `json:"xdr,omitempty"`
+		} `json:"results,omitempty"`
+		// RestorePreamble is set when archived entries must be restored first.
+		RestorePreamble *struct {
+			MinResourceFee  string `json:"minResourceFee"`
+			TransactionData string `json:"transactionData"`
+		} `json:"restorePreamble,omitempty"`
+		Cost         struct {
 			CpuInsns  int64 `json:"cpuInsns,omitempty"`
 			MemBytes  int64 `json:"memBytes,omitempty"`
 			CpuInsns_ int64 `json:"cpu_insns,omitempty"`
@@ -916,11 +1002,34 @@ func (c *Client) SimulateTransaction(ctx context.Context, envelopeXdr string) (*
 	if len(c.AltURLs) == 0 {
 		return nil, &AllNodesFailedError{}
 	}
+	if c.SimulationCache != nil {
+		stopTiming := timing.FromContext(ctx).Start(timing.PhaseCache)
+		key := HashEnvelope(envelopeXdr)
+		resp, result, age := c.SimulationCache.lookup(key)
+		stopTiming()
+		ev := CacheEvent{Cache: CacheSimulation, Method: "simulateTransaction", Key: key, Result: result}
+		if result != CacheResultMiss {
+			ev.Age, ev.TTL = age, c.SimulationCache.maxAge
+		}
+		c.observeCache(ctx, ev)
+		if result == CacheResultHit {
+			return resp, nil
+		}
+	}
 	var failures []NodeFailure
 	for attempt := 0; attempt < len(c.AltURLs); attempt++ {
-		resp, err := c.simulateTransactionAttempt(ctx, envelopeXdr)
+		hopStart := time.Now()
+		hopCtx, span := startFailoverHop(ctx, "simulateTransaction", attempt, c.SorobanURL)
+		resp, err := c.simulateTransactionAttempt(hopCtx, envelopeXdr)
+		endHop(ctx, span, hopStart, err)
+		err = c.requestError(ctx, "simulateTransaction", c.SorobanURL, attempt, hopStart, err)
 		if err == nil {
 			c.markSuccess(c.SorobanURL)
+			// Failed simulations are not cached: some are transient, and
+			// a cached failure would defeat SimulateTransactionWithRetry.
+			if c.SimulationCache != nil && resp.Result.Error == "" {
+				c.SimulationCache.Put(envelopeXdr, resp)
+			}
 			return resp, nil
 		}
 
@@ -929,8 +1038,8 @@ func (c *Client) SimulateTransaction(ctx context.Context, envelopeXdr string) (*
 		failures = append(failures, NodeFailure{URL: c.SorobanURL, Reason: err})
 
 		if attempt < len(c.AltURLs)-1 {
-			logger.Logger.Warn("Retrying transaction simulation with fallback RPC...", "error", err)
-			if !c.rotateURL() {
+			c.logContext(ctx, LogSubsystemFailover).Warn("Retrying transaction simulation with fallback RPC...", "error", err)
+			if !c.failover("simulateTransaction", attempt, err, time.Since(hopStart)) {
 				break
 			}
 		}
@@ -953,7 +1062,7 @@ func (c *Client) simulateTransactionAttempt(ctx context.Context, envelopeXdr str
 		}
 	}
 
-	logger.Logger.Debug("Simulating transaction (preflight)", "url", targetURL)
+	c.logContext(ctx, LogSubsystemRPC).Debug("Simulating transaction (preflight)", "url", targetURL)
 
 	// Fail fast if circuit breaker is open for this Soroban endpoint.
 	if !c.isHealthy(targetURL) {
@@ -1007,6 +1116,23 @@ func (c *Client) simulateTransactionAttempt(ctx context.Context, envelopeXdr str
 	return &rpcResp, nil
 }
 
+// observeLedger records a freshly seen ledger sequence for Health and feeds it
+// to the simulation cache.
+func (c *Client) observeLedger(seq uint32) {
+	if seq == 0 {
+		return
+	}
+	c.ledgers.mu.Lock()
+	if seq > c.ledgers.observed {
+		c.ledgers.observed = seq
+		c.ledgers.observedAt = time.Now()
+	}
+	c.ledgers.mu.Unlock()
+	if c.SimulationCache != nil {
+		c.SimulationCache.ObserveLedger(seq)
+	}
+}
+
 // GetHealth checks the health of the Soroban RPC endpoint.
 func (c *Client) GetHealth(ctx context.Context) (*GetHealthResponse, error) {
 	if len(c.AltURLs) == 0 {
@@ -1014,9 +1140,14 @@ func (c *Client) GetHealth(ctx context.Context) (*GetHealthResponse, error) {
 	}
 	var failures []NodeFailure
 	for attempt := 0; attempt < len(c.AltURLs); attempt++ {
-		resp, err := c.getHealthAttempt(ctx)
+		hopStart := time.Now()
+		hopCtx, span := startFailoverHop(ctx, "getHealth", attempt, c.SorobanURL)
+		resp, err := c.getHealthAttempt(hopCtx)
+		endHop(ctx, span, hopStart, err)
+		err = c.requestError(ctx, "getHealth", c.SorobanURL, attempt, hopStart, err)
 		if err == nil {
 			c.markSuccess(c.SorobanURL)
+			c.observeLedger(resp.Result.LatestLedger)
 			return resp, nil
 		}
 
@@ -1024,8 +1155,8 @@ func (c *Client) GetHealth(ctx context.Context) (*GetHealthResponse, error) {
 		failures = append(failures, NodeFailure{URL: c.SorobanURL, Reason: err})
 
 		if attempt < len(c.AltURLs)-1 {
-			logger.Logger.Warn("Retrying GetHealth with fallback RPC...", "error", err)
-			if !c.rotateURL() {
+			c.logContext(ctx, LogSubsystemFailover).Warn("Retrying GetHealth with fallback RPC...", "error", err)
+			if !c.failover("getHealth", attempt, err, time.Since(hopStart)) {
 				break
 			}
 			continue
@@ -1036,7 +1167,7 @@ func (c *Client) GetHealth(ctx context.Context) (*GetHealthResponse, error) {
 
 func (c *Client) getHealthAttempt(ctx context.Context) (*GetHealthResponse, error) {
 	targetURL := c.SorobanURL
-	logger.Logger.Debug("Checking Soroban RPC health", "url", targetURL)
+	c.logContext(ctx, LogSubsystemRPC).Debug("Checking Soroban RPC health", "url", targetURL)
 
 	// Fail fast if circuit breaker is open for this Soroban endpoint.
 	if !c.isHealthy(targetURL) {
@@ -1079,9 +1210,9 @@ func (c *Client) getHealthAttempt(ctx context.Context) (*GetHealthResponse, erro
 	}
 
 	if rpcResp.Error != nil {
-		return nil, errors.NewRPCError(errors.CodeRPCError, fmt.Errorf("rpc error from %s: %s (code %d)", targetURL, rpcResp.Error.Message, rpcResp.Error.Code))
+		return nil, errors.WrapRPCError(targetURL, rpcResp.Error.Message, rpcResp.Error.Code)
 	}
 
-	logger.Logger.Info("Soroban RPC health check successful", "url", targetURL, "status", rpcResp.Result.Status)
+	c.logContext(ctx, LogSubsystemRPC).Info("Soroban RPC health check successful", "url", targetURL, "status", rpcResp.Result.Status)
 	return &rpcResp, nil
-}
\ No newline at end of file
+}
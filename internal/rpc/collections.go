@@ -0,0 +1,153 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/effects"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/operations"
+)
+
+// Transactions returns an iterator over the transactions matching req.
+func (c *Client) Transactions(ctx context.Context, req horizonclient.TransactionRequest, opts ...IteratorOption) *Iterator[hProtocol.Transaction] {
+	o := resolveIteratorOptions(req.Limit, opts)
+	req.Limit = uint(o.pageSize)
+	return newIterator(ctx, pageIterator[hProtocol.TransactionsPage, hProtocol.Transaction]{
+		first: func() (hProtocol.TransactionsPage, error) { return c.Horizon.Transactions(req) },
+		next: func(page hProtocol.TransactionsPage) (hProtocol.TransactionsPage, error) {
+			return c.Horizon.NextTransactionsPage(page)
+		},
+		records: func(page hProtocol.TransactionsPage) []hProtocol.Transaction { return page.Embedded.Records },
+		max:     o.maxRecords,
+	})
+}
+
+// Operations returns an iterator over the operations matching req.
+func (c *Client) Operations(ctx context.Context, req horizonclient.OperationRequest, opts ...IteratorOption) *Iterator[operations.Operation] {
+	o := resolveIteratorOptions(req.Limit, opts)
+	req.Limit = uint(o.pageSize)
+	return newIterator(ctx, pageIterator[operations.OperationsPage, operations.Operation]{
+		first: func() (operations.OperationsPage, error) { return c.Horizon.Operations(req) },
+		next: func(page operations.OperationsPage) (operations.OperationsPage, error) {
+			return c.Horizon.NextOperationsPage(page)
+		},
+		records: func(page operations.OperationsPage) []operations.Operation { return page.Embedded.Records },
+		max:     o.maxRecords,
+	})
+}
+
+// Payments returns an iterator over the payment-like operations matching req.
+func (c *Client) Payments(ctx context.Context, req horizonclient.OperationRequest, opts ...IteratorOption) *Iterator[operations.Operation] {
+	o := resolveIteratorOptions(req.Limit, opts)
+	req.Limit = uint(o.pageSize)
+	return newIterator(ctx, pageIterator[operations.OperationsPage, operations.Operation]{
+		first: func() (operations.OperationsPage, error) { return c.Horizon.Payments(req) },
+		next: func(page operations.OperationsPage) (operations.OperationsPage, error) {
+			return c.Horizon.NextPaymentsPage(page)
+		},
+		records: func(page operations.OperationsPage) []operations.Operation { return page.Embedded.Records },
+		max:     o.maxRecords,
+	})
+}
+
+// Effects returns an iterator over the effects matching req.
+func (c *Client) Effects(ctx context.Context, req horizonclient.EffectRequest, opts ...IteratorOption) *Iterator[effects.Effect] {
+	o := resolveIteratorOptions(req.Limit, opts)
+	req.Limit = uint(o.pageSize)
+	return newIterator(ctx, pageIterator[effects.EffectsPage, effects.Effect]{
+		first: func() (effects.EffectsPage, error) { return c.Horizon.Effects(req) },
+		next: func(page effects.EffectsPage) (effects.EffectsPage, error) {
+			return c.Horizon.NextEffectsPage(page)
+		},
+		records: func(page effects.EffectsPage) []effects.Effect { return page.Embedded.Records },
+		max:     o.maxRecords,
+	})
+}
+
+// Accounts returns an iterator over the accounts matching req.
+func (c *Client) Accounts(ctx context.Context, req horizonclient.AccountsRequest, opts ...IteratorOption) *Iterator[hProtocol.Account] {
+	o := resolveIteratorOptions(req.Limit, opts)
+	req.Limit = uint(o.pageSize)
+	return newIterator(ctx, pageIterator[hProtocol.AccountsPage, hProtocol.Account]{
+		first: func() (hProtocol.AccountsPage, error) { return c.Horizon.Accounts(req) },
+		next: func(page hProtocol.AccountsPage) (hProtocol.AccountsPage, error) {
+			return c.Horizon.NextAccountsPage(page)
+		},
+		records: func(page hProtocol.AccountsPage) []hProtocol.Account { return page.Embedded.Records },
+		max:     o.maxRecords,
+	})
+}
+
+// Ledgers returns an iterator over the ledgers matching req.
+func (c *Client) Ledgers(ctx context.Context, req horizonclient.LedgerRequest, opts ...IteratorOption) *Iterator[hProtocol.Ledger] {
+	o := resolveIteratorOptions(req.Limit, opts)
+	req.Limit = uint(o.pageSize)
+	return newIterator(ctx, pageIterator[hProtocol.LedgersPage, hProtocol.Ledger]{
+		first: func() (hProtocol.LedgersPage, error) { return c.Horizon.Ledgers(req) },
+		next: func(page hProtocol.LedgersPage) (hProtocol.LedgersPage, error) {
+			return c.Horizon.NextLedgersPage(page)
+		},
+		records: func(page hProtocol.LedgersPage) []hProtocol.Ledger { return page.Embedded.Records },
+		max:     o.maxRecords,
+	})
+}
+
+// Assets returns an iterator over the asset statistics matching req.
+func (c *Client) Assets(ctx context.Context, req horizonclient.AssetRequest, opts ...IteratorOption) *Iterator[hProtocol.AssetStat] {
+	o := resolveIteratorOptions(req.Limit, opts)
+	req.Limit = uint(o.pageSize)
+	return newIterator(ctx, pageIterator[hProtocol.AssetsPage, hProtocol.AssetStat]{
+		first: func() (hProtocol.AssetsPage, error) { return c.Horizon.Assets(req) },
+		next: func(page hProtocol.AssetsPage) (hProtocol.AssetsPage, error) {
+			return c.Horizon.NextAssetsPage(page)
+		},
+		records: func(page hProtocol.AssetsPage) []hProtocol.AssetStat { return page.Embedded.Records },
+		max:     o.maxRecords,
+	})
+}
+
+// Offers returns an iterator over the offers matching req.
+func (c *Client) Offers(ctx context.Context, req horizonclient.OfferRequest, opts ...IteratorOption) *Iterator[hProtocol.Offer] {
+	o := resolveIteratorOptions(req.Limit, opts)
+	req.Limit = uint(o.pageSize)
+	return newIterator(ctx, pageIterator[hProtocol.OffersPage, hProtocol.Offer]{
+		first: func() (hProtocol.OffersPage, error) { return c.Horizon.Offers(req) },
+		next: func(page hProtocol.OffersPage) (hProtocol.OffersPage, error) {
+			return c.Horizon.NextOffersPage(page)
+		},
+		records: func(page hProtocol.OffersPage) []hProtocol.Offer { return page.Embedded.Records },
+		max:     o.maxRecords,
+	})
+}
+
+// Trades returns an iterator over the trades matching req.
+func (c *Client) Trades(ctx context.Context, req horizonclient.TradeRequest, opts ...IteratorOption) *Iterator[hProtocol.Trade] {
+	o := resolveIteratorOptions(req.Limit, opts)
+	req.Limit = uint(o.pageSize)
+	return newIterator(ctx, pageIterator[hProtocol.TradesPage, hProtocol.Trade]{
+		first: func() (hProtocol.TradesPage, error) { return c.Horizon.Trades(req) },
+		next: func(page hProtocol.TradesPage) (hProtocol.TradesPage, error) {
+			return c.Horizon.NextTradesPage(page)
+		},
+		records: func(page hProtocol.TradesPage) []hProtocol.Trade { return page.Embedded.Records },
+		max:     o.maxRecords,
+	})
+}
+
+// LiquidityPools returns an iterator over the liquidity pools matching req.
+func (c *Client) LiquidityPools(ctx context.Context, req horizonclient.LiquidityPoolsRequest, opts ...IteratorOption) *Iterator[hProtocol.LiquidityPool] {
+	o := resolveIteratorOptions(req.Limit, opts)
+	req.Limit = uint(o.pageSize)
+	return newIterator(ctx, pageIterator[hProtocol.LiquidityPoolsPage, hProtocol.LiquidityPool]{
+		first: func() (hProtocol.LiquidityPoolsPage, error) { return c.Horizon.LiquidityPools(req) },
+		next: func(page hProtocol.LiquidityPoolsPage) (hProtocol.LiquidityPoolsPage, error) {
+			return c.Horizon.NextLiquidityPoolsPage(page)
+		},
+		records: func(page hProtocol.LiquidityPoolsPage) []hProtocol.LiquidityPool { return page.Embedded.Records },
+		max:     o.maxRecords,
+	})
+}
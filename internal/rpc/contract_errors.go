@@ -0,0 +1,81 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/errors"
+)
+
+// maxContractErrorTrail bounds the diagnostic events kept on a
+// ContractError; the events closest to the failure are kept.
+const maxContractErrorTrail = 20
+
+// ContractErrorInfo is the spec entry for a contract error code.
+type ContractErrorInfo struct {
+	// Enum is the name of the error enum declaring the code.
+	Enum string
+	// Name is the case name, e.g. InsufficientBalance.
+	Name string
+	Doc  string
+}
+
+// ContractErrorResolver looks up a contract error code in the spec of the
+// contract that raised it. abi.EventRegistry implements it.
+type ContractErrorResolver interface {
+	ResolveContractError(ctx context.Context, contractID string, code uint32) (ContractErrorInfo, bool)
+}
+
+// ContractErrorFromEvents finds the first Error(Contract, #N) in a
+// diagnostic event trail and attributes it to the innermost contract call
+// active when it was raised. It returns nil when the events carry no
+// contract error, such as for host errors like running out of budget.
+func ContractErrorFromEvents(events []decoder.DiagnosticEvent) *errors.ContractError {
+	var stack []decoder.DiagnosticEvent
+	for i, e := range events {
+		switch e.Kind {
+		case decoder.DiagnosticKindFnCall:
+			stack = append(stack, e)
+		case decoder.DiagnosticKindFnReturn:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		case decoder.DiagnosticKindError:
+			if e.Error == nil || e.Error.ContractCode == nil {
+				continue
+			}
+			out := &errors.ContractError{ContractID: e.ContractID, Code: *e.Error.ContractCode}
+			if len(stack) > 0 {
+				call := stack[len(stack)-1]
+				out.ContractID, out.Function = call.ContractID, call.Function
+			}
+			start := 0
+			if i+1 > maxContractErrorTrail {
+				start = i + 1 - maxContractErrorTrail
+			}
+			for _, t := range events[start : i+1] {
+				out.Trail = append(out.Trail, t.String())
+			}
+			return out
+		}
+	}
+	return nil
+}
+
+// contractError extracts the contract error from events and, with
+// WithContractErrorResolver, names it from the contract's spec.
+func (c *Client) contractError(ctx context.Context, events []decoder.DiagnosticEvent) *errors.ContractError {
+	ce := ContractErrorFromEvents(events)
+	if ce == nil || ce.ContractID == "" || c.contractErrors == nil {
+		return ce
+	}
+	if info, ok := c.contractErrors.ResolveContractError(ctx, ce.ContractID, ce.Code); ok {
+		ce.Enum, ce.Name, ce.Doc = info.Enum, info.Name, info.Doc
+	} else {
+		c.logContext(ctx, LogSubsystemRPC).Debug("Contract error not in spec", "contract", ce.ContractID, "code", ce.Code)
+	}
+	return ce
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticErrorResolver map[uint32]ContractErrorInfo
+
+func (r staticErrorResolver) ResolveContractError(_ context.Context, _ string, code uint32) (ContractErrorInfo, bool) {
+	info, ok := r[code]
+	return info, ok
+}
+
+func TestContractErrorFromEvents(t *testing.T) {
+	code := uint32(5)
+	events := []decoder.DiagnosticEvent{
+		{Kind: decoder.DiagnosticKindFnCall, ContractID: "CROUTER", Function: "swap"},
+		{Kind: decoder.DiagnosticKindFnCall, ContractID: "CTOKEN", Function: "transfer"},
+		{Kind: decoder.DiagnosticKindLog, Data: `"balance too low"`},
+		{Kind: decoder.DiagnosticKindError, ContractID: "CTOKEN", Error: &decoder.HostError{Type: "Contract", ContractCode: &code}},
+		{Kind: decoder.DiagnosticKindError, Error: &decoder.HostError{Type: "Context", Code: "InvalidAction"}},
+	}
+
+	ce := ContractErrorFromEvents(events)
+	require.NotNil(t, ce)
+	assert.Equal(t, "CTOKEN", ce.ContractID)
+	assert.Equal(t, "transfer", ce.Function)
+	assert.Equal(t, uint32(5), ce.Code)
+	assert.Len(t, ce.Trail, 4)
+	assert.Equal(t, "contract CTOKEN function transfer failed: Error(Contract, #5)", ce.Error())
+
+	assert.Nil(t, ContractErrorFromEvents(events[4:]), "host errors are not contract errors")
+}
+
+func TestSimulateTransactionWithRetry_ResolvesContractError(t *testing.T) {
+	var raw xdr.DiagnosticEvent
+	require.NoError(t, xdr.SafeUnmarshalBase64(encodedErrorEvent(t, 3), &raw))
+	contractID := xdr.ContractId([32]byte{0x01})
+	raw.Event.ContractId = &contractID
+	event, err := xdr.MarshalBase64(raw)
+	require.NoError(t, err)
+
+	server, _ := newSimulateServer(t, func(call int32) map[string]interface{} {
+		return map[string]interface{}{"result": map[string]interface{}{
+			"error":  "HostError: Error(Contract, #3)",
+			"events": []string{event},
+		}}
+	})
+	defer server.Close()
+
+	client, err := NewClient(WithHorizonURL(server.URL), WithSorobanURL(server.URL),
+		WithContractErrorResolver(staticErrorResolver{3: {Enum: "Error", Name: "NotAllowed"}}))
+	require.NoError(t, err)
+	_, err = client.SimulateTransactionWithRetry(context.Background(), "AAAA", fastSimulateRetryConfig())
+
+	var ce *errs.ContractError
+	require.ErrorAs(t, err, &ce)
+	assert.Equal(t, uint32(3), ce.Code)
+	assert.Equal(t, strkey.MustEncode(strkey.VersionByteContract, contractID[:]), ce.ContractID)
+	assert.Equal(t, "Error::NotAllowed", ce.QualifiedName())
+	assert.ErrorIs(t, err, errs.ErrSimulationLogicError)
+	assert.Equal(t, errs.CategoryContractError, errs.CategoryOf(err))
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+)
+
+// DefaultCorrelationHeader is the header a correlation ID is sent in unless
+// WithCorrelationHeader names another.
+const DefaultCorrelationHeader = "X-Request-ID"
+
+type correlationIDKey struct{}
+
+// ContextWithCorrelationID returns a copy of ctx carrying id, which a client
+// sends with every request made under ctx and adds to its log lines. Use
+// WithCorrelationIDKey instead when the application already stores its
+// request IDs in the context under a key of its own.
+func ContextWithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// correlationConfig says where a correlation ID is read from and which
+// header carries it. The zero value uses ContextWithCorrelationID's key and
+// DefaultCorrelationHeader.
+type correlationConfig struct {
+	key    any
+	header string
+}
+
+// id returns the correlation ID in ctx: a string, or a fmt.Stringer such
+// as a UUID type, stored under the configured key.
+func (cfg correlationConfig) id(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	key := cfg.key
+	if key == nil {
+		key = correlationIDKey{}
+	}
+	switch v := ctx.Value(key).(type) {
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	}
+	return ""
+}
+
+// configured reports whether WithCorrelationIDKey or WithCorrelationHeader
+// was given.
+func (cfg correlationConfig) configured() bool {
+	return cfg.key != nil || cfg.header != ""
+}
+
+func (cfg correlationConfig) headerName() string {
+	if cfg.header == "" {
+		return DefaultCorrelationHeader
+	}
+	return cfg.header
+}
+
+// correlationTransport copies the correlation ID of each request's context
+// into its header. A header the caller already set is left alone.
+type correlationTransport struct {
+	cfg       correlationConfig
+	transport http.RoundTripper
+}
+
+func (t *correlationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	id := t.cfg.id(req.Context())
+	header := t.cfg.headerName()
+	if id == "" || req.Header.Get(header) != "" {
+		return t.transport.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set(header, id)
+	return t.transport.RoundTrip(req)
+}
+
+// loggerContext is logger with a "correlation_id" attribute when ctx
+// carries one.
+func (l *clientLoggers) loggerContext(ctx context.Context, subsystem string) *slog.Logger {
+	lg := l.logger(subsystem)
+	var cfg correlationConfig
+	if l != nil {
+		cfg = l.correlation
+	}
+	if id := cfg.id(ctx); id != "" {
+		return lg.With("correlation_id", id)
+	}
+	return lg
+}
+
+// logContext returns the client's logger for subsystem, annotated with the
+// correlation ID of ctx.
+func (c *Client) logContext(ctx context.Context, subsystem string) *slog.Logger {
+	return c.logs.loggerContext(ctx, subsystem)
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newHeaderRecordingServer(t *testing.T, header string) (*httptest.Server, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen = append(seen, r.Header.Get(header))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"status":"healthy","latestLedger":7}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), seen...)
+	}
+}
+
+func TestCorrelationID_Header(t *testing.T) {
+	server, seen := newHeaderRecordingServer(t, DefaultCorrelationHeader)
+	client, err := NewClient(WithNetwork(Testnet), WithSorobanURL(server.URL))
+	require.NoError(t, err)
+
+	_, err = client.GetHealth(ContextWithCorrelationID(context.Background(), "req-42"))
+	require.NoError(t, err)
+	_, err = client.GetHealth(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"req-42", ""}, seen())
+}
+
+type appRequestID string
+
+func (id appRequestID) String() string { return "app-" + string(id) }
+
+type appRequestIDKey struct{}
+
+func TestCorrelationID_CustomKeyAndHeader(t *testing.T) {
+	server, seen := newHeaderRecordingServer(t, "X-Trace-Id")
+	client, err := NewClient(
+		WithNetwork(Testnet),
+		WithSorobanURL(server.URL),
+		WithCorrelationIDKey(appRequestIDKey{}),
+		WithCorrelationHeader("x-trace-id"),
+	)
+	require.NoError(t, err)
+
+	ctx := context.WithValue(context.Background(), appRequestIDKey{}, appRequestID("7"))
+	_, err = client.GetHealth(ctx)
+	require.NoError(t, err)
+	// The default key is not consulted once another is configured.
+	_, err = client.GetHealth(ContextWithCorrelationID(context.Background(), "ignored"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"app-7", ""}, seen())
+
+	_, err = NewClient(WithCorrelationHeader(""))
+	assert.Error(t, err)
+	_, err = NewClient(WithCorrelationIDKey(nil))
+	assert.Error(t, err)
+}
+
+func TestCorrelationTransport_KeepsCallerHeader(t *testing.T) {
+	server, seen := newHeaderRecordingServer(t, DefaultCorrelationHeader)
+	hc := &http.Client{Transport: &correlationTransport{transport: http.DefaultTransport}}
+
+	req, err := http.NewRequestWithContext(ContextWithCorrelationID(context.Background(), "from-ctx"), http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set(DefaultCorrelationHeader, "explicit")
+	resp, err := hc.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, []string{"explicit"}, seen())
+}
+
+func TestCorrelationID_LogLines(t *testing.T) {
+	var buf bytes.Buffer
+	base := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	horizon := &asyncHorizon{resp: hProtocol.AsyncTransactionSubmissionResponse{TxStatus: "PENDING", Hash: "abc"}}
+	client := &Client{Horizon: horizon, logs: newClientLoggers(LogConfig{Logger: base})}
+
+	_, err := client.SubmitTransactionAsync(ContextWithCorrelationID(context.Background(), "req-9"), "AAAA")
+	require.NoError(t, err)
+
+	records := logRecords(t, &buf)
+	require.NotEmpty(t, records)
+	for _, rec := range records {
+		assert.Equal(t, "req-9", rec["correlation_id"], rec["msg"])
+		assert.Equal(t, LogSubsystemSubmission, rec["subsystem"])
+	}
+}
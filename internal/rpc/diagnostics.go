@@ -0,0 +1,50 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/errors"
+)
+
+// DiagnosticEvents decodes the diagnostic events returned by simulateTransaction.
+func (r *SimulateTransactionResponse) DiagnosticEvents() ([]decoder.DiagnosticEvent, error) {
+	if r == nil {
+		return []decoder.DiagnosticEvent{}, nil
+	}
+	events, err := decoder.DecodeDiagnosticEventsXDR(r.Result.Events)
+	if err != nil {
+		return nil, errors.WrapUnmarshalFailed(err, "simulation diagnostic events")
+	}
+	return events, nil
+}
+
+// FailureReason explains why the simulated invocation failed, preferring the
+// decoded diagnostic event trail over the bare host error string. It returns an
+// empty string when the simulation succeeded.
+func (r *SimulateTransactionResponse) FailureReason() string {
+	if r == nil || r.Result.Error == "" {
+		return ""
+	}
+	events, err := r.DiagnosticEvents()
+	if err == nil {
+		if summary := decoder.SummarizeFailure(events); summary != "" {
+			return summary
+		}
+	}
+	return r.Result.Error
+}
+
+// DiagnosticEvents decodes the diagnostic events recorded in the transaction's
+// result meta. Classic transactions yield an empty slice.
+func (r *TransactionResponse) DiagnosticEvents() ([]decoder.DiagnosticEvent, error) {
+	if r == nil {
+		return []decoder.DiagnosticEvent{}, nil
+	}
+	events, err := decoder.DiagnosticEventsFromMetaXDR(r.ResultMetaXdr)
+	if err != nil {
+		return nil, errors.WrapUnmarshalFailed(err, "transaction meta diagnostic events")
+	}
+	return events, nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodedErrorEvent(t *testing.T, code uint32) string {
+	t.Helper()
+	errSym := xdr.ScSymbol("error")
+	contractCode := xdr.Uint32(code)
+	diag := xdr.DiagnosticEvent{
+		Event: xdr.ContractEvent{
+			Type: xdr.ContractEventTypeDiagnostic,
+			Body: xdr.ContractEventBody{
+				V: 0,
+				V0: &xdr.ContractEventV0{
+					Topics: []xdr.ScVal{
+						{Type: xdr.ScValTypeScvSymbol, Sym: &errSym},
+						{Type: xdr.ScValTypeScvError, Error: &xdr.ScError{Type: xdr.ScErrorTypeSceContract, ContractCode: &contractCode}},
+					},
+					Data: xdr.ScVal{Type: xdr.ScValTypeScvVoid},
+				},
+			},
+		},
+	}
+	raw, err := diag.MarshalBinary()
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+func TestSimulateTransactionResponse_FailureReason(t *testing.T) {
+	resp := &SimulateTransactionResponse{}
+	assert.Empty(t, resp.FailureReason())
+
+	resp.Result.Error = "HostError: Error(Contract, #3)"
+	resp.Result.Events = []string{encodedErrorEvent(t, 3)}
+
+	events, err := resp.DiagnosticEvents()
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Contains(t, resp.FailureReason(), "Error(Contract, #3)")
+
+	resp.Result.Events = nil
+	assert.Equal(t, "HostError: Error(Contract, #3)", resp.FailureReason())
+}
+
+func TestTransactionResponse_DiagnosticEvents_Classic(t *testing.T) {
+	var nilResp *TransactionResponse
+	events, err := nilResp.DiagnosticEvents()
+	require.NoError(t, err)
+	assert.Empty(t, events)
+
+	_, err = (&TransactionResponse{ResultMetaXdr: "!!"}).DiagnosticEvents()
+	assert.Error(t, err)
+}
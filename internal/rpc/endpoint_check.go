@@ -0,0 +1,201 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+)
+
+// Endpoint kinds reported by CheckEndpoints.
+const (
+	EndpointHorizon = "horizon"
+	EndpointSoroban = "soroban"
+)
+
+// EndpointCheck is the result of probing one Horizon or Soroban RPC
+// endpoint directly, without retries or failover.
+type EndpointCheck struct {
+	Kind string
+	URL  string
+	// Latency is the time until the first probe's response headers
+	// arrived, including connection setup.
+	Latency time.Duration
+	// StatusCode is the HTTP status of the first probe, or zero when the
+	// endpoint could not be reached.
+	StatusCode      int
+	Passphrase      string
+	ProtocolVersion uint32
+	// CoreProtocolVersion is the highest protocol the Stellar Core behind
+	// a Horizon endpoint supports; zero for Soroban RPC.
+	CoreProtocolVersion uint32
+	// Version is the server software version, when the endpoint reports it.
+	Version string
+	Err     error
+}
+
+// Unauthorized reports whether the endpoint rejected the request's
+// credentials.
+func (e EndpointCheck) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+}
+
+// HasToken reports whether the client sends an authentication token.
+func (c *Client) HasToken() bool {
+	return c.token != ""
+}
+
+// CheckEndpoints probes every configured Horizon URL and the Soroban RPC
+// URL concurrently, with the client's token and headers. Results are in
+// the order Horizon URLs, then Soroban RPC.
+func (c *Client) CheckEndpoints(ctx context.Context) []EndpointCheck {
+	urls := c.AltURLs
+	if len(urls) == 0 {
+		urls = []string{c.HorizonURL}
+	}
+	checks := make([]EndpointCheck, len(urls)+1)
+	done := make(chan struct{})
+	for i, url := range urls {
+		go func(i int, url string) {
+			checks[i] = c.checkHorizon(ctx, url)
+			done <- struct{}{}
+		}(i, url)
+	}
+	go func() {
+		checks[len(urls)] = c.checkSoroban(ctx, c.sorobanTargetURL())
+		done <- struct{}{}
+	}()
+	for range checks {
+		<-done
+	}
+	return checks
+}
+
+func (c *Client) checkHorizon(ctx context.Context, url string) EndpointCheck {
+	check := EndpointCheck{Kind: EndpointHorizon, URL: url}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		check.Err = errors.WrapValidationError(fmt.Sprintf("invalid Horizon URL %q: %v", url, err))
+		return check
+	}
+	req.Header.Set("Accept", "application/json")
+	var root struct {
+		HorizonVersion        string `json:"horizon_version"`
+		NetworkPassphrase     string `json:"network_passphrase"`
+		CurrentProtocol       uint32 `json:"current_protocol_version"`
+		CoreSupportedProtocol uint32 `json:"core_supported_protocol_version"`
+	}
+	check.StatusCode, check.Latency, check.Err = c.probe(req, &root)
+	check.Version = root.HorizonVersion
+	check.Passphrase = root.NetworkPassphrase
+	check.ProtocolVersion = root.CurrentProtocol
+	check.CoreProtocolVersion = root.CoreSupportedProtocol
+	return check
+}
+
+func (c *Client) checkSoroban(ctx context.Context, url string) EndpointCheck {
+	check := EndpointCheck{Kind: EndpointSoroban, URL: url}
+	var network NetworkInfo
+	check.StatusCode, check.Latency, check.Err = c.probeSoroban(ctx, url, "getNetwork", &network)
+	if check.Err != nil {
+		return check
+	}
+	check.Passphrase = network.Passphrase
+	check.ProtocolVersion = network.ProtocolVersion
+
+	// getVersionInfo is newer than getNetwork; servers without it are
+	// still usable, so its failure is not reported.
+	var version struct {
+		Version string `json:"version"`
+	}
+	if _, _, err := c.probeSoroban(ctx, url, "getVersionInfo", &version); err == nil {
+		check.Version = version.Version
+	}
+	return check
+}
+
+// ProbeSoroban sends one JSON-RPC request for method, without params, to
+// the Soroban RPC at url, with the client's token and headers and without
+// retries. It returns the HTTP status, zero when no response arrived, and
+// the time until the response headers arrived.
+func (c *Client) ProbeSoroban(ctx context.Context, url, method string) (int, time.Duration, error) {
+	return c.probeSoroban(ctx, url, method, nil)
+}
+
+func (c *Client) probeSoroban(ctx context.Context, url, method string, out interface{}) (int, time.Duration, error) {
+	body, err := json.Marshal(jsonRPCRequest{Jsonrpc: "2.0", ID: 1, Method: method})
+	if err != nil {
+		return 0, 0, errors.WrapMarshalFailed(err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, 0, errors.WrapValidationError(fmt.Sprintf("invalid Soroban RPC URL %q: %v", url, err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	var raw json.RawMessage
+	status, latency, err := c.probe(req, &raw)
+	if err != nil {
+		return status, latency, err
+	}
+	rpcErr, err := decodeJSONRPCResponse(raw, out)
+	if err != nil {
+		return status, latency, err
+	}
+	if rpcErr != nil {
+		return status, latency, errors.WrapRPCError(url, rpcErr.Message, rpcErr.Code)
+	}
+	return status, latency, nil
+}
+
+// probe sends req once and decodes the JSON response body into out. The
+// latency runs until the response headers arrive.
+func (c *Client) probe(req *http.Request, out interface{}) (int, time.Duration, error) {
+	start := time.Now()
+	resp, err := c.probeClient().Do(req)
+	latency := time.Since(start)
+	if err != nil {
+		return 0, latency, errors.WrapRPCConnectionFailed(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, latency, errors.WrapRPCConnectionFailed(err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return resp.StatusCode, latency, errors.WrapUnauthorized(fmt.Sprintf("%s returned HTTP %d", req.URL.Host, resp.StatusCode))
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return resp.StatusCode, latency, fmt.Errorf("%w: %s returned HTTP 429", errors.ErrRateLimitExceeded, req.URL.Host)
+	case resp.StatusCode >= 400:
+		return resp.StatusCode, latency, errors.WrapRPCConnectionFailed(fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(truncateBody(body))))
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return resp.StatusCode, latency, errors.WrapUnmarshalFailed(err, truncateBody(body))
+	}
+	return resp.StatusCode, latency, nil
+}
+
+// probeClient sends requests with the client's token and headers but
+// without the retry transport, so failures and latencies are the
+// endpoint's own.
+func (c *Client) probeClient() *http.Client {
+	var transport http.RoundTripper = http.DefaultTransport
+	if c.token != "" || len(c.Headers) > 0 {
+		transport = &authTransport{token: c.token, headers: c.Headers, transport: transport}
+	}
+	timeout := c.getHTTPClient().Timeout
+	if timeout == 0 {
+		timeout = defaultHTTPTimeout
+	}
+	return &http.Client{Transport: transport, Timeout: timeout}
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckEndpoints(t *testing.T) {
+	horizon := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"horizon_version":"22.0.0","network_passphrase":"Test SDF Network ; September 2015",` +
+			`"current_protocol_version":22,"core_supported_protocol_version":23}`))
+	}))
+	defer horizon.Close()
+	locked := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer locked.Close()
+	soroban := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req jsonRPCRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		switch req.Method {
+		case "getNetwork":
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"passphrase":"Test SDF Network ; September 2015","protocolVersion":22}}`))
+		default:
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"method not found"}}`))
+		}
+	}))
+	defer soroban.Close()
+
+	client, err := NewClient(WithNetwork(Testnet), WithToken("secret"),
+		WithAltURLs([]string{horizon.URL, locked.URL}), WithSorobanURL(soroban.URL))
+	require.NoError(t, err)
+
+	checks := client.CheckEndpoints(context.Background())
+	require.Len(t, checks, 3)
+
+	assert.Equal(t, EndpointHorizon, checks[0].Kind)
+	require.NoError(t, checks[0].Err)
+	assert.Equal(t, "22.0.0", checks[0].Version)
+	assert.Equal(t, uint32(22), checks[0].ProtocolVersion)
+	assert.Equal(t, uint32(23), checks[0].CoreProtocolVersion)
+	assert.Positive(t, checks[0].Latency)
+
+	assert.Equal(t, locked.URL, checks[1].URL)
+	assert.True(t, checks[1].Unauthorized())
+	assert.ErrorIs(t, checks[1].Err, errs.ErrUnauthorized)
+
+	assert.Equal(t, EndpointSoroban, checks[2].Kind)
+	require.NoError(t, checks[2].Err)
+	assert.Equal(t, "Test SDF Network ; September 2015", checks[2].Passphrase)
+	assert.Equal(t, uint32(22), checks[2].ProtocolVersion)
+	assert.Empty(t, checks[2].Version)
+}
+
+func TestCheckEndpointsUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	client, err := NewClient(WithNetwork(Testnet), WithHorizonURL(url), WithSorobanURL(url))
+	require.NoError(t, err)
+	for _, check := range client.CheckEndpoints(context.Background()) {
+		assert.ErrorIs(t, check.Err, errs.ErrRPCConnectionFailed, check.Kind)
+		assert.Zero(t, check.StatusCode)
+	}
+}
+
+func TestProbeSoroban(t *testing.T) {
+	var limited atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if limited.Load() {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"sequence":1000}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithNetwork(Testnet))
+	require.NoError(t, err)
+	status, latency, err := client.ProbeSoroban(context.Background(), server.URL, "getLatestLedger")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Positive(t, latency)
+
+	limited.Store(true)
+	status, _, err = client.ProbeSoroban(context.Background(), server.URL, "getLatestLedger")
+	assert.Equal(t, http.StatusTooManyRequests, status)
+	assert.ErrorIs(t, err, errs.ErrRateLimitExceeded)
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// knownNetworks are the networks ValidateEnvelope tries when signatures do
+// not verify for the client's own.
+var knownNetworks = []NetworkConfig{MainnetConfig, TestnetConfig, FuturenetConfig}
+
+// ValidateEnvelope checks a signed envelope against the client's network
+// before it is submitted, so a mistake surfaces as a descriptive error
+// rather than tx_bad_auth or tx_no_account. The source account, and the fee
+// source of a fee bump, must exist, and the envelope must carry signatures
+// that all verify against the transaction hash for the configured
+// passphrase, with the accounts' current signers as candidate keys. When
+// the signatures verify for another known network instead, the error
+// matches errors.ErrWrongNetwork and names it.
+func (c *Client) ValidateEnvelope(ctx context.Context, envelopeXdr string) error {
+	var env xdr.TransactionEnvelope
+	if err := xdr.SafeUnmarshalBase64(envelopeXdr, &env); err != nil {
+		return errors.WrapUnmarshalFailed(err, "transaction envelope")
+	}
+	network := c.GetNetworkName()
+
+	accounts := []string{env.SourceAccount().ToAccountId().Address()}
+	if env.IsFeeBump() {
+		accounts = append(accounts, env.FeeBumpAccount().ToAccountId().Address())
+	}
+	var signers []string
+	for _, id := range accounts {
+		account, err := c.AccountDetails(ctx, id)
+		if errors.Is(err, errors.ErrAccountNotFound) {
+			return errors.WrapSourceAccountNotFound(id, network)
+		}
+		if err != nil {
+			return err
+		}
+		for _, s := range account.Signers {
+			if s.Weight > 0 && s.Type != "preauth_tx" {
+				signers = append(signers, s.Key)
+			}
+		}
+	}
+
+	report, err := decoder.VerifySignatures(envelopeXdr, c.Config.NetworkPassphrase, signers...)
+	if err != nil {
+		return errors.WrapValidationError(err.Error())
+	}
+	if len(report.Signatures) == 0 && (report.Inner == nil || len(report.Inner.Signatures) == 0) {
+		return errors.WrapValidationError("transaction envelope is not signed")
+	}
+	invalid := invalidSignatures(report, "")
+	if len(invalid) == 0 {
+		return nil
+	}
+
+	for _, other := range knownNetworks {
+		if other.NetworkPassphrase == c.Config.NetworkPassphrase {
+			continue
+		}
+		r, err := decoder.VerifySignatures(envelopeXdr, other.NetworkPassphrase, signers...)
+		if err == nil && len(invalidSignatures(r, "")) == 0 {
+			c.logContext(ctx, LogSubsystemSubmission).Warn("Envelope signed for another network", "signed_for", other.Name, "network", network)
+			return errors.NewEnvelopeSignatureError(network, other.Name, invalid)
+		}
+	}
+	return errors.NewEnvelopeSignatureError(network, "", invalid)
+}
+
+// invalidSignatures names the signatures in r no candidate signer produced.
+func invalidSignatures(r *decoder.SignatureReport, prefix string) []string {
+	var out []string
+	for _, s := range r.Signatures {
+		if !s.Valid {
+			out = append(out, fmt.Sprintf("%ssignature %d", prefix, s.Index))
+		}
+	}
+	if r.Inner != nil {
+		out = append(out, invalidSignatures(r.Inner, "inner ")...)
+	}
+	return out
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/network"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type envelopeHorizon struct {
+	horizonclient.ClientInterface
+	accounts  map[string]hProtocol.Account
+	submitted int
+}
+
+func (m *envelopeHorizon) AccountDetail(req horizonclient.AccountRequest) (hProtocol.Account, error) {
+	acc, ok := m.accounts[req.AccountID]
+	if !ok {
+		return hProtocol.Account{}, &horizonclient.Error{Problem: problem.P{Status: http.StatusNotFound}}
+	}
+	return acc, nil
+}
+
+func (m *envelopeHorizon) AsyncSubmitTransactionXDR(string) (hProtocol.AsyncTransactionSubmissionResponse, error) {
+	m.submitted++
+	return hProtocol.AsyncTransactionSubmissionResponse{TxStatus: "PENDING"}, nil
+}
+
+func signedEnvelope(t *testing.T, passphrase string, source *keypair.Full, signers ...*keypair.Full) string {
+	t.Helper()
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &txnbuild.SimpleAccount{AccountID: source.Address(), Sequence: 1},
+		IncrementSequenceNum: true,
+		Operations:           []txnbuild.Operation{&txnbuild.BumpSequence{BumpTo: 9}},
+		BaseFee:              txnbuild.MinBaseFee,
+		Preconditions:        txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+	})
+	require.NoError(t, err)
+	if len(signers) > 0 {
+		tx, err = tx.Sign(passphrase, signers...)
+		require.NoError(t, err)
+	}
+	env, err := tx.Base64()
+	require.NoError(t, err)
+	return env
+}
+
+func TestValidateEnvelope(t *testing.T) {
+	source, cosigner, stranger := keypair.MustRandom(), keypair.MustRandom(), keypair.MustRandom()
+	horizon := &envelopeHorizon{accounts: map[string]hProtocol.Account{
+		source.Address(): {
+			AccountID: source.Address(),
+			Signers: []hProtocol.Signer{
+				{Key: source.Address(), Type: "ed25519_public_key", Weight: 1},
+				{Key: cosigner.Address(), Type: "ed25519_public_key", Weight: 1},
+			},
+		},
+	}}
+	client := &Client{Horizon: horizon, Config: TestnetConfig}
+	ctx := context.Background()
+
+	assert.NoError(t, client.ValidateEnvelope(ctx, signedEnvelope(t, network.TestNetworkPassphrase, source, source, cosigner)))
+
+	err := client.ValidateEnvelope(ctx, signedEnvelope(t, network.PublicNetworkPassphrase, source, source))
+	require.ErrorIs(t, err, errs.ErrWrongNetwork)
+	assert.ErrorIs(t, err, errs.ErrTxBadAuth)
+	var sigErr *errs.EnvelopeSignatureError
+	require.ErrorAs(t, err, &sigErr)
+	assert.Equal(t, "mainnet", sigErr.SignedFor)
+	assert.Equal(t, "testnet", sigErr.Network)
+
+	err = client.ValidateEnvelope(ctx, signedEnvelope(t, network.TestNetworkPassphrase, source, source, stranger))
+	require.ErrorIs(t, err, errs.ErrInvalidSignature)
+	assert.NotErrorIs(t, err, errs.ErrWrongNetwork)
+	assert.Contains(t, err.Error(), "signature 1")
+
+	err = client.ValidateEnvelope(ctx, signedEnvelope(t, network.TestNetworkPassphrase, source))
+	assert.ErrorIs(t, err, errs.ErrValidationFailed, "unsigned")
+
+	missing := keypair.MustRandom()
+	err = client.ValidateEnvelope(ctx, signedEnvelope(t, network.TestNetworkPassphrase, missing, missing))
+	assert.ErrorIs(t, err, errs.ErrAccountNotFound)
+	assert.Contains(t, err.Error(), "does not exist on testnet")
+
+	_, err = client.SubmitTransactionAsync(ctx, signedEnvelope(t, network.PublicNetworkPassphrase, source, source))
+	assert.ErrorIs(t, err, errs.ErrWrongNetwork)
+	assert.Zero(t, horizon.submitted, "rejected envelopes are not submitted")
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+
+	"github.com/dotandev/hintents/internal/errors"
+)
+
+// EventFilter selects events in a getEvents call. Topics holds one or more
+// topic patterns, each a list of base64 ScVals where "*" matches any single
+// topic and a trailing "**" matches any remaining topics.
+type EventFilter struct {
+	Type        string     `json:"type,omitempty"`
+	ContractIDs []string   `json:"contractIds,omitempty"`
+	Topics      [][]string `json:"topics,omitempty"`
+}
+
+// EventsRequest is a getEvents query. Either StartLedger or Cursor must be
+// set; Cursor continues from a previous page.
+type EventsRequest struct {
+	StartLedger uint32
+	EndLedger   uint32
+	Filters     []EventFilter
+	Cursor      string
+	Limit       int
+}
+
+// ContractEvent is a single event as returned by getEvents. Topic and Value
+// hold base64 ScVal XDR.
+type ContractEvent struct {
+	Type                     string   `json:"type"`
+	Ledger                   uint32   `json:"ledger"`
+	LedgerClosedAt           string   `json:"ledgerClosedAt"`
+	ContractID               string   `json:"contractId"`
+	ID                       string   `json:"id"`
+	TxHash                   string   `json:"txHash,omitempty"`
+	Topic                    []string `json:"topic"`
+	Value                    string   `json:"value"`
+	InSuccessfulContractCall bool     `json:"inSuccessfulContractCall"`
+}
+
+// EventsPage is one page of getEvents results. Cursor continues after the
+// last event returned.
+type EventsPage struct {
+	Events       []ContractEvent
+	Cursor       string
+	LatestLedger uint32
+}
+
+// GetEvents fetches contract events from Soroban RPC.
+func (c *Client) GetEvents(ctx context.Context, req EventsRequest) (*EventsPage, error) {
+	if req.StartLedger == 0 && req.Cursor == "" {
+		return nil, errors.WrapValidationError("getEvents needs a start ledger or a cursor")
+	}
+
+	params := map[string]interface{}{}
+	if req.Cursor == "" {
+		params["startLedger"] = req.StartLedger
+		if req.EndLedger != 0 {
+			params["endLedger"] = req.EndLedger
+		}
+	}
+	filters := req.Filters
+	if filters == nil {
+		filters = []EventFilter{}
+	}
+	params["filters"] = filters
+	pagination := map[string]interface{}{}
+	if req.Cursor != "" {
+		pagination["cursor"] = req.Cursor
+	}
+	if req.Limit > 0 {
+		pagination["limit"] = req.Limit
+	}
+	if len(pagination) > 0 {
+		params["pagination"] = pagination
+	}
+
+	var result struct {
+		Events       []ContractEvent `json:"events"`
+		Cursor       string          `json:"cursor"`
+		LatestLedger uint32          `json:"latestLedger"`
+	}
+	if err := c.callSoroban(ctx, "getEvents", params, &result); err != nil {
+		return nil, err
+	}
+	c.observeLedger(result.LatestLedger)
+	return &EventsPage{Events: result.Events, Cursor: result.Cursor, LatestLedger: result.LatestLedger}, nil
+}
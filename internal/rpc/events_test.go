@@ -0,0 +1,72 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEvents(t *testing.T) {
+	var params map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string                 `json:"method"`
+			Params map[string]interface{} `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "getEvents", req.Method)
+		params = req.Params
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      1,
+			"result": map[string]interface{}{
+				"events": []map[string]interface{}{{
+					"type":       "contract",
+					"ledger":     12,
+					"contractId": "CABC",
+					"id":         "0000000051539611648-0000000000",
+					"topic":      []string{"AAAADwAAAAh0cmFuc2Zlcg=="},
+					"value":      "AAAAAQ==",
+				}},
+				"cursor":       "0000000051539611648-0000000000",
+				"latestLedger": 20,
+			},
+		})
+	}))
+	defer server.Close()
+	client := newArchivalTestClient(t, server.URL)
+
+	page, err := client.GetEvents(context.Background(), EventsRequest{
+		StartLedger: 10,
+		Filters:     []EventFilter{{Type: "contract", ContractIDs: []string{"CABC"}}},
+		Limit:       5,
+	})
+	require.NoError(t, err)
+	require.Len(t, page.Events, 1)
+	assert.Equal(t, uint32(12), page.Events[0].Ledger)
+	assert.Equal(t, "CABC", page.Events[0].ContractID)
+	assert.Equal(t, uint32(20), page.LatestLedger)
+	assert.Equal(t, "0000000051539611648-0000000000", page.Cursor)
+
+	assert.Equal(t, float64(10), params["startLedger"])
+	assert.Equal(t, map[string]interface{}{"limit": float64(5)}, params["pagination"])
+
+	_, err = client.GetEvents(context.Background(), EventsRequest{Cursor: page.Cursor})
+	require.NoError(t, err)
+	assert.NotContains(t, params, "startLedger")
+	assert.Equal(t, map[string]interface{}{"cursor": page.Cursor}, params["pagination"])
+}
+
+func TestGetEvents_RequiresStart(t *testing.T) {
+	client := newArchivalTestClient(t, "http://localhost")
+	_, err := client.GetEvents(context.Background(), EventsRequest{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"expvar"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/dotandev/hintents/internal/errors"
+)
+
+// DefaultExpvarPrefix is the prefix WithExpvar uses when given none.
+const DefaultExpvarPrefix = "erst_rpc"
+
+// expvarNames are the variables published under a prefix, as
+// "<prefix>.<name>".
+var expvarNames = []string{"requests", "errors", "failovers", "cache", "health"}
+
+var (
+	expvarMu   sync.Mutex
+	expvarSets = make(map[string]*expvarSet)
+)
+
+// expvarSet is the set of variables published under one prefix. expvar
+// cannot unpublish, so the set lives for the process: clients built with
+// the same prefix add to the same counters, and health reports the most
+// recently built of them. A nil *expvarSet records nothing.
+type expvarSet struct {
+	mu        sync.Mutex
+	requests  *expvar.Map
+	errors    *expvar.Map
+	failovers *expvar.Map
+	cache     *expvar.Map
+	client    atomic.Pointer[Client]
+}
+
+// publishExpvar returns the set published under prefix, publishing it on
+// first use. A prefix whose names were published by something else is an
+// error rather than the panic expvar.Publish would raise.
+func publishExpvar(prefix string) (*expvarSet, error) {
+	expvarMu.Lock()
+	defer expvarMu.Unlock()
+
+	if s, ok := expvarSets[prefix]; ok {
+		return s, nil
+	}
+	for _, name := range expvarNames {
+		if expvar.Get(prefix+"."+name) != nil {
+			return nil, errors.WrapValidationError(fmt.Sprintf("expvar %s.%s is already published", prefix, name))
+		}
+	}
+
+	s := &expvarSet{
+		requests:  expvar.NewMap(prefix + ".requests"),
+		errors:    expvar.NewMap(prefix + ".errors"),
+		failovers: expvar.NewMap(prefix + ".failovers"),
+		cache:     expvar.NewMap(prefix + ".cache"),
+	}
+	expvar.Publish(prefix+".health", expvar.Func(s.health))
+	expvarSets[prefix] = s
+	return s, nil
+}
+
+// add increments m[outer][inner], creating the inner map on first use.
+func (s *expvarSet) add(m *expvar.Map, outer, inner string) {
+	inMap, ok := m.Get(outer).(*expvar.Map)
+	if !ok {
+		s.mu.Lock()
+		if inMap, ok = m.Get(outer).(*expvar.Map); !ok {
+			inMap = new(expvar.Map).Init()
+			m.Set(outer, inMap)
+		}
+		s.mu.Unlock()
+	}
+	inMap.Add(inner, 1)
+}
+
+// observeRequest counts a request under requests[endpoint][method] and,
+// when it failed, under errors[endpoint][kind].
+func (s *expvarSet) observeRequest(method, endpoint, errKind string) {
+	if s == nil {
+		return
+	}
+	s.add(s.requests, endpoint, method)
+	if errKind != "" {
+		s.add(s.errors, endpoint, errKind)
+	}
+}
+
+// observeError counts a failure that arrived in a successful HTTP response,
+// such as a JSON-RPC error object.
+func (s *expvarSet) observeError(endpoint, kind string) {
+	if s == nil {
+		return
+	}
+	s.add(s.errors, endpoint, kind)
+}
+
+func (s *expvarSet) observeFailover(from, to string) {
+	if s == nil {
+		return
+	}
+	s.failovers.Add(from+" -> "+to, 1)
+}
+
+func (s *expvarSet) observeCache(cache, result string) {
+	if s == nil {
+		return
+	}
+	s.add(s.cache, cache, result)
+}
+
+// health reports the current client's HealthStatus: per-endpoint failure
+// counts and circuit breaker state, and cache sizes.
+func (s *expvarSet) health() any {
+	c := s.client.Load()
+	if c == nil {
+		return nil
+	}
+	return c.Health()
+}
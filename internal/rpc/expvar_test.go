@@ -0,0 +1,89 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"net/url"
+	"testing"
+	"time"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readExpvar(t *testing.T, name string, into any) {
+	t.Helper()
+	v := expvar.Get(name)
+	require.NotNil(t, v, name)
+	require.NoError(t, json.Unmarshal([]byte(v.String()), into))
+}
+
+func TestWithExpvar(t *testing.T) {
+	server, _ := newSimulateServer(t, func(call int32) map[string]interface{} {
+		return map[string]interface{}{"result": map[string]interface{}{"latestLedger": 42}}
+	})
+	defer server.Close()
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	newClient := func() *Client {
+		client, err := NewClient(
+			WithNetwork(Testnet),
+			WithSorobanURL(server.URL),
+			WithAltURLs([]string{server.URL}),
+			WithSimulationCache(time.Minute),
+			WithExpvar("test_expvar"),
+		)
+		require.NoError(t, err)
+		client.SorobanURL = server.URL
+		return client
+	}
+
+	client := newClient()
+	for i := 0; i < 2; i++ {
+		_, err := client.SimulateTransaction(context.Background(), "AAAA")
+		require.NoError(t, err)
+	}
+
+	var requests map[string]map[string]int
+	readExpvar(t, "test_expvar.requests", &requests)
+	assert.Equal(t, 1, requests[u.Host]["simulateTransaction"])
+
+	var cache map[string]map[string]int
+	readExpvar(t, "test_expvar.cache", &cache)
+	assert.Equal(t, map[string]int{"miss": 1, "hit": 1}, cache[CacheSimulation])
+
+	var health HealthStatus
+	readExpvar(t, "test_expvar.health", &health)
+	assert.Equal(t, HealthStatusOK, health.Status)
+	require.NotNil(t, health.Cache.Simulation)
+	assert.Equal(t, 1, health.Cache.Simulation.Entries)
+
+	// A second client with the same prefix adds to the same counters.
+	_, err = newClient().SimulateTransaction(context.Background(), "AAAA")
+	require.NoError(t, err)
+	readExpvar(t, "test_expvar.requests", &requests)
+	assert.Equal(t, 2, requests[u.Host]["simulateTransaction"])
+}
+
+func TestWithExpvar_PrefixTaken(t *testing.T) {
+	expvar.NewInt("test_expvar_taken.requests")
+
+	_, err := NewClient(WithExpvar("test_expvar_taken"))
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+}
+
+func TestExpvarSet_Nil(t *testing.T) {
+	var s *expvarSet
+	assert.NotPanics(t, func() {
+		s.observeRequest("m", "e", "transport")
+		s.observeError("e", "rpc_-32600")
+		s.observeFailover("a", "b")
+		s.observeCache(CacheSimulation, CacheResultHit)
+	})
+}
@@ -5,8 +5,11 @@ package rpc
 
 import (
 	"context"
+	"fmt"
+	"net"
 	"testing"
 
+	"github.com/dotandev/hintents/internal/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -48,3 +51,33 @@ func TestClient_GetTransaction_Failover_Logic(t *testing.T) {
 	assert.Equal(t, 2, len(fallbackErr.Failures), "Should have recorded 2 failures")
 	assert.Contains(t, err.Error(), "all RPC endpoints failed")
 }
+
+func TestAllNodesFailedError_Category(t *testing.T) {
+	err := &AllNodesFailedError{Failures: []NodeFailure{
+		{URL: "a", Reason: errors.WrapRPCError("a", "bad request", 400)},
+		{URL: "b", Reason: errors.WrapRPCConnectionFailed(fmt.Errorf("connection reset"))},
+	}}
+	assert.Equal(t, errors.CategoryNetwork, errors.CategoryOf(err))
+	assert.True(t, errors.IsRetryable(fmt.Errorf("simulate: %w", err)))
+
+	err = &AllNodesFailedError{Failures: []NodeFailure{
+		{URL: "a", Reason: errors.WrapRPCError("a", "forbidden", 403)},
+	}}
+	assert.Equal(t, errors.CategoryAuth, errors.CategoryOf(err))
+	assert.False(t, errors.IsRetryable(err))
+}
+
+func TestAllNodesFailedError_Summary(t *testing.T) {
+	err := &AllNodesFailedError{Failures: []NodeFailure{
+		{URL: "https://a.example/rpc", Reason: &RequestError{Method: "getHealth", Err: errors.WrapRPCError("https://a.example", "slow down", 429)}},
+		{URL: "https://b.example", Reason: errors.WrapRPCTimeout(context.DeadlineExceeded)},
+		{URL: "https://c.example", Reason: errors.WrapRPCConnectionFailed(&net.DNSError{Err: "no such host", Name: "c.example"})},
+		{URL: "https://c.example", Reason: errors.WrapRPCConnectionFailed(&net.DNSError{Err: "no such host", Name: "c.example"})},
+	}}
+	assert.Equal(t, "all RPC endpoints failed: [a.example: HTTP 429 Too Many Requests, b.example: timeout, "+
+		"c.example: DNS lookup failed: no such host (2 attempts)]", err.Error())
+
+	var reqErr *RequestError
+	assert.ErrorAs(t, err, &reqErr)
+	assert.ErrorIs(t, err, errors.ErrRateLimitExceeded)
+}
@@ -0,0 +1,223 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// FaultConfig sets how often a FaultTransport injects each kind of
+// failure. Probabilities are between 0 and 1 and are drawn independently
+// for every HTTP attempt, so retries see fresh faults.
+type FaultConfig struct {
+	// LatencyProbability delays a request by Latency plus up to
+	// LatencyJitter before it is sent.
+	LatencyProbability float64
+	Latency            time.Duration
+	LatencyJitter      time.Duration
+
+	// TimeoutProbability makes a request hang until its context ends or
+	// TimeoutAfter elapses, then fail with a timeout error. TimeoutAfter
+	// defaults to 30 seconds.
+	TimeoutProbability float64
+	TimeoutAfter       time.Duration
+
+	// ResetProbability fails a request with a connection reset before it
+	// reaches the server.
+	ResetProbability float64
+
+	// TruncateProbability cuts the response body off halfway, ending it
+	// with io.ErrUnexpectedEOF.
+	TruncateProbability float64
+
+	// MalformedProbability replaces a response body with invalid JSON,
+	// keeping the status code.
+	MalformedProbability float64
+
+	// Hosts limits faults to requests for these hosts, such as
+	// "soroban-testnet.stellar.org", to exercise failover away from one
+	// endpoint. Empty means every host.
+	Hosts []string
+
+	// Seed makes the fault sequence reproducible. Zero seeds from the clock.
+	Seed int64
+}
+
+// FaultCounts is how many faults of each kind a FaultTransport injected.
+type FaultCounts struct {
+	Latency   int
+	Timeouts  int
+	Resets    int
+	Truncated int
+	Malformed int
+}
+
+// Total is the number of faults injected.
+func (c FaultCounts) Total() int {
+	return c.Latency + c.Timeouts + c.Resets + c.Truncated + c.Malformed
+}
+
+// malformedBody replaces response bodies when MalformedProbability fires.
+const malformedBody = `{"jsonrpc":"2.0","result":{"status":`
+
+// FaultTransport is an http.RoundTripper that injects latency, timeouts,
+// connection resets, truncated bodies and malformed JSON at configured
+// probabilities, for checking that retry and failover settings hold up
+// under failure. Installed with WithFaultInjection it sits beneath the
+// retry transport, so every retry attempt can fail independently.
+type FaultTransport struct {
+	config    FaultConfig
+	transport http.RoundTripper
+
+	mu     sync.Mutex
+	rng    *rand.Rand
+	counts FaultCounts
+}
+
+// NewFaultTransport creates a FaultTransport injecting faults into requests
+// sent through transport, which defaults to http.DefaultTransport.
+func NewFaultTransport(config FaultConfig, transport http.RoundTripper) *FaultTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if config.TimeoutAfter <= 0 {
+		config.TimeoutAfter = 30 * time.Second
+	}
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &FaultTransport{
+		config:    config,
+		transport: transport,
+		rng:       rand.New(rand.NewSource(seed)),
+	}
+}
+
+// Injected returns how many faults have been injected so far.
+func (ft *FaultTransport) Injected() FaultCounts {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+	return ft.counts
+}
+
+// RoundTrip implements http.RoundTripper, injecting faults around the
+// wrapped transport.
+func (ft *FaultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !ft.targets(req.URL.Hostname()) {
+		return ft.transport.RoundTrip(req)
+	}
+
+	if delay, ok := ft.latency(); ok {
+		if err := sleepContext(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+	if ft.roll(ft.config.ResetProbability, &ft.counts.Resets) {
+		return nil, &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+	}
+	if ft.roll(ft.config.TimeoutProbability, &ft.counts.Timeouts) {
+		if err := sleepContext(req.Context(), ft.config.TimeoutAfter); err != nil {
+			return nil, err
+		}
+		return nil, &net.OpError{Op: "read", Net: "tcp", Err: faultTimeoutError{}}
+	}
+
+	resp, err := ft.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case ft.roll(ft.config.MalformedProbability, &ft.counts.Malformed):
+		resp.Body.Close()
+		setBody(resp, io.NopCloser(bytes.NewReader([]byte(malformedBody))), len(malformedBody))
+	case ft.roll(ft.config.TruncateProbability, &ft.counts.Truncated):
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		setBody(resp, io.NopCloser(io.MultiReader(bytes.NewReader(body[:len(body)/2]), errReader{io.ErrUnexpectedEOF})), len(body))
+	}
+	return resp, nil
+}
+
+func (ft *FaultTransport) targets(host string) bool {
+	if len(ft.config.Hosts) == 0 {
+		return true
+	}
+	for _, h := range ft.config.Hosts {
+		if h == host {
+			return true
+		}
+	}
+	return false
+}
+
+// roll draws against p and counts the fault when it fires.
+func (ft *FaultTransport) roll(p float64, count *int) bool {
+	if p <= 0 {
+		return false
+	}
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+	if ft.rng.Float64() >= p {
+		return false
+	}
+	*count++
+	return true
+}
+
+func (ft *FaultTransport) latency() (time.Duration, bool) {
+	if !ft.roll(ft.config.LatencyProbability, &ft.counts.Latency) {
+		return 0, false
+	}
+	delay := ft.config.Latency
+	if ft.config.LatencyJitter > 0 {
+		ft.mu.Lock()
+		delay += time.Duration(ft.rng.Int63n(int64(ft.config.LatencyJitter)))
+		ft.mu.Unlock()
+	}
+	return delay, true
+}
+
+// setBody replaces resp's body, keeping Content-Length consistent with
+// what the server announced.
+func setBody(resp *http.Response, body io.ReadCloser, length int) {
+	resp.Body = body
+	resp.ContentLength = int64(length)
+	resp.Header.Set("Content-Length", strconv.Itoa(length))
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// faultTimeoutError is a net.Error reporting a timeout.
+type faultTimeoutError struct{}
+
+func (faultTimeoutError) Error() string   { return "i/o timeout (injected)" }
+func (faultTimeoutError) Timeout() bool   { return true }
+func (faultTimeoutError) Temporary() bool { return true }
+
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
@@ -0,0 +1,163 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func faultServer(t *testing.T, hits *int32) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits != nil {
+			atomic.AddInt32(hits, 1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"status":"healthy"}}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func faultGet(t *testing.T, ft *FaultTransport, ctx context.Context, target string) (*http.Response, error) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	require.NoError(t, err)
+	return ft.RoundTrip(req)
+}
+
+func TestFaultTransport_Reset(t *testing.T) {
+	var hits int32
+	srv := faultServer(t, &hits)
+	ft := NewFaultTransport(FaultConfig{ResetProbability: 1}, nil)
+
+	_, err := faultGet(t, ft, context.Background(), srv.URL)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, syscall.ECONNRESET)
+	assert.Zero(t, atomic.LoadInt32(&hits), "a reset request must not reach the server")
+	assert.Equal(t, FaultCounts{Resets: 1}, ft.Injected())
+}
+
+func TestFaultTransport_Timeout(t *testing.T) {
+	srv := faultServer(t, nil)
+	ft := NewFaultTransport(FaultConfig{TimeoutProbability: 1, TimeoutAfter: 10 * time.Millisecond}, nil)
+
+	_, err := faultGet(t, ft, context.Background(), srv.URL)
+	var netErr net.Error
+	require.ErrorAs(t, err, &netErr)
+	assert.True(t, netErr.Timeout())
+
+	ft = NewFaultTransport(FaultConfig{TimeoutProbability: 1, TimeoutAfter: time.Hour}, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = faultGet(t, ft, ctx, srv.URL)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestFaultTransport_Latency(t *testing.T) {
+	srv := faultServer(t, nil)
+	ft := NewFaultTransport(FaultConfig{LatencyProbability: 1, Latency: 30 * time.Millisecond}, nil)
+
+	start := time.Now()
+	resp, err := faultGet(t, ft, context.Background(), srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+	assert.Equal(t, 1, ft.Injected().Latency)
+}
+
+func TestFaultTransport_Bodies(t *testing.T) {
+	srv := faultServer(t, nil)
+
+	ft := NewFaultTransport(FaultConfig{TruncateProbability: 1}, nil)
+	resp, err := faultGet(t, ft, context.Background(), srv.URL)
+	require.NoError(t, err)
+	_, err = io.ReadAll(resp.Body)
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	ft = NewFaultTransport(FaultConfig{MalformedProbability: 1}, nil)
+	resp, err = faultGet(t, ft, context.Background(), srv.URL)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var v map[string]any
+	assert.Error(t, json.NewDecoder(resp.Body).Decode(&v))
+	assert.Equal(t, FaultCounts{Malformed: 1}, ft.Injected())
+}
+
+func TestFaultTransport_HostsAndSeed(t *testing.T) {
+	srv := faultServer(t, nil)
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	ft := NewFaultTransport(FaultConfig{ResetProbability: 1, Hosts: []string{"other.example"}}, nil)
+	resp, err := faultGet(t, ft, context.Background(), srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Zero(t, ft.Injected().Total())
+
+	outcomes := func() []bool {
+		ft := NewFaultTransport(FaultConfig{ResetProbability: 0.5, Hosts: []string{u.Hostname()}, Seed: 7}, nil)
+		var out []bool
+		for i := 0; i < 20; i++ {
+			resp, err := faultGet(t, ft, context.Background(), srv.URL)
+			if err == nil {
+				resp.Body.Close()
+			}
+			out = append(out, err != nil)
+		}
+		return out
+	}
+	first := outcomes()
+	assert.Equal(t, first, outcomes(), "the same seed must inject the same faults")
+	assert.Contains(t, first, true)
+	assert.Contains(t, first, false)
+}
+
+func TestFaultTransport_RetriesRecover(t *testing.T) {
+	var hits int32
+	srv := faultServer(t, &hits)
+	ft := NewFaultTransport(FaultConfig{ResetProbability: 0.6, Seed: 1}, nil)
+	rt := NewRetryTransport(RetryConfig{MaxRetries: 10, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}, ft)
+
+	for i := 0; i < 5; i++ {
+		req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+		require.NoError(t, err)
+		resp, err := rt.RoundTrip(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	assert.Equal(t, int32(5), atomic.LoadInt32(&hits))
+	assert.Positive(t, ft.Injected().Resets)
+}
+
+func TestWithFaultInjection(t *testing.T) {
+	_, err := NewClient(WithFaultInjection(FaultConfig{ResetProbability: 2}))
+	assert.Error(t, err)
+
+	srv := faultServer(t, nil)
+	client, err := NewClient(
+		WithNetwork(Testnet),
+		WithSorobanURL(srv.URL),
+		WithHTTPClient(&http.Client{}),
+		WithFaultInjection(FaultConfig{MalformedProbability: 1}),
+	)
+	require.NoError(t, err)
+
+	_, err = client.GetHealth(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 1, client.InjectedFaults().Malformed)
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+)
+
+// FeeStatsTTL is how long a /fee_stats response is reused. Horizon only
+// recomputes the stats once per ledger, so anything shorter would just
+// repeat the same request.
+const FeeStatsTTL = 5 * time.Second
+
+type feeStatsCache struct {
+	mu        sync.Mutex
+	stats     hProtocol.FeeStats
+	fetchedAt time.Time
+}
+
+// invalidate drops the cached stats so the next call refetches them.
+func (f *feeStatsCache) invalidate() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fetchedAt = time.Time{}
+}
+
+// FeeStats returns Horizon's fee statistics for recent ledgers, reusing a
+// response younger than FeeStatsTTL.
+func (c *Client) FeeStats(ctx context.Context) (hProtocol.FeeStats, error) {
+	c.feeStats.mu.Lock()
+	defer c.feeStats.mu.Unlock()
+
+	if !c.feeStats.fetchedAt.IsZero() && time.Since(c.feeStats.fetchedAt) < FeeStatsTTL {
+		return c.feeStats.stats, nil
+	}
+	if err := ctx.Err(); err != nil {
+		return hProtocol.FeeStats{}, err
+	}
+
+	c.logContext(ctx, LogSubsystemRPC).Debug("Fetching fee stats")
+
+	start := time.Now()
+	stats, err := c.Horizon.FeeStats()
+	if err != nil {
+		c.logContext(ctx, LogSubsystemRPC).Error("Failed to fetch fee stats", "error", err)
+		return hProtocol.FeeStats{}, c.requestError(ctx, "fee_stats", c.HorizonURL, 0, start, errors.WrapRPCConnectionFailed(err))
+	}
+	c.feeStats.stats = stats
+	c.feeStats.fetchedAt = time.Now()
+	return stats, nil
+}
+
+// SuggestClassicFee returns a per-operation base fee in stroops that would
+// have been enough for the given percentile of transactions in recent
+// ledgers. percentile must be one Horizon reports: 10-90 in steps of 10,
+// 95 or 99. The result is never below the network minimum base fee.
+func (c *Client) SuggestClassicFee(ctx context.Context, percentile int) (int64, error) {
+	stats, err := c.FeeStats(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	fee, err := SuggestFee(stats.FeeCharged, percentile, stats.LastLedgerBaseFee)
+	if err != nil {
+		return 0, err
+	}
+
+	c.logContext(ctx, LogSubsystemRPC).Debug("Suggested classic fee", "percentile", percentile, "fee", fee, "capacity_usage", stats.LedgerCapacityUsage)
+	return fee, nil
+}
+
+// SuggestFee returns the fee at percentile of d, raised to floor and to
+// the network minimum base fee. percentile must be one Horizon and Soroban
+// RPC report: 10-90 in steps of 10, 95 or 99.
+func SuggestFee(d hProtocol.FeeDistribution, percentile int, floor int64) (int64, error) {
+	fee, ok := feePercentile(d, percentile)
+	if !ok {
+		return 0, errors.WrapValidationError(fmt.Sprintf("unsupported fee percentile %d", percentile))
+	}
+	if floor < txnbuild.MinBaseFee {
+		floor = txnbuild.MinBaseFee
+	}
+	if fee < floor {
+		fee = floor
+	}
+	return fee, nil
+}
+
+func feePercentile(d hProtocol.FeeDistribution, percentile int) (int64, bool) {
+	switch percentile {
+	case 10:
+		return d.P10, true
+	case 20:
+		return d.P20, true
+	case 30:
+		return d.P30, true
+	case 40:
+		return d.P40, true
+	case 50:
+		return d.P50, true
+	case 60:
+		return d.P60, true
+	case 70:
+		return d.P70, true
+	case 80:
+		return d.P80, true
+	case 90:
+		return d.P90, true
+	case 95:
+		return d.P95, true
+	case 99:
+		return d.P99, true
+	}
+	return 0, false
+}
+
+// SorobanFeeDistribution is Soroban RPC's distribution of inclusion fees
+// bid in recent ledgers, in stroops.
+type SorobanFeeDistribution struct {
+	hProtocol.FeeDistribution
+	TransactionCount uint32 `json:"transactionCount,string"`
+	LedgerCount      uint32 `json:"ledgerCount"`
+}
+
+// SorobanFeeStats is the result of Soroban RPC's getFeeStats: inclusion
+// fee distributions for Soroban and for classic transactions.
+type SorobanFeeStats struct {
+	SorobanInclusionFee SorobanFeeDistribution `json:"sorobanInclusionFee"`
+	InclusionFee        SorobanFeeDistribution `json:"inclusionFee"`
+	LatestLedger        uint32                 `json:"latestLedger"`
+}
+
+// SorobanFeeStats returns Soroban RPC's inclusion fee statistics.
+func (c *Client) SorobanFeeStats(ctx context.Context) (*SorobanFeeStats, error) {
+	var stats SorobanFeeStats
+	if err := c.callSoroban(ctx, "getFeeStats", nil, &stats); err != nil {
+		return nil, err
+	}
+	c.observeLedger(stats.LatestLedger)
+	return &stats, nil
+}
+
+// SuggestSorobanInclusionFee is SuggestClassicFee for Soroban
+// transactions: the inclusion fee that would have been enough for the
+// given percentile of recent Soroban transactions, never below the
+// network minimum.
+func (c *Client) SuggestSorobanInclusionFee(ctx context.Context, percentile int) (int64, error) {
+	stats, err := c.SorobanFeeStats(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return SuggestFee(stats.SorobanInclusionFee.FeeDistribution, percentile, 0)
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type feeStatsHorizon struct {
+	horizonclient.ClientInterface
+	calls int
+	stats hProtocol.FeeStats
+}
+
+func (m *feeStatsHorizon) FeeStats() (hProtocol.FeeStats, error) {
+	m.calls++
+	return m.stats, nil
+}
+
+func TestSuggestClassicFee(t *testing.T) {
+	horizon := &feeStatsHorizon{stats: hProtocol.FeeStats{
+		LastLedgerBaseFee: 100,
+		FeeCharged:        hProtocol.FeeDistribution{P10: 50, P50: 100, P90: 2500, P99: 10000},
+	}}
+	client := &Client{Horizon: horizon}
+	ctx := context.Background()
+
+	fee, err := client.SuggestClassicFee(ctx, 90)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2500), fee)
+
+	fee, err = client.SuggestClassicFee(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), fee, "never below the base fee")
+
+	_, err = client.SuggestClassicFee(ctx, 75)
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+
+	assert.Equal(t, 1, horizon.calls, "fee stats are cached between calls")
+}
+
+func TestSorobanFeeStats(t *testing.T) {
+	dist := func(p50, p90 string) map[string]interface{} {
+		return map[string]interface{}{
+			"max": "5000", "min": "50", "mode": "100", "p10": "50", "p20": "60", "p30": "70", "p40": "80",
+			"p50": p50, "p60": "110", "p70": "120", "p80": "130", "p90": p90, "p95": "2000", "p99": "5000",
+			"transactionCount": "42", "ledgerCount": 50,
+		}
+	}
+	server := sorobanScript(t, map[string][]interface{}{
+		"getFeeStats": {map[string]interface{}{
+			"sorobanInclusionFee": dist("90", "1500"),
+			"inclusionFee":        dist("100", "200"),
+			"latestLedger":        1234,
+		}},
+	})
+	defer server.Close()
+	client := newArchivalTestClient(t, server.URL)
+	ctx := context.Background()
+
+	stats, err := client.SorobanFeeStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1500), stats.SorobanInclusionFee.P90)
+	assert.Equal(t, uint32(42), stats.SorobanInclusionFee.TransactionCount)
+	assert.Equal(t, uint32(50), stats.InclusionFee.LedgerCount)
+	assert.Equal(t, uint32(1234), stats.LatestLedger)
+
+	fee, err := client.SuggestSorobanInclusionFee(ctx, 50)
+	require.NoError(t, err)
+	assert.Equal(t, int64(txnbuild.MinBaseFee), fee, "never below the minimum fee")
+
+	fee, err = client.SuggestSorobanInclusionFee(ctx, 90)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1500), fee)
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func FuzzParseHeaders(f *testing.F) {
+	f.Add("")
+	f.Add("X=1,Y:2,Z=three")
+	f.Add(`{"A":"1","B":2,"C":true,"D":null,"E":[1]}`)
+	f.Add(`{"A":"1"`)
+	f.Add("null")
+	f.Add("X-Token=a\r\nInjected: 1")
+	f.Add("=,:,a=,=b")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		headers := ParseHeaders(input)
+		if headers == nil {
+			t.Fatalf("ParseHeaders(%q) returned nil", input)
+		}
+		// Every parsed header must be sendable.
+		req, err := http.NewRequest(http.MethodGet, "http://localhost", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for k, v := range headers {
+			if k == "" || v == "" {
+				t.Fatalf("ParseHeaders(%q) kept empty header %q=%q", input, k, v)
+			}
+			req.Header.Set(k, v)
+		}
+		if err := req.Header.Write(discard{}); err != nil {
+			t.Fatalf("ParseHeaders(%q) produced unwritable headers: %v", input, err)
+		}
+		for k, v := range headers {
+			if !validHeaderName(k) || !validHeaderValue(v) {
+				t.Fatalf("ParseHeaders(%q) kept invalid header %q=%q", input, k, v)
+			}
+		}
+	})
+}
+
+func FuzzIsValidURL(f *testing.F) {
+	f.Add("https://soroban-testnet.stellar.org")
+	f.Add("http://localhost:8000/rpc")
+	f.Add("ftp://example.com")
+	f.Add("https://")
+	f.Add("://")
+	f.Add("http://[::1]:8000")
+	f.Add("http://%zz")
+
+	f.Fuzz(func(t *testing.T, raw string) {
+		if isValidURL(raw) != nil {
+			return
+		}
+		u, err := url.Parse(raw)
+		if err != nil {
+			t.Fatalf("isValidURL accepted %q, which url.Parse rejects: %v", raw, err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			t.Fatalf("isValidURL accepted %q with scheme %q and host %q", raw, u.Scheme, u.Host)
+		}
+	})
+}
+
+func FuzzDecodeJSONRPCResponse(f *testing.F) {
+	f.Add([]byte(`{"jsonrpc":"2.0","id":1,"result":{"status":"healthy","latestLedger":10}}`))
+	f.Add([]byte(`{"jsonrpc":"2.0","id":"1","result":{"status":"healthy"}}`))
+	f.Add([]byte(`{"jsonrpc":"2.0","id":null,"error":{"code":-32700,"message":"parse error"}}`))
+	f.Add([]byte(`{"jsonrpc":"2.0","id":1,"result":"not an object"}`))
+	f.Add([]byte(`[{"jsonrpc":"2.0","id":1,"result":{}}]`))
+	f.Add([]byte(`null`))
+	f.Add([]byte(`<html>502 Bad Gateway</html>`))
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		var out struct {
+			Status       string `json:"status"`
+			LatestLedger uint32 `json:"latestLedger"`
+		}
+		rpcErr, err := decodeJSONRPCResponse(body, &out)
+		if rpcErr != nil && err != nil {
+			t.Fatalf("got both a JSON-RPC error and a decode error for %q", body)
+		}
+		if err != nil && len(err.Error()) > 2*maxErrorBodyLen+256 {
+			t.Fatalf("decode error quotes %d bytes of the body", len(err.Error()))
+		}
+		if err == nil && rpcErr == nil && !json.Valid(body) {
+			t.Fatalf("accepted invalid JSON %q", body)
+		}
+	})
+}
+
+type discard struct{}
+
+func (discard) Write(p []byte) (int, error) { return len(p), nil }
+
+func TestDecodeJSONRPCResponse(t *testing.T) {
+	var out struct {
+		Status string `json:"status"`
+	}
+	rpcErr, err := decodeJSONRPCResponse([]byte(`{"jsonrpc":"2.0","id":"7","result":{"status":"healthy"}}`), &out)
+	if err != nil || rpcErr != nil {
+		t.Fatalf("string id rejected: %v %v", rpcErr, err)
+	}
+	if out.Status != "healthy" {
+		t.Errorf("expected healthy, got %q", out.Status)
+	}
+
+	rpcErr, err = decodeJSONRPCResponse([]byte(`{"jsonrpc":"2.0","id":null,"error":{"code":-32700,"message":"parse error"}}`), &out)
+	if err != nil || rpcErr == nil || rpcErr.Code != -32700 {
+		t.Fatalf("expected the parse error object, got %v %v", rpcErr, err)
+	}
+}
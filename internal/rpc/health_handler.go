@@ -0,0 +1,148 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// Health statuses reported by HealthStatus.Status.
+const (
+	HealthStatusOK          = "ok"
+	HealthStatusDegraded    = "degraded"
+	HealthStatusUnavailable = "unavailable"
+)
+
+// HealthStatus is a snapshot of what the client knows about its endpoints,
+// built from the failures and successes of calls already made; taking it
+// sends no request. Status is ok when every endpoint's circuit breaker is
+// closed, degraded when at least one is, and unavailable when none is.
+type HealthStatus struct {
+	Status          string           `json:"status"`
+	Network         string           `json:"network"`
+	CurrentEndpoint string           `json:"current_endpoint"`
+	Endpoints       []EndpointHealth `json:"endpoints"`
+	LatestLedger    *ObservedLedger  `json:"latest_ledger,omitempty"`
+	Cache           CacheHealth      `json:"cache"`
+}
+
+// EndpointHealth is the circuit breaker state of one endpoint. Role is
+// "horizon" for the failover list and "soroban" for the Soroban RPC URL.
+type EndpointHealth struct {
+	URL                 string     `json:"url"`
+	Role                string     `json:"role"`
+	Current             bool       `json:"current"`
+	Healthy             bool       `json:"healthy"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	LastSuccess         *time.Time `json:"last_success,omitempty"`
+	LastFailure         *time.Time `json:"last_failure,omitempty"`
+}
+
+// ObservedLedger is the newest ledger the client has seen, from GetHealth or
+// the ledger ticker, and when it saw it.
+type ObservedLedger struct {
+	Sequence   uint32    `json:"sequence"`
+	ObservedAt time.Time `json:"observed_at"`
+}
+
+// CacheHealth reports which caches are enabled and how full they are.
+type CacheHealth struct {
+	LedgerEntries bool                   `json:"ledger_entries"`
+	Simulation    *SimulationCacheHealth `json:"simulation,omitempty"`
+}
+
+// SimulationCacheHealth describes the simulation cache, when one is set.
+type SimulationCacheHealth struct {
+	Entries int    `json:"entries"`
+	Ledger  uint32 `json:"ledger"`
+}
+
+// Health returns the client's current HealthStatus.
+func (c *Client) Health() HealthStatus {
+	c.mu.RLock()
+	status := HealthStatus{
+		Network:         c.GetNetworkName(),
+		CurrentEndpoint: c.HorizonURL,
+	}
+	seen := make(map[string]bool)
+	add := func(url, role string) {
+		if url == "" || seen[url] {
+			return
+		}
+		seen[url] = true
+		e := EndpointHealth{
+			URL:                 url,
+			Role:                role,
+			Current:             url == c.HorizonURL || url == c.SorobanURL,
+			Healthy:             c.isHealthyLocked(url),
+			ConsecutiveFailures: c.failures[url],
+		}
+		if t, ok := c.lastSuccess[url]; ok {
+			e.LastSuccess = &t
+		}
+		if t, ok := c.lastFailure[url]; ok {
+			e.LastFailure = &t
+		}
+		status.Endpoints = append(status.Endpoints, e)
+	}
+	for _, url := range c.AltURLs {
+		add(url, "horizon")
+	}
+	add(c.SorobanURL, "soroban")
+	c.mu.RUnlock()
+
+	healthy := 0
+	for _, e := range status.Endpoints {
+		if e.Healthy {
+			healthy++
+		}
+	}
+	switch {
+	case healthy == len(status.Endpoints):
+		status.Status = HealthStatusOK
+	case healthy > 0:
+		status.Status = HealthStatusDegraded
+	default:
+		status.Status = HealthStatusUnavailable
+	}
+
+	c.ledgers.mu.Lock()
+	if c.ledgers.observed > 0 {
+		status.LatestLedger = &ObservedLedger{Sequence: c.ledgers.observed, ObservedAt: c.ledgers.observedAt}
+	}
+	c.ledgers.mu.Unlock()
+
+	status.Cache.LedgerEntries = c.CacheEnabled
+	if sc := c.SimulationCache; sc != nil {
+		sc.mu.Lock()
+		status.Cache.Simulation = &SimulationCacheHealth{Entries: len(sc.entries), Ledger: sc.latestLedger}
+		sc.mu.Unlock()
+	}
+	return status
+}
+
+// HealthHandler serves Health as JSON, for mounting at a service's /healthz.
+// It answers 200 while any endpoint is usable and 503 once every circuit
+// breaker is open.
+func (c *Client) HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		status := c.Health()
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		if status.Status == HealthStatusUnavailable {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		if r.Method == http.MethodHead {
+			return
+		}
+		_ = json.NewEncoder(w).Encode(status)
+	})
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthHandler(t *testing.T) {
+	client, err := NewClient(
+		WithNetwork(Testnet),
+		WithAltURLs([]string{"https://a.example", "https://b.example"}),
+		WithSorobanURL("https://rpc.example"),
+		WithSimulationCache(time.Minute),
+	)
+	require.NoError(t, err)
+
+	client.markSuccess("https://rpc.example")
+	client.observeLedger(120)
+	client.observeLedger(110)
+	for i := 0; i < 5; i++ {
+		client.markFailure("https://b.example")
+	}
+
+	rec := httptest.NewRecorder()
+	client.HealthHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var status HealthStatus
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	assert.Equal(t, HealthStatusDegraded, status.Status)
+	assert.Equal(t, "testnet", status.Network)
+	assert.Equal(t, "https://a.example", status.CurrentEndpoint)
+	require.Len(t, status.Endpoints, 3)
+	assert.True(t, status.Endpoints[0].Current)
+	assert.False(t, status.Endpoints[1].Healthy)
+	assert.Equal(t, 5, status.Endpoints[1].ConsecutiveFailures)
+	assert.NotNil(t, status.Endpoints[1].LastFailure)
+	assert.Equal(t, "soroban", status.Endpoints[2].Role)
+	assert.NotNil(t, status.Endpoints[2].LastSuccess)
+	require.NotNil(t, status.LatestLedger)
+	assert.Equal(t, uint32(120), status.LatestLedger.Sequence)
+	assert.True(t, status.Cache.LedgerEntries)
+	require.NotNil(t, status.Cache.Simulation)
+	assert.Equal(t, uint32(120), status.Cache.Simulation.Ledger)
+
+	for _, url := range []string{"https://a.example", "https://rpc.example"} {
+		for i := 0; i < 5; i++ {
+			client.markFailure(url)
+		}
+	}
+	rec = httptest.NewRecorder()
+	client.HealthHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodHead, "/healthz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Empty(t, rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	client.HealthHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/healthz", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
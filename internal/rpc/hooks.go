@@ -0,0 +1,174 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RequestEvent is passed to request hooks before each HTTP attempt. Method
+// is the JSON-RPC method for Soroban calls and the HTTP method and leading
+// path segment, such as "GET /accounts", for Horizon ones. Attempt counts
+// transport retries from 1.
+type RequestEvent struct {
+	Method   string
+	Endpoint string
+	Attempt  int
+}
+
+// ResponseEvent is passed to response hooks when an HTTP attempt completes.
+// Err is set when no response arrived; StatusCode is zero then. Duration
+// runs until the response headers arrive.
+type ResponseEvent struct {
+	Method     string
+	Endpoint   string
+	Attempt    int
+	StatusCode int
+	Duration   time.Duration
+	Err        error
+}
+
+// RetryEvent is passed to retry hooks when an attempt failed and is about
+// to be retried after Backoff. Attempt, Duration, StatusCode and Err
+// describe the attempt that failed.
+type RetryEvent struct {
+	Method     string
+	Endpoint   string
+	Attempt    int
+	StatusCode int
+	Duration   time.Duration
+	Backoff    time.Duration
+	Err        error
+}
+
+// FailoverEvent is passed to failover hooks when a call gives up on a node
+// and rotates to the next. Method is the operation being called, Attempt
+// counts the nodes tried from 1, Duration is the time spent on the failed
+// node and Err why it failed. From and To are node URLs.
+type FailoverEvent struct {
+	Method   string
+	From     string
+	To       string
+	Attempt  int
+	Duration time.Duration
+	Err      error
+}
+
+// clientHooks holds the hooks registered with the With*Hook options. A nil
+// *clientHooks has none.
+type clientHooks struct {
+	onRequest  []func(RequestEvent)
+	onResponse []func(ResponseEvent)
+	onRetry    []func(RetryEvent)
+	onFailover []func(FailoverEvent)
+}
+
+// transportHooks reports whether request or response hooks need the HTTP
+// transport wrapped.
+func (h *clientHooks) transportHooks() bool {
+	return h != nil && (len(h.onRequest) > 0 || len(h.onResponse) > 0)
+}
+
+func (h *clientHooks) request(ev RequestEvent) {
+	if h == nil {
+		return
+	}
+	for _, fn := range h.onRequest {
+		fn(ev)
+	}
+}
+
+func (h *clientHooks) response(ev ResponseEvent) {
+	if h == nil {
+		return
+	}
+	for _, fn := range h.onResponse {
+		fn(ev)
+	}
+}
+
+func (h *clientHooks) retry(ev RetryEvent) {
+	if h == nil {
+		return
+	}
+	for _, fn := range h.onRetry {
+		fn(ev)
+	}
+}
+
+func (h *clientHooks) failover(ev FailoverEvent) {
+	if h == nil {
+		return
+	}
+	for _, fn := range h.onFailover {
+		fn(ev)
+	}
+}
+
+type attemptKey struct{}
+
+// contextWithAttempt records the 1-based transport attempt a request is,
+// for the hook transport beneath the retry transport.
+func contextWithAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, attemptKey{}, attempt)
+}
+
+func attemptFromContext(ctx context.Context) int {
+	if n, ok := ctx.Value(attemptKey{}).(int); ok {
+		return n
+	}
+	return 1
+}
+
+// hookTransport calls the request and response hooks around each attempt.
+type hookTransport struct {
+	hooks     *clientHooks
+	transport http.RoundTripper
+}
+
+func (t *hookTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(t.hooks.onRequest) == 0 && len(t.hooks.onResponse) == 0 {
+		return t.transport.RoundTrip(req)
+	}
+	method, endpoint := requestMethodLabel(req), req.URL.Host
+	attempt := attemptFromContext(req.Context())
+	t.hooks.request(RequestEvent{Method: method, Endpoint: endpoint, Attempt: attempt})
+
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(req)
+	ev := ResponseEvent{Method: method, Endpoint: endpoint, Attempt: attempt, Duration: time.Since(start), Err: err}
+	if resp != nil {
+		ev.StatusCode = resp.StatusCode
+	}
+	t.hooks.response(ev)
+	return resp, err
+}
+
+// failover rotates to the next node after the call to operation failed on
+// its attempt-th node with cause, telling the failover hooks. It returns
+// rotateURL's result.
+func (c *Client) failover(operation string, attempt int, cause error, elapsed time.Duration) bool {
+	c.mu.RLock()
+	from := c.HorizonURL
+	c.mu.RUnlock()
+	if !c.rotateURL() {
+		return false
+	}
+	if c.hooks != nil && len(c.hooks.onFailover) > 0 {
+		c.mu.RLock()
+		to := c.HorizonURL
+		c.mu.RUnlock()
+		c.hooks.failover(FailoverEvent{
+			Method:   operation,
+			From:     from,
+			To:       to,
+			Attempt:  attempt + 1,
+			Duration: elapsed,
+			Err:      cause,
+		})
+	}
+	return true
+}
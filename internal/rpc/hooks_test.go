@@ -0,0 +1,115 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHooks_RequestResponseRetry(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"status":"healthy"}}`))
+	}))
+	defer server.Close()
+
+	var requests []RequestEvent
+	var responses []ResponseEvent
+	var retries []RetryEvent
+	hooks := &clientHooks{
+		onRequest:  []func(RequestEvent){func(ev RequestEvent) { requests = append(requests, ev) }},
+		onResponse: []func(ResponseEvent){func(ev ResponseEvent) { responses = append(responses, ev) }},
+		onRetry:    []func(RetryEvent){func(ev RetryEvent) { retries = append(retries, ev) }},
+	}
+	rt := NewRetryTransport(RetryConfig{
+		MaxRetries:         2,
+		InitialBackoff:     time.Millisecond,
+		MaxBackoff:         5 * time.Millisecond,
+		StatusCodesToRetry: []int{http.StatusServiceUnavailable},
+	}, &hookTransport{hooks: hooks, transport: http.DefaultTransport})
+	rt.hooks = hooks
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"getHealth"}`))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := (&http.Client{Transport: rt}).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	host := strings.TrimPrefix(server.URL, "http://")
+	assert.Equal(t, []RequestEvent{
+		{Method: "getHealth", Endpoint: host, Attempt: 1},
+		{Method: "getHealth", Endpoint: host, Attempt: 2},
+	}, requests)
+
+	require.Len(t, responses, 2)
+	assert.Equal(t, http.StatusServiceUnavailable, responses[0].StatusCode)
+	assert.Equal(t, 1, responses[0].Attempt)
+	assert.Equal(t, http.StatusOK, responses[1].StatusCode)
+	assert.Equal(t, 2, responses[1].Attempt)
+	assert.Positive(t, responses[1].Duration)
+
+	require.Len(t, retries, 1)
+	assert.Equal(t, "getHealth", retries[0].Method)
+	assert.Equal(t, 1, retries[0].Attempt)
+	assert.Equal(t, http.StatusServiceUnavailable, retries[0].StatusCode)
+	assert.Positive(t, retries[0].Backoff)
+	assert.Error(t, retries[0].Err)
+}
+
+func TestHooks_Failover(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32603,"message":"internal"}}`))
+	}))
+	defer server.Close()
+
+	var events []FailoverEvent
+	client, err := NewClient(
+		WithNetwork(Testnet),
+		WithSorobanURL(server.URL),
+		WithAltURLs([]string{"https://a.example", "https://b.example"}),
+		WithFailoverHook(func(ev FailoverEvent) { events = append(events, ev) }),
+		WithFailoverHook(nil),
+	)
+	require.NoError(t, err)
+
+	_, err = client.GetHealth(context.Background())
+	require.Error(t, err)
+
+	require.Len(t, events, 1)
+	assert.Equal(t, "getHealth", events[0].Method)
+	assert.Equal(t, "https://a.example", events[0].From)
+	assert.Equal(t, "https://b.example", events[0].To)
+	assert.Equal(t, 1, events[0].Attempt)
+	assert.ErrorContains(t, events[0].Err, "internal")
+}
+
+func TestHooks_CustomHTTPClient(t *testing.T) {
+	server, _ := newHeaderRecordingServer(t, "")
+	var methods []string
+	client, err := NewClient(
+		WithNetwork(Testnet),
+		WithSorobanURL(server.URL),
+		WithHTTPClient(server.Client()),
+		WithRequestHook(func(ev RequestEvent) { methods = append(methods, ev.Method) }),
+	)
+	require.NoError(t, err)
+
+	_, err = client.GetHealth(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"getHealth"}, methods)
+}
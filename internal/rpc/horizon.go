@@ -0,0 +1,194 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+)
+
+// getHorizon performs a GET against a Horizon REST endpoint with automatic
+// failover and decodes the JSON body into out. It is used for endpoints the
+// horizonclient interface does not expose.
+func (c *Client) getHorizon(ctx context.Context, path string, query url.Values, out interface{}) error {
+	if len(c.AltURLs) == 0 {
+		return &AllNodesFailedError{}
+	}
+	var failures []NodeFailure
+	for attempt := 0; attempt < len(c.AltURLs); attempt++ {
+		baseURL := c.HorizonURL
+		hopStart := time.Now()
+		hopCtx, span := startFailoverHop(ctx, path, attempt, baseURL)
+		err := c.getHorizonAttempt(hopCtx, baseURL, path, query, out)
+		endHop(ctx, span, hopStart, err)
+		err = c.requestError(ctx, path, baseURL, attempt, hopStart, err)
+		if err == nil {
+			c.markSuccess(baseURL)
+			return nil
+		}
+		if isClientRequestError(err) || ctx.Err() != nil {
+			// The request itself is wrong; another node will answer the same.
+			return err
+		}
+
+		c.markFailure(baseURL)
+		failures = append(failures, NodeFailure{URL: baseURL, Reason: err})
+
+		if attempt < len(c.AltURLs)-1 {
+			c.logContext(ctx, LogSubsystemFailover).Warn("Retrying Horizon request with fallback RPC...", "path", path, "error", err)
+			if !c.failover(path, attempt, err, time.Since(hopStart)) {
+				break
+			}
+		}
+	}
+	return &AllNodesFailedError{Failures: failures}
+}
+
+func (c *Client) getHorizonAttempt(ctx context.Context, baseURL, path string, query url.Values, out interface{}) error {
+	if !c.isHealthy(baseURL) {
+		return errors.WrapRPCConnectionFailed(fmt.Errorf("circuit breaker open for %s", baseURL))
+	}
+
+	target := strings.TrimRight(baseURL, "/") + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	c.logContext(ctx, LogSubsystemRPC).Debug("Calling Horizon", "url", target)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return errors.WrapRPCConnectionFailed(err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.getHTTPClient().Do(req)
+	if err != nil {
+		return errors.WrapRPCConnectionFailed(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.WrapUnmarshalFailed(err, "body read error")
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.ParseHorizonProblem(baseURL, resp.StatusCode, body)
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return errors.WrapUnmarshalFailed(err, string(body))
+	}
+	return nil
+}
+
+// AsHorizonError extracts a typed Horizon problem from err. It recognises
+// both errors produced by this package and horizonclient errors wrapped by
+// the client's methods.
+func AsHorizonError(err error) (*errors.HorizonError, bool) {
+	var hErr *errors.HorizonError
+	if errors.As(err, &hErr) {
+		return hErr, true
+	}
+	var clientErr *horizonclient.Error
+	if !errors.As(err, &clientErr) {
+		var value horizonclient.Error
+		if !errors.As(err, &value) {
+			return nil, false
+		}
+		clientErr = &value
+	}
+	url := ""
+	if clientErr.Response != nil && clientErr.Response.Request != nil {
+		url = clientErr.Response.Request.URL.Host
+	}
+	p := clientErr.Problem
+	return errors.NewHorizonError(url, p.Status, p.Type, p.Title, p.Detail, p.Extras), true
+}
+
+// isClientRequestError reports whether err is a 4xx response, other than a
+// retryable one such as rate limiting, which failing over to another node
+// will not fix.
+func isClientRequestError(err error) bool {
+	var codeErr *errors.RPCCodeError
+	if !errors.As(err, &codeErr) {
+		return false
+	}
+	return codeErr.Code >= 400 && codeErr.Code < 500 && !errors.IsRetryable(err)
+}
+
+// assetQueryParams adds Horizon's <prefix>_asset_type/_code/_issuer parameters.
+func assetQueryParams(q url.Values, prefix string, asset txnbuild.Asset) error {
+	assetType, code, issuer, err := horizonAssetFields(prefix, asset)
+	if err != nil {
+		return err
+	}
+	q.Set(prefix+"_asset_type", string(assetType))
+	if assetType != horizonclient.AssetTypeNative {
+		q.Set(prefix+"_asset_code", code)
+		q.Set(prefix+"_asset_issuer", issuer)
+	}
+	return nil
+}
+
+// horizonAssetFields splits an asset into the type/code/issuer triple used by
+// horizonclient requests. role names the asset in validation errors.
+func horizonAssetFields(role string, asset txnbuild.Asset) (horizonclient.AssetType, string, string, error) {
+	if asset == nil {
+		return "", "", "", errors.WrapValidationError(role + " asset is required")
+	}
+	if asset.IsNative() {
+		return horizonclient.AssetTypeNative, "", "", nil
+	}
+	assetType, err := asset.GetType()
+	if err != nil {
+		return "", "", "", errors.WrapValidationError(fmt.Sprintf("invalid %s asset: %v", role, err))
+	}
+	if assetType == txnbuild.AssetTypeCreditAlphanum12 {
+		return horizonclient.AssetType12, asset.GetCode(), asset.GetIssuer(), nil
+	}
+	return horizonclient.AssetType4, asset.GetCode(), asset.GetIssuer(), nil
+}
+
+// canonicalAssetList renders assets in Horizon's comma-separated
+// "native" / "CODE:ISSUER" list form.
+func canonicalAssetList(assets []txnbuild.Asset) string {
+	parts := make([]string, 0, len(assets))
+	for _, a := range assets {
+		if a.IsNative() {
+			parts = append(parts, "native")
+			continue
+		}
+		parts = append(parts, a.GetCode()+":"+a.GetIssuer())
+	}
+	return strings.Join(parts, ",")
+}
+
+// assetFromHorizon converts Horizon's asset_type/code/issuer triple.
+func assetFromHorizon(assetType, code, issuer string) txnbuild.Asset {
+	if assetType == "native" || code == "" {
+		return txnbuild.NativeAsset{}
+	}
+	return txnbuild.CreditAsset{Code: code, Issuer: issuer}
+}
+
+// assetFromCanonical parses Horizon's "native" / "CODE:ISSUER" asset form.
+func assetFromCanonical(s string) txnbuild.Asset {
+	code, issuer, ok := strings.Cut(s, ":")
+	if !ok {
+		return txnbuild.NativeAsset{}
+	}
+	return txnbuild.CreditAsset{Code: code, Issuer: issuer}
+}
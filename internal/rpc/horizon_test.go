@@ -0,0 +1,34 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"fmt"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsHorizonError_WrappedClientError(t *testing.T) {
+	clientErr := &horizonclient.Error{Problem: problem.P{
+		Status: 400,
+		Title:  "Transaction Failed",
+		Extras: map[string]interface{}{
+			"result_codes": map[string]interface{}{"transaction": "tx_insufficient_fee"},
+		},
+	}}
+	err := errs.WrapRPCConnectionFailed(clientErr)
+
+	hErr, ok := AsHorizonError(err)
+	require.True(t, ok)
+	assert.True(t, hErr.IsInsufficientFee())
+	assert.ErrorIs(t, hErr, errs.ErrTxInsufficientFee)
+
+	_, ok = AsHorizonError(fmt.Errorf("plain"))
+	assert.False(t, ok)
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dotandev/hintents/internal/rpc/metrics"
+)
+
+// instrument wraps hc so every request it sends is recorded by m and
+// vars. The client is copied rather than modified, since it may have been
+// supplied by the caller.
+func instrument(hc *http.Client, m *metrics.Collector, vars *expvarSet) *http.Client {
+	if m == nil && vars == nil {
+		return hc
+	}
+	out := *hc
+	transport := out.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	out.Transport = &metricsTransport{metrics: m, vars: vars, transport: transport}
+	return &out
+}
+
+// metricsTransport records one request per round trip, including any retries
+// made beneath it, and labels it with the JSON-RPC method for Soroban calls
+// or the HTTP method and leading path segment for Horizon ones. JSON-RPC
+// error objects arrive with HTTP 200 and are counted by callSoroban.
+type metricsTransport struct {
+	metrics   *metrics.Collector
+	vars      *expvarSet
+	transport http.RoundTripper
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	method := requestMethodLabel(req)
+	endpoint := req.URL.Host
+	start := time.Now()
+
+	resp, err := t.transport.RoundTrip(req)
+	elapsed := time.Since(start)
+	if err != nil {
+		t.metrics.ObserveRequest(method, endpoint, "transport_error", elapsed)
+		t.metrics.ObserveError(method, endpoint, "transport")
+		t.vars.observeRequest(method, endpoint, "transport")
+		return nil, err
+	}
+
+	status, errKind := "ok", ""
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		status = strconv.Itoa(resp.StatusCode)
+		errKind = "http_" + status
+		t.metrics.ObserveError(method, endpoint, errKind)
+	}
+	t.metrics.ObserveRequest(method, endpoint, status, elapsed)
+	t.vars.observeRequest(method, endpoint, errKind)
+	return resp, nil
+}
+
+// requestMethodLabel names the API method req calls with a bounded set of
+// values, so that account IDs or hashes in Horizon paths never become labels.
+func requestMethodLabel(req *http.Request) string {
+	if req.Method == http.MethodPost && req.GetBody != nil &&
+		strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") {
+		if body, err := req.GetBody(); err == nil {
+			var rpc struct {
+				Method string `json:"method"`
+			}
+			err := json.NewDecoder(io.LimitReader(body, 1<<20)).Decode(&rpc)
+			body.Close()
+			if err == nil && rpc.Method != "" {
+				return rpc.Method
+			}
+		}
+	}
+	segment := strings.TrimPrefix(req.URL.Path, "/")
+	if i := strings.IndexByte(segment, '/'); i >= 0 {
+		segment = segment[:i]
+	}
+	return req.Method + " /" + segment
+}
+
+// endpointLabel reduces a node URL to the host used as the endpoint label.
+func endpointLabel(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	return u.Host
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dotandev/hintents/internal/rpc/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithMetricsCollector(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/accounts/"):
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"status":404,"title":"Resource Missing"}`))
+		case r.Method == http.MethodPost:
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"method not found"}}`))
+		default:
+			_, _ = w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+	host := endpointLabel(server.URL)
+
+	reg := prometheus.NewRegistry()
+	client, err := NewClient(
+		WithHorizonURL(server.URL),
+		WithSorobanURL(server.URL),
+		WithMetricsCollector(reg),
+	)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	assert.Error(t, client.callSoroban(ctx, "getFoo", nil, nil))
+	assert.Error(t, client.getHorizon(ctx, "/accounts/GABC", url.Values{}, &struct{}{}))
+
+	expected := `
+# HELP erst_rpc_errors_total RPC requests that failed, by method, endpoint and kind of failure.
+# TYPE erst_rpc_errors_total counter
+erst_rpc_errors_total{endpoint="` + host + `",kind="http_404",method="GET /accounts"} 1
+erst_rpc_errors_total{endpoint="` + host + `",kind="rpc_-32601",method="getFoo"} 1
+# HELP erst_rpc_requests_total RPC requests sent, by method, endpoint and outcome.
+# TYPE erst_rpc_requests_total counter
+erst_rpc_requests_total{endpoint="` + host + `",method="GET /accounts",status="404"} 1
+erst_rpc_requests_total{endpoint="` + host + `",method="getFoo",status="ok"} 1
+`
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected),
+		"erst_rpc_requests_total", "erst_rpc_errors_total"))
+	assert.Equal(t, 2, testutil.CollectAndCount(reg, "erst_rpc_request_duration_seconds"))
+}
+
+func TestWithMetricsCollector_CustomHTTPClient(t *testing.T) {
+	custom := &http.Client{Timeout: time.Second}
+	client, err := NewClient(WithHTTPClient(custom), WithMetricsCollector(prometheus.NewRegistry()))
+	require.NoError(t, err)
+	assert.Nil(t, custom.Transport, "the caller's client is not modified")
+	assert.IsType(t, &metricsTransport{}, client.httpClient.Transport)
+}
+
+func TestRetryTransportMetrics(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	reg := prometheus.NewRegistry()
+	m, err := metrics.New(reg)
+	require.NoError(t, err)
+	cfg := DefaultRetryConfig()
+	cfg.InitialBackoff = time.Millisecond
+	rt := NewRetryTransport(cfg, nil)
+	rt.metrics = m
+	hc := instrument(&http.Client{Transport: rt}, m, nil)
+
+	resp, err := hc.Get(server.URL + "/fee_stats")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	expected := `
+# HELP erst_rpc_retries_total Transport-level retries, by endpoint and reason.
+# TYPE erst_rpc_retries_total counter
+erst_rpc_retries_total{endpoint="` + endpointLabel(server.URL) + `",reason="503"} 1
+`
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected), "erst_rpc_retries_total"))
+}
+
+func TestFailoverMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m, err := metrics.New(reg)
+	require.NoError(t, err)
+	client := &Client{
+		HorizonURL:  "https://a.example",
+		AltURLs:     []string{"https://a.example", "https://b.example"},
+		failures:    map[string]int{},
+		lastFailure: map[string]time.Time{},
+		metrics:     m,
+	}
+	require.True(t, client.rotateURL())
+
+	expected := `
+# HELP erst_rpc_failovers_total Switches to a fallback node, by the node left and the node chosen.
+# TYPE erst_rpc_failovers_total counter
+erst_rpc_failovers_total{from="a.example",to="b.example"} 1
+`
+	assert.NoError(t, testutil.GatherAndCompare(reg, strings.NewReader(expected), "erst_rpc_failovers_total"))
+}
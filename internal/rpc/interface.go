@@ -0,0 +1,108 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/effects"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/operations"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+)
+
+// RPCClient is the full method set of *Client. Code that talks to the
+// network should accept RPCClient, or the narrowest of the interfaces it
+// embeds that covers what it uses, so tests can substitute a fake.
+type RPCClient interface {
+	SorobanClient
+	HorizonClient
+	StreamClient
+	ClientStatus
+}
+
+// SorobanClient covers the Soroban RPC methods of *Client.
+type SorobanClient interface {
+	GetTransaction(ctx context.Context, hash string) (*TransactionResponse, error)
+	GetLedgerHeader(ctx context.Context, sequence uint32) (*LedgerHeaderResponse, error)
+	GetLedgerEntries(ctx context.Context, keys []string) (map[string]string, error)
+	GetLedgerEntriesWithTTL(ctx context.Context, keys []string) (*LedgerEntriesWithTTL, error)
+	GetLatestLedger(ctx context.Context) (*LedgerInfo, error)
+	GetEvents(ctx context.Context, req EventsRequest) (*EventsPage, error)
+	GetNetwork(ctx context.Context) (*NetworkInfo, error)
+	VerifyNetwork(ctx context.Context) error
+	GetHealth(ctx context.Context) (*GetHealthResponse, error)
+	SimulateTransaction(ctx context.Context, envelopeXdr string) (*SimulateTransactionResponse, error)
+	SimulateTransactionWithRetry(ctx context.Context, envelopeXdr string, config RetryConfig) (*SimulateTransactionResponse, error)
+}
+
+// HorizonClient covers the Horizon methods of *Client that return a
+// result, as opposed to streaming.
+type HorizonClient interface {
+	AccountDetails(ctx context.Context, id string) (*AccountDetails, error)
+	GetAccounts(ctx context.Context, limit int) ([]AccountSummary, error)
+	GetAccountTransactions(ctx context.Context, account string, limit int) ([]TransactionSummary, error)
+	GetEventsForAccount(ctx context.Context, account string, limit int) ([]EventSummary, error)
+
+	Transactions(ctx context.Context, req horizonclient.TransactionRequest, opts ...IteratorOption) *Iterator[hProtocol.Transaction]
+	Operations(ctx context.Context, req horizonclient.OperationRequest, opts ...IteratorOption) *Iterator[operations.Operation]
+	Payments(ctx context.Context, req horizonclient.OperationRequest, opts ...IteratorOption) *Iterator[operations.Operation]
+	Effects(ctx context.Context, req horizonclient.EffectRequest, opts ...IteratorOption) *Iterator[effects.Effect]
+	TypedEffects(ctx context.Context, req horizonclient.EffectRequest, opts ...IteratorOption) ([]Effect, error)
+	Accounts(ctx context.Context, req horizonclient.AccountsRequest, opts ...IteratorOption) *Iterator[hProtocol.Account]
+	Ledgers(ctx context.Context, req horizonclient.LedgerRequest, opts ...IteratorOption) *Iterator[hProtocol.Ledger]
+	Assets(ctx context.Context, req horizonclient.AssetRequest, opts ...IteratorOption) *Iterator[hProtocol.AssetStat]
+	Offers(ctx context.Context, req horizonclient.OfferRequest, opts ...IteratorOption) *Iterator[hProtocol.Offer]
+	Trades(ctx context.Context, req horizonclient.TradeRequest, opts ...IteratorOption) *Iterator[hProtocol.Trade]
+	LiquidityPools(ctx context.Context, req horizonclient.LiquidityPoolsRequest, opts ...IteratorOption) *Iterator[hProtocol.LiquidityPool]
+
+	AssetStats(ctx context.Context, filter AssetStatsFilter, opts ...IteratorOption) ([]AssetStatistics, error)
+	AssetStat(ctx context.Context, asset txnbuild.Asset) (*AssetStatistics, error)
+	AssetHolders(ctx context.Context, asset txnbuild.Asset, opts ...IteratorOption) *Iterator[hProtocol.Account]
+	ClaimableBalances(ctx context.Context, filter ClaimableBalanceFilter) ([]ClaimableBalance, error)
+	ClaimableBalance(ctx context.Context, id string) (*ClaimableBalance, error)
+	LiquidityPoolsByReserves(ctx context.Context, reserves []txnbuild.Asset, opts ...IteratorOption) ([]LiquidityPoolInfo, error)
+	LiquidityPool(ctx context.Context, poolID string) (*LiquidityPoolInfo, error)
+	LiquidityPoolTrades(ctx context.Context, poolID string, opts ...IteratorOption) *Iterator[hProtocol.Trade]
+	LiquidityPoolEffects(ctx context.Context, poolID string, opts ...IteratorOption) *Iterator[effects.Effect]
+	OrderBook(ctx context.Context, selling, buying txnbuild.Asset, depth int) (*OrderBook, error)
+	TradeAggregations(ctx context.Context, pair AssetPair, resolution time.Duration, rng TimeRange) ([]TradeBucket, error)
+	FindStrictSendPaths(ctx context.Context, req StrictSendPathRequest) ([]PaymentPath, error)
+	FindStrictReceivePaths(ctx context.Context, req StrictReceivePathRequest) ([]PaymentPath, error)
+
+	FeeStats(ctx context.Context) (hProtocol.FeeStats, error)
+	SuggestClassicFee(ctx context.Context, percentile int) (int64, error)
+
+	ValidateEnvelope(ctx context.Context, envelopeXdr string) error
+	SubmitTransactionAsync(ctx context.Context, envelopeXdr string) (*AsyncSubmitResult, error)
+	WaitForTransaction(ctx context.Context, hash string, cfg PollConfig) (*hProtocol.Transaction, error)
+}
+
+// StreamClient covers the long-running methods of *Client that deliver
+// records as they arrive.
+type StreamClient interface {
+	StreamTransactions(ctx context.Context, cursor string, handler TransactionStreamHandler) error
+	StreamLedgers(ctx context.Context, cursor string, handler LedgerStreamHandler) error
+	StreamOperations(ctx context.Context, cursor string, handler OperationStreamHandler) error
+	StreamPayments(ctx context.Context, cursor string, handler OperationStreamHandler) error
+	StreamEffects(ctx context.Context, cursor string, handler EffectStreamHandler) error
+	StreamTypedEffects(ctx context.Context, cursor string, handler func(Effect) error) error
+	WatchAccount(ctx context.Context, accountID string) (<-chan AccountEvent, error)
+	OnLedger(fn func(LedgerInfo)) (unsubscribe func())
+	RunLedgerTicker(ctx context.Context, cfg LedgerTickerConfig) error
+}
+
+// ClientStatus covers the methods of *Client that report its
+// configuration and health without making a request.
+type ClientStatus interface {
+	GetNetworkPassphrase() string
+	GetNetworkName() string
+	Health() HealthStatus
+	HealthHandler() http.Handler
+}
+
+var _ RPCClient = (*Client)(nil)
@@ -3,7 +3,10 @@
 
 package rpc
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+)
 
 const horizonPageMaxLimit = 200
 
@@ -24,37 +27,151 @@ type pageIterator[P any, R any] struct {
 	max     int
 }
 
-func (it pageIterator[P, R]) collect() ([]R, error) {
-	page, err := it.first()
-	if err != nil {
-		return nil, err
-	}
-
-	out := make([]R, 0)
-	for {
-		rows := it.records(page)
-		if len(rows) == 0 {
-			return out, nil
-		}
+// Iterator walks every record of a paged Horizon collection, following the
+// next links transparently:
+//
+//	it := client.Transactions(ctx, horizonclient.TransactionRequest{ForAccount: id}, rpc.MaxRecords(500))
+//	for it.Next() {
+//		tx := it.Record()
+//	}
+//	if err := it.Err(); err != nil { ... }
+type Iterator[R any] struct {
+	ctx     context.Context
+	fetch   func(first bool) ([]R, error)
+	max     int
+	buf     []R
+	pos     int
+	seen    int
+	started bool
+	done    bool
+	current R
+	err     error
+}
 
-		if it.max > 0 {
-			remaining := it.max - len(out)
-			if remaining <= 0 {
-				return out, nil
+func newIterator[P any, R any](ctx context.Context, src pageIterator[P, R]) *Iterator[R] {
+	var page P
+	return &Iterator[R]{
+		ctx: ctx,
+		max: src.max,
+		fetch: func(first bool) ([]R, error) {
+			var err error
+			if first {
+				page, err = src.first()
+			} else {
+				page, err = src.next(page)
+				if err != nil {
+					err = fmt.Errorf("fetch next page: %w", err)
+				}
 			}
-			if len(rows) > remaining {
-				rows = rows[:remaining]
+			if err != nil {
+				return nil, err
 			}
-		}
-		out = append(out, rows...)
+			return src.records(page), nil
+		},
+	}
+}
 
-		if it.max > 0 && len(out) >= it.max {
-			return out, nil
-		}
+// NewSliceIterator returns an iterator over records, for fakes of the
+// collection methods. A non-nil err is reported by Err once the records
+// are exhausted, as if fetching the following page had failed.
+func NewSliceIterator[R any](ctx context.Context, records []R, err error) *Iterator[R] {
+	fetched := 0
+	return &Iterator[R]{
+		ctx: ctx,
+		fetch: func(bool) ([]R, error) {
+			fetched++
+			switch {
+			case fetched == 1 && len(records) > 0:
+				return records, nil
+			case err != nil:
+				return nil, err
+			}
+			return nil, nil
+		},
+	}
+}
 
-		page, err = it.next(page)
+// Next advances to the next record, fetching the following page when the
+// current one is exhausted. It returns false at the end of the collection,
+// once the record limit is reached, or on error.
+func (it *Iterator[R]) Next() bool {
+	if it.done {
+		return false
+	}
+	if it.max > 0 && it.seen >= it.max {
+		it.done = true
+		return false
+	}
+	if it.pos >= len(it.buf) {
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			it.done = true
+			return false
+		}
+		rows, err := it.fetch(!it.started)
+		it.started = true
 		if err != nil {
-			return out, fmt.Errorf("fetch next page: %w", err)
+			it.err = err
+			it.done = true
+			return false
+		}
+		if len(rows) == 0 {
+			it.done = true
+			return false
 		}
+		it.buf, it.pos = rows, 0
+	}
+	it.current = it.buf[it.pos]
+	it.pos++
+	it.seen++
+	return true
+}
+
+// Record returns the record Next advanced to.
+func (it *Iterator[R]) Record() R {
+	return it.current
+}
+
+// Err returns the error that stopped iteration, if any.
+func (it *Iterator[R]) Err() error {
+	return it.err
+}
+
+// Collect drains the iterator. On error the records read so far are returned
+// alongside it.
+func (it *Iterator[R]) Collect() ([]R, error) {
+	out := make([]R, 0)
+	for it.Next() {
+		out = append(out, it.Record())
+	}
+	return out, it.Err()
+}
+
+// IteratorOption configures a collection iterator.
+type IteratorOption func(*iteratorOptions)
+
+type iteratorOptions struct {
+	pageSize   int
+	maxRecords int
+}
+
+// PageSize sets the number of records requested per page (at most 200).
+func PageSize(n int) IteratorOption {
+	return func(o *iteratorOptions) { o.pageSize = n }
+}
+
+// MaxRecords stops iteration after n records; 0 means no limit.
+func MaxRecords(n int) IteratorOption {
+	return func(o *iteratorOptions) { o.maxRecords = n }
+}
+
+// resolveIteratorOptions applies opts on top of the page size already set on
+// the request, defaulting to the largest page Horizon allows.
+func resolveIteratorOptions(requestLimit uint, opts []IteratorOption) iteratorOptions {
+	o := iteratorOptions{pageSize: int(requestLimit)}
+	for _, opt := range opts {
+		opt(&o)
 	}
+	o.pageSize = normalizePageSize(o.pageSize)
+	return o
 }
@@ -0,0 +1,117 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pagedHorizon serves numbered transactions in pages of the requested size.
+type pagedHorizon struct {
+	horizonclient.ClientInterface
+	total     int
+	failAfter int
+	limits    []uint
+	pageCalls int
+}
+
+func (p *pagedHorizon) page(start int, limit uint) hProtocol.TransactionsPage {
+	var page hProtocol.TransactionsPage
+	for i := start; i < start+int(limit) && i < p.total; i++ {
+		page.Embedded.Records = append(page.Embedded.Records, hProtocol.Transaction{
+			Hash: fmt.Sprintf("tx%d", i),
+		})
+	}
+	page.Links.Next.Href = fmt.Sprintf("%d/%d", start+int(limit), limit)
+	return page
+}
+
+func (p *pagedHorizon) Transactions(req horizonclient.TransactionRequest) (hProtocol.TransactionsPage, error) {
+	p.limits = append(p.limits, req.Limit)
+	p.pageCalls++
+	return p.page(0, req.Limit), nil
+}
+
+func (p *pagedHorizon) NextTransactionsPage(prev hProtocol.TransactionsPage) (hProtocol.TransactionsPage, error) {
+	p.pageCalls++
+	if p.failAfter > 0 && p.pageCalls > p.failAfter {
+		return hProtocol.TransactionsPage{}, errors.New("horizon unavailable")
+	}
+	var start int
+	var limit uint
+	fmt.Sscanf(prev.Links.Next.Href, "%d/%d", &start, &limit)
+	return p.page(start, limit), nil
+}
+
+func TestIterator_FollowsNextLinks(t *testing.T) {
+	horizon := &pagedHorizon{total: 7}
+	client := &Client{Horizon: horizon}
+
+	it := client.Transactions(context.Background(), horizonclient.TransactionRequest{}, PageSize(3))
+	var hashes []string
+	for it.Next() {
+		hashes = append(hashes, it.Record().Hash)
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, []string{"tx0", "tx1", "tx2", "tx3", "tx4", "tx5", "tx6"}, hashes)
+	assert.Equal(t, []uint{3}, horizon.limits)
+	// Three full or partial pages plus the empty page that ends iteration.
+	assert.Equal(t, 4, horizon.pageCalls)
+	assert.False(t, it.Next())
+}
+
+func TestIterator_MaxRecordsStopsFetching(t *testing.T) {
+	horizon := &pagedHorizon{total: 1000}
+	client := &Client{Horizon: horizon}
+
+	records, err := client.Transactions(context.Background(), horizonclient.TransactionRequest{}, PageSize(10), MaxRecords(25)).Collect()
+	require.NoError(t, err)
+	assert.Len(t, records, 25)
+	assert.Equal(t, 3, horizon.pageCalls)
+}
+
+func TestIterator_DefaultPageSize(t *testing.T) {
+	horizon := &pagedHorizon{total: 1}
+	client := &Client{Horizon: horizon}
+
+	_, err := client.Transactions(context.Background(), horizonclient.TransactionRequest{}).Collect()
+	require.NoError(t, err)
+	assert.Equal(t, []uint{horizonPageMaxLimit}, horizon.limits)
+
+	horizon.limits = nil
+	_, err = client.Transactions(context.Background(), horizonclient.TransactionRequest{Limit: 500}).Collect()
+	require.NoError(t, err)
+	assert.Equal(t, []uint{horizonPageMaxLimit}, horizon.limits)
+}
+
+func TestIterator_ErrorKeepsPartialResults(t *testing.T) {
+	horizon := &pagedHorizon{total: 100, failAfter: 1}
+	client := &Client{Horizon: horizon}
+
+	records, err := client.Transactions(context.Background(), horizonclient.TransactionRequest{}, PageSize(5)).Collect()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "fetch next page")
+	assert.Len(t, records, 5)
+}
+
+func TestIterator_StopsOnCancelledContext(t *testing.T) {
+	horizon := &pagedHorizon{total: 10}
+	client := &Client{Horizon: horizon}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	it := client.Transactions(ctx, horizonclient.TransactionRequest{})
+	assert.False(t, it.Next())
+	assert.ErrorIs(t, it.Err(), context.Canceled)
+	assert.Equal(t, 0, horizon.pageCalls)
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/dotandev/hintents/internal/rpc/metrics"
+)
+
+// SlowRequest describes one HTTP attempt that took longer than the latency
+// budget of its method. The phases split Total: DNS, Connect and TLS are zero
+// when a pooled connection was reused, and Server is the wait between the
+// request being written and the first response byte, which is the
+// provider's processing time plus one network round trip. A large Server
+// against small DNS and Connect points at the provider; the reverse points
+// at the network.
+type SlowRequest struct {
+	Method     string
+	Endpoint   string
+	Budget     time.Duration
+	Total      time.Duration
+	DNS        time.Duration
+	Connect    time.Duration
+	TLS        time.Duration
+	Server     time.Duration
+	TTFB       time.Duration
+	Reused     bool
+	StatusCode int
+	Err        error
+}
+
+// latencyTransport times each attempt with httptrace and reports those over
+// budget. Total runs until the response headers arrive; reading the body is
+// not included.
+type latencyTransport struct {
+	budgets   map[string]time.Duration
+	onSlow    func(SlowRequest)
+	logs      *clientLoggers
+	metrics   *metrics.Collector
+	transport http.RoundTripper
+}
+
+// budgetFor returns the budget of method, falling back to the default
+// registered under the empty method name.
+func (t *latencyTransport) budgetFor(method string) (time.Duration, bool) {
+	if d, ok := t.budgets[method]; ok {
+		return d, true
+	}
+	d, ok := t.budgets[""]
+	return d, ok
+}
+
+func (t *latencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	method := requestMethodLabel(req)
+	budget, ok := t.budgetFor(method)
+	if !ok || budget <= 0 {
+		return t.transport.RoundTrip(req)
+	}
+
+	var (
+		mu                       sync.Mutex
+		dnsStart, connStart      time.Time
+		tlsStart, wrote, gotByte time.Time
+		s                        = SlowRequest{Method: method, Endpoint: req.URL.Host, Budget: budget}
+	)
+	stamp := func(at *time.Time) func() {
+		return func() {
+			mu.Lock()
+			*at = time.Now()
+			mu.Unlock()
+		}
+	}
+	since := func(from *time.Time, into *time.Duration) func() {
+		return func() {
+			mu.Lock()
+			*into = time.Since(*from)
+			mu.Unlock()
+		}
+	}
+	trace := &httptrace.ClientTrace{
+		DNSStart:          func(httptrace.DNSStartInfo) { stamp(&dnsStart)() },
+		DNSDone:           func(httptrace.DNSDoneInfo) { since(&dnsStart, &s.DNS)() },
+		ConnectStart:      func(string, string) { stamp(&connStart)() },
+		ConnectDone:       func(string, string, error) { since(&connStart, &s.Connect)() },
+		TLSHandshakeStart: stamp(&tlsStart),
+		TLSHandshakeDone:  func(tls.ConnectionState, error) { since(&tlsStart, &s.TLS)() },
+		GotConn: func(info httptrace.GotConnInfo) {
+			mu.Lock()
+			s.Reused = info.Reused
+			mu.Unlock()
+		},
+		WroteRequest:         func(httptrace.WroteRequestInfo) { stamp(&wrote)() },
+		GotFirstResponseByte: stamp(&gotByte),
+	}
+
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(req.WithContext(httptrace.WithClientTrace(req.Context(), trace)))
+	total := time.Since(start)
+	if total <= budget {
+		return resp, err
+	}
+
+	mu.Lock()
+	s.Total = total
+	if !gotByte.IsZero() {
+		s.TTFB = gotByte.Sub(start)
+		if !wrote.IsZero() {
+			s.Server = gotByte.Sub(wrote)
+		}
+	}
+	slow := s
+	mu.Unlock()
+	slow.Err = err
+	if resp != nil {
+		slow.StatusCode = resp.StatusCode
+	}
+	t.report(req.Context(), slow)
+	return resp, err
+}
+
+func (t *latencyTransport) report(ctx context.Context, s SlowRequest) {
+	t.logs.loggerContext(ctx, LogSubsystemTransport).Warn("RPC request exceeded latency budget",
+		"method", s.Method,
+		"endpoint", s.Endpoint,
+		"budget", s.Budget,
+		"total", s.Total.Round(time.Millisecond),
+		"dns", s.DNS.Round(time.Millisecond),
+		"connect", s.Connect.Round(time.Millisecond),
+		"tls", s.TLS.Round(time.Millisecond),
+		"server", s.Server.Round(time.Millisecond),
+		"ttfb", s.TTFB.Round(time.Millisecond),
+		"reused_conn", s.Reused,
+	)
+	t.metrics.ObserveSlowRequest(s.Method, s.Endpoint)
+	if t.onSlow != nil {
+		t.onSlow(s)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatencyBudget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			time.Sleep(30 * time.Millisecond)
+		}
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{}}`))
+	}))
+	defer server.Close()
+
+	var (
+		mu   sync.Mutex
+		slow []SlowRequest
+	)
+	reg := prometheus.NewRegistry()
+	client, err := NewClient(
+		WithHorizonURL(server.URL),
+		WithSorobanURL(server.URL),
+		WithLatencyBudget("GET /fee_stats", 5*time.Millisecond),
+		WithLatencyBudget("", time.Hour),
+		WithMetricsCollector(reg),
+		WithSlowRequestHandler(func(s SlowRequest) {
+			mu.Lock()
+			slow = append(slow, s)
+			mu.Unlock()
+		}),
+	)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, client.getHorizon(ctx, "/fee_stats", nil, &struct{}{}))
+	require.NoError(t, client.callSoroban(ctx, "getHealth", nil, nil))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, slow, 1, "only the method over its budget is reported")
+	s := slow[0]
+	assert.Equal(t, "GET /fee_stats", s.Method)
+	assert.Equal(t, endpointLabel(server.URL), s.Endpoint)
+	assert.Equal(t, 5*time.Millisecond, s.Budget)
+	assert.Equal(t, http.StatusOK, s.StatusCode)
+	assert.GreaterOrEqual(t, s.Total, 30*time.Millisecond)
+	assert.GreaterOrEqual(t, s.Server, 25*time.Millisecond, "the wait is attributed to the server")
+	assert.GreaterOrEqual(t, s.TTFB, s.Server)
+	assert.False(t, s.Reused)
+	assert.Equal(t, 1, testutil.CollectAndCount(reg, "erst_rpc_slow_requests_total"))
+}
+
+func TestLatencyBudgetValidation(t *testing.T) {
+	_, err := NewClient(WithLatencyBudget("getHealth", -time.Second))
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+}
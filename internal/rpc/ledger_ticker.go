@@ -0,0 +1,146 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+)
+
+// LedgerInfo describes a newly closed ledger.
+type LedgerInfo struct {
+	Sequence        uint32
+	Hash            string
+	ProtocolVersion uint32
+	// ClosedAt is only known when the ticker follows Horizon.
+	ClosedAt time.Time
+}
+
+// LedgerSource selects where the ledger ticker learns about new ledgers.
+type LedgerSource int
+
+const (
+	// LedgerSourceSoroban polls Soroban RPC getLatestLedger.
+	LedgerSourceSoroban LedgerSource = iota
+	// LedgerSourceHorizon follows Horizon's ledger SSE stream.
+	LedgerSourceHorizon
+)
+
+// DefaultLedgerPollInterval is how often getLatestLedger is polled, a little
+// under half a ledger close so no ledger goes unnoticed for long.
+const DefaultLedgerPollInterval = 2 * time.Second
+
+// LedgerTickerConfig configures RunLedgerTicker.
+type LedgerTickerConfig struct {
+	Source       LedgerSource
+	PollInterval time.Duration
+}
+
+type ledgerHub struct {
+	mu     sync.Mutex
+	subs   map[int]func(LedgerInfo)
+	nextID int
+	last   uint32
+	// observed is the newest ledger seen from any source, for Health.
+	observed   uint32
+	observedAt time.Time
+}
+
+// OnLedger registers fn to be called once for every new ledger observed by
+// RunLedgerTicker. Callbacks run on the ticker goroutine, in ledger order,
+// and should return quickly. The returned function unregisters fn.
+func (c *Client) OnLedger(fn func(LedgerInfo)) (unsubscribe func()) {
+	c.ledgers.mu.Lock()
+	defer c.ledgers.mu.Unlock()
+	if c.ledgers.subs == nil {
+		c.ledgers.subs = make(map[int]func(LedgerInfo))
+	}
+	id := c.ledgers.nextID
+	c.ledgers.nextID++
+	c.ledgers.subs[id] = fn
+	return func() {
+		c.ledgers.mu.Lock()
+		defer c.ledgers.mu.Unlock()
+		delete(c.ledgers.subs, id)
+	}
+}
+
+// RunLedgerTicker follows the ledger stream until ctx is cancelled,
+// expiring ledger-scoped caches and notifying OnLedger subscribers on every
+// ledger close. Only one ticker should run per client.
+func (c *Client) RunLedgerTicker(ctx context.Context, cfg LedgerTickerConfig) error {
+	if cfg.Source == LedgerSourceHorizon {
+		return c.StreamLedgers(ctx, "now", func(l hProtocol.Ledger) error {
+			c.publishLedger(LedgerInfo{
+				Sequence:        uint32(l.Sequence),
+				Hash:            l.Hash,
+				ProtocolVersion: uint32(l.ProtocolVersion),
+				ClosedAt:        l.ClosedAt,
+			})
+			return nil
+		})
+	}
+
+	interval := cfg.PollInterval
+	if interval <= 0 {
+		interval = DefaultLedgerPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		info, err := c.GetLatestLedger(ctx)
+		if err == nil {
+			c.publishLedger(*info)
+		} else if ctx.Err() == nil {
+			c.logContext(ctx, LogSubsystemRPC).Warn("Failed to poll latest ledger", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetLatestLedger returns the latest ledger known to Soroban RPC.
+func (c *Client) GetLatestLedger(ctx context.Context) (*LedgerInfo, error) {
+	var result struct {
+		ID              string `json:"id"`
+		ProtocolVersion uint32 `json:"protocolVersion"`
+		Sequence        uint32 `json:"sequence"`
+	}
+	if err := c.callSoroban(ctx, "getLatestLedger", nil, &result); err != nil {
+		return nil, err
+	}
+	return &LedgerInfo{Sequence: result.Sequence, Hash: result.ID, ProtocolVersion: result.ProtocolVersion}, nil
+}
+
+// publishLedger handles a ledger observation, ignoring ones already seen.
+func (c *Client) publishLedger(info LedgerInfo) {
+	c.ledgers.mu.Lock()
+	if info.Sequence <= c.ledgers.last {
+		c.ledgers.mu.Unlock()
+		return
+	}
+	c.ledgers.last = info.Sequence
+	subs := make([]func(LedgerInfo), 0, len(c.ledgers.subs))
+	for _, fn := range c.ledgers.subs {
+		subs = append(subs, fn)
+	}
+	c.ledgers.mu.Unlock()
+
+	c.log(LogSubsystemRPC).Debug("Ledger closed", "sequence", info.Sequence)
+
+	c.observeLedger(info.Sequence)
+	c.feeStats.invalidate()
+
+	for _, fn := range subs {
+		fn(info)
+	}
+}
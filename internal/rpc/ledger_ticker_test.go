@@ -0,0 +1,93 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunLedgerTicker_Soroban(t *testing.T) {
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "getLatestLedger", req.Method)
+		// Each ledger is reported twice to exercise de-duplication.
+		seq := 100 + atomic.AddInt32(&polls, 1)/2
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":{"id":"h%d","protocolVersion":22,"sequence":%d}}`, seq, seq)
+	}))
+	defer server.Close()
+	client := newArchivalTestClient(t, server.URL)
+	client.SimulationCache = NewSimulationCache(time.Minute)
+	client.SimulationCache.Put("env", &SimulateTransactionResponse{})
+
+	var mu sync.Mutex
+	var seen []uint32
+	done := make(chan struct{})
+	client.OnLedger(func(info LedgerInfo) {
+		mu.Lock()
+		defer mu.Unlock()
+		seen = append(seen, info.Sequence)
+		if len(seen) == 3 {
+			close(done)
+		}
+	})
+	removed := 0
+	unsubscribe := client.OnLedger(func(LedgerInfo) { removed++ })
+	unsubscribe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- client.RunLedgerTicker(ctx, LedgerTickerConfig{PollInterval: time.Millisecond}) }()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("ticker did not deliver ledgers")
+	}
+	cancel()
+	assert.ErrorIs(t, <-errCh, context.Canceled)
+
+	mu.Lock()
+	assert.Equal(t, []uint32{100, 101, 102}, seen[:3])
+	mu.Unlock()
+	assert.Zero(t, removed)
+	_, ok := client.SimulationCache.Get("env")
+	assert.False(t, ok, "new ledgers expire simulation cache entries")
+}
+
+func TestRunLedgerTicker_Horizon(t *testing.T) {
+	client := newHorizonTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ledgers", r.URL.Path)
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "id: 1\ndata: {\"sequence\":7,\"hash\":\"abc\",\"protocol_version\":22,\"closed_at\":\"2024-01-01T00:00:00Z\"}\n\n")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	got := make(chan LedgerInfo, 1)
+	client.OnLedger(func(info LedgerInfo) {
+		got <- info
+		cancel()
+	})
+
+	_ = client.RunLedgerTicker(ctx, LedgerTickerConfig{Source: LedgerSourceHorizon})
+	info := <-got
+	assert.Equal(t, uint32(7), info.Sequence)
+	assert.Equal(t, "abc", info.Hash)
+	assert.Equal(t, 2024, info.ClosedAt.Year())
+}
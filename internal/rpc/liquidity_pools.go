@@ -0,0 +1,173 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/amount"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/effects"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+)
+
+// PoolReserve is one side of a liquidity pool.
+type PoolReserve struct {
+	Asset         txnbuild.Asset
+	Amount        string
+	AmountStroops int64
+}
+
+// LiquidityPoolInfo is a decoded constant-product liquidity pool.
+type LiquidityPoolInfo struct {
+	ID                 string
+	FeeBP              uint32
+	Type               string
+	TotalTrustlines    uint64
+	TotalShares        string
+	TotalSharesStroops int64
+	Reserves           []PoolReserve
+	LastModifiedLedger uint32
+}
+
+// reserveFor returns the reserve holding asset and the opposite reserve.
+func (p *LiquidityPoolInfo) reserveFor(asset txnbuild.Asset) (PoolReserve, PoolReserve, error) {
+	if len(p.Reserves) != 2 {
+		return PoolReserve{}, PoolReserve{}, fmt.Errorf("pool %s has %d reserves, expected 2", p.ID, len(p.Reserves))
+	}
+	key := canonicalAssetList([]txnbuild.Asset{asset})
+	for i, r := range p.Reserves {
+		if canonicalAssetList([]txnbuild.Asset{r.Asset}) == key {
+			return r, p.Reserves[1-i], nil
+		}
+	}
+	return PoolReserve{}, PoolReserve{}, fmt.Errorf("asset %s is not a reserve of pool %s", key, p.ID)
+}
+
+// SpotPrice returns the marginal price of one unit of base in terms of the
+// other reserve asset, ignoring the pool fee.
+func (p *LiquidityPoolInfo) SpotPrice(base txnbuild.Asset) (*big.Rat, error) {
+	baseReserve, counterReserve, err := p.reserveFor(base)
+	if err != nil {
+		return nil, err
+	}
+	if baseReserve.AmountStroops == 0 {
+		return nil, fmt.Errorf("pool %s has an empty %s reserve", p.ID, base.GetCode())
+	}
+	return big.NewRat(counterReserve.AmountStroops, baseReserve.AmountStroops), nil
+}
+
+// SharePrice returns the value of one pool share expressed in quote. Both
+// reserves are valued at the pool's spot price, so a share is worth twice its
+// pro-rata portion of the quote reserve.
+func (p *LiquidityPoolInfo) SharePrice(quote txnbuild.Asset) (*big.Rat, error) {
+	quoteReserve, _, err := p.reserveFor(quote)
+	if err != nil {
+		return nil, err
+	}
+	if p.TotalSharesStroops == 0 {
+		return nil, fmt.Errorf("pool %s has no outstanding shares", p.ID)
+	}
+	return big.NewRat(2*quoteReserve.AmountStroops, p.TotalSharesStroops), nil
+}
+
+// ShareValue returns the reserve amounts redeemable for shares pool shares
+// (in stroops), rounded down as the protocol does on withdrawal.
+func (p *LiquidityPoolInfo) ShareValue(shares int64) ([]PoolReserve, error) {
+	if shares < 0 || shares > p.TotalSharesStroops {
+		return nil, fmt.Errorf("share amount %d outside [0, %d]", shares, p.TotalSharesStroops)
+	}
+	out := make([]PoolReserve, 0, len(p.Reserves))
+	for _, r := range p.Reserves {
+		v := new(big.Int).Mul(big.NewInt(r.AmountStroops), big.NewInt(shares))
+		v.Quo(v, big.NewInt(p.TotalSharesStroops))
+		out = append(out, PoolReserve{
+			Asset:         r.Asset,
+			Amount:        amount.StringFromInt64(v.Int64()),
+			AmountStroops: v.Int64(),
+		})
+	}
+	return out, nil
+}
+
+// LiquidityPoolsByReserves returns every pool holding all of the given
+// reserve assets. opts control paging as for LiquidityPools.
+func (c *Client) LiquidityPoolsByReserves(ctx context.Context, reserves []txnbuild.Asset, opts ...IteratorOption) ([]LiquidityPoolInfo, error) {
+	req := horizonclient.LiquidityPoolsRequest{}
+	for _, r := range reserves {
+		if _, _, _, err := horizonAssetFields("reserve", r); err != nil {
+			return nil, err
+		}
+		req.Reserves = append(req.Reserves, canonicalAssetList([]txnbuild.Asset{r}))
+	}
+
+	c.logContext(ctx, LogSubsystemRPC).Debug("Fetching liquidity pools", "reserves", req.Reserves)
+
+	records, err := c.LiquidityPools(ctx, req, opts...).Collect()
+	if err != nil {
+		c.logContext(ctx, LogSubsystemRPC).Error("Failed to fetch liquidity pools", "error", err)
+		return nil, errors.WrapRPCConnectionFailed(err)
+	}
+
+	out := make([]LiquidityPoolInfo, 0, len(records))
+	for _, r := range records {
+		out = append(out, decodeLiquidityPool(r))
+	}
+	return out, nil
+}
+
+// LiquidityPool fetches a single liquidity pool by ID.
+func (c *Client) LiquidityPool(ctx context.Context, poolID string) (*LiquidityPoolInfo, error) {
+	if poolID == "" {
+		return nil, errors.WrapValidationError("liquidity pool id is required")
+	}
+
+	c.logContext(ctx, LogSubsystemRPC).Debug("Fetching liquidity pool", "pool_id", poolID)
+
+	start := time.Now()
+	pool, err := c.Horizon.LiquidityPoolDetail(horizonclient.LiquidityPoolRequest{LiquidityPoolID: poolID})
+	if err != nil {
+		c.logContext(ctx, LogSubsystemRPC).Error("Failed to fetch liquidity pool", "pool_id", poolID, "error", err)
+		return nil, c.requestError(ctx, "liquidity_pool_detail", c.HorizonURL, 0, start, errors.WrapRPCConnectionFailed(err))
+	}
+	info := decodeLiquidityPool(pool)
+	return &info, nil
+}
+
+// LiquidityPoolTrades returns an iterator over the trades executed against
+// a pool.
+func (c *Client) LiquidityPoolTrades(ctx context.Context, poolID string, opts ...IteratorOption) *Iterator[hProtocol.Trade] {
+	return c.Trades(ctx, horizonclient.TradeRequest{ForLiquidityPool: poolID}, opts...)
+}
+
+// LiquidityPoolEffects returns an iterator over the effects on a pool, such
+// as deposits, withdrawals and trades.
+func (c *Client) LiquidityPoolEffects(ctx context.Context, poolID string, opts ...IteratorOption) *Iterator[effects.Effect] {
+	return c.Effects(ctx, horizonclient.EffectRequest{ForLiquidityPool: poolID}, opts...)
+}
+
+func decodeLiquidityPool(pool hProtocol.LiquidityPool) LiquidityPoolInfo {
+	info := LiquidityPoolInfo{
+		ID:                 pool.ID,
+		FeeBP:              pool.FeeBP,
+		Type:               pool.Type,
+		TotalTrustlines:    pool.TotalTrustlines,
+		TotalShares:        pool.TotalShares,
+		TotalSharesStroops: parseAmountOrZero(pool.TotalShares),
+		LastModifiedLedger: pool.LastModifiedLedger,
+	}
+	for _, r := range pool.Reserves {
+		info.Reserves = append(info.Reserves, PoolReserve{
+			Asset:         assetFromCanonical(r.Asset),
+			Amount:        r.Amount,
+			AmountStroops: parseAmountOrZero(r.Amount),
+		})
+	}
+	return info
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type poolsHorizon struct {
+	horizonclient.ClientInterface
+	poolsReq  horizonclient.LiquidityPoolsRequest
+	tradesReq horizonclient.TradeRequest
+}
+
+func testPool() hProtocol.LiquidityPool {
+	return hProtocol.LiquidityPool{
+		ID:          "pool1",
+		FeeBP:       30,
+		Type:        "constant_product",
+		TotalShares: "100.0000000",
+		Reserves: []hProtocol.LiquidityPoolReserve{
+			{Asset: "native", Amount: "1000.0000000"},
+			{Asset: "USDC:" + testIssuer, Amount: "250.0000000"},
+		},
+	}
+}
+
+func (m *poolsHorizon) LiquidityPools(req horizonclient.LiquidityPoolsRequest) (hProtocol.LiquidityPoolsPage, error) {
+	m.poolsReq = req
+	var page hProtocol.LiquidityPoolsPage
+	page.Embedded.Records = []hProtocol.LiquidityPool{testPool()}
+	return page, nil
+}
+
+func (m *poolsHorizon) NextLiquidityPoolsPage(hProtocol.LiquidityPoolsPage) (hProtocol.LiquidityPoolsPage, error) {
+	return hProtocol.LiquidityPoolsPage{}, nil
+}
+
+func (m *poolsHorizon) LiquidityPoolDetail(req horizonclient.LiquidityPoolRequest) (hProtocol.LiquidityPool, error) {
+	return testPool(), nil
+}
+
+func (m *poolsHorizon) Trades(req horizonclient.TradeRequest) (hProtocol.TradesPage, error) {
+	m.tradesReq = req
+	return hProtocol.TradesPage{}, nil
+}
+
+func TestLiquidityPoolsByReserves(t *testing.T) {
+	horizon := &poolsHorizon{}
+	client := &Client{Horizon: horizon}
+	usdc := txnbuild.CreditAsset{Code: "USDC", Issuer: testIssuer}
+
+	pools, err := client.LiquidityPoolsByReserves(context.Background(), []txnbuild.Asset{txnbuild.NativeAsset{}, usdc})
+	require.NoError(t, err)
+	require.Len(t, pools, 1)
+	assert.Equal(t, []string{"native", "USDC:" + testIssuer}, horizon.poolsReq.Reserves)
+
+	pool := pools[0]
+	assert.Equal(t, int64(100_0000000), pool.TotalSharesStroops)
+	assert.True(t, pool.Reserves[0].Asset.IsNative())
+
+	price, err := pool.SpotPrice(txnbuild.NativeAsset{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, price.Cmp(big.NewRat(1, 4)))
+
+	sharePrice, err := pool.SharePrice(usdc)
+	require.NoError(t, err)
+	assert.Equal(t, "5", sharePrice.RatString())
+
+	value, err := pool.ShareValue(10_0000000)
+	require.NoError(t, err)
+	assert.Equal(t, "100.0000000", value[0].Amount)
+	assert.Equal(t, "25.0000000", value[1].Amount)
+
+	_, err = pool.SpotPrice(txnbuild.CreditAsset{Code: "EURC", Issuer: testIssuer})
+	assert.Error(t, err)
+	_, err = pool.ShareValue(pool.TotalSharesStroops + 1)
+	assert.Error(t, err)
+}
+
+func TestLiquidityPool(t *testing.T) {
+	horizon := &poolsHorizon{}
+	client := &Client{Horizon: horizon}
+
+	pool, err := client.LiquidityPool(context.Background(), "pool1")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(30), pool.FeeBP)
+
+	_, err = client.LiquidityPool(context.Background(), "")
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+
+	it := client.LiquidityPoolTrades(context.Background(), "pool1")
+	assert.False(t, it.Next())
+	require.NoError(t, it.Err())
+	assert.Equal(t, "pool1", horizon.tradesReq.ForLiquidityPool)
+}
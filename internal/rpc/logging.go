@@ -0,0 +1,148 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/dotandev/hintents/internal/logger"
+)
+
+// Subsystems a client logs under. Each record carries a "subsystem"
+// attribute with one of these values, and LogConfig.Levels is keyed by them.
+const (
+	LogSubsystemRPC        = "rpc"
+	LogSubsystemFailover   = "failover"
+	LogSubsystemCache      = "cache"
+	LogSubsystemSubmission = "submission"
+	LogSubsystemTransport  = "transport"
+)
+
+// LogConfig controls how a client logs.
+type LogConfig struct {
+	// Logger receives the client's records. When nil, the process-wide
+	// logger.Logger is used, following later calls to logger.SetOutput.
+	Logger *slog.Logger
+	// Levels sets the minimum level per subsystem, overriding the level of
+	// Logger in either direction. Subsystems not listed inherit it.
+	Levels map[string]slog.Level
+	// DebugSampleRate keeps the first and then one in every DebugSampleRate
+	// debug records with the same subsystem and message, for events such as
+	// per-request and cache-hit logging that are too frequent to read in
+	// full. Values below 2 keep every record.
+	DebugSampleRate int
+}
+
+// clientLoggers hands out a client's per-subsystem loggers. A nil
+// *clientLoggers logs straight to logger.Logger, which keeps clients built
+// as struct literals working.
+type clientLoggers struct {
+	cfg         LogConfig
+	sampler     *logSampler
+	correlation correlationConfig
+
+	mu      sync.Mutex
+	base    *slog.Logger
+	loggers map[string]*slog.Logger
+}
+
+func newClientLoggers(cfg LogConfig) *clientLoggers {
+	l := &clientLoggers{cfg: cfg}
+	if cfg.DebugSampleRate > 1 {
+		l.sampler = &logSampler{rate: uint64(cfg.DebugSampleRate), seen: make(map[string]uint64)}
+	}
+	return l
+}
+
+// logger returns the logger for subsystem, rebuilding the set when the
+// process-wide logger it derives from has been replaced.
+func (l *clientLoggers) logger(subsystem string) *slog.Logger {
+	if l == nil {
+		return logger.Logger
+	}
+	base := l.cfg.Logger
+	if base == nil {
+		base = logger.Logger
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.base != base {
+		l.base = base
+		l.loggers = make(map[string]*slog.Logger)
+	}
+	if lg, ok := l.loggers[subsystem]; ok {
+		return lg
+	}
+	h := &subsystemHandler{inner: base.Handler(), subsystem: subsystem, sampler: l.sampler}
+	if lvl, ok := l.cfg.Levels[subsystem]; ok {
+		h.level = lvl
+		h.hasLevel = true
+	}
+	lg := slog.New(h).With("subsystem", subsystem)
+	l.loggers[subsystem] = lg
+	return lg
+}
+
+// log returns the client's logger for subsystem.
+func (c *Client) log(subsystem string) *slog.Logger {
+	return c.logs.logger(subsystem)
+}
+
+// subsystemHandler applies a subsystem's level and debug sampling in front
+// of the handler that writes the records.
+type subsystemHandler struct {
+	inner     slog.Handler
+	subsystem string
+	level     slog.Level
+	hasLevel  bool
+	sampler   *logSampler
+}
+
+func (h *subsystemHandler) Enabled(ctx context.Context, lvl slog.Level) bool {
+	if h.hasLevel {
+		return lvl >= h.level
+	}
+	return h.inner.Enabled(ctx, lvl)
+}
+
+func (h *subsystemHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level < slog.LevelInfo && !h.sampler.keep(h.subsystem, r.Message) {
+		return nil
+	}
+	return h.inner.Handle(ctx, r)
+}
+
+func (h *subsystemHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	out := *h
+	out.inner = h.inner.WithAttrs(attrs)
+	return &out
+}
+
+func (h *subsystemHandler) WithGroup(name string) slog.Handler {
+	out := *h
+	out.inner = h.inner.WithGroup(name)
+	return &out
+}
+
+// logSampler counts records by subsystem and message.
+type logSampler struct {
+	rate uint64
+	mu   sync.Mutex
+	seen map[string]uint64
+}
+
+func (s *logSampler) keep(subsystem, msg string) bool {
+	if s == nil {
+		return true
+	}
+	key := subsystem + "\x00" + msg
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := s.seen[key]
+	s.seen[key] = n + 1
+	return n%s.rate == 0
+}
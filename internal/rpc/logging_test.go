@@ -0,0 +1,95 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var out []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var rec map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &rec))
+		out = append(out, rec)
+	}
+	return out
+}
+
+func TestSubsystemLoggers(t *testing.T) {
+	var buf bytes.Buffer
+	base := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	client, err := NewClient(
+		WithLogger(base),
+		WithSubsystemLogLevel(LogSubsystemFailover, slog.LevelDebug),
+		WithSubsystemLogLevel(LogSubsystemCache, slog.LevelError),
+	)
+	require.NoError(t, err)
+
+	client.log(LogSubsystemFailover).Debug("hop")
+	client.log(LogSubsystemRPC).Debug("dropped by the base level")
+	client.log(LogSubsystemRPC).Warn("kept")
+	client.log(LogSubsystemCache).Warn("dropped by the cache level")
+
+	recs := logRecords(t, &buf)
+	require.Len(t, recs, 2)
+	assert.Equal(t, "hop", recs[0]["msg"])
+	assert.Equal(t, LogSubsystemFailover, recs[0]["subsystem"])
+	assert.Equal(t, "kept", recs[1]["msg"])
+	assert.Equal(t, LogSubsystemRPC, recs[1]["subsystem"])
+}
+
+func TestDebugLogSampling(t *testing.T) {
+	var buf bytes.Buffer
+	base := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client, err := NewClient(WithLogger(base), WithDebugLogSampling(3))
+	require.NoError(t, err)
+
+	for i := 0; i < 7; i++ {
+		client.log(LogSubsystemCache).Debug("Cache hit", "i", i)
+		client.log(LogSubsystemCache).Info("not sampled")
+	}
+	client.log(LogSubsystemRPC).Debug("Cache hit")
+
+	var hits, infos int
+	for _, rec := range logRecords(t, &buf) {
+		switch {
+		case rec["msg"] == "not sampled":
+			infos++
+		case rec["subsystem"] == LogSubsystemCache:
+			assert.Contains(t, []float64{0, 3, 6}, rec["i"])
+			hits++
+		}
+	}
+	assert.Equal(t, 3, hits)
+	assert.Equal(t, 7, infos)
+	assert.Contains(t, buf.String(), `"subsystem":"rpc"`, "sampling is counted per subsystem")
+}
+
+func TestClientLoggersFollowProcessLogger(t *testing.T) {
+	previous := logger.Logger
+	t.Cleanup(func() { logger.Logger = previous })
+
+	var literal Client
+	assert.Same(t, logger.Logger, literal.log(LogSubsystemRPC))
+
+	client, err := NewClient()
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	logger.Logger = slog.New(slog.NewJSONHandler(&buf, nil))
+	client.log(LogSubsystemSubmission).Info("submitted")
+	assert.Contains(t, buf.String(), `"subsystem":"submission"`)
+}
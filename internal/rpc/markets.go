@@ -0,0 +1,242 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/amount"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+)
+
+// Resolutions accepted by Horizon's trade aggregation endpoint.
+const (
+	Resolution1Minute   = time.Minute
+	Resolution5Minutes  = 5 * time.Minute
+	Resolution15Minutes = 15 * time.Minute
+	Resolution1Hour     = time.Hour
+	Resolution1Day      = 24 * time.Hour
+	Resolution1Week     = 7 * 24 * time.Hour
+)
+
+// Price is an exact rational price as reported by Horizon.
+type Price struct {
+	N int64
+	D int64
+}
+
+// Rat returns the price as an exact rational; a zero denominator yields zero.
+func (p Price) Rat() *big.Rat {
+	if p.D == 0 {
+		return new(big.Rat)
+	}
+	return big.NewRat(p.N, p.D)
+}
+
+// Float64 returns the price as a float, which may lose precision.
+func (p Price) Float64() float64 {
+	f, _ := p.Rat().Float64()
+	return f
+}
+
+// String renders the price with Stellar's seven decimal places.
+func (p Price) String() string {
+	return p.Rat().FloatString(7)
+}
+
+// PriceLevel is one aggregated level of an order book side.
+type PriceLevel struct {
+	Price Price
+	// Amount is the amount of the selling asset available at this level.
+	Amount string
+	// AmountStroops is Amount in the asset's smallest unit.
+	AmountStroops int64
+}
+
+// OrderBook is a decoded order book snapshot. Bids and asks are ordered best
+// first, as returned by Horizon.
+type OrderBook struct {
+	Selling txnbuild.Asset
+	Buying  txnbuild.Asset
+	Bids    []PriceLevel
+	Asks    []PriceLevel
+}
+
+// BestBid returns the highest bid, if any.
+func (ob *OrderBook) BestBid() (PriceLevel, bool) {
+	if len(ob.Bids) == 0 {
+		return PriceLevel{}, false
+	}
+	return ob.Bids[0], true
+}
+
+// BestAsk returns the lowest ask, if any.
+func (ob *OrderBook) BestAsk() (PriceLevel, bool) {
+	if len(ob.Asks) == 0 {
+		return PriceLevel{}, false
+	}
+	return ob.Asks[0], true
+}
+
+// Spread returns best ask minus best bid, or nil when either side is empty.
+func (ob *OrderBook) Spread() *big.Rat {
+	bid, okBid := ob.BestBid()
+	ask, okAsk := ob.BestAsk()
+	if !okBid || !okAsk {
+		return nil
+	}
+	return new(big.Rat).Sub(ask.Price.Rat(), bid.Price.Rat())
+}
+
+// OrderBook fetches the order book for selling/buying with at most depth
+// levels per side (Horizon caps this at 200; 0 uses the cap).
+func (c *Client) OrderBook(ctx context.Context, selling, buying txnbuild.Asset, depth int) (*OrderBook, error) {
+	sType, sCode, sIssuer, err := horizonAssetFields("selling", selling)
+	if err != nil {
+		return nil, err
+	}
+	bType, bCode, bIssuer, err := horizonAssetFields("buying", buying)
+	if err != nil {
+		return nil, err
+	}
+
+	c.logContext(ctx, LogSubsystemRPC).Debug("Fetching order book", "depth", depth)
+
+	start := time.Now()
+	summary, err := c.Horizon.OrderBook(horizonclient.OrderBookRequest{
+		SellingAssetType:   sType,
+		SellingAssetCode:   sCode,
+		SellingAssetIssuer: sIssuer,
+		BuyingAssetType:    bType,
+		BuyingAssetCode:    bCode,
+		BuyingAssetIssuer:  bIssuer,
+		Limit:              uint(normalizePageSize(depth)),
+	})
+	if err != nil {
+		c.logContext(ctx, LogSubsystemRPC).Error("Failed to fetch order book", "error", err)
+		return nil, c.requestError(ctx, "order_book", c.HorizonURL, 0, start, errors.WrapRPCConnectionFailed(err))
+	}
+
+	return &OrderBook{
+		Selling: selling,
+		Buying:  buying,
+		Bids:    decodePriceLevels(summary.Bids),
+		Asks:    decodePriceLevels(summary.Asks),
+	}, nil
+}
+
+func decodePriceLevels(levels []hProtocol.PriceLevel) []PriceLevel {
+	out := make([]PriceLevel, 0, len(levels))
+	for _, l := range levels {
+		level := PriceLevel{
+			Price:  Price{N: int64(l.PriceR.N), D: int64(l.PriceR.D)},
+			Amount: l.Amount,
+		}
+		if stroops, err := amount.ParseInt64(l.Amount); err == nil {
+			level.AmountStroops = stroops
+		}
+		out = append(out, level)
+	}
+	return out
+}
+
+// AssetPair identifies a market by its base and counter assets.
+type AssetPair struct {
+	Base    txnbuild.Asset
+	Counter txnbuild.Asset
+}
+
+// TimeRange is a half-open [Start, End) interval.
+type TimeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// TradeBucket is one trade aggregation bucket with decoded OHLC prices.
+type TradeBucket struct {
+	Start         time.Time
+	TradeCount    int64
+	BaseVolume    string
+	CounterVolume string
+	Average       string
+	Open          Price
+	High          Price
+	Low           Price
+	Close         Price
+}
+
+// TradeAggregations returns OHLC buckets for pair over rng at the given
+// resolution, following pagination until the range is exhausted.
+func (c *Client) TradeAggregations(ctx context.Context, pair AssetPair, resolution time.Duration, rng TimeRange) ([]TradeBucket, error) {
+	if !validResolution(resolution) {
+		return nil, errors.WrapValidationError(fmt.Sprintf("unsupported trade aggregation resolution %s", resolution))
+	}
+	if !rng.Start.IsZero() && !rng.End.IsZero() && !rng.End.After(rng.Start) {
+		return nil, errors.WrapValidationError("trade aggregation range end must be after start")
+	}
+	baseType, baseCode, baseIssuer, err := horizonAssetFields("base", pair.Base)
+	if err != nil {
+		return nil, err
+	}
+	counterType, counterCode, counterIssuer, err := horizonAssetFields("counter", pair.Counter)
+	if err != nil {
+		return nil, err
+	}
+
+	req := horizonclient.TradeAggregationRequest{
+		StartTime:          rng.Start,
+		EndTime:            rng.End,
+		Resolution:         resolution,
+		BaseAssetType:      baseType,
+		BaseAssetCode:      baseCode,
+		BaseAssetIssuer:    baseIssuer,
+		CounterAssetType:   counterType,
+		CounterAssetCode:   counterCode,
+		CounterAssetIssuer: counterIssuer,
+		Order:              horizonclient.OrderAsc,
+		Limit:              horizonPageMaxLimit,
+	}
+
+	records, err := newIterator(ctx, pageIterator[hProtocol.TradeAggregationsPage, hProtocol.TradeAggregation]{
+		first: func() (hProtocol.TradeAggregationsPage, error) { return c.Horizon.TradeAggregations(req) },
+		next: func(page hProtocol.TradeAggregationsPage) (hProtocol.TradeAggregationsPage, error) {
+			return c.Horizon.NextTradeAggregationsPage(page)
+		},
+		records: func(page hProtocol.TradeAggregationsPage) []hProtocol.TradeAggregation { return page.Embedded.Records },
+	}).Collect()
+	if err != nil {
+		c.logContext(ctx, LogSubsystemRPC).Error("Failed to fetch trade aggregations", "error", err)
+		return nil, errors.WrapRPCConnectionFailed(err)
+	}
+
+	out := make([]TradeBucket, 0, len(records))
+	for _, r := range records {
+		out = append(out, TradeBucket{
+			Start:         time.UnixMilli(r.Timestamp).UTC(),
+			TradeCount:    r.TradeCount,
+			BaseVolume:    r.BaseVolume,
+			CounterVolume: r.CounterVolume,
+			Average:       r.Average,
+			Open:          Price{N: r.OpenR.N, D: r.OpenR.D},
+			High:          Price{N: r.HighR.N, D: r.HighR.D},
+			Low:           Price{N: r.LowR.N, D: r.LowR.D},
+			Close:         Price{N: r.CloseR.N, D: r.CloseR.D},
+		})
+	}
+	return out, nil
+}
+
+func validResolution(d time.Duration) bool {
+	switch d {
+	case Resolution1Minute, Resolution5Minutes, Resolution15Minutes, Resolution1Hour, Resolution1Day, Resolution1Week:
+		return true
+	}
+	return false
+}
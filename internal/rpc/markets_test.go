@@ -0,0 +1,125 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type marketsHorizon struct {
+	horizonclient.ClientInterface
+	orderBookReq horizonclient.OrderBookRequest
+	aggReq       horizonclient.TradeAggregationRequest
+	aggPages     []hProtocol.TradeAggregationsPage
+}
+
+func (m *marketsHorizon) OrderBook(req horizonclient.OrderBookRequest) (hProtocol.OrderBookSummary, error) {
+	m.orderBookReq = req
+	return hProtocol.OrderBookSummary{
+		Bids: []hProtocol.PriceLevel{{PriceR: hProtocol.Price{N: 1, D: 10}, Price: "0.1000000", Amount: "50.0000000"}},
+		Asks: []hProtocol.PriceLevel{{PriceR: hProtocol.Price{N: 3, D: 20}, Price: "0.1500000", Amount: "7.5000000"}},
+	}, nil
+}
+
+func (m *marketsHorizon) TradeAggregations(req horizonclient.TradeAggregationRequest) (hProtocol.TradeAggregationsPage, error) {
+	m.aggReq = req
+	return m.aggPages[0], nil
+}
+
+func (m *marketsHorizon) NextTradeAggregationsPage(page hProtocol.TradeAggregationsPage) (hProtocol.TradeAggregationsPage, error) {
+	m.aggPages = m.aggPages[1:]
+	if len(m.aggPages) == 0 {
+		return hProtocol.TradeAggregationsPage{}, nil
+	}
+	return m.aggPages[0], nil
+}
+
+func aggPage(timestamps ...int64) hProtocol.TradeAggregationsPage {
+	var page hProtocol.TradeAggregationsPage
+	for _, ts := range timestamps {
+		page.Embedded.Records = append(page.Embedded.Records, hProtocol.TradeAggregation{
+			Timestamp:  ts,
+			TradeCount: 2,
+			BaseVolume: "10.0000000",
+			OpenR:      hProtocol.TradePrice{N: 1, D: 4},
+			CloseR:     hProtocol.TradePrice{N: 1, D: 2},
+		})
+	}
+	return page
+}
+
+func TestOrderBook(t *testing.T) {
+	usdc := txnbuild.CreditAsset{Code: "USDC", Issuer: testIssuer}
+	horizon := &marketsHorizon{}
+	client := &Client{Horizon: horizon}
+
+	ob, err := client.OrderBook(context.Background(), txnbuild.NativeAsset{}, usdc, 20)
+	require.NoError(t, err)
+
+	assert.Equal(t, horizonclient.AssetTypeNative, horizon.orderBookReq.SellingAssetType)
+	assert.Equal(t, horizonclient.AssetType4, horizon.orderBookReq.BuyingAssetType)
+	assert.Equal(t, "USDC", horizon.orderBookReq.BuyingAssetCode)
+	assert.Equal(t, uint(20), horizon.orderBookReq.Limit)
+
+	bid, ok := ob.BestBid()
+	require.True(t, ok)
+	assert.Equal(t, "0.1000000", bid.Price.String())
+	assert.Equal(t, int64(500000000), bid.AmountStroops)
+	assert.InDelta(t, 0.1, bid.Price.Float64(), 1e-9)
+
+	assert.Equal(t, 0, ob.Spread().Cmp(big.NewRat(1, 20)))
+}
+
+func TestTradeAggregations(t *testing.T) {
+	horizon := &marketsHorizon{aggPages: []hProtocol.TradeAggregationsPage{
+		aggPage(1700000000000, 1700003600000),
+		aggPage(1700007200000),
+	}}
+	client := &Client{Horizon: horizon}
+
+	start := time.Unix(1700000000, 0)
+	buckets, err := client.TradeAggregations(context.Background(),
+		AssetPair{Base: txnbuild.NativeAsset{}, Counter: txnbuild.CreditAsset{Code: "USDC", Issuer: testIssuer}},
+		Resolution1Hour,
+		TimeRange{Start: start, End: start.Add(3 * time.Hour)},
+	)
+	require.NoError(t, err)
+	require.Len(t, buckets, 3)
+
+	assert.Equal(t, time.Hour, horizon.aggReq.Resolution)
+	assert.Equal(t, horizonclient.OrderAsc, horizon.aggReq.Order)
+	assert.True(t, buckets[0].Start.Equal(start))
+	assert.Equal(t, "0.2500000", buckets[0].Open.String())
+	assert.Equal(t, "0.5000000", buckets[0].Close.String())
+	assert.Equal(t, int64(2), buckets[2].TradeCount)
+}
+
+func TestTradeAggregations_Validation(t *testing.T) {
+	client := &Client{Horizon: &marketsHorizon{}}
+	pair := AssetPair{Base: txnbuild.NativeAsset{}, Counter: txnbuild.CreditAsset{Code: "USDC", Issuer: testIssuer}}
+	now := time.Now()
+
+	_, err := client.TradeAggregations(context.Background(), pair, 2*time.Minute, TimeRange{})
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+
+	_, err = client.TradeAggregations(context.Background(), pair, Resolution1Day, TimeRange{Start: now, End: now.Add(-time.Hour)})
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+
+	_, err = client.TradeAggregations(context.Background(), AssetPair{Base: txnbuild.NativeAsset{}}, Resolution1Day, TimeRange{})
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+}
+
+func TestPriceZeroDenominator(t *testing.T) {
+	assert.Equal(t, "0.0000000", Price{N: 1}.String())
+}
@@ -0,0 +1,193 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+// Package metrics exports RPC client activity as Prometheus metrics. A
+// Collector is attached to a client with rpc.WithMetricsCollector and
+// records, per method and endpoint, request counts and latencies, errors,
+// transport retries, node failovers, cache lookups and the age of the
+// entries they find, and requests
+// over their latency budget.
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	namespace = "erst"
+	subsystem = "rpc"
+)
+
+// Collector holds the RPC client's Prometheus collectors. The zero value and
+// a nil *Collector are valid and record nothing, so callers need not check
+// whether metrics are enabled.
+type Collector struct {
+	requests  *prometheus.CounterVec
+	latency   *prometheus.HistogramVec
+	errors    *prometheus.CounterVec
+	retries   *prometheus.CounterVec
+	failovers *prometheus.CounterVec
+	cache     *prometheus.CounterVec
+	cacheAge  *prometheus.HistogramVec
+	slow      *prometheus.CounterVec
+}
+
+// New creates a Collector and registers its collectors with reg. Clients
+// built against the same registerer share one set of series: when a
+// collector is already registered, the existing one is reused.
+func New(reg prometheus.Registerer) (*Collector, error) {
+	c := &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "requests_total",
+			Help:      "RPC requests sent, by method, endpoint and outcome.",
+		}, []string{"method", "endpoint", "status"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "request_duration_seconds",
+			Help:      "RPC request latency including transport retries, by method and endpoint.",
+			Buckets:   []float64{.01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+		}, []string{"method", "endpoint"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "errors_total",
+			Help:      "RPC requests that failed, by method, endpoint and kind of failure.",
+		}, []string{"method", "endpoint", "kind"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "retries_total",
+			Help:      "Transport-level retries, by endpoint and reason.",
+		}, []string{"endpoint", "reason"}),
+		failovers: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "failovers_total",
+			Help:      "Switches to a fallback node, by the node left and the node chosen.",
+		}, []string{"from", "to"}),
+		cache: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "cache_requests_total",
+			Help:      "Cache lookups, by cache and result (hit, miss or stale).",
+		}, []string{"cache", "result"}),
+		cacheAge: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "cache_entry_age_seconds",
+			Help:      "Age of the entry found by a cache lookup, by cache and result (hit or stale).",
+			Buckets:   []float64{1, 5, 15, 60, 300, 900, 3600, 4 * 3600, 24 * 3600},
+		}, []string{"cache", "result"}),
+		slow: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "slow_requests_total",
+			Help:      "Requests that exceeded their method's latency budget, by method and endpoint.",
+		}, []string{"method", "endpoint"}),
+	}
+
+	var err error
+	if c.requests, err = register(reg, c.requests); err != nil {
+		return nil, err
+	}
+	if c.latency, err = register(reg, c.latency); err != nil {
+		return nil, err
+	}
+	if c.errors, err = register(reg, c.errors); err != nil {
+		return nil, err
+	}
+	if c.retries, err = register(reg, c.retries); err != nil {
+		return nil, err
+	}
+	if c.failovers, err = register(reg, c.failovers); err != nil {
+		return nil, err
+	}
+	if c.cache, err = register(reg, c.cache); err != nil {
+		return nil, err
+	}
+	if c.cacheAge, err = register(reg, c.cacheAge); err != nil {
+		return nil, err
+	}
+	if c.slow, err = register(reg, c.slow); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// register registers col with reg, returning the collector already
+// registered under the same description if there is one.
+func register[T prometheus.Collector](reg prometheus.Registerer, col T) (T, error) {
+	err := reg.Register(col)
+	if err == nil {
+		return col, nil
+	}
+	var are prometheus.AlreadyRegisteredError
+	if errors.As(err, &are) {
+		if existing, ok := are.ExistingCollector.(T); ok {
+			return existing, nil
+		}
+	}
+	return col, err
+}
+
+// ObserveRequest records a completed request and its latency. status is
+// "ok", an HTTP status code, or "transport_error".
+func (c *Collector) ObserveRequest(method, endpoint, status string, d time.Duration) {
+	if c == nil || c.requests == nil {
+		return
+	}
+	c.requests.WithLabelValues(method, endpoint, status).Inc()
+	c.latency.WithLabelValues(method, endpoint).Observe(d.Seconds())
+}
+
+// ObserveError records a failed request. kind is a short, bounded label such
+// as "http_503", "transport" or "rpc_-32600".
+func (c *Collector) ObserveError(method, endpoint, kind string) {
+	if c == nil || c.errors == nil {
+		return
+	}
+	c.errors.WithLabelValues(method, endpoint, kind).Inc()
+}
+
+// ObserveRetry records a transport-level retry against endpoint.
+func (c *Collector) ObserveRetry(endpoint, reason string) {
+	if c == nil || c.retries == nil {
+		return
+	}
+	c.retries.WithLabelValues(endpoint, reason).Inc()
+}
+
+// ObserveFailover records a switch from one node to another.
+func (c *Collector) ObserveFailover(from, to string) {
+	if c == nil || c.failovers == nil {
+		return
+	}
+	c.failovers.WithLabelValues(from, to).Inc()
+}
+
+// ObserveCache records a lookup in the named cache. result is "hit",
+// "miss" or "stale"; age, the age of the entry found, is recorded for hits
+// and stale entries.
+func (c *Collector) ObserveCache(cache, result string, age time.Duration) {
+	if c == nil || c.cache == nil {
+		return
+	}
+	c.cache.WithLabelValues(cache, result).Inc()
+	if result != "miss" && c.cacheAge != nil {
+		c.cacheAge.WithLabelValues(cache, result).Observe(age.Seconds())
+	}
+}
+
+// ObserveSlowRequest records a request that exceeded its latency budget.
+func (c *Collector) ObserveSlowRequest(method, endpoint string) {
+	if c == nil || c.slow == nil {
+		return
+	}
+	c.slow.WithLabelValues(method, endpoint).Inc()
+}
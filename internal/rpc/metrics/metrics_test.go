@@ -0,0 +1,56 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollector(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	c, err := New(reg)
+	require.NoError(t, err)
+
+	c.ObserveRequest("getHealth", "rpc.example", "ok", 20*time.Millisecond)
+	c.ObserveRequest("getHealth", "rpc.example", "503", time.Second)
+	c.ObserveError("getHealth", "rpc.example", "http_503")
+	c.ObserveRetry("rpc.example", "503")
+	c.ObserveFailover("a.example", "b.example")
+	c.ObserveCache("simulation", "hit", time.Second)
+	c.ObserveCache("simulation", "miss", 0)
+	c.ObserveCache("simulation", "miss", 0)
+	c.ObserveCache("ledger_entries", "stale", 25*time.Hour)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(c.requests.WithLabelValues("getHealth", "rpc.example", "ok")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(c.errors.WithLabelValues("getHealth", "rpc.example", "http_503")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(c.retries.WithLabelValues("rpc.example", "503")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(c.failovers.WithLabelValues("a.example", "b.example")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(c.cache.WithLabelValues("simulation", "miss")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(c.cache.WithLabelValues("ledger_entries", "stale")))
+	assert.Equal(t, 1, testutil.CollectAndCount(c.latency))
+	assert.Equal(t, 2, testutil.CollectAndCount(c.cacheAge), "misses have no age")
+
+	// A second collector on the same registry shares the series.
+	again, err := New(reg)
+	require.NoError(t, err)
+	again.ObserveCache("simulation", "hit", time.Second)
+	assert.Equal(t, 2.0, testutil.ToFloat64(c.cache.WithLabelValues("simulation", "hit")))
+}
+
+func TestNilCollector(t *testing.T) {
+	var c *Collector
+	assert.NotPanics(t, func() {
+		c.ObserveRequest("m", "e", "ok", time.Millisecond)
+		c.ObserveError("m", "e", "transport")
+		c.ObserveRetry("e", "transport")
+		c.ObserveFailover("a", "b")
+		c.ObserveCache("simulation", "hit", time.Second)
+	})
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"fmt"
+
+	"github.com/dotandev/hintents/internal/errors"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/operations"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// MuxedAddress is an account address split into its underlying G address and,
+// for muxed (M...) addresses, the 64-bit multiplexing ID.
+type MuxedAddress struct {
+	// Account is the underlying G... account that holds the balance.
+	Account string
+	// ID is the multiplexing ID; only meaningful when Muxed is true.
+	ID    uint64
+	Muxed bool
+}
+
+// NewMuxedAddress builds the muxed address for account and id.
+func NewMuxedAddress(account string, id uint64) (MuxedAddress, error) {
+	if _, err := xdr.MuxedAccountFromAccountId(account, id); err != nil {
+		return MuxedAddress{}, errors.WrapValidationError(fmt.Sprintf("invalid account %q: %v", account, err))
+	}
+	return MuxedAddress{Account: account, ID: id, Muxed: true}, nil
+}
+
+// ParseMuxedAddress accepts either a G... or an M... address.
+func ParseMuxedAddress(address string) (MuxedAddress, error) {
+	m, err := xdr.AddressToMuxedAccount(address)
+	if err != nil {
+		return MuxedAddress{}, errors.WrapValidationError(fmt.Sprintf("invalid address %q: %v", address, err))
+	}
+	account := m.ToAccountId()
+	out := MuxedAddress{Account: account.Address()}
+	if m.Type == xdr.CryptoKeyTypeKeyTypeMuxedEd25519 {
+		out.ID = uint64(m.Med25519.Id)
+		out.Muxed = true
+	}
+	return out, nil
+}
+
+// String returns the M... address when muxed and the G... address otherwise.
+func (m MuxedAddress) String() string {
+	if !m.Muxed {
+		return m.Account
+	}
+	muxed, err := xdr.MuxedAccountFromAccountId(m.Account, m.ID)
+	if err != nil {
+		return m.Account
+	}
+	return muxed.Address()
+}
+
+// underlyingAccount maps an M... address to its G... account, since Horizon
+// only indexes by the latter. Anything else is returned unchanged.
+func underlyingAccount(address string) string {
+	if m, err := ParseMuxedAddress(address); err == nil && m.Muxed {
+		return m.Account
+	}
+	return address
+}
+
+// muxedFromHorizon combines the account, <field>_muxed and <field>_muxed_id
+// triple Horizon emits for every account-valued field.
+func muxedFromHorizon(account, muxed string, id uint64) MuxedAddress {
+	if muxed == "" {
+		return MuxedAddress{Account: account}
+	}
+	return MuxedAddress{Account: account, ID: id, Muxed: true}
+}
+
+// TransactionSource returns the (possibly muxed) source account of tx.
+func TransactionSource(tx hProtocol.Transaction) MuxedAddress {
+	return muxedFromHorizon(tx.Account, tx.AccountMuxed, tx.AccountMuxedID)
+}
+
+// PaymentAccounts are the resolved participants of a value-moving operation.
+type PaymentAccounts struct {
+	Source MuxedAddress
+	From   MuxedAddress
+	To     MuxedAddress
+}
+
+// ResolvePaymentAccounts extracts the sender and receiver of payments, path
+// payments, account merges and account creations, keeping any muxed IDs. It
+// reports false for other operation types.
+func ResolvePaymentAccounts(op operations.Operation) (PaymentAccounts, bool) {
+	switch o := op.(type) {
+	case operations.Payment:
+		return paymentAccounts(o.Base, o), true
+	case operations.PathPayment:
+		return paymentAccounts(o.Base, o.Payment), true
+	case operations.PathPaymentStrictSend:
+		return paymentAccounts(o.Base, o.Payment), true
+	case operations.AccountMerge:
+		return PaymentAccounts{
+			Source: muxedFromHorizon(o.SourceAccount, o.SourceAccountMuxed, o.SourceAccountMuxedID),
+			From:   muxedFromHorizon(o.Account, o.AccountMuxed, o.AccountMuxedID),
+			To:     muxedFromHorizon(o.Into, o.IntoMuxed, o.IntoMuxedID),
+		}, true
+	case operations.CreateAccount:
+		return PaymentAccounts{
+			Source: muxedFromHorizon(o.SourceAccount, o.SourceAccountMuxed, o.SourceAccountMuxedID),
+			From:   muxedFromHorizon(o.Funder, o.FunderMuxed, o.FunderMuxedID),
+			To:     MuxedAddress{Account: o.Account},
+		}, true
+	}
+	return PaymentAccounts{}, false
+}
+
+func paymentAccounts(b operations.Base, p operations.Payment) PaymentAccounts {
+	return PaymentAccounts{
+		Source: muxedFromHorizon(b.SourceAccount, b.SourceAccountMuxed, b.SourceAccountMuxedID),
+		From:   muxedFromHorizon(p.From, p.FromMuxed, p.FromMuxedID),
+		To:     muxedFromHorizon(p.To, p.ToMuxed, p.ToMuxedID),
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"strings"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/operations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMuxedAddressRoundTrip(t *testing.T) {
+	m, err := NewMuxedAddress(testIssuer, 1234)
+	require.NoError(t, err)
+
+	encoded := m.String()
+	assert.True(t, strings.HasPrefix(encoded, "M"))
+
+	parsed, err := ParseMuxedAddress(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, m, parsed)
+	assert.Equal(t, testIssuer, underlyingAccount(encoded))
+
+	plain, err := ParseMuxedAddress(testIssuer)
+	require.NoError(t, err)
+	assert.False(t, plain.Muxed)
+	assert.Equal(t, testIssuer, plain.String())
+
+	_, err = ParseMuxedAddress("not-an-address")
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+	_, err = NewMuxedAddress("GBAD", 1)
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+}
+
+func TestResolvePaymentAccounts(t *testing.T) {
+	to, err := NewMuxedAddress(testClaimant, 42)
+	require.NoError(t, err)
+
+	op := operations.PathPaymentStrictSend{Payment: operations.Payment{
+		Base:      operations.Base{SourceAccount: testIssuer},
+		From:      testIssuer,
+		To:        testClaimant,
+		ToMuxed:   to.String(),
+		ToMuxedID: 42,
+	}}
+
+	accounts, ok := ResolvePaymentAccounts(op)
+	require.True(t, ok)
+	assert.False(t, accounts.From.Muxed)
+	assert.Equal(t, to, accounts.To)
+	assert.Equal(t, testIssuer, accounts.Source.Account)
+
+	_, ok = ResolvePaymentAccounts(operations.SetOptions{})
+	assert.False(t, ok)
+
+	src := TransactionSource(hProtocol.Transaction{Account: testClaimant, AccountMuxed: to.String(), AccountMuxedID: 42})
+	assert.Equal(t, uint64(42), src.ID)
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+
+	"github.com/dotandev/hintents/internal/errors"
+)
+
+// NetworkInfo describes the network a Soroban RPC endpoint serves.
+type NetworkInfo struct {
+	Passphrase      string `json:"passphrase"`
+	ProtocolVersion uint32 `json:"protocolVersion"`
+	FriendbotURL    string `json:"friendbotUrl,omitempty"`
+}
+
+// GetNetwork returns the network Soroban RPC serves.
+func (c *Client) GetNetwork(ctx context.Context) (*NetworkInfo, error) {
+	var info NetworkInfo
+	if err := c.callSoroban(ctx, "getNetwork", nil, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// VerifyNetwork checks that Soroban RPC serves the network the client is
+// configured for, returning an error matching errors.ErrNetworkMismatch when
+// it does not. A client without a network passphrase is not checked.
+func (c *Client) VerifyNetwork(ctx context.Context) error {
+	expected := c.Config.NetworkPassphrase
+	if expected == "" {
+		return nil
+	}
+	info, err := c.GetNetwork(ctx)
+	if err != nil {
+		return err
+	}
+	if info.Passphrase != expected {
+		return errors.WrapNetworkMismatch(c.SorobanURL, expected, info.Passphrase)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyNetwork(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"passphrase":"Test SDF Network ; September 2015","protocolVersion":22}}`))
+	}))
+	defer server.Close()
+
+	testnet, err := NewClient(WithNetwork(Testnet), WithSorobanURL(server.URL))
+	require.NoError(t, err)
+	info, err := testnet.GetNetwork(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint32(22), info.ProtocolVersion)
+	assert.NoError(t, testnet.VerifyNetwork(context.Background()))
+
+	mainnet, err := NewClient(WithNetwork(Mainnet), WithSorobanURL(server.URL))
+	require.NoError(t, err)
+	err = mainnet.VerifyNetwork(context.Background())
+	assert.ErrorIs(t, err, errs.ErrNetworkMismatch)
+	var mismatch *errs.NetworkMismatchError
+	require.ErrorAs(t, err, &mismatch)
+	assert.Equal(t, "Test SDF Network ; September 2015", mismatch.Actual)
+	assert.Equal(t, errs.CategoryValidation, errs.CategoryOf(err))
+}
@@ -3,21 +3,30 @@
 
 package rpc
 
-import hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+import (
+	"time"
 
-// TransactionResponse holds the XDR data for a transaction
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+)
+
+// TransactionResponse holds the XDR data for a transaction and the ledger
+// it was applied in
 type TransactionResponse struct {
-	EnvelopeXdr   string
-	ResultXdr     string
-	ResultMetaXdr string
+	EnvelopeXdr     string
+	ResultXdr       string
+	ResultMetaXdr   string
+	Ledger          int32
+	LedgerCloseTime time.Time
 }
 
 // ParseTransactionResponse converts a Horizon transaction into a TransactionResponse
 func ParseTransactionResponse(tx hProtocol.Transaction) *TransactionResponse {
 	return &TransactionResponse{
-		EnvelopeXdr:   tx.EnvelopeXdr,
-		ResultXdr:     tx.ResultXdr,
-		ResultMetaXdr: tx.ResultMetaXdr,
+		EnvelopeXdr:     tx.EnvelopeXdr,
+		ResultXdr:       tx.ResultXdr,
+		ResultMetaXdr:   tx.ResultMetaXdr,
+		Ledger:          tx.Ledger,
+		LedgerCloseTime: tx.LedgerCloseTime,
 	}
 }
 
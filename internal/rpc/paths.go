@@ -0,0 +1,149 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"net/url"
+	"sort"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/amount"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+)
+
+// StrictSendPathRequest asks for routes that send exactly SourceAmount of
+// SourceAsset. Exactly one of DestinationAccount or DestinationAssets must be set.
+type StrictSendPathRequest struct {
+	SourceAsset        txnbuild.Asset
+	SourceAmount       string
+	DestinationAccount string
+	DestinationAssets  []txnbuild.Asset
+}
+
+// StrictReceivePathRequest asks for routes that deliver exactly
+// DestinationAmount of DestinationAsset. Exactly one of SourceAccount or
+// SourceAssets must be set.
+type StrictReceivePathRequest struct {
+	DestinationAsset  txnbuild.Asset
+	DestinationAmount string
+	SourceAccount     string
+	SourceAssets      []txnbuild.Asset
+}
+
+// PaymentPath is a single route found by Horizon's path finding. Path lists
+// the intermediate assets between the source and destination assets.
+type PaymentPath struct {
+	SourceAsset       txnbuild.Asset
+	SourceAmount      string
+	DestinationAsset  txnbuild.Asset
+	DestinationAmount string
+	Path              []txnbuild.Asset
+}
+
+// FindStrictSendPaths returns the routes for a strict-send payment, best
+// first (largest destination amount).
+func (c *Client) FindStrictSendPaths(ctx context.Context, req StrictSendPathRequest) ([]PaymentPath, error) {
+	if err := validatePathAmount(req.SourceAmount); err != nil {
+		return nil, err
+	}
+	if (req.DestinationAccount == "") == (len(req.DestinationAssets) == 0) {
+		return nil, errors.WrapValidationError("exactly one of destination account or destination assets is required")
+	}
+
+	q := url.Values{}
+	if err := assetQueryParams(q, "source", req.SourceAsset); err != nil {
+		return nil, err
+	}
+	q.Set("source_amount", req.SourceAmount)
+	if req.DestinationAccount != "" {
+		q.Set("destination_account", req.DestinationAccount)
+	} else {
+		q.Set("destination_assets", canonicalAssetList(req.DestinationAssets))
+	}
+
+	paths, err := c.findPaths(ctx, "/paths/strict-send", q)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(paths, func(i, j int) bool {
+		return parseAmountOrZero(paths[i].DestinationAmount) > parseAmountOrZero(paths[j].DestinationAmount)
+	})
+	return paths, nil
+}
+
+// FindStrictReceivePaths returns the routes for a strict-receive payment,
+// best first (smallest source amount).
+func (c *Client) FindStrictReceivePaths(ctx context.Context, req StrictReceivePathRequest) ([]PaymentPath, error) {
+	if err := validatePathAmount(req.DestinationAmount); err != nil {
+		return nil, err
+	}
+	if (req.SourceAccount == "") == (len(req.SourceAssets) == 0) {
+		return nil, errors.WrapValidationError("exactly one of source account or source assets is required")
+	}
+
+	q := url.Values{}
+	if err := assetQueryParams(q, "destination", req.DestinationAsset); err != nil {
+		return nil, err
+	}
+	q.Set("destination_amount", req.DestinationAmount)
+	if req.SourceAccount != "" {
+		q.Set("source_account", req.SourceAccount)
+	} else {
+		q.Set("source_assets", canonicalAssetList(req.SourceAssets))
+	}
+
+	paths, err := c.findPaths(ctx, "/paths/strict-receive", q)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(paths, func(i, j int) bool {
+		return parseAmountOrZero(paths[i].SourceAmount) < parseAmountOrZero(paths[j].SourceAmount)
+	})
+	return paths, nil
+}
+
+func (c *Client) findPaths(ctx context.Context, endpoint string, q url.Values) ([]PaymentPath, error) {
+	c.logContext(ctx, LogSubsystemRPC).Debug("Finding payment paths", "endpoint", endpoint)
+
+	var page hProtocol.PathsPage
+	if err := c.getHorizon(ctx, endpoint, q, &page); err != nil {
+		return nil, err
+	}
+
+	out := make([]PaymentPath, 0, len(page.Embedded.Records))
+	for _, p := range page.Embedded.Records {
+		hops := make([]txnbuild.Asset, 0, len(p.Path))
+		for _, hop := range p.Path {
+			hops = append(hops, assetFromHorizon(hop.Type, hop.Code, hop.Issuer))
+		}
+		out = append(out, PaymentPath{
+			SourceAsset:       assetFromHorizon(p.SourceAssetType, p.SourceAssetCode, p.SourceAssetIssuer),
+			SourceAmount:      p.SourceAmount,
+			DestinationAsset:  assetFromHorizon(p.DestinationAssetType, p.DestinationAssetCode, p.DestinationAssetIssuer),
+			DestinationAmount: p.DestinationAmount,
+			Path:              hops,
+		})
+	}
+
+	c.logContext(ctx, LogSubsystemRPC).Debug("Payment paths found", "count", len(out))
+	return out, nil
+}
+
+func validatePathAmount(v string) error {
+	n, err := amount.ParseInt64(v)
+	if err != nil || n <= 0 {
+		return errors.WrapValidationError("path amount must be a positive decimal with at most 7 places")
+	}
+	return nil
+}
+
+func parseAmountOrZero(v string) int64 {
+	n, err := amount.ParseInt64(v)
+	if err != nil {
+		return 0
+	}
+	return n
+}
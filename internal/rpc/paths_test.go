@@ -0,0 +1,117 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testIssuer = "GCEZWKCA5VLDNRLN3RPRJMRZOX3Z6G5CHCGSNFHEYVXM3XOJMDS674JZ"
+
+func newHorizonTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	client, err := NewClient(WithNetwork(Testnet), WithHorizonURL(server.URL), WithHTTPClient(http.DefaultClient))
+	require.NoError(t, err)
+	return client
+}
+
+func TestFindStrictSendPaths(t *testing.T) {
+	client := newHorizonTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/paths/strict-send", r.URL.Path)
+		q := r.URL.Query()
+		assert.Equal(t, "native", q.Get("source_asset_type"))
+		assert.Equal(t, "10", q.Get("source_amount"))
+		assert.Equal(t, "USDC:"+testIssuer, q.Get("destination_assets"))
+		w.Write([]byte(`{"_embedded":{"records":[
+			{"source_asset_type":"native","source_amount":"10.0000000","destination_asset_type":"credit_alphanum4","destination_asset_code":"USDC","destination_asset_issuer":"` + testIssuer + `","destination_amount":"1.1000000","path":[]},
+			{"source_asset_type":"native","source_amount":"10.0000000","destination_asset_type":"credit_alphanum4","destination_asset_code":"USDC","destination_asset_issuer":"` + testIssuer + `","destination_amount":"1.2000000","path":[{"asset_type":"credit_alphanum4","asset_code":"EURC","asset_issuer":"` + testIssuer + `"}]}
+		]}}`))
+	})
+
+	paths, err := client.FindStrictSendPaths(context.Background(), StrictSendPathRequest{
+		SourceAsset:       txnbuild.NativeAsset{},
+		SourceAmount:      "10",
+		DestinationAssets: []txnbuild.Asset{txnbuild.CreditAsset{Code: "USDC", Issuer: testIssuer}},
+	})
+	require.NoError(t, err)
+	require.Len(t, paths, 2)
+
+	assert.Equal(t, "1.2000000", paths[0].DestinationAmount)
+	require.Len(t, paths[0].Path, 1)
+	assert.Equal(t, "EURC", paths[0].Path[0].GetCode())
+	assert.True(t, paths[0].SourceAsset.IsNative())
+	assert.Equal(t, "USDC", paths[0].DestinationAsset.GetCode())
+	assert.Equal(t, "1.1000000", paths[1].DestinationAmount)
+}
+
+func TestFindStrictReceivePaths(t *testing.T) {
+	client := newHorizonTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/paths/strict-receive", r.URL.Path)
+		q := r.URL.Query()
+		assert.Equal(t, "credit_alphanum4", q.Get("destination_asset_type"))
+		assert.Equal(t, "USDC", q.Get("destination_asset_code"))
+		assert.Equal(t, testIssuer, q.Get("source_account"))
+		w.Write([]byte(`{"_embedded":{"records":[
+			{"source_asset_type":"native","source_amount":"12.0000000","destination_asset_type":"credit_alphanum4","destination_asset_code":"USDC","destination_asset_issuer":"` + testIssuer + `","destination_amount":"1.0000000","path":[]},
+			{"source_asset_type":"native","source_amount":"9.5000000","destination_asset_type":"credit_alphanum4","destination_asset_code":"USDC","destination_asset_issuer":"` + testIssuer + `","destination_amount":"1.0000000","path":[]}
+		]}}`))
+	})
+
+	paths, err := client.FindStrictReceivePaths(context.Background(), StrictReceivePathRequest{
+		DestinationAsset:  txnbuild.CreditAsset{Code: "USDC", Issuer: testIssuer},
+		DestinationAmount: "1",
+		SourceAccount:     testIssuer,
+	})
+	require.NoError(t, err)
+	require.Len(t, paths, 2)
+	assert.Equal(t, "9.5000000", paths[0].SourceAmount)
+}
+
+func TestFindPaths_Validation(t *testing.T) {
+	client := newHorizonTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("no request expected")
+	})
+	ctx := context.Background()
+
+	_, err := client.FindStrictSendPaths(ctx, StrictSendPathRequest{SourceAsset: txnbuild.NativeAsset{}, SourceAmount: "0", DestinationAccount: testIssuer})
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+
+	_, err = client.FindStrictSendPaths(ctx, StrictSendPathRequest{SourceAsset: txnbuild.NativeAsset{}, SourceAmount: "1"})
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+
+	_, err = client.FindStrictReceivePaths(ctx, StrictReceivePathRequest{DestinationAmount: "1", SourceAccount: testIssuer})
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+}
+
+func TestFindPaths_BadRequestNotFailedOver(t *testing.T) {
+	calls := 0
+	client := newHorizonTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"title":"Bad Request","detail":"destination_assets is invalid"}`))
+	})
+	client.AltURLs = append(client.AltURLs, client.HorizonURL)
+
+	_, err := client.FindStrictSendPaths(context.Background(), StrictSendPathRequest{
+		SourceAsset: txnbuild.NativeAsset{}, SourceAmount: "1", DestinationAccount: testIssuer,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "destination_assets is invalid")
+	assert.Equal(t, 1, calls)
+
+	hErr, ok := AsHorizonError(err)
+	require.True(t, ok)
+	assert.Equal(t, "Bad Request", hErr.Title)
+}
@@ -0,0 +1,163 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+)
+
+// RequestError records where and when a request to one node failed. Every
+// node failure the client returns, directly or inside an
+// AllNodesFailedError, is a *RequestError wrapping the underlying cause, so
+// errors.Is and errors.As keep matching the cause.
+type RequestError struct {
+	// Method is the JSON-RPC method, Horizon path or client operation.
+	Method   string
+	Endpoint string
+	// Attempt is the 1-based position of the node in the failover sequence.
+	Attempt int
+	// RequestID is the correlation ID sent with the request, if any.
+	RequestID string
+	// Status is the HTTP status of the failed response, or 0 when none
+	// arrived or the cause, such as a JSON-RPC error, does not record one.
+	Status  int
+	Elapsed time.Duration
+	Err     error
+}
+
+func (e *RequestError) Error() string {
+	details := []string{fmt.Sprintf("attempt %d", e.Attempt)}
+	if e.RequestID != "" {
+		details = append(details, "request "+e.RequestID)
+	}
+	if e.Status != 0 {
+		details = append(details, fmt.Sprintf("status %d", e.Status))
+	}
+	details = append(details, e.Elapsed.Round(time.Millisecond).String())
+	return fmt.Sprintf("%s %s (%s): %v", e.Method, endpointLabel(e.Endpoint), strings.Join(details, ", "), e.Err)
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// LogAttrs returns the error's context as slog key-value pairs.
+func (e *RequestError) LogAttrs() []any {
+	attrs := []any{
+		"method", e.Method,
+		"endpoint", endpointLabel(e.Endpoint),
+		"attempt", e.Attempt,
+		"elapsed", e.Elapsed,
+	}
+	if e.RequestID != "" {
+		attrs = append(attrs, "request_id", e.RequestID)
+	}
+	if e.Status != 0 {
+		attrs = append(attrs, "status", e.Status)
+	}
+	return attrs
+}
+
+// requestError wraps the error of failover hop attempt, which started at
+// start against nodeURL, in a *RequestError. It returns nil for nil and
+// leaves an error that is already a *RequestError alone.
+func (c *Client) requestError(ctx context.Context, method, nodeURL string, attempt int, start time.Time, err error) error {
+	if err == nil {
+		return nil
+	}
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) {
+		return err
+	}
+	var cfg correlationConfig
+	if c.logs != nil {
+		cfg = c.logs.correlation
+	}
+	return &RequestError{
+		Method:    method,
+		Endpoint:  nodeURL,
+		Attempt:   attempt + 1,
+		RequestID: cfg.id(ctx),
+		Status:    httpStatusOf(err),
+		Elapsed:   time.Since(start),
+		Err:       err,
+	}
+}
+
+// httpStatusOf recovers the HTTP status behind err, or 0 when the failure
+// happened before a response arrived.
+func httpStatusOf(err error) int {
+	var hErr *errors.HorizonError
+	if errors.As(err, &hErr) {
+		return hErr.Status
+	}
+	var clientErr *horizonclient.Error
+	if errors.As(err, &clientErr) {
+		return clientErr.Problem.Status
+	}
+	var codeErr *errors.RPCCodeError
+	if errors.As(err, &codeErr) && codeErr.Code >= 100 && codeErr.Code < 600 {
+		return codeErr.Code
+	}
+	switch {
+	case errors.Is(err, errors.ErrRPCResponseTooLarge):
+		return 413
+	case errors.Is(err, errors.ErrLedgerNotFound):
+		return 404
+	case errors.Is(err, errors.ErrLedgerArchived):
+		return 410
+	case errors.Is(err, errors.ErrRateLimitExceeded):
+		return 429
+	}
+	return 0
+}
+
+// Summary is a short reason for the failure, such as "HTTP 429 Too Many
+// Requests", "timeout" or "DNS lookup failed: no such host", for listing
+// several endpoints' failures on one line.
+func (f NodeFailure) Summary() string {
+	err := f.Reason
+	if err == nil {
+		return "unknown error"
+	}
+	var dnsErr *net.DNSError
+	var netErr net.Error
+	switch {
+	case errors.As(err, &dnsErr):
+		return "DNS lookup failed: " + dnsErr.Err
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return "connection refused"
+	case errors.Is(err, syscall.ECONNRESET):
+		return "connection reset"
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, errors.ErrRPCTimeout),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	}
+	if status := httpStatusOf(err); status != 0 {
+		return fmt.Sprintf("HTTP %d %s", status, http.StatusText(status))
+	}
+	var codeErr *errors.RPCCodeError
+	if errors.As(err, &codeErr) {
+		return fmt.Sprintf("RPC error %d: %s", codeErr.Code, codeErr.Message)
+	}
+	if strings.Contains(err.Error(), "circuit breaker open") {
+		return "circuit breaker open"
+	}
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) {
+		return reqErr.Err.Error()
+	}
+	return err.Error()
+}
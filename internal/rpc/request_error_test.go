@@ -0,0 +1,63 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestError_HorizonStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"type":"not_found","title":"Resource Missing","status":404}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithNetwork(Testnet), WithHorizonURL(server.URL))
+	require.NoError(t, err)
+
+	var out map[string]interface{}
+	err = client.getHorizon(ContextWithCorrelationID(context.Background(), "req-7"), "/accounts/GX", nil, &out)
+	require.Error(t, err)
+
+	var reqErr *RequestError
+	require.ErrorAs(t, err, &reqErr)
+	assert.Equal(t, "/accounts/GX", reqErr.Method)
+	assert.Equal(t, server.URL, reqErr.Endpoint)
+	assert.Equal(t, 1, reqErr.Attempt)
+	assert.Equal(t, "req-7", reqErr.RequestID)
+	assert.Equal(t, http.StatusNotFound, reqErr.Status)
+	assert.Positive(t, reqErr.Elapsed)
+	assert.Contains(t, err.Error(), "attempt 1, request req-7, status 404")
+	assert.ErrorIs(t, err, errs.ErrRPCError, "the cause still matches")
+}
+
+func TestRequestError_InsideAllNodesFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"bad params"}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithNetwork(Testnet), WithSorobanURL(server.URL))
+	require.NoError(t, err)
+
+	_, err = client.GetHealth(context.Background())
+	var allFailed *AllNodesFailedError
+	require.ErrorAs(t, err, &allFailed)
+
+	var reqErr *RequestError
+	require.ErrorAs(t, err, &reqErr)
+	assert.Equal(t, "getHealth", reqErr.Method)
+	assert.Zero(t, reqErr.Status, "JSON-RPC errors carry no HTTP status")
+	assert.ErrorIs(t, err, errs.ErrRPCInvalidParams)
+	assert.Contains(t, reqErr.LogAttrs(), "getHealth")
+}
@@ -14,6 +14,8 @@ import (
 
 	"github.com/dotandev/hintents/internal/errors"
 	"github.com/dotandev/hintents/internal/logger"
+	"github.com/dotandev/hintents/internal/rpc/metrics"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // RetryConfig defines the retry behavior
@@ -83,8 +85,8 @@ func (r *Retrier) Do(ctx context.Context, req *http.Request) (*http.Response, er
 
 		// Check if response status is retryable
 		if r.shouldRetry(resp.StatusCode) {
-			lastErr = fmt.Errorf("status code %d", resp.StatusCode)
 			retryAfter := r.getRetryAfter(resp)
+			lastErr = retryStatusError(resp.StatusCode, retryAfter)
 
 			logger.Logger.Warn("Rate limited or temporary failure, will retry",
 				"attempt", attempt+1,
@@ -114,6 +116,16 @@ func (r *Retrier) Do(ctx context.Context, req *http.Request) (*http.Response, er
 	return nil, errors.WrapRPCConnectionFailed(lastErr)
 }
 
+// retryStatusError describes a retryable status. A 429 becomes a rate limit
+// error carrying the server's Retry-After, so callers that run out of
+// retries can still honour it.
+func retryStatusError(status int, retryAfter time.Duration) error {
+	if status == http.StatusTooManyRequests {
+		return errors.WrapRateLimited(retryAfter)
+	}
+	return fmt.Errorf("status code %d", status)
+}
+
 // shouldRetry determines if the response status code warrants a retry
 func (r *Retrier) shouldRetry(statusCode int) bool {
 	for _, code := range r.config.StatusCodesToRetry {
@@ -184,6 +196,9 @@ func (r *Retrier) waitWithContext(ctx context.Context, duration time.Duration) e
 type RetryTransport struct {
 	config    RetryConfig
 	transport http.RoundTripper
+	metrics   *metrics.Collector
+	logs      *clientLoggers
+	hooks     *clientHooks
 }
 
 // NewRetryTransport creates a new RetryTransport with the given config
@@ -197,40 +212,73 @@ func NewRetryTransport(config RetryConfig, transport http.RoundTripper) *RetryTr
 	}
 }
 
-// RoundTrip implements http.RoundTripper interface with retry logic
+// RoundTrip implements http.RoundTripper interface with retry logic. Each
+// attempt is recorded as a child span of the request's context.
 func (rt *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	var lastErr error
 	backoff := rt.config.InitialBackoff
+	var method string
+	retried := func(attempt, status int, elapsed time.Duration, err error) {
+		if rt.hooks == nil || len(rt.hooks.onRetry) == 0 {
+			return
+		}
+		if method == "" {
+			method = requestMethodLabel(req)
+		}
+		rt.hooks.retry(RetryEvent{
+			Method:     method,
+			Endpoint:   req.URL.Host,
+			Attempt:    attempt + 1,
+			StatusCode: status,
+			Duration:   elapsed,
+			Backoff:    backoff,
+			Err:        err,
+		})
+	}
 
 	for attempt := 0; attempt <= rt.config.MaxRetries; attempt++ {
+		var waited time.Duration
 		if attempt > 0 {
 			if err := rt.waitWithContext(req.Context(), backoff); err != nil {
 				return nil, errors.WrapRPCTimeout(err)
 			}
+			waited = backoff
 		}
 
-		resp, err := rt.transport.RoundTrip(req)
+		_, span := startRetryAttempt(req.Context(), req.URL.Host, attempt, waited)
+		start := time.Now()
+		resp, err := rt.transport.RoundTrip(req.WithContext(contextWithAttempt(req.Context(), attempt+1)))
+		elapsed := time.Since(start)
 		if err != nil {
+			endSpan(span, err)
 			lastErr = err
 			if attempt < rt.config.MaxRetries {
-				logger.Logger.Debug("RoundTrip failed, will retry", "attempt", attempt+1, "error", err)
+				rt.logs.loggerContext(req.Context(), LogSubsystemTransport).Debug("RoundTrip failed, will retry", "attempt", attempt+1, "error", err)
+				rt.metrics.ObserveRetry(req.URL.Host, "transport")
 			}
 			backoff = rt.nextBackoff(backoff)
+			if attempt < rt.config.MaxRetries {
+				retried(attempt, 0, elapsed, err)
+			}
 			continue
 		}
+		span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
 
 		// HTTP 413: response too large -- not retryable
 		if resp.StatusCode == http.StatusRequestEntityTooLarge {
 			resp.Body.Close()
-			return nil, errors.WrapRPCResponseTooLarge(req.URL.String())
+			err := errors.WrapRPCResponseTooLarge(req.URL.String())
+			endSpan(span, err)
+			return nil, err
 		}
 
 		// Check if response status is retryable
 		if rt.shouldRetry(resp.StatusCode) {
-			lastErr = fmt.Errorf("status code %d", resp.StatusCode)
 			retryAfter := rt.getRetryAfter(resp)
+			lastErr = retryStatusError(resp.StatusCode, retryAfter)
+			endSpan(span, lastErr)
 
-			logger.Logger.Warn("Rate limited or temporary failure, will retry",
+			rt.logs.loggerContext(req.Context(), LogSubsystemTransport).Warn("Rate limited or temporary failure, will retry",
 				"attempt", attempt+1,
 				"status_code", resp.StatusCode,
 				"retry_after", retryAfter,
@@ -245,6 +293,8 @@ func (rt *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 			}
 
 			if attempt < rt.config.MaxRetries {
+				rt.metrics.ObserveRetry(req.URL.Host, strconv.Itoa(resp.StatusCode))
+				retried(attempt, resp.StatusCode, elapsed, lastErr)
 				continue
 			}
 			// If we've exhausted retries on a retryable error, return error
@@ -252,6 +302,7 @@ func (rt *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 		}
 
 		// Success or non-retryable error
+		endSpan(span, nil)
 		return resp, nil
 	}
 
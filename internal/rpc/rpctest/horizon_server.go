@@ -0,0 +1,542 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpctest
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/network"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/operations"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// SubmitResult scripts the outcome of the next submission to a
+// HorizonServer. The zero value accepts the transaction.
+type SubmitResult struct {
+	// ResultCode is the transaction result code, such as tx_bad_seq or
+	// tx_failed. Empty and tx_success accept the transaction.
+	ResultCode string
+	// OperationCodes are reported for tx_failed, one per operation.
+	OperationCodes []string
+	// AsyncStatus overrides the tx_status of an async submission, for
+	// DUPLICATE and TRY_AGAIN_LATER. It is ERROR for a failing ResultCode
+	// and PENDING otherwise.
+	AsyncStatus rpc.AsyncSubmitStatus
+}
+
+// HorizonServer is an in-process fake Horizon. It serves the root
+// resource, accounts and their operations, fee stats, transactions with cursor paging, and
+// synchronous and async submission,
+// whose outcome tests script with QueueSubmitResult. Accepted transactions
+// are stored and returned by later lookups, after PendingLookups not-found
+// responses. FailNext makes it fail requests to exercise failover.
+type HorizonServer struct {
+	*httptest.Server
+
+	// Passphrase is the network transactions are hashed for. It defaults to
+	// the testnet passphrase.
+	Passphrase string
+	// PendingLookups is the number of times a transaction accepted by async
+	// submission is reported as not found before it appears.
+	PendingLookups int
+	// Apply, when set, is called with the envelope of every transaction the
+	// server accepts, so tests can update account state to match it.
+	Apply func(env xdr.TransactionEnvelope)
+
+	mu       sync.Mutex
+	accounts map[string]hProtocol.Account
+	txs      []hProtocol.Transaction
+	ops      []accountOperation
+	feeStats hProtocol.FeeStats
+	pending  map[string]int
+	submits  []SubmitResult
+	failures []int
+	ledger   int32
+	hits     map[string]int
+}
+
+// NewHorizonServer starts a HorizonServer. Callers must Close it.
+func NewHorizonServer() *HorizonServer {
+	s := &HorizonServer{
+		Passphrase: network.TestNetworkPassphrase,
+		accounts:   make(map[string]hProtocol.Account),
+		pending:    make(map[string]int),
+		hits:       make(map[string]int),
+		ledger:     1000,
+	}
+	s.feeStats.LastLedgerBaseFee = 100
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", s.handleRoot)
+	mux.HandleFunc("GET /accounts/{id}", s.handleAccount)
+	mux.HandleFunc("GET /accounts/{id}/transactions", s.handleTransactions)
+	mux.HandleFunc("GET /accounts/{id}/operations", s.handleOperations)
+	mux.HandleFunc("GET /transactions", s.handleTransactions)
+	mux.HandleFunc("GET /transactions/{hash}", s.handleTransaction)
+	mux.HandleFunc("GET /fee_stats", s.handleFeeStats)
+	mux.HandleFunc("POST /transactions", s.handleSubmit)
+	mux.HandleFunc("POST /transactions_async", s.handleSubmitAsync)
+	s.Server = httptest.NewServer(s.intercept(mux))
+	return s
+}
+
+// AddAccount stores account, replacing any account with the same ID.
+func (s *HorizonServer) AddAccount(account hProtocol.Account) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if account.ID == "" {
+		account.ID = account.AccountID
+	}
+	s.accounts[account.ID] = account
+}
+
+// Account returns the stored account with the given ID.
+func (s *HorizonServer) Account(id string) (hProtocol.Account, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	account, ok := s.accounts[id]
+	return account, ok
+}
+
+// AddTransaction stores tx as already included in a ledger. Its paging
+// token is assigned if empty.
+func (s *HorizonServer) AddTransaction(tx hProtocol.Transaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addTransaction(tx)
+}
+
+func (s *HorizonServer) addTransaction(tx hProtocol.Transaction) {
+	if tx.PT == "" {
+		tx.PT = strconv.Itoa(len(s.txs) + 1)
+	}
+	if tx.ID == "" {
+		tx.ID = tx.Hash
+	}
+	if tx.Ledger == 0 {
+		s.ledger++
+		tx.Ledger = s.ledger
+	}
+	s.txs = append(s.txs, tx)
+}
+
+type accountOperation struct {
+	account string
+	op      operations.Operation
+}
+
+// AddOperation stores op, a Horizon operation record such as
+// operations.Payment, in the history of account. Operations are served in
+// the order they were added.
+func (s *HorizonServer) AddOperation(account string, op operations.Operation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ops = append(s.ops, accountOperation{account: account, op: op})
+}
+
+// SetFeeStats sets the response to /fee_stats. Its last ledger is filled
+// in from the server's ledger when zero.
+func (s *HorizonServer) SetFeeStats(stats hProtocol.FeeStats) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.feeStats = stats
+}
+
+// QueueSubmitResult scripts the outcome of the next submissions, one
+// result per submission, in order.
+func (s *HorizonServer) QueueSubmitResult(results ...SubmitResult) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.submits = append(s.submits, results...)
+}
+
+// FailNext answers the next len(statuses) requests with the given HTTP
+// statuses, one per request, instead of serving them.
+func (s *HorizonServer) FailNext(statuses ...int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, statuses...)
+}
+
+// Requests returns how many requests were made for path, such as
+// "/transactions_async", including failed ones.
+func (s *HorizonServer) Requests(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.hits[path]
+}
+
+func (s *HorizonServer) intercept(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.hits[r.URL.Path]++
+		status := 0
+		if len(s.failures) > 0 {
+			status, s.failures = s.failures[0], s.failures[1:]
+		}
+		s.mu.Unlock()
+		if status != 0 {
+			writeProblem(w, problem.P{Title: http.StatusText(status), Status: status})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *HorizonServer) handleAccount(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	account, ok := s.accounts[r.PathValue("id")]
+	s.mu.Unlock()
+	if !ok {
+		writeProblem(w, problem.NotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, account)
+}
+
+func (s *HorizonServer) handleTransaction(w http.ResponseWriter, r *http.Request) {
+	hash := r.PathValue("hash")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.pending[hash] > 0 {
+		s.pending[hash]--
+		writeProblem(w, problem.NotFound)
+		return
+	}
+	for _, tx := range s.txs {
+		if tx.Hash == hash {
+			writeJSON(w, http.StatusOK, tx)
+			return
+		}
+	}
+	writeProblem(w, problem.NotFound)
+}
+
+// handleTransactions serves a page of transactions after cursor, following
+// Horizon's cursor, limit and order parameters.
+func (s *HorizonServer) handleTransactions(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 200 {
+		limit = 200
+	}
+	desc := q.Get("order") == "desc"
+	cursor, _ := strconv.ParseInt(q.Get("cursor"), 10, 64)
+	account := r.PathValue("id")
+
+	s.mu.Lock()
+	var matching []hProtocol.Transaction
+	for _, tx := range s.txs {
+		if account == "" || tx.Account == account {
+			matching = append(matching, tx)
+		}
+	}
+	s.mu.Unlock()
+	sort.SliceStable(matching, func(i, j int) bool {
+		a, _ := strconv.ParseInt(matching[i].PT, 10, 64)
+		b, _ := strconv.ParseInt(matching[j].PT, 10, 64)
+		if desc {
+			return a > b
+		}
+		return a < b
+	})
+
+	var records []hProtocol.Transaction
+	for _, tx := range matching {
+		pt, _ := strconv.ParseInt(tx.PT, 10, 64)
+		if q.Get("cursor") != "" && q.Get("cursor") != "now" && (desc && pt >= cursor || !desc && pt <= cursor) {
+			continue
+		}
+		records = append(records, tx)
+		if len(records) == limit {
+			break
+		}
+	}
+
+	next := q.Get("cursor")
+	if len(records) > 0 {
+		next = records[len(records)-1].PT
+	}
+	nq := r.URL.Query()
+	nq.Set("cursor", next)
+	nq.Set("limit", strconv.Itoa(limit))
+	self := s.URL + r.URL.Path + "?" + r.URL.RawQuery
+	page := map[string]any{
+		"_links": map[string]any{
+			"self": map[string]string{"href": self},
+			"next": map[string]string{"href": s.URL + r.URL.Path + "?" + nq.Encode()},
+			"prev": map[string]string{"href": self},
+		},
+		"_embedded": map[string]any{"records": emptyIfNil(records)},
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+// handleOperations serves a page of an account's operations, following
+// Horizon's limit and order parameters. The cursor is the position of the
+// last operation returned.
+func (s *HorizonServer) handleOperations(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	if limit <= 0 {
+		limit = 10
+	}
+	desc := q.Get("order") == "desc"
+	cursor, err := strconv.Atoi(q.Get("cursor"))
+	if err != nil {
+		cursor = -1
+	}
+	account := r.PathValue("id")
+
+	s.mu.Lock()
+	var positions []int
+	for i, o := range s.ops {
+		if o.account == account {
+			positions = append(positions, i)
+		}
+	}
+	if desc {
+		sort.Sort(sort.Reverse(sort.IntSlice(positions)))
+	}
+	records := []operations.Operation{}
+	next := q.Get("cursor")
+	for _, i := range positions {
+		if cursor >= 0 && (desc && i >= cursor || !desc && i <= cursor) {
+			continue
+		}
+		records = append(records, s.ops[i].op)
+		next = strconv.Itoa(i)
+		if len(records) == limit {
+			break
+		}
+	}
+	s.mu.Unlock()
+
+	nq := r.URL.Query()
+	nq.Set("cursor", next)
+	nq.Set("limit", strconv.Itoa(limit))
+	self := s.URL + r.URL.Path + "?" + r.URL.RawQuery
+	writeJSON(w, http.StatusOK, map[string]any{
+		"_links": map[string]any{
+			"self": map[string]string{"href": self},
+			"next": map[string]string{"href": s.URL + r.URL.Path + "?" + nq.Encode()},
+			"prev": map[string]string{"href": self},
+		},
+		"_embedded": map[string]any{"records": records},
+	})
+}
+
+// handleRoot serves the root resource for a Horizon on protocol 22.
+func (s *HorizonServer) handleRoot(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	root := hProtocol.Root{
+		HorizonVersion:               "rpctest",
+		HorizonSequence:              s.ledger,
+		NetworkPassphrase:            s.Passphrase,
+		CurrentProtocolVersion:       22,
+		CoreSupportedProtocolVersion: 22,
+	}
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, root)
+}
+
+func (s *HorizonServer) handleFeeStats(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	stats := s.feeStats
+	if stats.LastLedger == 0 {
+		stats.LastLedger = uint32(s.ledger)
+	}
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, stats)
+}
+
+func emptyIfNil(txs []hProtocol.Transaction) []hProtocol.Transaction {
+	if txs == nil {
+		return []hProtocol.Transaction{}
+	}
+	return txs
+}
+
+// handleSubmit serves the synchronous POST /transactions.
+func (s *HorizonServer) handleSubmit(w http.ResponseWriter, r *http.Request) {
+	tx, result, err := s.submit(r)
+	if err != nil {
+		writeProblem(w, problem.P{Title: "Transaction Malformed", Status: http.StatusBadRequest, Detail: err.Error()})
+		return
+	}
+	if failed(result) {
+		writeProblem(w, problem.P{
+			Type:   "https://stellar.org/horizon-errors/transaction_failed",
+			Title:  "Transaction Failed",
+			Status: http.StatusBadRequest,
+			Detail: "The transaction failed when submitted to the stellar network.",
+			Extras: map[string]interface{}{
+				"envelope_xdr": tx.EnvelopeXdr,
+				"result_xdr":   tx.ResultXdr,
+				"result_codes": map[string]interface{}{
+					"transaction": result.ResultCode,
+					"operations":  result.OperationCodes,
+				},
+			},
+		})
+		return
+	}
+	s.mu.Lock()
+	s.addTransaction(tx)
+	stored := s.txs[len(s.txs)-1]
+	s.mu.Unlock()
+	s.apply(tx)
+	writeJSON(w, http.StatusOK, stored)
+}
+
+// handleSubmitAsync serves POST /transactions_async with Horizon's status
+// codes: 201 PENDING, 409 DUPLICATE, 503 TRY_AGAIN_LATER and 400 ERROR.
+func (s *HorizonServer) handleSubmitAsync(w http.ResponseWriter, r *http.Request) {
+	tx, result, err := s.submit(r)
+	if err != nil {
+		writeProblem(w, problem.P{Title: "Transaction Malformed", Status: http.StatusBadRequest, Detail: err.Error()})
+		return
+	}
+	status := result.AsyncStatus
+	if status == "" {
+		status = rpc.AsyncStatusPending
+		if failed(result) {
+			status = rpc.AsyncStatusError
+		}
+	}
+	resp := hProtocol.AsyncTransactionSubmissionResponse{TxStatus: string(status), Hash: tx.Hash}
+	code := http.StatusCreated
+	switch status {
+	case rpc.AsyncStatusPending:
+		s.mu.Lock()
+		s.pending[tx.Hash] = s.PendingLookups
+		s.addTransaction(tx)
+		s.mu.Unlock()
+		s.apply(tx)
+	case rpc.AsyncStatusDuplicate:
+		code = http.StatusConflict
+	case rpc.AsyncStatusTryAgainLater:
+		code = http.StatusServiceUnavailable
+	case rpc.AsyncStatusError:
+		code = http.StatusBadRequest
+		resp.ErrorResultXDR = tx.ResultXdr
+	}
+	writeJSON(w, code, resp)
+}
+
+func (s *HorizonServer) apply(tx hProtocol.Transaction) {
+	if s.Apply == nil {
+		return
+	}
+	var env xdr.TransactionEnvelope
+	if err := xdr.SafeUnmarshalBase64(tx.EnvelopeXdr, &env); err == nil {
+		s.Apply(env)
+	}
+}
+
+// submit decodes the submitted envelope and takes the next scripted result.
+func (s *HorizonServer) submit(r *http.Request) (hProtocol.Transaction, SubmitResult, error) {
+	if err := r.ParseForm(); err != nil {
+		return hProtocol.Transaction{}, SubmitResult{}, err
+	}
+	envelope := r.PostForm.Get("tx")
+	var env xdr.TransactionEnvelope
+	if err := xdr.SafeUnmarshalBase64(envelope, &env); err != nil {
+		return hProtocol.Transaction{}, SubmitResult{}, fmt.Errorf("decode envelope: %w", err)
+	}
+	hash, err := network.HashTransactionInEnvelope(env, s.Passphrase)
+	if err != nil {
+		return hProtocol.Transaction{}, SubmitResult{}, err
+	}
+
+	s.mu.Lock()
+	var result SubmitResult
+	if len(s.submits) > 0 {
+		result, s.submits = s.submits[0], s.submits[1:]
+	}
+	s.mu.Unlock()
+
+	resultXdr, err := transactionResultXDR(result.ResultCode, int64(env.Fee()))
+	if err != nil {
+		return hProtocol.Transaction{}, SubmitResult{}, err
+	}
+	source := env.SourceAccount().ToAccountId()
+	tx := hProtocol.Transaction{
+		Hash:            hex.EncodeToString(hash[:]),
+		Successful:      !failed(result),
+		Account:         source.Address(),
+		AccountSequence: env.SeqNum(),
+		FeeCharged:      int64(env.Fee()),
+		MaxFee:          int64(env.Fee()),
+		OperationCount:  int32(len(env.Operations())),
+		EnvelopeXdr:     envelope,
+		ResultXdr:       resultXdr,
+		LedgerCloseTime: time.Now().UTC(),
+		FeeAccount:      source.Address(),
+		MemoType:        "none",
+		Signatures:      []string{},
+	}
+	return tx, result, nil
+}
+
+func failed(r SubmitResult) bool {
+	return r.ResultCode != "" && r.ResultCode != "tx_success"
+}
+
+// transactionResultXDR encodes a TransactionResult with the given Horizon
+// result code, such as tx_bad_seq.
+func transactionResultXDR(code string, fee int64) (string, error) {
+	if code == "" {
+		code = "tx_success"
+	}
+	res := xdr.TransactionResult{FeeCharged: xdr.Int64(fee)}
+	found := false
+	for v := int32(-30); v <= 30; v++ {
+		c := xdr.TransactionResultCode(v)
+		if c.ValidEnum(v) && errors.NormalizeTxResultCode(c.String()) == code {
+			res.Result.Code, found = c, true
+			break
+		}
+	}
+	if !found {
+		return "", fmt.Errorf("unknown transaction result code %q", code)
+	}
+	switch res.Result.Code {
+	case xdr.TransactionResultCodeTxSuccess, xdr.TransactionResultCodeTxFailed:
+		res.Result.Results = &[]xdr.OperationResult{}
+	case xdr.TransactionResultCodeTxFeeBumpInnerSuccess, xdr.TransactionResultCodeTxFeeBumpInnerFailed:
+		return "", fmt.Errorf("fee-bump result code %q is not supported", code)
+	}
+	return xdr.MarshalBase64(res)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/hal+json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeProblem(w http.ResponseWriter, p problem.P) {
+	if p.Type == "" {
+		p.Type = "https://stellar.org/horizon-errors/" + strings.ReplaceAll(strings.ToLower(p.Title), " ", "_")
+	}
+	w.Header().Set("Content-Type", "application/problem+json; charset=utf-8")
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
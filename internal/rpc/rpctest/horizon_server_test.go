@@ -0,0 +1,146 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpctest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/network"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/operations"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newHorizonClient(t *testing.T, urls ...string) *rpc.Client {
+	t.Helper()
+	client, err := rpc.NewClient(rpc.WithNetwork(rpc.Testnet), rpc.WithHorizonURL(urls[0]), rpc.WithAltURLs(urls))
+	require.NoError(t, err)
+	return client
+}
+
+func signedEnvelope(t *testing.T, source *keypair.Full) string {
+	t.Helper()
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &txnbuild.SimpleAccount{AccountID: source.Address(), Sequence: 1},
+		IncrementSequenceNum: true,
+		Operations:           []txnbuild.Operation{&txnbuild.BumpSequence{BumpTo: 9}},
+		BaseFee:              txnbuild.MinBaseFee,
+		Preconditions:        txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+	})
+	require.NoError(t, err)
+	tx, err = tx.Sign(network.TestNetworkPassphrase, source)
+	require.NoError(t, err)
+	env, err := tx.Base64()
+	require.NoError(t, err)
+	return env
+}
+
+func TestHorizonServer_AccountsAndPaging(t *testing.T) {
+	server := NewHorizonServer()
+	defer server.Close()
+
+	account := keypair.MustRandom().Address()
+	server.AddAccount(hProtocol.Account{AccountID: account, Sequence: 42})
+	for i := 0; i < 25; i++ {
+		server.AddTransaction(hProtocol.Transaction{Hash: fmt.Sprintf("%064x", i), Account: account, Successful: true})
+	}
+	server.AddTransaction(hProtocol.Transaction{Hash: fmt.Sprintf("%064x", 99), Account: "GOTHER"})
+
+	client := newHorizonClient(t, server.URL)
+	details, err := client.AccountDetails(context.Background(), account)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), details.Sequence)
+
+	txs, err := client.Transactions(context.Background(), horizonclient.TransactionRequest{ForAccount: account, Limit: 10}).Collect()
+	require.NoError(t, err)
+	require.Len(t, txs, 25)
+	assert.Equal(t, fmt.Sprintf("%064x", 24), txs[24].Hash)
+	assert.Equal(t, 4, server.Requests("/accounts/"+account+"/transactions"), "three full pages and an empty one")
+
+	_, err = client.AccountDetails(context.Background(), keypair.MustRandom().Address())
+	assert.Error(t, err)
+}
+
+func TestHorizonServer_Operations(t *testing.T) {
+	server := NewHorizonServer()
+	defer server.Close()
+
+	account := keypair.MustRandom().Address()
+	for i := 0; i < 5; i++ {
+		op := operations.BumpSequence{BumpTo: fmt.Sprint(i)}
+		op.ID, op.Type, op.TypeI = fmt.Sprint(i), "bump_sequence", 11
+		server.AddOperation(account, op)
+	}
+	server.AddOperation("GOTHER", operations.BumpSequence{})
+
+	client := newHorizonClient(t, server.URL)
+	ops, err := client.Operations(context.Background(), horizonclient.OperationRequest{
+		ForAccount: account, Order: horizonclient.OrderDesc, Limit: 2,
+	}).Collect()
+	require.NoError(t, err)
+	require.Len(t, ops, 5)
+	assert.Equal(t, "4", ops[0].GetID())
+	assert.Equal(t, "bump_sequence", ops[0].GetType())
+	assert.Equal(t, "0", ops[4].GetID())
+}
+
+func TestHorizonServer_SubmitAndWait(t *testing.T) {
+	server := NewHorizonServer()
+	defer server.Close()
+	server.PendingLookups = 2
+
+	source := keypair.MustRandom()
+	server.AddAccount(hProtocol.Account{
+		AccountID: source.Address(),
+		Signers:   []hProtocol.Signer{{Key: source.Address(), Type: "ed25519_public_key", Weight: 1}},
+	})
+	client := newHorizonClient(t, server.URL)
+	env := signedEnvelope(t, source)
+
+	res, err := client.SubmitTransactionAsync(context.Background(), env)
+	require.NoError(t, err)
+	assert.Equal(t, rpc.AsyncStatusPending, res.Status)
+
+	tx, err := client.WaitForTransaction(context.Background(), res.Hash, rpc.PollConfig{Interval: time.Millisecond, Timeout: 5 * time.Second})
+	require.NoError(t, err)
+	assert.True(t, tx.Successful)
+	assert.Equal(t, 3, server.Requests("/transactions/"+res.Hash))
+
+	server.QueueSubmitResult(SubmitResult{ResultCode: "tx_bad_seq"})
+	_, err = client.SubmitTransactionAsync(context.Background(), env)
+	assert.ErrorIs(t, err, errs.ErrTxBadSeq)
+
+	server.QueueSubmitResult(SubmitResult{AsyncStatus: rpc.AsyncStatusDuplicate})
+	res, err = client.SubmitTransactionAsync(context.Background(), env)
+	require.NoError(t, err)
+	assert.Equal(t, rpc.AsyncStatusDuplicate, res.Status)
+}
+
+func TestHorizonServer_Failover(t *testing.T) {
+	primary, backup := NewHorizonServer(), NewHorizonServer()
+	defer primary.Close()
+	defer backup.Close()
+
+	hash := fmt.Sprintf("%064x", 1)
+	for _, s := range []*HorizonServer{primary, backup} {
+		s.AddTransaction(hProtocol.Transaction{Hash: hash, Successful: true})
+	}
+	primary.FailNext(http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway)
+
+	client := newHorizonClient(t, primary.URL, backup.URL)
+	_, err := client.GetTransaction(context.Background(), hash)
+	require.NoError(t, err)
+	assert.Positive(t, primary.Requests("/transactions/"+hash))
+	assert.Equal(t, 1, backup.Requests("/transactions/"+hash))
+}
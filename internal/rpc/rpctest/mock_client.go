@@ -0,0 +1,668 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+// Package rpctest provides fakes of the rpc package for tests of code built
+// on rpc.RPCClient.
+package rpctest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/effects"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/operations"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+)
+
+// ErrNotProgrammed is returned by MockClient methods whose Func field is
+// not set.
+var ErrNotProgrammed = errors.New("mock method not programmed")
+
+// Call is one recorded MockClient call. Args holds the call's arguments
+// other than the context and iterator options.
+type Call struct {
+	Method string
+	Args   []any
+}
+
+// MockClient is an rpc.RPCClient whose methods call the matching Func
+// field, such as GetTransactionFunc for GetTransaction, and record every
+// call for the assertion helpers. Methods without a Func return zero values
+// and an error matching ErrNotProgrammed; iterators report it from Err.
+//
+//	m := &rpctest.MockClient{
+//		GetTransactionFunc: func(ctx context.Context, hash string) (*rpc.TransactionResponse, error) {
+//			return &rpc.TransactionResponse{EnvelopeXdr: envelope}, nil
+//		},
+//	}
+//	runCodeUnderTest(m)
+//	m.AssertCalled(t, "GetTransaction", hash)
+type MockClient struct {
+	// NetworkPassphrase and NetworkName are returned by GetNetworkPassphrase
+	// and GetNetworkName when their Funcs are not set.
+	NetworkPassphrase string
+	NetworkName       string
+
+	GetTransactionFunc               func(ctx context.Context, hash string) (*rpc.TransactionResponse, error)
+	GetLedgerHeaderFunc              func(ctx context.Context, sequence uint32) (*rpc.LedgerHeaderResponse, error)
+	GetLedgerEntriesFunc             func(ctx context.Context, keys []string) (map[string]string, error)
+	GetLedgerEntriesWithTTLFunc      func(ctx context.Context, keys []string) (*rpc.LedgerEntriesWithTTL, error)
+	GetLatestLedgerFunc              func(ctx context.Context) (*rpc.LedgerInfo, error)
+	GetEventsFunc                    func(ctx context.Context, req rpc.EventsRequest) (*rpc.EventsPage, error)
+	GetNetworkFunc                   func(ctx context.Context) (*rpc.NetworkInfo, error)
+	VerifyNetworkFunc                func(ctx context.Context) error
+	GetHealthFunc                    func(ctx context.Context) (*rpc.GetHealthResponse, error)
+	SimulateTransactionFunc          func(ctx context.Context, envelopeXdr string) (*rpc.SimulateTransactionResponse, error)
+	SimulateTransactionWithRetryFunc func(ctx context.Context, envelopeXdr string, config rpc.RetryConfig) (*rpc.SimulateTransactionResponse, error)
+
+	AccountDetailsFunc         func(ctx context.Context, id string) (*rpc.AccountDetails, error)
+	GetAccountsFunc            func(ctx context.Context, limit int) ([]rpc.AccountSummary, error)
+	GetAccountTransactionsFunc func(ctx context.Context, account string, limit int) ([]rpc.TransactionSummary, error)
+	GetEventsForAccountFunc    func(ctx context.Context, account string, limit int) ([]rpc.EventSummary, error)
+
+	TransactionsFunc   func(ctx context.Context, req horizonclient.TransactionRequest, opts ...rpc.IteratorOption) *rpc.Iterator[hProtocol.Transaction]
+	OperationsFunc     func(ctx context.Context, req horizonclient.OperationRequest, opts ...rpc.IteratorOption) *rpc.Iterator[operations.Operation]
+	PaymentsFunc       func(ctx context.Context, req horizonclient.OperationRequest, opts ...rpc.IteratorOption) *rpc.Iterator[operations.Operation]
+	EffectsFunc        func(ctx context.Context, req horizonclient.EffectRequest, opts ...rpc.IteratorOption) *rpc.Iterator[effects.Effect]
+	TypedEffectsFunc   func(ctx context.Context, req horizonclient.EffectRequest, opts ...rpc.IteratorOption) ([]rpc.Effect, error)
+	AccountsFunc       func(ctx context.Context, req horizonclient.AccountsRequest, opts ...rpc.IteratorOption) *rpc.Iterator[hProtocol.Account]
+	LedgersFunc        func(ctx context.Context, req horizonclient.LedgerRequest, opts ...rpc.IteratorOption) *rpc.Iterator[hProtocol.Ledger]
+	AssetsFunc         func(ctx context.Context, req horizonclient.AssetRequest, opts ...rpc.IteratorOption) *rpc.Iterator[hProtocol.AssetStat]
+	OffersFunc         func(ctx context.Context, req horizonclient.OfferRequest, opts ...rpc.IteratorOption) *rpc.Iterator[hProtocol.Offer]
+	TradesFunc         func(ctx context.Context, req horizonclient.TradeRequest, opts ...rpc.IteratorOption) *rpc.Iterator[hProtocol.Trade]
+	LiquidityPoolsFunc func(ctx context.Context, req horizonclient.LiquidityPoolsRequest, opts ...rpc.IteratorOption) *rpc.Iterator[hProtocol.LiquidityPool]
+
+	AssetStatsFunc               func(ctx context.Context, filter rpc.AssetStatsFilter, opts ...rpc.IteratorOption) ([]rpc.AssetStatistics, error)
+	AssetStatFunc                func(ctx context.Context, asset txnbuild.Asset) (*rpc.AssetStatistics, error)
+	AssetHoldersFunc             func(ctx context.Context, asset txnbuild.Asset, opts ...rpc.IteratorOption) *rpc.Iterator[hProtocol.Account]
+	ClaimableBalancesFunc        func(ctx context.Context, filter rpc.ClaimableBalanceFilter) ([]rpc.ClaimableBalance, error)
+	ClaimableBalanceFunc         func(ctx context.Context, id string) (*rpc.ClaimableBalance, error)
+	LiquidityPoolsByReservesFunc func(ctx context.Context, reserves []txnbuild.Asset, opts ...rpc.IteratorOption) ([]rpc.LiquidityPoolInfo, error)
+	LiquidityPoolFunc            func(ctx context.Context, poolID string) (*rpc.LiquidityPoolInfo, error)
+	LiquidityPoolTradesFunc      func(ctx context.Context, poolID string, opts ...rpc.IteratorOption) *rpc.Iterator[hProtocol.Trade]
+	LiquidityPoolEffectsFunc     func(ctx context.Context, poolID string, opts ...rpc.IteratorOption) *rpc.Iterator[effects.Effect]
+	OrderBookFunc                func(ctx context.Context, selling, buying txnbuild.Asset, depth int) (*rpc.OrderBook, error)
+	TradeAggregationsFunc        func(ctx context.Context, pair rpc.AssetPair, resolution time.Duration, rng rpc.TimeRange) ([]rpc.TradeBucket, error)
+	FindStrictSendPathsFunc      func(ctx context.Context, req rpc.StrictSendPathRequest) ([]rpc.PaymentPath, error)
+	FindStrictReceivePathsFunc   func(ctx context.Context, req rpc.StrictReceivePathRequest) ([]rpc.PaymentPath, error)
+
+	FeeStatsFunc          func(ctx context.Context) (hProtocol.FeeStats, error)
+	SuggestClassicFeeFunc func(ctx context.Context, percentile int) (int64, error)
+
+	ValidateEnvelopeFunc       func(ctx context.Context, envelopeXdr string) error
+	SubmitTransactionAsyncFunc func(ctx context.Context, envelopeXdr string) (*rpc.AsyncSubmitResult, error)
+	WaitForTransactionFunc     func(ctx context.Context, hash string, cfg rpc.PollConfig) (*hProtocol.Transaction, error)
+
+	StreamTransactionsFunc func(ctx context.Context, cursor string, handler rpc.TransactionStreamHandler) error
+	StreamLedgersFunc      func(ctx context.Context, cursor string, handler rpc.LedgerStreamHandler) error
+	StreamOperationsFunc   func(ctx context.Context, cursor string, handler rpc.OperationStreamHandler) error
+	StreamPaymentsFunc     func(ctx context.Context, cursor string, handler rpc.OperationStreamHandler) error
+	StreamEffectsFunc      func(ctx context.Context, cursor string, handler rpc.EffectStreamHandler) error
+	StreamTypedEffectsFunc func(ctx context.Context, cursor string, handler func(rpc.Effect) error) error
+	WatchAccountFunc       func(ctx context.Context, accountID string) (<-chan rpc.AccountEvent, error)
+	OnLedgerFunc           func(fn func(rpc.LedgerInfo)) (unsubscribe func())
+	RunLedgerTickerFunc    func(ctx context.Context, cfg rpc.LedgerTickerConfig) error
+
+	GetNetworkPassphraseFunc func() string
+	GetNetworkNameFunc       func() string
+	HealthFunc               func() rpc.HealthStatus
+	HealthHandlerFunc        func() http.Handler
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+var _ rpc.RPCClient = (*MockClient)(nil)
+
+func (m *MockClient) record(method string, args ...any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+}
+
+func notProgrammed(method string) error {
+	return fmt.Errorf("%w: %s", ErrNotProgrammed, method)
+}
+
+// Calls returns the recorded calls, in order. With a method name, only the
+// calls to that method are returned.
+func (m *MockClient) Calls(method ...string) []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []Call
+	for _, c := range m.calls {
+		if len(method) == 0 || c.Method == method[0] {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Reset forgets the recorded calls. The programmed Funcs are kept.
+func (m *MockClient) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+}
+
+// AssertCalled fails t unless method was called, with exactly args when
+// any are given.
+func (m *MockClient) AssertCalled(t testing.TB, method string, args ...any) bool {
+	t.Helper()
+	calls := m.Calls(method)
+	if len(args) == 0 && len(calls) > 0 {
+		return true
+	}
+	for _, c := range calls {
+		if reflect.DeepEqual(c.Args, args) {
+			return true
+		}
+	}
+	if len(args) == 0 {
+		t.Errorf("expected %s to be called", method)
+	} else {
+		t.Errorf("expected %s to be called with %v; calls: %v", method, args, calls)
+	}
+	return false
+}
+
+// AssertNotCalled fails t if method was called.
+func (m *MockClient) AssertNotCalled(t testing.TB, method string) bool {
+	t.Helper()
+	if calls := m.Calls(method); len(calls) > 0 {
+		t.Errorf("expected %s not to be called, got %d calls: %v", method, len(calls), calls)
+		return false
+	}
+	return true
+}
+
+// AssertNumberOfCalls fails t unless method was called exactly n times.
+func (m *MockClient) AssertNumberOfCalls(t testing.TB, method string, n int) bool {
+	t.Helper()
+	if got := len(m.Calls(method)); got != n {
+		t.Errorf("expected %s to be called %d times, got %d", method, n, got)
+		return false
+	}
+	return true
+}
+
+// Soroban RPC
+
+func (m *MockClient) GetTransaction(ctx context.Context, hash string) (*rpc.TransactionResponse, error) {
+	m.record("GetTransaction", hash)
+	if m.GetTransactionFunc != nil {
+		return m.GetTransactionFunc(ctx, hash)
+	}
+	return nil, notProgrammed("GetTransaction")
+}
+
+func (m *MockClient) GetLedgerHeader(ctx context.Context, sequence uint32) (*rpc.LedgerHeaderResponse, error) {
+	m.record("GetLedgerHeader", sequence)
+	if m.GetLedgerHeaderFunc != nil {
+		return m.GetLedgerHeaderFunc(ctx, sequence)
+	}
+	return nil, notProgrammed("GetLedgerHeader")
+}
+
+func (m *MockClient) GetLedgerEntries(ctx context.Context, keys []string) (map[string]string, error) {
+	m.record("GetLedgerEntries", keys)
+	if m.GetLedgerEntriesFunc != nil {
+		return m.GetLedgerEntriesFunc(ctx, keys)
+	}
+	return nil, notProgrammed("GetLedgerEntries")
+}
+
+func (m *MockClient) GetLedgerEntriesWithTTL(ctx context.Context, keys []string) (*rpc.LedgerEntriesWithTTL, error) {
+	m.record("GetLedgerEntriesWithTTL", keys)
+	if m.GetLedgerEntriesWithTTLFunc != nil {
+		return m.GetLedgerEntriesWithTTLFunc(ctx, keys)
+	}
+	return nil, notProgrammed("GetLedgerEntriesWithTTL")
+}
+
+func (m *MockClient) GetLatestLedger(ctx context.Context) (*rpc.LedgerInfo, error) {
+	m.record("GetLatestLedger")
+	if m.GetLatestLedgerFunc != nil {
+		return m.GetLatestLedgerFunc(ctx)
+	}
+	return nil, notProgrammed("GetLatestLedger")
+}
+
+func (m *MockClient) GetEvents(ctx context.Context, req rpc.EventsRequest) (*rpc.EventsPage, error) {
+	m.record("GetEvents", req)
+	if m.GetEventsFunc != nil {
+		return m.GetEventsFunc(ctx, req)
+	}
+	return nil, notProgrammed("GetEvents")
+}
+
+func (m *MockClient) GetNetwork(ctx context.Context) (*rpc.NetworkInfo, error) {
+	m.record("GetNetwork")
+	if m.GetNetworkFunc != nil {
+		return m.GetNetworkFunc(ctx)
+	}
+	return nil, notProgrammed("GetNetwork")
+}
+
+func (m *MockClient) VerifyNetwork(ctx context.Context) error {
+	m.record("VerifyNetwork")
+	if m.VerifyNetworkFunc != nil {
+		return m.VerifyNetworkFunc(ctx)
+	}
+	return notProgrammed("VerifyNetwork")
+}
+
+func (m *MockClient) GetHealth(ctx context.Context) (*rpc.GetHealthResponse, error) {
+	m.record("GetHealth")
+	if m.GetHealthFunc != nil {
+		return m.GetHealthFunc(ctx)
+	}
+	return nil, notProgrammed("GetHealth")
+}
+
+func (m *MockClient) SimulateTransaction(ctx context.Context, envelopeXdr string) (*rpc.SimulateTransactionResponse, error) {
+	m.record("SimulateTransaction", envelopeXdr)
+	if m.SimulateTransactionFunc != nil {
+		return m.SimulateTransactionFunc(ctx, envelopeXdr)
+	}
+	return nil, notProgrammed("SimulateTransaction")
+}
+
+func (m *MockClient) SimulateTransactionWithRetry(ctx context.Context, envelopeXdr string, config rpc.RetryConfig) (*rpc.SimulateTransactionResponse, error) {
+	m.record("SimulateTransactionWithRetry", envelopeXdr, config)
+	if m.SimulateTransactionWithRetryFunc != nil {
+		return m.SimulateTransactionWithRetryFunc(ctx, envelopeXdr, config)
+	}
+	return nil, notProgrammed("SimulateTransactionWithRetry")
+}
+
+// Horizon
+
+func (m *MockClient) AccountDetails(ctx context.Context, id string) (*rpc.AccountDetails, error) {
+	m.record("AccountDetails", id)
+	if m.AccountDetailsFunc != nil {
+		return m.AccountDetailsFunc(ctx, id)
+	}
+	return nil, notProgrammed("AccountDetails")
+}
+
+func (m *MockClient) GetAccounts(ctx context.Context, limit int) ([]rpc.AccountSummary, error) {
+	m.record("GetAccounts", limit)
+	if m.GetAccountsFunc != nil {
+		return m.GetAccountsFunc(ctx, limit)
+	}
+	return nil, notProgrammed("GetAccounts")
+}
+
+func (m *MockClient) GetAccountTransactions(ctx context.Context, account string, limit int) ([]rpc.TransactionSummary, error) {
+	m.record("GetAccountTransactions", account, limit)
+	if m.GetAccountTransactionsFunc != nil {
+		return m.GetAccountTransactionsFunc(ctx, account, limit)
+	}
+	return nil, notProgrammed("GetAccountTransactions")
+}
+
+func (m *MockClient) GetEventsForAccount(ctx context.Context, account string, limit int) ([]rpc.EventSummary, error) {
+	m.record("GetEventsForAccount", account, limit)
+	if m.GetEventsForAccountFunc != nil {
+		return m.GetEventsForAccountFunc(ctx, account, limit)
+	}
+	return nil, notProgrammed("GetEventsForAccount")
+}
+
+func (m *MockClient) Transactions(ctx context.Context, req horizonclient.TransactionRequest, opts ...rpc.IteratorOption) *rpc.Iterator[hProtocol.Transaction] {
+	m.record("Transactions", req)
+	if m.TransactionsFunc != nil {
+		return m.TransactionsFunc(ctx, req, opts...)
+	}
+	return rpc.NewSliceIterator[hProtocol.Transaction](ctx, nil, notProgrammed("Transactions"))
+}
+
+func (m *MockClient) Operations(ctx context.Context, req horizonclient.OperationRequest, opts ...rpc.IteratorOption) *rpc.Iterator[operations.Operation] {
+	m.record("Operations", req)
+	if m.OperationsFunc != nil {
+		return m.OperationsFunc(ctx, req, opts...)
+	}
+	return rpc.NewSliceIterator[operations.Operation](ctx, nil, notProgrammed("Operations"))
+}
+
+func (m *MockClient) Payments(ctx context.Context, req horizonclient.OperationRequest, opts ...rpc.IteratorOption) *rpc.Iterator[operations.Operation] {
+	m.record("Payments", req)
+	if m.PaymentsFunc != nil {
+		return m.PaymentsFunc(ctx, req, opts...)
+	}
+	return rpc.NewSliceIterator[operations.Operation](ctx, nil, notProgrammed("Payments"))
+}
+
+func (m *MockClient) Effects(ctx context.Context, req horizonclient.EffectRequest, opts ...rpc.IteratorOption) *rpc.Iterator[effects.Effect] {
+	m.record("Effects", req)
+	if m.EffectsFunc != nil {
+		return m.EffectsFunc(ctx, req, opts...)
+	}
+	return rpc.NewSliceIterator[effects.Effect](ctx, nil, notProgrammed("Effects"))
+}
+
+func (m *MockClient) TypedEffects(ctx context.Context, req horizonclient.EffectRequest, opts ...rpc.IteratorOption) ([]rpc.Effect, error) {
+	m.record("TypedEffects", req)
+	if m.TypedEffectsFunc != nil {
+		return m.TypedEffectsFunc(ctx, req, opts...)
+	}
+	return nil, notProgrammed("TypedEffects")
+}
+
+func (m *MockClient) Accounts(ctx context.Context, req horizonclient.AccountsRequest, opts ...rpc.IteratorOption) *rpc.Iterator[hProtocol.Account] {
+	m.record("Accounts", req)
+	if m.AccountsFunc != nil {
+		return m.AccountsFunc(ctx, req, opts...)
+	}
+	return rpc.NewSliceIterator[hProtocol.Account](ctx, nil, notProgrammed("Accounts"))
+}
+
+func (m *MockClient) Ledgers(ctx context.Context, req horizonclient.LedgerRequest, opts ...rpc.IteratorOption) *rpc.Iterator[hProtocol.Ledger] {
+	m.record("Ledgers", req)
+	if m.LedgersFunc != nil {
+		return m.LedgersFunc(ctx, req, opts...)
+	}
+	return rpc.NewSliceIterator[hProtocol.Ledger](ctx, nil, notProgrammed("Ledgers"))
+}
+
+func (m *MockClient) Assets(ctx context.Context, req horizonclient.AssetRequest, opts ...rpc.IteratorOption) *rpc.Iterator[hProtocol.AssetStat] {
+	m.record("Assets", req)
+	if m.AssetsFunc != nil {
+		return m.AssetsFunc(ctx, req, opts...)
+	}
+	return rpc.NewSliceIterator[hProtocol.AssetStat](ctx, nil, notProgrammed("Assets"))
+}
+
+func (m *MockClient) Offers(ctx context.Context, req horizonclient.OfferRequest, opts ...rpc.IteratorOption) *rpc.Iterator[hProtocol.Offer] {
+	m.record("Offers", req)
+	if m.OffersFunc != nil {
+		return m.OffersFunc(ctx, req, opts...)
+	}
+	return rpc.NewSliceIterator[hProtocol.Offer](ctx, nil, notProgrammed("Offers"))
+}
+
+func (m *MockClient) Trades(ctx context.Context, req horizonclient.TradeRequest, opts ...rpc.IteratorOption) *rpc.Iterator[hProtocol.Trade] {
+	m.record("Trades", req)
+	if m.TradesFunc != nil {
+		return m.TradesFunc(ctx, req, opts...)
+	}
+	return rpc.NewSliceIterator[hProtocol.Trade](ctx, nil, notProgrammed("Trades"))
+}
+
+func (m *MockClient) LiquidityPools(ctx context.Context, req horizonclient.LiquidityPoolsRequest, opts ...rpc.IteratorOption) *rpc.Iterator[hProtocol.LiquidityPool] {
+	m.record("LiquidityPools", req)
+	if m.LiquidityPoolsFunc != nil {
+		return m.LiquidityPoolsFunc(ctx, req, opts...)
+	}
+	return rpc.NewSliceIterator[hProtocol.LiquidityPool](ctx, nil, notProgrammed("LiquidityPools"))
+}
+
+func (m *MockClient) AssetStats(ctx context.Context, filter rpc.AssetStatsFilter, opts ...rpc.IteratorOption) ([]rpc.AssetStatistics, error) {
+	m.record("AssetStats", filter)
+	if m.AssetStatsFunc != nil {
+		return m.AssetStatsFunc(ctx, filter, opts...)
+	}
+	return nil, notProgrammed("AssetStats")
+}
+
+func (m *MockClient) AssetStat(ctx context.Context, asset txnbuild.Asset) (*rpc.AssetStatistics, error) {
+	m.record("AssetStat", asset)
+	if m.AssetStatFunc != nil {
+		return m.AssetStatFunc(ctx, asset)
+	}
+	return nil, notProgrammed("AssetStat")
+}
+
+func (m *MockClient) AssetHolders(ctx context.Context, asset txnbuild.Asset, opts ...rpc.IteratorOption) *rpc.Iterator[hProtocol.Account] {
+	m.record("AssetHolders", asset)
+	if m.AssetHoldersFunc != nil {
+		return m.AssetHoldersFunc(ctx, asset, opts...)
+	}
+	return rpc.NewSliceIterator[hProtocol.Account](ctx, nil, notProgrammed("AssetHolders"))
+}
+
+func (m *MockClient) ClaimableBalances(ctx context.Context, filter rpc.ClaimableBalanceFilter) ([]rpc.ClaimableBalance, error) {
+	m.record("ClaimableBalances", filter)
+	if m.ClaimableBalancesFunc != nil {
+		return m.ClaimableBalancesFunc(ctx, filter)
+	}
+	return nil, notProgrammed("ClaimableBalances")
+}
+
+func (m *MockClient) ClaimableBalance(ctx context.Context, id string) (*rpc.ClaimableBalance, error) {
+	m.record("ClaimableBalance", id)
+	if m.ClaimableBalanceFunc != nil {
+		return m.ClaimableBalanceFunc(ctx, id)
+	}
+	return nil, notProgrammed("ClaimableBalance")
+}
+
+func (m *MockClient) LiquidityPoolsByReserves(ctx context.Context, reserves []txnbuild.Asset, opts ...rpc.IteratorOption) ([]rpc.LiquidityPoolInfo, error) {
+	m.record("LiquidityPoolsByReserves", reserves)
+	if m.LiquidityPoolsByReservesFunc != nil {
+		return m.LiquidityPoolsByReservesFunc(ctx, reserves, opts...)
+	}
+	return nil, notProgrammed("LiquidityPoolsByReserves")
+}
+
+func (m *MockClient) LiquidityPool(ctx context.Context, poolID string) (*rpc.LiquidityPoolInfo, error) {
+	m.record("LiquidityPool", poolID)
+	if m.LiquidityPoolFunc != nil {
+		return m.LiquidityPoolFunc(ctx, poolID)
+	}
+	return nil, notProgrammed("LiquidityPool")
+}
+
+func (m *MockClient) LiquidityPoolTrades(ctx context.Context, poolID string, opts ...rpc.IteratorOption) *rpc.Iterator[hProtocol.Trade] {
+	m.record("LiquidityPoolTrades", poolID)
+	if m.LiquidityPoolTradesFunc != nil {
+		return m.LiquidityPoolTradesFunc(ctx, poolID, opts...)
+	}
+	return rpc.NewSliceIterator[hProtocol.Trade](ctx, nil, notProgrammed("LiquidityPoolTrades"))
+}
+
+func (m *MockClient) LiquidityPoolEffects(ctx context.Context, poolID string, opts ...rpc.IteratorOption) *rpc.Iterator[effects.Effect] {
+	m.record("LiquidityPoolEffects", poolID)
+	if m.LiquidityPoolEffectsFunc != nil {
+		return m.LiquidityPoolEffectsFunc(ctx, poolID, opts...)
+	}
+	return rpc.NewSliceIterator[effects.Effect](ctx, nil, notProgrammed("LiquidityPoolEffects"))
+}
+
+func (m *MockClient) OrderBook(ctx context.Context, selling, buying txnbuild.Asset, depth int) (*rpc.OrderBook, error) {
+	m.record("OrderBook", selling, buying, depth)
+	if m.OrderBookFunc != nil {
+		return m.OrderBookFunc(ctx, selling, buying, depth)
+	}
+	return nil, notProgrammed("OrderBook")
+}
+
+func (m *MockClient) TradeAggregations(ctx context.Context, pair rpc.AssetPair, resolution time.Duration, rng rpc.TimeRange) ([]rpc.TradeBucket, error) {
+	m.record("TradeAggregations", pair, resolution, rng)
+	if m.TradeAggregationsFunc != nil {
+		return m.TradeAggregationsFunc(ctx, pair, resolution, rng)
+	}
+	return nil, notProgrammed("TradeAggregations")
+}
+
+func (m *MockClient) FindStrictSendPaths(ctx context.Context, req rpc.StrictSendPathRequest) ([]rpc.PaymentPath, error) {
+	m.record("FindStrictSendPaths", req)
+	if m.FindStrictSendPathsFunc != nil {
+		return m.FindStrictSendPathsFunc(ctx, req)
+	}
+	return nil, notProgrammed("FindStrictSendPaths")
+}
+
+func (m *MockClient) FindStrictReceivePaths(ctx context.Context, req rpc.StrictReceivePathRequest) ([]rpc.PaymentPath, error) {
+	m.record("FindStrictReceivePaths", req)
+	if m.FindStrictReceivePathsFunc != nil {
+		return m.FindStrictReceivePathsFunc(ctx, req)
+	}
+	return nil, notProgrammed("FindStrictReceivePaths")
+}
+
+func (m *MockClient) FeeStats(ctx context.Context) (hProtocol.FeeStats, error) {
+	m.record("FeeStats")
+	if m.FeeStatsFunc != nil {
+		return m.FeeStatsFunc(ctx)
+	}
+	return hProtocol.FeeStats{}, notProgrammed("FeeStats")
+}
+
+func (m *MockClient) SuggestClassicFee(ctx context.Context, percentile int) (int64, error) {
+	m.record("SuggestClassicFee", percentile)
+	if m.SuggestClassicFeeFunc != nil {
+		return m.SuggestClassicFeeFunc(ctx, percentile)
+	}
+	return 0, notProgrammed("SuggestClassicFee")
+}
+
+func (m *MockClient) ValidateEnvelope(ctx context.Context, envelopeXdr string) error {
+	m.record("ValidateEnvelope", envelopeXdr)
+	if m.ValidateEnvelopeFunc != nil {
+		return m.ValidateEnvelopeFunc(ctx, envelopeXdr)
+	}
+	return notProgrammed("ValidateEnvelope")
+}
+
+func (m *MockClient) SubmitTransactionAsync(ctx context.Context, envelopeXdr string) (*rpc.AsyncSubmitResult, error) {
+	m.record("SubmitTransactionAsync", envelopeXdr)
+	if m.SubmitTransactionAsyncFunc != nil {
+		return m.SubmitTransactionAsyncFunc(ctx, envelopeXdr)
+	}
+	return nil, notProgrammed("SubmitTransactionAsync")
+}
+
+func (m *MockClient) WaitForTransaction(ctx context.Context, hash string, cfg rpc.PollConfig) (*hProtocol.Transaction, error) {
+	m.record("WaitForTransaction", hash, cfg)
+	if m.WaitForTransactionFunc != nil {
+		return m.WaitForTransactionFunc(ctx, hash, cfg)
+	}
+	return nil, notProgrammed("WaitForTransaction")
+}
+
+// Streaming
+
+func (m *MockClient) StreamTransactions(ctx context.Context, cursor string, handler rpc.TransactionStreamHandler) error {
+	m.record("StreamTransactions", cursor)
+	if m.StreamTransactionsFunc != nil {
+		return m.StreamTransactionsFunc(ctx, cursor, handler)
+	}
+	return notProgrammed("StreamTransactions")
+}
+
+func (m *MockClient) StreamLedgers(ctx context.Context, cursor string, handler rpc.LedgerStreamHandler) error {
+	m.record("StreamLedgers", cursor)
+	if m.StreamLedgersFunc != nil {
+		return m.StreamLedgersFunc(ctx, cursor, handler)
+	}
+	return notProgrammed("StreamLedgers")
+}
+
+func (m *MockClient) StreamOperations(ctx context.Context, cursor string, handler rpc.OperationStreamHandler) error {
+	m.record("StreamOperations", cursor)
+	if m.StreamOperationsFunc != nil {
+		return m.StreamOperationsFunc(ctx, cursor, handler)
+	}
+	return notProgrammed("StreamOperations")
+}
+
+func (m *MockClient) StreamPayments(ctx context.Context, cursor string, handler rpc.OperationStreamHandler) error {
+	m.record("StreamPayments", cursor)
+	if m.StreamPaymentsFunc != nil {
+		return m.StreamPaymentsFunc(ctx, cursor, handler)
+	}
+	return notProgrammed("StreamPayments")
+}
+
+func (m *MockClient) StreamEffects(ctx context.Context, cursor string, handler rpc.EffectStreamHandler) error {
+	m.record("StreamEffects", cursor)
+	if m.StreamEffectsFunc != nil {
+		return m.StreamEffectsFunc(ctx, cursor, handler)
+	}
+	return notProgrammed("StreamEffects")
+}
+
+func (m *MockClient) StreamTypedEffects(ctx context.Context, cursor string, handler func(rpc.Effect) error) error {
+	m.record("StreamTypedEffects", cursor)
+	if m.StreamTypedEffectsFunc != nil {
+		return m.StreamTypedEffectsFunc(ctx, cursor, handler)
+	}
+	return notProgrammed("StreamTypedEffects")
+}
+
+func (m *MockClient) WatchAccount(ctx context.Context, accountID string) (<-chan rpc.AccountEvent, error) {
+	m.record("WatchAccount", accountID)
+	if m.WatchAccountFunc != nil {
+		return m.WatchAccountFunc(ctx, accountID)
+	}
+	return nil, notProgrammed("WatchAccount")
+}
+
+func (m *MockClient) OnLedger(fn func(rpc.LedgerInfo)) (unsubscribe func()) {
+	m.record("OnLedger")
+	if m.OnLedgerFunc != nil {
+		return m.OnLedgerFunc(fn)
+	}
+	return func() {}
+}
+
+func (m *MockClient) RunLedgerTicker(ctx context.Context, cfg rpc.LedgerTickerConfig) error {
+	m.record("RunLedgerTicker", cfg)
+	if m.RunLedgerTickerFunc != nil {
+		return m.RunLedgerTickerFunc(ctx, cfg)
+	}
+	return notProgrammed("RunLedgerTicker")
+}
+
+// Status
+
+func (m *MockClient) GetNetworkPassphrase() string {
+	m.record("GetNetworkPassphrase")
+	if m.GetNetworkPassphraseFunc != nil {
+		return m.GetNetworkPassphraseFunc()
+	}
+	return m.NetworkPassphrase
+}
+
+func (m *MockClient) GetNetworkName() string {
+	m.record("GetNetworkName")
+	if m.GetNetworkNameFunc != nil {
+		return m.GetNetworkNameFunc()
+	}
+	return m.NetworkName
+}
+
+func (m *MockClient) Health() rpc.HealthStatus {
+	m.record("Health")
+	if m.HealthFunc != nil {
+		return m.HealthFunc()
+	}
+	return rpc.HealthStatus{}
+}
+
+func (m *MockClient) HealthHandler() http.Handler {
+	m.record("HealthHandler")
+	if m.HealthHandlerFunc != nil {
+		return m.HealthHandlerFunc()
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var status rpc.HealthStatus
+		if m.HealthFunc != nil {
+			status = m.HealthFunc()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(status)
+	})
+}
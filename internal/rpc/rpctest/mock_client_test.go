@@ -0,0 +1,68 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpctest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failureRecorder is a testing.TB that records failures instead of
+// reporting them.
+type failureRecorder struct {
+	testing.TB
+	failed bool
+}
+
+func (r *failureRecorder) Helper() {}
+
+func (r *failureRecorder) Errorf(string, ...any) { r.failed = true }
+
+func TestMockClient(t *testing.T) {
+	ctx := context.Background()
+	m := &MockClient{
+		NetworkPassphrase: "Test SDF Network ; September 2015",
+		GetTransactionFunc: func(ctx context.Context, hash string) (*rpc.TransactionResponse, error) {
+			return &rpc.TransactionResponse{EnvelopeXdr: "AAAA" + hash}, nil
+		},
+		TransactionsFunc: func(ctx context.Context, req horizonclient.TransactionRequest, opts ...rpc.IteratorOption) *rpc.Iterator[hProtocol.Transaction] {
+			return rpc.NewSliceIterator(ctx, []hProtocol.Transaction{{Hash: "a"}, {Hash: "b"}}, nil)
+		},
+	}
+	var client rpc.RPCClient = m
+
+	tx, err := client.GetTransaction(ctx, "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "AAAAabc", tx.EnvelopeXdr)
+
+	txs, err := client.Transactions(ctx, horizonclient.TransactionRequest{ForAccount: "G1"}).Collect()
+	require.NoError(t, err)
+	assert.Len(t, txs, 2)
+
+	_, err = client.SimulateTransaction(ctx, "env")
+	assert.True(t, errors.Is(err, ErrNotProgrammed))
+	_, err = client.Ledgers(ctx, horizonclient.LedgerRequest{}).Collect()
+	assert.True(t, errors.Is(err, ErrNotProgrammed))
+	assert.Equal(t, "Test SDF Network ; September 2015", client.GetNetworkPassphrase())
+
+	m.AssertCalled(t, "GetTransaction", "abc")
+	m.AssertCalled(t, "Transactions", horizonclient.TransactionRequest{ForAccount: "G1"})
+	m.AssertNumberOfCalls(t, "SimulateTransaction", 1)
+	m.AssertNotCalled(t, "SubmitTransactionAsync")
+	assert.Len(t, m.Calls(), 5)
+
+	recorder := &failureRecorder{}
+	assert.False(t, m.AssertCalled(recorder, "GetTransaction", "other"))
+	assert.True(t, recorder.failed)
+
+	m.Reset()
+	assert.Empty(t, m.Calls())
+}
@@ -0,0 +1,244 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpctest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/keypair"
+)
+
+// StandalonePassphrase is the passphrase of the network quickstart runs
+// with --local.
+const StandalonePassphrase = "Standalone Network ; February 2017"
+
+// QuickstartEnv is the environment variable that enables StartQuickstart.
+// Without it the helper skips the test, so end-to-end tests cost nothing in
+// a plain go test run.
+const QuickstartEnv = "ERST_QUICKSTART"
+
+// DefaultQuickstartImage is the container image StartQuickstart runs.
+const DefaultQuickstartImage = "stellar/quickstart:latest"
+
+// Quickstart is a standalone network running in a stellar/quickstart
+// container, with a Client configured for it and a set of funded accounts.
+type Quickstart struct {
+	*rpc.Client
+
+	HorizonURL   string
+	SorobanURL   string
+	FriendbotURL string
+	Passphrase   string
+
+	// Accounts are funded by friendbot before StartQuickstart returns.
+	Accounts []*keypair.Full
+
+	container string
+}
+
+// QuickstartOption configures StartQuickstart.
+type QuickstartOption func(*quickstartConfig)
+
+type quickstartConfig struct {
+	image    string
+	accounts int
+	timeout  time.Duration
+}
+
+// WithImage runs image instead of DefaultQuickstartImage, to pin a
+// protocol version.
+func WithImage(image string) QuickstartOption {
+	return func(c *quickstartConfig) { c.image = image }
+}
+
+// WithFundedAccounts sets how many accounts are created and funded. It
+// defaults to 2.
+func WithFundedAccounts(n int) QuickstartOption {
+	return func(c *quickstartConfig) { c.accounts = n }
+}
+
+// WithStartTimeout bounds how long to wait for the network to come up. It
+// defaults to 3 minutes, which covers a cold image start.
+func WithStartTimeout(d time.Duration) QuickstartOption {
+	return func(c *quickstartConfig) { c.timeout = d }
+}
+
+// StartQuickstart launches a stellar/quickstart container running a
+// standalone network with Horizon, Soroban RPC and friendbot, waits until
+// it closes ledgers and serves RPC, funds the test accounts and returns
+// the network. The container is removed when the test ends.
+//
+// It skips the test unless ERST_QUICKSTART is set and docker is on the
+// PATH:
+//
+//	ERST_QUICKSTART=1 go test ./...
+func StartQuickstart(t testing.TB, opts ...QuickstartOption) *Quickstart {
+	t.Helper()
+	if os.Getenv(QuickstartEnv) == "" {
+		t.Skipf("set %s=1 to run tests against a quickstart container", QuickstartEnv)
+	}
+	if _, err := exec.LookPath("docker"); err != nil {
+		t.Skip("docker is not available")
+	}
+
+	cfg := quickstartConfig{image: DefaultQuickstartImage, accounts: 2, timeout: 3 * time.Minute}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.timeout)
+	defer cancel()
+
+	id, err := docker(ctx, "run", "-d", "--rm", "-p", "127.0.0.1::8000", cfg.image,
+		"--local", "--enable", "core,horizon,rpc")
+	if err != nil {
+		t.Fatalf("start quickstart: %v", err)
+	}
+	t.Cleanup(func() {
+		if _, err := docker(context.Background(), "rm", "-f", id); err != nil {
+			t.Logf("remove quickstart container %s: %v", id, err)
+		}
+	})
+
+	addr, err := docker(ctx, "port", id, "8000/tcp")
+	if err != nil {
+		t.Fatalf("find quickstart port: %v", err)
+	}
+	base := "http://" + strings.SplitN(addr, "\n", 2)[0]
+	qs := &Quickstart{
+		HorizonURL:   base,
+		SorobanURL:   base + "/rpc",
+		FriendbotURL: base + "/friendbot",
+		Passphrase:   StandalonePassphrase,
+		container:    id,
+	}
+	qs.Client, err = rpc.NewClient(rpc.WithNetworkConfig(rpc.NetworkConfig{
+		Name:              "standalone",
+		HorizonURL:        qs.HorizonURL,
+		SorobanRPCURL:     qs.SorobanURL,
+		NetworkPassphrase: qs.Passphrase,
+	}))
+	if err != nil {
+		t.Fatalf("configure client: %v", err)
+	}
+
+	if err := qs.waitReady(ctx); err != nil {
+		t.Fatalf("quickstart did not become ready: %v\n%s", err, qs.logs())
+	}
+	for i := 0; i < cfg.accounts; i++ {
+		kp := keypair.MustRandom()
+		if err := qs.fund(ctx, kp.Address()); err != nil {
+			t.Fatalf("fund test account: %v", err)
+		}
+		qs.Accounts = append(qs.Accounts, kp)
+	}
+	return qs
+}
+
+// Fund creates and funds address with friendbot.
+func (q *Quickstart) Fund(t testing.TB, address string) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if err := q.fund(ctx, address); err != nil {
+		t.Fatalf("fund %s: %v", address, err)
+	}
+}
+
+// waitReady polls until Horizon reports a closed ledger and Soroban RPC
+// answers getNetwork with the standalone passphrase.
+func (q *Quickstart) waitReady(ctx context.Context) error {
+	var last error
+	for {
+		last = q.ready(ctx)
+		if last == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (last error: %v)", ctx.Err(), last)
+		case <-time.After(2 * time.Second):
+		}
+	}
+}
+
+func (q *Quickstart) ready(ctx context.Context) error {
+	root, err := q.Horizon.Root()
+	if err != nil {
+		return fmt.Errorf("horizon: %w", err)
+	}
+	if root.HorizonSequence < 2 {
+		return fmt.Errorf("horizon has not ingested a ledger yet")
+	}
+	info, err := q.GetNetwork(ctx)
+	if err != nil {
+		return fmt.Errorf("soroban rpc: %w", err)
+	}
+	if info.Passphrase != q.Passphrase {
+		return fmt.Errorf("soroban rpc reports network %q", info.Passphrase)
+	}
+	return nil
+}
+
+// fund asks friendbot for address, retrying while friendbot itself is
+// still being funded after startup.
+func (q *Quickstart) fund(ctx context.Context, address string) error {
+	var last error
+	for {
+		last = q.friendbot(ctx, address)
+		if last == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (last error: %v)", ctx.Err(), last)
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func (q *Quickstart) friendbot(ctx context.Context, address string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, q.FriendbotURL+"?addr="+address, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("friendbot: %s: %s", resp.Status, bytes.TrimSpace(body))
+	}
+	return nil
+}
+
+// logs returns the tail of the container log, for failure messages.
+func (q *Quickstart) logs() string {
+	out, err := exec.Command("docker", "logs", "--tail", "50", q.container).CombinedOutput()
+	if err != nil {
+		return err.Error()
+	}
+	return string(out)
+}
+
+func docker(ctx context.Context, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("docker %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
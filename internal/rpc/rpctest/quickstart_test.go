@@ -0,0 +1,36 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpctest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartQuickstart(t *testing.T) {
+	qs := StartQuickstart(t, WithFundedAccounts(1))
+
+	info, err := qs.GetNetwork(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, StandalonePassphrase, info.Passphrase)
+
+	require.Len(t, qs.Accounts, 1)
+	account, err := qs.Horizon.AccountDetail(horizonclient.AccountRequest{AccountID: qs.Accounts[0].Address()})
+	require.NoError(t, err)
+	assert.NotEmpty(t, account.Balances)
+}
+
+func TestStartQuickstart_SkipsWithoutEnv(t *testing.T) {
+	t.Setenv(QuickstartEnv, "")
+	ran := false
+	t.Run("skipped", func(t *testing.T) {
+		StartQuickstart(t)
+		ran = true
+	})
+	assert.False(t, ran)
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpctest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/stellar/go-stellar-sdk/network"
+)
+
+// Response is one scripted reply of a SorobanServer. Result is encoded as
+// the JSON-RPC result unless Error is set. A non-zero HTTPStatus replaces
+// the whole reply with a bare HTTP error, for exercising retries.
+type Response struct {
+	Result     any
+	Error      *RPCError
+	HTTPStatus int
+}
+
+// RPCError is a JSON-RPC error object.
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+// Result returns a Response carrying result.
+func Result(result any) Response {
+	return Response{Result: result}
+}
+
+// Error returns a Response carrying a JSON-RPC error.
+func Error(code int, message string) Response {
+	return Response{Error: &RPCError{Code: code, Message: message}}
+}
+
+// SorobanServer is a fake Soroban JSON-RPC server whose replies tests
+// script per method. Replies queued with On are served in order and the
+// last one repeats, so a getTransaction script of NOT_FOUND, NOT_FOUND,
+// SUCCESS reports the transaction after two polls and keeps reporting it.
+// getHealth, getNetwork and getLatestLedger answer for a healthy testnet
+// node until scripted; any other unscripted method is a method-not-found
+// error.
+type SorobanServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	scripts  map[string][]Response
+	handlers map[string]func(params json.RawMessage) Response
+	calls    map[string][]json.RawMessage
+}
+
+// NewSorobanServer starts a SorobanServer. Callers must Close it.
+func NewSorobanServer() *SorobanServer {
+	s := &SorobanServer{
+		scripts:  make(map[string][]Response),
+		handlers: make(map[string]func(json.RawMessage) Response),
+		calls:    make(map[string][]json.RawMessage),
+	}
+	s.On("getHealth", Result(map[string]any{
+		"status": "healthy", "latestLedger": 1000, "oldestLedger": 1, "ledgerRetentionWindow": 17280,
+	}))
+	s.On("getNetwork", Result(map[string]any{
+		"passphrase": network.TestNetworkPassphrase, "protocolVersion": 22,
+	}))
+	s.On("getLatestLedger", Result(map[string]any{
+		"id": "0000000000000000000000000000000000000000000000000000000000000000", "sequence": 1000, "protocolVersion": 22,
+	}))
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// On replaces method's script with responses.
+func (s *SorobanServer) On(method string, responses ...Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.handlers, method)
+	s.scripts[method] = append([]Response(nil), responses...)
+}
+
+// Handle answers method by calling fn with the request's params, for
+// replies that depend on the request.
+func (s *SorobanServer) Handle(method string, fn func(params json.RawMessage) Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.scripts, method)
+	s.handlers[method] = fn
+}
+
+// Calls returns the params of every call to method, in order.
+func (s *SorobanServer) Calls(method string) []json.RawMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]json.RawMessage(nil), s.calls[method]...)
+}
+
+func (s *SorobanServer) serve(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeRPC(w, nil, Error(-32700, "parse error: "+err.Error()))
+		return
+	}
+	resp := s.next(req.Method, req.Params)
+	if resp.HTTPStatus != 0 {
+		http.Error(w, http.StatusText(resp.HTTPStatus), resp.HTTPStatus)
+		return
+	}
+	writeRPC(w, req.ID, resp)
+}
+
+func (s *SorobanServer) next(method string, params json.RawMessage) Response {
+	s.mu.Lock()
+	s.calls[method] = append(s.calls[method], params)
+	handler := s.handlers[method]
+	script := s.scripts[method]
+	var resp Response
+	if handler == nil && len(script) > 0 {
+		resp = script[0]
+		if len(script) > 1 {
+			s.scripts[method] = script[1:]
+		}
+	}
+	s.mu.Unlock()
+
+	switch {
+	case handler != nil:
+		return handler(params)
+	case len(script) == 0:
+		return Error(-32601, "method not found: "+method)
+	}
+	return resp
+}
+
+func writeRPC(w http.ResponseWriter, id json.RawMessage, resp Response) {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	body := map[string]any{"jsonrpc": "2.0", "id": id}
+	if resp.Error != nil {
+		body["error"] = resp.Error
+	} else {
+		body["result"] = resp.Result
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpctest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSorobanClient(t *testing.T, server *SorobanServer) *rpc.Client {
+	t.Helper()
+	client, err := rpc.NewClient(rpc.WithNetwork(rpc.Testnet), rpc.WithSorobanURL(server.URL), rpc.WithCacheEnabled(false))
+	require.NoError(t, err)
+	return client
+}
+
+// call posts a JSON-RPC request for a method the client has no wrapper for.
+func call(t *testing.T, url, method string) map[string]any {
+	t.Helper()
+	body, _ := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": method, "params": map[string]string{"hash": "abc"}})
+	resp, err := http.Post(url, "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var out struct {
+		Result map[string]any `json:"result"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	return out.Result
+}
+
+func TestSorobanServer_Defaults(t *testing.T) {
+	server := NewSorobanServer()
+	defer server.Close()
+	client := newSorobanClient(t, server)
+
+	require.NoError(t, client.VerifyNetwork(context.Background()))
+	ledger, err := client.GetLatestLedger(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1000), ledger.Sequence)
+
+	_, err = client.GetEvents(context.Background(), rpc.EventsRequest{StartLedger: 1})
+	assert.ErrorIs(t, err, errs.ErrRPCMethodNotFound)
+}
+
+func TestSorobanServer_ScriptedSimulation(t *testing.T) {
+	server := NewSorobanServer()
+	defer server.Close()
+	client := newSorobanClient(t, server)
+
+	server.On("simulateTransaction",
+		Result(map[string]any{"error": "HostError: Error(Contract, #3)", "latestLedger": 1000}),
+		Result(map[string]any{"minResourceFee": "1234", "transactionData": "AAAA", "latestLedger": 1001}),
+	)
+
+	first, err := client.SimulateTransaction(context.Background(), "env-1")
+	require.NoError(t, err)
+	assert.Contains(t, first.Result.Error, "Error(Contract, #3)")
+
+	second, err := client.SimulateTransaction(context.Background(), "env-2")
+	require.NoError(t, err)
+	assert.Equal(t, "1234", second.Result.MinResourceFee)
+	assert.Equal(t, "AAAA", second.Result.TransactionData)
+
+	calls := server.Calls("simulateTransaction")
+	require.Len(t, calls, 2)
+	assert.Contains(t, string(calls[1]), "env-2")
+}
+
+func TestSorobanServer_TransactionProgression(t *testing.T) {
+	server := NewSorobanServer()
+	defer server.Close()
+
+	server.On("getTransaction",
+		Result(map[string]any{"status": "NOT_FOUND"}),
+		Result(map[string]any{"status": "NOT_FOUND"}),
+		Result(map[string]any{"status": "SUCCESS", "ledger": 1002}),
+	)
+	var statuses []any
+	for i := 0; i < 4; i++ {
+		statuses = append(statuses, call(t, server.URL, "getTransaction")["status"])
+	}
+	assert.Equal(t, []any{"NOT_FOUND", "NOT_FOUND", "SUCCESS", "SUCCESS"}, statuses)
+
+	server.Handle("getTransaction", func(params json.RawMessage) Response {
+		var p struct{ Hash string }
+		_ = json.Unmarshal(params, &p)
+		return Result(map[string]any{"status": "FAILED", "txHash": p.Hash})
+	})
+	assert.Equal(t, "abc", call(t, server.URL, "getTransaction")["txHash"])
+}
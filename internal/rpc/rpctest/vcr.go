@@ -0,0 +1,348 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpctest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/errors"
+)
+
+// ErrNoInteraction is returned by a replaying Recorder for a request that
+// matches no unused interaction in its cassette.
+var ErrNoInteraction = errors.New("no recorded interaction matches request")
+
+// RecordEnv is the environment variable that switches Cassette to
+// recording when set to a non-empty value.
+const RecordEnv = "ERST_VCR_RECORD"
+
+// Mode selects whether a Recorder talks to the network.
+type Mode int
+
+const (
+	// ModeReplay answers every request from the cassette and never touches
+	// the network.
+	ModeReplay Mode = iota
+	// ModeRecord sends every request to the network and writes the
+	// sanitized interactions to the cassette on Save.
+	ModeRecord
+)
+
+const redacted = "[REDACTED]"
+
+// secretSeedPattern matches Stellar secret seeds.
+var secretSeedPattern = regexp.MustCompile(`\bS[A-Z2-7]{55}\b`)
+
+// sensitiveNameParts mark header and query parameter names whose values are
+// credentials.
+var sensitiveNameParts = []string{"token", "secret", "password", "apikey", "api-key", "api_key", "auth", "cookie"}
+
+// CassetteFile is the on-disk form of a cassette.
+type CassetteFile struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is one recorded request and the response it got.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a sanitized request.
+type RecordedRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a sanitized response.
+type RecordedResponse struct {
+	Status  int         `json:"status"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// Matcher reports whether a live request, sanitized the same way as the
+// cassette, is the recorded one.
+type Matcher func(live, recorded RecordedRequest) bool
+
+// DefaultMatcher matches on method, URL and body. JSON bodies are compared
+// as values, ignoring a top-level JSON-RPC "id", so key order and request
+// numbering do not matter.
+func DefaultMatcher(live, recorded RecordedRequest) bool {
+	if live.Method != recorded.Method || live.URL != recorded.URL {
+		return false
+	}
+	if live.Body == recorded.Body {
+		return true
+	}
+	var a, b map[string]any
+	if json.Unmarshal([]byte(live.Body), &a) != nil || json.Unmarshal([]byte(recorded.Body), &b) != nil {
+		return false
+	}
+	delete(a, "id")
+	delete(b, "id")
+	return reflect.DeepEqual(a, b)
+}
+
+// Recorder is an http.RoundTripper that records interactions with Horizon
+// and Soroban RPC to a cassette file and replays them. Before anything is
+// stored or matched, credentials are scrubbed: headers and query parameters
+// named like credentials, the secrets given with WithSecrets, and Stellar
+// secret seeds are replaced with [REDACTED].
+//
+//	rec := rpctest.Cassette(t, "get_transaction")
+//	client, _ := rpc.NewClient(rpc.WithHTTPClient(rec.HTTPClient()))
+type Recorder struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+	match     Matcher
+	secrets   []string
+
+	mu       sync.Mutex
+	cassette CassetteFile
+	used     []bool
+}
+
+// RecorderOption configures a Recorder.
+type RecorderOption func(*Recorder)
+
+// WithTransport sets the transport a recording Recorder sends requests
+// through. It defaults to http.DefaultTransport.
+func WithTransport(rt http.RoundTripper) RecorderOption {
+	return func(r *Recorder) { r.transport = rt }
+}
+
+// WithMatcher replaces DefaultMatcher.
+func WithMatcher(m Matcher) RecorderOption {
+	return func(r *Recorder) { r.match = m }
+}
+
+// WithSecrets scrubs each non-empty secret, such as an RPC token, wherever
+// it appears.
+func WithSecrets(secrets ...string) RecorderOption {
+	return func(r *Recorder) {
+		for _, s := range secrets {
+			if s != "" {
+				r.secrets = append(r.secrets, s)
+			}
+		}
+	}
+}
+
+// NewRecorder returns a Recorder for the cassette at path. In ModeReplay the
+// cassette must exist; in ModeRecord it is created or replaced by Save.
+func NewRecorder(path string, mode Mode, opts ...RecorderOption) (*Recorder, error) {
+	r := &Recorder{path: path, mode: mode, transport: http.DefaultTransport, match: DefaultMatcher}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if mode == ModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("load cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("parse cassette %s: %w", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+	return r, nil
+}
+
+// Cassette returns a Recorder for testdata/cassettes/<name>.json, replaying
+// unless ERST_VCR_RECORD is set, in which case the cassette is re-recorded
+// against the live endpoints and saved when the test ends.
+func Cassette(t testing.TB, name string, opts ...RecorderOption) *Recorder {
+	t.Helper()
+	mode := ModeReplay
+	if os.Getenv(RecordEnv) != "" {
+		mode = ModeRecord
+	}
+	r, err := NewRecorder(filepath.Join("testdata", "cassettes", name+".json"), mode, opts...)
+	if err != nil {
+		t.Fatalf("cassette %s: %v (set %s=1 to record it)", name, err, RecordEnv)
+	}
+	if mode == ModeRecord {
+		t.Cleanup(func() {
+			if err := r.Save(); err != nil {
+				t.Errorf("save cassette %s: %v", name, err)
+			}
+		})
+	}
+	return r
+}
+
+// HTTPClient returns an http.Client using r, for rpc.WithHTTPClient.
+func (r *Recorder) HTTPClient() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Interactions returns the interactions recorded or loaded so far.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.cassette.Interactions...)
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	live := RecordedRequest{
+		Method:  req.Method,
+		URL:     r.scrubURL(req.URL),
+		Headers: r.scrubHeaders(req.Header),
+		Body:    r.scrubText(string(body)),
+	}
+	if r.mode == ModeReplay {
+		return r.replay(req, live)
+	}
+	return r.record(req, live)
+}
+
+func (r *Recorder) replay(req *http.Request, live RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, in := range r.cassette.Interactions {
+		if r.used[i] || !r.match(live, in.Request) {
+			continue
+		}
+		r.used[i] = true
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.Status, http.StatusText(in.Response.Status)),
+			StatusCode:    in.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        in.Response.Headers.Clone(),
+			Body:          io.NopCloser(strings.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: %s %s in %s", ErrNoInteraction, live.Method, live.URL, r.path)
+}
+
+func (r *Recorder) record(req *http.Request, live RecordedRequest) (*http.Response, error) {
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request: live,
+		Response: RecordedResponse{
+			Status:  resp.StatusCode,
+			Headers: r.scrubHeaders(resp.Header),
+			Body:    r.scrubText(string(body)),
+		},
+	})
+	return resp, nil
+}
+
+// Save writes the recorded interactions to the cassette file. It does
+// nothing when replaying.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+func (r *Recorder) scrubHeaders(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	out := make(http.Header, len(h))
+	for name, values := range h {
+		for _, v := range values {
+			if isSensitiveName(name) {
+				v = redacted
+			}
+			out.Add(name, r.scrubText(v))
+		}
+	}
+	return out
+}
+
+func (r *Recorder) scrubURL(u *url.URL) string {
+	c := *u
+	c.User = nil
+	if q := c.Query(); len(q) > 0 {
+		for name, values := range q {
+			if isSensitiveName(name) {
+				for i := range values {
+					values[i] = redacted
+				}
+			}
+		}
+		c.RawQuery = q.Encode()
+	}
+	return r.scrubText(strings.ReplaceAll(c.String(), url.QueryEscape(redacted), redacted))
+}
+
+func (r *Recorder) scrubText(s string) string {
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	return secretSeedPattern.ReplaceAllString(s, redacted)
+}
+
+func isSensitiveName(name string) bool {
+	lower := strings.ToLower(name)
+	for _, part := range sensitiveNameParts {
+		if strings.Contains(lower, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// readRequestBody returns req's body, leaving it readable for the
+// transport.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
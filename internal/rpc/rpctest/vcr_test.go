@@ -0,0 +1,63 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpctest
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorderRecordAndReplay(t *testing.T) {
+	const token = "tok-123"
+	const seed = "SBXXLTPJQY4FZBQ6LHUWM2PA3YKKTBJZGNDUBG5TRTLQ3ZVZ5HZGSYPC"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"jsonrpc":"2.0","id":7,"result":{"sequence":42}}`)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	rec, err := NewRecorder(path, ModeRecord, WithSecrets(token))
+	require.NoError(t, err)
+
+	post := func(client *http.Client, body string) (*http.Response, error) {
+		req, err := http.NewRequest(http.MethodPost, server.URL+"?api_key="+token, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		return client.Do(req)
+	}
+	resp, err := post(rec.HTTPClient(), `{"jsonrpc":"2.0","id":1,"method":"getLatestLedger","params":{"note":"`+seed+`"}}`)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.NoError(t, rec.Save())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), token)
+	assert.NotContains(t, string(data), seed)
+
+	replay, err := NewRecorder(path, ModeReplay, WithSecrets(token))
+	require.NoError(t, err)
+	server.Close()
+
+	// Key order and the JSON-RPC id differ from the recording.
+	resp, err = post(replay.HTTPClient(), `{"params":{"note":"`+seed+`"},"method":"getLatestLedger","id":2,"jsonrpc":"2.0"}`)
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), `"sequence":42`)
+
+	_, err = post(replay.HTTPClient(), `{"jsonrpc":"2.0","id":3,"method":"getLatestLedger"}`)
+	assert.True(t, errors.Is(err, ErrNoInteraction))
+}
@@ -0,0 +1,182 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+)
+
+// SimulationFailureClass distinguishes failures worth retrying from those that
+// will reproduce on every attempt.
+type SimulationFailureClass string
+
+const (
+	SimulationFailureNone SimulationFailureClass = ""
+	// SimulationFailureTransient covers RPC backlog, unavailable ledger
+	// snapshots, dropped connections and rate limiting.
+	SimulationFailureTransient SimulationFailureClass = "transient"
+	// SimulationFailureContract covers deterministic host and contract errors.
+	SimulationFailureContract SimulationFailureClass = "contract"
+	// SimulationFailureRequest covers malformed requests and other errors that
+	// a retry will not fix.
+	SimulationFailureRequest SimulationFailureClass = "request"
+)
+
+// transientSimulationPatterns are lower-cased fragments of error messages that
+// Soroban RPC and intermediate proxies emit for temporary conditions.
+var transientSimulationPatterns = []string{
+	"backlog",
+	"snapshot",
+	"not yet available",
+	"unavailable",
+	"connection reset",
+	"connection refused",
+	"broken pipe",
+	"timed out",
+	"timeout",
+	"try again",
+	"too many requests",
+	"rate limit",
+	"circuit breaker open",
+}
+
+// SimulationError is returned by SimulateTransactionWithRetry when simulation
+// does not succeed. Response is set when the RPC answered with a result, so the
+// diagnostic events of a failed contract call remain available. Contract is
+// set when the call failed with Error(Contract, #N).
+type SimulationError struct {
+	Class    SimulationFailureClass
+	Message  string
+	Attempts int
+	Response *SimulateTransactionResponse
+	Contract *errors.ContractError
+	Err      error
+}
+
+func (e *SimulationError) Error() string {
+	return fmt.Sprintf("simulation failed (%s) after %d attempt(s): %s", e.Class, e.Attempts, e.Message)
+}
+
+// Unwrap exposes the underlying transport error, or ErrSimulationLogicError
+// and the contract error, if any, for failures reported in the simulation
+// result.
+func (e *SimulationError) Unwrap() []error {
+	if e.Err != nil {
+		return []error{e.Err}
+	}
+	if e.Contract != nil {
+		return []error{errors.ErrSimulationLogicError, e.Contract}
+	}
+	return []error{errors.ErrSimulationLogicError}
+}
+
+// IsTransientSimulationError reports whether err, as returned by
+// SimulateTransaction, is a temporary condition worth retrying.
+func IsTransientSimulationError(err error) bool {
+	return ClassifySimulationFailure(nil, err) == SimulationFailureTransient
+}
+
+// ClassifySimulationFailure inspects the outcome of a SimulateTransaction call.
+func ClassifySimulationFailure(resp *SimulateTransactionResponse, err error) SimulationFailureClass {
+	if err != nil {
+		return classifySimulationTransportError(err)
+	}
+	if resp == nil || resp.Result.Error == "" {
+		return SimulationFailureNone
+	}
+	if matchesTransientPattern(resp.Result.Error) {
+		return SimulationFailureTransient
+	}
+	return SimulationFailureContract
+}
+
+func classifySimulationTransportError(err error) SimulationFailureClass {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return SimulationFailureRequest
+	}
+
+	var allFailed *AllNodesFailedError
+	if errors.As(err, &allFailed) {
+		for _, f := range allFailed.Failures {
+			if classifySimulationTransportError(f.Reason) == SimulationFailureTransient {
+				return SimulationFailureTransient
+			}
+		}
+		return SimulationFailureRequest
+	}
+
+	if errors.IsRetryable(err) {
+		return SimulationFailureTransient
+	}
+	// Soroban RPC reports backlog and missing snapshots as internal errors,
+	// which are not retryable in general.
+	if errors.Is(err, errors.ErrRPCError) && matchesTransientPattern(err.Error()) {
+		return SimulationFailureTransient
+	}
+	return SimulationFailureRequest
+}
+
+func matchesTransientPattern(msg string) bool {
+	lower := strings.ToLower(msg)
+	for _, p := range transientSimulationPatterns {
+		if strings.Contains(lower, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// SimulateTransactionWithRetry simulates a transaction, retrying with
+// exponential backoff only while failures are transient. Contract errors are
+// returned immediately as a *SimulationError carrying the simulation response
+// and, for Error(Contract, #N), the decoded *errors.ContractError.
+func (c *Client) SimulateTransactionWithRetry(ctx context.Context, envelopeXdr string, config RetryConfig) (*SimulateTransactionResponse, error) {
+	backoffs := NewRetrier(config, nil)
+	backoff := config.InitialBackoff
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			if err := backoffs.waitWithContext(ctx, backoff); err != nil {
+				return nil, errors.WrapRPCTimeout(err)
+			}
+			backoff = backoffs.nextBackoff(backoff)
+		}
+
+		resp, err := c.SimulateTransaction(ctx, envelopeXdr)
+		class := ClassifySimulationFailure(resp, err)
+		if class == SimulationFailureNone {
+			return resp, nil
+		}
+
+		simErr := &SimulationError{Class: class, Attempts: attempt + 1, Response: resp, Err: err}
+		if err != nil {
+			simErr.Message = err.Error()
+		} else {
+			simErr.Message = resp.Result.Error
+		}
+		if class == SimulationFailureContract {
+			if events, evErr := resp.DiagnosticEvents(); evErr == nil {
+				simErr.Contract = c.contractError(ctx, events)
+			}
+			if simErr.Contract != nil {
+				simErr.Message = simErr.Contract.Error()
+			}
+		}
+
+		if class != SimulationFailureTransient || attempt >= config.MaxRetries {
+			return resp, simErr
+		}
+
+		c.logContext(ctx, LogSubsystemRPC).Warn("Transient simulation failure, will retry",
+			"attempt", attempt+1,
+			"backoff", backoff.Round(time.Millisecond),
+			"error", simErr.Message,
+		)
+	}
+}
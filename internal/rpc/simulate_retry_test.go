@@ -0,0 +1,141 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fastSimulateRetryConfig() RetryConfig {
+	return RetryConfig{MaxRetries: 3, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+}
+
+func newSimulateServer(t *testing.T, respond func(call int32) map[string]interface{}) (*httptest.Server, *int32) {
+	t.Helper()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		body := respond(n)
+		body["jsonrpc"] = "2.0"
+		body["id"] = 1
+		json.NewEncoder(w).Encode(body)
+	}))
+	return server, &calls
+}
+
+func TestClassifySimulationFailure(t *testing.T) {
+	contractResp := &SimulateTransactionResponse{}
+	contractResp.Result.Error = "HostError: Error(Contract, #5)"
+	snapshotResp := &SimulateTransactionResponse{}
+	snapshotResp.Result.Error = "ledger snapshot unavailable"
+
+	tests := []struct {
+		name string
+		resp *SimulateTransactionResponse
+		err  error
+		want SimulationFailureClass
+	}{
+		{"success", &SimulateTransactionResponse{}, nil, SimulationFailureNone},
+		{"contract error", contractResp, nil, SimulationFailureContract},
+		{"snapshot unavailable", snapshotResp, nil, SimulationFailureTransient},
+		{"connection reset", nil, errs.WrapRPCConnectionFailed(fmt.Errorf("read: connection reset by peer")), SimulationFailureTransient},
+		{"rpc backlog", nil, errs.WrapRPCError("http://rpc", "request backlog full", -32603), SimulationFailureTransient},
+		{"invalid params", nil, errs.WrapRPCError("http://rpc", "invalid parameters", -32602), SimulationFailureRequest},
+		{"cancelled", nil, context.Canceled, SimulationFailureRequest},
+		{"all nodes transient", nil, &AllNodesFailedError{Failures: []NodeFailure{
+			{URL: "a", Reason: errs.WrapRPCTimeout(fmt.Errorf("deadline"))},
+		}}, SimulationFailureTransient},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClassifySimulationFailure(tt.resp, tt.err))
+		})
+	}
+}
+
+func TestSimulateTransactionWithRetry_RetriesTransient(t *testing.T) {
+	server, calls := newSimulateServer(t, func(call int32) map[string]interface{} {
+		if call < 3 {
+			return map[string]interface{}{"error": map[string]interface{}{"code": -32603, "message": "ledger snapshot not yet available"}}
+		}
+		return map[string]interface{}{"result": map[string]interface{}{"minResourceFee": "100"}}
+	})
+	defer server.Close()
+
+	client := newArchivalTestClient(t, server.URL)
+	resp, err := client.SimulateTransactionWithRetry(context.Background(), "AAAA", fastSimulateRetryConfig())
+	require.NoError(t, err)
+	assert.Equal(t, "100", resp.Result.MinResourceFee)
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+}
+
+func TestSimulateTransactionWithRetry_DoesNotCacheFailures(t *testing.T) {
+	server, calls := newSimulateServer(t, func(call int32) map[string]interface{} {
+		if call == 1 {
+			return map[string]interface{}{"result": map[string]interface{}{"error": "request backlog full", "latestLedger": 10}}
+		}
+		return map[string]interface{}{"result": map[string]interface{}{"minResourceFee": "100", "latestLedger": 10}}
+	})
+	defer server.Close()
+
+	client := newArchivalTestClient(t, server.URL)
+	client.SimulationCache = NewSimulationCache(time.Minute)
+	resp, err := client.SimulateTransactionWithRetry(context.Background(), "AAAA", fastSimulateRetryConfig())
+	require.NoError(t, err)
+	assert.Equal(t, "100", resp.Result.MinResourceFee)
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+
+	// The success is cached.
+	resp, err = client.SimulateTransactionWithRetry(context.Background(), "AAAA", fastSimulateRetryConfig())
+	require.NoError(t, err)
+	assert.Equal(t, "100", resp.Result.MinResourceFee)
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
+
+func TestSimulateTransactionWithRetry_ContractErrorNotRetried(t *testing.T) {
+	server, calls := newSimulateServer(t, func(call int32) map[string]interface{} {
+		return map[string]interface{}{"result": map[string]interface{}{"error": "HostError: Error(Contract, #5)"}}
+	})
+	defer server.Close()
+
+	client := newArchivalTestClient(t, server.URL)
+	resp, err := client.SimulateTransactionWithRetry(context.Background(), "AAAA", fastSimulateRetryConfig())
+	require.Error(t, err)
+	assert.NotNil(t, resp)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+
+	var simErr *SimulationError
+	require.ErrorAs(t, err, &simErr)
+	assert.Equal(t, SimulationFailureContract, simErr.Class)
+	assert.Equal(t, 1, simErr.Attempts)
+	assert.ErrorIs(t, err, errs.ErrSimulationLogicError)
+}
+
+func TestSimulateTransactionWithRetry_GivesUp(t *testing.T) {
+	server, calls := newSimulateServer(t, func(call int32) map[string]interface{} {
+		return map[string]interface{}{"error": map[string]interface{}{"code": -32603, "message": "request backlog full"}}
+	})
+	defer server.Close()
+
+	client := newArchivalTestClient(t, server.URL)
+	_, err := client.SimulateTransactionWithRetry(context.Background(), "AAAA", fastSimulateRetryConfig())
+
+	var simErr *SimulationError
+	require.ErrorAs(t, err, &simErr)
+	assert.Equal(t, SimulationFailureTransient, simErr.Class)
+	assert.Equal(t, 4, simErr.Attempts)
+	assert.Equal(t, int32(4), atomic.LoadInt32(calls))
+}
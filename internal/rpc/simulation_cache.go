@@ -0,0 +1,135 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// DefaultSimulationCacheMaxAge bounds how long a simulation result is served
+// when no newer ledger has been observed, roughly one ledger close.
+const DefaultSimulationCacheMaxAge = 6 * time.Second
+
+type simulationCacheEntry struct {
+	resp     *SimulateTransactionResponse
+	ledger   uint32
+	storedAt time.Time
+}
+
+// SimulationCache is an in-memory cache of simulateTransaction results keyed
+// by (envelope hash, latest ledger). Observing a newer ledger invalidates every
+// entry simulated against an older one, so repeated previews of the same
+// unsigned transaction are only re-simulated when chain state may have changed.
+type SimulationCache struct {
+	mu           sync.Mutex
+	entries      map[string]simulationCacheEntry
+	latestLedger uint32
+	maxAge       time.Duration
+	now          func() time.Time
+}
+
+// NewSimulationCache creates a cache whose entries expire after maxAge even if
+// no new ledger is observed. A non-positive maxAge uses DefaultSimulationCacheMaxAge.
+func NewSimulationCache(maxAge time.Duration) *SimulationCache {
+	if maxAge <= 0 {
+		maxAge = DefaultSimulationCacheMaxAge
+	}
+	return &SimulationCache{
+		entries: make(map[string]simulationCacheEntry),
+		maxAge:  maxAge,
+		now:     time.Now,
+	}
+}
+
+// HashEnvelope returns the hex SHA-256 of the decoded envelope XDR, falling back
+// to the raw string when it is not valid base64.
+func HashEnvelope(envelopeXdr string) string {
+	data, err := base64.StdEncoding.DecodeString(envelopeXdr)
+	if err != nil {
+		data = []byte(envelopeXdr)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Get returns the cached result for envelopeXdr if it was simulated against
+// the latest observed ledger and has not exceeded the max age.
+func (c *SimulationCache) Get(envelopeXdr string) (*SimulateTransactionResponse, bool) {
+	resp, result, _ := c.lookup(HashEnvelope(envelopeXdr))
+	return resp, result == CacheResultHit
+}
+
+// lookup returns the entry stored under key, whether it is a hit, a miss
+// or stale, and its age. Stale entries are evicted.
+func (c *SimulationCache) lookup(key string) (*SimulateTransactionResponse, string, time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, CacheResultMiss, 0
+	}
+	age := c.now().Sub(entry.storedAt)
+	if entry.ledger < c.latestLedger || age > c.maxAge {
+		delete(c.entries, key)
+		return nil, CacheResultStale, age
+	}
+	return entry.resp, CacheResultHit, age
+}
+
+// Put stores a simulation result. The ledger it was simulated against is taken
+// from the response and also counts as an observation of that ledger.
+func (c *SimulationCache) Put(envelopeXdr string, resp *SimulateTransactionResponse) {
+	if resp == nil {
+		return
+	}
+	ledger := resp.Result.LatestLedger
+	c.ObserveLedger(ledger)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if ledger < c.latestLedger {
+		return
+	}
+	c.entries[HashEnvelope(envelopeXdr)] = simulationCacheEntry{
+		resp:     resp,
+		ledger:   ledger,
+		storedAt: c.now(),
+	}
+}
+
+// ObserveLedger records that seq is the latest ledger and drops entries that
+// were simulated against an earlier one.
+func (c *SimulationCache) ObserveLedger(seq uint32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if seq <= c.latestLedger {
+		return
+	}
+	c.latestLedger = seq
+	for key, entry := range c.entries {
+		if entry.ledger < seq {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// Len returns the number of entries currently held.
+func (c *SimulationCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Clear removes all entries.
+func (c *SimulationCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]simulationCacheEntry)
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func simulatedAt(ledger uint32) *SimulateTransactionResponse {
+	resp := &SimulateTransactionResponse{}
+	resp.Result.LatestLedger = ledger
+	return resp
+}
+
+func TestSimulationCache_InvalidatesOnNewLedger(t *testing.T) {
+	cache := NewSimulationCache(time.Minute)
+
+	cache.Put("AAAA", simulatedAt(100))
+	got, ok := cache.Get("AAAA")
+	require.True(t, ok)
+	assert.Equal(t, uint32(100), got.Result.LatestLedger)
+
+	_, ok = cache.Get("BBBB")
+	assert.False(t, ok)
+
+	cache.ObserveLedger(99)
+	_, ok = cache.Get("AAAA")
+	assert.True(t, ok, "an older ledger must not invalidate")
+
+	cache.ObserveLedger(101)
+	_, ok = cache.Get("AAAA")
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.Len())
+
+	cache.Put("AAAA", simulatedAt(100))
+	assert.Equal(t, 0, cache.Len(), "stale results are not stored")
+}
+
+func TestSimulationCache_MaxAge(t *testing.T) {
+	now := time.Now()
+	cache := NewSimulationCache(time.Second)
+	cache.now = func() time.Time { return now }
+
+	cache.Put("AAAA", simulatedAt(5))
+	now = now.Add(2 * time.Second)
+
+	_, ok := cache.Get("AAAA")
+	assert.False(t, ok)
+}
+
+func TestHashEnvelope(t *testing.T) {
+	assert.Equal(t, HashEnvelope("AAAA"), HashEnvelope("AAAA"))
+	assert.NotEqual(t, HashEnvelope("AAAA"), HashEnvelope("AAAB"))
+	assert.Len(t, HashEnvelope("not base64!"), 64)
+}
+
+func TestSimulateTransaction_UsesSimulationCache(t *testing.T) {
+	server, calls := newSimulateServer(t, func(call int32) map[string]interface{} {
+		return map[string]interface{}{"result": map[string]interface{}{"latestLedger": 42, "minResourceFee": "10"}}
+	})
+	defer server.Close()
+
+	client, err := NewClient(WithNetwork(Testnet), WithSorobanURL(server.URL), WithAltURLs([]string{server.URL}), WithSimulationCache(time.Minute))
+	require.NoError(t, err)
+	client.SorobanURL = server.URL
+
+	for i := 0; i < 3; i++ {
+		resp, err := client.SimulateTransaction(context.Background(), "AAAA")
+		require.NoError(t, err)
+		assert.Equal(t, "10", resp.Result.MinResourceFee)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+
+	client.observeLedger(43)
+	_, err = client.SimulateTransaction(context.Background(), "AAAA")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
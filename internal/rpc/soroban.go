@@ -0,0 +1,176 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+)
+
+// jsonRPCRequest is the generic Soroban JSON-RPC 2.0 request envelope.
+type jsonRPCRequest struct {
+	Jsonrpc string      `json:"jsonrpc"`
+	ID      int         `json:"id"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
+// jsonRPCError is the error object of a JSON-RPC 2.0 response.
+type jsonRPCError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// jsonRPCResponse is the generic Soroban JSON-RPC 2.0 response envelope.
+// ID is kept raw because servers may echo it as a string or, for errors
+// raised before the request was parsed, null.
+type jsonRPCResponse struct {
+	Jsonrpc string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result"`
+	Error   *jsonRPCError   `json:"error,omitempty"`
+}
+
+// sorobanTargetURL returns the configured Soroban RPC URL, falling back to the
+// public endpoint for well-known networks.
+func (c *Client) sorobanTargetURL() string {
+	if c.SorobanURL != "" {
+		return c.SorobanURL
+	}
+	switch c.Network {
+	case Testnet:
+		return TestnetSorobanURL
+	case Mainnet:
+		return MainnetSorobanURL
+	case Futurenet:
+		return FuturenetSorobanURL
+	}
+	return ""
+}
+
+// callSoroban invokes a Soroban JSON-RPC method with automatic failover and
+// decodes the result into out.
+func (c *Client) callSoroban(ctx context.Context, method string, params interface{}, out interface{}) error {
+	if len(c.AltURLs) == 0 {
+		return &AllNodesFailedError{}
+	}
+	var failures []NodeFailure
+	for attempt := 0; attempt < len(c.AltURLs); attempt++ {
+		hopStart := time.Now()
+		hopCtx, span := startFailoverHop(ctx, method, attempt, c.SorobanURL)
+		err := c.callSorobanAttempt(hopCtx, method, params, out)
+		endHop(ctx, span, hopStart, err)
+		err = c.requestError(ctx, method, c.SorobanURL, attempt, hopStart, err)
+		if err == nil {
+			c.markSuccess(c.SorobanURL)
+			return nil
+		}
+
+		c.markFailure(c.SorobanURL)
+		failures = append(failures, NodeFailure{URL: c.SorobanURL, Reason: err})
+
+		if attempt < len(c.AltURLs)-1 {
+			c.logContext(ctx, LogSubsystemFailover).Warn("Retrying Soroban RPC call with fallback RPC...", "method", method, "error", err)
+			if !c.failover(method, attempt, err, time.Since(hopStart)) {
+				break
+			}
+		}
+	}
+	return &AllNodesFailedError{Failures: failures}
+}
+
+func (c *Client) callSorobanAttempt(ctx context.Context, method string, params interface{}, out interface{}) error {
+	targetURL := c.sorobanTargetURL()
+
+	c.logContext(ctx, LogSubsystemRPC).Debug("Calling Soroban RPC", "method", method, "url", targetURL)
+
+	// Fail fast if circuit breaker is open for this Soroban endpoint.
+	if !c.isHealthy(targetURL) {
+		return errors.WrapRPCConnectionFailed(
+			fmt.Errorf("circuit breaker open for %s", targetURL),
+		)
+	}
+
+	bodyBytes, err := json.Marshal(jsonRPCRequest{
+		Jsonrpc: "2.0",
+		ID:      1,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return errors.WrapMarshalFailed(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", targetURL, bytes.NewBuffer(bodyBytes))
+	if err != nil {
+		return errors.WrapRPCConnectionFailed(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.getHTTPClient().Do(req)
+	if err != nil {
+		return errors.WrapRPCConnectionFailed(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusRequestEntityTooLarge {
+		return errors.WrapRPCResponseTooLarge(targetURL)
+	}
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.WrapUnmarshalFailed(err, "body read error")
+	}
+
+	rpcErr, err := decodeJSONRPCResponse(respBytes, out)
+	if err != nil {
+		return err
+	}
+	if rpcErr != nil {
+		kind := fmt.Sprintf("rpc_%d", rpcErr.Code)
+		c.metrics.ObserveError(method, endpointLabel(targetURL), kind)
+		c.vars.observeError(endpointLabel(targetURL), kind)
+		return errors.WrapRPCError(targetURL, rpcErr.Message, rpcErr.Code)
+	}
+	return nil
+}
+
+// maxErrorBodyLen caps how much of an unparseable response is quoted in
+// the error, since the body comes from the remote server.
+const maxErrorBodyLen = 512
+
+// decodeJSONRPCResponse parses a JSON-RPC 2.0 response body, decoding its
+// result into out when out is non-nil. A JSON-RPC error object is returned
+// as rpcErr rather than err.
+func decodeJSONRPCResponse(body []byte, out interface{}) (rpcErr *jsonRPCError, err error) {
+	var rpcResp jsonRPCResponse
+	if err := json.Unmarshal(body, &rpcResp); err != nil {
+		return nil, errors.WrapUnmarshalFailed(err, truncateBody(body))
+	}
+	if rpcResp.Error != nil {
+		return rpcResp.Error, nil
+	}
+	if out == nil || len(rpcResp.Result) == 0 {
+		return nil, nil
+	}
+	if err := json.Unmarshal(rpcResp.Result, out); err != nil {
+		return nil, errors.WrapUnmarshalFailed(err, truncateBody(rpcResp.Result))
+	}
+	return nil, nil
+}
+
+func truncateBody(body []byte) string {
+	if len(body) <= maxErrorBodyLen {
+		return string(body)
+	}
+	return string(body[:maxErrorBodyLen]) + fmt.Sprintf("... (%d bytes)", len(body))
+}
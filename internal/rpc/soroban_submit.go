@@ -0,0 +1,128 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/errors"
+)
+
+// Soroban RPC getTransaction statuses.
+const (
+	SorobanTxSuccess  = "SUCCESS"
+	SorobanTxFailed   = "FAILED"
+	SorobanTxNotFound = "NOT_FOUND"
+)
+
+// SendTransaction submits a signed transaction envelope through Soroban
+// RPC's sendTransaction, for networks or transactions that go through RPC
+// rather than Horizon. Statuses and errors are as for SubmitTransactionAsync,
+// including the envelope check when the client has a network passphrase.
+func (c *Client) SendTransaction(ctx context.Context, envelopeXdr string) (*AsyncSubmitResult, error) {
+	if envelopeXdr == "" {
+		return nil, errors.WrapValidationError("transaction envelope is required")
+	}
+	if c.Config.NetworkPassphrase != "" {
+		if err := c.ValidateEnvelope(ctx, envelopeXdr); err != nil {
+			return nil, err
+		}
+	}
+
+	var resp struct {
+		Status         string `json:"status"`
+		Hash           string `json:"hash"`
+		ErrorResultXdr string `json:"errorResultXdr"`
+	}
+	if err := c.callSoroban(ctx, "sendTransaction", map[string]string{"transaction": envelopeXdr}, &resp); err != nil {
+		return nil, err
+	}
+
+	result := &AsyncSubmitResult{
+		Status:         AsyncSubmitStatus(resp.Status),
+		Hash:           resp.Hash,
+		ErrorResultXDR: resp.ErrorResultXdr,
+	}
+	if resp.ErrorResultXdr != "" {
+		result.ResultCode, result.InnerResultCode = decodeResultCodes(resp.ErrorResultXdr)
+	}
+	c.logContext(ctx, LogSubsystemSubmission).Debug("sendTransaction status", "hash", result.Hash, "status", result.Status)
+
+	if result.Accepted() {
+		return result, nil
+	}
+	return result, errors.NewSendTransactionError(string(result.Status), result.Hash, result.ResultCode).
+		WithInnerResultCode(result.InnerResultCode)
+}
+
+// SorobanTransaction is a transaction as reported by Soroban RPC's
+// getTransaction.
+type SorobanTransaction struct {
+	Status        string `json:"status"`
+	Ledger        uint32 `json:"ledger"`
+	CreatedAt     string `json:"createdAt"`
+	EnvelopeXdr   string `json:"envelopeXdr"`
+	ResultXdr     string `json:"resultXdr"`
+	ResultMetaXdr string `json:"resultMetaXdr"`
+	LatestLedger  uint32 `json:"latestLedger"`
+}
+
+// GetSorobanTransaction looks a transaction up on Soroban RPC. A transaction
+// the RPC has not seen, or no longer retains, has status NOT_FOUND.
+func (c *Client) GetSorobanTransaction(ctx context.Context, hash string) (*SorobanTransaction, error) {
+	var tx SorobanTransaction
+	if err := c.callSoroban(ctx, "getTransaction", map[string]string{"hash": hash}, &tx); err != nil {
+		return nil, err
+	}
+	c.observeLedger(tx.LatestLedger)
+	return &tx, nil
+}
+
+// WaitForSorobanTransaction is WaitForTransaction for transactions sent
+// with SendTransaction: it polls Soroban RPC's getTransaction until the
+// hash is included. A failed transaction is returned together with an
+// *errors.TransactionResultError.
+func (c *Client) WaitForSorobanTransaction(ctx context.Context, hash string, cfg PollConfig) (*SorobanTransaction, error) {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultPollConfig().Interval
+	}
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Timeout)
+		defer cancel()
+	}
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		tx, err := c.GetSorobanTransaction(ctx, hash)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, errors.WrapRPCTimeout(ctx.Err())
+			}
+			return nil, err
+		}
+		switch tx.Status {
+		case SorobanTxSuccess:
+			return tx, nil
+		case SorobanTxFailed:
+			code, inner := decodeResultCodes(tx.ResultXdr)
+			txErr := errors.NewTransactionResultError(code, nil).WithInnerResultCode(inner)
+			if events, evErr := decoder.DiagnosticEventsFromMetaXDR(tx.ResultMetaXdr); evErr == nil {
+				txErr.WithContractError(c.contractError(ctx, events))
+			}
+			return tx, txErr
+		}
+
+		c.logContext(ctx, LogSubsystemSubmission).Debug("Transaction not yet included", "hash", hash)
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.WrapRPCTimeout(ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
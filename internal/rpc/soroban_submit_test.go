@@ -0,0 +1,109 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sorobanScript answers each JSON-RPC method with its next scripted result,
+// repeating the last one.
+func sorobanScript(t *testing.T, script map[string][]interface{}) *httptest.Server {
+	t.Helper()
+	var mu sync.Mutex
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string `json:"method"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		mu.Lock()
+		results := script[req.Method]
+		require.NotEmpty(t, results, req.Method)
+		result := results[0]
+		if len(results) > 1 {
+			script[req.Method] = results[1:]
+		}
+		mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": 1, "result": result})
+	}))
+}
+
+func TestSendTransaction(t *testing.T) {
+	server := sorobanScript(t, map[string][]interface{}{
+		"sendTransaction": {
+			map[string]string{"status": "PENDING", "hash": "abc"},
+			map[string]string{"status": "ERROR", "hash": "abc", "errorResultXdr": txResultXDR(t, xdr.TransactionResultCodeTxBadSeq)},
+		},
+	})
+	defer server.Close()
+	client := newArchivalTestClient(t, server.URL)
+	client.Config.NetworkPassphrase = ""
+
+	res, err := client.SendTransaction(context.Background(), "AAAA")
+	require.NoError(t, err)
+	assert.True(t, res.Accepted())
+	assert.Equal(t, "abc", res.Hash)
+
+	res, err = client.SendTransaction(context.Background(), "AAAA")
+	assert.ErrorIs(t, err, errs.ErrTxStatusError)
+	assert.ErrorIs(t, err, errs.ErrTxBadSeq)
+	assert.Equal(t, "tx_bad_seq", res.ResultCode)
+
+	_, err = client.SendTransaction(context.Background(), "")
+	assert.Error(t, err)
+}
+
+func TestWaitForSorobanTransaction(t *testing.T) {
+	server := sorobanScript(t, map[string][]interface{}{
+		"getTransaction": {
+			map[string]interface{}{"status": "NOT_FOUND", "latestLedger": 10},
+			map[string]interface{}{"status": "SUCCESS", "ledger": 11, "latestLedger": 11},
+		},
+	})
+	defer server.Close()
+	client := newArchivalTestClient(t, server.URL)
+
+	tx, err := client.WaitForSorobanTransaction(context.Background(), "abc", PollConfig{Interval: time.Millisecond, Timeout: time.Second})
+	require.NoError(t, err)
+	assert.Equal(t, SorobanTxSuccess, tx.Status)
+	assert.Equal(t, uint32(11), tx.Ledger)
+}
+
+func TestWaitForSorobanTransactionFailed(t *testing.T) {
+	server := sorobanScript(t, map[string][]interface{}{
+		"getTransaction": {map[string]interface{}{
+			"status": "FAILED", "ledger": 11, "resultXdr": txResultXDR(t, xdr.TransactionResultCodeTxFailed),
+		}},
+	})
+	defer server.Close()
+	client := newArchivalTestClient(t, server.URL)
+
+	tx, err := client.WaitForSorobanTransaction(context.Background(), "abc", PollConfig{Interval: time.Millisecond})
+	var txErr *errs.TransactionResultError
+	require.ErrorAs(t, err, &txErr)
+	assert.Equal(t, "tx_failed", txErr.ResultCode)
+	assert.Equal(t, SorobanTxFailed, tx.Status)
+}
+
+func TestWaitForSorobanTransactionTimeout(t *testing.T) {
+	server := sorobanScript(t, map[string][]interface{}{
+		"getTransaction": {map[string]interface{}{"status": "NOT_FOUND"}},
+	})
+	defer server.Close()
+	client := newArchivalTestClient(t, server.URL)
+
+	_, err := client.WaitForSorobanTransaction(context.Background(), "abc", PollConfig{Interval: time.Millisecond, Timeout: 20 * time.Millisecond})
+	assert.Error(t, err)
+}
@@ -0,0 +1,357 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/effects"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/operations"
+)
+
+// StreamConfig controls reconnection behaviour for Horizon SSE streams.
+type StreamConfig struct {
+	// HeartbeatTimeout is how long a connection may stay silent, including
+	// Horizon's keep-alive comments, before it is considered dead.
+	HeartbeatTimeout time.Duration
+	InitialBackoff   time.Duration
+	MaxBackoff       time.Duration
+	// MaxReconnects caps consecutive failed connection attempts; 0 means retry
+	// until the context is cancelled.
+	MaxReconnects int
+}
+
+// DefaultStreamConfig returns the reconnection settings used when none are set.
+func DefaultStreamConfig() StreamConfig {
+	return StreamConfig{
+		HeartbeatTimeout: 60 * time.Second,
+		InitialBackoff:   500 * time.Millisecond,
+		MaxBackoff:       30 * time.Second,
+	}
+}
+
+func (s StreamConfig) withDefaults() StreamConfig {
+	def := DefaultStreamConfig()
+	if s.HeartbeatTimeout <= 0 {
+		s.HeartbeatTimeout = def.HeartbeatTimeout
+	}
+	if s.InitialBackoff <= 0 {
+		s.InitialBackoff = def.InitialBackoff
+	}
+	if s.MaxBackoff <= 0 {
+		s.MaxBackoff = def.MaxBackoff
+	}
+	return s
+}
+
+// TransactionStreamHandler receives transactions from StreamTransactions.
+// Returning an error stops the stream.
+type TransactionStreamHandler func(hProtocol.Transaction) error
+
+// OperationStreamHandler receives operations from StreamOperations and
+// StreamPayments. Returning an error stops the stream.
+type OperationStreamHandler func(operations.Operation) error
+
+// LedgerStreamHandler receives ledgers from StreamLedgers. Returning an error
+// stops the stream.
+type LedgerStreamHandler func(hProtocol.Ledger) error
+
+// EffectStreamHandler receives effects from StreamEffects. Returning an error
+// stops the stream.
+type EffectStreamHandler func(effects.Effect) error
+
+// StreamTransactions streams transactions from Horizon starting after cursor
+// ("now" when empty), reconnecting and resuming from the last delivered
+// record if the connection drops. It blocks until ctx is cancelled or the
+// handler returns an error.
+func (c *Client) StreamTransactions(ctx context.Context, cursor string, handler TransactionStreamHandler) error {
+	return c.streamHorizon(ctx, "/transactions", cursor, transactionStreamDecoder(handler))
+}
+
+// StreamAccountTransactions streams the transactions that affect account;
+// see StreamTransactions.
+func (c *Client) StreamAccountTransactions(ctx context.Context, account, cursor string, handler TransactionStreamHandler) error {
+	return c.streamHorizon(ctx, "/accounts/"+url.PathEscape(account)+"/transactions", cursor, transactionStreamDecoder(handler))
+}
+
+// StreamLedgers streams closed ledgers from Horizon; see StreamTransactions.
+func (c *Client) StreamLedgers(ctx context.Context, cursor string, handler LedgerStreamHandler) error {
+	return c.streamHorizon(ctx, "/ledgers", cursor, func(data []byte) error {
+		var ledger hProtocol.Ledger
+		if err := json.Unmarshal(data, &ledger); err != nil {
+			return errors.WrapUnmarshalFailed(err, string(data))
+		}
+		return handler(ledger)
+	})
+}
+
+// StreamOperations streams operations from Horizon; see StreamTransactions.
+func (c *Client) StreamOperations(ctx context.Context, cursor string, handler OperationStreamHandler) error {
+	return c.streamHorizon(ctx, "/operations", cursor, operationStreamDecoder(handler))
+}
+
+// StreamPayments streams payment-like operations from Horizon; see
+// StreamTransactions.
+func (c *Client) StreamPayments(ctx context.Context, cursor string, handler OperationStreamHandler) error {
+	return c.streamHorizon(ctx, "/payments", cursor, operationStreamDecoder(handler))
+}
+
+// StreamEffects streams effects from Horizon; see StreamTransactions.
+func (c *Client) StreamEffects(ctx context.Context, cursor string, handler EffectStreamHandler) error {
+	return c.streamHorizon(ctx, "/effects", cursor, effectStreamDecoder(handler))
+}
+
+func transactionStreamDecoder(handler TransactionStreamHandler) func([]byte) error {
+	return func(data []byte) error {
+		var tx hProtocol.Transaction
+		if err := json.Unmarshal(data, &tx); err != nil {
+			return errors.WrapUnmarshalFailed(err, string(data))
+		}
+		return handler(tx)
+	}
+}
+
+func operationStreamDecoder(handler OperationStreamHandler) func([]byte) error {
+	return func(data []byte) error {
+		var base struct {
+			TypeI int32 `json:"type_i"`
+		}
+		if err := json.Unmarshal(data, &base); err != nil {
+			return errors.WrapUnmarshalFailed(err, string(data))
+		}
+		op, err := operations.UnmarshalOperation(base.TypeI, data)
+		if err != nil {
+			return errors.WrapUnmarshalFailed(err, string(data))
+		}
+		return handler(op)
+	}
+}
+
+func effectStreamDecoder(handler EffectStreamHandler) func([]byte) error {
+	return func(data []byte) error {
+		var base struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(data, &base); err != nil {
+			return errors.WrapUnmarshalFailed(err, string(data))
+		}
+		effect, err := effects.UnmarshalEffect(base.Type, data)
+		if err != nil {
+			return errors.WrapUnmarshalFailed(err, string(data))
+		}
+		return handler(effect)
+	}
+}
+
+// streamHandlerError marks errors returned by the caller's handler so they
+// terminate the stream instead of triggering a reconnect.
+type streamHandlerError struct {
+	err error
+}
+
+func (e *streamHandlerError) Error() string { return e.err.Error() }
+func (e *streamHandlerError) Unwrap() error { return e.err }
+
+// streamHorizon consumes a Horizon SSE endpoint, failing over across AltURLs
+// and resuming from the last event ID after every reconnect.
+func (c *Client) streamHorizon(ctx context.Context, path, cursor string, onEvent func(data []byte) error) error {
+	cfg := c.StreamConfig.withDefaults()
+	if cursor == "" {
+		cursor = "now"
+	}
+
+	// Streams are long-lived, so the client-wide request timeout must not apply.
+	httpClient := *c.getHTTPClient()
+	httpClient.Timeout = 0
+
+	backoff := cfg.InitialBackoff
+	failedAttempts := 0
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		baseURL := c.HorizonURL
+		received, err := c.streamConnection(ctx, &httpClient, baseURL, path, &cursor, cfg.HeartbeatTimeout, onEvent)
+
+		var handlerErr *streamHandlerError
+		switch {
+		case errors.As(err, &handlerErr):
+			return handlerErr.err
+		case ctx.Err() != nil:
+			return ctx.Err()
+		}
+		var permanent *permanentStreamError
+		if errors.As(err, &permanent) {
+			return permanent.err
+		}
+
+		if received {
+			c.markSuccess(baseURL)
+			backoff = cfg.InitialBackoff
+			failedAttempts = 0
+		}
+		if err == nil {
+			// Horizon closes idle streams periodically; resume from the cursor,
+			// pausing briefly if the connection produced nothing at all.
+			c.logContext(ctx, LogSubsystemRPC).Debug("Horizon stream closed, reconnecting", "path", path, "cursor", cursor)
+			if !received {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(cfg.InitialBackoff):
+				}
+			}
+			continue
+		}
+
+		c.markFailure(baseURL)
+		failedAttempts++
+		if cfg.MaxReconnects > 0 && failedAttempts > cfg.MaxReconnects {
+			return errors.WrapRPCConnectionFailed(fmt.Errorf("stream %s gave up after %d reconnects: %w", path, cfg.MaxReconnects, err))
+		}
+
+		c.logContext(ctx, LogSubsystemFailover).Warn("Horizon stream interrupted, reconnecting",
+			"url", baseURL,
+			"path", path,
+			"cursor", cursor,
+			"backoff", backoff,
+			"error", err,
+		)
+		c.rotateURL()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > cfg.MaxBackoff {
+			backoff = cfg.MaxBackoff
+		}
+	}
+}
+
+// streamConnection reads events from a single SSE connection until it ends.
+// received reports whether at least one event was read, which marks the
+// endpoint healthy. cursor is advanced after each successfully handled event.
+func (c *Client) streamConnection(
+	ctx context.Context,
+	httpClient *http.Client,
+	baseURL, path string,
+	cursor *string,
+	heartbeatTimeout time.Duration,
+	onEvent func(data []byte) error,
+) (received bool, err error) {
+	connCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	u, err := url.Parse(strings.TrimRight(baseURL, "/") + path)
+	if err != nil {
+		return false, &permanentStreamError{err: errors.WrapValidationError(fmt.Sprintf("invalid stream URL: %v", err))}
+	}
+	q := u.Query()
+	q.Set("cursor", *cursor)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(connCtx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return false, errors.WrapRPCConnectionFailed(err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return false, errors.WrapRPCConnectionFailed(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		rpcErr := errors.WrapRPCError(baseURL, resp.Status, resp.StatusCode)
+		if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+			return false, &permanentStreamError{err: rpcErr}
+		}
+		return false, rpcErr
+	}
+
+	// The watchdog tears the connection down if Horizon goes silent, which
+	// unblocks the pending read below.
+	watchdog := time.AfterFunc(heartbeatTimeout, cancel)
+	defer watchdog.Stop()
+
+	reader := bufio.NewReader(resp.Body)
+	var id string
+	var data bytes.Buffer
+
+	for {
+		line, readErr := reader.ReadString('\n')
+		watchdog.Reset(heartbeatTimeout)
+
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case line == "":
+			if data.Len() > 0 {
+				payload := data.Bytes()
+				if !isStreamControlMessage(payload) {
+					received = true
+					if err := onEvent(payload); err != nil {
+						return received, &streamHandlerError{err: err}
+					}
+					if id != "" {
+						*cursor = id
+					}
+				}
+			}
+			id = ""
+			data.Reset()
+		case strings.HasPrefix(line, ":"):
+			// Keep-alive comment.
+		case strings.HasPrefix(line, "id:"):
+			id = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+
+		if readErr != nil {
+			if readErr == io.EOF {
+				return received, nil
+			}
+			if connCtx.Err() != nil && ctx.Err() == nil {
+				return received, errors.WrapRPCTimeout(fmt.Errorf("no data from %s for %s", baseURL, heartbeatTimeout))
+			}
+			return received, errors.WrapRPCConnectionFailed(readErr)
+		}
+	}
+}
+
+// isStreamControlMessage reports whether payload is one of Horizon's
+// "hello"/"byebye" stream lifecycle messages rather than a record.
+func isStreamControlMessage(payload []byte) bool {
+	s := string(bytes.TrimSpace(payload))
+	return s == `"hello"` || s == `"byebye"`
+}
+
+// permanentStreamError marks failures a reconnect cannot fix, such as a 404
+// for an unknown account.
+type permanentStreamError struct {
+	err error
+}
+
+func (e *permanentStreamError) Error() string { return e.err.Error() }
+func (e *permanentStreamError) Unwrap() error { return e.err }
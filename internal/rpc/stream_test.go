@@ -0,0 +1,188 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/effects"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/operations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSSE(w http.ResponseWriter, id, data string) {
+	if id != "" {
+		fmt.Fprintf(w, "id: %s\n", id)
+	}
+	fmt.Fprintf(w, "data: %s\n\n", data)
+	w.(http.Flusher).Flush()
+}
+
+func fastStreamConfig() StreamConfig {
+	return StreamConfig{HeartbeatTimeout: time.Second, InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+}
+
+func TestStreamTransactions_ResumesFromCursor(t *testing.T) {
+	var mu sync.Mutex
+	var cursors []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/transactions", r.URL.Path)
+		assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+
+		mu.Lock()
+		cursors = append(cursors, r.URL.Query().Get("cursor"))
+		conn := len(cursors)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		writeSSE(w, "", `"hello"`)
+		fmt.Fprint(w, ": keepalive\n\n")
+		if conn == 1 {
+			writeSSE(w, "100-1", `{"id":"tx1","hash":"h1","paging_token":"100-1"}`)
+			writeSSE(w, "100-2", `{"id":"tx2","hash":"h2","paging_token":"100-2"}`)
+			return
+		}
+		writeSSE(w, "101-1", `{"id":"tx3","hash":"h3","paging_token":"101-1"}`)
+		writeSSE(w, "", `"byebye"`)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithNetwork(Testnet), WithHorizonURL(server.URL), WithStreamConfig(fastStreamConfig()))
+	require.NoError(t, err)
+
+	stop := errors.New("stop")
+	var hashes []string
+	err = client.StreamTransactions(context.Background(), "", func(tx hProtocol.Transaction) error {
+		hashes = append(hashes, tx.Hash)
+		if len(hashes) == 3 {
+			return stop
+		}
+		return nil
+	})
+
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, []string{"h1", "h2", "h3"}, hashes)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"now", "100-2"}, cursors)
+}
+
+func TestStreamOperations_FailsOverToAltURL(t *testing.T) {
+	bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer bad.Close()
+
+	good := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/operations", r.URL.Path)
+		assert.Equal(t, "5", r.URL.Query().Get("cursor"))
+		writeSSE(w, "6", `{"id":"6","paging_token":"6","type":"payment","type_i":1,"amount":"10.0000000"}`)
+	}))
+	defer good.Close()
+
+	client, err := NewClient(
+		WithNetwork(Testnet),
+		WithAltURLs([]string{bad.URL, good.URL}),
+		WithHTTPClient(http.DefaultClient),
+		WithStreamConfig(fastStreamConfig()),
+	)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var got operations.Operation
+	err = client.StreamOperations(ctx, "5", func(op operations.Operation) error {
+		got = op
+		cancel()
+		return nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	payment, ok := got.(operations.Payment)
+	require.True(t, ok, "expected a payment operation, got %T", got)
+	assert.Equal(t, "10.0000000", payment.Amount)
+	assert.Equal(t, good.URL, client.HorizonURL)
+}
+
+func TestStreamAccountTransactions(t *testing.T) {
+	const account = "GAAZI4TCR3TY5OJHCTJC2A4QSY6CJWJH5IAJTGKIN2ER7LBNVKOCCWN7"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/"+account+"/transactions", r.URL.Path)
+		assert.Equal(t, "now", r.URL.Query().Get("cursor"))
+		w.Header().Set("Content-Type", "text/event-stream")
+		writeSSE(w, "100-1", `{"id":"tx1","hash":"h1","source_account":"`+account+`","paging_token":"100-1"}`)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithNetwork(Testnet), WithHorizonURL(server.URL), WithStreamConfig(fastStreamConfig()))
+	require.NoError(t, err)
+
+	stop := errors.New("stop")
+	var got hProtocol.Transaction
+	err = client.StreamAccountTransactions(context.Background(), account, "", func(tx hProtocol.Transaction) error {
+		got = tx
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, "h1", got.Hash)
+	assert.Equal(t, account, got.Account)
+}
+
+func TestStreamEffects_PermanentErrorStops(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(WithNetwork(Testnet), WithHorizonURL(server.URL), WithStreamConfig(fastStreamConfig()))
+	require.NoError(t, err)
+
+	err = client.StreamEffects(context.Background(), "", func(effects.Effect) error { return nil })
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "404")
+}
+
+func TestStream_HeartbeatTimeoutReconnects(t *testing.T) {
+	var mu sync.Mutex
+	conns := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		conns++
+		n := conns
+		mu.Unlock()
+		if n == 1 {
+			writeSSE(w, "", `"hello"`)
+			<-r.Context().Done()
+			return
+		}
+		writeSSE(w, "1", `{"id":"e1","type":"account_created","type_i":0}`)
+	}))
+	defer server.Close()
+
+	cfg := fastStreamConfig()
+	cfg.HeartbeatTimeout = 50 * time.Millisecond
+	client, err := NewClient(WithNetwork(Testnet), WithHorizonURL(server.URL), WithStreamConfig(cfg))
+	require.NoError(t, err)
+
+	done := errors.New("done")
+	err = client.StreamEffects(context.Background(), "", func(e effects.Effect) error {
+		assert.Equal(t, "account_created", e.GetType())
+		return done
+	})
+	assert.ErrorIs(t, err, done)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 2, conns)
+}
@@ -0,0 +1,220 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/telemetry"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// AsyncSubmitStatus is the status stellar-core reports for an asynchronously
+// submitted transaction.
+type AsyncSubmitStatus string
+
+const (
+	AsyncStatusPending       AsyncSubmitStatus = "PENDING"
+	AsyncStatusDuplicate     AsyncSubmitStatus = "DUPLICATE"
+	AsyncStatusTryAgainLater AsyncSubmitStatus = "TRY_AGAIN_LATER"
+	AsyncStatusError         AsyncSubmitStatus = "ERROR"
+)
+
+// AsyncSubmitResult is the outcome of a transactions_async submission.
+type AsyncSubmitResult struct {
+	Status AsyncSubmitStatus
+	Hash   string
+	// ErrorResultXDR is the TransactionResult XDR when Status is ERROR.
+	ErrorResultXDR string
+	// ResultCode is decoded from ErrorResultXDR, e.g. tx_bad_seq.
+	ResultCode string
+	// InnerResultCode is the inner transaction's code when a fee-bump was
+	// rejected with tx_fee_bump_inner_failed.
+	InnerResultCode string
+}
+
+// Accepted reports whether the transaction is, or already was, queued for
+// inclusion.
+func (r *AsyncSubmitResult) Accepted() bool {
+	return r.Status == AsyncStatusPending || r.Status == AsyncStatusDuplicate
+}
+
+// SubmitTransactionAsync submits a signed transaction envelope through
+// Horizon's transactions_async endpoint and returns as soon as stellar-core
+// has queued or rejected it. When the client has a network passphrase, the
+// envelope is first checked with ValidateEnvelope. PENDING and DUPLICATE are
+// returned without error; TRY_AGAIN_LATER and ERROR are returned together
+// with an *errors.SendTransactionError so callers can branch with errors.Is.
+// The submission span is remembered by hash, and a later WaitForTransaction
+// for the same hash links its spans to it.
+// With WithAuditLog, the submission and its outcome are recorded in the
+// audit log, and an envelope is not sent if its submit record cannot be
+// written.
+func (c *Client) SubmitTransactionAsync(ctx context.Context, envelopeXdr string) (result *AsyncSubmitResult, err error) {
+	if envelopeXdr == "" {
+		return nil, errors.WrapValidationError("transaction envelope is required")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	ctx, span := telemetry.GetTracer().Start(ctx, "rpc_submit_transaction")
+	span.SetAttributes(
+		attribute.String("network", string(c.Network)),
+		attribute.String("rpc.url", c.HorizonURL),
+	)
+	defer func() { endSpan(span, err) }()
+
+	if c.Config.NetworkPassphrase != "" {
+		if err := c.ValidateEnvelope(ctx, envelopeXdr); err != nil {
+			return nil, err
+		}
+	}
+
+	txHash, err := c.auditSubmit(envelopeXdr)
+	if err != nil {
+		return nil, err
+	}
+	if c.audit != nil {
+		defer func() { c.auditResult(ctx, txHash, result, err) }()
+	}
+
+	c.logContext(ctx, LogSubsystemSubmission).Debug("Submitting transaction asynchronously")
+
+	start := time.Now()
+	resp, err := c.Horizon.AsyncSubmitTransactionXDR(envelopeXdr)
+	if err != nil {
+		c.logContext(ctx, LogSubsystemSubmission).Error("Async transaction submission failed", "error", err)
+		return nil, c.requestError(ctx, "submit_transaction_async", c.HorizonURL, 0, start, errors.WrapRPCConnectionFailed(err))
+	}
+	span.SetAttributes(
+		attribute.String("transaction.hash", resp.Hash),
+		attribute.String("transaction.status", resp.TxStatus),
+	)
+	c.submissions.remember(resp.Hash, span.SpanContext())
+
+	result = &AsyncSubmitResult{
+		Status:         AsyncSubmitStatus(resp.TxStatus),
+		Hash:           resp.Hash,
+		ErrorResultXDR: resp.ErrorResultXDR,
+	}
+	if resp.ErrorResultXDR != "" {
+		result.ResultCode, result.InnerResultCode = decodeResultCodes(resp.ErrorResultXDR)
+	}
+
+	c.logContext(ctx, LogSubsystemSubmission).Debug("Async submission status", "hash", result.Hash, "status", result.Status)
+
+	if result.Accepted() {
+		return result, nil
+	}
+	return result, errors.NewSendTransactionError(string(result.Status), result.Hash, result.ResultCode).
+		WithInnerResultCode(result.InnerResultCode)
+}
+
+// PollConfig controls how WaitForTransaction polls for inclusion.
+type PollConfig struct {
+	// Interval between lookups; defaults to one ledger close.
+	Interval time.Duration
+	// Timeout bounds the whole wait; 0 relies on ctx alone.
+	Timeout time.Duration
+}
+
+// DefaultPollConfig polls every ledger for up to one minute.
+func DefaultPollConfig() PollConfig {
+	return PollConfig{Interval: 5 * time.Second, Timeout: time.Minute}
+}
+
+// WaitForTransaction polls Horizon until the transaction hash is included in
+// a ledger. A failed transaction is returned together with an
+// *errors.TransactionResultError, which carries the *errors.ContractError
+// when a contract invocation failed. The wait is traced with one child span per
+// poll, linked to the submission span when this client submitted the hash.
+func (c *Client) WaitForTransaction(ctx context.Context, hash string, cfg PollConfig) (confirmed *hProtocol.Transaction, err error) {
+	ctx, span := telemetry.GetTracer().Start(ctx, "rpc_wait_for_transaction",
+		trace.WithLinks(c.submissions.links(hash)...))
+	span.SetAttributes(attribute.String("transaction.hash", hash))
+	defer func() { endSpan(span, err) }()
+
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultPollConfig().Interval
+	}
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Timeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+
+	for poll := 0; ; poll++ {
+		tx, err := c.pollTransaction(ctx, hash, poll)
+		if err == nil {
+			if !tx.Successful {
+				code, inner := decodeResultCodes(tx.ResultXdr)
+				txErr := errors.NewTransactionResultError(code, nil).WithInnerResultCode(inner)
+				if events, evErr := decoder.DiagnosticEventsFromMetaXDR(tx.ResultMetaXdr); evErr == nil {
+					txErr.WithContractError(c.contractError(ctx, events))
+				}
+				return &tx, txErr
+			}
+			return &tx, nil
+		}
+		if hErr, ok := err.(*horizonclient.Error); !ok || hErr.Problem.Status != http.StatusNotFound {
+			c.logContext(ctx, LogSubsystemSubmission).Error("Failed to poll transaction", "hash", hash, "error", err)
+			return nil, errors.WrapRPCConnectionFailed(err)
+		}
+
+		c.logContext(ctx, LogSubsystemSubmission).Debug("Transaction not yet included", "hash", hash)
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.WrapRPCTimeout(ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// pollTransaction looks hash up once, under a span for the poll.
+func (c *Client) pollTransaction(ctx context.Context, hash string, poll int) (hProtocol.Transaction, error) {
+	_, span := telemetry.GetTracer().Start(ctx, "rpc_poll_transaction",
+		trace.WithLinks(c.submissions.links(hash)...))
+	span.SetAttributes(
+		attribute.Int("poll.attempt", poll),
+		attribute.String("rpc.url", c.HorizonURL),
+	)
+	tx, err := c.Horizon.TransactionDetail(hash)
+	if hErr, ok := err.(*horizonclient.Error); ok && hErr.Problem.Status == http.StatusNotFound {
+		// Not yet included is the expected answer while polling.
+		span.SetAttributes(attribute.Bool("transaction.included", false))
+		span.End()
+		return tx, err
+	}
+	span.SetAttributes(attribute.Bool("transaction.included", err == nil))
+	endSpan(span, err)
+	return tx, err
+}
+
+// decodeResultCodes returns the result code of a base64 TransactionResult
+// and, for fee-bump results, the inner transaction's code. Both are empty
+// when the XDR cannot be decoded.
+func decodeResultCodes(resultXdr string) (code, inner string) {
+	var res xdr.TransactionResult
+	if err := xdr.SafeUnmarshalBase64(resultXdr, &res); err != nil {
+		return "", ""
+	}
+	code = errors.NormalizeTxResultCode(res.Result.Code.String())
+	if pair, ok := res.Result.GetInnerResultPair(); ok {
+		inner = errors.NormalizeTxResultCode(pair.Result.Result.Code.String())
+	}
+	return code, inner
+}
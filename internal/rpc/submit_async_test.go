@@ -0,0 +1,181 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc/audit"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/network"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type asyncHorizon struct {
+	horizonclient.ClientInterface
+	resp       hProtocol.AsyncTransactionSubmissionResponse
+	notFound   int
+	lookups    int
+	successful bool
+	resultXdr  string
+}
+
+func (m *asyncHorizon) AsyncSubmitTransactionXDR(string) (hProtocol.AsyncTransactionSubmissionResponse, error) {
+	return m.resp, nil
+}
+
+func (m *asyncHorizon) TransactionDetail(hash string) (hProtocol.Transaction, error) {
+	m.lookups++
+	if m.lookups <= m.notFound {
+		return hProtocol.Transaction{}, &horizonclient.Error{Problem: problem.P{Status: 404}}
+	}
+	return hProtocol.Transaction{Hash: hash, Successful: m.successful, ResultXdr: m.resultXdr}, nil
+}
+
+func txResultXDR(t *testing.T, code xdr.TransactionResultCode) string {
+	t.Helper()
+	res := xdr.TransactionResult{Result: xdr.TransactionResultResult{Code: code}}
+	if code == xdr.TransactionResultCodeTxFailed {
+		res.Result.Results = &[]xdr.OperationResult{}
+	}
+	s, err := xdr.MarshalBase64(res)
+	require.NoError(t, err)
+	return s
+}
+
+func TestSubmitTransactionAsync(t *testing.T) {
+	horizon := &asyncHorizon{resp: hProtocol.AsyncTransactionSubmissionResponse{TxStatus: "PENDING", Hash: "abc"}}
+	client := &Client{Horizon: horizon}
+
+	res, err := client.SubmitTransactionAsync(context.Background(), "AAAA")
+	require.NoError(t, err)
+	assert.Equal(t, AsyncStatusPending, res.Status)
+	assert.True(t, res.Accepted())
+
+	horizon.resp = hProtocol.AsyncTransactionSubmissionResponse{TxStatus: "TRY_AGAIN_LATER", Hash: "abc"}
+	res, err = client.SubmitTransactionAsync(context.Background(), "AAAA")
+	assert.ErrorIs(t, err, errs.ErrTxTryAgainLater)
+	assert.False(t, res.Accepted())
+
+	horizon.resp = hProtocol.AsyncTransactionSubmissionResponse{
+		TxStatus:       "ERROR",
+		Hash:           "abc",
+		ErrorResultXDR: txResultXDR(t, xdr.TransactionResultCodeTxBadSeq),
+	}
+	res, err = client.SubmitTransactionAsync(context.Background(), "AAAA")
+	assert.ErrorIs(t, err, errs.ErrTxStatusError)
+	assert.ErrorIs(t, err, errs.ErrTxBadSeq)
+	assert.Equal(t, "tx_bad_seq", res.ResultCode)
+
+	_, err = client.SubmitTransactionAsync(context.Background(), "")
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+}
+
+func TestWaitForTransaction(t *testing.T) {
+	horizon := &asyncHorizon{notFound: 2, successful: true}
+	client := &Client{Horizon: horizon}
+
+	tx, err := client.WaitForTransaction(context.Background(), "abc", PollConfig{Interval: time.Millisecond, Timeout: time.Second})
+	require.NoError(t, err)
+	assert.Equal(t, "abc", tx.Hash)
+	assert.Equal(t, 3, horizon.lookups)
+}
+
+func TestWaitForTransaction_Failed(t *testing.T) {
+	horizon := &asyncHorizon{resultXdr: txResultXDR(t, xdr.TransactionResultCodeTxFailed)}
+	client := &Client{Horizon: horizon}
+
+	_, err := client.WaitForTransaction(context.Background(), "abc", PollConfig{Interval: time.Millisecond})
+	assert.ErrorIs(t, err, errs.ErrTxFailed)
+}
+
+func TestWaitForTransaction_Timeout(t *testing.T) {
+	horizon := &asyncHorizon{notFound: 1 << 30}
+	client := &Client{Horizon: horizon}
+
+	_, err := client.WaitForTransaction(context.Background(), "abc", PollConfig{Interval: time.Millisecond, Timeout: 20 * time.Millisecond})
+	assert.ErrorIs(t, err, errs.ErrRPCTimeout)
+}
+
+func feeBumpResultXDR(t *testing.T, inner xdr.TransactionResultCode) string {
+	t.Helper()
+	res := xdr.TransactionResult{Result: xdr.TransactionResultResult{
+		Code: xdr.TransactionResultCodeTxFeeBumpInnerFailed,
+		InnerResultPair: &xdr.InnerTransactionResultPair{
+			Result: xdr.InnerTransactionResult{Result: xdr.InnerTransactionResultResult{Code: inner}},
+		},
+	}}
+	s, err := xdr.MarshalBase64(res)
+	require.NoError(t, err)
+	return s
+}
+
+func TestSubmitTransactionAsync_FeeBumpInnerFailed(t *testing.T) {
+	horizon := &asyncHorizon{resp: hProtocol.AsyncTransactionSubmissionResponse{
+		TxStatus:       "ERROR",
+		Hash:           "abc",
+		ErrorResultXDR: feeBumpResultXDR(t, xdr.TransactionResultCodeTxBadSeq),
+	}}
+	client := &Client{Horizon: horizon}
+
+	res, err := client.SubmitTransactionAsync(context.Background(), "AAAA")
+	assert.ErrorIs(t, err, errs.ErrTxBadSeq)
+	assert.Equal(t, "tx_fee_bump_inner_failed", res.ResultCode)
+	assert.Equal(t, "tx_bad_seq", res.InnerResultCode)
+}
+
+type failingAuditLog struct{ audit.Log }
+
+func (failingAuditLog) Append(*audit.Record) error { return os.ErrPermission }
+
+func TestSubmitTransactionAsync_AuditLog(t *testing.T) {
+	source := keypair.MustRandom()
+	horizon := &envelopeHorizon{accounts: map[string]hProtocol.Account{
+		source.Address(): {
+			AccountID: source.Address(),
+			Signers:   []hProtocol.Signer{{Key: source.Address(), Type: "ed25519_public_key", Weight: 1}},
+		},
+	}}
+	log, err := audit.OpenJSONL(filepath.Join(t.TempDir(), "audit.jsonl"))
+	require.NoError(t, err)
+	defer log.Close()
+	client := &Client{Horizon: horizon, HorizonURL: TestnetHorizonURL, Network: Testnet, Config: TestnetConfig, audit: log}
+
+	env := signedEnvelope(t, network.TestNetworkPassphrase, source, source)
+	_, err = client.SubmitTransactionAsync(context.Background(), env)
+	require.NoError(t, err)
+
+	records, err := log.Records()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.NoError(t, audit.Verify(records))
+
+	submit, result := records[0], records[1]
+	assert.Equal(t, audit.EventSubmit, submit.Event)
+	assert.Len(t, submit.TxHash, 64)
+	assert.Equal(t, source.Address(), submit.Source)
+	assert.Equal(t, []string{"bump_sequence"}, submit.Operations)
+	assert.Equal(t, TestnetHorizonURL, submit.Endpoint)
+	assert.Equal(t, string(Testnet), submit.Network)
+
+	assert.Equal(t, audit.EventResult, result.Event)
+	assert.Equal(t, submit.TxHash, result.TxHash)
+	assert.Equal(t, "PENDING", result.Status)
+	assert.Empty(t, result.Error)
+
+	client.audit = failingAuditLog{}
+	_, err = client.SubmitTransactionAsync(context.Background(), env)
+	assert.ErrorIs(t, err, errs.ErrAuditLog)
+	assert.Equal(t, 1, horizon.submitted, "envelope must not be sent without a submit record")
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/rpc/audit"
+	"github.com/stellar/go-stellar-sdk/network"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// auditSubmit writes the submit record for envelopeXdr, returning the
+// transaction hash when it could be computed. Without an audit log it does
+// nothing.
+func (c *Client) auditSubmit(envelopeXdr string) (string, error) {
+	if c.audit == nil {
+		return "", nil
+	}
+	rec := &audit.Record{
+		Event:    audit.EventSubmit,
+		Network:  string(c.Network),
+		Endpoint: c.HorizonURL,
+	}
+	var env xdr.TransactionEnvelope
+	if err := xdr.SafeUnmarshalBase64(envelopeXdr, &env); err == nil {
+		if c.Config.NetworkPassphrase != "" {
+			if h, err := network.HashTransactionInEnvelope(env, c.Config.NetworkPassphrase); err == nil {
+				rec.TxHash = hex.EncodeToString(h[:])
+			}
+		}
+		if d, err := decoder.DescribeEnvelope(env); err == nil {
+			rec.Source = d.Source
+			if d.InnerTx != nil {
+				rec.FeeSource = d.Source
+				d = d.InnerTx
+				rec.Source = d.Source
+			}
+			for _, op := range d.Operations {
+				rec.Operations = append(rec.Operations, summarizeOperation(op))
+			}
+		}
+	}
+	if err := c.audit.Append(rec); err != nil {
+		return rec.TxHash, errors.WrapAuditLog(err)
+	}
+	return rec.TxHash, nil
+}
+
+// auditResult writes the result record of a submission. The envelope has
+// already been sent, so a failed write is logged rather than returned.
+func (c *Client) auditResult(ctx context.Context, hash string, result *AsyncSubmitResult, submitErr error) {
+	if c.audit == nil {
+		return
+	}
+	rec := &audit.Record{
+		Event:    audit.EventResult,
+		TxHash:   hash,
+		Network:  string(c.Network),
+		Endpoint: c.HorizonURL,
+	}
+	if result != nil {
+		if result.Hash != "" {
+			rec.TxHash = result.Hash
+		}
+		rec.Status = string(result.Status)
+		rec.ResultCode = result.ResultCode
+	}
+	if submitErr != nil {
+		rec.Error = submitErr.Error()
+	}
+	if err := c.audit.Append(rec); err != nil {
+		c.logContext(ctx, LogSubsystemSubmission).Error("Failed to write audit result record", "hash", rec.TxHash, "error", err)
+	}
+}
+
+// summarizeOperation renders op as its type followed by the amount, asset
+// and destination it moves, when it has them, e.g.
+// "payment amount=10.0000000 asset=native destination=GA...".
+func summarizeOperation(op decoder.DecodedOperation) string {
+	parts := []string{op.Type}
+	for _, key := range []string{"amount", "asset", "destination"} {
+		if v, ok := op.Details[key]; ok {
+			parts = append(parts, fmt.Sprintf("%s=%v", key, v))
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dotandev/hintents/internal/telemetry"
+	"github.com/dotandev/hintents/internal/timing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxTrackedSubmissions bounds how many submission spans a client remembers
+// for linking from WaitForTransaction.
+const maxTrackedSubmissions = 256
+
+// startFailoverHop starts the span for one node tried by a failover loop.
+// Hop 0 is the node the client was already using; each later hop follows a
+// rotation to a fallback. Transport retries against the node become children
+// of the hop when the attempt passes the returned context down.
+func startFailoverHop(ctx context.Context, operation string, hop int, nodeURL string) (context.Context, trace.Span) {
+	ctx, span := telemetry.GetTracer().Start(ctx, "rpc_failover_hop")
+	span.SetAttributes(
+		attribute.String("rpc.operation", operation),
+		attribute.Int("failover.hop", hop),
+		attribute.String("rpc.url", nodeURL),
+		attribute.String("rpc.endpoint", endpointLabel(nodeURL)),
+	)
+	return ctx, span
+}
+
+// endSpan records err, if any, on span and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// endHop ends a failover hop's span and adds the hop's duration to the
+// network time of the run's timing profile, if ctx carries one.
+func endHop(ctx context.Context, span trace.Span, start time.Time, err error) {
+	timing.FromContext(ctx).Observe(timing.PhaseNetwork, time.Since(start))
+	endSpan(span, err)
+}
+
+// startRetryAttempt starts the span for one HTTP attempt made by the retry
+// transport, after it has waited backoff.
+func startRetryAttempt(ctx context.Context, endpoint string, attempt int, backoff time.Duration) (context.Context, trace.Span) {
+	ctx, span := telemetry.GetTracer().Start(ctx, "rpc_http_attempt", trace.WithSpanKind(trace.SpanKindClient))
+	span.SetAttributes(
+		attribute.String("rpc.endpoint", endpoint),
+		attribute.Int("retry.attempt", attempt),
+		attribute.Int64("retry.backoff_ms", backoff.Milliseconds()),
+	)
+	return ctx, span
+}
+
+// submissionSpans remembers the span context of recent submissions by
+// transaction hash, so confirmation polling can link back to them.
+type submissionSpans struct {
+	mu     sync.Mutex
+	spans  map[string]trace.SpanContext
+	hashes []string
+}
+
+func (s *submissionSpans) remember(hash string, sc trace.SpanContext) {
+	if hash == "" || !sc.IsValid() {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.spans == nil {
+		s.spans = make(map[string]trace.SpanContext)
+	}
+	if _, ok := s.spans[hash]; !ok {
+		s.hashes = append(s.hashes, hash)
+		if len(s.hashes) > maxTrackedSubmissions {
+			delete(s.spans, s.hashes[0])
+			s.hashes = s.hashes[1:]
+		}
+	}
+	s.spans[hash] = sc
+}
+
+// links returns a link to the submission span for hash, if one is known.
+func (s *submissionSpans) links(hash string) []trace.Link {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sc, ok := s.spans[hash]
+	if !ok {
+		return nil
+	}
+	return []trace.Link{{
+		SpanContext: sc,
+		Attributes:  []attribute.KeyValue{attribute.String("link.kind", "submission")},
+	}}
+}
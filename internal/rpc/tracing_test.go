@@ -0,0 +1,205 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dotandev/hintents/internal/timing"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/support/render/problem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	rec := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return rec
+}
+
+func spansNamed(rec *tracetest.SpanRecorder, name string) []sdktrace.ReadOnlySpan {
+	var out []sdktrace.ReadOnlySpan
+	for _, s := range rec.Ended() {
+		if s.Name() == name {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func spanAttr(s sdktrace.ReadOnlySpan, key string) attribute.Value {
+	for _, kv := range s.Attributes() {
+		if string(kv.Key) == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestRetryTransportSpans(t *testing.T) {
+	rec := recordSpans(t)
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := DefaultRetryConfig()
+	cfg.InitialBackoff = time.Millisecond
+	cfg.JitterFraction = 0
+	hc := &http.Client{Transport: NewRetryTransport(cfg, nil)}
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := hc.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	parent.End()
+
+	attempts := spansNamed(rec, "rpc_http_attempt")
+	require.Len(t, attempts, 2)
+	for _, s := range attempts {
+		assert.Equal(t, parent.SpanContext().SpanID(), s.Parent().SpanID())
+	}
+	assert.Equal(t, int64(429), spanAttr(attempts[0], "http.status_code").AsInt64())
+	assert.Equal(t, codes.Error, attempts[0].Status().Code)
+	assert.Equal(t, int64(1), spanAttr(attempts[1], "retry.attempt").AsInt64())
+	assert.Equal(t, int64(200), spanAttr(attempts[1], "http.status_code").AsInt64())
+	assert.Equal(t, attribute.INT64, spanAttr(attempts[1], "retry.backoff_ms").Type())
+}
+
+func TestFailoverHopSpans(t *testing.T) {
+	rec := recordSpans(t)
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer down.Close()
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer up.Close()
+
+	client, err := NewClient(WithAltURLs([]string{down.URL, up.URL}))
+	require.NoError(t, err)
+	require.NoError(t, client.getHorizon(context.Background(), "/fee_stats", nil, &struct{}{}))
+
+	hops := spansNamed(rec, "rpc_failover_hop")
+	require.Len(t, hops, 2)
+	assert.Equal(t, down.URL, spanAttr(hops[0], "rpc.url").AsString())
+	assert.Equal(t, codes.Error, hops[0].Status().Code)
+	assert.Equal(t, int64(1), spanAttr(hops[1], "failover.hop").AsInt64())
+	assert.Equal(t, up.URL, spanAttr(hops[1], "rpc.url").AsString())
+
+	// The HTTP attempt against each node is a child of its hop.
+	attempts := spansNamed(rec, "rpc_http_attempt")
+	require.Len(t, attempts, 2)
+	assert.Equal(t, hops[1].SpanContext().SpanID(), attempts[1].Parent().SpanID())
+}
+
+type lifecycleHorizon struct {
+	horizonclient.ClientInterface
+	lookups int
+}
+
+func (m *lifecycleHorizon) AsyncSubmitTransactionXDR(string) (hProtocol.AsyncTransactionSubmissionResponse, error) {
+	return hProtocol.AsyncTransactionSubmissionResponse{TxStatus: "PENDING", Hash: "abc123"}, nil
+}
+
+func (m *lifecycleHorizon) TransactionDetail(hash string) (hProtocol.Transaction, error) {
+	m.lookups++
+	if m.lookups == 1 {
+		return hProtocol.Transaction{}, &horizonclient.Error{Problem: problem.P{Status: http.StatusNotFound}}
+	}
+	return hProtocol.Transaction{Hash: hash, Successful: true}, nil
+}
+
+func TestSubmissionLinkedToPolls(t *testing.T) {
+	rec := recordSpans(t)
+	client := &Client{Horizon: &lifecycleHorizon{}}
+	ctx := context.Background()
+
+	res, err := client.SubmitTransactionAsync(ctx, "AAAA")
+	require.NoError(t, err)
+	_, err = client.WaitForTransaction(ctx, res.Hash, PollConfig{Interval: time.Millisecond})
+	require.NoError(t, err)
+
+	submit := spansNamed(rec, "rpc_submit_transaction")
+	require.Len(t, submit, 1)
+	assert.Equal(t, "abc123", spanAttr(submit[0], "transaction.hash").AsString())
+
+	wait := spansNamed(rec, "rpc_wait_for_transaction")
+	require.Len(t, wait, 1)
+	require.Len(t, wait[0].Links(), 1)
+	assert.Equal(t, submit[0].SpanContext().SpanID(), wait[0].Links()[0].SpanContext.SpanID())
+
+	polls := spansNamed(rec, "rpc_poll_transaction")
+	require.Len(t, polls, 2)
+	assert.Equal(t, codes.Unset, polls[0].Status().Code, "not found yet is not an error")
+	assert.False(t, spanAttr(polls[0], "transaction.included").AsBool())
+	assert.True(t, spanAttr(polls[1], "transaction.included").AsBool())
+	for _, p := range polls {
+		assert.Equal(t, wait[0].SpanContext().SpanID(), p.Parent().SpanID())
+		require.Len(t, p.Links(), 1)
+	}
+}
+
+func TestSubmissionSpansBounded(t *testing.T) {
+	var s submissionSpans
+	sc := trace.NewSpanContext(trace.SpanContextConfig{TraceID: trace.TraceID{1}, SpanID: trace.SpanID{1}})
+	for i := 0; i < maxTrackedSubmissions+10; i++ {
+		s.remember(fmt.Sprintf("hash-%d", i), sc)
+	}
+	assert.Len(t, s.spans, maxTrackedSubmissions)
+	assert.Nil(t, s.links("hash-0"), "the oldest submission is dropped")
+	assert.Len(t, s.links(fmt.Sprintf("hash-%d", maxTrackedSubmissions+9)), 1)
+
+	s.remember("ignored", trace.SpanContext{})
+	assert.Nil(t, s.links("ignored"))
+}
+
+func TestSimulateTransaction_TimingProfile(t *testing.T) {
+	server, _ := newSimulateServer(t, func(call int32) map[string]interface{} {
+		return map[string]interface{}{"result": map[string]interface{}{"latestLedger": 42}}
+	})
+	defer server.Close()
+
+	client, err := NewClient(WithNetwork(Testnet), WithSorobanURL(server.URL), WithSimulationCache(time.Minute))
+	require.NoError(t, err)
+
+	prof := timing.New()
+	ctx := timing.WithProfiler(context.Background(), prof)
+	for i := 0; i < 2; i++ {
+		_, err := client.SimulateTransaction(ctx, "AAAA")
+		require.NoError(t, err)
+	}
+
+	phases := map[timing.Phase]int{}
+	for _, s := range prof.Report().Phases {
+		phases[s.Phase] = s.Count
+	}
+	// The second call is answered from the cache without a network hop.
+	assert.Equal(t, map[timing.Phase]int{timing.PhaseNetwork: 1, timing.PhaseCache: 2}, phases)
+}
@@ -0,0 +1,268 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/effects"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+)
+
+// Effect is a typed Horizon effect. The set of implementations is closed, so
+// consumers can type switch over the concrete *Effect types below; effects
+// this package does not model are returned as *UnknownEffect.
+type Effect interface {
+	// Info returns the fields common to every effect.
+	Info() EffectInfo
+	isEffect()
+}
+
+// EffectInfo holds the fields shared by all effects.
+type EffectInfo struct {
+	ID          string
+	OperationID string
+	Type        string
+	Account     MuxedAddress
+	At          time.Time
+}
+
+func (e EffectInfo) Info() EffectInfo { return e }
+func (EffectInfo) isEffect()          {}
+
+// AssetAmount is an amount of a specific asset.
+type AssetAmount struct {
+	Asset  txnbuild.Asset
+	Amount string
+}
+
+// TrustlineChange is the payload of the trustline_* effects.
+type TrustlineChange struct {
+	// Asset is nil for liquidity pool share trustlines.
+	Asset           txnbuild.Asset
+	LiquidityPoolID string
+	Limit           string
+}
+
+// SignerChange is the payload of the signer_* effects.
+type SignerChange struct {
+	Key    string
+	Weight int32
+}
+
+type (
+	AccountCreatedEffect struct {
+		EffectInfo
+		StartingBalance string
+	}
+	AccountRemovedEffect struct {
+		EffectInfo
+	}
+	AccountCreditedEffect struct {
+		EffectInfo
+		AssetAmount
+	}
+	AccountDebitedEffect struct {
+		EffectInfo
+		AssetAmount
+	}
+	AccountThresholdsUpdatedEffect struct {
+		EffectInfo
+		Thresholds AccountThresholds
+	}
+	AccountHomeDomainUpdatedEffect struct {
+		EffectInfo
+		HomeDomain string
+	}
+	SignerCreatedEffect struct {
+		EffectInfo
+		SignerChange
+	}
+	SignerRemovedEffect struct {
+		EffectInfo
+		SignerChange
+	}
+	SignerUpdatedEffect struct {
+		EffectInfo
+		SignerChange
+	}
+	TrustlineCreatedEffect struct {
+		EffectInfo
+		TrustlineChange
+	}
+	TrustlineRemovedEffect struct {
+		EffectInfo
+		TrustlineChange
+	}
+	TrustlineUpdatedEffect struct {
+		EffectInfo
+		TrustlineChange
+	}
+	TrustlineFlagsUpdatedEffect struct {
+		EffectInfo
+		Asset                           txnbuild.Asset
+		Trustor                         string
+		Authorized                      *bool
+		AuthorizedToMaintainLiabilities *bool
+		ClawbackEnabled                 *bool
+	}
+	TradeEffect struct {
+		EffectInfo
+		Seller  MuxedAddress
+		OfferID int64
+		Sold    AssetAmount
+		Bought  AssetAmount
+	}
+	ClaimableBalanceCreatedEffect struct {
+		EffectInfo
+		BalanceID string
+		AssetAmount
+	}
+	ClaimableBalanceClaimedEffect struct {
+		EffectInfo
+		BalanceID string
+		AssetAmount
+	}
+	ContractCreditedEffect struct {
+		EffectInfo
+		Contract string
+		AssetAmount
+	}
+	ContractDebitedEffect struct {
+		EffectInfo
+		Contract string
+		AssetAmount
+	}
+	SequenceBumpedEffect struct {
+		EffectInfo
+		NewSequence int64
+	}
+	// UnknownEffect carries an effect kind without a dedicated type.
+	UnknownEffect struct {
+		EffectInfo
+		Raw effects.Effect
+	}
+)
+
+// DecodeEffect converts a horizonclient effect into its typed form.
+func DecodeEffect(e effects.Effect) Effect {
+	switch ef := e.(type) {
+	case effects.AccountCreated:
+		return &AccountCreatedEffect{EffectInfo: effectInfo(ef.Base), StartingBalance: ef.StartingBalance}
+	case effects.AccountCredited:
+		return &AccountCreditedEffect{EffectInfo: effectInfo(ef.Base), AssetAmount: AssetAmount{assetFromBase(ef.Asset), ef.Amount}}
+	case effects.AccountDebited:
+		return &AccountDebitedEffect{EffectInfo: effectInfo(ef.Base), AssetAmount: AssetAmount{assetFromBase(ef.Asset), ef.Amount}}
+	case effects.AccountThresholdsUpdated:
+		return &AccountThresholdsUpdatedEffect{EffectInfo: effectInfo(ef.Base), Thresholds: AccountThresholds{
+			Low:    uint8(ef.LowThreshold),
+			Medium: uint8(ef.MedThreshold),
+			High:   uint8(ef.HighThreshold),
+		}}
+	case effects.AccountHomeDomainUpdated:
+		return &AccountHomeDomainUpdatedEffect{EffectInfo: effectInfo(ef.Base), HomeDomain: ef.HomeDomain}
+	case effects.SignerCreated:
+		return &SignerCreatedEffect{EffectInfo: effectInfo(ef.Base), SignerChange: SignerChange{ef.Key, ef.Weight}}
+	case effects.SignerRemoved:
+		return &SignerRemovedEffect{EffectInfo: effectInfo(ef.Base), SignerChange: SignerChange{ef.Key, ef.Weight}}
+	case effects.SignerUpdated:
+		return &SignerUpdatedEffect{EffectInfo: effectInfo(ef.Base), SignerChange: SignerChange{ef.Key, ef.Weight}}
+	case effects.TrustlineCreated:
+		return &TrustlineCreatedEffect{EffectInfo: effectInfo(ef.Base), TrustlineChange: trustlineChange(ef.LiquidityPoolID, ef.Asset.Type, ef.Asset.Code, ef.Asset.Issuer, ef.Limit)}
+	case effects.TrustlineRemoved:
+		return &TrustlineRemovedEffect{EffectInfo: effectInfo(ef.Base), TrustlineChange: trustlineChange(ef.LiquidityPoolID, ef.Asset.Type, ef.Asset.Code, ef.Asset.Issuer, ef.Limit)}
+	case effects.TrustlineUpdated:
+		return &TrustlineUpdatedEffect{EffectInfo: effectInfo(ef.Base), TrustlineChange: trustlineChange(ef.LiquidityPoolID, ef.Asset.Type, ef.Asset.Code, ef.Asset.Issuer, ef.Limit)}
+	case effects.TrustlineFlagsUpdated:
+		return &TrustlineFlagsUpdatedEffect{
+			EffectInfo:                      effectInfo(ef.Base),
+			Asset:                           assetFromBase(ef.Asset),
+			Trustor:                         ef.Trustor,
+			Authorized:                      ef.Authorized,
+			AuthorizedToMaintainLiabilities: ef.AuthorizedToMaintainLiabilities,
+			ClawbackEnabled:                 ef.ClawbackEnabled,
+		}
+	case effects.Trade:
+		return &TradeEffect{
+			EffectInfo: effectInfo(ef.Base),
+			Seller:     muxedFromHorizon(ef.Seller, ef.SellerMuxed, ef.SellerMuxedID),
+			OfferID:    ef.OfferID,
+			Sold:       AssetAmount{assetFromHorizon(ef.SoldAssetType, ef.SoldAssetCode, ef.SoldAssetIssuer), ef.SoldAmount},
+			Bought:     AssetAmount{assetFromHorizon(ef.BoughtAssetType, ef.BoughtAssetCode, ef.BoughtAssetIssuer), ef.BoughtAmount},
+		}
+	case effects.ClaimableBalanceCreated:
+		return &ClaimableBalanceCreatedEffect{EffectInfo: effectInfo(ef.Base), BalanceID: ef.BalanceID, AssetAmount: AssetAmount{assetFromCanonical(ef.Asset), ef.Amount}}
+	case effects.ClaimableBalanceClaimed:
+		return &ClaimableBalanceClaimedEffect{EffectInfo: effectInfo(ef.Base), BalanceID: ef.BalanceID, AssetAmount: AssetAmount{assetFromCanonical(ef.Asset), ef.Amount}}
+	case effects.ContractCredited:
+		return &ContractCreditedEffect{EffectInfo: effectInfo(ef.Base), Contract: ef.Contract, AssetAmount: AssetAmount{assetFromBase(ef.Asset), ef.Amount}}
+	case effects.ContractDebited:
+		return &ContractDebitedEffect{EffectInfo: effectInfo(ef.Base), Contract: ef.Contract, AssetAmount: AssetAmount{assetFromBase(ef.Asset), ef.Amount}}
+	case effects.SequenceBumped:
+		return &SequenceBumpedEffect{EffectInfo: effectInfo(ef.Base), NewSequence: ef.NewSeq}
+	case effects.Base:
+		if ef.Type == "account_removed" {
+			return &AccountRemovedEffect{EffectInfo: effectInfo(ef)}
+		}
+		return &UnknownEffect{EffectInfo: effectInfo(ef), Raw: e}
+	}
+	return &UnknownEffect{EffectInfo: effectInfo(baseOf(e)), Raw: e}
+}
+
+// baseOf recovers the common fields of an effect kind without a dedicated
+// type by round-tripping it through its JSON form.
+func baseOf(e effects.Effect) effects.Base {
+	b := effects.Base{ID: e.GetID(), PT: e.PagingToken(), Type: e.GetType(), Account: e.GetAccount()}
+	if raw, err := json.Marshal(e); err == nil {
+		_ = json.Unmarshal(raw, &b)
+	}
+	return b
+}
+
+// TypedEffects returns the effects matching req decoded into typed values.
+func (c *Client) TypedEffects(ctx context.Context, req horizonclient.EffectRequest, opts ...IteratorOption) ([]Effect, error) {
+	records, err := c.Effects(ctx, req, opts...).Collect()
+	if err != nil {
+		c.logContext(ctx, LogSubsystemRPC).Error("Failed to fetch effects", "error", err)
+		return nil, errors.WrapRPCConnectionFailed(err)
+	}
+	out := make([]Effect, 0, len(records))
+	for _, r := range records {
+		out = append(out, DecodeEffect(r))
+	}
+	return out, nil
+}
+
+// StreamTypedEffects is StreamEffects with typed effects.
+func (c *Client) StreamTypedEffects(ctx context.Context, cursor string, handler func(Effect) error) error {
+	return c.StreamEffects(ctx, cursor, func(e effects.Effect) error {
+		return handler(DecodeEffect(e))
+	})
+}
+
+// effectInfo extracts the common fields. Effect paging tokens are
+// "<operation id>-<index>".
+func effectInfo(b effects.Base) EffectInfo {
+	opID, _, _ := strings.Cut(b.PT, "-")
+	return EffectInfo{
+		ID:          b.ID,
+		OperationID: opID,
+		Type:        b.Type,
+		Account:     muxedFromHorizon(b.Account, b.AccountMuxed, b.AccountMuxedID),
+		At:          b.LedgerCloseTime,
+	}
+}
+
+func trustlineChange(poolID, assetType, code, issuer, limit string) TrustlineChange {
+	t := TrustlineChange{LiquidityPoolID: poolID, Limit: limit}
+	if poolID == "" {
+		t.Asset = assetFromHorizon(assetType, code, issuer)
+	}
+	return t
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stellar/go-stellar-sdk/clients/horizonclient"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/effects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeTestEffect(t *testing.T, effectType, body string) Effect {
+	t.Helper()
+	e, err := effects.UnmarshalEffect(effectType, []byte(body))
+	require.NoError(t, err)
+	return DecodeEffect(e)
+}
+
+func TestDecodeEffect_AccountCredited(t *testing.T) {
+	e := decodeTestEffect(t, "account_credited", `{"id":"0000000100-0000000001","paging_token":"100-1","account":"`+testClaimant+
+		`","account_muxed_id":"7","account_muxed":"M...","type":"account_credited","type_i":2,`+
+		`"asset_type":"credit_alphanum4","asset_code":"USDC","asset_issuer":"`+testIssuer+`","amount":"5.0000000"}`)
+
+	credited, ok := e.(*AccountCreditedEffect)
+	require.True(t, ok, "got %T", e)
+	assert.Equal(t, "100", credited.OperationID)
+	assert.Equal(t, "account_credited", credited.Info().Type)
+	assert.Equal(t, MuxedAddress{Account: testClaimant, ID: 7, Muxed: true}, credited.Account)
+	assert.Equal(t, "USDC", credited.Asset.GetCode())
+	assert.Equal(t, "5.0000000", credited.Amount)
+}
+
+func TestDecodeEffect_Trustlines(t *testing.T) {
+	e := decodeTestEffect(t, "trustline_created", `{"id":"1","paging_token":"200-1","account":"`+testClaimant+`","type":"trustline_created","type_i":20,`+
+		`"asset_type":"liquidity_pool_shares","liquidity_pool_id":"pool1","limit":"10.0000000"}`)
+	created, ok := e.(*TrustlineCreatedEffect)
+	require.True(t, ok, "got %T", e)
+	assert.Nil(t, created.Asset)
+	assert.Equal(t, "pool1", created.LiquidityPoolID)
+	assert.Equal(t, "10.0000000", created.Limit)
+
+	e = decodeTestEffect(t, "trustline_flags_updated", `{"id":"2","paging_token":"201-1","account":"`+testIssuer+`","type":"trustline_flags_updated","type_i":26,`+
+		`"asset_type":"credit_alphanum4","asset_code":"USDC","asset_issuer":"`+testIssuer+`","trustor":"`+testClaimant+`","authorized_flag":false}`)
+	flags, ok := e.(*TrustlineFlagsUpdatedEffect)
+	require.True(t, ok, "got %T", e)
+	assert.Equal(t, testClaimant, flags.Trustor)
+	require.NotNil(t, flags.Authorized)
+	assert.False(t, *flags.Authorized)
+	assert.Nil(t, flags.ClawbackEnabled)
+}
+
+func TestDecodeEffect_Trade(t *testing.T) {
+	e := decodeTestEffect(t, "trade", `{"id":"3","paging_token":"300-2","account":"`+testClaimant+`","type":"trade","type_i":33,`+
+		`"seller":"`+testIssuer+`","offer_id":"42","sold_amount":"1.0000000","sold_asset_type":"native",`+
+		`"bought_amount":"2.0000000","bought_asset_type":"credit_alphanum4","bought_asset_code":"USDC","bought_asset_issuer":"`+testIssuer+`"}`)
+	trade, ok := e.(*TradeEffect)
+	require.True(t, ok, "got %T", e)
+	assert.Equal(t, testIssuer, trade.Seller.Account)
+	assert.Equal(t, int64(42), trade.OfferID)
+	assert.True(t, trade.Sold.Asset.IsNative())
+	assert.Equal(t, "USDC", trade.Bought.Asset.GetCode())
+	assert.Equal(t, "2.0000000", trade.Bought.Amount)
+}
+
+func TestDecodeEffect_ContractAndClaimableBalance(t *testing.T) {
+	e := decodeTestEffect(t, "contract_credited", `{"id":"4","paging_token":"400-1","account":"`+testClaimant+`","type":"contract_credited","type_i":96,`+
+		`"asset_type":"native","contract":"CABC","amount":"3.0000000"}`)
+	contract, ok := e.(*ContractCreditedEffect)
+	require.True(t, ok, "got %T", e)
+	assert.Equal(t, "CABC", contract.Contract)
+	assert.True(t, contract.Asset.IsNative())
+
+	e = decodeTestEffect(t, "claimable_balance_claimed", `{"id":"5","paging_token":"500-1","account":"`+testClaimant+`","type":"claimable_balance_claimed","type_i":52,`+
+		`"asset":"USDC:`+testIssuer+`","balance_id":"00000000abc","amount":"4.0000000"}`)
+	claimed, ok := e.(*ClaimableBalanceClaimedEffect)
+	require.True(t, ok, "got %T", e)
+	assert.Equal(t, "00000000abc", claimed.BalanceID)
+	assert.Equal(t, "USDC", claimed.Asset.GetCode())
+}
+
+func TestDecodeEffect_Unknown(t *testing.T) {
+	e := decodeTestEffect(t, "data_created", `{"id":"6","paging_token":"600-1","account":"`+testClaimant+`","type":"data_created","type_i":40,"name":"k","value":"dg=="}`)
+	unknown, ok := e.(*UnknownEffect)
+	require.True(t, ok, "got %T", e)
+	assert.Equal(t, "data_created", unknown.Type)
+	assert.Equal(t, "600", unknown.OperationID)
+	assert.NotNil(t, unknown.Raw)
+}
+
+type typedEffectsHorizon struct {
+	horizonclient.ClientInterface
+	req horizonclient.EffectRequest
+}
+
+func (m *typedEffectsHorizon) Effects(req horizonclient.EffectRequest) (effects.EffectsPage, error) {
+	m.req = req
+	var page effects.EffectsPage
+	page.Embedded.Records = []effects.Effect{
+		effects.SequenceBumped{Base: effects.Base{ID: "1", PT: "700-1", Type: "sequence_bumped"}, NewSeq: 99},
+		effects.Base{ID: "2", PT: "701-1", Type: "account_removed", Account: testClaimant},
+	}
+	return page, nil
+}
+
+func (m *typedEffectsHorizon) NextEffectsPage(effects.EffectsPage) (effects.EffectsPage, error) {
+	return effects.EffectsPage{}, nil
+}
+
+func TestTypedEffects(t *testing.T) {
+	horizon := &typedEffectsHorizon{}
+	client := &Client{Horizon: horizon}
+
+	got, err := client.TypedEffects(context.Background(), horizonclient.EffectRequest{ForAccount: testClaimant})
+	require.NoError(t, err)
+	assert.Equal(t, testClaimant, horizon.req.ForAccount)
+	require.Len(t, got, 2)
+
+	bumped, ok := got[0].(*SequenceBumpedEffect)
+	require.True(t, ok, "got %T", got[0])
+	assert.Equal(t, int64(99), bumped.NewSequence)
+
+	removed, ok := got[1].(*AccountRemovedEffect)
+	require.True(t, ok, "got %T", got[1])
+	assert.Equal(t, testClaimant, removed.Account.Account)
+}
@@ -0,0 +1,269 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/base"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/effects"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/operations"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+)
+
+// AccountEventKind classifies an AccountEvent.
+type AccountEventKind string
+
+const (
+	AccountPaymentReceived  AccountEventKind = "payment_received"
+	AccountPaymentSent      AccountEventKind = "payment_sent"
+	AccountTrustlineChanged AccountEventKind = "trustline_changed"
+	AccountSignerChanged    AccountEventKind = "signer_changed"
+)
+
+// AccountEvent is one entry of the feed returned by WatchAccount. Exactly one
+// of Payment, Trustline or Signer is set, matching Kind.
+type AccountEvent struct {
+	Kind            AccountEventKind
+	OperationID     string
+	TransactionHash string
+	At              time.Time
+	Payment         *PaymentEvent
+	Trustline       *TrustlineEvent
+	Signer          *SignerEvent
+}
+
+// PaymentEvent describes value moving into or out of the watched account.
+// From is unknown (zero) when the credit was only visible as an effect, for
+// example when claiming a claimable balance.
+type PaymentEvent struct {
+	From   MuxedAddress
+	To     MuxedAddress
+	Asset  txnbuild.Asset
+	Amount string
+}
+
+// TrustlineEvent describes a trustline that was created, removed, updated or
+// had its authorization flags changed.
+type TrustlineEvent struct {
+	// Change is the effect type, e.g. trustline_created.
+	Change          string
+	Asset           txnbuild.Asset
+	LiquidityPoolID string
+	Limit           string
+}
+
+// SignerEvent describes a signer that was added, removed or reweighted.
+type SignerEvent struct {
+	// Change is the effect type, e.g. signer_created.
+	Change string
+	Key    string
+	Weight int32
+}
+
+// accountWatchDedupeWindow bounds how many recent event keys are remembered
+// for de-duplication across the payment and effect streams.
+const accountWatchDedupeWindow = 4096
+
+// WatchAccount follows the payments and effects of accountID (a G... or M...
+// address) from now on and merges them into one de-duplicated feed of typed
+// events. The channel is closed once ctx is cancelled or both underlying
+// streams have stopped.
+func (c *Client) WatchAccount(ctx context.Context, accountID string) (<-chan AccountEvent, error) {
+	addr, err := ParseMuxedAddress(accountID)
+	if err != nil {
+		return nil, err
+	}
+	account := addr.Account
+
+	out := make(chan AccountEvent, 64)
+	seen := newDedupeWindow(accountWatchDedupeWindow)
+	emit := func(ev AccountEvent) error {
+		if !seen.add(string(ev.Kind) + "|" + ev.OperationID + "|" + eventDetailKey(ev)) {
+			return nil
+		}
+		select {
+		case out <- ev:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		err := c.streamHorizon(ctx, "/accounts/"+account+"/payments", "now", operationStreamDecoder(func(op operations.Operation) error {
+			if ev, ok := accountEventFromOperation(account, op); ok {
+				return emit(ev)
+			}
+			return nil
+		}))
+		if err != nil && ctx.Err() == nil {
+			c.logContext(ctx, LogSubsystemRPC).Warn("Account payment stream stopped", "account", account, "error", err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		err := c.streamHorizon(ctx, "/accounts/"+account+"/effects", "now", effectStreamDecoder(func(e effects.Effect) error {
+			if ev, ok := accountEventFromEffect(e); ok {
+				return emit(ev)
+			}
+			return nil
+		}))
+		if err != nil && ctx.Err() == nil {
+			c.logContext(ctx, LogSubsystemRPC).Warn("Account effect stream stopped", "account", account, "error", err)
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out, nil
+}
+
+// eventDetailKey distinguishes several events of one kind in one operation,
+// such as two trustline changes. Payments are keyed by operation alone so the
+// payment record and its account_credited effect collapse into one event.
+func eventDetailKey(ev AccountEvent) string {
+	switch {
+	case ev.Trustline != nil:
+		return ev.Trustline.Change + "|" + ev.Trustline.LiquidityPoolID + canonicalAssetList(nonNilAssets(ev.Trustline.Asset))
+	case ev.Signer != nil:
+		return ev.Signer.Change + "|" + ev.Signer.Key
+	}
+	return ""
+}
+
+func nonNilAssets(a txnbuild.Asset) []txnbuild.Asset {
+	if a == nil {
+		return nil
+	}
+	return []txnbuild.Asset{a}
+}
+
+func accountEventFromOperation(account string, op operations.Operation) (AccountEvent, bool) {
+	if !op.IsTransactionSuccessful() {
+		return AccountEvent{}, false
+	}
+	parties, ok := ResolvePaymentAccounts(op)
+	if !ok {
+		return AccountEvent{}, false
+	}
+
+	payment := &PaymentEvent{From: parties.From, To: parties.To}
+	switch o := op.(type) {
+	case operations.Payment:
+		payment.Asset, payment.Amount = assetFromBase(o.Asset), o.Amount
+	case operations.PathPayment:
+		payment.Asset, payment.Amount = assetFromBase(o.Asset), o.Amount
+	case operations.PathPaymentStrictSend:
+		payment.Asset, payment.Amount = assetFromBase(o.Asset), o.Amount
+	case operations.CreateAccount:
+		payment.Asset, payment.Amount = txnbuild.NativeAsset{}, o.StartingBalance
+	case operations.AccountMerge:
+		payment.Asset = txnbuild.NativeAsset{}
+	}
+
+	kind := AccountPaymentReceived
+	if parties.To.Account != account {
+		kind = AccountPaymentSent
+	}
+	b := op.GetBase()
+	return AccountEvent{
+		Kind:            kind,
+		OperationID:     b.ID,
+		TransactionHash: b.TransactionHash,
+		At:              b.LedgerCloseTime,
+		Payment:         payment,
+	}, true
+}
+
+func accountEventFromEffect(e effects.Effect) (AccountEvent, bool) {
+	switch ef := DecodeEffect(e).(type) {
+	case *AccountCreditedEffect:
+		return effectEvent(ef.EffectInfo, AccountEvent{Kind: AccountPaymentReceived, Payment: &PaymentEvent{
+			To:     ef.Account,
+			Asset:  ef.Asset,
+			Amount: ef.Amount,
+		}}), true
+	case *AccountDebitedEffect:
+		return effectEvent(ef.EffectInfo, AccountEvent{Kind: AccountPaymentSent, Payment: &PaymentEvent{
+			From:   ef.Account,
+			Asset:  ef.Asset,
+			Amount: ef.Amount,
+		}}), true
+	case *TrustlineCreatedEffect:
+		return trustlineEvent(ef.EffectInfo, ef.TrustlineChange), true
+	case *TrustlineRemovedEffect:
+		return trustlineEvent(ef.EffectInfo, ef.TrustlineChange), true
+	case *TrustlineUpdatedEffect:
+		return trustlineEvent(ef.EffectInfo, ef.TrustlineChange), true
+	case *TrustlineFlagsUpdatedEffect:
+		return trustlineEvent(ef.EffectInfo, TrustlineChange{Asset: ef.Asset}), true
+	case *SignerCreatedEffect:
+		return signerEvent(ef.EffectInfo, ef.SignerChange), true
+	case *SignerRemovedEffect:
+		return signerEvent(ef.EffectInfo, ef.SignerChange), true
+	case *SignerUpdatedEffect:
+		return signerEvent(ef.EffectInfo, ef.SignerChange), true
+	}
+	return AccountEvent{}, false
+}
+
+func trustlineEvent(info EffectInfo, t TrustlineChange) AccountEvent {
+	return effectEvent(info, AccountEvent{Kind: AccountTrustlineChanged, Trustline: &TrustlineEvent{
+		Change:          info.Type,
+		Asset:           t.Asset,
+		LiquidityPoolID: t.LiquidityPoolID,
+		Limit:           t.Limit,
+	}})
+}
+
+func signerEvent(info EffectInfo, s SignerChange) AccountEvent {
+	return effectEvent(info, AccountEvent{Kind: AccountSignerChanged, Signer: &SignerEvent{Change: info.Type, Key: s.Key, Weight: s.Weight}})
+}
+
+// effectEvent fills the operation identity of ev from an effect.
+func effectEvent(info EffectInfo, ev AccountEvent) AccountEvent {
+	ev.OperationID = info.OperationID
+	ev.At = info.At
+	return ev
+}
+
+func assetFromBase(a base.Asset) txnbuild.Asset {
+	return assetFromHorizon(a.Type, a.Code, a.Issuer)
+}
+
+// dedupeWindow remembers the most recent keys up to a fixed capacity.
+type dedupeWindow struct {
+	mu    sync.Mutex
+	keys  map[string]struct{}
+	order []string
+	next  int
+}
+
+func newDedupeWindow(size int) *dedupeWindow {
+	return &dedupeWindow{keys: make(map[string]struct{}, size), order: make([]string, size)}
+}
+
+// add records key and reports whether it was not already present.
+func (d *dedupeWindow) add(key string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.keys[key]; ok {
+		return false
+	}
+	if old := d.order[d.next]; old != "" {
+		delete(d.keys, old)
+	}
+	d.order[d.next] = key
+	d.next = (d.next + 1) % len(d.order)
+	d.keys[key] = struct{}{}
+	return true
+}
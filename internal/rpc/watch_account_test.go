@@ -0,0 +1,97 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchAccount(t *testing.T) {
+	payment := `{"id":"100","paging_token":"100","type":"payment","type_i":1,"transaction_successful":true,"transaction_hash":"tx1",` +
+		`"source_account":"` + testIssuer + `","from":"` + testIssuer + `","to":"` + testClaimant + `","asset_type":"native","amount":"5.0000000"}`
+	credited := `{"id":"0000000100-0000000001","paging_token":"100-1","account":"` + testClaimant + `","type":"account_credited","type_i":2,"asset_type":"native","amount":"5.0000000"}`
+	trustline := `{"id":"0000000200-0000000001","paging_token":"200-1","account":"` + testClaimant + `","type":"trustline_created","type_i":20,` +
+		`"asset_type":"credit_alphanum4","asset_code":"USDC","asset_issuer":"` + testIssuer + `","limit":"1000.0000000"}`
+	signer := `{"id":"0000000300-0000000001","paging_token":"300-1","account":"` + testClaimant + `","type":"signer_created","type_i":10,"weight":1,"key":"` + testIssuer + `"}`
+
+	client := newHorizonTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		if r.URL.Query().Get("cursor") != "now" {
+			<-r.Context().Done()
+			return
+		}
+		switch r.URL.Path {
+		case "/accounts/" + testClaimant + "/payments":
+			fmt.Fprintf(w, "id: 100\ndata: %s\n\n", payment)
+		case "/accounts/" + testClaimant + "/effects":
+			fmt.Fprintf(w, "id: 100-1\ndata: %s\n\nid: 200-1\ndata: %s\n\nid: 300-1\ndata: %s\n\n", credited, trustline, signer)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	muxed, err := NewMuxedAddress(testClaimant, 9)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := client.WatchAccount(ctx, muxed.String())
+	require.NoError(t, err)
+
+	byKind := map[AccountEventKind][]AccountEvent{}
+	timeout := time.After(5 * time.Second)
+	for n := 0; n < 3; n++ {
+		select {
+		case ev := <-events:
+			byKind[ev.Kind] = append(byKind[ev.Kind], ev)
+		case <-timeout:
+			t.Fatal("timed out waiting for account events")
+		}
+	}
+
+	// The credited effect duplicates the payment; nothing else should arrive.
+	select {
+	case ev := <-events:
+		t.Fatalf("unexpected extra event %+v", ev)
+	case <-time.After(100 * time.Millisecond):
+	}
+	cancel()
+	for range events {
+	}
+
+	require.Len(t, byKind[AccountPaymentReceived], 1)
+	p := byKind[AccountPaymentReceived][0]
+	assert.Equal(t, "100", p.OperationID)
+	assert.Equal(t, "5.0000000", p.Payment.Amount)
+	assert.True(t, p.Payment.Asset.IsNative())
+
+	require.Len(t, byKind[AccountTrustlineChanged], 1)
+	tl := byKind[AccountTrustlineChanged][0].Trustline
+	assert.Equal(t, "trustline_created", tl.Change)
+	assert.Equal(t, "USDC", tl.Asset.GetCode())
+
+	require.Len(t, byKind[AccountSignerChanged], 1)
+	assert.Equal(t, testIssuer, byKind[AccountSignerChanged][0].Signer.Key)
+}
+
+func TestWatchAccount_InvalidAddress(t *testing.T) {
+	client := &Client{}
+	_, err := client.WatchAccount(context.Background(), "nope")
+	assert.Error(t, err)
+}
+
+func TestDedupeWindow(t *testing.T) {
+	d := newDedupeWindow(2)
+	assert.True(t, d.add("a"))
+	assert.False(t, d.add("a"))
+	assert.True(t, d.add("b"))
+	assert.True(t, d.add("c"))
+	assert.True(t, d.add("a"), "oldest key evicted")
+}
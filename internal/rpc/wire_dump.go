@@ -0,0 +1,250 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redacted replaces secret values in wire dumps.
+const redacted = "[REDACTED]"
+
+// sensitiveHeaders are always redacted, whatever their value.
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+}
+
+// sensitiveNameParts mark header and query parameter names whose values are
+// credentials, such as X-Api-Key or ?access_token=.
+var sensitiveNameParts = []string{"token", "secret", "password", "apikey", "api-key", "api_key", "auth"}
+
+// secretSeedPattern matches Stellar secret seeds, which can appear in bodies
+// of requests to misconfigured or local endpoints.
+var secretSeedPattern = regexp.MustCompile(`\bS[A-Z2-7]{55}\b`)
+
+// wireDumpTransport writes every request it sends and the response it gets
+// back, headers and bodies included, to w. It sits beneath the auth and retry
+// transports, so each attempt is dumped with the headers actually sent, and
+// credentials are redacted before anything is written. Response bodies are
+// dumped as the caller reads them, so limits on their size still apply.
+type wireDumpTransport struct {
+	w         io.Writer
+	secrets   []string
+	transport http.RoundTripper
+
+	mu  sync.Mutex
+	seq int
+}
+
+func newWireDumpTransport(w io.Writer, transport http.RoundTripper, secrets ...string) *wireDumpTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	t := &wireDumpTransport{w: w, transport: transport}
+	for _, s := range secrets {
+		if s != "" {
+			t.secrets = append(t.secrets, s)
+		}
+	}
+	return t
+}
+
+func (t *wireDumpTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := peekRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	t.mu.Lock()
+	t.seq++
+	seq := t.seq
+	t.mu.Unlock()
+
+	start := time.Now()
+	resp, rtErr := t.transport.RoundTrip(req)
+	elapsed := time.Since(start)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "### %d %s\n", seq, start.UTC().Format(time.RFC3339Nano))
+	fmt.Fprintf(&buf, "> %s %s\n", req.Method, t.redactURL(req.URL))
+	t.writeHeaders(&buf, "> ", req.Header)
+	t.writeBody(&buf, reqBody)
+
+	if rtErr != nil {
+		fmt.Fprintf(&buf, "< error after %s: %s\n\n", elapsed.Round(time.Millisecond), t.redactText(rtErr.Error()))
+		t.write(buf.Bytes())
+		return nil, rtErr
+	}
+
+	fmt.Fprintf(&buf, "< %s %s (%s)\n", resp.Proto, resp.Status, elapsed.Round(time.Millisecond))
+	t.writeHeaders(&buf, "< ", resp.Header)
+
+	// Event streams stay open for as long as the caller listens, so only
+	// their headers are dumped.
+	if isEventStream(req.Header.Get("Accept")) || isEventStream(resp.Header.Get("Content-Type")) {
+		buf.WriteString("\n(event stream, body not dumped)\n\n")
+		t.write(buf.Bytes())
+		return resp, nil
+	}
+	resp.Body = &dumpedBody{ReadCloser: resp.Body, t: t, dump: buf}
+	return resp, nil
+}
+
+// dumpedBody copies a response body as the caller reads it and writes the
+// dump once the body is exhausted or closed, so reading is never forced
+// beyond what the caller asks for.
+type dumpedBody struct {
+	io.ReadCloser
+	t *wireDumpTransport
+
+	mu      sync.Mutex
+	dump    bytes.Buffer
+	body    bytes.Buffer
+	readErr error
+	done    bool
+}
+
+func (b *dumpedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.mu.Lock()
+	b.body.Write(p[:n])
+	if err != nil && err != io.EOF {
+		b.readErr = err
+	}
+	b.mu.Unlock()
+	if err != nil {
+		b.flush()
+	}
+	return n, err
+}
+
+func (b *dumpedBody) Close() error {
+	b.flush()
+	return b.ReadCloser.Close()
+}
+
+func (b *dumpedBody) flush() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.done {
+		return
+	}
+	b.done = true
+	b.t.writeBody(&b.dump, b.body.Bytes())
+	if b.readErr != nil {
+		fmt.Fprintf(&b.dump, "< body read error: %s\n", b.readErr)
+	}
+	b.dump.WriteByte('\n')
+	b.t.write(b.dump.Bytes())
+}
+
+func (t *wireDumpTransport) write(p []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, _ = t.w.Write(p) // a failing dump must not fail the request
+}
+
+func (t *wireDumpTransport) writeHeaders(buf *bytes.Buffer, prefix string, h http.Header) {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, v := range h[name] {
+			if isSensitiveName(name) || sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+				v = redacted
+			}
+			fmt.Fprintf(buf, "%s%s: %s\n", prefix, name, t.redactText(v))
+		}
+	}
+}
+
+func (t *wireDumpTransport) writeBody(buf *bytes.Buffer, body []byte) {
+	if len(body) == 0 {
+		return
+	}
+	buf.WriteByte('\n')
+	buf.WriteString(t.redactText(string(body)))
+	if body[len(body)-1] != '\n' {
+		buf.WriteByte('\n')
+	}
+}
+
+func (t *wireDumpTransport) redactURL(u *url.URL) string {
+	c := *u
+	c.User = nil
+	if q := c.Query(); len(q) > 0 {
+		for name, values := range q {
+			if isSensitiveName(name) {
+				for i := range values {
+					values[i] = redacted
+				}
+			}
+		}
+		c.RawQuery = q.Encode()
+	}
+	// Encode escapes the brackets of the placeholder; keep it readable.
+	s := strings.ReplaceAll(c.String(), url.QueryEscape(redacted), redacted)
+	return t.redactText(s)
+}
+
+// redactText removes the client's own secrets and any secret seeds from s.
+func (t *wireDumpTransport) redactText(s string) string {
+	for _, secret := range t.secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	return secretSeedPattern.ReplaceAllString(s, redacted)
+}
+
+func isEventStream(mediaType string) bool {
+	return strings.HasPrefix(mediaType, "text/event-stream")
+}
+
+func isSensitiveName(name string) bool {
+	lower := strings.ToLower(name)
+	for _, part := range sensitiveNameParts {
+		if strings.Contains(lower, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// peekRequestBody returns a copy of req's body, leaving the body itself
+// readable for the transport.
+func peekRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		rc, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return io.ReadAll(rc)
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return body, nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stellar/go-stellar-sdk/keypair"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWireDump(t *testing.T) {
+	seed := keypair.MustRandom().Seed()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=abc")
+		if r.Method == http.MethodPost {
+			_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{"status":"healthy"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"value":"ok"}`))
+	}))
+	defer server.Close()
+
+	var dump bytes.Buffer
+	client, err := NewClient(
+		WithHorizonURL(server.URL),
+		WithSorobanURL(server.URL),
+		WithToken("tok-s3cret"),
+		WithHeaders(map[string]string{"X-Api-Key": "k-123", "X-Client": "erst"}),
+		WithWireDump(&dump),
+	)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	var out struct{ Value string }
+	require.NoError(t, client.getHorizon(ctx, "/fee_stats", url.Values{"access_token": {"q-456"}, "limit": {"2"}}, &out))
+	assert.Equal(t, "ok", out.Value, "the response body is still delivered")
+
+	var health struct{ Status string }
+	require.NoError(t, client.callSoroban(ctx, "getHealth", map[string]string{"note": seed + " tok-s3cret"}, &health))
+	assert.Equal(t, "healthy", health.Status)
+
+	text := dump.String()
+	assert.Contains(t, text, "### 1 ")
+	assert.Contains(t, text, "### 2 ")
+	assert.Contains(t, text, "> GET "+server.URL+"/fee_stats?access_token=[REDACTED]&limit=2")
+	assert.Contains(t, text, "> Authorization: [REDACTED]")
+	assert.Contains(t, text, "> X-Api-Key: [REDACTED]")
+	assert.Contains(t, text, "> X-Client: erst")
+	assert.Contains(t, text, "< HTTP/1.1 200 OK")
+	assert.Contains(t, text, "< Set-Cookie: [REDACTED]")
+	assert.Contains(t, text, `"method":"getHealth"`)
+	assert.Contains(t, text, `{"value":"ok"}`)
+	for _, secret := range []string{"tok-s3cret", "k-123", "q-456", "session=abc", seed} {
+		assert.NotContains(t, text, secret)
+	}
+}
+
+func TestWireDumpStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		writeSSE(w, "100-1", `{"id":"tx1","hash":"h1","paging_token":"100-1"}`)
+		// Keep the stream open, as Horizon does.
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	var dump bytes.Buffer
+	client, err := NewClient(WithNetwork(Testnet), WithHorizonURL(server.URL), WithStreamConfig(fastStreamConfig()), WithWireDump(&dump))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stop := errors.New("stop")
+	var got hProtocol.Transaction
+	err = client.StreamTransactions(ctx, "", func(tx hProtocol.Transaction) error {
+		got = tx
+		return stop
+	})
+	require.ErrorIs(t, err, stop, "the stream delivers events while it is dumped")
+	assert.Equal(t, "h1", got.Hash)
+
+	text := dump.String()
+	assert.Contains(t, text, "> GET "+server.URL+"/transactions?cursor=now")
+	assert.Contains(t, text, "< Content-Type: text/event-stream")
+	assert.Contains(t, text, "(event stream, body not dumped)")
+}
+
+func TestWireDumpBodyReadAsConsumed(t *testing.T) {
+	var dump bytes.Buffer
+	body := strings.Repeat("x", 1000)
+	hc := &http.Client{Transport: newWireDumpTransport(&dump, roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{Proto: "HTTP/1.1", Status: "200 OK", StatusCode: 200, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}, nil
+	}))}
+	resp, err := hc.Get("http://rpc.invalid/")
+	require.NoError(t, err)
+	assert.Empty(t, dump.String(), "nothing is dumped before the body is read")
+
+	part := make([]byte, 10)
+	_, err = io.ReadFull(resp.Body, part)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Contains(t, dump.String(), "\n"+strings.Repeat("x", 10)+"\n", "only what the caller read is dumped")
+	assert.NotContains(t, dump.String(), strings.Repeat("x", 11))
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+type failingTransport struct{}
+
+func (failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("dial tcp: connection refused")
+}
+
+func TestWireDumpTransportError(t *testing.T) {
+	var dump bytes.Buffer
+	hc := &http.Client{Transport: newWireDumpTransport(&dump, failingTransport{})}
+	req, err := http.NewRequest(http.MethodPost, "http://rpc.invalid/", strings.NewReader(`{"a":1}`))
+	require.NoError(t, err)
+	_, err = hc.Do(req)
+	require.Error(t, err)
+	assert.Contains(t, dump.String(), "> POST http://rpc.invalid/")
+	assert.Contains(t, dump.String(), `{"a":1}`)
+	assert.Contains(t, dump.String(), "< error after")
+}
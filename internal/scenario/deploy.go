@@ -0,0 +1,81 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package scenario
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/network"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// uploadOp installs wasm on the network. Its code is then addressed by
+// its SHA-256 hash.
+func uploadOp(wasm []byte) *txnbuild.InvokeHostFunction {
+	return &txnbuild.InvokeHostFunction{
+		HostFunction: xdr.HostFunction{
+			Type: xdr.HostFunctionTypeHostFunctionTypeUploadContractWasm,
+			Wasm: &wasm,
+		},
+	}
+}
+
+// createOp instantiates the uploaded code with hash wasmHash, deployed by
+// from with a random salt, and returns the operation with the address the
+// contract will have on the network with passphrase.
+func createOp(from string, wasmHash xdr.Hash, passphrase string) (*txnbuild.InvokeHostFunction, string, error) {
+	deployer, err := xdr.AddressToAccountId(from)
+	if err != nil {
+		return nil, "", errors.WrapValidationError("invalid deployer " + from)
+	}
+	var salt xdr.Uint256
+	if _, err := rand.Read(salt[:]); err != nil {
+		return nil, "", err
+	}
+	preimage := xdr.ContractIdPreimage{
+		Type: xdr.ContractIdPreimageTypeContractIdPreimageFromAddress,
+		FromAddress: &xdr.ContractIdPreimageFromAddress{
+			Address: xdr.ScAddress{Type: xdr.ScAddressTypeScAddressTypeAccount, AccountId: &deployer},
+			Salt:    salt,
+		},
+	}
+	id, err := contractID(preimage, passphrase)
+	if err != nil {
+		return nil, "", err
+	}
+	return &txnbuild.InvokeHostFunction{
+		HostFunction: xdr.HostFunction{
+			Type: xdr.HostFunctionTypeHostFunctionTypeCreateContract,
+			CreateContract: &xdr.CreateContractArgs{
+				ContractIdPreimage: preimage,
+				Executable: xdr.ContractExecutable{
+					Type:     xdr.ContractExecutableTypeContractExecutableWasm,
+					WasmHash: &wasmHash,
+				},
+			},
+		},
+	}, id, nil
+}
+
+// contractID derives the address of the contract created from preimage,
+// as the host does.
+func contractID(preimage xdr.ContractIdPreimage, passphrase string) (string, error) {
+	full := xdr.HashIdPreimage{
+		Type: xdr.EnvelopeTypeEnvelopeTypeContractId,
+		ContractId: &xdr.HashIdPreimageContractId{
+			NetworkId:          network.ID(passphrase),
+			ContractIdPreimage: preimage,
+		},
+	}
+	payload, err := full.MarshalBinary()
+	if err != nil {
+		return "", errors.WrapMarshalFailed(err)
+	}
+	hash := sha256.Sum256(payload)
+	return strkey.Encode(strkey.VersionByteContract, hash[:])
+}
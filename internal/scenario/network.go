@@ -0,0 +1,202 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/dotandev/hintents/internal/intent"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/rpc/rpctest"
+	"github.com/stellar/go-stellar-sdk/amount"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/protocols/horizon/base"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// Network is where a scenario runs: a client for it and a way to create
+// funded accounts.
+type Network interface {
+	Client() *rpc.Client
+	// Fund creates address with a starting balance of xlm.
+	Fund(ctx context.Context, address, xlm string) error
+}
+
+// LiveNetwork is a real test network whose accounts friendbot funds.
+type LiveNetwork struct {
+	client *rpc.Client
+	policy intent.RetryPolicy
+}
+
+// NewLiveNetwork runs scenarios with client, which must point at a network
+// with friendbot, such as testnet or a quickstart container.
+func NewLiveNetwork(client *rpc.Client) *LiveNetwork {
+	return &LiveNetwork{client: client}
+}
+
+// Testnet returns a LiveNetwork on the public testnet.
+func Testnet() (*LiveNetwork, error) {
+	client, err := rpc.NewClient(rpc.WithNetwork(rpc.Testnet))
+	if err != nil {
+		return nil, err
+	}
+	return NewLiveNetwork(client), nil
+}
+
+func (n *LiveNetwork) Client() *rpc.Client { return n.client }
+
+// Fund creates address with friendbot, which decides the balance.
+func (n *LiveNetwork) Fund(ctx context.Context, address, _ string) error {
+	_, err := intent.Onboard(ctx, n.client, &intent.AccountIntent{Account: address}, nil, n.policy)
+	return err
+}
+
+// FakeNetwork runs scenarios against in-process fakes of Horizon and
+// Soroban RPC. Classic payments, account creation and trustline changes
+// are applied to the fake accounts, fees included, so balance assertions
+// behave as on a real network. Soroban steps need simulateTransaction
+// scripted on Soroban, and report no events.
+type FakeNetwork struct {
+	Horizon *rpctest.HorizonServer
+	Soroban *rpctest.SorobanServer
+
+	client *rpc.Client
+	mu     sync.Mutex
+}
+
+// NewFakeNetwork starts the fakes. Callers must Close it.
+func NewFakeNetwork() (*FakeNetwork, error) {
+	n := &FakeNetwork{Horizon: rpctest.NewHorizonServer(), Soroban: rpctest.NewSorobanServer()}
+	n.Horizon.Apply = n.apply
+	client, err := rpc.NewClient(
+		rpc.WithNetwork(rpc.Testnet),
+		rpc.WithHorizonURL(n.Horizon.URL),
+		rpc.WithSorobanURL(n.Soroban.URL),
+	)
+	if err != nil {
+		n.Close()
+		return nil, err
+	}
+	n.client = client
+	return n, nil
+}
+
+func (n *FakeNetwork) Client() *rpc.Client { return n.client }
+
+// Close stops the fakes.
+func (n *FakeNetwork) Close() {
+	n.Horizon.Close()
+	n.Soroban.Close()
+}
+
+// Fund creates address holding xlm, with its master key as sole signer.
+func (n *FakeNetwork) Fund(_ context.Context, address, xlm string) error {
+	if _, err := amount.ParseInt64(xlm); err != nil {
+		return fmt.Errorf("invalid starting balance %q: %w", xlm, err)
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.Horizon.AddAccount(newFakeAccount(address, xlm))
+	return nil
+}
+
+func newFakeAccount(address, xlm string) hProtocol.Account {
+	return hProtocol.Account{
+		AccountID: address,
+		Sequence:  1,
+		Balances:  []hProtocol.Balance{{Balance: xlm, Asset: base.Asset{Type: "native"}}},
+		Signers:   []hProtocol.Signer{{Key: address, Type: "ed25519_public_key", Weight: 1}},
+	}
+}
+
+// apply updates the fake accounts for an accepted transaction.
+func (n *FakeNetwork) apply(env xdr.TransactionEnvelope) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	source := env.SourceAccount().ToAccountId().Address()
+	feeSource := source
+	if env.IsFeeBump() {
+		feeSource = env.FeeBumpAccount().ToAccountId().Address()
+	}
+	n.credit(feeSource, xdr.MustNewNativeAsset(), -int64(env.Fee()))
+
+	if acc, ok := n.Horizon.Account(source); ok {
+		acc.Sequence = env.SeqNum()
+		n.Horizon.AddAccount(acc)
+	}
+	for _, op := range env.Operations() {
+		opSource := source
+		if op.SourceAccount != nil {
+			opSource = op.SourceAccount.ToAccountId().Address()
+		}
+		switch body := op.Body; body.Type {
+		case xdr.OperationTypeCreateAccount:
+			created := body.CreateAccountOp
+			n.credit(opSource, xdr.MustNewNativeAsset(), -int64(created.StartingBalance))
+			n.Horizon.AddAccount(newFakeAccount(created.Destination.Address(), amount.String(created.StartingBalance)))
+		case xdr.OperationTypePayment:
+			pay := body.PaymentOp
+			n.credit(opSource, pay.Asset, -int64(pay.Amount))
+			n.credit(pay.Destination.ToAccountId().Address(), pay.Asset, int64(pay.Amount))
+		case xdr.OperationTypeChangeTrust:
+			n.changeTrust(opSource, body.ChangeTrustOp)
+		}
+	}
+}
+
+// credit adds delta stroops of asset to address's balance. The issuer of
+// an asset holds no balance of it.
+func (n *FakeNetwork) credit(address string, asset xdr.Asset, delta int64) {
+	acc, ok := n.Horizon.Account(address)
+	if !ok {
+		return
+	}
+	var typ, code, issuer string
+	if err := asset.Extract(&typ, &code, &issuer); err != nil || issuer == address {
+		return
+	}
+	for i, b := range acc.Balances {
+		if b.Asset.Code == code && b.Asset.Issuer == issuer {
+			current, _ := amount.ParseInt64(b.Balance)
+			acc.Balances[i].Balance = amount.StringFromInt64(current + delta)
+			n.Horizon.AddAccount(acc)
+			return
+		}
+	}
+}
+
+func (n *FakeNetwork) changeTrust(address string, op *xdr.ChangeTrustOp) {
+	acc, ok := n.Horizon.Account(address)
+	if !ok || op.Line.Type == xdr.AssetTypeAssetTypePoolShare {
+		return
+	}
+	var typ, code, issuer string
+	if err := op.Line.ToAsset().Extract(&typ, &code, &issuer); err != nil {
+		return
+	}
+	for i, b := range acc.Balances {
+		if b.Asset.Code != code || b.Asset.Issuer != issuer {
+			continue
+		}
+		if op.Limit == 0 {
+			acc.Balances = append(acc.Balances[:i], acc.Balances[i+1:]...)
+		} else {
+			acc.Balances[i].Limit = amount.String(op.Limit)
+		}
+		n.Horizon.AddAccount(acc)
+		return
+	}
+	acc.Balances = append(acc.Balances, hProtocol.Balance{
+		Balance:            "0.0000000",
+		Limit:              amount.String(op.Limit),
+		IsAuthorized:       &[]bool{true}[0],
+		Asset:              base.Asset{Type: typ, Code: code, Issuer: issuer},
+		BuyingLiabilities:  "0.0000000",
+		SellingLiabilities: "0.0000000",
+	})
+	n.Horizon.AddAccount(acc)
+}
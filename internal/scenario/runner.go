@@ -0,0 +1,405 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package scenario
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/intent"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/stellar/go-stellar-sdk/amount"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	hProtocol "github.com/stellar/go-stellar-sdk/protocols/horizon"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// TestnetEnv is the environment variable that lets RunFile run scenarios
+// declaring network: testnet. Without it they are skipped.
+const TestnetEnv = "ERST_SCENARIO_TESTNET"
+
+// Runner executes scenarios on a Network.
+type Runner struct {
+	Network Network
+	// Poll controls the wait for each transaction; rpc.DefaultPollConfig
+	// when zero.
+	Poll rpc.PollConfig
+}
+
+// Report is the outcome of a run.
+type Report struct {
+	// Accounts and Contracts map the scenario's names to the addresses
+	// they had in this run.
+	Accounts  map[string]string
+	Contracts map[string]string
+	Steps     []StepReport
+}
+
+// StepReport is the outcome of one step.
+type StepReport struct {
+	Name string
+	// Hashes of the transactions the step submitted; deployments submit
+	// two.
+	Hashes []string
+	// Err is why the step did not do what it was expected to.
+	Err error
+}
+
+// run is the state of one scenario run.
+type run struct {
+	*Runner
+	sc      *Scenario
+	client  *rpc.Client
+	keys    map[string]*keypair.Full
+	report  *Report
+	current *StepReport
+}
+
+// Run funds the scenario's accounts and executes its steps in order,
+// stopping at the first step that does not meet its expectation. The
+// report covers every step attempted and is returned with that step's
+// error.
+func (r *Runner) Run(ctx context.Context, sc *Scenario) (*Report, error) {
+	if r.Network == nil {
+		return nil, errors.WrapValidationError("scenario network is required")
+	}
+	x := &run{
+		Runner: r,
+		sc:     sc,
+		client: r.Network.Client(),
+		keys:   map[string]*keypair.Full{},
+		report: &Report{Accounts: map[string]string{}, Contracts: map[string]string{}},
+	}
+	for _, name := range sc.accountNames() {
+		kp := keypair.MustRandom()
+		if err := r.Network.Fund(ctx, kp.Address(), sc.Accounts[name].Fund); err != nil {
+			return x.report, fmt.Errorf("fund %s: %w", name, err)
+		}
+		x.keys[name] = kp
+		x.report.Accounts[name] = kp.Address()
+	}
+
+	for i, st := range sc.Steps {
+		x.report.Steps = append(x.report.Steps, StepReport{Name: st.label(i)})
+		x.current = &x.report.Steps[len(x.report.Steps)-1]
+		if err := x.step(ctx, st); err != nil {
+			x.current.Err = err
+			return x.report, fmt.Errorf("%s: %w", st.label(i), err)
+		}
+	}
+	return x.report, nil
+}
+
+func (x *run) step(ctx context.Context, st Step) error {
+	switch {
+	case st.Pay != nil:
+		asset, err := x.asset(st.Pay.Asset)
+		if err != nil {
+			return err
+		}
+		return x.submit(ctx, st.Expect, &intent.PaymentIntent{
+			From:   x.address(st.Pay.From),
+			To:     x.address(st.Pay.To),
+			Asset:  asset,
+			Amount: st.Pay.Amount,
+		}, st.Pay.From)
+	case st.Trust != nil:
+		asset, err := x.asset(st.Trust.Asset)
+		if err != nil {
+			return err
+		}
+		return x.submit(ctx, st.Expect, &intent.TrustlineIntent{
+			Account: x.address(st.Trust.Account),
+			Asset:   asset,
+			Limit:   st.Trust.Limit,
+		}, st.Trust.Account)
+	case st.Deploy != nil:
+		return x.deploy(ctx, st.Deploy, st.Expect)
+	case st.Invoke != nil:
+		args, _ := x.resolve(st.Invoke.Args).(map[string]interface{})
+		in, err := intent.InvokeByName(ctx, x.client, x.address(st.Invoke.From), x.address(st.Invoke.Contract), st.Invoke.Function, args)
+		if err != nil {
+			return err
+		}
+		return x.submit(ctx, st.Expect, in, st.Invoke.From)
+	default:
+		return x.assert(ctx, st.Assert)
+	}
+}
+
+func (x *run) deploy(ctx context.Context, d *DeployStep, expect *Expect) error {
+	path := d.Wasm
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(x.sc.dir, path)
+	}
+	wasm, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	from := x.address(d.From)
+	// Uploading the same code twice is a no-op, so only creation is held
+	// to the step's expectation.
+	if _, err := x.send(ctx, intent.NewOps(from, uploadOp(wasm)), d.From); err != nil {
+		return fmt.Errorf("upload %s: %w", d.Wasm, err)
+	}
+	op, id, err := createOp(from, xdr.Hash(sha256.Sum256(wasm)), x.client.GetNetworkPassphrase())
+	if err != nil {
+		return err
+	}
+	if err := x.submit(ctx, expect, intent.NewOps(from, op), d.From); err != nil {
+		return err
+	}
+	x.report.Contracts[d.As] = id
+	return nil
+}
+
+// submit sends in signed by signer and checks the outcome against expect.
+func (x *run) submit(ctx context.Context, expect *Expect, in intent.Intent, signer string) error {
+	tx, err := x.send(ctx, in, signer)
+	if expect == nil {
+		expect = &Expect{}
+	}
+	if expect.Success != nil && !*expect.Success {
+		if err == nil {
+			return fmt.Errorf("expected failure, but transaction %s succeeded", tx.Hash)
+		}
+		if expect.Error != "" && !strings.Contains(err.Error(), expect.Error) {
+			return fmt.Errorf("expected error containing %q, got: %w", expect.Error, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return x.checkEvents(tx, expect.Events)
+}
+
+func (x *run) send(ctx context.Context, in intent.Intent, signer string) (*hProtocol.Transaction, error) {
+	res, err := intent.SubmitWithRetry(ctx, x.client, in,
+		[]intent.Signer{intent.LocalSignerFromKeypair(x.keys[signer])},
+		intent.RetryPolicy{Poll: x.Poll}, intent.WithBaseFee(x.sc.BaseFee))
+	if res != nil && len(res.Attempts) > 0 {
+		if hash := res.Attempts[len(res.Attempts)-1].Hash; hash != "" {
+			x.current.Hashes = append(x.current.Hashes, hash)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res.Tx, nil
+}
+
+func (x *run) checkEvents(tx *hProtocol.Transaction, want []EventExpect) error {
+	if len(want) == 0 {
+		return nil
+	}
+	meta, err := decoder.AnalyzeMeta(tx.ResultMetaXdr)
+	if err != nil {
+		return fmt.Errorf("decode events of %s: %w", tx.Hash, err)
+	}
+	var got []decoder.DecodedEvent
+	if meta.Soroban != nil {
+		got = append(got, meta.Soroban.Events...)
+	}
+	for _, op := range meta.Operations {
+		got = append(got, op.Events...)
+	}
+	for _, w := range want {
+		if !x.emitted(got, w) {
+			return fmt.Errorf("no event matching contract=%q topics=%v data=%q among %d emitted", w.Contract, w.Topics, w.Data, len(got))
+		}
+	}
+	return nil
+}
+
+func (x *run) emitted(events []decoder.DecodedEvent, w EventExpect) bool {
+	for _, e := range events {
+		if w.Contract != "" && e.ContractID != x.address(w.Contract) {
+			continue
+		}
+		if len(w.Topics) > len(e.Topics) || (w.Data != "" && w.Data != e.Data) {
+			continue
+		}
+		match := true
+		for i, topic := range w.Topics {
+			if x.address(topic) != e.Topics[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+func (x *run) assert(ctx context.Context, a *AssertStep) error {
+	var failures []string
+	for _, name := range sortedKeys(a.Balances) {
+		acc, err := x.client.AccountDetails(ctx, x.address(name))
+		if err != nil {
+			return err
+		}
+		for _, assetName := range sortedKeys(a.Balances[name]) {
+			asset, err := x.asset(assetName)
+			if err != nil {
+				return err
+			}
+			want := a.Balances[name][assetName]
+			got, ok := balanceOf(acc, asset)
+			if !ok {
+				failures = append(failures, fmt.Sprintf("%s holds no %s, want %s", name, assetName, want))
+				continue
+			}
+			if err := compareAmount(got, want); err != nil {
+				failures = append(failures, fmt.Sprintf("%s balance of %s: %v", name, assetName, err))
+			}
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("%s", strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+func balanceOf(acc *rpc.AccountDetails, asset txnbuild.Asset) (string, bool) {
+	for _, b := range acc.Balances {
+		if b.Asset == nil || b.Asset.IsNative() != asset.IsNative() {
+			continue
+		}
+		if asset.IsNative() || (b.Asset.GetCode() == asset.GetCode() && b.Asset.GetIssuer() == asset.GetIssuer()) {
+			return b.Balance, true
+		}
+	}
+	return "", false
+}
+
+// compareAmount checks got against want, an amount optionally prefixed
+// with a comparison operator.
+func compareAmount(got, want string) error {
+	op, value := "=", strings.TrimSpace(want)
+	for _, prefix := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(value, prefix) {
+			op, value = prefix, strings.TrimSpace(value[len(prefix):])
+			break
+		}
+	}
+	w, err := amount.ParseInt64(value)
+	if err != nil {
+		return fmt.Errorf("invalid expected amount %q", want)
+	}
+	g, err := amount.ParseInt64(got)
+	if err != nil {
+		return fmt.Errorf("invalid balance %q", got)
+	}
+	ok := map[string]bool{"=": g == w, ">=": g >= w, "<=": g <= w, ">": g > w, "<": g < w}[op]
+	if !ok {
+		return fmt.Errorf("got %s, want %s", got, want)
+	}
+	return nil
+}
+
+// asset parses native, XLM or CODE:ISSUER, where ISSUER may be an account
+// name.
+func (x *run) asset(s string) (txnbuild.Asset, error) {
+	if s == "" || strings.EqualFold(s, "native") || s == "XLM" {
+		return txnbuild.NativeAsset{}, nil
+	}
+	code, issuer, ok := strings.Cut(s, ":")
+	if !ok || code == "" || issuer == "" {
+		return nil, errors.WrapValidationError(fmt.Sprintf("invalid asset %q; use native or CODE:ISSUER", s))
+	}
+	return txnbuild.CreditAsset{Code: code, Issuer: x.address(issuer)}, nil
+}
+
+// address returns the address of the account or contract called name, or
+// name itself when it names neither.
+func (x *run) address(name string) string {
+	if addr, ok := x.report.Accounts[name]; ok {
+		return addr
+	}
+	if addr, ok := x.report.Contracts[name]; ok {
+		return addr
+	}
+	return name
+}
+
+// resolve replaces account and contract names among invocation arguments
+// with their addresses.
+func (x *run) resolve(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		return x.address(v)
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = x.resolve(item)
+		}
+		return out
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			out[k] = x.resolve(item)
+		}
+		return out
+	}
+	return v
+}
+
+// RunFile loads the scenario at path and runs it on the network it
+// declares, failing t on error. Fake-network scenarios run in-process;
+// testnet scenarios are skipped unless ERST_SCENARIO_TESTNET is set.
+func RunFile(t testing.TB, path string) *Report {
+	t.Helper()
+	sc, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var network Network
+	switch sc.Network {
+	case NetworkTestnet:
+		if os.Getenv(TestnetEnv) == "" {
+			t.Skipf("set %s=1 to run testnet scenarios", TestnetEnv)
+		}
+		if network, err = Testnet(); err != nil {
+			t.Fatal(err)
+		}
+	default:
+		fake, err := NewFakeNetwork()
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(fake.Close)
+		network = fake
+	}
+
+	report, err := (&Runner{Network: network}).Run(context.Background(), sc)
+	for _, st := range report.Steps {
+		t.Logf("%s: %v", st.Name, st.Hashes)
+	}
+	if err != nil {
+		t.Fatalf("scenario %s: %v", sc.Name, err)
+	}
+	return report
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
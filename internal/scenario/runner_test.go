@@ -0,0 +1,88 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package scenario
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dotandev/hintents/internal/decoder"
+	"github.com/dotandev/hintents/internal/rpc"
+	"github.com/dotandev/hintents/internal/rpc/rpctest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var fastPoll = rpc.PollConfig{Interval: 5 * time.Millisecond, Timeout: 5 * time.Second}
+
+func newFake(t *testing.T) *FakeNetwork {
+	t.Helper()
+	n, err := NewFakeNetwork()
+	require.NoError(t, err)
+	t.Cleanup(n.Close)
+	return n
+}
+
+func TestRunFile(t *testing.T) {
+	report := RunFile(t, "testdata/scenarios/issue_and_pay.yaml")
+	require.Len(t, report.Steps, 4)
+	assert.Len(t, report.Steps[0].Hashes, 1)
+	assert.Empty(t, report.Steps[3].Hashes, "asserts submit nothing")
+	assert.Len(t, report.Accounts, 3)
+}
+
+func TestRunFailingAssertion(t *testing.T) {
+	n := newFake(t)
+	sc, err := Parse([]byte(`
+accounts:
+  alice: {fund: "100"}
+  bob: {fund: "100"}
+steps:
+  - pay: {from: alice, to: bob, amount: "5"}
+  - name: wrong balance
+    assert: {balances: {bob: {native: "100"}}}
+`))
+	require.NoError(t, err)
+
+	report, err := (&Runner{Network: n, Poll: fastPoll}).Run(context.Background(), sc)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "step 2 (wrong balance)")
+	assert.Contains(t, err.Error(), "got 105.0000000, want 100")
+	require.Len(t, report.Steps, 2)
+	assert.NoError(t, report.Steps[0].Err)
+	assert.Error(t, report.Steps[1].Err)
+}
+
+func TestRunExpectedFailure(t *testing.T) {
+	n := newFake(t)
+	sc, err := Parse([]byte(`
+accounts:
+  alice: {fund: "100"}
+  bob: {fund: "100"}
+steps:
+  - pay: {from: alice, to: bob, amount: "5"}
+    expect: {success: false, error: tx_failed}
+  - pay: {from: alice, to: bob, amount: "5"}
+    expect: {success: false}
+`))
+	require.NoError(t, err)
+	n.Horizon.QueueSubmitResult(rpctest.SubmitResult{ResultCode: "tx_failed", OperationCodes: []string{"op_underfunded"}})
+
+	_, err = (&Runner{Network: n, Poll: fastPoll}).Run(context.Background(), sc)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "step 2: expected failure")
+}
+
+func TestRunEventExpectation(t *testing.T) {
+	x := &run{report: &Report{
+		Accounts:  map[string]string{"alice": "GALICE"},
+		Contracts: map[string]string{"token": "CTOKEN"},
+	}}
+	events := []decoder.DecodedEvent{{ContractID: "CTOKEN", Topics: []string{"transfer", "GALICE", "GBOB"}, Data: "5"}}
+	assert.True(t, x.emitted(events, EventExpect{Contract: "token", Topics: []string{"transfer", "alice"}}))
+	assert.True(t, x.emitted(events, EventExpect{Data: "5"}))
+	assert.False(t, x.emitted(events, EventExpect{Contract: "token", Topics: []string{"mint"}}))
+	assert.False(t, x.emitted(events, EventExpect{Contract: "alice"}))
+}
@@ -0,0 +1,256 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+// Package scenario runs end-to-end regression scenarios described in YAML:
+// accounts to fund, then a sequence of payments, trustlines, contract
+// deployments and invocations, each built and submitted through the
+// intent pipeline, with expectations on their outcome, the events they
+// emit and the balances they leave behind.
+//
+//	name: pay bob
+//	accounts:
+//	  alice: {fund: "1000"}
+//	  bob: {fund: "100"}
+//	steps:
+//	  - name: alice pays bob
+//	    pay: {from: alice, to: bob, amount: "25"}
+//	  - assert:
+//	      balances:
+//	        bob: {native: "125"}
+//
+// Accounts are created fresh for every run; steps refer to them, and to
+// deployed contracts, by name.
+package scenario
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Networks a scenario can ask to run on.
+const (
+	NetworkFake    = "fake"
+	NetworkTestnet = "testnet"
+)
+
+// DefaultBaseFee is the inclusion fee per operation, in stroops, when a
+// scenario does not set one.
+const DefaultBaseFee = 100
+
+// Scenario is a parsed scenario file.
+type Scenario struct {
+	Name string `yaml:"name"`
+	// Network is fake (the default) or testnet.
+	Network string `yaml:"network"`
+	// BaseFee is the inclusion fee per operation in stroops.
+	BaseFee  int64              `yaml:"base_fee"`
+	Accounts map[string]Account `yaml:"accounts"`
+	Steps    []Step             `yaml:"steps"`
+
+	// dir is the directory of the scenario file, for relative wasm paths.
+	dir string
+}
+
+// Account is a test account created for the run.
+type Account struct {
+	// Fund is the starting XLM balance. On testnet friendbot decides the
+	// amount and Fund only needs to be non-empty.
+	Fund string `yaml:"fund"`
+}
+
+// Step is one action, set by exactly one of its action fields, and what
+// it is expected to do.
+type Step struct {
+	Name   string      `yaml:"name"`
+	Pay    *PayStep    `yaml:"pay"`
+	Trust  *TrustStep  `yaml:"trust"`
+	Deploy *DeployStep `yaml:"deploy"`
+	Invoke *InvokeStep `yaml:"invoke"`
+	Assert *AssertStep `yaml:"assert"`
+	Expect *Expect     `yaml:"expect"`
+}
+
+// PayStep sends Amount of Asset, native by default, from From to To.
+// Assets are written native or CODE:ISSUER, where ISSUER may be an account
+// name.
+type PayStep struct {
+	From   string `yaml:"from"`
+	To     string `yaml:"to"`
+	Amount string `yaml:"amount"`
+	Asset  string `yaml:"asset"`
+}
+
+// TrustStep adds Account's trustline to Asset, up to Limit.
+type TrustStep struct {
+	Account string `yaml:"account"`
+	Asset   string `yaml:"asset"`
+	Limit   string `yaml:"limit"`
+}
+
+// DeployStep uploads the contract in Wasm, relative to the scenario file,
+// and creates an instance of it from From, which later steps call As.
+type DeployStep struct {
+	From string `yaml:"from"`
+	Wasm string `yaml:"wasm"`
+	As   string `yaml:"as"`
+}
+
+// InvokeStep calls Function on Contract with arguments keyed by parameter
+// name. String arguments naming an account or contract are replaced with
+// its address.
+type InvokeStep struct {
+	From     string                 `yaml:"from"`
+	Contract string                 `yaml:"contract"`
+	Function string                 `yaml:"function"`
+	Args     map[string]interface{} `yaml:"args"`
+}
+
+// AssertStep checks state without submitting anything. Balances maps an
+// account to asset amounts, each exact or prefixed with one of >=, <=, >
+// or <, such as ">= 99.99".
+type AssertStep struct {
+	Balances map[string]map[string]string `yaml:"balances"`
+}
+
+// Expect is what a submitting step must do. Without it the step must
+// succeed.
+type Expect struct {
+	// Success defaults to true.
+	Success *bool `yaml:"success"`
+	// Error must appear in the failure message of a failing step.
+	Error string `yaml:"error"`
+	// Events must each match an event the transaction emitted.
+	Events []EventExpect `yaml:"events"`
+}
+
+// EventExpect matches a contract event. Contract and topics may name
+// accounts and contracts. Topics are compared in order and may be a
+// prefix of the event's; Data is compared when set.
+type EventExpect struct {
+	Contract string   `yaml:"contract"`
+	Topics   []string `yaml:"topics"`
+	Data     string   `yaml:"data"`
+}
+
+// Load reads and parses the scenario file at path.
+func Load(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	sc, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	sc.dir = filepath.Dir(path)
+	return sc, nil
+}
+
+// Parse parses and validates a scenario. Unknown fields are errors, so
+// typos in a scenario do not silently skip a check.
+func Parse(data []byte) (*Scenario, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var sc Scenario
+	if err := dec.Decode(&sc); err != nil {
+		return nil, errors.WrapValidationError(fmt.Sprintf("invalid scenario: %v", err))
+	}
+	if sc.Network == "" {
+		sc.Network = NetworkFake
+	}
+	if sc.BaseFee == 0 {
+		sc.BaseFee = DefaultBaseFee
+	}
+	if err := sc.Validate(); err != nil {
+		return nil, err
+	}
+	return &sc, nil
+}
+
+// Validate checks that every step has exactly one action and refers only
+// to declared accounts and previously deployed contracts.
+func (sc *Scenario) Validate() error {
+	if sc.Network != NetworkFake && sc.Network != NetworkTestnet {
+		return errors.WrapValidationError(fmt.Sprintf("unknown network %q; use %s or %s", sc.Network, NetworkFake, NetworkTestnet))
+	}
+	if len(sc.Steps) == 0 {
+		return errors.WrapValidationError("scenario has no steps")
+	}
+	for _, name := range sc.accountNames() {
+		if sc.Accounts[name].Fund == "" {
+			return errors.WrapValidationError(fmt.Sprintf("account %s: fund is required", name))
+		}
+	}
+
+	contracts := map[string]bool{}
+	account := func(i int, field, name string) error {
+		if _, ok := sc.Accounts[name]; !ok {
+			return errors.WrapValidationError(fmt.Sprintf("step %d: %s %q is not a declared account", i+1, field, name))
+		}
+		return nil
+	}
+	for i, st := range sc.Steps {
+		if n := st.actions(); n != 1 {
+			return errors.WrapValidationError(fmt.Sprintf("step %d: want exactly one of pay, trust, deploy, invoke or assert, got %d", i+1, n))
+		}
+		var err error
+		switch {
+		case st.Pay != nil:
+			if err = account(i, "from", st.Pay.From); err == nil {
+				err = account(i, "to", st.Pay.To)
+			}
+		case st.Trust != nil:
+			err = account(i, "account", st.Trust.Account)
+		case st.Deploy != nil:
+			if err = account(i, "from", st.Deploy.From); err == nil && (st.Deploy.Wasm == "" || st.Deploy.As == "") {
+				err = errors.WrapValidationError(fmt.Sprintf("step %d: deploy needs wasm and as", i+1))
+			}
+			contracts[st.Deploy.As] = true
+		case st.Invoke != nil:
+			err = account(i, "from", st.Invoke.From)
+			if err == nil && st.Invoke.Function == "" {
+				err = errors.WrapValidationError(fmt.Sprintf("step %d: invoke needs a function", i+1))
+			}
+		case st.Assert != nil:
+			for name := range st.Assert.Balances {
+				if err = account(i, "balance of", name); err != nil {
+					break
+				}
+			}
+			if err == nil && st.Expect != nil {
+				err = errors.WrapValidationError(fmt.Sprintf("step %d: assert steps take no expect", i+1))
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (st Step) actions() int {
+	n := 0
+	for _, set := range []bool{st.Pay != nil, st.Trust != nil, st.Deploy != nil, st.Invoke != nil, st.Assert != nil} {
+		if set {
+			n++
+		}
+	}
+	return n
+}
+
+// label names the step in reports.
+func (st Step) label(i int) string {
+	if st.Name != "" {
+		return fmt.Sprintf("step %d (%s)", i+1, st.Name)
+	}
+	return fmt.Sprintf("step %d", i+1)
+}
+
+func (sc *Scenario) accountNames() []string {
+	return sortedKeys(sc.Accounts)
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package scenario
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDefaults(t *testing.T) {
+	sc, err := Parse([]byte(`
+name: minimal
+accounts:
+  alice: {fund: "10"}
+steps:
+  - assert: {balances: {alice: {native: "10"}}}
+`))
+	require.NoError(t, err)
+	assert.Equal(t, NetworkFake, sc.Network)
+	assert.Equal(t, int64(DefaultBaseFee), sc.BaseFee)
+	assert.Equal(t, "step 1", sc.Steps[0].label(0))
+}
+
+func TestParseRejects(t *testing.T) {
+	tests := []struct {
+		name, yaml, want string
+	}{
+		{"unknown field", "steps:\n  - pay: {from: a, to: b, amount: '1', memo: x}\n", "memo"},
+		{"no steps", "accounts:\n  a: {fund: '1'}\n", "no steps"},
+		{"unknown network", "network: mainnet\nsteps:\n  - assert: {}\n", "unknown network"},
+		{"missing fund", "accounts:\n  a: {}\nsteps:\n  - assert: {}\n", "fund is required"},
+		{"two actions", "accounts:\n  a: {fund: '1'}\nsteps:\n  - trust: {account: a, asset: 'X:a'}\n    assert: {}\n", "exactly one"},
+		{"undeclared account", "accounts:\n  a: {fund: '1'}\nsteps:\n  - pay: {from: a, to: b, amount: '1'}\n", `"b" is not a declared account`},
+		{"deploy without name", "accounts:\n  a: {fund: '1'}\nsteps:\n  - deploy: {from: a, wasm: x.wasm}\n", "deploy needs wasm and as"},
+		{"assert with expect", "accounts:\n  a: {fund: '1'}\nsteps:\n  - assert: {}\n    expect: {success: false}\n", "take no expect"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.yaml))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestCompareAmount(t *testing.T) {
+	assert.NoError(t, compareAmount("10.0000000", "10"))
+	assert.NoError(t, compareAmount("10.5", ">= 10"))
+	assert.NoError(t, compareAmount("9", "< 10"))
+	assert.Error(t, compareAmount("10", "> 10"))
+	assert.Error(t, compareAmount("10", "ten"))
+}
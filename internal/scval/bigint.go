@@ -0,0 +1,149 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package scval
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+var (
+	maxU64  = new(big.Int).SetUint64(^uint64(0))
+	maxI128 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 127), big.NewInt(1))
+	minI128 = new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 127))
+	maxU128 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	maxI256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(1))
+	minI256 = new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 255))
+	maxU256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+)
+
+// I128 encodes v as an I128, failing if it is out of range.
+func I128(v *big.Int) (xdr.ScVal, error) {
+	if err := checkRange(v, minI128, maxI128, "i128"); err != nil {
+		return xdr.ScVal{}, err
+	}
+	words := signedWords(v, 2)
+	parts := xdr.Int128Parts{Hi: xdr.Int64(int64(words[0])), Lo: xdr.Uint64(words[1])}
+	return xdr.ScVal{Type: xdr.ScValTypeScvI128, I128: &parts}, nil
+}
+
+// U128 encodes v as a U128, failing if it is negative or too large.
+func U128(v *big.Int) (xdr.ScVal, error) {
+	if err := checkRange(v, new(big.Int), maxU128, "u128"); err != nil {
+		return xdr.ScVal{}, err
+	}
+	words := signedWords(v, 2)
+	parts := xdr.UInt128Parts{Hi: xdr.Uint64(words[0]), Lo: xdr.Uint64(words[1])}
+	return xdr.ScVal{Type: xdr.ScValTypeScvU128, U128: &parts}, nil
+}
+
+// I256 encodes v as an I256, failing if it is out of range.
+func I256(v *big.Int) (xdr.ScVal, error) {
+	if err := checkRange(v, minI256, maxI256, "i256"); err != nil {
+		return xdr.ScVal{}, err
+	}
+	w := signedWords(v, 4)
+	parts := xdr.Int256Parts{HiHi: xdr.Int64(int64(w[0])), HiLo: xdr.Uint64(w[1]), LoHi: xdr.Uint64(w[2]), LoLo: xdr.Uint64(w[3])}
+	return xdr.ScVal{Type: xdr.ScValTypeScvI256, I256: &parts}, nil
+}
+
+// U256 encodes v as a U256, failing if it is negative or too large.
+func U256(v *big.Int) (xdr.ScVal, error) {
+	if err := checkRange(v, new(big.Int), maxU256, "u256"); err != nil {
+		return xdr.ScVal{}, err
+	}
+	w := signedWords(v, 4)
+	parts := xdr.UInt256Parts{HiHi: xdr.Uint64(w[0]), HiLo: xdr.Uint64(w[1]), LoHi: xdr.Uint64(w[2]), LoLo: xdr.Uint64(w[3])}
+	return xdr.ScVal{Type: xdr.ScValTypeScvU256, U256: &parts}, nil
+}
+
+// BigInt returns the value of any integer ScVal.
+func BigInt(val xdr.ScVal) (*big.Int, error) {
+	switch val.Type {
+	case xdr.ScValTypeScvU32:
+		if val.U32 != nil {
+			return new(big.Int).SetUint64(uint64(*val.U32)), nil
+		}
+	case xdr.ScValTypeScvI32:
+		if val.I32 != nil {
+			return big.NewInt(int64(*val.I32)), nil
+		}
+	case xdr.ScValTypeScvU64:
+		if val.U64 != nil {
+			return new(big.Int).SetUint64(uint64(*val.U64)), nil
+		}
+	case xdr.ScValTypeScvI64:
+		if val.I64 != nil {
+			return big.NewInt(int64(*val.I64)), nil
+		}
+	case xdr.ScValTypeScvTimepoint:
+		if val.Timepoint != nil {
+			return new(big.Int).SetUint64(uint64(*val.Timepoint)), nil
+		}
+	case xdr.ScValTypeScvDuration:
+		if val.Duration != nil {
+			return new(big.Int).SetUint64(uint64(*val.Duration)), nil
+		}
+	case xdr.ScValTypeScvU128:
+		if p := val.U128; p != nil {
+			return fromWords(false, uint64(p.Hi), uint64(p.Lo)), nil
+		}
+	case xdr.ScValTypeScvI128:
+		if p := val.I128; p != nil {
+			return fromWords(true, uint64(p.Hi), uint64(p.Lo)), nil
+		}
+	case xdr.ScValTypeScvU256:
+		if p := val.U256; p != nil {
+			return fromWords(false, uint64(p.HiHi), uint64(p.HiLo), uint64(p.LoHi), uint64(p.LoLo)), nil
+		}
+	case xdr.ScValTypeScvI256:
+		if p := val.I256; p != nil {
+			return fromWords(true, uint64(p.HiHi), uint64(p.HiLo), uint64(p.LoHi), uint64(p.LoLo)), nil
+		}
+	default:
+		return nil, mismatch(val, "integer")
+	}
+	return nil, malformed(val)
+}
+
+func checkRange(v, lo, hi *big.Int, name string) error {
+	if v == nil {
+		return errors.WrapValidationError(fmt.Sprintf("nil *big.Int cannot be encoded as %s", name))
+	}
+	if v.Cmp(lo) < 0 || v.Cmp(hi) > 0 {
+		return errors.WrapValidationError(fmt.Sprintf("%s overflows %s", v, name))
+	}
+	return nil
+}
+
+// signedWords splits v into n big-endian 64-bit words of its two's
+// complement representation.
+func signedWords(v *big.Int, n int) []uint64 {
+	x := new(big.Int).Set(v)
+	if x.Sign() < 0 {
+		x.Add(x, new(big.Int).Lsh(big.NewInt(1), uint(64*n)))
+	}
+	words := make([]uint64, n)
+	for k := n - 1; k >= 0; k-- {
+		words[k] = new(big.Int).And(x, maxU64).Uint64()
+		x.Rsh(x, 64)
+	}
+	return words
+}
+
+// fromWords is the inverse of signedWords.
+func fromWords(signed bool, words ...uint64) *big.Int {
+	x := new(big.Int)
+	for _, w := range words {
+		x.Lsh(x, 64)
+		x.Or(x, new(big.Int).SetUint64(w))
+	}
+	if signed && words[0]>>63 == 1 {
+		x.Sub(x, new(big.Int).Lsh(big.NewInt(1), uint(64*len(words))))
+	}
+	return x
+}
@@ -0,0 +1,341 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package scval
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/dotandev/hintents/internal/address"
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+var (
+	scValType     = reflect.TypeOf(xdr.ScVal{})
+	scAddressType = reflect.TypeOf(xdr.ScAddress{})
+	bigIntType    = reflect.TypeOf(big.Int{})
+	bigIntPtrType = reflect.TypeOf(&big.Int{})
+	timeType      = reflect.TypeOf(time.Time{})
+	durationType  = reflect.TypeOf(time.Duration(0))
+	marshalerType = reflect.TypeOf((*Marshaler)(nil)).Elem()
+)
+
+// ToScVal converts v to an ScVal as described in the package comment.
+func ToScVal(v interface{}) (xdr.ScVal, error) {
+	if v == nil {
+		return Void(), nil
+	}
+	return encode(reflect.ValueOf(v), "")
+}
+
+// NewAddress parses a G... or C... address.
+func NewAddress(addr string) (xdr.ScAddress, error) {
+	a, err := address.Parse(addr)
+	if err != nil {
+		return xdr.ScAddress{}, err
+	}
+	if a.Kind != address.Account && a.Kind != address.Contract {
+		return xdr.ScAddress{}, errors.WrapValidationError(fmt.Sprintf("%q is not an account or contract address", addr))
+	}
+	return a.ScAddress()
+}
+
+func encode(v reflect.Value, width string) (xdr.ScVal, error) {
+	if !v.IsValid() {
+		return Void(), nil
+	}
+	if v.Type().Implements(marshalerType) {
+		if v.Kind() == reflect.Ptr && v.IsNil() {
+			return Void(), nil
+		}
+		return v.Interface().(Marshaler).MarshalScVal()
+	}
+	if v.CanAddr() && reflect.PointerTo(v.Type()).Implements(marshalerType) {
+		return v.Addr().Interface().(Marshaler).MarshalScVal()
+	}
+
+	switch v.Type() {
+	case scValType:
+		return v.Interface().(xdr.ScVal), nil
+	case scAddressType:
+		addr := v.Interface().(xdr.ScAddress)
+		return xdr.ScVal{Type: xdr.ScValTypeScvAddress, Address: &addr}, nil
+	case bigIntPtrType:
+		return encodeBig(v.Interface().(*big.Int), width)
+	case bigIntType:
+		b := v.Interface().(big.Int)
+		return encodeBig(&b, width)
+	case timeType:
+		t := v.Interface().(time.Time)
+		if t.Before(time.Unix(0, 0)) {
+			return xdr.ScVal{}, errors.WrapValidationError(fmt.Sprintf("timepoint %s is before the epoch", t))
+		}
+		tp := xdr.TimePoint(t.Unix())
+		return xdr.ScVal{Type: xdr.ScValTypeScvTimepoint, Timepoint: &tp}, nil
+	case durationType:
+		d := v.Interface().(time.Duration)
+		if d < 0 {
+			return xdr.ScVal{}, errors.WrapValidationError(fmt.Sprintf("negative duration %s", d))
+		}
+		secs := xdr.Duration(d / time.Second)
+		return xdr.ScVal{Type: xdr.ScValTypeScvDuration, Duration: &secs}, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return Void(), nil
+		}
+		return encode(v.Elem(), width)
+
+	case reflect.Bool:
+		b := v.Bool()
+		return xdr.ScVal{Type: xdr.ScValTypeScvBool, B: &b}, nil
+
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		n := xdr.Int32(v.Int())
+		return xdr.ScVal{Type: xdr.ScValTypeScvI32, I32: &n}, nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		n := xdr.Uint32(v.Uint())
+		return xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: &n}, nil
+	case reflect.Int, reflect.Int64:
+		if width != "" {
+			return encodeBig(big.NewInt(v.Int()), width)
+		}
+		n := xdr.Int64(v.Int())
+		return xdr.ScVal{Type: xdr.ScValTypeScvI64, I64: &n}, nil
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		if width != "" {
+			return encodeBig(new(big.Int).SetUint64(v.Uint()), width)
+		}
+		n := xdr.Uint64(v.Uint())
+		return xdr.ScVal{Type: xdr.ScValTypeScvU64, U64: &n}, nil
+
+	case reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return xdr.ScVal{}, errors.WrapValidationError(fmt.Sprintf("%s has no ScVal encoding; use an integer or *big.Int", v.Type()))
+
+	case reflect.String:
+		s := v.String()
+		switch v.Type() {
+		case reflect.TypeOf(Symbol("")):
+			sym := xdr.ScSymbol(s)
+			return xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym}, nil
+		case reflect.TypeOf(Address("")):
+			addr, err := NewAddress(s)
+			if err != nil {
+				return xdr.ScVal{}, err
+			}
+			return xdr.ScVal{Type: xdr.ScValTypeScvAddress, Address: &addr}, nil
+		}
+		str := xdr.ScString(s)
+		return xdr.ScVal{Type: xdr.ScValTypeScvString, Str: &str}, nil
+
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make(xdr.ScBytes, v.Len())
+			reflect.Copy(reflect.ValueOf([]byte(b)), v)
+			return xdr.ScVal{Type: xdr.ScValTypeScvBytes, Bytes: &b}, nil
+		}
+		vec := make(xdr.ScVec, 0, v.Len())
+		for k := 0; k < v.Len(); k++ {
+			item, err := encode(v.Index(k), width)
+			if err != nil {
+				return xdr.ScVal{}, fmt.Errorf("index %d: %w", k, err)
+			}
+			vec = append(vec, item)
+		}
+		return Vec(vec...), nil
+
+	case reflect.Map:
+		m := make(xdr.ScMap, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key, err := encode(iter.Key(), "")
+			if err != nil {
+				return xdr.ScVal{}, fmt.Errorf("map key %v: %w", iter.Key(), err)
+			}
+			val, err := encode(iter.Value(), width)
+			if err != nil {
+				return xdr.ScVal{}, fmt.Errorf("map value for %v: %w", iter.Key(), err)
+			}
+			m = append(m, xdr.ScMapEntry{Key: key, Val: val})
+		}
+		return Map(m...), nil
+
+	case reflect.Struct:
+		fields := structFields(v.Type())
+		m := make(xdr.ScMap, 0, len(fields))
+		for _, f := range fields {
+			val, err := encode(v.FieldByIndex(f.index), f.width)
+			if err != nil {
+				return xdr.ScVal{}, fmt.Errorf("field %s: %w", f.name, err)
+			}
+			sym := xdr.ScSymbol(f.name)
+			m = append(m, xdr.ScMapEntry{Key: xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym}, Val: val})
+		}
+		return Map(m...), nil
+	}
+	return xdr.ScVal{}, errors.WrapValidationError(fmt.Sprintf("%s has no ScVal encoding", v.Type()))
+}
+
+func encodeBig(b *big.Int, width string) (xdr.ScVal, error) {
+	switch width {
+	case "", "i128":
+		return I128(b)
+	case "u128":
+		return U128(b)
+	case "i256":
+		return I256(b)
+	case "u256":
+		return U256(b)
+	}
+	return xdr.ScVal{}, errors.WrapValidationError(fmt.Sprintf("unknown integer width %q", width))
+}
+
+// Vec returns a Vec holding items.
+func Vec(items ...xdr.ScVal) xdr.ScVal {
+	vec := xdr.ScVec(items)
+	p := &vec
+	return xdr.ScVal{Type: xdr.ScValTypeScvVec, Vec: &p}
+}
+
+// Map returns a Map holding entries sorted by key, which the host requires
+// of every map. The entries slice itself is left untouched.
+func Map(entries ...xdr.ScMapEntry) xdr.ScVal {
+	m := append(xdr.ScMap(nil), entries...)
+	sort.SliceStable(m, func(i, j int) bool { return Compare(m[i].Key, m[j].Key) < 0 })
+	p := &m
+	return xdr.ScVal{Type: xdr.ScValTypeScvMap, Map: &p}
+}
+
+// Compare orders ScVals the way the Soroban host does for map keys: by
+// type first, then by value. It returns -1, 0 or 1.
+func Compare(a, b xdr.ScVal) int {
+	if a.Type != b.Type {
+		if a.Type < b.Type {
+			return -1
+		}
+		return 1
+	}
+	switch a.Type {
+	case xdr.ScValTypeScvBool:
+		return compareBool(a.B != nil && *a.B, b.B != nil && *b.B)
+	case xdr.ScValTypeScvU32, xdr.ScValTypeScvI32, xdr.ScValTypeScvU64, xdr.ScValTypeScvI64,
+		xdr.ScValTypeScvTimepoint, xdr.ScValTypeScvDuration,
+		xdr.ScValTypeScvU128, xdr.ScValTypeScvI128, xdr.ScValTypeScvU256, xdr.ScValTypeScvI256:
+		x, errX := BigInt(a)
+		y, errY := BigInt(b)
+		if errX == nil && errY == nil {
+			return x.Cmp(y)
+		}
+	case xdr.ScValTypeScvString:
+		if a.Str != nil && b.Str != nil {
+			return strings.Compare(string(*a.Str), string(*b.Str))
+		}
+	case xdr.ScValTypeScvSymbol:
+		if a.Sym != nil && b.Sym != nil {
+			return strings.Compare(string(*a.Sym), string(*b.Sym))
+		}
+	case xdr.ScValTypeScvBytes:
+		if a.Bytes != nil && b.Bytes != nil {
+			return bytes.Compare(*a.Bytes, *b.Bytes)
+		}
+	case xdr.ScValTypeScvVec:
+		if a.Vec != nil && *a.Vec != nil && b.Vec != nil && *b.Vec != nil {
+			x, y := **a.Vec, **b.Vec
+			for k := 0; k < len(x) && k < len(y); k++ {
+				if c := Compare(x[k], y[k]); c != 0 {
+					return c
+				}
+			}
+			return compareInt(len(x), len(y))
+		}
+	}
+	// Addresses and the remaining types order by their XDR encoding, which
+	// puts the discriminant first like the host does.
+	x, _ := a.MarshalBinary()
+	y, _ := b.MarshalBinary()
+	return bytes.Compare(x, y)
+}
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case !a:
+		return -1
+	}
+	return 1
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+type field struct {
+	name  string
+	width string
+	index []int
+}
+
+var fieldCache sync.Map // reflect.Type -> []field
+
+// structFields returns the encoded fields of t, sorted by name as Soroban
+// lays out struct maps.
+func structFields(t reflect.Type) []field {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.([]field)
+	}
+	var fields []field
+	for k := 0; k < t.NumField(); k++ {
+		sf := t.Field(k)
+		if !sf.IsExported() {
+			continue
+		}
+		tag := sf.Tag.Get("scval")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = snakeCase(sf.Name)
+		}
+		fields = append(fields, field{name: name, width: opts, index: sf.Index})
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].name < fields[j].name })
+	fieldCache.Store(t, fields)
+	return fields
+}
+
+// snakeCase turns Go field names such as ContractID into contract_id.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && !unicode.IsUpper(runes[i-1])
+			nextLower := i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || nextLower {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
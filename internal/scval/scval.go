@@ -0,0 +1,67 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+// Package scval converts between Soroban ScVal values and native Go types.
+//
+// ToScVal and FromScVal follow encoding/json conventions:
+//
+//	bool                               Bool
+//	int8, int16, int32                 I32
+//	uint8, uint16, uint32              U32
+//	int, int64                         I64
+//	uint, uint64                       U64
+//	time.Time                          Timepoint
+//	time.Duration                      Duration (whole seconds)
+//	*big.Int                           I128, or the width named by a tag option
+//	string                             String
+//	Symbol                             Symbol
+//	Address                            Address
+//	[]byte, [N]byte                    Bytes
+//	slices and arrays                  Vec
+//	maps                               Map, with keys sorted as the host expects
+//	structs                            Map with Symbol keys
+//	nil pointers and interfaces        Void
+//	xdr.ScVal                          itself
+//
+// Struct fields are keyed by their snake_case name unless a tag such as
+// `scval:"name"` overrides it; `scval:"-"` skips the field. The options
+// i128, u128, i256 and u256 choose the width of *big.Int fields, as in
+// `scval:"amount,u128"`. Types implementing Marshaler or Unmarshaler
+// convert themselves.
+//
+// Decoding accepts any integer type that fits the target, checking for
+// overflow, so a U128 holding 5 decodes into an int. Floats are rejected
+// since ScVals have no floating point type.
+//
+// For building values by hand, constructors such as U32, I64, U128From, Sym
+// and Field accept only Go types that always fit, so they cannot fail.
+// Decode and MustDecode are generic forms of FromScVal, and Typed carries
+// an ScVal together with the Go type it is known to decode into.
+package scval
+
+import (
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// Symbol is a string encoded as an ScVal symbol rather than a string.
+type Symbol string
+
+// Address is an account (G...) or contract (C...) address encoded as an
+// ScVal address.
+type Address string
+
+// Marshaler is implemented by types that convert themselves to an ScVal.
+type Marshaler interface {
+	MarshalScVal() (xdr.ScVal, error)
+}
+
+// Unmarshaler is implemented by types that decode themselves from an ScVal.
+type Unmarshaler interface {
+	UnmarshalScVal(xdr.ScVal) error
+}
+
+// Void returns the void ScVal, the encoding of unit and of a missing
+// Option value.
+func Void() xdr.ScVal {
+	return xdr.ScVal{Type: xdr.ScValTypeScvVoid}
+}
@@ -0,0 +1,165 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package scval
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type transfer struct {
+	From       Address
+	To         Address
+	Amount     *big.Int `scval:"amount,u128"`
+	Memo       string
+	ContractID [4]byte
+	Tags       []Symbol
+	Ignored    string `scval:"-"`
+}
+
+func TestRoundTrip_Struct(t *testing.T) {
+	from, to := keypair.MustRandom().Address(), keypair.MustRandom().Address()
+	amount, _ := new(big.Int).SetString("340282366920938463463374607431768211455", 10) // u128 max
+	in := transfer{From: Address(from), To: Address(to), Amount: amount, Memo: "hi", ContractID: [4]byte{1, 2, 3, 4}, Tags: []Symbol{"a", "b"}, Ignored: "x"}
+
+	val, err := ToScVal(in)
+	require.NoError(t, err)
+	require.Equal(t, xdr.ScValTypeScvMap, val.Type)
+	m := **val.Map
+	var keys []string
+	for _, e := range m {
+		keys = append(keys, string(*e.Key.Sym))
+	}
+	assert.Equal(t, []string{"amount", "contract_id", "from", "memo", "tags", "to"}, keys, "keys are sorted")
+	assert.Equal(t, xdr.ScValTypeScvU128, m[0].Val.Type)
+
+	var out transfer
+	require.NoError(t, FromScVal(val, &out))
+	in.Ignored = ""
+	assert.Equal(t, in, out)
+}
+
+func TestRoundTrip_Scalars(t *testing.T) {
+	now := time.Unix(1700000000, 0).UTC()
+	for _, v := range []interface{}{
+		true, int32(-5), uint32(7), int64(-9), uint64(10), "text", Symbol("sym"),
+		[]byte{0xde, 0xad}, now, 90 * time.Second, []int64{1, 2, 3},
+		map[string]uint32{"b": 2, "a": 1},
+	} {
+		val, err := ToScVal(v)
+		require.NoError(t, err, "%T", v)
+		out := reflect0(v)
+		require.NoError(t, FromScVal(val, out), "%T", v)
+		assert.Equal(t, v, deref(out), "%T", v)
+	}
+}
+
+func TestBigIntWidths(t *testing.T) {
+	neg := big.NewInt(-1)
+	for name, f := range map[string]func(*big.Int) (xdr.ScVal, error){"i128": I128, "i256": I256} {
+		val, err := f(neg)
+		require.NoError(t, err, name)
+		back, err := BigInt(val)
+		require.NoError(t, err, name)
+		assert.Equal(t, neg, back, name)
+	}
+
+	_, err := U128(neg)
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+	_, err = I128(new(big.Int).Lsh(big.NewInt(1), 127))
+	assert.ErrorIs(t, err, errs.ErrValidationFailed, "2^127 overflows i128")
+	big256 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	val, err := U256(big256)
+	require.NoError(t, err)
+	back, err := BigInt(val)
+	require.NoError(t, err)
+	assert.Equal(t, big256, back)
+}
+
+func TestFromScVal_Overflow(t *testing.T) {
+	val, err := U128(new(big.Int).Lsh(big.NewInt(1), 64))
+	require.NoError(t, err)
+	var n uint64
+	assert.ErrorIs(t, FromScVal(val, &n), errs.ErrValidationFailed)
+
+	small, err := U128(big.NewInt(300))
+	require.NoError(t, err)
+	var i int
+	require.NoError(t, FromScVal(small, &i))
+	assert.Equal(t, 300, i)
+	var b uint8
+	assert.ErrorIs(t, FromScVal(small, &b), errs.ErrValidationFailed)
+
+	neg, _ := ToScVal(int32(-1))
+	var u uint32
+	assert.ErrorIs(t, FromScVal(neg, &u), errs.ErrValidationFailed)
+}
+
+func TestFromScVal_Interface(t *testing.T) {
+	val, err := ToScVal(map[Symbol]interface{}{"n": uint32(1), "list": []string{"x"}, "none": nil})
+	require.NoError(t, err)
+	var out interface{}
+	require.NoError(t, FromScVal(val, &out))
+	assert.Equal(t, map[string]interface{}{"n": uint64(1), "list": []interface{}{"x"}, "none": nil}, out)
+}
+
+func TestToScVal_Errors(t *testing.T) {
+	_, err := ToScVal(1.5)
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+	_, err = ToScVal(Address("nope"))
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+	assert.ErrorIs(t, FromScVal(Void(), nil), errs.ErrValidationFailed)
+
+	var missing struct{ Foo uint32 }
+	empty, _ := ToScVal(struct{}{})
+	assert.ErrorIs(t, FromScVal(empty, &missing), errs.ErrValidationFailed)
+}
+
+func TestOptionPointers(t *testing.T) {
+	var p *uint32
+	val, err := ToScVal(p)
+	require.NoError(t, err)
+	assert.Equal(t, xdr.ScValTypeScvVoid, val.Type)
+
+	seven := uint32(7)
+	val, _ = ToScVal(&seven)
+	require.NoError(t, FromScVal(val, &p))
+	require.NotNil(t, p)
+	assert.Equal(t, seven, *p)
+	require.NoError(t, FromScVal(Void(), &p))
+	assert.Nil(t, p)
+}
+
+func TestCompare(t *testing.T) {
+	a, _ := ToScVal(uint32(2))
+	b, _ := ToScVal(uint32(10))
+	s, _ := ToScVal(Symbol("a"))
+	assert.Equal(t, -1, Compare(a, b))
+	assert.Equal(t, 1, Compare(b, a))
+	assert.Equal(t, 0, Compare(a, a))
+	assert.Equal(t, -1, Compare(a, s), "u32 sorts before symbol")
+}
+
+func TestSnakeCase(t *testing.T) {
+	for in, want := range map[string]string{"Amount": "amount", "ContractID": "contract_id", "HTTPServer": "http_server", "ToAddr2": "to_addr2"} {
+		assert.Equal(t, want, snakeCase(in))
+	}
+}
+
+// reflect0 returns a pointer to a new zero value of v's type.
+func reflect0(v interface{}) interface{} {
+	return reflect.New(reflect.TypeOf(v)).Interface()
+}
+
+func deref(p interface{}) interface{} {
+	return reflect.ValueOf(p).Elem().Interface()
+}
@@ -0,0 +1,185 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package scval
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// Signed is the set of Go signed integer types.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned is the set of Go unsigned integer types.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// The constructors below only accept types that always fit the ScVal they
+// build, so unlike ToScVal they cannot fail and return the value directly.
+
+// Bool returns a Bool holding b.
+func Bool(b bool) xdr.ScVal {
+	return xdr.ScVal{Type: xdr.ScValTypeScvBool, B: &b}
+}
+
+// U32 returns a U32 holding v.
+func U32[T ~uint8 | ~uint16 | ~uint32](v T) xdr.ScVal {
+	n := xdr.Uint32(v)
+	return xdr.ScVal{Type: xdr.ScValTypeScvU32, U32: &n}
+}
+
+// I32 returns an I32 holding v.
+func I32[T ~int8 | ~int16 | ~int32](v T) xdr.ScVal {
+	n := xdr.Int32(v)
+	return xdr.ScVal{Type: xdr.ScValTypeScvI32, I32: &n}
+}
+
+// U64 returns a U64 holding v.
+func U64[T Unsigned](v T) xdr.ScVal {
+	n := xdr.Uint64(v)
+	return xdr.ScVal{Type: xdr.ScValTypeScvU64, U64: &n}
+}
+
+// I64 returns an I64 holding v.
+func I64[T Signed](v T) xdr.ScVal {
+	n := xdr.Int64(v)
+	return xdr.ScVal{Type: xdr.ScValTypeScvI64, I64: &n}
+}
+
+// U128From returns a U128 holding v. Use U128 for values wider than 64 bits.
+func U128From[T Unsigned](v T) xdr.ScVal {
+	parts := xdr.UInt128Parts{Lo: xdr.Uint64(v)}
+	return xdr.ScVal{Type: xdr.ScValTypeScvU128, U128: &parts}
+}
+
+// I128From returns an I128 holding v. Use I128 for values wider than 64
+// bits.
+func I128From[T Signed](v T) xdr.ScVal {
+	n := int64(v)
+	parts := xdr.Int128Parts{Lo: xdr.Uint64(n)}
+	if n < 0 {
+		parts.Hi = -1
+	}
+	return xdr.ScVal{Type: xdr.ScValTypeScvI128, I128: &parts}
+}
+
+// Str returns a String holding s.
+func Str(s string) xdr.ScVal {
+	str := xdr.ScString(s)
+	return xdr.ScVal{Type: xdr.ScValTypeScvString, Str: &str}
+}
+
+// Sym returns a Symbol holding s. The host limits symbols to 32 characters
+// from [a-zA-Z0-9_]; Sym does not check this.
+func Sym(s string) xdr.ScVal {
+	sym := xdr.ScSymbol(s)
+	return xdr.ScVal{Type: xdr.ScValTypeScvSymbol, Sym: &sym}
+}
+
+// Bytes returns a Bytes holding a copy of b.
+func Bytes(b []byte) xdr.ScVal {
+	bs := xdr.ScBytes(append([]byte{}, b...))
+	return xdr.ScVal{Type: xdr.ScValTypeScvBytes, Bytes: &bs}
+}
+
+// Pair returns a map entry for use with Map.
+func Pair(key, val xdr.ScVal) xdr.ScMapEntry {
+	return xdr.ScMapEntry{Key: key, Val: val}
+}
+
+// Field returns a map entry keyed by the symbol name, the form contract
+// structs take.
+func Field(name string, val xdr.ScVal) xdr.ScMapEntry {
+	return Pair(Sym(name), val)
+}
+
+// Struct encodes x, a struct or pointer to one, as a Map with Symbol keys.
+// It fails for any other kind of value, which ToScVal would encode as
+// something other than a contract struct.
+func Struct(x interface{}) (xdr.ScVal, error) {
+	t := reflect.TypeOf(x)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return xdr.ScVal{}, errors.WrapValidationError(fmt.Sprintf("Struct needs a struct, got %T", x))
+	}
+	return ToScVal(x)
+}
+
+// Decode decodes val into a new T, so callers need not declare the target
+// first.
+func Decode[T any](val xdr.ScVal) (T, error) {
+	var out T
+	err := FromScVal(val, &out)
+	return out, err
+}
+
+// MustDecode is like Decode but panics if val does not decode into T. It is
+// meant for values whose type is already known, such as those held in a
+// Typed or checked against a contract spec.
+func MustDecode[T any](val xdr.ScVal) T {
+	out, err := Decode[T](val)
+	if err != nil {
+		var zero T
+		panic(fmt.Sprintf("scval: decode %s into %T: %v", val.Type, zero, err))
+	}
+	return out
+}
+
+// Typed is an ScVal known to decode into T. Function signatures can use it
+// to state which Go type an argument or result carries, and Value never
+// fails. The zero Typed holds Void and the zero T.
+type Typed[T any] struct {
+	val   xdr.ScVal
+	value T
+}
+
+// Of encodes v and wraps the result.
+func Of[T any](v T) (Typed[T], error) {
+	val, err := ToScVal(v)
+	if err != nil {
+		return Typed[T]{}, err
+	}
+	return As[T](val)
+}
+
+// As wraps val after checking that it decodes into T.
+func As[T any](val xdr.ScVal) (Typed[T], error) {
+	value, err := Decode[T](val)
+	if err != nil {
+		return Typed[T]{}, err
+	}
+	return Typed[T]{val: val, value: value}, nil
+}
+
+// ScVal returns the wrapped ScVal.
+func (t Typed[T]) ScVal() xdr.ScVal {
+	if t.val.Type == 0 && t.val.B == nil {
+		return Void()
+	}
+	return t.val
+}
+
+// Value returns the wrapped value decoded as T.
+func (t Typed[T]) Value() T { return t.value }
+
+// MarshalScVal implements Marshaler.
+func (t Typed[T]) MarshalScVal() (xdr.ScVal, error) { return t.ScVal(), nil }
+
+// UnmarshalScVal implements Unmarshaler.
+func (t *Typed[T]) UnmarshalScVal(val xdr.ScVal) error {
+	w, err := As[T](val)
+	if err != nil {
+		return err
+	}
+	*t = w
+	return nil
+}
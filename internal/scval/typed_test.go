@@ -0,0 +1,98 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package scval
+
+import (
+	"math/big"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstructors_MatchToScVal(t *testing.T) {
+	type level uint8
+	cases := []struct {
+		got  xdr.ScVal
+		want interface{}
+	}{
+		{Bool(true), true},
+		{U32(level(7)), uint32(7)},
+		{I32(int16(-3)), int32(-3)},
+		{U64(uint(9)), uint64(9)},
+		{I64(-9), int64(-9)},
+		{Str("hi"), "hi"},
+		{Sym("transfer"), Symbol("transfer")},
+		{Bytes([]byte{1, 2}), []byte{1, 2}},
+	}
+	for _, c := range cases {
+		want, err := ToScVal(c.want)
+		require.NoError(t, err)
+		assert.Equal(t, want, c.got, "%T", c.want)
+	}
+}
+
+func TestWideFromNative(t *testing.T) {
+	for _, n := range []int64{0, 1, -1, -1 << 63, 1<<63 - 1} {
+		want, err := I128(big.NewInt(n))
+		require.NoError(t, err)
+		assert.Equal(t, want, I128From(n), "%d", n)
+	}
+	want, err := U128(new(big.Int).SetUint64(1<<64 - 1))
+	require.NoError(t, err)
+	assert.Equal(t, want, U128From(uint64(1<<64-1)))
+}
+
+func TestMapOfFields(t *testing.T) {
+	val := Map(Field("b", U32(uint32(2))), Field("a", Str("x")))
+	got := MustDecode[map[string]interface{}](val)
+	assert.Equal(t, map[string]interface{}{"a": "x", "b": uint64(2)}, got)
+	assert.Equal(t, "a", string(*(**val.Map)[0].Key.Sym))
+}
+
+func TestStruct(t *testing.T) {
+	type point struct{ X, Y int32 }
+	val, err := Struct(&point{X: 1, Y: 2})
+	require.NoError(t, err)
+	assert.Equal(t, point{X: 1, Y: 2}, MustDecode[point](val))
+
+	_, err = Struct([]int{1})
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+	_, err = Struct(nil)
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+}
+
+func TestDecode(t *testing.T) {
+	n, err := Decode[uint8](U32(uint32(300)))
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+	assert.Zero(t, n)
+
+	assert.Equal(t, 5, MustDecode[int](U128From(uint(5))))
+	assert.Panics(t, func() { MustDecode[string](Bool(true)) })
+}
+
+func TestTyped(t *testing.T) {
+	amount, err := Of(big.NewInt(42))
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), amount.Value().Int64())
+
+	_, err = As[string](U32(uint32(1)))
+	assert.ErrorIs(t, err, errs.ErrValidationFailed)
+
+	type args struct {
+		To     Address
+		Amount Typed[*big.Int]
+	}
+	val, err := ToScVal(args{To: Address(keypair.MustRandom().Address()), Amount: amount})
+	require.NoError(t, err)
+	var out args
+	require.NoError(t, FromScVal(val, &out))
+	assert.Equal(t, int64(42), out.Amount.Value().Int64())
+
+	var zero Typed[int]
+	assert.Equal(t, Void(), zero.ScVal())
+}
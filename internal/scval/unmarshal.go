@@ -0,0 +1,405 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package scval
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"time"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+var unmarshalerType = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+
+// FromScVal decodes val into the value out points to, as described in the
+// package comment. Decoding into an empty interface yields bool, int64,
+// uint64, *big.Int, string, Symbol, Address, []byte, []interface{},
+// map[string]interface{} (map[interface{}]interface{} when keys are not
+// strings), time.Time, time.Duration or nil.
+func FromScVal(val xdr.ScVal, out interface{}) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.WrapValidationError(fmt.Sprintf("FromScVal needs a non-nil pointer, got %T", out))
+	}
+	return decode(val, rv.Elem())
+}
+
+func decode(val xdr.ScVal, v reflect.Value) error {
+	if v.CanAddr() && reflect.PointerTo(v.Type()).Implements(unmarshalerType) {
+		return v.Addr().Interface().(Unmarshaler).UnmarshalScVal(val)
+	}
+
+	switch v.Type() {
+	case scValType:
+		v.Set(reflect.ValueOf(val))
+		return nil
+	case scAddressType:
+		if val.Type != xdr.ScValTypeScvAddress || val.Address == nil {
+			return mismatch(val, "address")
+		}
+		v.Set(reflect.ValueOf(*val.Address))
+		return nil
+	case bigIntType:
+		b, err := BigInt(val)
+		if err != nil {
+			return err
+		}
+		if v.CanAddr() {
+			v.Addr().Interface().(*big.Int).Set(b)
+		} else {
+			v.Set(reflect.ValueOf(*b))
+		}
+		return nil
+	case timeType:
+		n, err := uintOf(val, 63)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(time.Unix(int64(n), 0).UTC()))
+		return nil
+	case durationType:
+		n, err := uintOf(val, 33) // whole seconds that fit a time.Duration
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(time.Duration(n) * time.Second))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if val.Type == xdr.ScValTypeScvVoid {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return decode(val, v.Elem())
+
+	case reflect.Interface:
+		if v.NumMethod() != 0 {
+			return errors.WrapValidationError(fmt.Sprintf("cannot decode into non-empty interface %s", v.Type()))
+		}
+		native, err := nativeValue(val)
+		if err != nil {
+			return err
+		}
+		if native == nil {
+			v.Set(reflect.Zero(v.Type()))
+		} else {
+			v.Set(reflect.ValueOf(native))
+		}
+		return nil
+
+	case reflect.Bool:
+		if val.Type != xdr.ScValTypeScvBool || val.B == nil {
+			return mismatch(val, "bool")
+		}
+		v.SetBool(*val.B)
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		b, err := BigInt(val)
+		if err != nil {
+			return err
+		}
+		if !b.IsInt64() || v.OverflowInt(b.Int64()) {
+			return errors.WrapValidationError(fmt.Sprintf("%s overflows %s", b, v.Type()))
+		}
+		v.SetInt(b.Int64())
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		b, err := BigInt(val)
+		if err != nil {
+			return err
+		}
+		if b.Sign() < 0 || !b.IsUint64() || v.OverflowUint(b.Uint64()) {
+			return errors.WrapValidationError(fmt.Sprintf("%s overflows %s", b, v.Type()))
+		}
+		v.SetUint(b.Uint64())
+		return nil
+
+	case reflect.String:
+		s, err := stringOf(val)
+		if err != nil {
+			return err
+		}
+		v.SetString(s)
+		return nil
+
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			if val.Type != xdr.ScValTypeScvBytes || val.Bytes == nil {
+				return mismatch(val, "bytes")
+			}
+			v.SetBytes(append([]byte(nil), *val.Bytes...))
+			return nil
+		}
+		items, err := vecOf(val)
+		if err != nil {
+			return err
+		}
+		s := reflect.MakeSlice(v.Type(), len(items), len(items))
+		for k, item := range items {
+			if err := decode(item, s.Index(k)); err != nil {
+				return fmt.Errorf("index %d: %w", k, err)
+			}
+		}
+		v.Set(s)
+		return nil
+
+	case reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			if val.Type != xdr.ScValTypeScvBytes || val.Bytes == nil {
+				return mismatch(val, "bytes")
+			}
+			if len(*val.Bytes) != v.Len() {
+				return errors.WrapValidationError(fmt.Sprintf("got %d bytes, want %d", len(*val.Bytes), v.Len()))
+			}
+			reflect.Copy(v, reflect.ValueOf([]byte(*val.Bytes)))
+			return nil
+		}
+		items, err := vecOf(val)
+		if err != nil {
+			return err
+		}
+		if len(items) != v.Len() {
+			return errors.WrapValidationError(fmt.Sprintf("got %d elements, want %d", len(items), v.Len()))
+		}
+		for k, item := range items {
+			if err := decode(item, v.Index(k)); err != nil {
+				return fmt.Errorf("index %d: %w", k, err)
+			}
+		}
+		return nil
+
+	case reflect.Map:
+		entries, err := mapOf(val)
+		if err != nil {
+			return err
+		}
+		m := reflect.MakeMapWithSize(v.Type(), len(entries))
+		for _, e := range entries {
+			key := reflect.New(v.Type().Key()).Elem()
+			if err := decode(e.Key, key); err != nil {
+				return fmt.Errorf("map key: %w", err)
+			}
+			item := reflect.New(v.Type().Elem()).Elem()
+			if err := decode(e.Val, item); err != nil {
+				return fmt.Errorf("map value for %v: %w", key, err)
+			}
+			m.SetMapIndex(key, item)
+		}
+		v.Set(m)
+		return nil
+
+	case reflect.Struct:
+		entries, err := mapOf(val)
+		if err != nil {
+			return err
+		}
+		byName := make(map[string]xdr.ScVal, len(entries))
+		for _, e := range entries {
+			if e.Key.Type != xdr.ScValTypeScvSymbol || e.Key.Sym == nil {
+				return errors.WrapValidationError(fmt.Sprintf("struct map key is %s, not a symbol", e.Key.Type))
+			}
+			byName[string(*e.Key.Sym)] = e.Val
+		}
+		for _, f := range structFields(v.Type()) {
+			item, ok := byName[f.name]
+			if !ok {
+				return errors.WrapValidationError(fmt.Sprintf("missing field %s", f.name))
+			}
+			if err := decode(item, v.FieldByIndex(f.index)); err != nil {
+				return fmt.Errorf("field %s: %w", f.name, err)
+			}
+		}
+		return nil
+	}
+	return errors.WrapValidationError(fmt.Sprintf("cannot decode an ScVal into %s", v.Type()))
+}
+
+// nativeValue converts val to the Go types listed on FromScVal.
+func nativeValue(val xdr.ScVal) (interface{}, error) {
+	switch val.Type {
+	case xdr.ScValTypeScvVoid:
+		return nil, nil
+	case xdr.ScValTypeScvBool:
+		if val.B != nil {
+			return *val.B, nil
+		}
+	case xdr.ScValTypeScvI32, xdr.ScValTypeScvI64:
+		b, err := BigInt(val)
+		if err != nil {
+			return nil, err
+		}
+		return b.Int64(), nil
+	case xdr.ScValTypeScvU32, xdr.ScValTypeScvU64:
+		b, err := BigInt(val)
+		if err != nil {
+			return nil, err
+		}
+		return b.Uint64(), nil
+	case xdr.ScValTypeScvTimepoint:
+		var t time.Time
+		err := decode(val, reflect.ValueOf(&t).Elem())
+		return t, err
+	case xdr.ScValTypeScvDuration:
+		var d time.Duration
+		err := decode(val, reflect.ValueOf(&d).Elem())
+		return d, err
+	case xdr.ScValTypeScvU128, xdr.ScValTypeScvI128, xdr.ScValTypeScvU256, xdr.ScValTypeScvI256:
+		return BigInt(val)
+	case xdr.ScValTypeScvString:
+		if val.Str != nil {
+			return string(*val.Str), nil
+		}
+	case xdr.ScValTypeScvSymbol:
+		if val.Sym != nil {
+			return Symbol(*val.Sym), nil
+		}
+	case xdr.ScValTypeScvAddress:
+		s, err := stringOf(val)
+		return Address(s), err
+	case xdr.ScValTypeScvBytes:
+		if val.Bytes != nil {
+			return append([]byte(nil), *val.Bytes...), nil
+		}
+	case xdr.ScValTypeScvVec:
+		items, err := vecOf(val)
+		if err != nil {
+			return nil, err
+		}
+		out := make([]interface{}, 0, len(items))
+		for k, item := range items {
+			native, err := nativeValue(item)
+			if err != nil {
+				return nil, fmt.Errorf("index %d: %w", k, err)
+			}
+			out = append(out, native)
+		}
+		return out, nil
+	case xdr.ScValTypeScvMap:
+		entries, err := mapOf(val)
+		if err != nil {
+			return nil, err
+		}
+		return nativeMap(entries)
+	default:
+		return nil, errors.WrapValidationError(fmt.Sprintf("%s has no native Go form", val.Type))
+	}
+	return nil, malformed(val)
+}
+
+func nativeMap(entries xdr.ScMap) (interface{}, error) {
+	keys := make([]interface{}, len(entries))
+	stringKeys := true
+	for k, e := range entries {
+		key, err := nativeValue(e.Key)
+		if err != nil {
+			return nil, fmt.Errorf("map key: %w", err)
+		}
+		switch key.(type) {
+		case string, Symbol:
+		default:
+			stringKeys = false
+		}
+		keys[k] = key
+	}
+	if stringKeys {
+		out := make(map[string]interface{}, len(entries))
+		for k, e := range entries {
+			val, err := nativeValue(e.Val)
+			if err != nil {
+				return nil, fmt.Errorf("map value for %v: %w", keys[k], err)
+			}
+			out[fmt.Sprint(keys[k])] = val
+		}
+		return out, nil
+	}
+	out := make(map[interface{}]interface{}, len(entries))
+	for k, e := range entries {
+		if !reflect.TypeOf(keys[k]).Comparable() {
+			return nil, errors.WrapValidationError(fmt.Sprintf("map key of type %s has no native Go form", e.Key.Type))
+		}
+		if b, ok := keys[k].(*big.Int); ok {
+			keys[k] = b.String()
+		}
+		val, err := nativeValue(e.Val)
+		if err != nil {
+			return nil, fmt.Errorf("map value for %v: %w", keys[k], err)
+		}
+		out[keys[k]] = val
+	}
+	return out, nil
+}
+
+// uintOf returns an unsigned integer ScVal that fits in bits bits.
+func uintOf(val xdr.ScVal, bits uint) (uint64, error) {
+	b, err := BigInt(val)
+	if err != nil {
+		return 0, err
+	}
+	if b.Sign() < 0 || b.BitLen() > int(bits) {
+		return 0, errors.WrapValidationError(fmt.Sprintf("%s is out of range", b))
+	}
+	return b.Uint64(), nil
+}
+
+func stringOf(val xdr.ScVal) (string, error) {
+	switch val.Type {
+	case xdr.ScValTypeScvString:
+		if val.Str != nil {
+			return string(*val.Str), nil
+		}
+	case xdr.ScValTypeScvSymbol:
+		if val.Sym != nil {
+			return string(*val.Sym), nil
+		}
+	case xdr.ScValTypeScvAddress:
+		if val.Address != nil {
+			s, err := val.Address.String()
+			if err != nil {
+				return "", errors.WrapValidationError(fmt.Sprintf("invalid address: %v", err))
+			}
+			return s, nil
+		}
+	default:
+		return "", mismatch(val, "string, symbol or address")
+	}
+	return "", malformed(val)
+}
+
+func vecOf(val xdr.ScVal) (xdr.ScVec, error) {
+	if val.Type != xdr.ScValTypeScvVec {
+		return nil, mismatch(val, "vec")
+	}
+	if val.Vec == nil || *val.Vec == nil {
+		return nil, nil
+	}
+	return **val.Vec, nil
+}
+
+func mapOf(val xdr.ScVal) (xdr.ScMap, error) {
+	if val.Type != xdr.ScValTypeScvMap {
+		return nil, mismatch(val, "map")
+	}
+	if val.Map == nil || *val.Map == nil {
+		return nil, nil
+	}
+	return **val.Map, nil
+}
+
+func mismatch(val xdr.ScVal, want string) error {
+	return errors.WrapValidationError(fmt.Sprintf("got %s, want %s", val.Type, want))
+}
+
+func malformed(val xdr.ScVal) error {
+	return errors.WrapValidationError(fmt.Sprintf("malformed %s has no value", val.Type))
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package sep
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/intent"
+	"github.com/dotandev/hintents/internal/logger"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+)
+
+// WebAuthClient obtains SEP-10 session tokens from an anchor.
+type WebAuthClient struct {
+	// HomeDomain is the anchor's domain, whose stellar.toml names the auth
+	// endpoint and the server signing key.
+	HomeDomain        string
+	NetworkPassphrase string
+	HTTPClient        *http.Client
+
+	// Endpoint and SigningKey skip stellar.toml discovery when both are set.
+	Endpoint   string
+	SigningKey string
+
+	// UseHTTP fetches stellar.toml over plain HTTP. Intended for tests.
+	UseHTTP bool
+}
+
+// NewWebAuthClient returns a client for the anchor at homeDomain.
+func NewWebAuthClient(homeDomain, networkPassphrase string) *WebAuthClient {
+	return &WebAuthClient{HomeDomain: homeDomain, NetworkPassphrase: networkPassphrase}
+}
+
+type challengeResponse struct {
+	Transaction       string `json:"transaction"`
+	NetworkPassphrase string `json:"network_passphrase"`
+	Error             string `json:"error"`
+}
+
+type tokenResponse struct {
+	Token string `json:"token"`
+	Error string `json:"error"`
+}
+
+// Authenticate proves control of account to the anchor and returns the JWT
+// it issues. The challenge is checked for the home domain, the web auth
+// domain, the network and the server signature before any signer sees it.
+// Accounts with several signers pass each of them.
+func (c *WebAuthClient) Authenticate(ctx context.Context, account string, signers ...intent.Signer) (string, error) {
+	if len(signers) == 0 {
+		return "", errors.WrapValidationError("at least one signer is required")
+	}
+	endpoint, signingKey, err := c.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return "", errors.WrapValidationError(fmt.Sprintf("invalid web auth endpoint %q", endpoint))
+	}
+
+	q := u.Query()
+	q.Set("account", account)
+	q.Set("home_domain", c.HomeDomain)
+	u.RawQuery = q.Encode()
+	body, err := get(ctx, c.HTTPClient, u.String())
+	if err != nil {
+		return "", err
+	}
+	var challenge challengeResponse
+	if err := json.Unmarshal(body, &challenge); err != nil {
+		return "", errors.WrapUnmarshalFailed(err, string(body))
+	}
+	if challenge.Error != "" {
+		return "", errors.WrapUnauthorized(challenge.Error)
+	}
+	if challenge.NetworkPassphrase != "" && challenge.NetworkPassphrase != c.NetworkPassphrase {
+		return "", errors.WrapValidationError(fmt.Sprintf("challenge is for network %q, not %q", challenge.NetworkPassphrase, c.NetworkPassphrase))
+	}
+
+	tx, client, _, _, err := txnbuild.ReadChallengeTx(challenge.Transaction, signingKey, c.NetworkPassphrase, u.Host, []string{c.HomeDomain})
+	if err != nil {
+		return "", errors.WrapValidationError(fmt.Sprintf("invalid challenge: %v", err))
+	}
+	if client != account {
+		return "", errors.WrapValidationError(fmt.Sprintf("challenge is for %s, not %s", client, account))
+	}
+
+	if tx, err = intent.SignTransaction(ctx, tx, c.NetworkPassphrase, signers...); err != nil {
+		return "", err
+	}
+	signed, err := tx.Base64()
+	if err != nil {
+		return "", errors.WrapMarshalFailed(err)
+	}
+
+	payload, err := json.Marshal(map[string]string{"transaction": signed})
+	if err != nil {
+		return "", errors.WrapMarshalFailed(err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", errors.WrapRPCConnectionFailed(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	body, err = do(c.HTTPClient, req)
+	var token tokenResponse
+	if jsonErr := json.Unmarshal(body, &token); jsonErr == nil && token.Error != "" {
+		return "", errors.WrapUnauthorized(token.Error)
+	}
+	if err != nil {
+		return "", err
+	}
+	if token.Token == "" {
+		return "", errors.WrapUnmarshalFailed(fmt.Errorf("no token in response"), string(body))
+	}
+
+	logger.Logger.Debug("SEP-10 authentication succeeded", "home_domain", c.HomeDomain, "account", account)
+	return token.Token, nil
+}
+
+func (c *WebAuthClient) discover(ctx context.Context) (string, string, error) {
+	if c.HomeDomain == "" {
+		return "", "", errors.WrapValidationError("home domain is required")
+	}
+	if c.Endpoint != "" && c.SigningKey != "" {
+		return c.Endpoint, c.SigningKey, nil
+	}
+	t, err := FetchStellarToml(ctx, c.HTTPClient, c.HomeDomain, c.UseHTTP)
+	if err != nil {
+		return "", "", err
+	}
+	if t.WebAuthEndpoint == "" || t.SigningKey == "" {
+		return "", "", errors.WrapValidationError(fmt.Sprintf("%s does not publish WEB_AUTH_ENDPOINT and SIGNING_KEY", c.HomeDomain))
+	}
+	if t.NetworkPassphrase != "" && t.NetworkPassphrase != c.NetworkPassphrase {
+		return "", "", errors.WrapValidationError(fmt.Sprintf("%s serves network %q, not %q", c.HomeDomain, t.NetworkPassphrase, c.NetworkPassphrase))
+	}
+	return t.WebAuthEndpoint, t.SigningKey, nil
+}
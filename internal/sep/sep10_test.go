@@ -0,0 +1,141 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package sep
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/intent"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/network"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testAnchor serves stellar.toml and a SEP-10 endpoint signing challenges
+// with challengeKey while advertising advertisedKey.
+func testAnchor(t *testing.T, challengeKey *keypair.Full, advertisedKey string) (*httptest.Server, string) {
+	t.Helper()
+	var srv *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/stellar.toml", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "NETWORK_PASSPHRASE = %q\nWEB_AUTH_ENDPOINT = %q\nSIGNING_KEY = %q\n\n[[CURRENCIES]]\ncode = \"USDC\"\n",
+			network.TestNetworkPassphrase, srv.URL+"/auth", advertisedKey)
+	})
+	mux.HandleFunc("/auth", func(w http.ResponseWriter, r *http.Request) {
+		host := strings.TrimPrefix(srv.URL, "http://")
+		if r.Method == http.MethodGet {
+			tx, err := txnbuild.BuildChallengeTx(challengeKey.Seed(), r.URL.Query().Get("account"), host, host, network.TestNetworkPassphrase, 5*time.Minute, nil)
+			require.NoError(t, err)
+			env, err := tx.Base64()
+			require.NoError(t, err)
+			_ = json.NewEncoder(w).Encode(map[string]string{"transaction": env, "network_passphrase": network.TestNetworkPassphrase})
+			return
+		}
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		_, client, _, _, err := txnbuild.ReadChallengeTx(body["transaction"], challengeKey.Address(), network.TestNetworkPassphrase, host, []string{host})
+		require.NoError(t, err)
+		if _, err := txnbuild.VerifyChallengeTxSigners(body["transaction"], challengeKey.Address(), network.TestNetworkPassphrase, host, []string{host}, client); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "challenge not signed by client"})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": "jwt-for-" + client})
+	})
+	srv = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, strings.TrimPrefix(srv.URL, "http://")
+}
+
+func TestWebAuthClient_Authenticate(t *testing.T) {
+	server, user := keypair.MustRandom(), keypair.MustRandom()
+	_, domain := testAnchor(t, server, server.Address())
+
+	c := NewWebAuthClient(domain, network.TestNetworkPassphrase)
+	c.UseHTTP = true
+	token, err := c.Authenticate(context.Background(), user.Address(), intent.LocalSignerFromKeypair(user))
+	require.NoError(t, err)
+	assert.Equal(t, "jwt-for-"+user.Address(), token)
+}
+
+func TestWebAuthClient_RejectsBadChallenges(t *testing.T) {
+	server, user := keypair.MustRandom(), keypair.MustRandom()
+	ctx := context.Background()
+
+	t.Run("wrong server key", func(t *testing.T) {
+		_, domain := testAnchor(t, keypair.MustRandom(), server.Address())
+		c := NewWebAuthClient(domain, network.TestNetworkPassphrase)
+		c.UseHTTP = true
+		_, err := c.Authenticate(ctx, user.Address(), intent.LocalSignerFromKeypair(user))
+		assert.ErrorIs(t, err, errs.ErrValidationFailed)
+	})
+
+	t.Run("wrong network", func(t *testing.T) {
+		_, domain := testAnchor(t, server, server.Address())
+		c := NewWebAuthClient(domain, network.PublicNetworkPassphrase)
+		c.UseHTTP = true
+		_, err := c.Authenticate(ctx, user.Address(), intent.LocalSignerFromKeypair(user))
+		assert.ErrorIs(t, err, errs.ErrValidationFailed)
+	})
+
+	t.Run("wrong home domain", func(t *testing.T) {
+		srv, _ := testAnchor(t, server, server.Address())
+		c := NewWebAuthClient("example.com", network.TestNetworkPassphrase)
+		c.Endpoint, c.SigningKey = srv.URL+"/auth", server.Address()
+		_, err := c.Authenticate(ctx, user.Address(), intent.LocalSignerFromKeypair(user))
+		assert.ErrorIs(t, err, errs.ErrValidationFailed)
+	})
+
+	t.Run("signature rejected by anchor", func(t *testing.T) {
+		_, domain := testAnchor(t, server, server.Address())
+		c := NewWebAuthClient(domain, network.TestNetworkPassphrase)
+		c.UseHTTP = true
+		_, err := c.Authenticate(ctx, user.Address(), intent.LocalSignerFromKeypair(keypair.MustRandom()))
+		assert.ErrorIs(t, err, errs.ErrUnauthorized)
+	})
+}
+
+func TestParseStellarToml(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    StellarToml
+	}{
+		{
+			name:    "quoting and tables",
+			content: "# comment\nSIGNING_KEY=\"GABC\"\nWEB_AUTH_ENDPOINT = 'https://a.example/auth'\n[DOCUMENTATION]\nSIGNING_KEY = \"ignored\"\n",
+			want:    StellarToml{SigningKey: "GABC", WebAuthEndpoint: "https://a.example/auth"},
+		},
+		{
+			name:    "inline comment",
+			content: "SIGNING_KEY = \"GABC\" # prod\nNETWORK_PASSPHRASE = \"Test SDF Network ; September 2015\"  # testnet\n",
+			want:    StellarToml{SigningKey: "GABC", NetworkPassphrase: "Test SDF Network ; September 2015"},
+		},
+		{
+			name:    "escapes and multi-line strings",
+			content: "NETWORK_PASSPHRASE = \"say \\\"hi\\\"\"\nWEB_AUTH_ENDPOINT = \"\"\"\nhttps://a.example/auth\"\"\"\n",
+			want:    StellarToml{NetworkPassphrase: `say "hi"`, WebAuthEndpoint: "https://a.example/auth"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseStellarToml(tt.content)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, *got)
+		})
+	}
+
+	_, err := parseStellarToml("SIGNING_KEY = \"unterminated\n")
+	assert.ErrorIs(t, err, errs.ErrUnmarshalFailed)
+}
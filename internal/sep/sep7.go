@@ -0,0 +1,300 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package sep
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/intent"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/strkey"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stellar/go-stellar-sdk/xdr"
+)
+
+// URIScheme prefixes every SEP-7 request.
+const URIScheme = "web+stellar:"
+
+// maxURIMessage is the longest msg parameter SEP-7 allows.
+const maxURIMessage = 300
+
+// uriSignaturePrefix is prepended to the request before signing: 35 zero
+// bytes, then 4, then the scheme name.
+var uriSignaturePrefix = append(append(make([]byte, 35), 4), []byte("stellar.sep.7 - URI Scheme")...)
+
+// PayRequest asks a wallet to pay Destination.
+type PayRequest struct {
+	Destination string
+	// Amount may be left empty for the user to fill in.
+	Amount string
+	// AssetCode and AssetIssuer are empty for XLM.
+	AssetCode   string
+	AssetIssuer string
+	Memo        string
+	// MemoType is MEMO_TEXT, MEMO_ID, MEMO_HASH or MEMO_RETURN.
+	MemoType string
+}
+
+// TxRequest asks a wallet to sign a transaction envelope.
+type TxRequest struct {
+	XDR string
+	// Replace lists fields the wallet should fill in, in the SEP-11 txrep
+	// path format of SEP-7.
+	Replace string
+	// PubKey is the key the requester expects to sign.
+	PubKey string
+	Chain  string
+}
+
+// URIRequest is a parsed or to-be-encoded web+stellar: URI. Exactly one of
+// Pay and Tx is set.
+type URIRequest struct {
+	Pay *PayRequest
+	Tx  *TxRequest
+
+	// Callback is where the wallet posts the signed envelope instead of
+	// submitting it.
+	Callback          string
+	Message           string
+	NetworkPassphrase string
+	// OriginDomain is the requester's domain. Its stellar.toml publishes the
+	// URI_REQUEST_SIGNING_KEY that Signature must verify against.
+	OriginDomain string
+	Signature    string
+
+	// unsigned is the request exactly as received, without the signature.
+	unsigned string
+}
+
+// NewTxURIRequest returns a request for a wallet to sign tx.
+func NewTxURIRequest(tx *txnbuild.Transaction, networkPassphrase string) (*URIRequest, error) {
+	env, err := tx.Base64()
+	if err != nil {
+		return nil, errors.WrapMarshalFailed(err)
+	}
+	return &URIRequest{Tx: &TxRequest{XDR: env}, NetworkPassphrase: networkPassphrase}, nil
+}
+
+// Encode validates r and returns it as a URI, with the signature if r has
+// one.
+func (r *URIRequest) Encode() (string, error) {
+	uri, err := r.encodeUnsigned()
+	if err != nil {
+		return "", err
+	}
+	if r.Signature != "" {
+		uri += "&signature=" + url.QueryEscape(r.Signature)
+	}
+	return uri, nil
+}
+
+func (r *URIRequest) encodeUnsigned() (string, error) {
+	if err := r.validate(); err != nil {
+		return "", err
+	}
+	var op string
+	var params []string
+	add := func(key, value string) {
+		if value != "" {
+			params = append(params, key+"="+url.QueryEscape(value))
+		}
+	}
+	if r.Pay != nil {
+		op = "pay"
+		add("destination", r.Pay.Destination)
+		add("amount", r.Pay.Amount)
+		add("asset_code", r.Pay.AssetCode)
+		add("asset_issuer", r.Pay.AssetIssuer)
+		add("memo", r.Pay.Memo)
+		add("memo_type", r.Pay.MemoType)
+	} else {
+		op = "tx"
+		add("xdr", r.Tx.XDR)
+		add("replace", r.Tx.Replace)
+		add("pubkey", r.Tx.PubKey)
+		add("chain", r.Tx.Chain)
+	}
+	if r.Callback != "" {
+		add("callback", "url:"+r.Callback)
+	}
+	add("msg", r.Message)
+	add("network_passphrase", r.NetworkPassphrase)
+	add("origin_domain", r.OriginDomain)
+	return URIScheme + op + "?" + strings.Join(params, "&"), nil
+}
+
+// Sign signs r on behalf of OriginDomain and returns the signed URI. signer
+// must hold the domain's URI_REQUEST_SIGNING_KEY.
+func (r *URIRequest) Sign(ctx context.Context, signer intent.Signer) (string, error) {
+	if r.OriginDomain == "" {
+		return "", errors.WrapValidationError("origin domain is required to sign a URI request")
+	}
+	unsigned, err := r.encodeUnsigned()
+	if err != nil {
+		return "", err
+	}
+	sig, err := signer.Sign(ctx, append(append([]byte{}, uriSignaturePrefix...), unsigned...))
+	if err != nil {
+		return "", errors.WrapUnauthorized(fmt.Sprintf("signer %s: %v", signer.PublicKey(), err))
+	}
+	r.Signature = base64.StdEncoding.EncodeToString(sig.Signature)
+	r.unsigned = unsigned
+	return unsigned + "&signature=" + url.QueryEscape(r.Signature), nil
+}
+
+// ParseURI decodes and validates a web+stellar: URI. It does not check the
+// signature; see Verify and VerifyOrigin.
+func ParseURI(uri string) (*URIRequest, error) {
+	if !strings.HasPrefix(uri, URIScheme) {
+		return nil, errors.WrapValidationError("not a " + URIScheme + " URI")
+	}
+	op, query, _ := strings.Cut(strings.TrimPrefix(uri, URIScheme), "?")
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, errors.WrapValidationError(fmt.Sprintf("invalid URI query: %v", err))
+	}
+
+	r := &URIRequest{
+		Message:           values.Get("msg"),
+		NetworkPassphrase: values.Get("network_passphrase"),
+		OriginDomain:      values.Get("origin_domain"),
+		Signature:         values.Get("signature"),
+		unsigned:          uri,
+	}
+	if i := strings.Index(uri, "&signature="); i >= 0 {
+		r.unsigned = uri[:i]
+	}
+	if cb := values.Get("callback"); cb != "" {
+		if !strings.HasPrefix(cb, "url:") {
+			return nil, errors.WrapValidationError(fmt.Sprintf("unsupported callback %q", cb))
+		}
+		r.Callback = strings.TrimPrefix(cb, "url:")
+	}
+
+	switch op {
+	case "pay":
+		r.Pay = &PayRequest{
+			Destination: values.Get("destination"),
+			Amount:      values.Get("amount"),
+			AssetCode:   values.Get("asset_code"),
+			AssetIssuer: values.Get("asset_issuer"),
+			Memo:        values.Get("memo"),
+			MemoType:    values.Get("memo_type"),
+		}
+	case "tx":
+		r.Tx = &TxRequest{
+			XDR:     values.Get("xdr"),
+			Replace: values.Get("replace"),
+			PubKey:  values.Get("pubkey"),
+			Chain:   values.Get("chain"),
+		}
+	default:
+		return nil, errors.WrapValidationError(fmt.Sprintf("unsupported URI operation %q", op))
+	}
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	if r.OriginDomain != "" && r.Signature == "" {
+		return nil, errors.WrapValidationError("origin_domain requires a signature")
+	}
+	return r, nil
+}
+
+// Verify checks Signature against signingKey, a G... address.
+func (r *URIRequest) Verify(signingKey string) error {
+	if r.Signature == "" {
+		return errors.WrapValidationError("URI request is not signed")
+	}
+	kp, err := keypair.ParseAddress(signingKey)
+	if err != nil {
+		return errors.WrapValidationError(fmt.Sprintf("invalid signing key %q", signingKey))
+	}
+	sig, err := base64.StdEncoding.DecodeString(r.Signature)
+	if err != nil {
+		return errors.WrapValidationError("URI signature is not valid base64")
+	}
+	unsigned := r.unsigned
+	if unsigned == "" {
+		if unsigned, err = r.encodeUnsigned(); err != nil {
+			return err
+		}
+	}
+	if err := kp.Verify(append(append([]byte{}, uriSignaturePrefix...), unsigned...), sig); err != nil {
+		return errors.WrapUnauthorized(fmt.Sprintf("URI request is not signed by %s", signingKey))
+	}
+	return nil
+}
+
+// VerifyOrigin checks Signature against the URI_REQUEST_SIGNING_KEY in the
+// stellar.toml of OriginDomain.
+func (r *URIRequest) VerifyOrigin(ctx context.Context, httpClient *http.Client, useHTTP bool) error {
+	if r.OriginDomain == "" {
+		return errors.WrapValidationError("URI request has no origin domain")
+	}
+	t, err := FetchStellarToml(ctx, httpClient, r.OriginDomain, useHTTP)
+	if err != nil {
+		return err
+	}
+	if t.URIRequestSigningKey == "" {
+		return errors.WrapValidationError(fmt.Sprintf("%s does not publish URI_REQUEST_SIGNING_KEY", r.OriginDomain))
+	}
+	return r.Verify(t.URIRequestSigningKey)
+}
+
+func (r *URIRequest) validate() error {
+	if (r.Pay == nil) == (r.Tx == nil) {
+		return errors.WrapValidationError("exactly one of pay and tx must be set")
+	}
+	if len(r.Message) > maxURIMessage {
+		return errors.WrapValidationError(fmt.Sprintf("msg is longer than %d characters", maxURIMessage))
+	}
+	if r.Callback != "" {
+		if u, err := url.Parse(r.Callback); err != nil || u.Scheme == "" || u.Host == "" {
+			return errors.WrapValidationError(fmt.Sprintf("invalid callback URL %q", r.Callback))
+		}
+	}
+	if r.Tx != nil {
+		if r.Tx.XDR == "" {
+			return errors.WrapValidationError("xdr is required")
+		}
+		var env xdr.TransactionEnvelope
+		if err := xdr.SafeUnmarshalBase64(r.Tx.XDR, &env); err != nil {
+			return errors.WrapUnmarshalFailed(err, r.Tx.XDR)
+		}
+		if r.Tx.PubKey != "" && !strkey.IsValidEd25519PublicKey(r.Tx.PubKey) {
+			return errors.WrapValidationError(fmt.Sprintf("invalid pubkey %q", r.Tx.PubKey))
+		}
+		return nil
+	}
+
+	p := r.Pay
+	if !strkey.IsValidEd25519PublicKey(p.Destination) && !strkey.IsValidMuxedAccountEd25519PublicKey(p.Destination) && !strkey.IsValidContractAddress(p.Destination) {
+		return errors.WrapValidationError(fmt.Sprintf("invalid destination %q", p.Destination))
+	}
+	if p.AssetCode != "" && p.AssetIssuer == "" {
+		return errors.WrapValidationError("asset_issuer is required for non-native assets")
+	}
+	if p.AssetIssuer != "" && !strkey.IsValidEd25519PublicKey(p.AssetIssuer) {
+		return errors.WrapValidationError(fmt.Sprintf("invalid asset_issuer %q", p.AssetIssuer))
+	}
+	switch p.MemoType {
+	case "":
+		if p.Memo != "" {
+			return errors.WrapValidationError("memo_type is required with memo")
+		}
+	case "MEMO_TEXT", "MEMO_ID", "MEMO_HASH", "MEMO_RETURN":
+		if _, err := intent.ParseMemo(p.MemoType, p.Memo); err != nil {
+			return err
+		}
+	default:
+		return errors.WrapValidationError(fmt.Sprintf("invalid memo_type %q", p.MemoType))
+	}
+	return nil
+}
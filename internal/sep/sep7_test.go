@@ -0,0 +1,110 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package sep
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	errs "github.com/dotandev/hintents/internal/errors"
+	"github.com/dotandev/hintents/internal/intent"
+	"github.com/stellar/go-stellar-sdk/keypair"
+	"github.com/stellar/go-stellar-sdk/network"
+	"github.com/stellar/go-stellar-sdk/txnbuild"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayURI_RoundTrip(t *testing.T) {
+	dest, issuer := keypair.MustRandom().Address(), keypair.MustRandom().Address()
+	r := &URIRequest{
+		Pay:      &PayRequest{Destination: dest, Amount: "120.5", AssetCode: "USDC", AssetIssuer: issuer, Memo: "order 7", MemoType: "MEMO_TEXT"},
+		Callback: "https://example.com/cb?x=1",
+		Message:  "pay for order 7",
+	}
+	uri, err := r.Encode()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(uri, "web+stellar:pay?destination="+dest+"&amount=120.5"))
+	assert.Contains(t, uri, "callback=url%3Ahttps%3A%2F%2Fexample.com%2Fcb%3Fx%3D1")
+
+	parsed, err := ParseURI(uri)
+	require.NoError(t, err)
+	assert.Equal(t, r.Pay, parsed.Pay)
+	assert.Equal(t, r.Callback, parsed.Callback)
+	assert.Equal(t, r.Message, parsed.Message)
+	assert.Nil(t, parsed.Tx)
+}
+
+func TestTxURI_SignAndVerify(t *testing.T) {
+	source, origin := keypair.MustRandom(), keypair.MustRandom()
+	tx, err := txnbuild.NewTransaction(txnbuild.TransactionParams{
+		SourceAccount:        &txnbuild.SimpleAccount{AccountID: source.Address(), Sequence: 1},
+		IncrementSequenceNum: true,
+		Operations:           []txnbuild.Operation{&txnbuild.BumpSequence{BumpTo: 10}},
+		BaseFee:              txnbuild.MinBaseFee,
+		Preconditions:        txnbuild.Preconditions{TimeBounds: txnbuild.NewInfiniteTimeout()},
+	})
+	require.NoError(t, err)
+
+	r, err := NewTxURIRequest(tx, network.TestNetworkPassphrase)
+	require.NoError(t, err)
+	r.OriginDomain = "example.com"
+	uri, err := r.Sign(context.Background(), intent.LocalSignerFromKeypair(origin))
+	require.NoError(t, err)
+
+	parsed, err := ParseURI(uri)
+	require.NoError(t, err)
+	require.NotNil(t, parsed.Tx)
+	assert.Equal(t, r.Tx.XDR, parsed.Tx.XDR)
+	assert.Equal(t, network.TestNetworkPassphrase, parsed.NetworkPassphrase)
+	assert.NoError(t, parsed.Verify(origin.Address()))
+	assert.ErrorIs(t, parsed.Verify(keypair.MustRandom().Address()), errs.ErrUnauthorized)
+
+	tampered, err := ParseURI(strings.Replace(uri, "origin_domain=example.com", "origin_domain=evil.example", 1))
+	require.NoError(t, err)
+	assert.ErrorIs(t, tampered.Verify(origin.Address()), errs.ErrUnauthorized)
+}
+
+func TestURIRequest_VerifyOrigin(t *testing.T) {
+	origin := keypair.MustRandom()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "URI_REQUEST_SIGNING_KEY = %q\n", origin.Address())
+	}))
+	defer srv.Close()
+
+	r := &URIRequest{
+		Pay:          &PayRequest{Destination: keypair.MustRandom().Address()},
+		OriginDomain: strings.TrimPrefix(srv.URL, "http://"),
+	}
+	uri, err := r.Sign(context.Background(), intent.LocalSignerFromKeypair(origin))
+	require.NoError(t, err)
+	parsed, err := ParseURI(uri)
+	require.NoError(t, err)
+	assert.NoError(t, parsed.VerifyOrigin(context.Background(), srv.Client(), true))
+}
+
+func TestParseURI_Invalid(t *testing.T) {
+	dest := keypair.MustRandom().Address()
+	for name, uri := range map[string]string{
+		"scheme":           "stellar:pay?destination=" + dest,
+		"operation":        "web+stellar:send?destination=" + dest,
+		"destination":      "web+stellar:pay?destination=GBAD",
+		"issuer missing":   "web+stellar:pay?destination=" + dest + "&asset_code=USDC",
+		"memo type":        "web+stellar:pay?destination=" + dest + "&memo=1&memo_type=MEMO_NUMBER",
+		"memo value":       "web+stellar:pay?destination=" + dest + "&memo=abc&memo_type=MEMO_ID",
+		"callback":         "web+stellar:pay?destination=" + dest + "&callback=https%3A%2F%2Fexample.com",
+		"xdr":              "web+stellar:tx?xdr=notxdr",
+		"unsigned origin":  "web+stellar:pay?destination=" + dest + "&origin_domain=example.com",
+		"message too long": "web+stellar:pay?destination=" + dest + "&msg=" + strings.Repeat("a", 301),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseURI(uri)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sep implements the client side of Stellar ecosystem proposals that
+// wallets and anchors rely on: SEP-10 web authentication and SEP-7 signing
+// request URIs.
+package sep
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/dotandev/hintents/internal/errors"
+)
+
+// maxResponseSize caps how much of a response body, such as a stellar.toml
+// file, is read.
+const maxResponseSize = 100 * 1024
+
+// StellarToml holds the fields of a domain's stellar.toml that this package
+// uses.
+type StellarToml struct {
+	NetworkPassphrase    string `toml:"NETWORK_PASSPHRASE"`
+	WebAuthEndpoint      string `toml:"WEB_AUTH_ENDPOINT"`
+	SigningKey           string `toml:"SIGNING_KEY"`
+	URIRequestSigningKey string `toml:"URI_REQUEST_SIGNING_KEY"`
+}
+
+// FetchStellarToml downloads and parses https://domain/.well-known/stellar.toml.
+// Only the top-level keys above are read; other keys and tables such as
+// [[CURRENCIES]] are ignored.
+func FetchStellarToml(ctx context.Context, httpClient *http.Client, domain string, useHTTP bool) (*StellarToml, error) {
+	scheme := "https"
+	if useHTTP {
+		scheme = "http"
+	}
+	url := fmt.Sprintf("%s://%s/.well-known/stellar.toml", scheme, domain)
+	body, err := get(ctx, httpClient, url)
+	if err != nil {
+		return nil, err
+	}
+	return parseStellarToml(string(body))
+}
+
+func parseStellarToml(content string) (*StellarToml, error) {
+	t := &StellarToml{}
+	if _, err := toml.Decode(content, t); err != nil {
+		return nil, errors.WrapUnmarshalFailed(err, "stellar.toml")
+	}
+	return t, nil
+}
+
+func get(ctx context.Context, httpClient *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.WrapRPCConnectionFailed(err)
+	}
+	return do(httpClient, req)
+}
+
+func do(httpClient *http.Client, req *http.Request) ([]byte, error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, errors.WrapRPCConnectionFailed(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, errors.WrapUnmarshalFailed(err, "body read error")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return body, errors.WrapRPCError(req.URL.String(), strings.TrimSpace(string(body)), resp.StatusCode)
+	}
+	return body, nil
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+// Package timing records where the time of a simulate or replay run goes.
+// A Profiler is carried in the run's context; the RPC client and the debug
+// command add the time they spend on the network, decoding XDR, consulting
+// caches and executing locally, and Report summarises it. Without a
+// Profiler in the context nothing is recorded.
+package timing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Phase names a kind of work a run spends time on.
+type Phase string
+
+const (
+	PhaseNetwork   Phase = "network"
+	PhaseDecode    Phase = "xdr_decode"
+	PhaseCache     Phase = "cache"
+	PhaseExecution Phase = "execution"
+)
+
+// phaseOrder lists the built-in phases in report order; other phases follow
+// alphabetically.
+var phaseOrder = map[Phase]int{PhaseNetwork: 0, PhaseDecode: 1, PhaseCache: 2, PhaseExecution: 3}
+
+// Profiler accumulates time per phase. It is safe for concurrent use, and a
+// nil *Profiler records nothing.
+type Profiler struct {
+	mu     sync.Mutex
+	now    func() time.Time
+	start  time.Time
+	phases map[Phase]*PhaseStats
+}
+
+// New returns a Profiler whose wall clock starts now.
+func New() *Profiler {
+	return &Profiler{now: time.Now, start: time.Now(), phases: make(map[Phase]*PhaseStats)}
+}
+
+// Observe adds one span of d to phase.
+func (p *Profiler) Observe(phase Phase, d time.Duration) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s, ok := p.phases[phase]
+	if !ok {
+		s = &PhaseStats{Phase: phase, Min: d}
+		p.phases[phase] = s
+	}
+	s.Count++
+	s.Total += d
+	if d < s.Min {
+		s.Min = d
+	}
+	if d > s.Max {
+		s.Max = d
+	}
+}
+
+// Start begins timing phase and returns the function that ends it:
+//
+//	defer timing.FromContext(ctx).Start(timing.PhaseDecode)()
+func (p *Profiler) Start(phase Phase) func() {
+	if p == nil {
+		return func() {}
+	}
+	start := p.now()
+	return func() { p.Observe(phase, p.now().Sub(start)) }
+}
+
+// PhaseStats is the time recorded for one phase.
+type PhaseStats struct {
+	Phase Phase         `json:"phase"`
+	Count int           `json:"count"`
+	Total time.Duration `json:"total_ns"`
+	Min   time.Duration `json:"min_ns"`
+	Max   time.Duration `json:"max_ns"`
+}
+
+// Mean is the average span of the phase.
+func (s PhaseStats) Mean() time.Duration {
+	if s.Count == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Count)
+}
+
+// Report is a snapshot of a Profiler. Phases can overlap when work runs
+// concurrently, as with --compare-network, so their totals may add up to
+// more than Wall.
+type Report struct {
+	Wall   time.Duration `json:"wall_ns"`
+	Phases []PhaseStats  `json:"phases"`
+}
+
+// Report returns the time recorded so far.
+func (p *Profiler) Report() Report {
+	if p == nil {
+		return Report{}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	r := Report{Wall: p.now().Sub(p.start)}
+	for _, s := range p.phases {
+		r.Phases = append(r.Phases, *s)
+	}
+	sort.Slice(r.Phases, func(i, j int) bool {
+		oi, iBuiltin := phaseOrder[r.Phases[i].Phase]
+		oj, jBuiltin := phaseOrder[r.Phases[j].Phase]
+		switch {
+		case iBuiltin && jBuiltin:
+			return oi < oj
+		case iBuiltin != jBuiltin:
+			return iBuiltin
+		default:
+			return r.Phases[i].Phase < r.Phases[j].Phase
+		}
+	})
+	return r
+}
+
+// Unaccounted is the wall time not covered by any phase, or zero when
+// phases overlapped by more than that.
+func (r Report) Unaccounted() time.Duration {
+	rest := r.Wall
+	for _, s := range r.Phases {
+		rest -= s.Total
+	}
+	if rest < 0 {
+		return 0
+	}
+	return rest
+}
+
+// WriteText writes r as a table with each phase's share of the wall time.
+func (r Report) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Timing profile (wall %s)\n", round(r.Wall))
+	fmt.Fprintln(tw, "PHASE\tCOUNT\tTOTAL\tSHARE\tMEAN\tMAX")
+	for _, s := range r.Phases {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\n", s.Phase, s.Count, round(s.Total), share(s.Total, r.Wall), round(s.Mean()), round(s.Max))
+	}
+	rest := r.Unaccounted()
+	fmt.Fprintf(tw, "other\t-\t%s\t%s\t-\t-\n", round(rest), share(rest, r.Wall))
+	return tw.Flush()
+}
+
+func round(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond)
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond)
+	default:
+		return d.Round(time.Microsecond)
+	}
+}
+
+func share(d, wall time.Duration) string {
+	if wall <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", 100*float64(d)/float64(wall))
+}
+
+type profilerKey struct{}
+
+// WithProfiler returns a copy of ctx carrying p.
+func WithProfiler(ctx context.Context, p *Profiler) context.Context {
+	return context.WithValue(ctx, profilerKey{}, p)
+}
+
+// FromContext returns the Profiler in ctx, or nil when there is none.
+func FromContext(ctx context.Context) *Profiler {
+	if ctx == nil {
+		return nil
+	}
+	p, _ := ctx.Value(profilerKey{}).(*Profiler)
+	return p
+}
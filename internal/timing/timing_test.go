@@ -0,0 +1,77 @@
+// Copyright 2025 Erst Users
+// SPDX-License-Identifier: Apache-2.0
+
+package timing
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fakeClock(p *Profiler) *time.Time {
+	now := p.start
+	p.now = func() time.Time { return now }
+	return &now
+}
+
+func TestProfiler_Report(t *testing.T) {
+	p := New()
+	now := fakeClock(p)
+
+	stop := p.Start(PhaseExecution)
+	*now = now.Add(300 * time.Millisecond)
+	stop()
+	p.Observe(PhaseNetwork, 100*time.Millisecond)
+	p.Observe(PhaseNetwork, 50*time.Millisecond)
+	p.Observe("bytecode_fetch", 10*time.Millisecond)
+	p.Observe(PhaseDecode, 5*time.Millisecond)
+	*now = now.Add(200 * time.Millisecond)
+
+	r := p.Report()
+	assert.Equal(t, 500*time.Millisecond, r.Wall)
+	require.Len(t, r.Phases, 4)
+	assert.Equal(t, []Phase{PhaseNetwork, PhaseDecode, PhaseExecution, "bytecode_fetch"},
+		[]Phase{r.Phases[0].Phase, r.Phases[1].Phase, r.Phases[2].Phase, r.Phases[3].Phase})
+
+	network := r.Phases[0]
+	assert.Equal(t, 2, network.Count)
+	assert.Equal(t, 150*time.Millisecond, network.Total)
+	assert.Equal(t, 50*time.Millisecond, network.Min)
+	assert.Equal(t, 100*time.Millisecond, network.Max)
+	assert.Equal(t, 75*time.Millisecond, network.Mean())
+	assert.Equal(t, 35*time.Millisecond, r.Unaccounted())
+
+	var buf bytes.Buffer
+	require.NoError(t, r.WriteText(&buf))
+	out := buf.String()
+	assert.Contains(t, out, "wall 500ms")
+	assert.Contains(t, out, "network")
+	assert.Contains(t, out, "60.0%")
+	assert.Contains(t, out, "other")
+}
+
+func TestReport_OverlappingPhases(t *testing.T) {
+	r := Report{Wall: time.Second, Phases: []PhaseStats{
+		{Phase: PhaseExecution, Count: 2, Total: 1500 * time.Millisecond},
+	}}
+	assert.Zero(t, r.Unaccounted())
+}
+
+func TestProfiler_NilAndContext(t *testing.T) {
+	var p *Profiler
+	assert.NotPanics(t, func() {
+		p.Observe(PhaseCache, time.Millisecond)
+		p.Start(PhaseCache)()
+		assert.Empty(t, p.Report().Phases)
+	})
+
+	assert.Nil(t, FromContext(context.Background()))
+	real := New()
+	ctx := WithProfiler(context.Background(), real)
+	assert.Same(t, real, FromContext(ctx))
+}
@@ -17,7 +17,7 @@ import (
 const defaultLimit = 10
 
 type Wizard struct {
-	client   *rpc.Client
+	client   rpc.HorizonClient
 	renderer terminal.Renderer
 }
 
@@ -27,7 +27,7 @@ type SelectionResult struct {
 	CreatedAt string
 }
 
-func New(client *rpc.Client) *Wizard {
+func New(client rpc.HorizonClient) *Wizard {
 	return &Wizard{
 		client:   client,
 		renderer: terminal.NewANSIRenderer(),